@@ -0,0 +1,292 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package api serves the public gRPC API of a node, and the REST gateway mapped to it
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/actpool"
+	iotexapi "github.com/iotexproject/iotex-core/api/proto"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/dispatcher"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/network"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	pb "github.com/iotexproject/iotex-core/proto"
+)
+
+// Server is the gRPC API server, which optionally serves the REST gateway too
+type Server struct {
+	cfg        config.API
+	bc         blockchain.Blockchain
+	dp         dispatcher.Dispatcher
+	ap         actpool.ActPool
+	p2p        network.Overlay
+	grpcServer *grpc.Server
+	gateway    *http.Server
+	port       int
+	cancel     context.CancelFunc
+}
+
+// NewServer creates an API server
+func NewServer(
+	cfg config.API,
+	chain blockchain.Blockchain,
+	dispatcher dispatcher.Dispatcher,
+	actPool actpool.ActPool,
+	p2p network.Overlay,
+) *Server {
+	s := &Server{
+		cfg: cfg,
+		bc:  chain,
+		dp:  dispatcher,
+		ap:  actPool,
+		p2p: p2p,
+	}
+	s.grpcServer = grpc.NewServer()
+	iotexapi.RegisterAPIServiceServer(s.grpcServer, s)
+	reflection.Register(s.grpcServer)
+	return s
+}
+
+// Start starts serving the gRPC API, and the REST gateway if its port is set
+func (s *Server) Start(_ context.Context) error {
+	lis, err := net.Listen("tcp", ":"+strconv.Itoa(s.cfg.Port))
+	if err != nil {
+		return errors.Wrap(err, "error when creating the API listener")
+	}
+	s.port = lis.Addr().(*net.TCPAddr).Port
+	logger.Info().Int("port", s.port).Msg("Starting API server")
+	go func() {
+		if err := s.grpcServer.Serve(lis); err != nil {
+			logger.Error().Err(err).Msg("error when serving the API")
+		}
+	}()
+	if s.cfg.GatewayPort == 0 {
+		return nil
+	}
+	return s.startGateway()
+}
+
+// Stop stops the API server and the gateway
+func (s *Server) Stop(ctx context.Context) error {
+	if s.gateway != nil {
+		if err := s.gateway.Shutdown(ctx); err != nil {
+			return errors.Wrap(err, "error when shutting down the API gateway")
+		}
+	}
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.grpcServer.Stop()
+	return nil
+}
+
+// Port returns the port that the gRPC API is actually bound to
+func (s *Server) Port() int {
+	return s.port
+}
+
+// GetAccount returns the state of an account
+func (s *Server) GetAccount(_ context.Context, in *iotexapi.GetAccountRequest) (*iotexapi.GetAccountResponse, error) {
+	state, err := s.bc.StateByAddr(in.Address)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	pendingNonce, err := s.ap.GetPendingNonce(in.Address)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &iotexapi.GetAccountResponse{
+		AccountMeta: &iotexapi.AccountMeta{
+			Address:      in.Address,
+			Balance:      state.Balance.String(),
+			Nonce:        state.Nonce,
+			PendingNonce: pendingNonce,
+			IsCandidate:  state.IsCandidate,
+		},
+	}, nil
+}
+
+// GetBlock returns the block of the hash if it is set, or otherwise the block at the height
+func (s *Server) GetBlock(_ context.Context, in *iotexapi.GetBlockRequest) (*iotexapi.GetBlockResponse, error) {
+	var (
+		blk *blockchain.Block
+		err error
+	)
+	if in.Hash != "" {
+		var h hash.Hash32B
+		if h, err = toHash(in.Hash); err != nil {
+			return nil, err
+		}
+		blk, err = s.bc.GetBlockByHash(h)
+	} else {
+		blk, err = s.bc.GetBlockByHeight(in.Height)
+	}
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	h := blk.HashBlock()
+	return &iotexapi.GetBlockResponse{Block: blk.ConvertToBlockPb(), Hash: hex.EncodeToString(h[:])}, nil
+}
+
+// GetAction returns a committed transfer, vote or execution, and the hash of the block containing it
+func (s *Server) GetAction(_ context.Context, in *iotexapi.GetActionRequest) (*iotexapi.GetActionResponse, error) {
+	h, err := toHash(in.Hash)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		act     *pb.ActionPb
+		blkHash hash.Hash32B
+	)
+	if tsf, err := s.bc.GetTransferByTransferHash(h); err == nil {
+		act = tsf.ConvertToActionPb()
+		blkHash, err = s.bc.GetBlockHashByTransferHash(h)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	} else if vote, err := s.bc.GetVoteByVoteHash(h); err == nil {
+		act = vote.ConvertToActionPb()
+		blkHash, err = s.bc.GetBlockHashByVoteHash(h)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	} else if execution, err := s.bc.GetExecutionByExecutionHash(h); err == nil {
+		act = execution.ConvertToActionPb()
+		blkHash, err = s.bc.GetBlockHashByExecutionHash(h)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	} else {
+		return nil, status.Errorf(codes.NotFound, "action %s is not found", in.Hash)
+	}
+	return &iotexapi.GetActionResponse{Action: act, BlockHash: hex.EncodeToString(blkHash[:])}, nil
+}
+
+// GetReceipt returns the receipt of an execution
+func (s *Server) GetReceipt(_ context.Context, in *iotexapi.GetReceiptRequest) (*iotexapi.GetReceiptResponse, error) {
+	h, err := toHash(in.Hash)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := s.bc.GetReceiptByExecutionHash(h)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &iotexapi.GetReceiptResponse{Receipt: receipt.ConvertToReceiptPb()}, nil
+}
+
+// GetCandidates returns the candidates at the height, or at the tip height if it is 0
+func (s *Server) GetCandidates(_ context.Context, in *iotexapi.GetCandidatesRequest) (*iotexapi.GetCandidatesResponse, error) {
+	height := in.Height
+	if height == 0 {
+		height = s.bc.TipHeight()
+	}
+	candidates, err := s.bc.CandidatesByHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	res := &iotexapi.GetCandidatesResponse{Candidates: make([]*pb.Candidate, 0, len(candidates))}
+	for _, c := range candidates {
+		candidate := &pb.Candidate{
+			Address:          c.Address,
+			PubKey:           c.PubKey,
+			CreationHeight:   c.CreationHeight,
+			LastUpdateHeight: c.LastUpdateHeight,
+		}
+		if c.Votes != nil {
+			candidate.Votes = c.Votes.Bytes()
+		}
+		res.Candidates = append(res.Candidates, candidate)
+	}
+	return res, nil
+}
+
+// GetChainMeta returns the tip of the chain, the total supply and the numbers of the actions
+func (s *Server) GetChainMeta(context.Context, *iotexapi.GetChainMetaRequest) (*iotexapi.GetChainMetaResponse, error) {
+	transfers, err := s.bc.GetTotalTransfers()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	votes, err := s.bc.GetTotalVotes()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	executions, err := s.bc.GetTotalExecutions()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	tipHash := s.bc.TipHash()
+	return &iotexapi.GetChainMetaResponse{
+		ChainMeta: &iotexapi.ChainMeta{
+			Height:        s.bc.TipHeight(),
+			TipHash:       hex.EncodeToString(tipHash[:]),
+			Supply:        new(big.Int).SetUint64(blockchain.Gen.TotalSupply).String(),
+			NumTransfers:  transfers,
+			NumVotes:      votes,
+			NumExecutions: executions,
+		},
+	}, nil
+}
+
+// SendAction broadcasts a signed transfer, vote or execution to the network, and adds it to the local actpool via the
+// dispatcher
+func (s *Server) SendAction(_ context.Context, in *iotexapi.SendActionRequest) (*iotexapi.SendActionResponse, error) {
+	act := in.Action
+	var h hash.Hash32B
+	switch {
+	case act.GetTransfer() != nil:
+		if err := action.ValidateTransferPayload(act.GetTransfer().Payload, s.cfg.MaxTransferPayloadBytes); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		tsf := &action.Transfer{}
+		tsf.ConvertFromActionPb(act)
+		h = tsf.Hash()
+	case act.GetVote() != nil:
+		vote := &action.Vote{}
+		vote.ConvertFromActionPb(act)
+		h = vote.Hash()
+	case act.GetExecution() != nil:
+		execution := &action.Execution{}
+		execution.ConvertFromActionPb(act)
+		h = execution.Hash()
+	default:
+		return nil, status.Error(codes.InvalidArgument, "action should be a transfer, a vote or an execution")
+	}
+	chainID := s.bc.ChainID()
+	if err := s.p2p.Broadcast(chainID, act); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	s.dp.HandleBroadcast(chainID, act, nil)
+	return &iotexapi.SendActionResponse{Hash: hex.EncodeToString(h[:])}, nil
+}
+
+// toHash decodes a hex encoded hash
+func toHash(s string) (hash.Hash32B, error) {
+	var h hash.Hash32B
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(h) {
+		return h, status.Errorf(codes.InvalidArgument, "invalid hash %s", s)
+	}
+	copy(h[:], b)
+	return h, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	iotexapi "github.com/iotexproject/iotex-core/api/proto"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_dispatcher"
+	"github.com/iotexproject/iotex-core/test/mock/mock_network"
+)
+
+func TestServer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	ap := mock_actpool.NewMockActPool(ctrl)
+	dp := mock_dispatcher.NewMockDispatcher(ctrl)
+	p2p := mock_network.NewMockOverlay(ctrl)
+	cfg := config.Default.API
+	cfg.Port = 0
+	cfg.GatewayPort = 0
+	s := NewServer(cfg, bc, dp, ap, p2p)
+	ctx := context.Background()
+	require.NoError(s.Start(ctx))
+	defer func() {
+		require.NoError(s.Stop(ctx))
+	}()
+
+	conn, err := grpc.Dial(fmt.Sprintf("127.0.0.1:%d", s.Port()), grpc.WithInsecure())
+	require.NoError(err)
+	defer conn.Close()
+	client := iotexapi.NewAPIServiceClient(conn)
+
+	// The balance which overflows int64 is kept
+	balance, ok := new(big.Int).SetString("100000000000000000000000", 10)
+	require.True(ok)
+	bc.EXPECT().StateByAddr("io1alfa").Return(&state.State{Balance: balance, Nonce: 2}, nil)
+	ap.EXPECT().GetPendingNonce("io1alfa").Return(uint64(3), nil)
+	account, err := client.GetAccount(ctx, &iotexapi.GetAccountRequest{Address: "io1alfa"})
+	require.NoError(err)
+	require.Equal("100000000000000000000000", account.AccountMeta.Balance)
+	require.Equal(uint64(2), account.AccountMeta.Nonce)
+	require.Equal(uint64(3), account.AccountMeta.PendingNonce)
+
+	_, err = client.GetBlock(ctx, &iotexapi.GetBlockRequest{Hash: "xyz"})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	bc.EXPECT().GetBlockByHeight(uint64(5)).Return(nil, blockchain.ErrInvalidBlock)
+	_, err = client.GetBlock(ctx, &iotexapi.GetBlockRequest{Height: 5})
+	require.Equal(codes.NotFound, status.Code(err))
+
+	bc.EXPECT().TipHeight().Return(uint64(10))
+	bc.EXPECT().CandidatesByHeight(uint64(10)).Return([]*state.Candidate{
+		{Address: "io1alfa", Votes: balance, CreationHeight: 1, LastUpdateHeight: 9},
+	}, nil)
+	candidates, err := client.GetCandidates(ctx, &iotexapi.GetCandidatesRequest{})
+	require.NoError(err)
+	require.Equal(1, len(candidates.Candidates))
+	require.Equal(balance.Bytes(), candidates.Candidates[0].Votes)
+	require.Equal(uint64(9), candidates.Candidates[0].LastUpdateHeight)
+
+	_, err = client.SendAction(ctx, &iotexapi.SendActionRequest{Action: &pb.ActionPb{}})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = client.SendAction(ctx, &iotexapi.SendActionRequest{
+		Action: &pb.ActionPb{
+			Action: &pb.ActionPb_Transfer{Transfer: &pb.TransferPb{
+				Amount:    balance.Bytes(),
+				Recipient: "io1bravo",
+				Payload:   make([]byte, cfg.MaxTransferPayloadBytes+1),
+			}},
+			Nonce: 3,
+		},
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	bc.EXPECT().ChainID().Return(uint32(1))
+	p2p.EXPECT().Broadcast(uint32(1), gomock.Any()).Return(nil)
+	dp.EXPECT().HandleBroadcast(uint32(1), gomock.Any(), gomock.Any())
+	res, err := client.SendAction(ctx, &iotexapi.SendActionRequest{
+		Action: &pb.ActionPb{
+			Action: &pb.ActionPb_Transfer{Transfer: &pb.TransferPb{Amount: balance.Bytes(), Recipient: "io1bravo"}},
+			Nonce:  3,
+		},
+	})
+	require.NoError(err)
+	require.Equal(64, len(res.Hash))
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+//go:build gateway
+// +build gateway
+
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	iotexapi "github.com/iotexproject/iotex-core/api/proto"
+	"github.com/iotexproject/iotex-core/logger"
+)
+
+// startGateway serves the REST gateway, which proxies the requests to the gRPC API
+func (s *Server) startGateway() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	mux := runtime.NewServeMux()
+	endpoint := net.JoinHostPort("127.0.0.1", strconv.Itoa(s.port))
+	if err := iotexapi.RegisterAPIServiceHandlerFromEndpoint(ctx, mux, endpoint, []grpc.DialOption{grpc.WithInsecure()}); err != nil {
+		return errors.Wrap(err, "error when registering the API gateway")
+	}
+	gwLis, err := net.Listen("tcp", ":"+strconv.Itoa(s.cfg.GatewayPort))
+	if err != nil {
+		return errors.Wrap(err, "error when creating the API gateway listener")
+	}
+	logger.Info().Str("addr", gwLis.Addr().String()).Msg("Starting API gateway")
+	s.gateway = &http.Server{Handler: mux}
+	go func() {
+		if err := s.gateway.Serve(gwLis); err != nil && err != http.ErrServerClosed {
+			logger.Error().Err(err).Msg("error when serving the API gateway")
+		}
+	}()
+	return nil
+}
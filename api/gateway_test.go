@@ -0,0 +1,66 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+//go:build gateway
+// +build gateway
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+)
+
+func TestGateway(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	cfg := config.Default.API
+	cfg.Port = 0
+	cfg.GatewayPort = 14016
+	s := NewServer(cfg, bc, nil, nil, nil)
+	ctx := context.Background()
+	require.NoError(s.Start(ctx))
+	defer func() {
+		require.NoError(s.Stop(ctx))
+	}()
+
+	bc.EXPECT().GetTotalTransfers().Return(uint64(4), nil)
+	bc.EXPECT().GetTotalVotes().Return(uint64(5), nil)
+	bc.EXPECT().GetTotalExecutions().Return(uint64(6), nil)
+	bc.EXPECT().TipHash().Return(hash.ZeroHash32B)
+	bc.EXPECT().TipHeight().Return(uint64(7))
+	resp, err := http.Get("http://127.0.0.1:14016/v1/chainmeta")
+	require.NoError(err)
+	defer resp.Body.Close()
+	require.Equal(http.StatusOK, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(err)
+
+	var res struct {
+		ChainMeta struct {
+			Height       string `json:"height"`
+			Supply       string `json:"supply"`
+			NumTransfers string `json:"numTransfers"`
+		} `json:"chainMeta"`
+	}
+	require.NoError(json.Unmarshal(body, &res))
+	require.Equal("7", res.ChainMeta.Height)
+	require.Equal("10000000000", res.ChainMeta.Supply)
+	require.Equal("4", res.ChainMeta.NumTransfers)
+}
@@ -0,0 +1,17 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+//go:build !gateway
+// +build !gateway
+
+package api
+
+import "github.com/pkg/errors"
+
+// startGateway fails, because the REST gateway is not built in without the gateway tag
+func (s *Server) startGateway() error {
+	return errors.New("the API gateway is not built in, rebuild with -tags gateway or set the gateway port to 0")
+}
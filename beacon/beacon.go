@@ -7,6 +7,7 @@
 package beacon
 
 import (
+	"encoding/hex"
 	"hash"
 
 	"golang.org/x/crypto/blake2b"
@@ -24,6 +25,15 @@ type Beacon struct {
 	hash hash.Hash
 }
 
+// GenesisSeed returns the seed of the first epoch, which is used before any group signature of the DKG is available
+func GenesisSeed() []byte {
+	seed, err := hex.DecodeString(startSeed)
+	if err != nil {
+		logger.Panic().Err(err).Msg("error when decoding the genesis seed")
+	}
+	return seed
+}
+
 // NewBeacon creates new beacon with initial string
 func NewBeacon() (Beacon, error) {
 	hash, err := blake2b.New(64, nil)
@@ -30,3 +30,8 @@ func TestBeacon(t *testing.T) {
 	b.NextEpoch()
 	assert.Equal(t, decodeHash("1a2c5d909c40cd705e0464c0e804a2d1ae60477c17a3651b385a6ec2fe659296e1efdec3e4531b3b27433a2a137abd2eeb60a260aff1028cb7a71402a35af2b5"), b.GetSeed())
 }
+
+func TestGenesisSeed(t *testing.T) {
+	assert.Equal(t, decodeHash(startSeed), GenesisSeed())
+	assert.Equal(t, 32, len(GenesisSeed()))
+}
@@ -306,6 +306,19 @@ func (tsf *Transfer) IntrinsicGas() (uint64, error) {
 	return payloadSize*TransferPayloadGas + TransferBaseIntrinsicGas, nil
 }
 
+// ValidateTransferPayload checks that the payload of a transfer is no longer than the limit
+func ValidateTransferPayload(payload []byte, maxBytes uint64) error {
+	if uint64(len(payload)) > maxBytes {
+		return errors.Wrapf(
+			ErrAction,
+			"transfer payload contains %d bytes, and is longer than %d bytes limit",
+			len(payload),
+			maxBytes,
+		)
+	}
+	return nil
+}
+
 // Cost returns the total cost of a transfer
 func (tsf *Transfer) Cost() (*big.Int, error) {
 	intrinsicGas, err := tsf.IntrinsicGas()
@@ -10,6 +10,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/iotxaddress"
@@ -98,3 +99,12 @@ func TestCoinbaseTsf(t *testing.T) {
 	require.NotNil(t, coinbaseTsf)
 	require.True(coinbaseTsf.isCoinbase)
 }
+
+func TestValidateTransferPayload(t *testing.T) {
+	require := require.New(t)
+	require.NoError(ValidateTransferPayload(nil, 0))
+	require.NoError(ValidateTransferPayload(make([]byte, 8), 8))
+	err := ValidateTransferPayload(make([]byte, 9), 8)
+	require.Error(err)
+	require.Equal(ErrAction, errors.Cause(err))
+}
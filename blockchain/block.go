@@ -63,7 +63,9 @@ type Block struct {
 	Executions      []*action.Execution
 	SecretProposals []*action.SecretProposal
 	SecretWitness   *action.SecretWitness
-	receipts        map[hash.Hash32B]*Receipt
+	// Certificate is the proof of the block's finality, which is attached after consensus is reached
+	Certificate *CommitCertificate
+	receipts    map[hash.Hash32B]*Receipt
 }
 
 // NewBlock returns a new block
@@ -128,6 +130,11 @@ func (b *Block) IsDummyBlock() bool {
 	return b.Header.height > 0 && len(b.Header.blockSig) == 0 && b.Header.Pubkey == keypair.ZeroPublicKey && len(b.Transfers)+len(b.Votes)+len(b.Executions) == 0
 }
 
+// IsSecretBlock checks whether block is a DKG secret block, which carries no coinbase transfer
+func (b *Block) IsSecretBlock() bool {
+	return b.SecretWitness != nil || len(b.SecretProposals) > 0
+}
+
 // Height returns the height of this block
 func (b *Block) Height() uint64 {
 	return b.Header.height
@@ -227,7 +234,11 @@ func (b *Block) ConvertToBlockPb() *iproto.BlockPb {
 	if b.SecretWitness != nil {
 		actions = append(actions, b.SecretWitness.ConvertToActionPb())
 	}
-	return &iproto.BlockPb{Header: b.ConvertToBlockHeaderPb(), Actions: actions}
+	blkPb := &iproto.BlockPb{Header: b.ConvertToBlockHeaderPb(), Actions: actions}
+	if b.Certificate != nil {
+		blkPb.Certificate = b.Certificate.ConvertToCommitCertificatePb()
+	}
+	return blkPb
 }
 
 // Serialize returns the serialized byte stream of the block
@@ -287,6 +298,11 @@ func (b *Block) ConvertFromBlockPb(pbBlock *iproto.BlockPb) {
 			logger.Fatal().Msg("unexpected action")
 		}
 	}
+
+	if pbCert := pbBlock.GetCertificate(); pbCert != nil {
+		b.Certificate = &CommitCertificate{}
+		b.Certificate.ConvertFromCommitCertificatePb(pbCert)
+	}
 }
 
 // Deserialize parses the byte stream into a Block
@@ -354,6 +370,15 @@ func (b *Block) SignBlock(signer *iotxaddress.Address) error {
 	return nil
 }
 
+// VerifyCertificate verifies the commit certificate attached to the block against the number of the delegates of the
+// block's epoch and their DKG public key shares recorded on chain
+func (b *Block) VerifyCertificate(numDelegates int, pubkeyShares map[string][]byte) error {
+	if b.Certificate == nil {
+		return errors.New("The block does not have a commit certificate")
+	}
+	return b.Certificate.Verify(b, numDelegates, pubkeyShares)
+}
+
 // VerifySignature verifies the signature saved in block header
 func (b *Block) VerifySignature() bool {
 	blkHash := b.HashBlock()
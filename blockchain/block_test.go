@@ -193,7 +193,7 @@ func TestConvertFromBlockPb(t *testing.T) {
 
 func TestWrongRootHash(t *testing.T) {
 	require := require.New(t)
-	val := validator{nil, ""}
+	val := validator{nil}
 	tsf1, err := action.NewTransfer(1, big.NewInt(20), ta.Addrinfo["producer"].RawAddress, ta.Addrinfo["alfa"].RawAddress, []byte{}, uint64(100000), big.NewInt(10))
 	require.NoError(err)
 	require.NoError(action.Sign(tsf1, ta.Addrinfo["producer"].PrivateKey))
@@ -212,7 +212,7 @@ func TestWrongRootHash(t *testing.T) {
 
 func TestSignBlock(t *testing.T) {
 	require := require.New(t)
-	val := validator{nil, ""}
+	val := validator{nil}
 	tsf1, err := action.NewTransfer(1, big.NewInt(20), ta.Addrinfo["producer"].RawAddress, ta.Addrinfo["alfa"].RawAddress, []byte{}, uint64(100000), big.NewInt(10))
 	require.NoError(err)
 	require.NoError(action.Sign(tsf1, ta.Addrinfo["producer"].PrivateKey))
@@ -238,7 +238,7 @@ func TestWrongNonce(t *testing.T) {
 	require.NoError(sf.Start(context.Background()))
 	_, err = sf.LoadOrCreateState(ta.Addrinfo["producer"].RawAddress, Gen.TotalSupply)
 	require.NoError(err)
-	val := validator{sf, ""}
+	val := validator{sf}
 	_, err = sf.RunActions(0, nil, nil, nil)
 	require.Nil(err)
 	require.Nil(sf.Commit())
@@ -352,7 +352,7 @@ func TestWrongCoinbaseTsf(t *testing.T) {
 	require.NoError(sf.Start(context.Background()))
 	_, err = sf.LoadOrCreateState(ta.Addrinfo["producer"].RawAddress, Gen.TotalSupply)
 	require.Nil(err)
-	val := validator{sf, ""}
+	val := validator{sf}
 	_, err = sf.RunActions(0, nil, nil, nil)
 	require.Nil(err)
 
@@ -491,14 +491,12 @@ func TestValidateSecretBlock(t *testing.T) {
 	err = blk.SignBlock(ta.Addrinfo["producer"])
 	require.NoError(err)
 
-	val := validator{sf, delegates[1]}
+	val := validator{sf}
 	require.NoError(val.Validate(blk, 2, hash, false))
 
-	// Falsify secret proposal
-	dummySecretProposal, err := action.NewSecretProposal(2, delegates[0], delegates[1], []uint32{1, 2, 3, 4, 5})
-	require.NoError(err)
-	secretProposals[1] = dummySecretProposal
-	blk = NewSecretBlock(1, 3, hash, clock.New(), secretProposals, secretWitness)
+	// Missing witness
+	blk = NewSecretBlock(1, 3, hash, clock.New(), secretProposals, nil)
+	require.True(blk.IsSecretBlock())
 	err = blk.SignBlock(ta.Addrinfo["producer"])
 	require.NoError(err)
 	err = val.Validate(blk, 2, hash, false)
@@ -24,6 +24,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	iproto "github.com/iotexproject/iotex-core/proto"
 	"github.com/iotexproject/iotex-core/state"
 )
 
@@ -60,6 +61,14 @@ type Blockchain interface {
 	GetTransfersFromAddress(address string) ([]hash.Hash32B, error)
 	// GetTransfersToAddress returns transaction to address
 	GetTransfersToAddress(address string) ([]hash.Hash32B, error)
+	// GetTransferCountFromAddress returns the number of transfers from address
+	GetTransferCountFromAddress(address string) (uint64, error)
+	// GetTransfersFromAddressByRange returns at most count transfers from address, starting from the start-th one
+	GetTransfersFromAddressByRange(address string, start uint64, count uint64) ([]hash.Hash32B, error)
+	// GetTransferCountToAddress returns the number of transfers to address
+	GetTransferCountToAddress(address string) (uint64, error)
+	// GetTransfersToAddressByRange returns at most count transfers to address, starting from the start-th one
+	GetTransfersToAddressByRange(address string, start uint64, count uint64) ([]hash.Hash32B, error)
 	// GetTransfersByTransferHash returns transfer by transfer hash
 	GetTransferByTransferHash(h hash.Hash32B) (*action.Transfer, error)
 	// GetBlockHashByTransferHash returns Block hash by transfer hash
@@ -68,6 +77,14 @@ type Blockchain interface {
 	GetVotesFromAddress(address string) ([]hash.Hash32B, error)
 	// GetVoteToAddress returns vote to address
 	GetVotesToAddress(address string) ([]hash.Hash32B, error)
+	// GetVoteCountFromAddress returns the number of votes from address
+	GetVoteCountFromAddress(address string) (uint64, error)
+	// GetVotesFromAddressByRange returns at most count votes from address, starting from the start-th one
+	GetVotesFromAddressByRange(address string, start uint64, count uint64) ([]hash.Hash32B, error)
+	// GetVoteCountToAddress returns the number of votes to address
+	GetVoteCountToAddress(address string) (uint64, error)
+	// GetVotesToAddressByRange returns at most count votes to address, starting from the start-th one
+	GetVotesToAddressByRange(address string, start uint64, count uint64) ([]hash.Hash32B, error)
 	// GetVotesByVoteHash returns vote by vote hash
 	GetVoteByVoteHash(h hash.Hash32B) (*action.Vote, error)
 	// GetBlockHashByVoteHash returns Block hash by vote hash
@@ -76,6 +93,14 @@ type Blockchain interface {
 	GetExecutionsFromAddress(address string) ([]hash.Hash32B, error)
 	// GetExecutionsToAddress returns executions to address
 	GetExecutionsToAddress(address string) ([]hash.Hash32B, error)
+	// GetExecutionCountFromAddress returns the number of executions from address
+	GetExecutionCountFromAddress(address string) (uint64, error)
+	// GetExecutionsFromAddressByRange returns at most count executions from address, starting from the start-th one
+	GetExecutionsFromAddressByRange(address string, start uint64, count uint64) ([]hash.Hash32B, error)
+	// GetExecutionCountToAddress returns the number of executions to address
+	GetExecutionCountToAddress(address string) (uint64, error)
+	// GetExecutionsToAddressByRange returns at most count executions to address, starting from the start-th one
+	GetExecutionsToAddressByRange(address string, start uint64, count uint64) ([]hash.Hash32B, error)
 	// GetExecutionByExecutionHash returns execution by execution hash
 	GetExecutionByExecutionHash(h hash.Hash32B) (*action.Execution, error)
 	// GetBlockHashByExecutionHash returns Block hash by execution hash
@@ -110,6 +135,10 @@ type Blockchain interface {
 	CommitBlock(blk *Block) error
 	// ValidateBlock validates a new block before adding it to the blockchain
 	ValidateBlock(blk *Block, containCoinbase bool) error
+	// StateSnapshot returns the block at the height and the snapshot of the state at it
+	StateSnapshot(height uint64) (*Block, *iproto.StateSnapshotPb, error)
+	// ImportStateSnapshot makes the block the new tip with the snapshot of the state at it, without the blocks before
+	ImportStateSnapshot(blk *Block, snapshot *iproto.StateSnapshotPb) error
 
 	// For action operations
 	// Validator returns the current validator object
@@ -119,8 +148,8 @@ type Blockchain interface {
 
 	// For smart contract operations
 	// ExecuteContractRead runs a read-only smart contract operation, this is done off the network since it does not
-	// cause any state change
-	ExecuteContractRead(*action.Execution) ([]byte, error)
+	// cause any state change. The receipt carries the return value and the gas consumed.
+	ExecuteContractRead(*action.Execution) (*Receipt, error)
 }
 
 // blockchain implements the Blockchain interface
@@ -234,17 +263,7 @@ func NewBlockchain(cfg *config.Config, opts ...Option) Blockchain {
 		}
 	}
 	// Set block validator
-	pubKey, _, err := cfg.KeyPair()
-	if err != nil {
-		logger.Error().Err(err).Msg("Failed to get key pair of producer")
-		return nil
-	}
-	address, err := iotxaddress.GetAddressByPubkey(iotxaddress.IsTestnet, iotxaddress.ChainID, pubKey)
-	if err != nil {
-		logger.Error().Err(err).Msg("Failed to get producer's address by public key")
-		return nil
-	}
-	chain.validator = &validator{sf: chain.sf, validatorAddr: address.RawAddress}
+	chain.validator = &validator{sf: chain.sf}
 
 	if chain.dao != nil {
 		chain.lifecycle.Add(chain.dao)
@@ -453,6 +472,38 @@ func (bc *blockchain) GetTransfersToAddress(address string) ([]hash.Hash32B, err
 	return bc.dao.getTransfersByRecipientAddress(address)
 }
 
+// GetTransferCountFromAddress returns the number of transfers from address
+func (bc *blockchain) GetTransferCountFromAddress(address string) (uint64, error) {
+	if !bc.config.Explorer.Enabled {
+		return 0, errors.New("explorer not enabled")
+	}
+	return bc.dao.getTransferCountBySenderAddress(address)
+}
+
+// GetTransfersFromAddressByRange returns at most count transfers from address, starting from the start-th one
+func (bc *blockchain) GetTransfersFromAddressByRange(address string, start uint64, count uint64) ([]hash.Hash32B, error) {
+	if !bc.config.Explorer.Enabled {
+		return nil, errors.New("explorer not enabled")
+	}
+	return bc.dao.getTransfersBySenderAddressRange(address, start, count)
+}
+
+// GetTransferCountToAddress returns the number of transfers to address
+func (bc *blockchain) GetTransferCountToAddress(address string) (uint64, error) {
+	if !bc.config.Explorer.Enabled {
+		return 0, errors.New("explorer not enabled")
+	}
+	return bc.dao.getTransferCountByRecipientAddress(address)
+}
+
+// GetTransfersToAddressByRange returns at most count transfers to address, starting from the start-th one
+func (bc *blockchain) GetTransfersToAddressByRange(address string, start uint64, count uint64) ([]hash.Hash32B, error) {
+	if !bc.config.Explorer.Enabled {
+		return nil, errors.New("explorer not enabled")
+	}
+	return bc.dao.getTransfersByRecipientAddressRange(address, start, count)
+}
+
 // GetTransferByTransferHash returns transfer by transfer hash
 func (bc *blockchain) GetTransferByTransferHash(h hash.Hash32B) (*action.Transfer, error) {
 	if !bc.config.Explorer.Enabled {
@@ -498,6 +549,38 @@ func (bc *blockchain) GetVotesToAddress(address string) ([]hash.Hash32B, error)
 	return bc.dao.getVotesByRecipientAddress(address)
 }
 
+// GetVoteCountFromAddress returns the number of votes from address
+func (bc *blockchain) GetVoteCountFromAddress(address string) (uint64, error) {
+	if !bc.config.Explorer.Enabled {
+		return 0, errors.New("explorer not enabled")
+	}
+	return bc.dao.getVoteCountBySenderAddress(address)
+}
+
+// GetVotesFromAddressByRange returns at most count votes from address, starting from the start-th one
+func (bc *blockchain) GetVotesFromAddressByRange(address string, start uint64, count uint64) ([]hash.Hash32B, error) {
+	if !bc.config.Explorer.Enabled {
+		return nil, errors.New("explorer not enabled")
+	}
+	return bc.dao.getVotesBySenderAddressRange(address, start, count)
+}
+
+// GetVoteCountToAddress returns the number of votes to address
+func (bc *blockchain) GetVoteCountToAddress(address string) (uint64, error) {
+	if !bc.config.Explorer.Enabled {
+		return 0, errors.New("explorer not enabled")
+	}
+	return bc.dao.getVoteCountByRecipientAddress(address)
+}
+
+// GetVotesToAddressByRange returns at most count votes to address, starting from the start-th one
+func (bc *blockchain) GetVotesToAddressByRange(address string, start uint64, count uint64) ([]hash.Hash32B, error) {
+	if !bc.config.Explorer.Enabled {
+		return nil, errors.New("explorer not enabled")
+	}
+	return bc.dao.getVotesByRecipientAddressRange(address, start, count)
+}
+
 // GetVotesByVoteHash returns vote by vote hash
 func (bc *blockchain) GetVoteByVoteHash(h hash.Hash32B) (*action.Vote, error) {
 	if !bc.config.Explorer.Enabled {
@@ -543,6 +626,38 @@ func (bc *blockchain) GetExecutionsToAddress(address string) ([]hash.Hash32B, er
 	return bc.dao.getExecutionsByContractAddress(address)
 }
 
+// GetExecutionCountFromAddress returns the number of executions from address
+func (bc *blockchain) GetExecutionCountFromAddress(address string) (uint64, error) {
+	if !bc.config.Explorer.Enabled {
+		return 0, errors.New("explorer not enabled")
+	}
+	return bc.dao.getExecutionCountByExecutorAddress(address)
+}
+
+// GetExecutionsFromAddressByRange returns at most count executions from address, starting from the start-th one
+func (bc *blockchain) GetExecutionsFromAddressByRange(address string, start uint64, count uint64) ([]hash.Hash32B, error) {
+	if !bc.config.Explorer.Enabled {
+		return nil, errors.New("explorer not enabled")
+	}
+	return bc.dao.getExecutionsByExecutorAddressRange(address, start, count)
+}
+
+// GetExecutionCountToAddress returns the number of executions to address
+func (bc *blockchain) GetExecutionCountToAddress(address string) (uint64, error) {
+	if !bc.config.Explorer.Enabled {
+		return 0, errors.New("explorer not enabled")
+	}
+	return bc.dao.getExecutionCountByContractAddress(address)
+}
+
+// GetExecutionsToAddressByRange returns at most count executions to address, starting from the start-th one
+func (bc *blockchain) GetExecutionsToAddressByRange(address string, start uint64, count uint64) ([]hash.Hash32B, error) {
+	if !bc.config.Explorer.Enabled {
+		return nil, errors.New("explorer not enabled")
+	}
+	return bc.dao.getExecutionsByContractAddressRange(address, start, count)
+}
+
 // GetExecutionByExecutionHash returns execution by execution hash
 func (bc *blockchain) GetExecutionByExecutionHash(h hash.Hash32B) (*action.Execution, error) {
 	if !bc.config.Explorer.Enabled {
@@ -711,13 +826,68 @@ func (bc *blockchain) CommitBlock(blk *Block) error {
 	return bc.commitBlock(blk)
 }
 
+// StateSnapshot returns the block at the height and the snapshot of the state at it
+func (bc *blockchain) StateSnapshot(height uint64) (*Block, *iproto.StateSnapshotPb, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	if bc.sf == nil {
+		return nil, nil, errors.New("statefactory cannot be nil")
+	}
+	if height > bc.tipHeight {
+		return nil, nil, errors.Errorf("height %d is higher than the tip %d", height, bc.tipHeight)
+	}
+	blk, err := bc.GetBlockByHeight(height)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get the block on height %d", height)
+	}
+	// The snapshot is taken from the trie of the block's state root, whose nodes are kept after the later blocks have
+	// updated the accounts, so that neither the later changes nor the pending ones are included
+	snapshot, err := bc.sf.Snapshot(blk.Header.stateRoot, blk.Height())
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get the state snapshot on height %d", blk.Height())
+	}
+	return blk, snapshot, nil
+}
+
+// ImportStateSnapshot makes the block the new tip with the snapshot of the state at it, without the blocks before
+func (bc *blockchain) ImportStateSnapshot(blk *Block, snapshot *iproto.StateSnapshotPb) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	if bc.sf == nil {
+		return errors.New("statefactory cannot be nil")
+	}
+	if blk.Height() <= bc.tipHeight {
+		return errors.Errorf("block %d is not higher than the tip %d", blk.Height(), bc.tipHeight)
+	}
+	if snapshot.Height != blk.Height() {
+		return errors.Wrapf(
+			state.ErrInvalidSnapshot,
+			"snapshot height %d doesn't match the block %d",
+			snapshot.Height,
+			blk.Height(),
+		)
+	}
+	if err := bc.sf.LoadSnapshot(blk.Header.stateRoot, snapshot); err != nil {
+		return errors.Wrapf(err, "failed to load the state snapshot on height %d", blk.Height())
+	}
+	if err := bc.dao.putBlock(blk); err != nil {
+		return err
+	}
+	bc.tipHeight = blk.Height()
+	bc.tipHash = blk.HashBlock()
+	logger.Info().Uint64("height", blk.Height()).Msg("import a state snapshot")
+	return nil
+}
+
 // StateByAddr returns the state of an address
 func (bc *blockchain) StateByAddr(address string) (*state.State, error) {
 	if bc.sf != nil {
 		s, err := bc.sf.State(address)
 		if err != nil {
 			logger.Warn().Err(err).Str("Address", address)
-			return nil, errors.New("account does not exist")
+			return nil, errors.Wrapf(err, "failed to get the state of %s", address)
 		}
 		return s, nil
 	}
@@ -736,7 +906,7 @@ func (bc *blockchain) Validator() Validator {
 
 // ExecuteContractRead runs a read-only smart contract operation, this is done off the network since it does not
 // cause any state change
-func (bc *blockchain) ExecuteContractRead(ex *action.Execution) ([]byte, error) {
+func (bc *blockchain) ExecuteContractRead(ex *action.Execution) (*Receipt, error) {
 	// use latest block as carrier to run the offline execution
 	// the block itself is not used
 	h := bc.TipHeight()
@@ -752,9 +922,9 @@ func (bc *blockchain) ExecuteContractRead(ex *action.Execution) ([]byte, error)
 	exHash := ex.Hash()
 	receipt, ok := blk.receipts[exHash]
 	if !ok {
-		return nil, errors.Wrap(err, "failed to get receipt in ExecuteContractRead")
+		return nil, errors.New("failed to get receipt in ExecuteContractRead")
 	}
-	return receipt.ReturnValue, nil
+	return receipt, nil
 }
 
 //======================================
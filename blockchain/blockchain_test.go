@@ -578,7 +578,7 @@ func TestBlockchain_MintNewDummyBlock(t *testing.T) {
 	sf, err := state.NewFactory(cfg, state.DefaultTrieOption())
 	require.NoError(err)
 	require.NoError(sf.Start(context.Background()))
-	val := validator{sf, ""}
+	val := validator{sf}
 
 	ctx := context.Background()
 	bc := NewBlockchain(cfg, InMemDaoOption(), InMemStateFactoryOption())
@@ -764,7 +764,7 @@ func TestActions(t *testing.T) {
 	sf.LoadOrCreateState(a.RawAddress, uint64(100000))
 	sf.LoadOrCreateState(c.RawAddress, uint64(100000))
 
-	val := validator{sf, ""}
+	val := validator{sf}
 	tsfs := []*action.Transfer{}
 	votes := []*action.Vote{}
 	for i := 0; i < 5000; i++ {
@@ -201,7 +201,7 @@ func (dao *blockDAO) getTransfersBySenderAddress(address string) ([]hash.Hash32B
 		return nil, errors.Wrapf(err, "for sender %x", address)
 	}
 
-	res, getTransfersErr := dao.getTransfersByAddress(address, senderTransferCount, transferFromPrefix)
+	res, getTransfersErr := dao.getTransfersByAddress(address, 0, senderTransferCount, transferFromPrefix)
 	if getTransfersErr != nil {
 		return nil, getTransfersErr
 	}
@@ -209,6 +209,16 @@ func (dao *blockDAO) getTransfersBySenderAddress(address string) ([]hash.Hash32B
 	return res, nil
 }
 
+// getTransfersBySenderAddressRange returns at most count transfers for sender, starting from the start-th one
+func (dao *blockDAO) getTransfersBySenderAddressRange(address string, start uint64, count uint64) ([]hash.Hash32B, error) {
+	total, err := dao.getTransferCountBySenderAddress(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "for sender %x", address)
+	}
+	start, end := indexRange(start, count, total)
+	return dao.getTransfersByAddress(address, start, end, transferFromPrefix)
+}
+
 // getTransferCountBySenderAddress returns transfer count by sender address
 func (dao *blockDAO) getTransferCountBySenderAddress(address string) (uint64, error) {
 	senderTransferCountKey := append(transferFromPrefix, address...)
@@ -230,7 +240,7 @@ func (dao *blockDAO) getTransfersByRecipientAddress(address string) ([]hash.Hash
 		return nil, errors.Wrapf(getCountErr, "for recipient %x", address)
 	}
 
-	res, getTransfersErr := dao.getTransfersByAddress(address, recipientTransferCount, transferToPrefix)
+	res, getTransfersErr := dao.getTransfersByAddress(address, 0, recipientTransferCount, transferToPrefix)
 	if getTransfersErr != nil {
 		return nil, getTransfersErr
 	}
@@ -238,11 +248,21 @@ func (dao *blockDAO) getTransfersByRecipientAddress(address string) ([]hash.Hash
 	return res, nil
 }
 
-// getTransfersByAddress returns transfers by address
-func (dao *blockDAO) getTransfersByAddress(address string, count uint64, keyPrefix []byte) ([]hash.Hash32B, error) {
+// getTransfersByRecipientAddressRange returns at most count transfers for recipient, starting from the start-th one
+func (dao *blockDAO) getTransfersByRecipientAddressRange(address string, start uint64, count uint64) ([]hash.Hash32B, error) {
+	total, err := dao.getTransferCountByRecipientAddress(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "for recipient %x", address)
+	}
+	start, end := indexRange(start, count, total)
+	return dao.getTransfersByAddress(address, start, end, transferToPrefix)
+}
+
+// getTransfersByAddress returns the transfers of address in the index between start and end
+func (dao *blockDAO) getTransfersByAddress(address string, start uint64, end uint64, keyPrefix []byte) ([]hash.Hash32B, error) {
 	var res []hash.Hash32B
 
-	for i := uint64(0); i < count; i++ {
+	for i := start; i < end; i++ {
 		// put new transfer to recipient
 		key := append(keyPrefix, address...)
 		key = append(key, byteutil.Uint64ToBytes(i)...)
@@ -281,7 +301,7 @@ func (dao *blockDAO) getVotesBySenderAddress(address string) ([]hash.Hash32B, er
 		return nil, errors.Wrapf(err, "to get votecount for sender %x", address)
 	}
 
-	res, err := dao.getVotesByAddress(address, senderVoteCount, voteFromPrefix)
+	res, err := dao.getVotesByAddress(address, 0, senderVoteCount, voteFromPrefix)
 	if err != nil {
 		return nil, errors.Wrapf(err, "to get votes for sender %x", address)
 	}
@@ -289,6 +309,16 @@ func (dao *blockDAO) getVotesBySenderAddress(address string) ([]hash.Hash32B, er
 	return res, nil
 }
 
+// getVotesBySenderAddressRange returns at most count votes for sender, starting from the start-th one
+func (dao *blockDAO) getVotesBySenderAddressRange(address string, start uint64, count uint64) ([]hash.Hash32B, error) {
+	total, err := dao.getVoteCountBySenderAddress(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "for sender %x", address)
+	}
+	start, end := indexRange(start, count, total)
+	return dao.getVotesByAddress(address, start, end, voteFromPrefix)
+}
+
 // getVoteCountBySenderAddress returns vote count by sender address
 func (dao *blockDAO) getVoteCountBySenderAddress(address string) (uint64, error) {
 	senderVoteCountKey := append(voteFromPrefix, address...)
@@ -309,7 +339,7 @@ func (dao *blockDAO) getVotesByRecipientAddress(address string) ([]hash.Hash32B,
 		return nil, errors.Wrapf(err, "to get votecount for recipient %x", address)
 	}
 
-	res, err := dao.getVotesByAddress(address, recipientVoteCount, voteToPrefix)
+	res, err := dao.getVotesByAddress(address, 0, recipientVoteCount, voteToPrefix)
 	if err != nil {
 		return nil, errors.Wrapf(err, "to get votes for recipient %x", address)
 	}
@@ -317,11 +347,21 @@ func (dao *blockDAO) getVotesByRecipientAddress(address string) ([]hash.Hash32B,
 	return res, nil
 }
 
-// getVotesByAddress returns votes by address
-func (dao *blockDAO) getVotesByAddress(address string, count uint64, keyPrefix []byte) ([]hash.Hash32B, error) {
+// getVotesByRecipientAddressRange returns at most count votes for recipient, starting from the start-th one
+func (dao *blockDAO) getVotesByRecipientAddressRange(address string, start uint64, count uint64) ([]hash.Hash32B, error) {
+	total, err := dao.getVoteCountByRecipientAddress(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "for recipient %x", address)
+	}
+	start, end := indexRange(start, count, total)
+	return dao.getVotesByAddress(address, start, end, voteToPrefix)
+}
+
+// getVotesByAddress returns the votes of address in the index between start and end
+func (dao *blockDAO) getVotesByAddress(address string, start uint64, end uint64, keyPrefix []byte) ([]hash.Hash32B, error) {
 	var res []hash.Hash32B
 
-	for i := uint64(0); i < count; i++ {
+	for i := start; i < end; i++ {
 		// put new vote to recipient
 		key := append(keyPrefix, address...)
 		key = append(key, byteutil.Uint64ToBytes(i)...)
@@ -361,7 +401,7 @@ func (dao *blockDAO) getExecutionsByExecutorAddress(address string) ([]hash.Hash
 		return nil, errors.Wrapf(err, "for executor %x", address)
 	}
 
-	res, getExecutionsErr := dao.getExecutionsByAddress(address, executorExecutionCount, executionFromPrefix)
+	res, getExecutionsErr := dao.getExecutionsByAddress(address, 0, executorExecutionCount, executionFromPrefix)
 	if getExecutionsErr != nil {
 		return nil, getExecutionsErr
 	}
@@ -369,6 +409,16 @@ func (dao *blockDAO) getExecutionsByExecutorAddress(address string) ([]hash.Hash
 	return res, nil
 }
 
+// getExecutionsByExecutorAddressRange returns at most count executions for executor, starting from the start-th one
+func (dao *blockDAO) getExecutionsByExecutorAddressRange(address string, start uint64, count uint64) ([]hash.Hash32B, error) {
+	total, err := dao.getExecutionCountByExecutorAddress(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "for executor %x", address)
+	}
+	start, end := indexRange(start, count, total)
+	return dao.getExecutionsByAddress(address, start, end, executionFromPrefix)
+}
+
 // getExecutionCountByExecutorAddress returns execution count by executor address
 func (dao *blockDAO) getExecutionCountByExecutorAddress(address string) (uint64, error) {
 	executorExecutionCountKey := append(executionFromPrefix, address...)
@@ -390,7 +440,7 @@ func (dao *blockDAO) getExecutionsByContractAddress(address string) ([]hash.Hash
 		return nil, errors.Wrapf(getCountErr, "for contract %x", address)
 	}
 
-	res, getExecutionsErr := dao.getExecutionsByAddress(address, contractExecutionCount, executionToPrefix)
+	res, getExecutionsErr := dao.getExecutionsByAddress(address, 0, contractExecutionCount, executionToPrefix)
 	if getExecutionsErr != nil {
 		return nil, getExecutionsErr
 	}
@@ -398,11 +448,21 @@ func (dao *blockDAO) getExecutionsByContractAddress(address string) ([]hash.Hash
 	return res, nil
 }
 
-// getExecutionsByAddress returns executions by address
-func (dao *blockDAO) getExecutionsByAddress(address string, count uint64, keyPrefix []byte) ([]hash.Hash32B, error) {
+// getExecutionsByContractAddressRange returns at most count executions for contract, starting from the start-th one
+func (dao *blockDAO) getExecutionsByContractAddressRange(address string, start uint64, count uint64) ([]hash.Hash32B, error) {
+	total, err := dao.getExecutionCountByContractAddress(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "for contract %x", address)
+	}
+	start, end := indexRange(start, count, total)
+	return dao.getExecutionsByAddress(address, start, end, executionToPrefix)
+}
+
+// getExecutionsByAddress returns the executions of address in the index between start and end
+func (dao *blockDAO) getExecutionsByAddress(address string, start uint64, end uint64, keyPrefix []byte) ([]hash.Hash32B, error) {
 	var res []hash.Hash32B
 
-	for i := uint64(0); i < count; i++ {
+	for i := start; i < end; i++ {
 		// put new execution to recipient
 		key := append(keyPrefix, address...)
 		key = append(key, byteutil.Uint64ToBytes(i)...)
@@ -434,6 +494,17 @@ func (dao *blockDAO) getExecutionCountByContractAddress(address string) (uint64,
 	return enc.MachineEndian.Uint64(value), nil
 }
 
+// indexRange clips the range of count entries from start to the total number of entries of an index
+func indexRange(start uint64, count uint64, total uint64) (uint64, uint64) {
+	if start > total {
+		start = total
+	}
+	if count > total-start {
+		count = total - start
+	}
+	return start, start + count
+}
+
 // getBlockchainHeight returns the blockchain height
 func (dao *blockDAO) getBlockchainHeight() (uint64, error) {
 	value, err := dao.kvstore.Get(blockNS, topHeightKey)
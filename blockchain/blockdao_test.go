@@ -347,6 +347,20 @@ func TestBlockDAO(t *testing.T) {
 		require.Equal(t, executionHash1, contractExecutions[0])
 		require.Equal(t, executionHash2, contractExecutions[1])
 		require.Equal(t, executionHash3, contractExecutions[2])
+
+		// Test get the range of executions
+		contractExecutions, err = dao.getExecutionsByContractAddressRange(testaddress.Addrinfo["delta"].RawAddress, 1, 1)
+		require.NoError(t, err)
+		require.Equal(t, []hash.Hash32B{executionHash2}, contractExecutions)
+		contractExecutions, err = dao.getExecutionsByContractAddressRange(testaddress.Addrinfo["delta"].RawAddress, 2, 5)
+		require.NoError(t, err)
+		require.Equal(t, []hash.Hash32B{executionHash3}, contractExecutions)
+		contractExecutions, err = dao.getExecutionsByContractAddressRange(testaddress.Addrinfo["delta"].RawAddress, 5, 1)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(contractExecutions))
+		recipientTransfers, err = dao.getTransfersByRecipientAddressRange(testaddress.Addrinfo["charlie"].RawAddress, 0, 2)
+		require.NoError(t, err)
+		require.Equal(t, []hash.Hash32B{transferHash3}, recipientTransfers)
 	}
 
 	testDeleteDao := func(kvstore db.KVStore, t *testing.T) {
@@ -30,8 +30,7 @@ type Validator interface {
 }
 
 type validator struct {
-	sf            state.Factory
-	validatorAddr string
+	sf state.Factory
 }
 
 var (
@@ -49,6 +48,8 @@ var (
 	ErrBalance = errors.New("invalid balance")
 	// ErrDKGSecretProposal indicates the error of DKG secret proposal
 	ErrDKGSecretProposal = errors.New("invalid DKG secret proposal")
+	// ErrInvalidProducer indicates that the block is not produced by a delegate of its epoch
+	ErrInvalidProducer = errors.New("invalid block producer")
 )
 
 // Validate validates the given block's content
@@ -246,6 +247,9 @@ func (v *validator) verifyActions(blk *Block, containCoinbase bool) error {
 	}
 
 	// Verify Witness
+	if len(blk.SecretProposals) > 0 && blk.SecretWitness == nil {
+		return errors.Wrap(ErrDKGSecretProposal, "missing the witness of the secret proposals")
+	}
 	if blk.SecretWitness != nil {
 		// Verify witness sender address
 		if _, err := iotxaddress.GetPubkeyHash(blk.SecretWitness.SrcAddr()); err != nil {
@@ -283,18 +287,7 @@ func (v *validator) verifyActions(blk *Block, containCoinbase bool) error {
 			accountNonceMap[sp.SrcAddr()] = make([]uint64, 0)
 		}
 		accountNonceMap[sp.SrcAddr()] = append(accountNonceMap[sp.SrcAddr()], sp.Nonce())
-
-		// verify secret if the validator is recipient
-		if v.validatorAddr == sp.DstAddr() {
-			validatorID := iotxaddress.CreateID(v.validatorAddr)
-			result, err := crypto.DKG.ShareVerify(validatorID, sp.Secret(), blk.SecretWitness.Witness())
-			if err == nil {
-				err = ErrDKGSecretProposal
-			}
-			if !result {
-				return errors.Wrap(err, "failed to verify the DKG secret share")
-			}
-		}
+		// The secret is encrypted to the recipient, who decrypts and verifies it in the consensus
 	}
 
 	if blk.Header.height > 0 {
@@ -0,0 +1,164 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+var (
+	// ErrInvalidCertificate indicates the error of an invalid commit certificate
+	ErrInvalidCertificate = errors.New("invalid commit certificate")
+)
+
+// CommitCertificate is the proof of a block's finality. It carries the BLS signature shares on the block hash, which
+// are signed by a quorum of the delegates who endorsed to commit the block, and aggregates the first crypto.Degree+1 of
+// them into a threshold signature.
+type CommitCertificate struct {
+	Height       uint64
+	BlockHash    hash.Hash32B
+	Endorsers    []string
+	DKGIDs       [][]byte
+	DKGPubkeys   [][]byte
+	DKGSigs      [][]byte
+	AggregateSig []byte
+}
+
+// CertificateQuorum returns the number of the endorsers that a commit certificate requires, which is more than 2/3 of
+// the delegates of the block's epoch, the same as the quorum to commit the block
+func CertificateQuorum(numDelegates int) int {
+	return numDelegates*2/3 + 1
+}
+
+// NewCommitCertificate puts the signature shares into a commit certificate of the block. It requires the shares of a
+// quorum of the numDelegates delegates, and aggregates them if there are at least crypto.Degree+1.
+func NewCommitCertificate(
+	blk *Block,
+	numDelegates int,
+	endorsers []string,
+	dkgIDs [][]byte,
+	dkgPubkeys [][]byte,
+	dkgSigs [][]byte,
+) (*CommitCertificate, error) {
+	if len(endorsers) != len(dkgIDs) || len(endorsers) != len(dkgPubkeys) || len(endorsers) != len(dkgSigs) {
+		return nil, errors.Wrap(ErrInvalidCertificate, "unmatched number of endorsers, IDs, public keys and signatures")
+	}
+	if len(endorsers) < CertificateQuorum(numDelegates) {
+		return nil, errors.Wrapf(
+			ErrInvalidCertificate,
+			"%d signature shares are not enough for %d delegates, at least %d are required",
+			len(endorsers),
+			numDelegates,
+			CertificateQuorum(numDelegates),
+		)
+	}
+	cert := &CommitCertificate{
+		Height:     blk.Height(),
+		BlockHash:  blk.HashBlock(),
+		Endorsers:  endorsers,
+		DKGIDs:     dkgIDs,
+		DKGPubkeys: dkgPubkeys,
+		DKGSigs:    dkgSigs,
+	}
+	if len(endorsers) >= crypto.Degree+1 {
+		aggregateSig, err := crypto.BLS.SignAggregate(dkgIDs[:crypto.Degree+1], dkgSigs[:crypto.Degree+1])
+		if err != nil {
+			return nil, errors.Wrap(err, "error when aggregating the signature shares")
+		}
+		cert.AggregateSig = aggregateSig
+	}
+	return cert, nil
+}
+
+// Verify verifies that the certificate is for the given block and endorsed by a quorum of the numDelegates delegates.
+// Each DKG ID has to belong to its endorser, each DKG public key has to be the share of its endorser recorded on chain,
+// each signature share has to be valid, and so does the aggregate signature of the first crypto.Degree+1 shares.
+// pubkeyShares maps the delegates of the block's epoch to their recorded DKG public key shares.
+func (c *CommitCertificate) Verify(blk *Block, numDelegates int, pubkeyShares map[string][]byte) error {
+	if c.Height != blk.Height() {
+		return errors.Wrapf(ErrInvalidCertificate, "certificate height %d does not match block height %d", c.Height, blk.Height())
+	}
+	if c.BlockHash != blk.HashBlock() {
+		return errors.Wrap(ErrInvalidCertificate, "certificate block hash does not match")
+	}
+	n := len(c.Endorsers)
+	if len(c.DKGIDs) != n || len(c.DKGPubkeys) != n || len(c.DKGSigs) != n {
+		return errors.Wrap(ErrInvalidCertificate, "unmatched number of endorsers, IDs, public keys and signatures")
+	}
+	if n < CertificateQuorum(numDelegates) {
+		return errors.Wrapf(
+			ErrInvalidCertificate,
+			"%d endorsers are not a quorum of %d delegates",
+			n,
+			numDelegates,
+		)
+	}
+	seen := make(map[string]bool, len(c.Endorsers))
+	for i, endorser := range c.Endorsers {
+		if seen[endorser] {
+			return errors.Wrapf(ErrInvalidCertificate, "duplicate endorser %s", endorser)
+		}
+		seen[endorser] = true
+		if !bytes.Equal(c.DKGIDs[i], iotxaddress.CreateID(endorser)) {
+			return errors.Wrapf(ErrInvalidCertificate, "DKG ID does not belong to endorser %s", endorser)
+		}
+		share, ok := pubkeyShares[endorser]
+		if !ok {
+			return errors.Wrapf(ErrInvalidCertificate, "endorser %s has no DKG public key share recorded", endorser)
+		}
+		if !bytes.Equal(c.DKGPubkeys[i], share) {
+			return errors.Wrapf(ErrInvalidCertificate, "DKG public key is not the share recorded for endorser %s", endorser)
+		}
+		if err := crypto.BLS.VerifyShare(c.DKGPubkeys[i], c.BlockHash[:], c.DKGSigs[i]); err != nil {
+			return errors.Wrapf(ErrInvalidCertificate, "error when verifying the signature share of %s: %v", endorser, err)
+		}
+	}
+	if n < crypto.Degree+1 {
+		return nil
+	}
+	err := crypto.BLS.VerifyAggregate(
+		c.DKGIDs[:crypto.Degree+1],
+		c.DKGPubkeys[:crypto.Degree+1],
+		c.BlockHash[:],
+		c.AggregateSig,
+	)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidCertificate, "error when verifying the aggregate signature: %v", err)
+	}
+	return nil
+}
+
+// ConvertToCommitCertificatePb converts CommitCertificate to CommitCertificatePb
+func (c *CommitCertificate) ConvertToCommitCertificatePb() *iproto.CommitCertificatePb {
+	return &iproto.CommitCertificatePb{
+		Height:             c.Height,
+		BlockHash:          c.BlockHash[:],
+		Endorsers:          c.Endorsers,
+		DkgIDs:             c.DKGIDs,
+		DkgPubkeys:         c.DKGPubkeys,
+		AggregateSignature: c.AggregateSig,
+		DkgSigs:            c.DKGSigs,
+	}
+}
+
+// ConvertFromCommitCertificatePb converts CommitCertificatePb to CommitCertificate
+func (c *CommitCertificate) ConvertFromCommitCertificatePb(pbCert *iproto.CommitCertificatePb) {
+	c.Height = pbCert.GetHeight()
+	copy(c.BlockHash[:], pbCert.GetBlockHash())
+	c.Endorsers = pbCert.GetEndorsers()
+	c.DKGIDs = pbCert.GetDkgIDs()
+	c.DKGPubkeys = pbCert.GetDkgPubkeys()
+	c.DKGSigs = pbCert.GetDkgSigs()
+	c.AggregateSig = pbCert.GetAggregateSignature()
+}
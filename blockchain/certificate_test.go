@@ -0,0 +1,178 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+func TestCommitCertificate(t *testing.T) {
+	require := require.New(t)
+
+	const numNodes = 21
+	endorsers, dkgAddrs := generateDKGAddresses(t, numNodes)
+	blk := NewBlock(1, 1, hash.ZeroHash32B, clock.New(), nil, nil, nil)
+	blkHash := blk.HashBlock()
+
+	dkgIDs := make([][]byte, numNodes)
+	dkgPubkeys := make([][]byte, numNodes)
+	dkgSigs := make([][]byte, numNodes)
+	pubkeyShares := make(map[string][]byte, numNodes)
+	for i, dkgAddr := range dkgAddrs {
+		_, sig, err := crypto.BLS.SignShare(dkgAddr.PrivateKey, blkHash[:])
+		require.NoError(err)
+		dkgIDs[i] = dkgAddr.ID
+		dkgPubkeys[i] = dkgAddr.PublicKey
+		dkgSigs[i] = sig
+		pubkeyShares[endorsers[i]] = dkgAddr.PublicKey
+	}
+
+	// The signature shares of Degree+1 endorsers are not from a quorum of the delegates
+	quorum := CertificateQuorum(numNodes)
+	require.True(quorum > crypto.Degree+1)
+	_, err := NewCommitCertificate(blk, numNodes, endorsers[:quorum-1], dkgIDs[:quorum-1],
+		dkgPubkeys[:quorum-1], dkgSigs[:quorum-1])
+	require.Equal(ErrInvalidCertificate, errors.Cause(err))
+
+	require.Error(blk.VerifyCertificate(numNodes, pubkeyShares))
+	cert, err := NewCommitCertificate(blk, numNodes, endorsers, dkgIDs, dkgPubkeys, dkgSigs)
+	require.NoError(err)
+	require.Equal(numNodes, len(cert.Endorsers))
+	blk.Certificate = cert
+	require.NoError(blk.VerifyCertificate(numNodes, pubkeyShares))
+
+	// The certificate survives the protobuf conversion, but it is not part of the block hash
+	blkPb := blk.ConvertToBlockPb()
+	newBlk := &Block{}
+	newBlk.ConvertFromBlockPb(blkPb)
+	require.Equal(blkHash, newBlk.HashBlock())
+	require.Equal(cert, newBlk.Certificate)
+	require.NoError(newBlk.VerifyCertificate(numNodes, pubkeyShares))
+
+	// The certificate of another block
+	otherBlk := NewBlock(1, 2, blkHash, clock.New(), nil, nil, nil)
+	require.Error(cert.Verify(otherBlk, numNodes, pubkeyShares))
+
+	// The certificate is not endorsed by a quorum of the delegates, even though its shares are enough to aggregate
+	forged := *cert
+	forged.Endorsers = cert.Endorsers[:crypto.Degree+1]
+	forged.DKGIDs = cert.DKGIDs[:crypto.Degree+1]
+	forged.DKGPubkeys = cert.DKGPubkeys[:crypto.Degree+1]
+	forged.DKGSigs = cert.DKGSigs[:crypto.Degree+1]
+	require.Equal(ErrInvalidCertificate, errors.Cause(forged.Verify(blk, numNodes, pubkeyShares)))
+
+	// The same endorser is counted twice to make up the quorum
+	forged = *cert
+	forged.Endorsers = append(append([]string{}, cert.Endorsers...), cert.Endorsers[0])
+	forged.DKGIDs = append(append([][]byte{}, cert.DKGIDs...), cert.DKGIDs[0])
+	forged.DKGPubkeys = append(append([][]byte{}, cert.DKGPubkeys...), cert.DKGPubkeys[0])
+	forged.DKGSigs = append(append([][]byte{}, cert.DKGSigs...), cert.DKGSigs[0])
+	require.Equal(ErrInvalidCertificate, errors.Cause(forged.Verify(blk, numNodes+1, pubkeyShares)))
+
+	// The DKG ID does not belong to the endorser
+	forged = *cert
+	forged.Endorsers = append([]string{endorsers[numNodes-1]}, cert.Endorsers[1:]...)
+	require.Equal(ErrInvalidCertificate, errors.Cause(forged.Verify(blk, numNodes, pubkeyShares)))
+
+	// The signature share of an endorser beyond the aggregated ones is not on the block hash
+	forged = *cert
+	forged.DKGSigs = append([][]byte{}, cert.DKGSigs...)
+	forged.DKGSigs[numNodes-1] = cert.DKGSigs[0]
+	require.Equal(ErrInvalidCertificate, errors.Cause(forged.Verify(blk, numNodes, pubkeyShares)))
+
+	// The aggregate signature is not on the block hash
+	forged = *cert
+	forged.AggregateSig = append([]byte{}, cert.AggregateSig...)
+	forged.AggregateSig[0] ^= 0xFF
+	require.Equal(ErrInvalidCertificate, errors.Cause(forged.Verify(blk, numNodes, pubkeyShares)))
+
+	// A quorum of a few delegates is not enough to aggregate, but each of their signature shares is verified
+	smallCert, err := NewCommitCertificate(blk, 4, endorsers[:3], dkgIDs[:3], dkgPubkeys[:3], dkgSigs[:3])
+	require.NoError(err)
+	require.Nil(smallCert.AggregateSig)
+	require.NoError(smallCert.Verify(blk, 4, pubkeyShares))
+	require.Equal(ErrInvalidCertificate, errors.Cause(smallCert.Verify(blk, 5, pubkeyShares)))
+
+	// The endorser has no DKG public key share recorded
+	unrecorded := make(map[string][]byte, numNodes)
+	for endorser, share := range pubkeyShares {
+		unrecorded[endorser] = share
+	}
+	delete(unrecorded, cert.Endorsers[0])
+	require.Equal(ErrInvalidCertificate, errors.Cause(cert.Verify(blk, numNodes, unrecorded)))
+
+	// The certificate is signed with the keys of another DKG run by the same endorsers, which are not the recorded ones
+	otherDKGAddrs := generateDKGKeys(t, endorsers)
+	for i, dkgAddr := range otherDKGAddrs {
+		_, sig, err := crypto.BLS.SignShare(dkgAddr.PrivateKey, blkHash[:])
+		require.NoError(err)
+		dkgPubkeys[i] = dkgAddr.PublicKey
+		dkgSigs[i] = sig
+	}
+	forgedCert, err := NewCommitCertificate(blk, numNodes, endorsers, dkgIDs, dkgPubkeys, dkgSigs)
+	require.NoError(err)
+	require.Equal(ErrInvalidCertificate, errors.Cause(forgedCert.Verify(blk, numNodes, pubkeyShares)))
+}
+
+func generateDKGAddresses(t *testing.T, numNodes int) ([]string, []*iotxaddress.DKGAddress) {
+	rawAddrs := make([]string, numNodes)
+	for i := 0; i < numNodes; i++ {
+		addr, err := iotxaddress.NewAddress(iotxaddress.IsTestnet, iotxaddress.ChainID)
+		require.NoError(t, err)
+		rawAddrs[i] = addr.RawAddress
+	}
+	return rawAddrs, generateDKGKeys(t, rawAddrs)
+}
+
+// generateDKGKeys runs a DKG among the addresses and returns their DKG key pairs
+func generateDKGKeys(t *testing.T, rawAddrs []string) []*iotxaddress.DKGAddress {
+	require := require.New(t)
+
+	numNodes := len(rawAddrs)
+	skList := make([][]uint32, numNodes)
+	idList := make([][]uint8, numNodes)
+	sharesList := make([][][]uint32, numNodes)
+	witnessesList := make([][][]byte, numNodes)
+	for i := 0; i < numNodes; i++ {
+		idList[i] = iotxaddress.CreateID(rawAddrs[i])
+		skList[i] = crypto.DKG.SkGeneration()
+	}
+	for i := 0; i < numNodes; i++ {
+		var err error
+		_, sharesList[i], witnessesList[i], err = crypto.DKG.Init(skList[i], idList)
+		require.NoError(err)
+	}
+
+	shares := make([][]uint32, numNodes)
+	shareStatusMatrix := make([][21]bool, numNodes)
+	for i := 0; i < numNodes; i++ {
+		for j := 0; j < numNodes; j++ {
+			shares[j] = sharesList[j][i]
+		}
+		var err error
+		shareStatusMatrix[i], err = crypto.DKG.SharesCollect(idList[i], shares, witnessesList)
+		require.NoError(err)
+	}
+	dkgAddrs := make([]*iotxaddress.DKGAddress, numNodes)
+	for i := 0; i < numNodes; i++ {
+		for j := 0; j < numNodes; j++ {
+			shares[j] = sharesList[j][i]
+		}
+		_, pk, ask, err := crypto.DKG.KeyPairGeneration(shares, shareStatusMatrix)
+		require.NoError(err)
+		dkgAddrs[i] = &iotxaddress.DKGAddress{PrivateKey: ask, PublicKey: pk, ID: idList[i]}
+	}
+	return dkgAddrs
+}
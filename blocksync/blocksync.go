@@ -8,7 +8,9 @@ package blocksync
 
 import (
 	"context"
+	"encoding/hex"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/actpool"
@@ -17,8 +19,11 @@ import (
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network"
 	"github.com/iotexproject/iotex-core/network/node"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/state"
 )
 
 // BlockSync defines the interface of blocksyncer
@@ -28,18 +33,68 @@ type BlockSync interface {
 	P2P() network.Overlay
 	ProcessSyncRequest(sender string, sync *pb.BlockSync) error
 	ProcessBlock(blk *blockchain.Block) error
-	ProcessBlockSync(blk *blockchain.Block) error
+	ProcessBlockSync(sender string, blk *blockchain.Block) error
+	ProcessBlockHeaders(sender string, headers []*pb.BlockHeaderPb) error
+	ProcessStateSnapshot(sender string, data *pb.BlockContainer) error
 }
 
 // blockSyncer implements BlockSync interface
 type blockSyncer struct {
-	ackBlockCommit bool // acknowledges latest committed block
-	ackBlockSync   bool // acknowledges old block from sync request
-	ackSyncReq     bool // acknowledges incoming Sync request
-	buf            *blockBuffer
-	worker         *syncWorker
-	bc             blockchain.Blockchain
-	p2p            network.Overlay
+	ackBlockCommit  bool // acknowledges latest committed block
+	ackBlockSync    bool // acknowledges old block from sync request
+	ackSyncReq      bool // acknowledges incoming Sync request
+	maxBlocksPerMsg uint64
+	headerBatchSize uint64
+	// headers are the verified headers, which is nil unless header-first sync is enabled
+	headers *headerChain
+	// checkpointHeight and checkpointHash are the trusted block to fast sync from
+	checkpointHeight uint64
+	checkpointHash   hash.Hash32B
+	buf              *blockBuffer
+	worker           *syncWorker
+	bc               blockchain.Blockchain
+	p2p              network.Overlay
+}
+
+// Option sets block syncer construction parameter
+type Option func(*blockSyncer) error
+
+// CertificateValidatorOption sets the function to validate the commit certificate of the synced blocks
+func CertificateValidatorOption(validate func(*blockchain.Block) error) Option {
+	return func(bs *blockSyncer) error {
+		if validate == nil {
+			return errors.New("certificate validator is nil")
+		}
+		bs.buf.validateCert = validate
+		return nil
+	}
+}
+
+// ProducerValidatorOption sets the function to validate the producer of the synced headers and blocks. It returns an
+// error wrapping blockchain.ErrInvalidProducer if the producer is not a delegate of the block's epoch, and any other
+// error if the delegates of the epoch are not known yet.
+func ProducerValidatorOption(validate func(*blockchain.Block) error) Option {
+	return func(bs *blockSyncer) error {
+		if validate == nil {
+			return errors.New("producer validator is nil")
+		}
+		bs.buf.validateProducer = validate
+		if bs.headers != nil {
+			bs.headers.validateProducer = validate
+		}
+		return nil
+	}
+}
+
+// CommitCallbackOption sets the function called with each synced block after it's committed
+func CommitCallbackOption(cb func(*blockchain.Block)) Option {
+	return func(bs *blockSyncer) error {
+		if cb == nil {
+			return errors.New("commit callback is nil")
+		}
+		bs.buf.onCommit = cb
+		return nil
+	}
 }
 
 // NewBlockSyncer returns a new block syncer instance
@@ -48,27 +103,52 @@ func NewBlockSyncer(
 	chain blockchain.Blockchain,
 	ap actpool.ActPool,
 	p2p network.Overlay,
+	opts ...Option,
 ) (BlockSync, error) {
 	if cfg == nil || chain == nil || ap == nil || p2p == nil {
 		return nil, errors.New("cannot create BlockSync: missing param")
 	}
 
 	buf := &blockBuffer{
-		blocks: make(map[uint64]*blockchain.Block),
-		bc:     chain,
-		ap:     ap,
-		size:   cfg.BlockSync.BufferSize,
-	}
-	w := newSyncWorker(chain.ChainID(), cfg, p2p, buf)
-	return &blockSyncer{
-		ackBlockCommit: cfg.IsDelegate() || cfg.IsFullnode(),
-		ackBlockSync:   cfg.IsDelegate() || cfg.IsFullnode(),
-		ackSyncReq:     cfg.IsDelegate() || cfg.IsFullnode(),
-		bc:             chain,
-		buf:            buf,
-		p2p:            p2p,
-		worker:         w,
-	}, nil
+		blocks:      make(map[uint64]*blockchain.Block),
+		bc:          chain,
+		ap:          ap,
+		size:        cfg.BlockSync.BufferSize,
+		requireCert: cfg.BlockSync.RequireCertificate,
+	}
+	var headers *headerChain
+	if cfg.BlockSync.HeaderFirst {
+		headers = newHeaderChain()
+	}
+	var checkpointHash hash.Hash32B
+	if cfg.BlockSync.CheckpointHeight > 0 {
+		h, err := hex.DecodeString(cfg.BlockSync.CheckpointHash)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode checkpoint hash")
+		}
+		checkpointHash = byteutil.BytesTo32B(h)
+	}
+	w := newSyncWorker(chain.ChainID(), cfg, p2p, buf, headers)
+	bs := &blockSyncer{
+		ackBlockCommit:   cfg.IsDelegate() || cfg.IsFullnode(),
+		ackBlockSync:     cfg.IsDelegate() || cfg.IsFullnode(),
+		ackSyncReq:       cfg.IsDelegate() || cfg.IsFullnode(),
+		maxBlocksPerMsg:  cfg.BlockSync.MaxBlocksPerMsg,
+		headerBatchSize:  cfg.BlockSync.HeaderBatchSize,
+		headers:          headers,
+		checkpointHeight: cfg.BlockSync.CheckpointHeight,
+		checkpointHash:   checkpointHash,
+		bc:               chain,
+		buf:              buf,
+		p2p:              p2p,
+		worker:           w,
+	}
+	for _, opt := range opts {
+		if err := opt(bs); err != nil {
+			return nil, err
+		}
+	}
+	return bs, nil
 }
 
 // P2P returns the network overlay object
@@ -83,15 +163,21 @@ func (bs *blockSyncer) Start(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	bs.buf.startHeight = startHeight
-	bs.buf.confirmedHeight = startHeight - 1
+	bs.buf.reset(startHeight - 1)
+	if bs.headers != nil {
+		bs.headers.reset(bs.bc.TipHeight(), bs.bc.TipHash())
+	}
+	if bs.checkpointHeight > bs.bc.TipHeight() {
+		logger.Info().Uint64("checkpoint", bs.checkpointHeight).Msg("Fast sync from the state snapshot.")
+		bs.worker.SetFastSync(true)
+	}
 	return bs.worker.Start(ctx)
 }
 
 // Stop stops a block syncer
 func (bs *blockSyncer) Stop(ctx context.Context) error {
 	logger.Debug().Msg("Stopping block syncer")
-	return bs.worker.Start(ctx)
+	return bs.worker.Stop(ctx)
 }
 
 // ProcessBlock processes an incoming latest committed block
@@ -120,15 +206,85 @@ func (bs *blockSyncer) ProcessBlock(blk *blockchain.Block) error {
 	return nil
 }
 
-func (bs *blockSyncer) ProcessBlockSync(blk *blockchain.Block) error {
+// ProcessBlockSync processes a block sent back by the peer for a sync request
+func (bs *blockSyncer) ProcessBlockSync(sender string, blk *blockchain.Block) error {
 	if !bs.ackBlockSync {
 		// node is not meant to handle sync block, simply exit
 		return nil
 	}
-	bs.buf.Flush(blk)
+	if bs.headers != nil {
+		if err := bs.headers.verify(blk); err != nil {
+			return err
+		}
+	}
+	// The block with an invalid certificate or producer is rejected upfront, so that the peer which has sent it is held
+	// to account. The producer of a block whose epoch's delegates are not known yet is verified when it is committed.
+	if err := bs.buf.verifyCertificate(blk); err != nil {
+		return err
+	}
+	if err := bs.buf.verifyProducer(blk); errors.Cause(err) == blockchain.ErrInvalidProducer {
+		return err
+	}
+	if _, re := bs.buf.Flush(blk); re == bCheckinValid {
+		bs.worker.Received(sender, blk.Height())
+	}
+	return nil
+}
+
+// ProcessBlockHeaders processes the headers sent back for a header-first sync request
+func (bs *blockSyncer) ProcessBlockHeaders(sender string, headers []*pb.BlockHeaderPb) error {
+	if !bs.ackBlockSync || bs.headers == nil {
+		return nil
+	}
+	added, err := bs.headers.add(convertFromHeaderPbs(headers))
+	bs.worker.ReceivedSingle(sender, err == nil)
+	if err != nil {
+		return err
+	}
+	logger.Debug().Int("added", added).Uint64("height", bs.headers.Height()).Msg("Verified block headers.")
 	return nil
 }
 
+// ProcessStateSnapshot processes the state snapshot sent back for a fast sync request. The snapshot is imported if
+// its block is the checkpoint, and the blocks after the checkpoint are synced as usual.
+func (bs *blockSyncer) ProcessStateSnapshot(sender string, data *pb.BlockContainer) error {
+	if !bs.ackBlockSync || bs.checkpointHeight <= bs.bc.TipHeight() {
+		return nil
+	}
+	err := bs.importStateSnapshot(data)
+	bs.worker.ReceivedSingle(sender, err == nil)
+	if err != nil {
+		return err
+	}
+	bs.buf.reset(bs.bc.TipHeight())
+	if bs.headers != nil {
+		bs.headers.reset(bs.bc.TipHeight(), bs.bc.TipHash())
+	}
+	bs.worker.SetFastSync(false)
+	return nil
+}
+
+// importStateSnapshot checks that the block of the snapshot is the checkpoint, and imports the snapshot, which is
+// verified against the state root of the checkpoint
+func (bs *blockSyncer) importStateSnapshot(data *pb.BlockContainer) error {
+	if data.Block == nil || data.Snapshot == nil {
+		return errors.Wrap(state.ErrInvalidSnapshot, "incomplete state snapshot")
+	}
+	// The block is deserialized to check its transactions against the root in its header
+	blkBytes, err := proto.Marshal(data.Block)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidHeader, "failed to marshal the block of the snapshot: %v", err)
+	}
+	blk := &blockchain.Block{}
+	if err := blk.Deserialize(blkBytes); err != nil {
+		return errors.Wrapf(ErrInvalidHeader, "failed to deserialize the block of the snapshot: %v", err)
+	}
+	if blk.Height() != bs.checkpointHeight || blk.HashBlock() != bs.checkpointHash {
+		return errors.Wrapf(ErrInvalidHeader, "block %d doesn't match the checkpoint", blk.Height())
+	}
+	return bs.bc.ImportStateSnapshot(blk, data.Snapshot)
+}
+
 // ProcessSyncRequest processes a block sync request
 func (bs *blockSyncer) ProcessSyncRequest(sender string, sync *pb.BlockSync) error {
 	if !bs.ackSyncReq {
@@ -136,15 +292,82 @@ func (bs *blockSyncer) ProcessSyncRequest(sender string, sync *pb.BlockSync) err
 		return nil
 	}
 
+	if sync.Snapshot {
+		return bs.sendStateSnapshot(sender, sync.Start)
+	}
+	if sync.HeadersOnly {
+		return bs.sendHeaders(sender, sync.Start, sync.End)
+	}
+	maxBlocks := bs.maxBlocksPerMsg
+	if maxBlocks == 0 {
+		maxBlocks = 1
+	}
+	var blocks []*pb.BlockPb
 	for i := sync.Start; i <= sync.End; i++ {
 		blk, err := bs.bc.GetBlockByHeight(i)
 		if err != nil {
 			return err
 		}
-		// TODO: send back multiple blocks in one shot
-		if err := bs.p2p.Tell(bs.bc.ChainID(), node.NewTCPNode(sender), &pb.BlockContainer{Block: blk.ConvertToBlockPb()}); err != nil {
-			logger.Warn().Err(err).Msg("Failed to response to ProcessSyncRequest.")
+		blocks = append(blocks, blk.ConvertToBlockPb())
+		if uint64(len(blocks)) == maxBlocks || i == sync.End {
+			bs.sendBlocks(sender, blocks)
+			blocks = nil
+		}
+	}
+	return nil
+}
+
+// sendBlocks sends back a batch of blocks in one message. A single block is sent in the block field, so that the peers
+// which don't know about the batch could still take it.
+func (bs *blockSyncer) sendBlocks(sender string, blocks []*pb.BlockPb) {
+	msg := &pb.BlockContainer{}
+	if len(blocks) == 1 {
+		msg.Block = blocks[0]
+	} else {
+		msg.Blocks = blocks
+	}
+	if err := bs.p2p.Tell(bs.bc.ChainID(), node.NewTCPNode(sender), msg); err != nil {
+		logger.Warn().Err(err).Msg("Failed to response to ProcessSyncRequest.")
+	}
+}
+
+// sendHeaders sends back the headers in the range in one message, up to the header batch size and the tip
+func (bs *blockSyncer) sendHeaders(sender string, start uint64, end uint64) error {
+	if tip := bs.bc.TipHeight(); end > tip {
+		end = tip
+	}
+	if bs.headerBatchSize > 0 && end >= start && end-start >= bs.headerBatchSize {
+		end = start + bs.headerBatchSize - 1
+	}
+	msg := &pb.BlockContainer{}
+	for i := start; i <= end; i++ {
+		blk, err := bs.bc.GetBlockByHeight(i)
+		if err != nil {
+			return err
 		}
+		msg.Headers = append(msg.Headers, blk.ConvertToBlockHeaderPb())
+	}
+	if len(msg.Headers) == 0 {
+		return nil
+	}
+	if err := bs.p2p.Tell(bs.bc.ChainID(), node.NewTCPNode(sender), msg); err != nil {
+		logger.Warn().Err(err).Msg("Failed to send block headers.")
+	}
+	return nil
+}
+
+// sendStateSnapshot sends back the state snapshot at the checkpoint, along with the block at the checkpoint
+func (bs *blockSyncer) sendStateSnapshot(sender string, checkpoint uint64) error {
+	if bs.bc.TipHeight() < checkpoint {
+		return nil
+	}
+	blk, snapshot, err := bs.bc.StateSnapshot(checkpoint)
+	if err != nil {
+		return err
+	}
+	msg := &pb.BlockContainer{Block: blk.ConvertToBlockPb(), Snapshot: snapshot}
+	if err := bs.p2p.Tell(bs.bc.ChainID(), node.NewTCPNode(sender), msg); err != nil {
+		logger.Warn().Err(err).Msg("Failed to send state snapshot.")
 	}
 	return nil
 }
@@ -8,11 +8,15 @@ package blocksync
 
 import (
 	"context"
+	"encoding/hex"
+	"net"
 	"testing"
 	"time"
 
 	"github.com/facebookgo/clock"
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -22,8 +26,10 @@ import (
 	"github.com/iotexproject/iotex-core/network"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blocksync"
+	"github.com/iotexproject/iotex-core/test/mock/mock_network"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
 )
@@ -63,10 +69,9 @@ func generateP2P() network.Overlay {
 	c := &config.Network{
 		Host: "127.0.0.1",
 		Port: 10001,
-		MsgLogsCleaningInterval: 2 * time.Second,
-		MsgLogRetention:         10 * time.Second,
 		HealthCheckInterval:     time.Second,
 		SilentInterval:          5 * time.Second,
+		SeenCacheSize:           10000,
 		PeerMaintainerInterval:  time.Second,
 		NumPeersLowerBound:      5,
 		NumPeersUpperBound:      5,
@@ -190,6 +195,46 @@ func TestBlockSyncerProcessSyncRequest(t *testing.T) {
 	assert.Nil(bs.ProcessSyncRequest("", pbBs))
 }
 
+func TestBlockSyncerProcessSyncRequestBatch(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mBc := mock_blockchain.NewMockBlockchain(ctrl)
+	mBc.EXPECT().ChainID().AnyTimes().Return(config.Default.Chain.ID)
+	mBc.EXPECT().GetBlockByHeight(gomock.Any()).AnyTimes().DoAndReturn(func(height uint64) (*bc.Block, error) {
+		return bc.NewBlock(config.Default.Chain.ID, height, hash.Hash32B{}, clock.New(), nil, nil, nil), nil
+	})
+	ap, err := actpool.NewActPool(mBc, config.Default.ActPool)
+	require.NoError(err)
+	var batches [][]uint64
+	p2p := mock_network.NewMockOverlay(ctrl)
+	p2p.EXPECT().Tell(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ uint32, _ net.Addr, msg proto.Message) error {
+			data := msg.(*pb.BlockContainer)
+			var heights []uint64
+			if data.Block != nil {
+				heights = append(heights, data.Block.Header.Height)
+			}
+			for _, blk := range data.Blocks {
+				heights = append(heights, blk.Header.Height)
+			}
+			batches = append(batches, heights)
+			return nil
+		}).Times(3)
+
+	cfg := config.Default
+	cfg.NodeType = config.FullNodeType
+	cfg.BlockSync.MaxBlocksPerMsg = 2
+	bs, err := NewBlockSyncer(&cfg, mBc, ap, p2p)
+	require.NoError(err)
+
+	// The last block which doesn't fill a batch is sent in the block field
+	require.NoError(bs.ProcessSyncRequest("127.0.0.1:10001", &pb.BlockSync{Start: 1, End: 5}))
+	require.Equal([][]uint64{{1, 2}, {3, 4}, {5}}, batches)
+}
+
 func TestBlockSyncerProcessSyncRequestError(t *testing.T) {
 	require := require.New(t)
 	ctx := context.Background()
@@ -370,9 +415,9 @@ func TestBlockSyncerProcessBlockSync(t *testing.T) {
 	h1 := chain1.TipHeight()
 	assert.Equal(t, uint64(3), h1)
 
-	require.Nil(bs2.ProcessBlockSync(blk3))
-	require.Nil(bs2.ProcessBlockSync(blk2))
-	require.Nil(bs2.ProcessBlockSync(blk1))
+	require.Nil(bs2.ProcessBlockSync("", blk3))
+	require.Nil(bs2.ProcessBlockSync("", blk2))
+	require.Nil(bs2.ProcessBlockSync("", blk1))
 	h2 := chain2.TipHeight()
 	assert.Equal(t, h1, h2)
 }
@@ -417,6 +462,104 @@ func TestBlockSyncerSync(t *testing.T) {
 	time.Sleep(time.Millisecond << 7)
 }
 
+func TestBlockSyncerHeaderFirstAndFastSync(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.Default
+	cfg.NodeType = config.FullNodeType
+	cfg.BlockSync.HeaderFirst = true
+	chain1 := bc.NewBlockchain(&cfg, bc.InMemStateFactoryOption(), bc.InMemDaoOption())
+	require.NoError(chain1.Start(ctx))
+	defer func() {
+		require.NoError(chain1.Stop(ctx))
+	}()
+	var blks []*bc.Block
+	for i := 0; i < 4; i++ {
+		blk, err := chain1.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
+		require.NoError(err)
+		require.NoError(chain1.CommitBlock(blk))
+		blks = append(blks, blk)
+	}
+	ap1, err := actpool.NewActPool(chain1, cfg.ActPool)
+	require.NoError(err)
+	var sent []*pb.BlockContainer
+	p2p := mock_network.NewMockOverlay(ctrl)
+	p2p.EXPECT().Tell(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ uint32, _ net.Addr, msg proto.Message) error {
+			sent = append(sent, msg.(*pb.BlockContainer))
+			return nil
+		}).AnyTimes()
+	bs1, err := NewBlockSyncer(&cfg, chain1, ap1, p2p)
+	require.NoError(err)
+
+	// The headers are sent back in one message up to the tip
+	require.NoError(bs1.ProcessSyncRequest("127.0.0.1:10001", &pb.BlockSync{Start: 1, End: 10, HeadersOnly: true}))
+	require.Len(sent, 1)
+	require.Len(sent[0].Headers, 4)
+	require.Nil(sent[0].Block)
+
+	// The node fast syncs from the checkpoint at height 2, and syncs the blocks after it
+	cfg.BlockSync.CheckpointHeight = 2
+	checkpointHash := blks[1].HashBlock()
+	cfg.BlockSync.CheckpointHash = hex.EncodeToString(checkpointHash[:])
+	chain2 := bc.NewBlockchain(&cfg, bc.InMemStateFactoryOption(), bc.InMemDaoOption())
+	require.NoError(chain2.Start(ctx))
+	defer func() {
+		require.NoError(chain2.Stop(ctx))
+	}()
+	ap2, err := actpool.NewActPool(chain2, cfg.ActPool)
+	require.NoError(err)
+	bs2, err := NewBlockSyncer(&cfg, chain2, ap2, p2p)
+	require.NoError(err)
+	require.NoError(bs1.ProcessSyncRequest("127.0.0.1:10002", &pb.BlockSync{Start: 2, Snapshot: true}))
+	require.Len(sent, 2)
+	snapshot := sent[1]
+	require.Equal(uint64(2), snapshot.Block.Header.Height)
+	require.Empty(snapshot.Headers)
+
+	// The snapshot whose block is not the checkpoint is rejected
+	tampered := proto.Clone(snapshot).(*pb.BlockContainer)
+	tampered.Block = blks[2].ConvertToBlockPb()
+	require.Equal(ErrInvalidHeader, errors.Cause(bs2.ProcessStateSnapshot("", tampered)))
+	require.Equal(uint64(0), chain2.TipHeight())
+
+	// The snapshot which doesn't match the state root of the checkpoint is rejected
+	_, tip, err := chain1.StateSnapshot(4)
+	require.NoError(err)
+	tampered = proto.Clone(snapshot).(*pb.BlockContainer)
+	tampered.Snapshot = tip
+	tampered.Snapshot.Height = 2
+	require.Equal(state.ErrInvalidSnapshot, errors.Cause(bs2.ProcessStateSnapshot("", tampered)))
+	require.Equal(uint64(0), chain2.TipHeight())
+
+	require.NoError(bs2.ProcessStateSnapshot("", snapshot))
+	require.Equal(uint64(2), chain2.TipHeight())
+	require.Equal(blks[1].HashBlock(), chain2.TipHash())
+
+	// The blocks after the checkpoint are verified against the headers
+	blk, err := chain1.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
+	require.NoError(err)
+	require.NoError(chain1.CommitBlock(blk))
+	require.NoError(bs1.ProcessSyncRequest("127.0.0.1:10002", &pb.BlockSync{Start: 3, End: 5, HeadersOnly: true}))
+	require.NoError(bs2.ProcessBlockHeaders("", sent[2].Headers))
+	other := bc.NewBlock(cfg.Chain.ID, 3, chain2.TipHash(), clock.New(), nil, nil, nil)
+	require.Equal(ErrInvalidHeader, errors.Cause(bs2.ProcessBlockSync("", other)))
+	for _, b := range []*bc.Block{blks[2], blks[3], blk} {
+		require.NoError(bs2.ProcessBlockSync("", b))
+	}
+	require.Equal(uint64(5), chain2.TipHeight())
+	require.Equal(chain1.TipHash(), chain2.TipHash())
+	balance1, err := chain1.Balance(ta.Addrinfo["producer"].RawAddress)
+	require.NoError(err)
+	balance2, err := chain2.Balance(ta.Addrinfo["producer"].RawAddress)
+	require.NoError(err)
+	require.Equal(balance1, balance2)
+}
+
 func newTestConfig() (*config.Config, error) {
 	cfg := config.Default
 	cfg.Chain.TrieDBPath = "trie.test"
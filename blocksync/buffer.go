@@ -9,6 +9,8 @@ package blocksync
 import (
 	"sync"
 
+	"github.com/pkg/errors"
+
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/logger"
@@ -32,6 +34,14 @@ type blockBuffer struct {
 	size            uint64
 	startHeight     uint64
 	confirmedHeight uint64
+	// validateCert validates the commit certificate of the block, and requireCert rejects the block without one even
+	// if the validator doesn't expect it
+	validateCert func(*blockchain.Block) error
+	requireCert  bool
+	// validateProducer validates that the block is produced by a delegate of its epoch
+	validateProducer func(*blockchain.Block) error
+	// onCommit is called with each block committed from the buffer
+	onCommit func(*blockchain.Block)
 }
 
 // Flush tries to put given block into buffer and flush buffer into blockchain.
@@ -55,7 +65,7 @@ func (b *blockBuffer) Flush(blk *blockchain.Block) (bool, bCheckinResult) {
 		if blk.IsDummyBlock() {
 			return moved, bCheckinLower
 		}
-		if err := commitBlock(b.bc, b.ap, blk); err != nil {
+		if err := b.commitBlock(blk); err != nil {
 			return moved, bCheckinLower
 		}
 
@@ -76,7 +86,7 @@ func (b *blockBuffer) Flush(blk *blockchain.Block) (bool, bCheckinResult) {
 		if b.blocks[syncHeight] == nil {
 			continue
 		}
-		if err := commitBlock(b.bc, b.ap, b.blocks[syncHeight]); err == nil {
+		if err := b.commitBlock(b.blocks[syncHeight]); err == nil {
 			syncedHeight = syncHeight
 			if !b.blocks[syncedHeight].IsDummyBlock() {
 				b.confirmedHeight = syncedHeight
@@ -134,6 +144,19 @@ func (b *blockBuffer) Flush(blk *blockchain.Block) (bool, bCheckinResult) {
 	return moved, bCheckinValid
 }
 
+// reset restarts the buffer above the given confirmed height, and drops the blocks below it
+func (b *blockBuffer) reset(confirmedHeight uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.confirmedHeight = confirmedHeight
+	b.startHeight = confirmedHeight + 1
+	for h := range b.blocks {
+		if h < b.startHeight {
+			delete(b.blocks, h)
+		}
+	}
+}
+
 // GetBlocksIntervalsToSync returns groups of syncBlocksInterval are missing upto targetHeight.
 func (b *blockBuffer) GetBlocksIntervalsToSync(targetHeight uint64) []syncBlocksInterval {
 	var (
@@ -176,3 +199,59 @@ func (b *blockBuffer) GetBlocksIntervalsToSync(targetHeight uint64) []syncBlocks
 	}
 	return bi
 }
+
+// commitBlock verifies the commit certificate and the producer of the block before committing it into blockchain
+func (b *blockBuffer) commitBlock(blk *blockchain.Block) error {
+	if err := b.verifyCertificate(blk); err != nil {
+		return err
+	}
+	if err := b.verifyProducer(blk); err != nil {
+		return err
+	}
+	if err := commitBlock(b.bc, b.ap, blk); err != nil {
+		return err
+	}
+	if b.onCommit != nil {
+		b.onCommit(blk)
+	}
+	return nil
+}
+
+// verifyCertificate verifies the commit certificate of the block. A synced dummy block is rejected, since nothing
+// proves that the delegates have agreed on it. The validator is called even if the block doesn't have a certificate,
+// so that it rejects the block of which the delegates are able to certify.
+func (b *blockBuffer) verifyCertificate(blk *blockchain.Block) error {
+	if blk.IsDummyBlock() {
+		return errors.Wrapf(blockchain.ErrInvalidCertificate, "block %d is a dummy block", blk.Height())
+	}
+	if blk.Certificate == nil && b.requireCert {
+		return errors.Wrapf(blockchain.ErrInvalidCertificate, "block %d does not have a commit certificate", blk.Height())
+	}
+	if b.validateCert == nil {
+		if b.requireCert {
+			return errors.Wrapf(
+				blockchain.ErrInvalidCertificate,
+				"no validator to verify the commit certificate of block %d",
+				blk.Height(),
+			)
+		}
+		return nil
+	}
+	if err := b.validateCert(blk); err != nil {
+		logger.Warn().Err(err).Uint64("height", blk.Height()).Msg("Failed to verify the commit certificate")
+		return err
+	}
+	return nil
+}
+
+// verifyProducer verifies that the block is produced by a delegate of its epoch
+func (b *blockBuffer) verifyProducer(blk *blockchain.Block) error {
+	if b.validateProducer == nil {
+		return nil
+	}
+	if err := b.validateProducer(blk); err != nil {
+		logger.Warn().Err(err).Uint64("height", blk.Height()).Msg("Failed to verify the block producer")
+		return err
+	}
+	return nil
+}
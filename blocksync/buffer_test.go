@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -42,6 +43,7 @@ func TestBlockBufferFlush(t *testing.T) {
 		testutil.CleanupPath(t, cfg.Chain.TrieDBPath)
 	}()
 
+	var committed []*blockchain.Block
 	b := blockBuffer{
 		bc:              chain,
 		ap:              ap,
@@ -49,6 +51,7 @@ func TestBlockBufferFlush(t *testing.T) {
 		size:            16,
 		startHeight:     1,
 		confirmedHeight: 0,
+		onCommit:        func(blk *blockchain.Block) { committed = append(committed, blk) },
 	}
 
 	blk, err := chain.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
@@ -56,6 +59,7 @@ func TestBlockBufferFlush(t *testing.T) {
 	moved, re := b.Flush(blk)
 	assert.Equal(true, moved)
 	assert.Equal(bCheckinValid, re)
+	assert.Equal([]*blockchain.Block{blk}, committed)
 
 	blk = blockchain.NewBlock(uint32(123), uint64(0), hash.Hash32B{}, clock.New(), nil, nil, nil)
 	moved, re = b.Flush(blk)
@@ -78,6 +82,81 @@ func TestBlockBufferFlush(t *testing.T) {
 	assert.Equal(bCheckinHigher, re)
 }
 
+func TestBlockBufferFlushWithCertificate(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg, err := newTestConfig()
+	require.Nil(err)
+	testutil.CleanupPath(t, cfg.Chain.ChainDBPath)
+	testutil.CleanupPath(t, cfg.Chain.TrieDBPath)
+
+	chain := blockchain.NewBlockchain(cfg, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(chain.Start(ctx))
+	require.NotNil(chain)
+	ap, err := actpool.NewActPool(chain, cfg.ActPool)
+	require.NotNil(ap)
+	require.Nil(err)
+	defer func() {
+		require.Nil(chain.Stop(ctx))
+		testutil.CleanupPath(t, cfg.Chain.ChainDBPath)
+		testutil.CleanupPath(t, cfg.Chain.TrieDBPath)
+	}()
+
+	certValid := false
+	b := blockBuffer{
+		bc:              chain,
+		ap:              ap,
+		blocks:          make(map[uint64]*blockchain.Block),
+		size:            16,
+		startHeight:     1,
+		confirmedHeight: 0,
+		requireCert:     true,
+		validateCert: func(blk *blockchain.Block) error {
+			if !certValid {
+				return errors.New("invalid certificate")
+			}
+			return nil
+		},
+	}
+
+	// The block without a certificate is rejected
+	blk, err := chain.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
+	require.Nil(err)
+	require.Error(b.commitBlock(blk))
+	require.Equal(uint64(0), chain.TipHeight())
+
+	// The block with an invalid certificate is rejected
+	blk.Certificate = &blockchain.CommitCertificate{Height: blk.Height(), BlockHash: blk.HashBlock()}
+	require.Error(b.commitBlock(blk))
+	require.Equal(uint64(0), chain.TipHeight())
+
+	// The block with a valid certificate is committed
+	certValid = true
+	moved, re := b.Flush(blk)
+	require.True(moved)
+	require.Equal(bCheckinValid, re)
+	require.Equal(uint64(1), chain.TipHeight())
+
+	// The dummy block is rejected even with a valid certificate
+	dummy := chain.MintNewDummyBlock()
+	dummy.Certificate = &blockchain.CommitCertificate{Height: dummy.Height(), BlockHash: dummy.HashBlock()}
+	require.Error(b.commitBlock(dummy))
+	require.Equal(uint64(1), chain.TipHeight())
+
+	// The block without a certificate is rejected by the validator expecting one, even if it isn't required
+	b.requireCert = false
+	b.validateCert = func(blk *blockchain.Block) error {
+		if blk.Certificate == nil {
+			return errors.New("certificate is expected")
+		}
+		return nil
+	}
+	blk, err = chain.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
+	require.Nil(err)
+	require.Error(b.commitBlock(blk))
+	require.Equal(uint64(1), chain.TipHeight())
+}
+
 func TestBlockBufferGetBlocksIntervalsToSync(t *testing.T) {
 	require := require.New(t)
 	assert := assert.New(t)
@@ -0,0 +1,157 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	pb "github.com/iotexproject/iotex-core/proto"
+)
+
+// ErrInvalidHeader indicates that the block header doesn't link to the previous one or isn't signed by its producer
+var ErrInvalidHeader = errors.New("invalid block header")
+
+// headerChain keeps the verified headers above the tip of the blockchain, whose bodies are to be downloaded. A header
+// is a block without the body.
+type headerChain struct {
+	mu      sync.RWMutex
+	headers map[uint64]*blockchain.Block
+	height  uint64
+	hash    hash.Hash32B
+	// validateProducer validates that the header is produced by a delegate of its epoch
+	validateProducer func(*blockchain.Block) error
+}
+
+func newHeaderChain() *headerChain {
+	return &headerChain{headers: make(map[uint64]*blockchain.Block)}
+}
+
+// Height returns the height of the last verified header
+func (hc *headerChain) Height() uint64 {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	return hc.height
+}
+
+// reset drops all the headers, and restarts the header chain from the given block
+func (hc *headerChain) reset(height uint64, hash hash.Hash32B) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.headers = make(map[uint64]*blockchain.Block)
+	hc.height = height
+	hc.hash = hash
+}
+
+// add verifies the headers following the last verified one, and returns the number of headers added. The headers
+// which have been verified already are skipped, and the ones whose producer can't be validated yet are held back until
+// the delegates of their epoch are known.
+func (hc *headerChain) add(headers []*blockchain.Block) (int, error) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	sort.Slice(headers, func(i, j int) bool { return headers[i].Height() < headers[j].Height() })
+	added := 0
+	for _, header := range headers {
+		if header.Height() <= hc.height {
+			continue
+		}
+		if header.Height() != hc.height+1 {
+			return added, errors.Wrapf(ErrInvalidHeader, "header %d doesn't follow %d", header.Height(), hc.height)
+		}
+		if err := verifyHeader(header, hc.hash); err != nil {
+			return added, err
+		}
+		if hc.validateProducer != nil {
+			err := hc.validateProducer(header)
+			if errors.Cause(err) == blockchain.ErrInvalidProducer {
+				return added, errors.Wrapf(ErrInvalidHeader, "header %d: %v", header.Height(), err)
+			}
+			if err != nil {
+				logger.Debug().Err(err).Uint64("height", header.Height()).Msg("Held back the block header.")
+				break
+			}
+		}
+		hc.headers[header.Height()] = header
+		hc.height = header.Height()
+		hc.hash = header.HashBlock()
+		added++
+	}
+	return added, nil
+}
+
+// verify checks that the block matches the verified header at its height, if any
+func (hc *headerChain) verify(blk *blockchain.Block) error {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+
+	header, ok := hc.headers[blk.Height()]
+	if !ok {
+		return nil
+	}
+	if blkHash, headerHash := blk.HashBlock(), header.HashBlock(); blkHash != headerHash {
+		return errors.Wrapf(
+			ErrInvalidHeader,
+			"block %d hash %x doesn't match the verified header %x",
+			blk.Height(),
+			blkHash,
+			headerHash,
+		)
+	}
+	return nil
+}
+
+// prune drops the headers up to the tip of the blockchain. The header chain restarts from the tip if the tip is not on
+// it, or if it has gone beyond the verified headers.
+func (hc *headerChain) prune(tipHeight uint64, tipHash hash.Hash32B) {
+	hc.mu.Lock()
+	header, ok := hc.headers[tipHeight]
+	if tipHeight >= hc.height || (ok && header.HashBlock() != tipHash) {
+		hc.mu.Unlock()
+		hc.reset(tipHeight, tipHash)
+		return
+	}
+	defer hc.mu.Unlock()
+	for height := range hc.headers {
+		if height <= tipHeight {
+			delete(hc.headers, height)
+		}
+	}
+}
+
+// verifyHeader checks that the header links to the previous hash, and is signed by its producer unless it is a dummy
+func verifyHeader(header *blockchain.Block, prevHash hash.Hash32B) error {
+	if header.PrevHash() != prevHash {
+		return errors.Wrapf(
+			ErrInvalidHeader,
+			"header %d prev hash %x doesn't match %x",
+			header.Height(),
+			header.PrevHash(),
+			prevHash,
+		)
+	}
+	if !header.IsDummyBlock() && !header.VerifySignature() {
+		return errors.Wrapf(ErrInvalidHeader, "header %d has an invalid signature", header.Height())
+	}
+	return nil
+}
+
+// convertFromHeaderPbs converts the header messages into the blocks without bodies
+func convertFromHeaderPbs(headerPbs []*pb.BlockHeaderPb) []*blockchain.Block {
+	headers := make([]*blockchain.Block, 0, len(headerPbs))
+	for _, headerPb := range headerPbs {
+		header := &blockchain.Block{}
+		header.ConvertFromBlockHeaderPb(&pb.BlockPb{Header: headerPb})
+		headers = append(headers, header)
+	}
+	return headers
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	bc "github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	pb "github.com/iotexproject/iotex-core/proto"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestHeaderChain(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	chain := bc.NewBlockchain(&cfg, bc.InMemStateFactoryOption(), bc.InMemDaoOption())
+	require.NoError(chain.Start(ctx))
+	defer func() {
+		require.NoError(chain.Stop(ctx))
+	}()
+	var blks []*bc.Block
+	for i := 0; i < 3; i++ {
+		blk, err := chain.MintNewBlock(nil, nil, nil, ta.Addrinfo["producer"], "")
+		require.NoError(err)
+		require.NoError(chain.CommitBlock(blk))
+		blks = append(blks, blk)
+	}
+	var headerPbs []*pb.BlockHeaderPb
+	for _, blk := range blks {
+		headerPbs = append(headerPbs, blk.ConvertToBlockHeaderPb())
+	}
+	genesis, err := chain.GetBlockByHeight(0)
+	require.NoError(err)
+
+	// The headers out of order are verified against the genesis block
+	hc := newHeaderChain()
+	hc.reset(0, genesis.HashBlock())
+	added, err := hc.add(convertFromHeaderPbs([]*pb.BlockHeaderPb{headerPbs[1], headerPbs[0]}))
+	require.NoError(err)
+	require.Equal(2, added)
+	require.Equal(uint64(2), hc.Height())
+
+	// The verified headers are skipped, and the gap is rejected
+	added, err = hc.add(convertFromHeaderPbs(headerPbs))
+	require.NoError(err)
+	require.Equal(1, added)
+	hc.reset(0, genesis.HashBlock())
+	_, err = hc.add(convertFromHeaderPbs(headerPbs[1:]))
+	require.Equal(ErrInvalidHeader, errors.Cause(err))
+
+	// The header which doesn't link to the last one, or whose signature is tampered, is rejected
+	hc.reset(0, hash.ZeroHash32B)
+	_, err = hc.add(convertFromHeaderPbs(headerPbs))
+	require.Equal(ErrInvalidHeader, errors.Cause(err))
+	tampered := *headerPbs[0]
+	tampered.Signature = append([]byte{}, tampered.Signature...)
+	tampered.Signature[0]++
+	hc.reset(0, genesis.HashBlock())
+	_, err = hc.add(convertFromHeaderPbs([]*pb.BlockHeaderPb{&tampered}))
+	require.Equal(ErrInvalidHeader, errors.Cause(err))
+	require.Equal(uint64(0), hc.Height())
+
+	// The block is verified against the header at its height
+	_, err = hc.add(convertFromHeaderPbs(headerPbs))
+	require.NoError(err)
+	require.NoError(hc.verify(blks[0]))
+	other := bc.NewBlock(cfg.Chain.ID, 1, genesis.HashBlock(), clock.New(), nil, nil, nil)
+	require.Equal(ErrInvalidHeader, errors.Cause(hc.verify(other)))
+
+	// The headers up to the tip are pruned, and the header chain restarts from the tip which goes beyond it
+	hc.prune(1, blks[0].HashBlock())
+	require.Equal(uint64(3), hc.Height())
+	require.NoError(hc.verify(other))
+	hc.prune(3, blks[2].HashBlock())
+	require.Empty(hc.headers)
+	require.Equal(uint64(3), hc.Height())
+
+	// The header whose producer is not a delegate is rejected, and the ones whose producer can't be validated yet are
+	// held back
+	hc.reset(0, genesis.HashBlock())
+	hc.validateProducer = func(header *bc.Block) error {
+		if header.Height() == 2 {
+			return errors.Wrap(bc.ErrInvalidProducer, "not a delegate")
+		}
+		return nil
+	}
+	added, err = hc.add(convertFromHeaderPbs(headerPbs))
+	require.Equal(ErrInvalidHeader, errors.Cause(err))
+	require.Equal(1, added)
+	hc.validateProducer = func(header *bc.Block) error {
+		if header.Height() == 3 {
+			return errors.New("unknown delegates")
+		}
+		return nil
+	}
+	added, err = hc.add(convertFromHeaderPbs(headerPbs))
+	require.NoError(err)
+	require.Equal(1, added)
+	require.Equal(uint64(2), hc.Height())
+}
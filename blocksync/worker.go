@@ -8,8 +8,13 @@ package blocksync
 
 import (
 	"context"
+	"math"
 	"net"
+	"sort"
 	"sync"
+	"time"
+
+	"github.com/facebookgo/clock"
 
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/logger"
@@ -23,23 +28,82 @@ type syncBlocksInterval struct {
 	End   uint64
 }
 
+// syncRequest is a range of blocks requested from a peer, which is shared by all the heights in the range
+type syncRequest struct {
+	peer    string
+	sentAt  time.Time
+	pending uint64
+	expired bool
+}
+
+// peerStats records how many blocks a peer has sent back and how long it has taken
+type peerStats struct {
+	blocks  uint64
+	elapsed time.Duration
+}
+
+// throughput returns the number of blocks per second sent back by the peer. A peer which hasn't been asked yet has the
+// highest throughput, so that every peer gets a chance.
+func (s *peerStats) throughput() float64 {
+	if s == nil || s.elapsed == 0 {
+		return math.Inf(1)
+	}
+	return float64(s.blocks) / s.elapsed.Seconds()
+}
+
 type syncWorker struct {
 	chainID      uint32
 	mu           sync.RWMutex
 	targetHeight uint64
 	p2p          network.Overlay
-	rrIdx        int
 	buf          *blockBuffer
 	task         *routine.RecurringTask
+	clock        clock.Clock
+	chunkSize    uint64
+	timeout      time.Duration
+	// requests are the in-flight sync requests by height
+	requests map[uint64]*syncRequest
+	// peers are the stats of the peers by address
+	peers map[string]*peerStats
+	// failed are the peers which haven't sent back the block in time by height
+	failed map[uint64]string
+	// headers are the verified headers whose bodies are downloaded, which is nil unless header-first sync is enabled
+	headers         *headerChain
+	headerBatchSize uint64
+	// fastSync requests the state snapshot at the checkpoint instead of the blocks
+	fastSync   bool
+	checkpoint uint64
+	// single is the in-flight request of the headers or the state snapshot, which is sent to one peer at a time
+	single *syncRequest
 }
 
-func newSyncWorker(chainID uint32, cfg *config.Config, p2p network.Overlay, buf *blockBuffer) *syncWorker {
+func newSyncWorker(
+	chainID uint32,
+	cfg *config.Config,
+	p2p network.Overlay,
+	buf *blockBuffer,
+	headers *headerChain,
+) *syncWorker {
 	w := &syncWorker{
-		chainID:      chainID,
-		p2p:          p2p,
-		buf:          buf,
-		targetHeight: 0,
-		rrIdx:        0,
+		chainID:         chainID,
+		p2p:             p2p,
+		buf:             buf,
+		targetHeight:    0,
+		clock:           clock.New(),
+		chunkSize:       cfg.BlockSync.ChunkSize,
+		timeout:         cfg.BlockSync.RequestTimeout,
+		requests:        make(map[uint64]*syncRequest),
+		peers:           make(map[string]*peerStats),
+		failed:          make(map[uint64]string),
+		headers:         headers,
+		headerBatchSize: cfg.BlockSync.HeaderBatchSize,
+		checkpoint:      cfg.BlockSync.CheckpointHeight,
+	}
+	if w.chunkSize == 0 {
+		w.chunkSize = math.MaxUint64
+	}
+	if w.timeout == 0 {
+		w.timeout = cfg.BlockSync.Interval
 	}
 	if interval := syncTaskInterval(cfg); interval != 0 {
 		w.task = routine.NewRecurringTask(w.Sync, cfg.BlockSync.Interval)
@@ -69,7 +133,17 @@ func (w *syncWorker) SetTargetHeight(h uint64) {
 	}
 }
 
-// Sync checks the sliding window and send more sync request if needed
+// SetFastSync turns on or off fast sync, which requests the state snapshot at the checkpoint instead of the blocks
+func (w *syncWorker) SetFastSync(enabled bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.fastSync = enabled
+	w.single = nil
+}
+
+// Sync checks the sliding window, splits the missing blocks which haven't been requested into chunks, and requests
+// the chunks from the peers at once, starting from the fastest one. With header-first sync, the next batch of headers
+// is requested as well, and only the blocks of the verified headers are requested.
 func (w *syncWorker) Sync() {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -79,20 +153,199 @@ func (w *syncWorker) Sync() {
 		logger.Info().Msg("No peer exist to sync with.")
 		return
 	}
-	intervals := w.buf.GetBlocksIntervalsToSync(w.targetHeight)
-	logger.Info().Interface("intervals", intervals).Uint64("targetHeight", w.targetHeight).Msg("block sync intervals.")
+	if w.fastSync {
+		w.requestSingle(w.rankPeers(peers), &pb.BlockSync{Start: w.checkpoint, Snapshot: true})
+		return
+	}
+	targetHeight := w.targetHeight
+	if w.headers != nil {
+		w.headers.prune(w.buf.bc.TipHeight(), w.buf.bc.TipHash())
+		if h := w.headers.Height(); h < targetHeight {
+			targetHeight = h
+		}
+	}
+	intervals := w.buf.GetBlocksIntervalsToSync(targetHeight)
+	logger.Info().Interface("intervals", intervals).Uint64("targetHeight", targetHeight).Msg("block sync intervals.")
+
+	missing := make(map[uint64]bool)
+	for _, interval := range intervals {
+		for h := interval.Start; h <= interval.End; h++ {
+			missing[h] = true
+		}
+	}
+	w.expireRequests(missing)
+	for h := range w.failed {
+		if !missing[h] {
+			delete(w.failed, h)
+		}
+	}
+
+	ranked := w.rankPeers(peers)
+	if w.headers != nil {
+		w.requestHeaders(ranked)
+	}
+	for i, chunk := range w.splitIntervals(intervals) {
+		// The chunk is not requested again from the peer which has failed it, unless it is the only peer
+		for j := 0; j < len(ranked); j++ {
+			p := ranked[(i+j)%len(ranked)]
+			if len(ranked) > 1 && w.failed[chunk.Start] == p.String() {
+				continue
+			}
+			if err := w.sync(p, chunk); err != nil {
+				logger.Warn().Err(err).Str("peer", p.String()).Msg("Failed to sync block.")
+				w.stats(p.String()).elapsed += w.timeout
+				continue
+			}
+			break
+		}
+	}
+}
+
+// Received marks the block at the given height as received from the sender. The peer which the block was requested from
+// is credited with the block only if it is the sender, so that a peer can't earn the credit of the blocks sent by others.
+func (w *syncWorker) Received(sender string, height uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.failed, height)
+	req, ok := w.requests[height]
+	if !ok {
+		return
+	}
+	delete(w.requests, height)
+	stats := w.stats(req.peer)
+	if req.peer == sender {
+		stats.blocks++
+	}
+	req.pending--
+	if req.pending == 0 && !req.expired {
+		stats.elapsed += w.clock.Now().Sub(req.sentAt)
+	}
+}
+
+// ReceivedSingle marks the headers or the state snapshot as received from the sender. The peer is charged the whole
+// timeout if they are invalid. The request stays in flight if the sender is not the peer which it was sent to.
+func (w *syncWorker) ReceivedSingle(sender string, valid bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.single == nil || w.single.peer != sender {
+		return
+	}
+	if !valid {
+		w.stats(w.single.peer).elapsed += w.timeout
+	}
+	w.single = nil
+}
+
+// requestHeaders requests the next batch of headers following the verified ones, up to the target height
+func (w *syncWorker) requestHeaders(ranked []net.Addr) {
+	start := w.headers.Height() + 1
+	if start > w.targetHeight {
+		return
+	}
+	end := w.targetHeight
+	if w.headerBatchSize > 0 && end-start >= w.headerBatchSize {
+		end = start + w.headerBatchSize - 1
+	}
+	w.requestSingle(ranked, &pb.BlockSync{Start: start, End: end, HeadersOnly: true})
+}
+
+// requestSingle sends the request to the fastest peer, unless the last one is still in flight. The peer of the last
+// request is charged the whole timeout and skipped, if it has timed out.
+func (w *syncWorker) requestSingle(ranked []net.Addr, msg *pb.BlockSync) {
+	now := w.clock.Now()
+	last := w.single
+	if last != nil {
+		if now.Sub(last.sentAt) < w.timeout {
+			return
+		}
+		w.stats(last.peer).elapsed += w.timeout
+		w.single = nil
+	}
+	for _, p := range ranked {
+		if last != nil && len(ranked) > 1 && last.peer == p.String() {
+			continue
+		}
+		if err := w.p2p.Tell(w.chainID, p, msg); err != nil {
+			logger.Warn().Err(err).Str("peer", p.String()).Msg("Failed to sync.")
+			w.stats(p.String()).elapsed += w.timeout
+			continue
+		}
+		w.single = &syncRequest{peer: p.String(), sentAt: now}
+		return
+	}
+}
+
+// expireRequests drops the requests which are no longer needed, and the ones which have timed out. The peer of a timed
+// out request is charged the whole timeout, once per request.
+func (w *syncWorker) expireRequests(missing map[uint64]bool) {
+	now := w.clock.Now()
+	for h, req := range w.requests {
+		if !missing[h] {
+			delete(w.requests, h)
+			continue
+		}
+		if now.Sub(req.sentAt) < w.timeout {
+			continue
+		}
+		if !req.expired {
+			req.expired = true
+			w.stats(req.peer).elapsed += w.timeout
+		}
+		w.failed[h] = req.peer
+		delete(w.requests, h)
+	}
+}
+
+// splitIntervals splits the heights of the intervals which haven't been requested into consecutive chunks of at most
+// the chunk size
+func (w *syncWorker) splitIntervals(intervals []syncBlocksInterval) []syncBlocksInterval {
+	var chunks []syncBlocksInterval
 	for _, interval := range intervals {
-		w.rrIdx = w.rrIdx % len(peers)
-		p := peers[w.rrIdx]
-		if err := w.sync(p, interval); err != nil {
-			logger.Warn().Err(err).Msg("Failed to sync block.")
+		for h := interval.Start; h <= interval.End; h++ {
+			if _, ok := w.requests[h]; ok {
+				continue
+			}
+			last := len(chunks) - 1
+			if last >= 0 && chunks[last].End+1 == h && h-chunks[last].Start < w.chunkSize {
+				chunks[last].End = h
+				continue
+			}
+			chunks = append(chunks, syncBlocksInterval{Start: h, End: h})
 		}
-		w.rrIdx++
 	}
+	return chunks
+}
+
+// rankPeers sorts the peers by the throughput in descending order
+func (w *syncWorker) rankPeers(peers []net.Addr) []net.Addr {
+	ranked := make([]net.Addr, len(peers))
+	copy(ranked, peers)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return w.peers[ranked[i].String()].throughput() > w.peers[ranked[j].String()].throughput()
+	})
+	return ranked
+}
+
+func (w *syncWorker) stats(peer string) *peerStats {
+	stats, ok := w.peers[peer]
+	if !ok {
+		stats = &peerStats{}
+		w.peers[peer] = stats
+	}
+	return stats
 }
 
 func (w *syncWorker) sync(p net.Addr, interval syncBlocksInterval) error {
-	return w.p2p.Tell(w.chainID, p, &pb.BlockSync{
+	if err := w.p2p.Tell(w.chainID, p, &pb.BlockSync{
 		Start: interval.Start, End: interval.End,
-	})
+	}); err != nil {
+		return err
+	}
+	req := &syncRequest{peer: p.String(), sentAt: w.clock.Now(), pending: interval.End - interval.Start + 1}
+	for h := interval.Start; h <= interval.End; h++ {
+		w.requests[h] = req
+	}
+	return nil
 }
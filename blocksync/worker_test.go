@@ -0,0 +1,239 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	bc "github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/network/node"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_network"
+)
+
+func TestSyncWorkerParallelSync(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	peers := []net.Addr{
+		node.NewTCPNode("127.0.0.1:10001"),
+		node.NewTCPNode("127.0.0.1:10002"),
+		node.NewTCPNode("127.0.0.1:10003"),
+	}
+	requested := make(map[string][]syncBlocksInterval)
+	p2p := mock_network.NewMockOverlay(ctrl)
+	p2p.EXPECT().GetPeers().Return(peers).AnyTimes()
+	p2p.EXPECT().Tell(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ uint32, peer net.Addr, msg proto.Message) error {
+			sync := msg.(*pb.BlockSync)
+			requested[peer.String()] = append(requested[peer.String()], syncBlocksInterval{sync.Start, sync.End})
+			return nil
+		}).AnyTimes()
+
+	cfg := config.Default
+	cfg.BlockSync.ChunkSize = 4
+	cfg.BlockSync.RequestTimeout = 5 * time.Second
+	buf := &blockBuffer{blocks: make(map[uint64]*bc.Block), size: 16, startHeight: 1}
+	w := newSyncWorker(config.Default.Chain.ID, &cfg, p2p, buf, nil)
+	clk := clock.NewMock()
+	w.clock = clk
+	w.SetTargetHeight(10)
+
+	// The missing blocks are split into chunks which are requested from all the peers at once
+	w.Sync()
+	require.Equal(map[string][]syncBlocksInterval{
+		peers[0].String(): {{1, 4}},
+		peers[1].String(): {{5, 8}},
+		peers[2].String(): {{9, 10}},
+	}, requested)
+
+	// The blocks in flight are not requested again
+	w.Sync()
+	require.Len(requested[peers[0].String()], 1)
+	require.Len(requested[peers[1].String()], 1)
+	require.Len(requested[peers[2].String()], 1)
+
+	// The second peer sends back 4 blocks in 1 second, and the third one sends back 2 blocks in 2 seconds
+	clk.Add(time.Second)
+	for h := uint64(5); h <= 8; h++ {
+		buf.blocks[h] = &bc.Block{}
+		w.Received(peers[1].String(), h)
+	}
+	clk.Add(time.Second)
+	for h := uint64(9); h <= 10; h++ {
+		buf.blocks[h] = &bc.Block{}
+		w.Received(peers[2].String(), h)
+	}
+	require.Equal(4.0, w.peers[peers[1].String()].throughput())
+	require.Equal(1.0, w.peers[peers[2].String()].throughput())
+
+	// The first peer times out, and its chunk is requested from the fastest peer
+	clk.Add(cfg.BlockSync.RequestTimeout)
+	w.Sync()
+	require.Equal(0.0, w.peers[peers[0].String()].throughput())
+	require.Len(requested[peers[0].String()], 1)
+	require.Equal([]syncBlocksInterval{{5, 8}, {1, 4}}, requested[peers[1].String()])
+	require.Equal(map[uint64]string{1: peers[0].String(), 2: peers[0].String(), 3: peers[0].String(),
+		4: peers[0].String()}, w.failed)
+
+	// The blocks sent by a peer which wasn't asked for them are no longer in flight, but aren't credited to anyone
+	buf.blocks[1] = &bc.Block{}
+	w.Received(peers[2].String(), 1)
+	require.NotContains(w.requests, uint64(1))
+	require.Equal(uint64(4), w.peers[peers[1].String()].blocks)
+	require.Equal(uint64(2), w.peers[peers[2].String()].blocks)
+
+	// The received blocks are no longer in flight
+	for h := uint64(2); h <= 4; h++ {
+		buf.blocks[h] = &bc.Block{}
+		w.Received(peers[1].String(), h)
+	}
+	require.Empty(w.requests)
+	require.Empty(w.failed)
+	require.Equal(uint64(7), w.peers[peers[1].String()].blocks)
+}
+
+func TestSyncWorkerRetryOnTellError(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	peers := []net.Addr{node.NewTCPNode("127.0.0.1:10001"), node.NewTCPNode("127.0.0.1:10002")}
+	p2p := mock_network.NewMockOverlay(ctrl)
+	p2p.EXPECT().GetPeers().Return(peers).AnyTimes()
+	p2p.EXPECT().Tell(gomock.Any(), peers[0], gomock.Any()).Return(errors.New("connection refused")).Times(1)
+	p2p.EXPECT().Tell(gomock.Any(), peers[1], gomock.Any()).Return(nil).Times(1)
+
+	cfg := config.Default
+	cfg.BlockSync.ChunkSize = 0
+	buf := &blockBuffer{blocks: make(map[uint64]*bc.Block), size: 16, startHeight: 1}
+	w := newSyncWorker(config.Default.Chain.ID, &cfg, p2p, buf, nil)
+	w.SetTargetHeight(3)
+
+	// The whole range is requested from the other peer, and the failed peer is ranked last
+	w.Sync()
+	require.Len(w.requests, 3)
+	require.Equal(peers[1].String(), w.requests[1].peer)
+	require.Equal(uint64(3), w.requests[1].pending)
+	require.Equal([]net.Addr{peers[1], peers[0]}, w.rankPeers(peers))
+}
+
+func TestSyncWorkerHeaderFirst(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	peers := []net.Addr{node.NewTCPNode("127.0.0.1:10001"), node.NewTCPNode("127.0.0.1:10002")}
+	var requested []*pb.BlockSync
+	p2p := mock_network.NewMockOverlay(ctrl)
+	p2p.EXPECT().GetPeers().Return(peers).AnyTimes()
+	p2p.EXPECT().Tell(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ uint32, _ net.Addr, msg proto.Message) error {
+			requested = append(requested, msg.(*pb.BlockSync))
+			return nil
+		}).AnyTimes()
+	mBc := mock_blockchain.NewMockBlockchain(ctrl)
+	mBc.EXPECT().TipHeight().Return(uint64(0)).AnyTimes()
+	mBc.EXPECT().TipHash().Return(hash.ZeroHash32B).AnyTimes()
+
+	cfg := config.Default
+	cfg.BlockSync.ChunkSize = 0
+	cfg.BlockSync.HeaderBatchSize = 4
+	cfg.BlockSync.RequestTimeout = 5 * time.Second
+	buf := &blockBuffer{blocks: make(map[uint64]*bc.Block), bc: mBc, size: 16, startHeight: 1}
+	headers := newHeaderChain()
+	w := newSyncWorker(config.Default.Chain.ID, &cfg, p2p, buf, headers)
+	clk := clock.NewMock()
+	w.clock = clk
+	w.SetTargetHeight(10)
+
+	// Only the first batch of headers is requested, because no header has been verified yet
+	w.Sync()
+	require.Equal([]*pb.BlockSync{{Start: 1, End: 4, HeadersOnly: true}}, requested)
+
+	// The headers in flight are not requested again, and only the blocks of the verified headers are requested
+	headers.height = 4
+	requested = nil
+	w.Sync()
+	require.Equal([]*pb.BlockSync{{Start: 1, End: 4}}, requested)
+
+	// The headers sent by a peer which wasn't asked for them don't complete the request
+	peer := w.single.peer
+	other := peers[0].String()
+	if other == peer {
+		other = peers[1].String()
+	}
+	w.ReceivedSingle(other, true)
+	require.NotNil(w.single)
+
+	// The next batch is requested once the headers have been received
+	w.ReceivedSingle(peer, true)
+	requested = nil
+	w.Sync()
+	require.Equal([]*pb.BlockSync{{Start: 5, End: 8, HeadersOnly: true}}, requested)
+
+	// The headers which have timed out are requested from the other peer
+	peer = w.single.peer
+	clk.Add(cfg.BlockSync.RequestTimeout)
+	requested = nil
+	w.Sync()
+	require.Equal(&pb.BlockSync{Start: 5, End: 8, HeadersOnly: true}, requested[0])
+	require.NotEqual(peer, w.single.peer)
+	require.True(w.peers[peer].elapsed >= cfg.BlockSync.RequestTimeout)
+}
+
+func TestSyncWorkerFastSync(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	peers := []net.Addr{node.NewTCPNode("127.0.0.1:10001")}
+	var requested []*pb.BlockSync
+	p2p := mock_network.NewMockOverlay(ctrl)
+	p2p.EXPECT().GetPeers().Return(peers).AnyTimes()
+	p2p.EXPECT().Tell(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ uint32, _ net.Addr, msg proto.Message) error {
+			requested = append(requested, msg.(*pb.BlockSync))
+			return nil
+		}).AnyTimes()
+
+	cfg := config.Default
+	cfg.BlockSync.ChunkSize = 0
+	cfg.BlockSync.CheckpointHeight = 100
+	buf := &blockBuffer{blocks: make(map[uint64]*bc.Block), size: 16, startHeight: 1}
+	w := newSyncWorker(config.Default.Chain.ID, &cfg, p2p, buf, nil)
+	w.SetTargetHeight(200)
+
+	// Only the state snapshot at the checkpoint is requested
+	w.SetFastSync(true)
+	w.Sync()
+	w.Sync()
+	require.Equal([]*pb.BlockSync{{Start: 100, Snapshot: true}}, requested)
+	require.Empty(w.requests)
+
+	// The blocks are requested once fast sync is done
+	w.SetFastSync(false)
+	requested = nil
+	w.Sync()
+	require.Equal([]*pb.BlockSync{{Start: 1, End: 16}}, requested)
+}
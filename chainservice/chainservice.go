@@ -4,19 +4,23 @@ import (
 	"context"
 	"os"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/api"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/action"
 	"github.com/iotexproject/iotex-core/blocksync"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus"
+	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/explorer"
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network"
 	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/state"
 )
 
 // ChainService is a blockchain service with all blockchain components.
@@ -26,6 +30,7 @@ type ChainService struct {
 	consensus consensus.Consensus
 	chain     blockchain.Blockchain
 	explorer  *explorer.Server
+	api       *api.Server
 }
 
 // New creates a ChainService from config and network.Overlay and dispatcher.Dispatcher.
@@ -64,14 +69,28 @@ func newChainService(cfg *config.Config, p2p network.Overlay, dispatcher dispatc
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create actpool")
 	}
-	bs, err := blocksync.NewBlockSyncer(cfg, chain, actPool, p2p)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create blockSyncer")
-	}
 	consensus := consensus.NewConsensus(cfg, chain, actPool, p2p)
 	if consensus == nil {
 		return nil, errors.Wrap(err, "failed to create consensus")
 	}
+	bsOpts := []blocksync.Option{
+		blocksync.CommitCallbackOption(consensus.HandleCommittedBlock),
+	}
+	if cfg.Consensus.Scheme == config.RollDPoSScheme {
+		// The producers are only known to be the delegates of their epochs with roll-DPoS, who certify the blocks once
+		// their DKG public key shares are on chain
+		validateProducer := rolldpos.NewProducerValidator(cfg.Consensus.RollDPoS, chain)
+		validateCert := rolldpos.NewCertificateValidator(cfg.Consensus.RollDPoS, chain)
+		bsOpts = append(
+			bsOpts,
+			blocksync.ProducerValidatorOption(validateProducer),
+			blocksync.CertificateValidatorOption(validateCert),
+		)
+	}
+	bs, err := blocksync.NewBlockSyncer(cfg, chain, actPool, p2p, bsOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create blockSyncer")
+	}
 
 	var exp *explorer.Server
 	if cfg.Explorer.IsTest || os.Getenv("APP_ENV") == "development" {
@@ -80,13 +99,22 @@ func newChainService(cfg *config.Config, p2p network.Overlay, dispatcher dispatc
 	} else {
 		exp = explorer.NewServer(cfg.Explorer, chain, consensus, dispatcher, actPool, p2p)
 	}
-	return &ChainService{
+	var apiSvr *api.Server
+	if cfg.API.Enabled {
+		apiSvr = api.NewServer(cfg.API, chain, dispatcher, actPool, p2p)
+	}
+	cs := &ChainService{
 		actpool:   actPool,
 		chain:     chain,
 		blocksync: bs,
 		consensus: consensus,
 		explorer:  exp,
-	}, nil
+		api:       apiSvr,
+	}
+	if err := cs.registerHandlers(dispatcher); err != nil {
+		return nil, errors.Wrap(err, "failed to register the handlers with the dispatcher")
+	}
+	return cs, nil
 }
 
 // Start starts the server
@@ -103,11 +131,21 @@ func (cs *ChainService) Start(ctx context.Context) error {
 	if err := cs.explorer.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting explorer")
 	}
+	if cs.api != nil {
+		if err := cs.api.Start(ctx); err != nil {
+			return errors.Wrap(err, "error when starting API server")
+		}
+	}
 	return nil
 }
 
 // Stop stops the server
 func (cs *ChainService) Stop(ctx context.Context) error {
+	if cs.api != nil {
+		if err := cs.api.Stop(ctx); err != nil {
+			return errors.Wrap(err, "error when stopping API server")
+		}
+	}
 	if err := cs.explorer.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping explorer")
 	}
@@ -123,8 +161,32 @@ func (cs *ChainService) Stop(ctx context.Context) error {
 	return nil
 }
 
-// HandleAction handles incoming action request.
-func (cs *ChainService) HandleAction(act *pb.ActionPb) error {
+// registerHandlers registers the handlers of the actpool, the block syncer and the consensus with the dispatcher
+func (cs *ChainService) registerHandlers(dp dispatcher.Dispatcher) error {
+	chainID := cs.ChainID()
+	handlers := []struct {
+		msgType uint32
+		lane    dispatcher.Lane
+		handler dispatcher.Handler
+	}{
+		{pb.MsgProposeProtoMsgType, dispatcher.ConsensusLane, cs.handleBlockPropose},
+		{pb.MsgEndorseProtoMsgType, dispatcher.ConsensusLane, cs.handleEndorse},
+		{pb.MsgBlockProtoMsgType, dispatcher.BlockLane, cs.handleBlock},
+		{pb.MsgBlockSyncReqType, dispatcher.SyncLane, cs.handleSyncRequest},
+		{pb.MsgBlockSyncDataType, dispatcher.SyncLane, cs.handleBlockSyncData},
+		{pb.MsgActionType, dispatcher.ActionLane, cs.handleAction},
+	}
+	for _, h := range handlers {
+		if err := dp.RegisterHandler(chainID, h.msgType, h.lane, h.handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleAction handles incoming action request.
+func (cs *ChainService) handleAction(_ string, msg proto.Message) error {
+	act := msg.(*pb.ActionPb)
 	if pbTsf := act.GetTransfer(); pbTsf != nil {
 		tsf := &action.Transfer{}
 		tsf.ConvertFromActionPb(act)
@@ -150,33 +212,70 @@ func (cs *ChainService) HandleAction(act *pb.ActionPb) error {
 	return nil
 }
 
-// HandleBlock handles incoming block request.
-func (cs *ChainService) HandleBlock(pbBlock *pb.BlockPb) error {
+// handleBlock handles incoming block request.
+func (cs *ChainService) handleBlock(_ string, msg proto.Message) error {
 	blk := &blockchain.Block{}
-	blk.ConvertFromBlockPb(pbBlock)
+	blk.ConvertFromBlockPb(msg.(*pb.BlockPb))
 	return cs.blocksync.ProcessBlock(blk)
 }
 
-// HandleBlockSync handles incoming block sync request.
-func (cs *ChainService) HandleBlockSync(pbBlock *pb.BlockPb) error {
-	blk := &blockchain.Block{}
-	blk.ConvertFromBlockPb(pbBlock)
-	return cs.blocksync.ProcessBlockSync(blk)
+// handleBlockSyncData handles the blocks, the headers or the state snapshot sent back for a block sync request. The
+// blocks are handled in order, and the block sent along with a state snapshot is the checkpoint of the snapshot. The
+// sender is only blamed for the data proven to be invalid, rather than the data failing to be handled, e.g., when the
+// chain has moved on.
+func (cs *ChainService) handleBlockSyncData(sender string, msg proto.Message) error {
+	data := msg.(*pb.BlockContainer)
+	if data.Snapshot != nil {
+		if err := cs.blocksync.ProcessStateSnapshot(sender, data); err != nil {
+			return syncDataError(err, "failed to import the state snapshot")
+		}
+		return nil
+	}
+	var invalid error
+	if len(data.Headers) > 0 {
+		if err := cs.blocksync.ProcessBlockHeaders(sender, data.Headers); err != nil {
+			invalid = syncDataError(err, "failed to sync the block headers")
+		}
+	}
+	var blocks []*pb.BlockPb
+	if data.Block != nil {
+		blocks = append(blocks, data.Block)
+	}
+	for _, pbBlock := range append(blocks, data.Blocks...) {
+		blk := &blockchain.Block{}
+		blk.ConvertFromBlockPb(pbBlock)
+		if err := cs.blocksync.ProcessBlockSync(sender, blk); err != nil && invalid == nil {
+			invalid = syncDataError(err, "failed to sync the block")
+		}
+	}
+	return invalid
+}
+
+// syncDataError wraps the error of handling the synced data in dispatcher.ErrInvalidMsg if it proves that the data is
+// invalid, so that the sender is blamed
+func syncDataError(err error, msg string) error {
+	switch errors.Cause(err) {
+	case blocksync.ErrInvalidHeader, blockchain.ErrInvalidCertificate, blockchain.ErrInvalidProducer,
+		state.ErrInvalidSnapshot:
+		return errors.Wrapf(dispatcher.ErrInvalidMsg, "%s: %v", msg, err)
+	default:
+		return errors.Wrap(err, msg)
+	}
 }
 
-// HandleSyncRequest handles incoming sync request.
-func (cs *ChainService) HandleSyncRequest(sender string, sync *pb.BlockSync) error {
-	return cs.blocksync.ProcessSyncRequest(sender, sync)
+// handleSyncRequest handles incoming sync request.
+func (cs *ChainService) handleSyncRequest(sender string, msg proto.Message) error {
+	return cs.blocksync.ProcessSyncRequest(sender, msg.(*pb.BlockSync))
 }
 
-// HandleBlockPropose handles incoming block propose request.
-func (cs *ChainService) HandleBlockPropose(propose *pb.ProposePb) error {
-	return cs.consensus.HandleBlockPropose(propose)
+// handleBlockPropose handles incoming block propose request.
+func (cs *ChainService) handleBlockPropose(_ string, msg proto.Message) error {
+	return cs.consensus.HandleBlockPropose(msg.(*pb.ProposePb))
 }
 
-// HandleEndorse handles incoming endorse request.
-func (cs *ChainService) HandleEndorse(endorse *pb.EndorsePb) error {
-	return cs.consensus.HandleEndorse(endorse)
+// handleEndorse handles incoming endorse request.
+func (cs *ChainService) handleEndorse(_ string, msg proto.Message) error {
+	return cs.consensus.HandleEndorse(msg.(*pb.EndorsePb))
 }
 
 // ChainID returns ChainID.
@@ -206,3 +305,8 @@ func (cs *ChainService) BlockSync() blocksync.BlockSync {
 func (cs *ChainService) Explorer() *explorer.Server {
 	return cs.explorer
 }
+
+// API returns the API server, which is nil if the API is disabled
+func (cs *ChainService) API() *api.Server {
+	return cs.api
+}
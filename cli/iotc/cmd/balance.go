@@ -31,12 +31,12 @@ func balance(args []string) string {
 		logger.Error().Err(err).Msg("cannot getexplorer client")
 		return ""
 	}
-	balance, err := client.GetAddressBalance(args[0])
+	balance, err := client.GetAddressBalanceV2(args[0])
 	if err != nil {
 		logger.Error().Err(err).Msgf("cannot get balance for address %s", args[0])
 		return ""
 	}
-	return fmt.Sprintf("Address %s balance: %d", args[0], balance)
+	return fmt.Sprintf("Address %s balance: %s", args[0], balance)
 }
 
 func init() {
@@ -31,13 +31,13 @@ func details(args []string) string {
 		logger.Error().Err(err).Msg("cannot get explorer client")
 		return ""
 	}
-	det, err := client.GetAddressDetails(args[0])
+	det, err := client.GetAddressDetailsV2(args[0])
 	if err != nil {
 		logger.Error().Err(err).Msgf("cannot get details for address %s", args[0])
 		return ""
 	}
 	return fmt.Sprintf("Address %s nonce: %d\n", args[0], det.Nonce) +
-		fmt.Sprintf("Address %s balance: %d", args[0], det.TotalBalance)
+		fmt.Sprintf("Address %s balance: %s", args[0], det.TotalBalance)
 }
 
 func init() {
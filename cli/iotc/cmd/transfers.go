@@ -33,7 +33,7 @@ func transfers(args []string) string {
 		logger.Error().Err(err).Msg("cannot get explorer client")
 		return ""
 	}
-	transfers, err := client.GetTransfersByAddress(args[0], 0, int64(limit))
+	transfers, err := client.GetTransfersByAddressV2(args[0], 0, int64(limit))
 	if err != nil {
 		logger.Error().Err(err).Msgf("cannot get transfers for address %s", args[0])
 		return ""
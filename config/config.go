@@ -7,6 +7,7 @@
 package config
 
 import (
+	"encoding/hex"
 	"flag"
 	"os"
 	"time"
@@ -18,8 +19,10 @@ import (
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/network/nat"
 	"github.com/iotexproject/iotex-core/pkg/enc"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/proto"
 )
 
 // IMPORTANT: to define a config, add a field or a new config type to the existing config types. In addition, provide
@@ -53,6 +56,18 @@ const (
 	StandaloneScheme = "STANDALONE"
 	// NOOPScheme means that the node does not create only block
 	NOOPScheme = "NOOP"
+	// PBFTScheme means the permissioned BFT consensus among a fixed set of validators
+	PBFTScheme = "PBFT"
+
+	// GRPCTransport means the overlay of the gRPC peer service and the built-in gossip
+	GRPCTransport = "grpc"
+	// Libp2pTransport means the overlay on libp2p, which broadcasts by gossipsub and tells by direct streams
+	Libp2pTransport = "libp2p"
+
+	// NoiseSecurity means the libp2p connections are secured by the noise protocol
+	NoiseSecurity = "noise"
+	// SecioSecurity means the libp2p connections are secured by secio
+	SecioSecurity = "secio"
 )
 
 var (
@@ -60,10 +75,8 @@ var (
 	Default = Config{
 		NodeType: FullNodeType,
 		Network: Network{
-			Host: "127.0.0.1",
-			Port: 4689,
-			MsgLogsCleaningInterval:             2 * time.Second,
-			MsgLogRetention:                     5 * time.Second,
+			Host:                                "127.0.0.1",
+			Port:                                4689,
 			HealthCheckInterval:                 time.Second,
 			SilentInterval:                      5 * time.Second,
 			PeerMaintainerInterval:              time.Second,
@@ -73,20 +86,55 @@ var (
 			NumPeersUpperBound:                  5,
 			PingInterval:                        time.Second,
 			RateLimitEnabled:                    false,
-			RateLimitPerSec:                     10000,
-			RateLimitWindowSize:                 60 * time.Second,
-			BootstrapNodes:                      make([]string, 0),
-			TLSEnabled:                          false,
-			CACrtPath:                           "",
-			PeerCrtPath:                         "",
-			PeerKeyPath:                         "",
-			KLClientParams:                      keepalive.ClientParameters{},
-			KLServerParams:                      keepalive.ServerParameters{},
-			KLPolicy:                            keepalive.EnforcementPolicy{},
-			MaxMsgSize:                          10485760,
-			PeerDiscovery:                       true,
-			TopologyPath:                        "",
-			TTL:                                 3,
+			RateLimit: RateLimits{
+				Requests: RateLimit{Rate: 10000, Burst: 20000},
+				Bytes:    RateLimit{Rate: 10485760, Burst: 20971520},
+				PerMsgType: map[uint32]RateLimit{
+					iproto.MsgActionType:          {Rate: 1000, Burst: 2000},
+					iproto.MsgEndorseProtoMsgType: {Rate: 5000, Burst: 10000},
+				},
+			},
+			RateLimitPath:             "",
+			RateLimitReloadInterval:   10 * time.Second,
+			RateLimitMaxBuckets:       10000,
+			ExternalHost:              "",
+			ExternalPort:              0,
+			NATTraversal:              "",
+			NATGateway:                "",
+			NATMappingLifetime:        20 * time.Minute,
+			ObservedAddrThreshold:     3,
+			Transport:                 GRPCTransport,
+			Libp2pSecurity:            NoiseSecurity,
+			Libp2pPrivKey:             "",
+			TracePath:                 "",
+			BootstrapNodes:            make([]string, 0),
+			TLSEnabled:                false,
+			CACrtPath:                 "",
+			PeerCrtPath:               "",
+			PeerKeyPath:               "",
+			KLClientParams:            keepalive.ClientParameters{},
+			KLServerParams:            keepalive.ServerParameters{},
+			KLPolicy:                  keepalive.EnforcementPolicy{},
+			MaxMsgSize:                10485760,
+			PeerDiscovery:             true,
+			TopologyPath:              "",
+			TTL:                       3,
+			PeerReputationEnabled:     true,
+			PeerBanThreshold:          -100,
+			PeerScoreRecoveryInterval: time.Minute,
+			PeerBanDuration:           time.Hour,
+			PeerBanListPath:           "",
+			PeerScoreCacheSize:        10000,
+			AddrBookPath:              "",
+			AddrBookBucketSize:        16,
+			DiscoveryInterval:         time.Minute,
+			SeenCacheSize:             10000,
+			GossipFanout:              0,
+			GossipFanoutPerMsgType:    map[uint32]uint{},
+			GossipLazyPushMsgTypes:    []uint32{iproto.MsgBlockProtoMsgType},
+			NodePubKey:                "",
+			NodePrivKey:               "",
+			NodeKeyPath:               "/tmp/nodekey.yaml",
 		},
 		Chain: Chain{
 			ChainDBPath: "/tmp/chain.db",
@@ -116,21 +164,32 @@ var (
 				AcceptProposeTTL:         time.Second,
 				AcceptProposalEndorseTTL: time.Second,
 				AcceptCommitEndorseTTL:   time.Second,
-				Delay:             5 * time.Second,
-				NumSubEpochs:      1,
-				EventChanSize:     10000,
-				NumDelegates:      21,
-				EnableDummyBlock:  true,
-				TimeBasedRotation: false,
+				Delay:                    5 * time.Second,
+				NumSubEpochs:             1,
+				EventChanSize:            10000,
+				NumDelegates:             21,
+				TimeBasedRotation:        false,
+				SignedMsgDBPath:          "/tmp/signedmsg.db",
+				EnableDKG:                false,
+				ProductivityDBPath:       "/tmp/productivity.db",
 			},
 			BlockCreationInterval: 10 * time.Second,
+			Schemes:               make(map[string]interface{}),
 		},
 		BlockSync: BlockSync{
-			Interval:   10 * time.Second,
-			BufferSize: 16,
+			Interval:        10 * time.Second,
+			BufferSize:      16,
+			ChunkSize:       4,
+			MaxBlocksPerMsg: 16,
+			RequestTimeout:  5 * time.Second,
+			HeaderBatchSize: 256,
 		},
 		Dispatcher: Dispatcher{
-			EventChanSize: 10000,
+			Workers:       4,
+			ConsensusLane: DispatcherLane{QueueSize: 1000, Weight: 8},
+			BlockLane:     DispatcherLane{QueueSize: 1000, Weight: 4},
+			SyncLane:      DispatcherLane{QueueSize: 1000, Weight: 2},
+			ActionLane:    DispatcherLane{QueueSize: 10000, Weight: 1},
 		},
 		Explorer: Explorer{
 			Enabled:                 false,
@@ -138,6 +197,15 @@ var (
 			Port:                    14004,
 			TpsWindow:               10,
 			MaxTransferPayloadBytes: 1024,
+			EthPort:                 0,
+			EthMaxLogBlockRange:     1000,
+			MaxPageSize:             1000,
+		},
+		API: API{
+			Enabled:                 false,
+			Port:                    14014,
+			GatewayPort:             0,
+			MaxTransferPayloadBytes: 1024,
 		},
 		System: System{
 			HeartbeatInterval: 10 * time.Second,
@@ -158,7 +226,9 @@ var (
 		ValidateConsensusScheme,
 		ValidateRollDPoS,
 		ValidateDispatcher,
+		ValidateBlockSync,
 		ValidateExplorer,
+		ValidateAPI,
 		ValidateNetwork,
 		ValidateActPool,
 		ValidateChain,
@@ -168,13 +238,13 @@ var (
 // Network is the config struct for network package
 type (
 	Network struct {
-		Host                    string        `yaml:"host"`
-		Port                    int           `yaml:"port"`
-		MsgLogsCleaningInterval time.Duration `yaml:"msgLogsCleaningInterval"`
-		MsgLogRetention         time.Duration `yaml:"msgLogRetention"`
-		HealthCheckInterval     time.Duration `yaml:"healthCheckInterval"`
-		SilentInterval          time.Duration `yaml:"silentInterval"`
-		PeerMaintainerInterval  time.Duration `yaml:"peerMaintainerInterval"`
+		// Host and Port are the address that the node binds to, e.g., 0.0.0.0 to listen on all the interfaces. It's
+		// advertised to the other nodes unless the external address is configured or learned.
+		Host                   string        `yaml:"host"`
+		Port                   int           `yaml:"port"`
+		HealthCheckInterval    time.Duration `yaml:"healthCheckInterval"`
+		SilentInterval         time.Duration `yaml:"silentInterval"`
+		PeerMaintainerInterval time.Duration `yaml:"peerMaintainerInterval"`
 		// Force disconnecting a random peer every given number of peer maintenance round
 		PeerForceDisconnectionRoundInterval int                         `yaml:"peerForceDisconnectionRoundInterval"`
 		AllowMultiConnsPerHost              bool                        `yaml:"allowMultiConnsPerHost"`
@@ -182,8 +252,7 @@ type (
 		NumPeersUpperBound                  uint                        `yaml:"numPeersUpperBound"`
 		PingInterval                        time.Duration               `yaml:"pingInterval"`
 		RateLimitEnabled                    bool                        `yaml:"rateLimitEnabled"`
-		RateLimitPerSec                     uint64                      `yaml:"rateLimitPerSec"`
-		RateLimitWindowSize                 time.Duration               `yaml:"rateLimitWindowSize"`
+		RateLimit                           RateLimits                  `yaml:"rateLimit"`
 		BootstrapNodes                      []string                    `yaml:"bootstrapNodes"`
 		TLSEnabled                          bool                        `yaml:"tlsEnabled"`
 		CACrtPath                           string                      `yaml:"caCrtPath"`
@@ -196,6 +265,94 @@ type (
 		PeerDiscovery                       bool                        `yaml:"peerDiscovery"`
 		TopologyPath                        string                      `yaml:"topologyPath"`
 		TTL                                 int32                       `yaml:"ttl"`
+		// PeerReputationEnabled lowers the score of a peer on each misbehavior, and disconnects and bans the peer once
+		// its score drops below the ban threshold. Every peer starts at 0, and regains a point every recovery interval.
+		PeerReputationEnabled     bool          `yaml:"peerReputationEnabled"`
+		PeerBanThreshold          int64         `yaml:"peerBanThreshold"`
+		PeerScoreRecoveryInterval time.Duration `yaml:"peerScoreRecoveryInterval"`
+		// PeerBanDuration is how long a banned peer is refused
+		PeerBanDuration time.Duration `yaml:"peerBanDuration"`
+		// PeerBanListPath is the file which persists the bans across restarts. The bans are kept in memory only if it
+		// is empty.
+		PeerBanListPath string `yaml:"peerBanListPath"`
+		// PeerScoreCacheSize is the max number of the peers whose scores are kept, and the score of the least recently
+		// penalized peer is forgotten once it's exceeded
+		PeerScoreCacheSize int `yaml:"peerScoreCacheSize"`
+		// AddrBookPath is the file which persists the known addresses across restarts. The addresses are kept in
+		// memory only if it is empty.
+		AddrBookPath string `yaml:"addrBookPath"`
+		// AddrBookBucketSize is the max number of addresses in each bucket of the address book
+		AddrBookBucketSize int `yaml:"addrBookBucketSize"`
+		// DiscoveryInterval is how often the node looks up the network to find more nodes
+		DiscoveryInterval time.Duration `yaml:"discoveryInterval"`
+		// NodePubKey and NodePrivKey are the keypair which identifies the node in the handshake with its peers. If they
+		// are empty, the keypair is loaded from NodeKeyPath, where a new one is generated and persisted on the first
+		// start. The generated keypair is kept in memory only if NodeKeyPath is empty.
+		NodePubKey  string `yaml:"nodePubKey"`
+		NodePrivKey string `yaml:"nodePrivKey"`
+		NodeKeyPath string `yaml:"nodeKeyPath"`
+		// SeenCacheSize is the number of the most recent broadcast messages remembered to drop the duplicates
+		SeenCacheSize int `yaml:"seenCacheSize"`
+		// GossipFanout is the number of random peers which a broadcast message is relayed to, or all the peers if it
+		// is 0. GossipFanoutPerMsgType overrides it for the given message types.
+		GossipFanout           uint            `yaml:"gossipFanout"`
+		GossipFanoutPerMsgType map[uint32]uint `yaml:"gossipFanoutPerMsgType"`
+		// GossipLazyPushMsgTypes are the message types which are only announced by their hashes when relayed, and the
+		// peers which haven't seen them pull the full messages
+		GossipLazyPushMsgTypes []uint32 `yaml:"gossipLazyPushMsgTypes"`
+		// RateLimitPath is the YAML file of the rate limits, which is checked every reload interval and overrides
+		// RateLimit once it changes, so that the limits could be tuned without a restart. It's not watched if empty.
+		RateLimitPath           string        `yaml:"rateLimitPath"`
+		RateLimitReloadInterval time.Duration `yaml:"rateLimitReloadInterval"`
+		// RateLimitMaxBuckets is the max number of the peers and hosts whose rate limit buckets are kept
+		RateLimitMaxBuckets int `yaml:"rateLimitMaxBuckets"`
+		// ExternalHost and ExternalPort are the address advertised to the other nodes, in case that the node is
+		// reachable at another address than the one it binds to, e.g., behind a NAT. They are learned from the NAT
+		// gateway or the peers if they are empty.
+		ExternalHost string `yaml:"externalHost"`
+		ExternalPort int    `yaml:"externalPort"`
+		// NATTraversal is the mechanism mapping the port on the NAT gateway, which is either "upnp" or "natpmp". No
+		// port is mapped if it is empty.
+		NATTraversal string `yaml:"natTraversal"`
+		// NATGateway is the address of the NAT gateway, which is discovered if it is empty. It's the location of the
+		// device description for UPnP.
+		NATGateway string `yaml:"natGateway"`
+		// NATMappingLifetime is how long the port mapping lasts, which is renewed every half of the lifetime
+		NATMappingLifetime time.Duration `yaml:"natMappingLifetime"`
+		// ObservedAddrThreshold is the number of distinct peers which need to agree on the host they observe before it
+		// is advertised as the external host. The observed hosts are ignored if it is 0.
+		ObservedAddrThreshold int `yaml:"observedAddrThreshold"`
+		// Transport selects the implementation of the overlay, which is either "grpc" or "libp2p". libp2p requires the
+		// node built with the libp2p tag, and the bootstrap nodes are the multiaddrs ending with the peer IDs, e.g.,
+		// /ip4/1.2.3.4/tcp/4689/p2p/QmPeerID.
+		Transport string `yaml:"transport"`
+		// Libp2pSecurity is the protocol securing the libp2p connections, which is either "noise" or "secio"
+		Libp2pSecurity string `yaml:"libp2pSecurity"`
+		// Libp2pPrivKey is the hex encoded libp2p private key which the peer ID is derived from. A new Ed25519 key is
+		// generated on each start if it is empty.
+		Libp2pPrivKey string `yaml:"libp2pPrivKey"`
+		// TracePath is the file which the messages sent and received by the node are recorded to, for debugging the
+		// propagation in a local cluster. The messages are not recorded if it is empty.
+		TracePath string `yaml:"tracePath"`
+	}
+
+	// RateLimits are the limits of the requests that each peer could send. The requests over any limit are dropped,
+	// and the peer is penalized.
+	RateLimits struct {
+		// Requests limits the number of the requests of all kinds
+		Requests RateLimit `yaml:"requests"`
+		// Bytes limits the size of the requests in bytes
+		Bytes RateLimit `yaml:"bytes"`
+		// PerMsgType limits the number of the messages of each type, keyed by the message type
+		PerMsgType map[uint32]RateLimit `yaml:"perMsgType"`
+	}
+
+	// RateLimit is a token bucket limit, which is refilled with Rate tokens every second up to Burst
+	RateLimit struct {
+		// Rate is the number of tokens refilled every second, and 0 means no limit
+		Rate uint64 `yaml:"rate"`
+		// Burst is the capacity of the bucket, and it's the rate if it's 0
+		Burst uint64 `yaml:"burst"`
 	}
 
 	// Chain is the config struct for blockchain package
@@ -216,16 +373,39 @@ type (
 
 	// Consensus is the config struct for consensus package
 	Consensus struct {
-		// There are three schemes that are supported
+		// Scheme is the name of a registered consensus scheme
 		Scheme                string        `yaml:"scheme"`
 		RollDPoS              RollDPoS      `yaml:"rollDPoS"`
 		BlockCreationInterval time.Duration `yaml:"blockCreationInterval"`
+		// Schemes keeps the config sections of the registered schemes other than roll-DPoS, e.g., PBFT, keyed by the
+		// scheme name
+		Schemes map[string]interface{} `yaml:"schemes"`
 	}
 
 	// BlockSync is the config struct for the BlockSync
 	BlockSync struct {
 		Interval   time.Duration `yaml:"interval"` // update duration
 		BufferSize uint64        `yaml:"bufferSize"`
+		// RequireCertificate rejects the synced blocks without a commit certificate, even before a quorum of the
+		// delegates have recorded the DKG public key shares to certify them
+		RequireCertificate bool `yaml:"requireCertificate"`
+		// ChunkSize is the max number of blocks requested from one peer at a time, and 0 means no limit
+		ChunkSize uint64 `yaml:"chunkSize"`
+		// MaxBlocksPerMsg is the max number of blocks sent back in one message, and 0 means one block per message
+		MaxBlocksPerMsg uint64 `yaml:"maxBlocksPerMsg"`
+		// RequestTimeout is how long to wait for a peer to send back the blocks before asking another peer, and 0
+		// means the sync interval
+		RequestTimeout time.Duration `yaml:"requestTimeout"`
+		// HeaderFirst downloads and verifies the header chain first, and only downloads the bodies of the verified
+		// headers
+		HeaderFirst bool `yaml:"headerFirst"`
+		// HeaderBatchSize is the max number of headers requested at a time
+		HeaderBatchSize uint64 `yaml:"headerBatchSize"`
+		// CheckpointHeight and CheckpointHash are the trusted block to fast sync from. If the chain is lower than the
+		// checkpoint, the state snapshot at the checkpoint is imported from a peer instead of replaying the blocks
+		// before it, and the blocks after it are synced as usual.
+		CheckpointHeight uint64 `yaml:"checkpointHeight"`
+		CheckpointHash   string `yaml:"checkpointHash"`
 	}
 
 	// RollDPoS is the config struct for RollDPoS consensus package
@@ -242,13 +422,38 @@ type (
 		NumSubEpochs             uint          `yaml:"numSubEpochs"`
 		EventChanSize            uint          `yaml:"eventChanSize"`
 		NumDelegates             uint          `yaml:"numDelegates"`
-		EnableDummyBlock         bool          `yaml:"enableDummyBlock"`
 		TimeBasedRotation        bool          `yaml:"timeBasedRotation"`
+		// SignedMsgDBPath is the path of the DB file keeping the messages signed by the delegate, which guards the
+		// delegate against double signing across restarts. A delegate doesn't start without it.
+		SignedMsgDBPath string `yaml:"signedMsgDBPath"`
+		// EnableDKG enables the distributed key generation at the beginning of each epoch, whose group signatures
+		// produce the random seed of the next epoch. It only works with exactly crypto.NumDKGNodes delegates.
+		EnableDKG bool `yaml:"enableDKG"`
+		// ProductivityDBPath is the path of the DB file keeping the delegates' productivity of each epoch, which is
+		// persisted along with the chain. It's kept in memory if it's empty.
+		ProductivityDBPath string `yaml:"productivityDBPath"`
+		// ProbationThreshold is the minimum percentage of the blocks that a delegate should produce out of its turns
+		// in an epoch, below which it's put on probation and not elected in the next epoch. 0 disables the probation.
+		ProbationThreshold uint `yaml:"probationThreshold"`
+	}
+
+	// Dispatcher is the dispatcher config. The messages are queued in the lanes of their classes, and the workers take
+	// them from the lanes in proportion to the lane weights.
+	Dispatcher struct {
+		// Workers is the number of goroutines handling the queued messages
+		Workers       uint           `yaml:"workers"`
+		ConsensusLane DispatcherLane `yaml:"consensusLane"`
+		BlockLane     DispatcherLane `yaml:"blockLane"`
+		SyncLane      DispatcherLane `yaml:"syncLane"`
+		ActionLane    DispatcherLane `yaml:"actionLane"`
 	}
 
-	// Dispatcher is the dispatcher config
-	Dispatcher struct {
-		EventChanSize uint `yaml:"eventChanSize"`
+	// DispatcherLane is the config of the dispatcher queue for a class of messages
+	DispatcherLane struct {
+		// QueueSize is the number of messages which could be pending in the lane
+		QueueSize uint `yaml:"queueSize"`
+		// Weight is the relative share of the workers' turns that the lane gets when the lanes are busy
+		Weight uint `yaml:"weight"`
 	}
 
 	// Explorer is the explorer service config
@@ -259,6 +464,23 @@ type (
 		TpsWindow int  `yaml:"tpsWindow"`
 		// MaxTransferPayloadBytes limits how many bytes a playload can contain at most
 		MaxTransferPayloadBytes uint64 `yaml:"maxTransferPayloadBytes"`
+		// EthPort is the port of the Ethereum compatible JSON-RPC endpoint, which is disabled if it is 0
+		EthPort int `yaml:"ethPort"`
+		// EthMaxLogBlockRange limits how many blocks eth_getLogs could scan in a request
+		EthMaxLogBlockRange uint64 `yaml:"ethMaxLogBlockRange"`
+		// MaxPageSize limits how many actions a page of the actions of an address could contain
+		MaxPageSize int64 `yaml:"maxPageSize"`
+	}
+
+	// API is the config of the gRPC API service
+	API struct {
+		Enabled bool `yaml:"enabled"`
+		Port    int  `yaml:"port"`
+		// GatewayPort is the port of the REST gateway to the gRPC API, which is disabled if it is 0. The gateway is only
+		// built with the gateway tag, i.e., `go build -tags gateway`
+		GatewayPort int `yaml:"gatewayPort"`
+		// MaxTransferPayloadBytes limits how many bytes the payload of a transfer sent through the API can contain
+		MaxTransferPayloadBytes uint64 `yaml:"maxTransferPayloadBytes"`
 	}
 
 	// System is the system config
@@ -313,6 +535,7 @@ type (
 		BlockSync  BlockSync  `yaml:"blockSync"`
 		Dispatcher Dispatcher `yaml:"dispatcher"`
 		Explorer   Explorer   `yaml:"explorer"`
+		API        API        `yaml:"api"`
 		System     System     `yaml:"system"`
 		DB         DB         `yaml:"db"`
 	}
@@ -432,6 +655,37 @@ func (cfg *Config) KeyPair() (keypair.PublicKey, keypair.PrivateKey, error) {
 	return pk, sk, nil
 }
 
+// NodeKeyPair returns the decoded node keypair, which is checked to match by signing a dummy message and verifying it
+func (cfg *Network) NodeKeyPair() (keypair.PublicKey, keypair.PrivateKey, error) {
+	pk, err := keypair.DecodePublicKey(cfg.NodePubKey)
+	if err != nil {
+		return keypair.ZeroPublicKey,
+			keypair.ZeroPrivateKey,
+			errors.Wrapf(err, "error when decoding node public key %s", cfg.NodePubKey)
+	}
+	sk, err := keypair.DecodePrivateKey(cfg.NodePrivKey)
+	if err != nil {
+		return keypair.ZeroPublicKey, keypair.ZeroPrivateKey, errors.Wrap(err, "error when decoding node private key")
+	}
+	validationMsg := "connecting the physical world block by block"
+	if !crypto.EC283.Verify(pk, []byte(validationMsg), crypto.EC283.Sign(sk, []byte(validationMsg))) {
+		return keypair.ZeroPublicKey, keypair.ZeroPrivateKey, errors.New("node has unmatched pubkey and prikey")
+	}
+	return pk, sk, nil
+}
+
+// Validate checks that the byte limit lets through a message of the max size, which would be dropped forever otherwise
+func (limits *RateLimits) Validate(maxMsgSize int) error {
+	burst := limits.Bytes.Burst
+	if burst == 0 {
+		burst = limits.Bytes.Rate
+	}
+	if limits.Bytes.Rate > 0 && burst < uint64(maxMsgSize) {
+		return errors.Errorf("byte rate limit burst %d should be no less than max message size %d", burst, maxMsgSize)
+	}
+	return nil
+}
+
 // ValidateKeyPair validates the block producer address
 func ValidateKeyPair(cfg *Config) error {
 	priKey, err := keypair.DecodePrivateKey(cfg.Chain.ProducerPrivKey)
@@ -482,8 +736,36 @@ func ValidateConsensusScheme(cfg *Config) error {
 
 // ValidateDispatcher validates the dispatcher configs
 func ValidateDispatcher(cfg *Config) error {
-	if cfg.Dispatcher.EventChanSize <= 0 {
-		return errors.Wrap(ErrInvalidCfg, "dispatcher event chan size should be greater than 0")
+	if cfg.Dispatcher.Workers == 0 {
+		return errors.Wrap(ErrInvalidCfg, "dispatcher workers should be greater than 0")
+	}
+	lanes := map[string]DispatcherLane{
+		"consensus": cfg.Dispatcher.ConsensusLane,
+		"block":     cfg.Dispatcher.BlockLane,
+		"sync":      cfg.Dispatcher.SyncLane,
+		"action":    cfg.Dispatcher.ActionLane,
+	}
+	for name, lane := range lanes {
+		if lane.QueueSize == 0 {
+			return errors.Wrapf(ErrInvalidCfg, "dispatcher %s lane queue size should be greater than 0", name)
+		}
+		if lane.Weight == 0 {
+			return errors.Wrapf(ErrInvalidCfg, "dispatcher %s lane weight should be greater than 0", name)
+		}
+	}
+	return nil
+}
+
+// ValidateBlockSync validates the block sync configs
+func ValidateBlockSync(cfg *Config) error {
+	if cfg.BlockSync.HeaderFirst && cfg.BlockSync.HeaderBatchSize == 0 {
+		return errors.Wrap(ErrInvalidCfg, "header batch size should be greater than 0")
+	}
+	if cfg.BlockSync.CheckpointHeight == 0 {
+		return nil
+	}
+	if h, err := hex.DecodeString(cfg.BlockSync.CheckpointHash); err != nil || len(h) != 32 {
+		return errors.Wrap(ErrInvalidCfg, "checkpoint hash should be a 32-byte hex string")
 	}
 	return nil
 }
@@ -496,10 +778,24 @@ func ValidateRollDPoS(cfg *Config) error {
 	if cfg.Consensus.Scheme == RollDPoSScheme && cfg.Consensus.RollDPoS.NumDelegates <= 0 {
 		return errors.Wrap(ErrInvalidCfg, "roll-DPoS event delegate number should be greater than 0")
 	}
-	if cfg.Consensus.Scheme == RollDPoSScheme &&
-		cfg.Consensus.RollDPoS.EnableDummyBlock &&
-		cfg.Consensus.RollDPoS.TimeBasedRotation {
-		return errors.Wrap(ErrInvalidCfg, "roll-DPoS should enable dummy block when doing time based rotation")
+	if cfg.Consensus.Scheme == RollDPoSScheme && cfg.Consensus.RollDPoS.TimeBasedRotation {
+		// The time slot of a block is derived from its timestamp, which is in seconds
+		interval := cfg.Consensus.RollDPoS.ProposerInterval
+		if interval < time.Second || interval%time.Second != 0 {
+			return errors.Wrap(
+				ErrInvalidCfg,
+				"roll-DPoS proposer interval should be a multiple of second when doing time based rotation",
+			)
+		}
+		if cfg.Consensus.RollDPoS.EnableDKG {
+			return errors.Wrap(ErrInvalidCfg, "roll-DPoS doesn't support DKG when doing time based rotation")
+		}
+	}
+	if cfg.IsDelegate() && cfg.Consensus.Scheme == RollDPoSScheme && cfg.Consensus.RollDPoS.SignedMsgDBPath == "" {
+		return errors.Wrap(ErrInvalidCfg, "roll-DPoS signed message DB path should not be empty for a delegate")
+	}
+	if cfg.Consensus.Scheme == RollDPoSScheme && cfg.Consensus.RollDPoS.ProbationThreshold > 100 {
+		return errors.Wrap(ErrInvalidCfg, "roll-DPoS probation threshold should not be greater than 100")
 	}
 	return nil
 }
@@ -509,6 +805,32 @@ func ValidateExplorer(cfg *Config) error {
 	if cfg.Explorer.Enabled && cfg.Explorer.TpsWindow <= 0 {
 		return errors.Wrap(ErrInvalidCfg, "tps window is not a positive integer when the explorer is enabled")
 	}
+	if cfg.Explorer.EthPort < 0 || cfg.Explorer.EthPort > 65535 {
+		return errors.Wrapf(ErrInvalidCfg, "Ethereum JSON-RPC port %d is out of range", cfg.Explorer.EthPort)
+	}
+	if cfg.Explorer.EthPort != 0 && cfg.Explorer.EthMaxLogBlockRange == 0 {
+		return errors.Wrap(ErrInvalidCfg, "Ethereum log block range should be greater than 0")
+	}
+	if cfg.Explorer.Enabled && cfg.Explorer.MaxPageSize <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "max page size should be greater than 0 when the explorer is enabled")
+	}
+	return nil
+}
+
+// ValidateAPI validates the API configs
+func ValidateAPI(cfg *Config) error {
+	if !cfg.API.Enabled {
+		return nil
+	}
+	if cfg.API.Port < 0 || cfg.API.Port > 65535 {
+		return errors.Wrapf(ErrInvalidCfg, "API port %d is out of range", cfg.API.Port)
+	}
+	if cfg.API.GatewayPort < 0 || cfg.API.GatewayPort > 65535 {
+		return errors.Wrapf(ErrInvalidCfg, "API gateway port %d is out of range", cfg.API.GatewayPort)
+	}
+	if cfg.API.Port != 0 && cfg.API.Port == cfg.API.GatewayPort {
+		return errors.Wrap(ErrInvalidCfg, "API port and gateway port should be different")
+	}
 	return nil
 }
 
@@ -517,6 +839,71 @@ func ValidateNetwork(cfg *Config) error {
 	if !cfg.Network.PeerDiscovery && cfg.Network.TopologyPath == "" {
 		return errors.Wrap(ErrInvalidCfg, "either peer discover should be enabled or a topology should be given")
 	}
+	if cfg.Network.PeerReputationEnabled {
+		if cfg.Network.PeerBanThreshold >= 0 {
+			return errors.Wrap(ErrInvalidCfg, "peer ban threshold should be negative")
+		}
+		if cfg.Network.PeerBanDuration <= 0 {
+			return errors.Wrap(ErrInvalidCfg, "peer ban duration should be greater than 0")
+		}
+		if cfg.Network.PeerScoreCacheSize <= 0 {
+			return errors.Wrap(ErrInvalidCfg, "peer score cache size should be greater than 0")
+		}
+	}
+	if cfg.Network.PeerDiscovery {
+		if cfg.Network.AddrBookBucketSize <= 0 {
+			return errors.Wrap(ErrInvalidCfg, "address book bucket size should be greater than 0")
+		}
+		if cfg.Network.DiscoveryInterval <= 0 {
+			return errors.Wrap(ErrInvalidCfg, "discovery interval should be greater than 0")
+		}
+	}
+	if cfg.Network.SeenCacheSize <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "seen cache size should be greater than 0")
+	}
+	if cfg.Network.RateLimitEnabled {
+		if err := cfg.Network.RateLimit.Validate(cfg.Network.MaxMsgSize); err != nil {
+			return errors.Wrap(ErrInvalidCfg, err.Error())
+		}
+		if cfg.Network.RateLimitPath != "" && cfg.Network.RateLimitReloadInterval <= 0 {
+			return errors.Wrap(ErrInvalidCfg, "rate limit reload interval should be greater than 0")
+		}
+		if cfg.Network.RateLimitMaxBuckets <= 0 {
+			return errors.Wrap(ErrInvalidCfg, "rate limit max buckets should be greater than 0")
+		}
+	}
+	if cfg.Network.ExternalPort < 0 || cfg.Network.ExternalPort > 65535 {
+		return errors.Wrap(ErrInvalidCfg, "external port should be between 0 and 65535")
+	}
+	switch cfg.Network.NATTraversal {
+	case "":
+	case nat.UPnP, nat.NATPMP:
+		if cfg.Network.NATMappingLifetime <= 0 {
+			return errors.Wrap(ErrInvalidCfg, "NAT mapping lifetime should be greater than 0")
+		}
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unknown NAT traversal mechanism %s", cfg.Network.NATTraversal)
+	}
+	if cfg.Network.ObservedAddrThreshold < 0 {
+		return errors.Wrap(ErrInvalidCfg, "observed address threshold should not be negative")
+	}
+	switch cfg.Network.Transport {
+	case "", GRPCTransport:
+	case Libp2pTransport:
+		if cfg.Network.Libp2pSecurity != NoiseSecurity && cfg.Network.Libp2pSecurity != SecioSecurity {
+			return errors.Wrapf(ErrInvalidCfg, "unknown libp2p security %s", cfg.Network.Libp2pSecurity)
+		}
+		if _, err := hex.DecodeString(cfg.Network.Libp2pPrivKey); err != nil {
+			return errors.Wrap(ErrInvalidCfg, "libp2p private key should be hex encoded")
+		}
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unknown transport %s", cfg.Network.Transport)
+	}
+	if cfg.Network.NodePubKey != "" || cfg.Network.NodePrivKey != "" {
+		if _, _, err := cfg.Network.NodeKeyPair(); err != nil {
+			return errors.Wrap(ErrInvalidCfg, err.Error())
+		}
+	}
 	return nil
 }
 
@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -195,6 +196,51 @@ func TestValidateExplorer(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "tps window is not a positive integer when the explorer is enabled"),
 	)
+
+	cfg = Default
+	cfg.Explorer.EthPort = -1
+	err = ValidateExplorer(&cfg)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "Ethereum JSON-RPC port -1 is out of range"))
+
+	cfg.Explorer.EthPort = 14005
+	cfg.Explorer.EthMaxLogBlockRange = 0
+	err = ValidateExplorer(&cfg)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "Ethereum log block range should be greater than 0"))
+
+	cfg = Default
+	cfg.Explorer.Enabled = true
+	cfg.Explorer.MaxPageSize = 0
+	err = ValidateExplorer(&cfg)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "max page size should be greater than 0 when the explorer is enabled"))
+}
+
+func TestValidateAPI(t *testing.T) {
+	cfg := Default
+	cfg.API.Port = 70000
+	require.NoError(t, ValidateAPI(&cfg))
+
+	cfg.API.Enabled = true
+	err := ValidateAPI(&cfg)
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "API port 70000 is out of range"))
+
+	cfg.API.Port = 14014
+	cfg.API.GatewayPort = -1
+	err = ValidateAPI(&cfg)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "API gateway port -1 is out of range"))
+
+	cfg.API.GatewayPort = 14014
+	err = ValidateAPI(&cfg)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "API port and gateway port should be different"))
+
+	cfg.API.GatewayPort = 0
+	require.NoError(t, ValidateAPI(&cfg))
 }
 
 func TestValidateChain(t *testing.T) {
@@ -255,14 +301,45 @@ func TestValidateConsensusScheme(t *testing.T) {
 
 func TestValidateDispatcher(t *testing.T) {
 	cfg := Default
-	cfg.Dispatcher.EventChanSize = 0
+	cfg.Dispatcher.ActionLane.QueueSize = 0
 	err := ValidateDispatcher(&cfg)
 	require.NotNil(t, err)
 	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
 	require.True(
 		t,
-		strings.Contains(err.Error(), "dispatcher event chan size should be greater than 0"),
+		strings.Contains(err.Error(), "dispatcher action lane queue size should be greater than 0"),
 	)
+
+	cfg = Default
+	cfg.Dispatcher.BlockLane.Weight = 0
+	err = ValidateDispatcher(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "dispatcher block lane weight should be greater than 0"))
+
+	cfg = Default
+	cfg.Dispatcher.Workers = 0
+	err = ValidateDispatcher(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "dispatcher workers should be greater than 0"))
+}
+
+func TestValidateBlockSync(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateBlockSync(&cfg))
+	cfg.BlockSync.HeaderFirst = true
+	cfg.BlockSync.HeaderBatchSize = 0
+	err := ValidateBlockSync(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "header batch size should be greater than 0"))
+
+	cfg = Default
+	cfg.BlockSync.CheckpointHeight = 100
+	cfg.BlockSync.CheckpointHash = "1234"
+	err = ValidateBlockSync(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "checkpoint hash should be a 32-byte hex string"))
+	cfg.BlockSync.CheckpointHash = strings.Repeat("ab", 32)
+	require.NoError(t, ValidateBlockSync(&cfg))
 }
 
 func TestValidateRollDPoS(t *testing.T) {
@@ -289,15 +366,42 @@ func TestValidateRollDPoS(t *testing.T) {
 	)
 
 	cfg.Consensus.RollDPoS.NumDelegates = 1
-	cfg.Consensus.RollDPoS.EnableDummyBlock = true
 	cfg.Consensus.RollDPoS.TimeBasedRotation = true
+	cfg.Consensus.RollDPoS.ProposerInterval = 1500 * time.Millisecond
 	err = ValidateRollDPoS(&cfg)
 	require.NotNil(t, err)
 	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
 	require.True(
 		t,
-		strings.Contains(err.Error(), "roll-DPoS should enable dummy block when doing time based rotation"),
+		strings.Contains(
+			err.Error(),
+			"roll-DPoS proposer interval should be a multiple of second when doing time based rotation",
+		),
 	)
+
+	cfg.Consensus.RollDPoS.ProposerInterval = 2 * time.Second
+	cfg.Consensus.RollDPoS.EnableDKG = true
+	err = ValidateRollDPoS(&cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "roll-DPoS doesn't support DKG when doing time based rotation"))
+
+	cfg.Consensus.RollDPoS.EnableDKG = false
+	cfg.Consensus.RollDPoS.ProbationThreshold = 101
+	err = ValidateRollDPoS(&cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "roll-DPoS probation threshold should not be greater than 100"))
+
+	cfg.Consensus.RollDPoS.ProbationThreshold = 50
+	cfg.Consensus.RollDPoS.SignedMsgDBPath = ""
+	err = ValidateRollDPoS(&cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "roll-DPoS signed message DB path should not be empty for a delegate"))
+
+	cfg.Consensus.RollDPoS.SignedMsgDBPath = Default.Consensus.RollDPoS.SignedMsgDBPath
+	require.NoError(t, ValidateRollDPoS(&cfg))
 }
 
 func TestValidateNetwork(t *testing.T) {
@@ -310,6 +414,110 @@ func TestValidateNetwork(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "either peer discover should be enabled or a topology should be given"),
 	)
+
+	cfg = Default
+	cfg.Network.PeerBanThreshold = 0
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "peer ban threshold should be negative"))
+	cfg.Network.PeerReputationEnabled = false
+	require.NoError(t, ValidateNetwork(&cfg))
+
+	cfg = Default
+	cfg.Network.PeerBanDuration = 0
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "peer ban duration should be greater than 0"))
+
+	cfg = Default
+	cfg.Network.PeerScoreCacheSize = 0
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "peer score cache size should be greater than 0"))
+
+	cfg = Default
+	cfg.Network.AddrBookBucketSize = 0
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "address book bucket size should be greater than 0"))
+
+	cfg = Default
+	cfg.Network.DiscoveryInterval = 0
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "discovery interval should be greater than 0"))
+
+	cfg = Default
+	cfg.Network.SeenCacheSize = 0
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "seen cache size should be greater than 0"))
+
+	cfg = Default
+	cfg.Network.RateLimitEnabled = true
+	cfg.Network.RateLimit.Bytes = RateLimit{Rate: 1024}
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "should be no less than max message size"))
+	cfg.Network.RateLimit.Bytes = RateLimit{Rate: 1024, Burst: uint64(cfg.Network.MaxMsgSize)}
+	require.NoError(t, ValidateNetwork(&cfg))
+	cfg.Network.RateLimitPath = "ratelimit.yaml"
+	cfg.Network.RateLimitReloadInterval = 0
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "rate limit reload interval should be greater than 0"))
+	cfg.Network.RateLimitPath = ""
+	cfg.Network.RateLimitMaxBuckets = 0
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "rate limit max buckets should be greater than 0"))
+
+	cfg = Default
+	cfg.Network.ExternalPort = 65536
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "external port should be between 0 and 65535"))
+
+	cfg = Default
+	cfg.Network.NATTraversal = "stun"
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "unknown NAT traversal mechanism stun"))
+	cfg.Network.NATTraversal = "upnp"
+	require.NoError(t, ValidateNetwork(&cfg))
+	cfg.Network.NATMappingLifetime = 0
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "NAT mapping lifetime should be greater than 0"))
+
+	cfg = Default
+	cfg.Network.ObservedAddrThreshold = -1
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "observed address threshold should not be negative"))
+
+	cfg = Default
+	cfg.Network.Transport = "quic"
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "unknown transport quic"))
+	cfg.Network.Transport = Libp2pTransport
+	require.NoError(t, ValidateNetwork(&cfg))
+	cfg.Network.Libp2pSecurity = "tls"
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "unknown libp2p security tls"))
+	cfg.Network.Libp2pSecurity = SecioSecurity
+	cfg.Network.Libp2pPrivKey = "xyz"
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "libp2p private key should be hex encoded"))
+
+	cfg = Default
+	cfg.Network.NodePubKey = cfg.Chain.ProducerPubKey
+	err = ValidateNetwork(&cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "error when decoding node private key"))
 }
 
 func TestValidateActPool(t *testing.T) {
@@ -15,7 +15,9 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/consensus/scheme/pbft"
 	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network"
@@ -24,6 +26,19 @@ import (
 	"github.com/iotexproject/iotex-core/proto"
 )
 
+func init() {
+	for name, creator := range map[string]SchemeCreator{
+		config.RollDPoSScheme:   newRollDPoS,
+		config.NOOPScheme:       newNoop,
+		config.StandaloneScheme: newStandalone,
+		config.PBFTScheme:       newPBFT,
+	} {
+		if err := RegisterScheme(name, creator); err != nil {
+			logger.Panic().Err(err).Msg("error when registering the built-in consensus schemes")
+		}
+	}
+}
+
 // Consensus is the interface for handling IotxConsensus view change.
 type Consensus interface {
 	lifecycle.StartStopper
@@ -31,8 +46,13 @@ type Consensus interface {
 	HandleBlockPropose(*iproto.ProposePb) error
 	HandleEndorse(*iproto.EndorsePb) error
 	Metrics() (scheme.ConsensusMetrics, error)
+	Productivity(epochNum uint64) (map[string]scheme.DelegateProductivity, error)
+	HandleCommittedBlock(blk *blockchain.Block)
 }
 
+// ErrProductivityNotTracked indicates that the consensus scheme doesn't track the delegates' productivity
+var ErrProductivityNotTracked = errors.New("consensus scheme doesn't track the delegates' productivity")
+
 // IotxConsensus implements Consensus
 type IotxConsensus struct {
 	cfg    *config.Consensus
@@ -88,39 +108,76 @@ func NewConsensus(
 		return nil
 	}
 
-	var err error
-	switch cfg.Consensus.Scheme {
-	case config.RollDPoSScheme:
-		cs.scheme, err = rolldpos.NewRollDPoSBuilder().
-			SetAddr(GetAddr(cfg)).
-			SetConfig(cfg.Consensus.RollDPoS).
-			SetBlockchain(bc).
-			SetActPool(ap).
-			SetP2P(p2p).
-			Build()
-		if err != nil {
-			logger.Panic().Err(err).Msg("error when constructing RollDPoS")
-		}
-	case config.NOOPScheme:
-		cs.scheme = scheme.NewNoop()
-	case config.StandaloneScheme:
-		cs.scheme = scheme.NewStandalone(
-			mintBlockCB,
-			commitBlockCB,
-			broadcastBlockCB,
-			bc,
-			cfg.Consensus.BlockCreationInterval,
-		)
-	default:
+	creator, err := schemeCreator(cfg.Consensus.Scheme)
+	if err != nil {
 		logger.Error().
+			Err(err).
 			Str("scheme", cfg.Consensus.Scheme).
 			Msg("Unexpected IotxConsensus scheme")
 		return nil
 	}
+	cs.scheme, err = creator(&SchemeDeps{
+		Config:           cfg,
+		Blockchain:       bc,
+		ActPool:          ap,
+		P2P:              p2p,
+		MintBlockCB:      mintBlockCB,
+		CommitBlockCB:    commitBlockCB,
+		BroadcastBlockCB: broadcastBlockCB,
+	})
+	if err != nil {
+		logger.Panic().Err(err).Str("scheme", cfg.Consensus.Scheme).Msg("error when constructing the consensus scheme")
+	}
 
 	return cs
 }
 
+func newRollDPoS(deps *SchemeDeps) (scheme.Scheme, error) {
+	cfg := deps.Config
+	bd := rolldpos.NewRollDPoSBuilder().
+		SetAddr(GetAddr(cfg)).
+		SetConfig(cfg.Consensus.RollDPoS).
+		SetBlockchain(deps.Blockchain).
+		SetActPool(deps.ActPool).
+		SetP2P(deps.P2P)
+	if cfg.Consensus.RollDPoS.SignedMsgDBPath != "" {
+		bd = bd.SetSignedMsgStore(db.NewBoltDB(cfg.Consensus.RollDPoS.SignedMsgDBPath, &cfg.DB))
+	}
+	if cfg.Consensus.RollDPoS.ProductivityDBPath != "" {
+		bd = bd.SetProductivityStore(db.NewBoltDB(cfg.Consensus.RollDPoS.ProductivityDBPath, &cfg.DB))
+	}
+	return bd.Build()
+}
+
+func newNoop(_ *SchemeDeps) (scheme.Scheme, error) {
+	return scheme.NewNoop(), nil
+}
+
+func newStandalone(deps *SchemeDeps) (scheme.Scheme, error) {
+	return scheme.NewStandalone(
+		deps.MintBlockCB,
+		deps.CommitBlockCB,
+		deps.BroadcastBlockCB,
+		deps.Blockchain,
+		deps.Config.Consensus.BlockCreationInterval,
+	), nil
+}
+
+func newPBFT(deps *SchemeDeps) (scheme.Scheme, error) {
+	cfg := pbft.DefaultConfig
+	if err := DecodeSchemeConfig(deps.Config, config.PBFTScheme, &cfg); err != nil {
+		return nil, err
+	}
+	return pbft.NewPBFTBuilder().
+		SetAddr(GetAddr(deps.Config)).
+		SetConfig(cfg).
+		SetBlockchain(deps.Blockchain).
+		SetP2P(deps.P2P).
+		SetMintBlockCB(deps.MintBlockCB).
+		SetCommitBlockCB(deps.CommitBlockCB).
+		Build()
+}
+
 // Start starts running the consensus algorithm
 func (c *IotxConsensus) Start(ctx context.Context) error {
 	logger.Info().
@@ -152,6 +209,23 @@ func (c *IotxConsensus) Metrics() (scheme.ConsensusMetrics, error) {
 	return c.scheme.Metrics()
 }
 
+// Productivity returns the delegates' productivity in the epoch, if the scheme tracks it
+func (c *IotxConsensus) Productivity(epochNum uint64) (map[string]scheme.DelegateProductivity, error) {
+	reporter, ok := c.scheme.(scheme.ProductivityReporter)
+	if !ok {
+		return nil, errors.Wrapf(ErrProductivityNotTracked, "scheme %s", c.cfg.Scheme)
+	}
+	return reporter.Productivity(epochNum)
+}
+
+// HandleCommittedBlock passes a block committed out of the consensus, e.g., by the block sync, to the scheme tracking
+// the delegates' productivity
+func (c *IotxConsensus) HandleCommittedBlock(blk *blockchain.Block) {
+	if reporter, ok := c.scheme.(scheme.ProductivityReporter); ok {
+		reporter.HandleCommittedBlock(blk)
+	}
+}
+
 // HandleBlockPropose handles a proposed block
 func (c *IotxConsensus) HandleBlockPropose(propose *iproto.ProposePb) error {
 	return c.scheme.HandleBlockPropose(propose)
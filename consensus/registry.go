@@ -0,0 +1,102 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/network"
+)
+
+var (
+	// ErrSchemeRegistered indicates that a scheme of the same name has been registered
+	ErrSchemeRegistered = errors.New("consensus scheme has been registered")
+	// ErrSchemeNotFound indicates that the scheme of the name has not been registered
+	ErrSchemeNotFound = errors.New("consensus scheme not found")
+)
+
+// SchemeDeps contains the components of the node that a consensus scheme could use
+type SchemeDeps struct {
+	Config     *config.Config
+	Blockchain blockchain.Blockchain
+	ActPool    actpool.ActPool
+	P2P        network.Overlay
+	// MintBlockCB mints a new block with the actions picked from the action pool
+	MintBlockCB scheme.CreateBlockCB
+	// CommitBlockCB commits the block and resets the action pool
+	CommitBlockCB scheme.ConsensusDoneCB
+	// BroadcastBlockCB broadcasts the block to the network
+	BroadcastBlockCB scheme.BroadcastCB
+}
+
+// SchemeCreator creates a consensus scheme
+type SchemeCreator func(deps *SchemeDeps) (scheme.Scheme, error)
+
+var (
+	schemesMu sync.RWMutex
+	schemes   = make(map[string]SchemeCreator)
+)
+
+// RegisterScheme registers a consensus scheme by name, which is chosen by config.Consensus.Scheme. It's usually called
+// in the init function of the package implementing the scheme.
+func RegisterScheme(name string, creator SchemeCreator) error {
+	schemesMu.Lock()
+	defer schemesMu.Unlock()
+	if _, ok := schemes[name]; ok {
+		return errors.Wrapf(ErrSchemeRegistered, "scheme %s", name)
+	}
+	schemes[name] = creator
+	return nil
+}
+
+// RegisteredSchemes returns the names of the registered consensus schemes in alphabetic order
+func RegisteredSchemes() []string {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func schemeCreator(name string) (SchemeCreator, error) {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+	creator, ok := schemes[name]
+	if !ok {
+		return nil, errors.Wrapf(ErrSchemeNotFound, "scheme %s", name)
+	}
+	return creator, nil
+}
+
+// DecodeSchemeConfig decodes the config section of a registered scheme, e.g., PBFT or a scheme registered out of this
+// repo, which is kept in config.Consensus.Schemes under the scheme name, into the given struct with yaml tags. The
+// struct is left untouched if the section doesn't exist.
+func DecodeSchemeConfig(cfg *config.Config, name string, out interface{}) error {
+	section, ok := cfg.Consensus.Schemes[name]
+	if !ok {
+		return nil
+	}
+	b, err := yaml.Marshal(section)
+	if err != nil {
+		return errors.Wrapf(err, "error when encoding the config of scheme %s", name)
+	}
+	if err := yaml.Unmarshal(b, out); err != nil {
+		return errors.Wrapf(err, "error when decoding the config of scheme %s", name)
+	}
+	return nil
+}
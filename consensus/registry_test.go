@@ -0,0 +1,90 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/consensus/scheme/pbft"
+)
+
+func TestRegisterScheme(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(
+		[]string{config.NOOPScheme, config.PBFTScheme, config.RollDPoSScheme, config.StandaloneScheme},
+		RegisteredSchemes(),
+	)
+	err := RegisterScheme(config.NOOPScheme, newNoop)
+	require.Equal(ErrSchemeRegistered, errors.Cause(err))
+	_, err = schemeCreator("POW")
+	require.Equal(ErrSchemeNotFound, errors.Cause(err))
+
+	require.NoError(RegisterScheme("POW", func(_ *SchemeDeps) (scheme.Scheme, error) {
+		return scheme.NewNoop(), nil
+	}))
+	defer func() {
+		schemesMu.Lock()
+		delete(schemes, "POW")
+		schemesMu.Unlock()
+	}()
+	creator, err := schemeCreator("POW")
+	require.NoError(err)
+	s, err := creator(&SchemeDeps{})
+	require.NoError(err)
+	require.NotNil(s)
+}
+
+func TestDecodeSchemeConfig(t *testing.T) {
+	require := require.New(t)
+
+	type powConfig struct {
+		Difficulty    uint64        `yaml:"difficulty"`
+		BlockInterval time.Duration `yaml:"blockInterval"`
+	}
+	var cfg config.Config
+	require.NoError(yaml.Unmarshal([]byte(`
+consensus:
+  scheme: POW
+  schemes:
+    POW:
+      difficulty: 20
+      blockInterval: 5s
+`), &cfg))
+
+	pow := powConfig{Difficulty: 1}
+	require.NoError(DecodeSchemeConfig(&cfg, "POW", &pow))
+	require.Equal(powConfig{Difficulty: 20, BlockInterval: 5 * time.Second}, pow)
+
+	// The config is untouched without its section
+	pow = powConfig{Difficulty: 1}
+	require.NoError(DecodeSchemeConfig(&cfg, "POS", &pow))
+	require.Equal(powConfig{Difficulty: 1}, pow)
+
+	// The built-in PBFT keeps its config in the schemes too
+	require.NoError(yaml.Unmarshal([]byte(`
+consensus:
+  scheme: PBFT
+  schemes:
+    PBFT:
+      validators: [io1a, io1b]
+      roundTimeout: 3s
+`), &cfg))
+	pbftCfg := pbft.DefaultConfig
+	require.NoError(DecodeSchemeConfig(&cfg, config.PBFTScheme, &pbftCfg))
+	require.Equal([]string{"io1a", "io1b"}, pbftCfg.Validators)
+	require.Equal(3*time.Second, pbftCfg.RoundTimeout)
+	require.Equal(pbft.DefaultConfig.BlockInterval, pbftCfg.BlockInterval)
+	require.NoError(pbft.ValidateConfig(pbftCfg))
+}
@@ -0,0 +1,656 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package pbft
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/network"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+var (
+	// ErrNewPBFT indicates the error of constructing PBFT
+	ErrNewPBFT = errors.New("error when constructing PBFT")
+)
+
+// Config is the config struct of PBFT, which is kept in config.Consensus.Schemes under config.PBFTScheme
+type Config struct {
+	// Validators are the addresses of the fixed validator set, which take turns to propose blocks in this order
+	Validators []string `yaml:"validators"`
+	// BlockInterval is the time that the proposer waits after the last block is committed
+	BlockInterval time.Duration `yaml:"blockInterval"`
+	// RoundTimeout is the time to wait for a round to commit the block before changing the round, which doubles in
+	// each of the following rounds at the same height
+	RoundTimeout  time.Duration `yaml:"roundTimeout"`
+	EventChanSize uint          `yaml:"eventChanSize"`
+}
+
+// DefaultConfig is the default config of PBFT, which is overridden by the config section of the scheme
+var DefaultConfig = Config{
+	BlockInterval: 10 * time.Second,
+	RoundTimeout:  10 * time.Second,
+	EventChanSize: 10000,
+}
+
+// ValidateConfig validates the config of PBFT
+func ValidateConfig(cfg Config) error {
+	if len(cfg.Validators) == 0 {
+		return errors.Wrap(config.ErrInvalidCfg, "PBFT validators should not be empty")
+	}
+	validators := make(map[string]bool)
+	for _, validator := range cfg.Validators {
+		if validators[validator] {
+			return errors.Wrapf(config.ErrInvalidCfg, "duplicate PBFT validator %s", validator)
+		}
+		validators[validator] = true
+	}
+	if cfg.EventChanSize <= 0 {
+		return errors.Wrap(config.ErrInvalidCfg, "PBFT event chan size should be greater than 0")
+	}
+	if cfg.RoundTimeout <= 0 {
+		return errors.Wrap(config.ErrInvalidCfg, "PBFT round timeout should be greater than 0")
+	}
+	return nil
+}
+
+// maxTimeoutShift caps the doubling of the round timeout
+const maxTimeoutShift = 6
+
+type proposeEvt struct {
+	proposer string
+	block    *blockchain.Block
+	round    uint32
+}
+
+// proposeTimerEvt tells the proposer of the round to propose the block
+type proposeTimerEvt struct {
+	height uint64
+	round  uint32
+}
+
+// timeoutEvt indicates that the round hasn't committed a block in time
+type timeoutEvt struct {
+	height uint64
+	round  uint32
+}
+
+// voteSet counts the votes on the block hashes by round
+type voteSet map[uint32]map[hash.Hash32B]map[string]bool
+
+func (s voteSet) add(v *vote) {
+	if _, ok := s[v.round]; !ok {
+		s[v.round] = make(map[hash.Hash32B]map[string]bool)
+	}
+	if _, ok := s[v.round][v.blkHash]; !ok {
+		s[v.round][v.blkHash] = make(map[string]bool)
+	}
+	s[v.round][v.blkHash][v.endorser] = true
+}
+
+func (s voteSet) count(round uint32, blkHash hash.Hash32B) int {
+	return len(s[round][blkHash])
+}
+
+func (s voteSet) has(round uint32, blkHash hash.Hash32B, endorser string) bool {
+	return s[round][blkHash][endorser]
+}
+
+// heightCtx keeps the consensus state of the height being agreed on
+type heightCtx struct {
+	height uint64
+	round  uint32
+	// proposals are the valid proposed blocks keyed by round
+	proposals map[uint32]*blockchain.Block
+	// blocks are the valid proposed blocks keyed by hash
+	blocks       map[hash.Hash32B]*blockchain.Block
+	prepares     voteSet
+	commits      voteSet
+	roundChanges voteSet
+	// prepared and committed indicate whether the validator has sent the prepare and the commit of the current round
+	prepared  bool
+	committed bool
+	// lockedBlock is the block with a prepare quorum in the highest round, which is the only block that the validator
+	// prepares and proposes in the following rounds of the height
+	lockedBlock *blockchain.Block
+	lockedRound uint32
+}
+
+func newHeightCtx(height uint64) *heightCtx {
+	return &heightCtx{
+		height:       height,
+		proposals:    make(map[uint32]*blockchain.Block),
+		blocks:       make(map[hash.Hash32B]*blockchain.Block),
+		prepares:     make(voteSet),
+		commits:      make(voteSet),
+		roundChanges: make(voteSet),
+	}
+}
+
+// PBFT is a permissioned BFT consensus scheme among a fixed set of validators. The validators take turns to propose
+// blocks. A block is committed in three phases: the proposer broadcasts the block in ProposePb, the validators prepare
+// it with an EndorsePb of PROPOSAL, and once more than 2/3 of the validators have prepared it, they broadcast an
+// EndorsePb of COMMIT. The block is committed with more than 2/3 of the commits. If a round doesn't commit a block in
+// time, the validators ask to move to the next round, whose proposer is the next validator, with EndorsePb of
+// ROUND_CHANGE. A node whose address is not a validator follows the consensus without voting.
+type PBFT struct {
+	cfg           Config
+	addr          *iotxaddress.Address
+	chain         blockchain.Blockchain
+	p2p           network.Overlay
+	mintBlockCB   scheme.CreateBlockCB
+	commitBlockCB scheme.ConsensusDoneCB
+	clock         clock.Clock
+	validators    []string
+	validatorSet  map[string]bool
+	isValidator   bool
+
+	evtq  chan interface{}
+	close chan struct{}
+	wg    sync.WaitGroup
+	// ctx is only accessed by the event loop
+	ctx *heightCtx
+	// mutex guards the height and round exposed by Metrics
+	mutex  sync.RWMutex
+	height uint64
+	round  uint32
+}
+
+// Start starts PBFT consensus
+func (p *PBFT) Start(_ context.Context) error {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.startHeight()
+		for {
+			select {
+			case <-p.close:
+				return
+			case evt := <-p.evtq:
+				p.handle(evt)
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops PBFT consensus
+func (p *PBFT) Stop(_ context.Context) error {
+	close(p.close)
+	p.wg.Wait()
+	return nil
+}
+
+// HandleBlockPropose handles incoming block propose
+func (p *PBFT) HandleBlockPropose(propose *iproto.ProposePb) error {
+	if propose.Block == nil {
+		return errors.New("the proposal doesn't contain a block")
+	}
+	blk := &blockchain.Block{}
+	blk.ConvertFromBlockPb(propose.Block)
+	p.enqueue(&proposeEvt{proposer: propose.Proposer, block: blk, round: propose.Round})
+	return nil
+}
+
+// HandleEndorse handles incoming endorse
+func (p *PBFT) HandleEndorse(ePb *iproto.EndorsePb) error {
+	if !ePb.Decision {
+		// The validators only vote for a block or a round
+		return nil
+	}
+	v := &vote{}
+	if err := v.fromProtoMsg(ePb); err != nil {
+		return errors.Wrap(err, "error when casting a proto msg to vote")
+	}
+	p.enqueue(v)
+	return nil
+}
+
+// SetDoneStream does nothing for PBFT (only used in simulator)
+func (p *PBFT) SetDoneStream(chan bool) {}
+
+// Metrics returns PBFT consensus metrics
+func (p *PBFT) Metrics() (scheme.ConsensusMetrics, error) {
+	p.mutex.RLock()
+	height, round := p.height, p.round
+	p.mutex.RUnlock()
+	return scheme.ConsensusMetrics{
+		LatestHeight:        p.chain.TipHeight(),
+		LatestRound:         round,
+		LatestDelegates:     p.validators,
+		LatestBlockProducer: p.proposer(height, round),
+		Candidates:          p.validators,
+	}, nil
+}
+
+func (p *PBFT) enqueue(evt interface{}) {
+	select {
+	case <-p.close:
+	case p.evtq <- evt:
+	default:
+		logger.Warn().Msg("PBFT event queue is full, the event is dropped")
+	}
+}
+
+func (p *PBFT) schedule(evt interface{}, d time.Duration) {
+	p.clock.AfterFunc(d, func() { p.enqueue(evt) })
+}
+
+func (p *PBFT) handle(evt interface{}) {
+	// The blocks could be committed by block sync when the validator falls behind
+	if p.chain.TipHeight() >= p.ctx.height {
+		p.startHeight()
+	}
+	switch e := evt.(type) {
+	case *proposeEvt:
+		p.handlePropose(e)
+	case *vote:
+		p.handleVote(e)
+	case *proposeTimerEvt:
+		if e.height == p.ctx.height && e.round == p.ctx.round {
+			p.propose()
+		}
+	case *timeoutEvt:
+		p.handleTimeout(e)
+	default:
+		logger.Error().Msgf("unexpected PBFT event %T", evt)
+	}
+}
+
+// quorum returns the number of votes of more than 2/3 of the validators
+func (p *PBFT) quorum() int {
+	return len(p.validators)*2/3 + 1
+}
+
+// proposer returns the validator proposing the block at the height and round
+func (p *PBFT) proposer(height uint64, round uint32) string {
+	return p.validators[(height+uint64(round))%uint64(len(p.validators))]
+}
+
+func (p *PBFT) roundTimeout(round uint32) time.Duration {
+	if round > maxTimeoutShift {
+		round = maxTimeoutShift
+	}
+	return p.cfg.RoundTimeout << round
+}
+
+func (p *PBFT) startHeight() {
+	p.ctx = newHeightCtx(p.chain.TipHeight() + 1)
+	p.startRound(0)
+}
+
+func (p *PBFT) startRound(round uint32) {
+	height := p.ctx.height
+	p.ctx.round = round
+	p.ctx.prepared = false
+	p.ctx.committed = false
+	p.mutex.Lock()
+	p.height, p.round = height, round
+	p.mutex.Unlock()
+	logger.Debug().Uint64("height", height).Uint32("round", round).Msg("PBFT enters a new round")
+
+	timeout := p.roundTimeout(round)
+	if round == 0 {
+		timeout += p.cfg.BlockInterval
+	}
+	p.schedule(&timeoutEvt{height: height, round: round}, timeout)
+	if p.isValidator && p.proposer(height, round) == p.addr.RawAddress {
+		if round == 0 {
+			p.schedule(&proposeTimerEvt{height: height, round: round}, p.cfg.BlockInterval)
+		} else {
+			p.propose()
+		}
+	}
+	// The proposal and the votes of the round could arrive before the validator enters it
+	if !p.inRound(height, round) {
+		return
+	}
+	p.prepare()
+	for blkHash := range p.ctx.prepares[round] {
+		if !p.inRound(height, round) {
+			return
+		}
+		p.checkPrepares(round, blkHash)
+	}
+}
+
+func (p *PBFT) inRound(height uint64, round uint32) bool {
+	return p.ctx.height == height && p.ctx.round == round
+}
+
+func (p *PBFT) propose() {
+	blk := p.ctx.lockedBlock
+	if blk == nil {
+		var err error
+		if blk, err = p.mintBlockCB(); err != nil {
+			logger.Error().Err(err).Uint64("height", p.ctx.height).Msg("error when minting a block")
+			return
+		}
+	}
+	if blk.Height() != p.ctx.height {
+		logger.Error().
+			Uint64("height", p.ctx.height).
+			Uint64("blockHeight", blk.Height()).
+			Msg("the minted block is not at the consensus height")
+		return
+	}
+	e := &proposeEvt{proposer: p.addr.RawAddress, block: blk, round: p.ctx.round}
+	p.broadcast(&iproto.ProposePb{Proposer: e.proposer, Block: blk.ConvertToBlockPb(), Round: e.round})
+	p.handlePropose(e)
+}
+
+func (p *PBFT) handlePropose(e *proposeEvt) {
+	blk := e.block
+	height := p.ctx.height
+	if blk.Height() != height {
+		logger.Debug().
+			Uint64("height", height).
+			Uint64("blockHeight", blk.Height()).
+			Msg("ignore the proposal not at the consensus height")
+		return
+	}
+	if _, ok := p.ctx.proposals[e.round]; ok {
+		return
+	}
+	if e.proposer != p.proposer(height, e.round) {
+		logger.Warn().
+			Str("proposer", e.proposer).
+			Uint64("height", height).
+			Uint32("round", e.round).
+			Msg("the proposal is not from the proposer of the round")
+		return
+	}
+	// The block could be re-proposed by another validator after the one minting it is locked on it
+	if !p.validatorSet[blk.ProducerAddress()] {
+		logger.Warn().Str("producer", blk.ProducerAddress()).Msg("the block is not produced by a validator")
+		return
+	}
+	if !blk.VerifySignature() {
+		logger.Warn().Str("producer", blk.ProducerAddress()).Msg("the block signature is invalid")
+		return
+	}
+	blkHash := blk.HashBlock()
+	if _, ok := p.ctx.blocks[blkHash]; !ok && e.proposer != p.addrString() {
+		if err := p.chain.ValidateBlock(blk, true); err != nil {
+			logger.Warn().Err(err).Uint64("height", height).Msg("the proposed block is invalid")
+			return
+		}
+	}
+	p.ctx.proposals[e.round] = blk
+	p.ctx.blocks[blkHash] = blk
+	logger.Debug().
+		Str("proposer", e.proposer).
+		Uint64("height", height).
+		Uint32("round", e.round).
+		Msg("PBFT receives a proposal")
+
+	if e.round == p.ctx.round {
+		p.prepare()
+	}
+	// The votes on the block could arrive before the block
+	for round := range p.ctx.prepares {
+		if p.ctx.height != height {
+			return
+		}
+		p.checkPrepares(round, blkHash)
+	}
+	for round := range p.ctx.commits {
+		if p.ctx.height != height {
+			return
+		}
+		p.checkCommits(round, blkHash)
+	}
+}
+
+func (p *PBFT) addrString() string {
+	if p.addr == nil {
+		return ""
+	}
+	return p.addr.RawAddress
+}
+
+// prepare votes for the proposal of the current round unless the validator is locked on another block
+func (p *PBFT) prepare() {
+	if p.ctx.prepared || !p.isValidator {
+		return
+	}
+	blk, ok := p.ctx.proposals[p.ctx.round]
+	if !ok {
+		return
+	}
+	blkHash := blk.HashBlock()
+	if p.ctx.lockedBlock != nil && p.ctx.lockedBlock.HashBlock() != blkHash {
+		logger.Debug().
+			Uint64("height", p.ctx.height).
+			Uint32("lockedRound", p.ctx.lockedRound).
+			Msg("the validator is locked on another block")
+		return
+	}
+	p.ctx.prepared = true
+	p.vote(iproto.EndorsePb_PROPOSAL, p.ctx.round, blkHash)
+}
+
+func (p *PBFT) handleVote(v *vote) {
+	if v.height != p.ctx.height {
+		return
+	}
+	if !p.validatorSet[v.endorser] {
+		logger.Warn().Str("endorser", v.endorser).Msg("the endorser is not a validator")
+		return
+	}
+	if !v.VerifySignature() {
+		logger.Warn().Str("endorser", v.endorser).Msg("the vote signature is invalid")
+		return
+	}
+	p.addVote(v)
+}
+
+func (p *PBFT) addVote(v *vote) {
+	switch v.topic {
+	case iproto.EndorsePb_PROPOSAL:
+		p.ctx.prepares.add(v)
+		p.checkPrepares(v.round, v.blkHash)
+	case iproto.EndorsePb_COMMIT:
+		p.ctx.commits.add(v)
+		p.checkCommits(v.round, v.blkHash)
+	case iproto.EndorsePb_ROUND_CHANGE:
+		p.ctx.roundChanges.add(v)
+		p.checkRoundChanges(v.round)
+	}
+}
+
+// checkPrepares locks the block prepared by a quorum, and commits it if it's prepared in the current round
+func (p *PBFT) checkPrepares(round uint32, blkHash hash.Hash32B) {
+	blk, ok := p.ctx.blocks[blkHash]
+	if !ok || p.ctx.prepares.count(round, blkHash) < p.quorum() {
+		return
+	}
+	if p.ctx.lockedBlock == nil || round > p.ctx.lockedRound {
+		p.ctx.lockedBlock = blk
+		p.ctx.lockedRound = round
+		p.prepare()
+	}
+	if round == p.ctx.round && !p.ctx.committed && p.isValidator {
+		p.ctx.committed = true
+		p.vote(iproto.EndorsePb_COMMIT, round, blkHash)
+	}
+}
+
+func (p *PBFT) checkCommits(round uint32, blkHash hash.Hash32B) {
+	blk, ok := p.ctx.blocks[blkHash]
+	if !ok || p.ctx.commits.count(round, blkHash) < p.quorum() {
+		return
+	}
+	if err := p.commitBlockCB(blk); err != nil {
+		logger.Error().Err(err).Uint64("height", blk.Height()).Msg("error when committing the block")
+		return
+	}
+	logger.Info().
+		Uint64("height", blk.Height()).
+		Uint32("round", round).
+		Str("producer", blk.ProducerAddress()).
+		Msg("PBFT commits a block")
+	p.broadcast(blk.ConvertToBlockPb())
+	p.startHeight()
+}
+
+// checkRoundChanges moves to the round if a quorum asks for it, and joins the round change if at least one honest
+// validator asks for it
+func (p *PBFT) checkRoundChanges(round uint32) {
+	if round <= p.ctx.round {
+		return
+	}
+	count := p.ctx.roundChanges.count(round, hash.ZeroHash32B)
+	if count >= p.quorum() {
+		p.startRound(round)
+		return
+	}
+	if count > len(p.validators)-p.quorum() && p.isValidator &&
+		!p.ctx.roundChanges.has(round, hash.ZeroHash32B, p.addr.RawAddress) {
+		p.vote(iproto.EndorsePb_ROUND_CHANGE, round, hash.ZeroHash32B)
+	}
+}
+
+func (p *PBFT) handleTimeout(e *timeoutEvt) {
+	if !p.inRound(e.height, e.round) {
+		return
+	}
+	logger.Warn().Uint64("height", e.height).Uint32("round", e.round).Msg("PBFT round timeout")
+	// Keep asking for the round change in case the votes are lost
+	p.schedule(e, p.roundTimeout(e.round))
+	if p.isValidator {
+		p.vote(iproto.EndorsePb_ROUND_CHANGE, e.round+1, hash.ZeroHash32B)
+	}
+}
+
+func (p *PBFT) vote(topic iproto.EndorsePb_EndorsementTopic, round uint32, blkHash hash.Hash32B) {
+	v := &vote{
+		topic:   topic,
+		height:  p.ctx.height,
+		round:   round,
+		blkHash: blkHash,
+	}
+	if err := v.Sign(p.addr); err != nil {
+		logger.Error().Err(err).Msg("error when signing the vote")
+		return
+	}
+	p.broadcast(v.toProtoMsg())
+	p.addVote(v)
+}
+
+func (p *PBFT) broadcast(msg proto.Message) {
+	if err := p.p2p.Broadcast(p.chain.ChainID(), msg); err != nil {
+		logger.Error().Err(err).Msg("error when broadcasting the PBFT message")
+	}
+}
+
+// Builder is the builder for PBFT
+type Builder struct {
+	cfg           Config
+	addr          *iotxaddress.Address
+	chain         blockchain.Blockchain
+	p2p           network.Overlay
+	mintBlockCB   scheme.CreateBlockCB
+	commitBlockCB scheme.ConsensusDoneCB
+	clock         clock.Clock
+}
+
+// NewPBFTBuilder instantiates a Builder instance
+func NewPBFTBuilder() *Builder {
+	return &Builder{}
+}
+
+// SetConfig sets PBFT config
+func (b *Builder) SetConfig(cfg Config) *Builder {
+	b.cfg = cfg
+	return b
+}
+
+// SetAddr sets the address and key pair for signature
+func (b *Builder) SetAddr(addr *iotxaddress.Address) *Builder {
+	b.addr = addr
+	return b
+}
+
+// SetBlockchain sets the blockchain APIs
+func (b *Builder) SetBlockchain(chain blockchain.Blockchain) *Builder {
+	b.chain = chain
+	return b
+}
+
+// SetP2P sets the P2P APIs
+func (b *Builder) SetP2P(p2p network.Overlay) *Builder {
+	b.p2p = p2p
+	return b
+}
+
+// SetMintBlockCB sets the callback to mint a new block
+func (b *Builder) SetMintBlockCB(cb scheme.CreateBlockCB) *Builder {
+	b.mintBlockCB = cb
+	return b
+}
+
+// SetCommitBlockCB sets the callback to commit the agreed block
+func (b *Builder) SetCommitBlockCB(cb scheme.ConsensusDoneCB) *Builder {
+	b.commitBlockCB = cb
+	return b
+}
+
+// SetClock sets the clock
+func (b *Builder) SetClock(clock clock.Clock) *Builder {
+	b.clock = clock
+	return b
+}
+
+// Build builds a PBFT consensus module
+func (b *Builder) Build() (*PBFT, error) {
+	if b.chain == nil {
+		return nil, errors.Wrap(ErrNewPBFT, "blockchain APIs is nil")
+	}
+	if b.p2p == nil {
+		return nil, errors.Wrap(ErrNewPBFT, "p2p APIs is nil")
+	}
+	if b.mintBlockCB == nil || b.commitBlockCB == nil {
+		return nil, errors.Wrap(ErrNewPBFT, "block callbacks are nil")
+	}
+	if err := ValidateConfig(b.cfg); err != nil {
+		return nil, errors.Wrap(ErrNewPBFT, err.Error())
+	}
+	if b.clock == nil {
+		b.clock = clock.New()
+	}
+	validatorSet := make(map[string]bool)
+	for _, validator := range b.cfg.Validators {
+		validatorSet[validator] = true
+	}
+	return &PBFT{
+		cfg:           b.cfg,
+		addr:          b.addr,
+		chain:         b.chain,
+		p2p:           b.p2p,
+		mintBlockCB:   b.mintBlockCB,
+		commitBlockCB: b.commitBlockCB,
+		clock:         b.clock,
+		validators:    b.cfg.Validators,
+		validatorSet:  validatorSet,
+		isValidator:   b.addr != nil && validatorSet[b.addr.RawAddress],
+		evtq:          make(chan interface{}, b.cfg.EventChanSize),
+		close:         make(chan struct{}),
+	}, nil
+}
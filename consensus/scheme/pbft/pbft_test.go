@@ -0,0 +1,292 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package pbft
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+type directOverlay struct {
+	peers []*PBFT
+}
+
+func (o *directOverlay) Start(_ context.Context) error { return nil }
+
+func (o *directOverlay) Stop(_ context.Context) error { return nil }
+
+func (o *directOverlay) Broadcast(chainID uint32, msg proto.Message) error {
+	// Only broadcast consensus message
+	if propose, ok := msg.(*iproto.ProposePb); ok {
+		for _, p := range o.peers {
+			if err := p.HandleBlockPropose(propose); err != nil {
+				return errors.Wrap(err, "error when handling block propose directly")
+			}
+		}
+	} else if endorse, ok := msg.(*iproto.EndorsePb); ok {
+		for _, p := range o.peers {
+			if err := p.HandleEndorse(endorse); err != nil {
+				return errors.Wrap(err, "error when handling endorse directly")
+			}
+		}
+	}
+	return nil
+}
+
+func (o *directOverlay) Tell(uint32, net.Addr, proto.Message) error { return nil }
+
+func (o *directOverlay) Self() net.Addr { return nil }
+
+func (o *directOverlay) GetPeers() []net.Addr { return nil }
+
+func (o *directOverlay) BannedPeers() map[string]time.Time { return nil }
+
+func newTestAddr() *iotxaddress.Address {
+	addr, err := iotxaddress.NewAddress(iotxaddress.IsTestnet, iotxaddress.ChainID)
+	if err != nil {
+		logger.Panic().Err(err).Msg("error when creating test IoTeX address")
+	}
+	return addr
+}
+
+func TestVote(t *testing.T) {
+	require := require.New(t)
+
+	addr := newTestAddr()
+	v := &vote{
+		topic:   iproto.EndorsePb_COMMIT,
+		height:  10,
+		round:   2,
+		blkHash: hash.Hash32B{1, 2, 3},
+	}
+	require.NoError(v.Sign(addr))
+	require.True(v.VerifySignature())
+
+	v2 := &vote{}
+	require.NoError(v2.fromProtoMsg(v.toProtoMsg()))
+	require.Equal(v, v2)
+	require.True(v2.VerifySignature())
+
+	// The signature doesn't cover another round
+	v2.round = 3
+	require.False(v2.VerifySignature())
+
+	// The public key doesn't belong to the endorser
+	v.endorser = newTestAddr().RawAddress
+	require.False(v.VerifySignature())
+}
+
+func TestBuild(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	pbftCfg := DefaultConfig
+	chain := blockchain.NewBlockchain(&cfg, blockchain.InMemDaoOption(), blockchain.InMemStateFactoryOption())
+	mint := func() (*blockchain.Block, error) { return nil, nil }
+	commit := func(*blockchain.Block) error { return nil }
+
+	_, err := NewPBFTBuilder().
+		SetConfig(pbftCfg).
+		SetBlockchain(chain).
+		SetP2P(&directOverlay{}).
+		SetMintBlockCB(mint).
+		SetCommitBlockCB(commit).
+		Build()
+	require.Equal(ErrNewPBFT, errors.Cause(err))
+
+	addr := newTestAddr()
+	pbftCfg.Validators = []string{newTestAddr().RawAddress}
+	p, err := NewPBFTBuilder().
+		SetConfig(pbftCfg).
+		SetAddr(addr).
+		SetBlockchain(chain).
+		SetP2P(&directOverlay{}).
+		SetMintBlockCB(mint).
+		SetCommitBlockCB(commit).
+		Build()
+	require.NoError(err)
+	require.False(p.isValidator)
+	require.Equal(1, p.quorum())
+
+	pbftCfg.Validators = append(pbftCfg.Validators, addr.RawAddress, "a", "b")
+	p, err = NewPBFTBuilder().
+		SetConfig(pbftCfg).
+		SetAddr(addr).
+		SetBlockchain(chain).
+		SetP2P(&directOverlay{}).
+		SetMintBlockCB(mint).
+		SetCommitBlockCB(commit).
+		Build()
+	require.NoError(err)
+	require.True(p.isValidator)
+	require.Equal(3, p.quorum())
+	require.Equal("a", p.proposer(1, 1))
+	require.Equal(addr.RawAddress, p.proposer(6, 3))
+	require.Equal(pbftCfg.RoundTimeout<<maxTimeoutShift, p.roundTimeout(100))
+}
+
+func TestValidateConfig(t *testing.T) {
+	require := require.New(t)
+
+	cfg := DefaultConfig
+	err := ValidateConfig(cfg)
+	require.Equal(config.ErrInvalidCfg, errors.Cause(err))
+	require.Contains(err.Error(), "PBFT validators should not be empty")
+
+	cfg.Validators = []string{"io1a", "io1b", "io1a"}
+	err = ValidateConfig(cfg)
+	require.Equal(config.ErrInvalidCfg, errors.Cause(err))
+	require.Contains(err.Error(), "duplicate PBFT validator io1a")
+
+	cfg.Validators = []string{"io1a", "io1b"}
+	require.NoError(ValidateConfig(cfg))
+	cfg.RoundTimeout = 0
+	err = ValidateConfig(cfg)
+	require.Equal(config.ErrInvalidCfg, errors.Cause(err))
+	require.Contains(err.Error(), "PBFT round timeout should be greater than 0")
+}
+
+func TestPBFTConsensus(t *testing.T) {
+	t.Parallel()
+
+	newConsensusComponents := func(numNodes int) ([]*PBFT, []blockchain.Blockchain) {
+		cfg := config.Default
+		cfg.Consensus.Scheme = config.PBFTScheme
+		pbftCfg := DefaultConfig
+		pbftCfg.BlockInterval = 100 * time.Millisecond
+		pbftCfg.RoundTimeout = 500 * time.Millisecond
+
+		addrs := make([]*iotxaddress.Address, 0, numNodes)
+		for i := 0; i < numNodes; i++ {
+			addr := newTestAddr()
+			addrs = append(addrs, addr)
+			pbftCfg.Validators = append(pbftCfg.Validators, addr.RawAddress)
+		}
+
+		chains := make([]blockchain.Blockchain, 0, numNodes)
+		p2ps := make([]*directOverlay, 0, numNodes)
+		cs := make([]*PBFT, 0, numNodes)
+		for i := 0; i < numNodes; i++ {
+			chain := blockchain.NewBlockchain(&cfg, blockchain.InMemDaoOption(), blockchain.InMemStateFactoryOption())
+			chains = append(chains, chain)
+			p2p := &directOverlay{}
+			p2ps = append(p2ps, p2p)
+
+			addr := addrs[i]
+			consensus, err := NewPBFTBuilder().
+				SetConfig(pbftCfg).
+				SetAddr(addr).
+				SetBlockchain(chain).
+				SetP2P(p2p).
+				SetMintBlockCB(func() (*blockchain.Block, error) {
+					return chain.MintNewBlock(nil, nil, nil, addr, "")
+				}).
+				SetCommitBlockCB(chain.CommitBlock).
+				Build()
+			require.NoError(t, err)
+			cs = append(cs, consensus)
+		}
+		for i := 0; i < numNodes; i++ {
+			for j := 0; j < numNodes; j++ {
+				if i != j {
+					p2ps[i].peers = append(p2ps[i].peers, cs[j])
+				}
+			}
+		}
+		return cs, chains
+	}
+
+	run := func(t *testing.T, numNodes int, numDown int, height uint64) {
+		ctx := context.Background()
+		cs, chains := newConsensusComponents(numNodes)
+		for i := 0; i < numNodes; i++ {
+			require.NoError(t, chains[i].Start(ctx))
+			// The down validators neither handle nor send any message
+			if i >= numNodes-numDown {
+				continue
+			}
+			require.NoError(t, cs[i].Start(ctx))
+		}
+		defer func() {
+			for i := 0; i < numNodes; i++ {
+				if i < numNodes-numDown {
+					require.NoError(t, cs[i].Stop(ctx))
+				}
+				require.NoError(t, chains[i].Stop(ctx))
+			}
+		}()
+
+		up := chains[:numNodes-numDown]
+		require.NoError(t, testutil.WaitUntil(100*time.Millisecond, 20*time.Second, func() (bool, error) {
+			for _, chain := range up {
+				if chain.TipHeight() < height {
+					return false, nil
+				}
+			}
+			return true, nil
+		}))
+		for h := uint64(1); h <= height; h++ {
+			blk, err := up[0].GetBlockByHeight(h)
+			require.NoError(t, err)
+			for _, chain := range up[1:] {
+				blkHash, err := chain.GetHashByHeight(h)
+				require.NoError(t, err)
+				require.Equal(t, blk.HashBlock(), blkHash)
+			}
+		}
+	}
+
+	t.Run("4-validators", func(t *testing.T) {
+		run(t, 4, 0, 5)
+	})
+
+	t.Run("1-validator-down", func(t *testing.T) {
+		// The blocks keep being committed when the down validator is the proposer
+		run(t, 4, 1, 5)
+	})
+
+	t.Run("2-validators-down", func(t *testing.T) {
+		ctx := context.Background()
+		cs, chains := newConsensusComponents(4)
+		for i := 0; i < 4; i++ {
+			require.NoError(t, chains[i].Start(ctx))
+		}
+		for i := 0; i < 2; i++ {
+			require.NoError(t, cs[i].Start(ctx))
+		}
+		defer func() {
+			for i := 0; i < 4; i++ {
+				if i < 2 {
+					require.NoError(t, cs[i].Stop(ctx))
+				}
+				require.NoError(t, chains[i].Stop(ctx))
+			}
+		}()
+
+		// No block could be committed without a quorum
+		time.Sleep(2 * time.Second)
+		require.Equal(t, uint64(0), chains[0].TipHeight())
+		require.Equal(t, uint64(0), chains[1].TipHeight())
+		metrics, err := cs[0].Metrics()
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), metrics.LatestRound)
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package pbft
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/enc"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+// vote is a validator's signed message of a phase, which is carried by EndorsePb. The topic PROPOSAL is the prepare
+// vote on the proposed block, COMMIT is the commit vote on the prepared block, and ROUND_CHANGE asks the validators to
+// move to the vote's round.
+type vote struct {
+	topic          iproto.EndorsePb_EndorsementTopic
+	height         uint64
+	round          uint32
+	blkHash        hash.Hash32B
+	endorser       string
+	endorserPubkey keypair.PublicKey
+	signature      []byte
+}
+
+// ByteStream returns a raw byte stream
+func (v *vote) ByteStream() []byte {
+	stream := make([]byte, 12)
+	enc.MachineEndian.PutUint64(stream[:8], v.height)
+	enc.MachineEndian.PutUint32(stream[8:], v.round)
+	stream = append(stream, byte(v.topic))
+	stream = append(stream, v.blkHash[:]...)
+	return stream
+}
+
+// Hash returns the hash of the vote for signature
+func (v *vote) Hash() hash.Hash32B {
+	return blake2b.Sum256(v.ByteStream())
+}
+
+// Sign signs with the validator's private key
+func (v *vote) Sign(validator *iotxaddress.Address) error {
+	if validator.PrivateKey == keypair.ZeroPrivateKey {
+		return errors.New("The validator's private key is empty")
+	}
+	hash := v.Hash()
+	v.endorser = validator.RawAddress
+	v.endorserPubkey = validator.PublicKey
+	v.signature = crypto.EC283.Sign(validator.PrivateKey, hash[:])
+	return nil
+}
+
+// VerifySignature verifies that the vote is signed by the endorser
+func (v *vote) VerifySignature() bool {
+	pubkeyHash := keypair.HashPubKey(v.endorserPubkey)
+	endorserPubkeyHash, err := iotxaddress.GetPubkeyHash(v.endorser)
+	if err != nil {
+		return false
+	}
+	if !bytes.Equal(pubkeyHash[:], endorserPubkeyHash) {
+		return false
+	}
+	hash := v.Hash()
+	return crypto.EC283.Verify(v.endorserPubkey, hash[:], v.signature)
+}
+
+func (v *vote) toProtoMsg() *iproto.EndorsePb {
+	return &iproto.EndorsePb{
+		Height:         v.height,
+		Round:          v.round,
+		BlockHash:      v.blkHash[:],
+		Topic:          v.topic,
+		Endorser:       v.endorser,
+		EndorserPubKey: v.endorserPubkey[:],
+		Decision:       true,
+		Signature:      v.signature,
+	}
+}
+
+func (v *vote) fromProtoMsg(ePb *iproto.EndorsePb) error {
+	pubkey, err := keypair.BytesToPublicKey(ePb.EndorserPubKey)
+	if err != nil {
+		return errors.Wrap(err, "error when converting the endorser's public key")
+	}
+	v.topic = ePb.Topic
+	v.height = ePb.Height
+	v.round = ePb.Round
+	copy(v.blkHash[:], ePb.BlockHash)
+	v.endorser = ePb.Endorser
+	v.endorserPubkey = pubkey
+	v.signature = make([]byte, len(ePb.Signature))
+	copy(v.signature, ePb.Signature)
+	return nil
+}
@@ -0,0 +1,495 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/beacon"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/action"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/enc"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// The DKG of an epoch works as the following:
+// 1. When entering the epoch, each delegate generates a secret and splits it into one share for each delegate
+// 2. During the first len(delegates) blocks of the epoch, each delegate proposes one secret block carrying the shares
+//    and the witness. Each share is encrypted with the Diffie-Hellman shared key of the producer and the recipient, so
+//    that only the recipient can decrypt it and verify it against the witness.
+// 3. Once all these blocks are committed, each delegate collects the shares addressed to it from the secret blocks, and
+//    generates its private key share of the group key
+// 4. Each following block of the epoch carries the producer's BLS signature share of the epoch seed, which is verified
+//    against the producer's DKG public key share recorded on chain. The first crypto.Degree+1 valid shares of
+//    different delegates aggregate into the threshold signature of the group key, which is unique no matter which
+//    shares are picked, and becomes the seed of the next epoch.
+
+var (
+	// ErrInvalidSecretBlock indicates the error of an invalid DKG secret block
+	ErrInvalidSecretBlock = errors.New("invalid DKG secret block")
+	// ErrNotEnoughDKGSignatures indicates the error of not enough valid DKG signatures to aggregate the epoch seed
+	ErrNotEnoughDKGSignatures = errors.New("not enough DKG signatures")
+)
+
+// shouldHandleDKG returns true if the delegates of the current epoch run the DKG
+func (ctx *rollDPoSCtx) shouldHandleDKG() bool {
+	return ctx.cfg.EnableDKG && len(ctx.epoch.delegates) == crypto.NumDKGNodes
+}
+
+// isDKGPhase returns true if the block at the given height is one of the blocks at the beginning of the epoch, which
+// are reserved for the secret blocks
+func (ctx *rollDPoSCtx) isDKGPhase(height uint64) bool {
+	return height >= ctx.epoch.height && height < ctx.epoch.height+uint64(len(ctx.epoch.delegates))
+}
+
+// isDKGPhaseFinished returns true if all the blocks reserved for the secret blocks have been committed
+func (ctx *rollDPoSCtx) isDKGPhaseFinished() bool {
+	return ctx.chain.TipHeight() >= ctx.epoch.height+uint64(len(ctx.epoch.delegates))-1
+}
+
+// generateDKGSecret generates the delegate's secret of the epoch, and splits it into the shares for all the delegates
+func (ctx *rollDPoSCtx) generateDKGSecret() error {
+	ids := make([][]uint8, len(ctx.epoch.delegates))
+	for i, delegate := range ctx.epoch.delegates {
+		ids[i] = iotxaddress.CreateID(delegate)
+	}
+	_, shares, witness, err := crypto.DKG.Init(crypto.DKG.SkGeneration(), ids)
+	if err != nil {
+		return errors.Wrap(err, "error when initializing the DKG")
+	}
+	ctx.epoch.secrets = shares
+	ctx.epoch.witness = witness
+	return nil
+}
+
+// delegatePubkeys returns the public keys of the delegates of the current epoch, which are registered with their
+// candidacy
+func (ctx *rollDPoSCtx) delegatePubkeys() (map[string]keypair.PublicKey, error) {
+	candidates, err := ctx.epochCandidates(ctx.epoch.num)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when getting the candidates")
+	}
+	pubkeys := make(map[string]keypair.PublicKey)
+	for _, candidate := range candidates {
+		if !isDelegate(candidate.Address, ctx.epoch.delegates) {
+			continue
+		}
+		pubkey, err := keypair.BytesToPublicKey(candidate.PubKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when getting the public key of %s", candidate.Address)
+		}
+		pubkeys[candidate.Address] = pubkey
+	}
+	for _, delegate := range ctx.epoch.delegates {
+		if _, ok := pubkeys[delegate]; !ok {
+			return nil, errors.Errorf("the public key of %s is unknown", delegate)
+		}
+	}
+	return pubkeys, nil
+}
+
+// cipherShare encrypts or decrypts the secret share of the secret proposal with the given nonce from the sender to the
+// recipient. The share is XORed with a key stream derived from the Diffie-Hellman shared key of the two delegates,
+// which is never reused because the nonces of the sender don't repeat.
+func cipherShare(sharedKey []byte, src string, dst string, nonce uint64, share []uint32) []uint32 {
+	seed := make([]byte, 0, len(sharedKey)+len(src)+len(dst)+8)
+	seed = append(seed, sharedKey...)
+	seed = append(seed, src...)
+	seed = append(seed, dst...)
+	seed = append(seed, byteutil.Uint64ToBytes(nonce)...)
+	res := make([]uint32, len(share))
+	var stream []byte
+	for i := range share {
+		if i%(hash.HashSize/4) == 0 {
+			block := append(append([]byte{}, seed...), byteutil.Uint64ToBytes(uint64(i))...)
+			stream = hash.Hash256b(block)
+		}
+		res[i] = share[i] ^ enc.MachineEndian.Uint32(stream[i%(hash.HashSize/4)*4:])
+	}
+	return res
+}
+
+// decryptShare decrypts the share addressed to the delegate in the secret block
+func (ctx *rollDPoSCtx) decryptShare(blk *blockchain.Block) ([]uint32, error) {
+	for _, sp := range blk.SecretProposals {
+		if sp.DstAddr() != ctx.addr.RawAddress {
+			continue
+		}
+		sharedKey, err := crypto.EC283.SharedKey(ctx.addr.PrivateKey, blk.Header.Pubkey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when deriving the shared key with %s", sp.SrcAddr())
+		}
+		return cipherShare(sharedKey, sp.SrcAddr(), sp.DstAddr(), sp.Nonce(), sp.Secret()), nil
+	}
+	return nil, errors.Errorf("no secret share to %s", ctx.addr.RawAddress)
+}
+
+// committedSecretBlocks returns the committed secret blocks of the current epoch keyed by the producer
+func (ctx *rollDPoSCtx) committedSecretBlocks() (map[string]*blockchain.Block, error) {
+	blks := make(map[string]*blockchain.Block)
+	endHeight := ctx.epoch.height + uint64(len(ctx.epoch.delegates)) - 1
+	if tipHeight := ctx.chain.TipHeight(); tipHeight < endHeight {
+		endHeight = tipHeight
+	}
+	for height := ctx.epoch.height; height <= endHeight; height++ {
+		blk, err := ctx.chain.GetBlockByHeight(height)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when getting the block at height %d", height)
+		}
+		if !blk.IsSecretBlock() {
+			continue
+		}
+		if _, ok := blks[blk.ProducerAddress()]; !ok {
+			blks[blk.ProducerAddress()] = blk
+		}
+	}
+	return blks, nil
+}
+
+// shouldMintSecretBlock returns true if the delegate needs to propose its secret block at the current round
+func (ctx *rollDPoSCtx) shouldMintSecretBlock() (bool, error) {
+	if !ctx.shouldHandleDKG() || !ctx.isDKGPhase(ctx.round.height) || len(ctx.epoch.secrets) == 0 {
+		return false, nil
+	}
+	blks, err := ctx.committedSecretBlocks()
+	if err != nil {
+		return false, err
+	}
+	_, ok := blks[ctx.addr.RawAddress]
+	return !ok, nil
+}
+
+// mintSecretBlock mints a secret block carrying the delegate's secret shares and witness
+func (ctx *rollDPoSCtx) mintSecretBlock() (*blockchain.Block, error) {
+	// The secret actions are not counted in the account's nonce in the state, but they still need to be consecutive to
+	// pass the validation
+	nonce, err := ctx.chain.Nonce(ctx.addr.RawAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when getting the nonce of the delegate")
+	}
+	pubkeys, err := ctx.delegatePubkeys()
+	if err != nil {
+		return nil, errors.Wrap(err, "error when getting the public keys of the delegates")
+	}
+	secretProposals := make([]*action.SecretProposal, 0, len(ctx.epoch.delegates))
+	for i, delegate := range ctx.epoch.delegates {
+		nonce++
+		sharedKey, err := crypto.EC283.SharedKey(ctx.addr.PrivateKey, pubkeys[delegate])
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when deriving the shared key with %s", delegate)
+		}
+		secret := cipherShare(sharedKey, ctx.addr.RawAddress, delegate, nonce, ctx.epoch.secrets[i])
+		sp, err := action.NewSecretProposal(nonce, ctx.addr.RawAddress, delegate, secret)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when creating the secret proposal to %s", delegate)
+		}
+		secretProposals = append(secretProposals, sp)
+	}
+	nonce++
+	secretWitness, err := action.NewSecretWitness(nonce, ctx.addr.RawAddress, ctx.epoch.witness)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when creating the secret witness")
+	}
+	blk, err := ctx.chain.MintNewSecretBlock(secretProposals, secretWitness, ctx.addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when minting a secret block")
+	}
+	logger.Info().
+		Uint64("height", blk.Height()).
+		Int("secretProposals", len(blk.SecretProposals)).
+		Msg("minted a new secret block")
+	return blk, nil
+}
+
+// validateSecretBlock validates that the secret block is proposed during the DKG phase, and carries the shares of the
+// producer for all the delegates in order. If the node is one of the delegates, it decrypts its own share and verifies
+// it against the witness.
+func (ctx *rollDPoSCtx) validateSecretBlock(blk *blockchain.Block) error {
+	if !ctx.shouldHandleDKG() || !ctx.isDKGPhase(blk.Height()) {
+		return errors.Wrap(ErrInvalidSecretBlock, "the secret block is not expected")
+	}
+	producer := blk.ProducerAddress()
+	if blk.SecretWitness == nil || blk.SecretWitness.SrcAddr() != producer {
+		return errors.Wrap(ErrInvalidSecretBlock, "the witness is not from the producer")
+	}
+	if len(blk.Transfers)+len(blk.Votes)+len(blk.Executions) > 0 {
+		return errors.Wrap(ErrInvalidSecretBlock, "the secret block carries other actions")
+	}
+	if len(blk.SecretProposals) != len(ctx.epoch.delegates) {
+		return errors.Wrapf(
+			ErrInvalidSecretBlock,
+			"%d secret proposals for %d delegates",
+			len(blk.SecretProposals),
+			len(ctx.epoch.delegates),
+		)
+	}
+	for i, sp := range blk.SecretProposals {
+		if sp.SrcAddr() != producer || sp.DstAddr() != ctx.epoch.delegates[i] {
+			return errors.Wrapf(ErrInvalidSecretBlock, "the secret proposal %d is from or to a wrong address", i)
+		}
+	}
+	blks, err := ctx.committedSecretBlocks()
+	if err != nil {
+		return err
+	}
+	if _, ok := blks[producer]; ok {
+		return errors.Wrapf(ErrInvalidSecretBlock, "the secret block of %s has been committed", producer)
+	}
+	if !isDelegate(ctx.addr.RawAddress, ctx.epoch.delegates) {
+		return nil
+	}
+	share, err := ctx.decryptShare(blk)
+	if err != nil {
+		return errors.Wrap(ErrInvalidSecretBlock, err.Error())
+	}
+	ok, err := crypto.DKG.ShareVerify(iotxaddress.CreateID(ctx.addr.RawAddress), share, blk.SecretWitness.Witness())
+	if err != nil || !ok {
+		return errors.Wrapf(ErrInvalidSecretBlock, "the secret share from %s is invalid", producer)
+	}
+	return nil
+}
+
+// generateDKGKeyPair generates the delegate's key pair of the group key from the committed secret blocks. A producer
+// qualifies if its secret block is committed, which gives all the delegates the same view of the qualified producers.
+func (ctx *rollDPoSCtx) generateDKGKeyPair() error {
+	blks, err := ctx.committedSecretBlocks()
+	if err != nil {
+		return err
+	}
+	id := iotxaddress.CreateID(ctx.addr.RawAddress)
+	shares := make([][]uint32, len(ctx.epoch.delegates))
+	witnesses := make([][][]byte, len(ctx.epoch.delegates))
+	var qualified [crypto.NumDKGNodes]bool
+	var numQualified int
+	var share []uint32
+	var witness [][]byte
+	for i, delegate := range ctx.epoch.delegates {
+		blk, ok := blks[delegate]
+		if !ok {
+			continue
+		}
+		if shares[i], err = ctx.decryptShare(blk); err != nil {
+			return errors.Wrapf(err, "error when decrypting the secret share from %s", delegate)
+		}
+		witnesses[i] = blk.SecretWitness.Witness()
+		qualified[i] = true
+		numQualified++
+		share = shares[i]
+		witness = witnesses[i]
+	}
+	if numQualified < crypto.Degree+1 {
+		return errors.Errorf("only %d secret blocks are committed", numQualified)
+	}
+	// The delegates without secret blocks are filled with a zero share, which is excluded by the status matrix
+	for i := range ctx.epoch.delegates {
+		if !qualified[i] {
+			shares[i] = make([]uint32, len(share))
+			witnesses[i] = witness
+		}
+	}
+	status, err := crypto.DKG.SharesCollect(id, shares, witnesses)
+	if err != nil {
+		return errors.Wrap(err, "error when collecting the secret shares")
+	}
+	for i, delegate := range ctx.epoch.delegates {
+		if qualified[i] && !status[i] {
+			return errors.Errorf("the secret share from %s is invalid", delegate)
+		}
+	}
+	statusMatrix := make([][crypto.NumDKGNodes]bool, len(ctx.epoch.delegates))
+	for i := range statusMatrix {
+		statusMatrix[i] = qualified
+	}
+	_, pk, ask, err := crypto.DKG.KeyPairGeneration(shares, statusMatrix)
+	if err != nil {
+		return errors.Wrap(err, "error when generating the DKG key pair")
+	}
+	ctx.epoch.dkgAddress = iotxaddress.DKGAddress{PrivateKey: ask, PublicKey: pk, ID: id}
+	return nil
+}
+
+// recordPubkeyShares records the DKG public key shares of the delegates carried by the blocks in the height range.
+// The share of a delegate is the DKG public key in the header of the first block it produces after the DKG phase,
+// which is signed by the delegate and agreed on by the consensus, so that all the nodes have the same shares. The
+// following blocks and the commit endorses of the delegate have to carry the same key.
+func (ctx *rollDPoSCtx) recordPubkeyShares(
+	shares map[string][]byte,
+	delegates []string,
+	startHeight uint64,
+	endHeight uint64,
+) error {
+	for height := startHeight; height <= endHeight; height++ {
+		blk, err := ctx.chain.GetBlockByHeight(height)
+		if err != nil {
+			return errors.Wrapf(err, "error when getting the block at height %d", height)
+		}
+		producer := blk.ProducerAddress()
+		if len(blk.Header.DKGPubkey) == 0 || !isDelegate(producer, delegates) {
+			continue
+		}
+		if !bytes.Equal(blk.Header.DKGID, iotxaddress.CreateID(producer)) {
+			continue
+		}
+		if _, ok := shares[producer]; !ok {
+			shares[producer] = blk.Header.DKGPubkey
+		}
+	}
+	return nil
+}
+
+// pubkeyShares returns the DKG public key shares recorded in the committed blocks of the current epoch, which are
+// scanned incrementally as the chain grows
+func (ctx *rollDPoSCtx) pubkeyShares() (map[string][]byte, error) {
+	dkgEndHeight := ctx.epoch.height + uint64(len(ctx.epoch.delegates)) - 1
+	tipHeight := ctx.chain.TipHeight()
+	epochEndHeight := ctx.epoch.height + uint64(len(ctx.epoch.delegates))*uint64(ctx.epoch.numSubEpochs) - 1
+	if tipHeight > epochEndHeight {
+		tipHeight = epochEndHeight
+	}
+	if ctx.epoch.pubkeyShares == nil || tipHeight < ctx.epoch.sharesHeight {
+		ctx.epoch.pubkeyShares = make(map[string][]byte)
+		ctx.epoch.sharesHeight = dkgEndHeight
+	}
+	if tipHeight > ctx.epoch.sharesHeight {
+		err := ctx.recordPubkeyShares(ctx.epoch.pubkeyShares, ctx.epoch.delegates, ctx.epoch.sharesHeight+1, tipHeight)
+		if err != nil {
+			return nil, err
+		}
+		ctx.epoch.sharesHeight = tipHeight
+	}
+	return ctx.epoch.pubkeyShares, nil
+}
+
+// certificateExpected returns true if a quorum of the delegates have their DKG public key shares recorded, so that
+// every committed block is able to carry a commit certificate signed by a quorum
+func certificateExpected(numDelegates int, numShares int) bool {
+	return numShares >= blockchain.CertificateQuorum(numDelegates)
+}
+
+// calcCommitQuorum calculates if more than 2/3 vote yes or no to commit the block as calcQuorum does. Once the block is
+// expected to carry a commit certificate, only the yes votes carrying the signature shares of the recorded DKG public
+// key shares are counted, so that the block committed by the quorum is certified by it.
+func (ctx *rollDPoSCtx) calcCommitQuorum(endorses map[string]*endorse) (bool, bool) {
+	if !ctx.shouldHandleDKG() {
+		return ctx.calcQuorum(endorses)
+	}
+	shares, err := ctx.pubkeyShares()
+	if err != nil {
+		logger.Error().Err(err).Msg("error when getting the recorded DKG public key shares")
+		return false, false
+	}
+	if !certificateExpected(len(ctx.epoch.delegates), len(shares)) {
+		return ctx.calcQuorum(endorses)
+	}
+	certified := make(map[string]*endorse, len(endorses))
+	for endorser, en := range endorses {
+		if en.decision {
+			if share, ok := shares[endorser]; !ok || !en.hasDKGSignature() || !bytes.Equal(en.dkgPubkey, share) {
+				continue
+			}
+		}
+		certified[endorser] = en
+	}
+	return ctx.calcQuorum(certified)
+}
+
+// calcEpochSeed calculates the random seed of the given epoch, which is the threshold signature of the previous
+// epoch's seed aggregated from the signature shares in the previous epoch's blocks. Each signature share is verified
+// against the producer's DKG public key share recorded in the previous epoch. The genesis seed is used for the first
+// epoch, and the previous seed is kept if the delegates of the previous epoch don't run the DKG. It returns an error
+// if the previous epoch doesn't have enough valid signature shares, and nil if the DKG is disabled.
+func (ctx *rollDPoSCtx) calcEpochSeed(epochNum uint64) ([]byte, error) {
+	if !ctx.cfg.EnableDKG {
+		return nil, nil
+	}
+	if epochNum <= 1 {
+		return beacon.GenesisSeed(), nil
+	}
+	if seed, ok := ctx.seeds.Load(epochNum); ok {
+		return seed.([]byte), nil
+	}
+	prevSeed, err := ctx.calcEpochSeed(epochNum - 1)
+	if err != nil {
+		return nil, err
+	}
+	delegates, err := ctx.rollingDelegates(epochNum - 1)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error when getting the delegates of epoch %d", epochNum-1)
+	}
+	if len(delegates) != crypto.NumDKGNodes {
+		ctx.seeds.Store(epochNum, prevSeed)
+		return prevSeed, nil
+	}
+	numBlks := uint64(ctx.cfg.NumDelegates) * uint64(ctx.getNumSubEpochs())
+	startHeight := numBlks*(epochNum-2) + 1
+	endHeight := startHeight + numBlks - 1
+	tipHeight := ctx.chain.TipHeight()
+	if tipHeight < endHeight {
+		endHeight = tipHeight
+	}
+	shares := make(map[string][]byte)
+	if err := ctx.recordPubkeyShares(shares, delegates, startHeight+uint64(len(delegates)), endHeight); err != nil {
+		return nil, err
+	}
+	ids := make([][]uint8, 0, crypto.Degree+1)
+	pks := make([][]byte, 0, crypto.Degree+1)
+	sigs := make([][]byte, 0, crypto.Degree+1)
+	signed := make(map[string]bool)
+	for height := startHeight; height <= endHeight && len(ids) < crypto.Degree+1; height++ {
+		blk, err := ctx.chain.GetBlockByHeight(height)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when getting the block at height %d", height)
+		}
+		producer := blk.ProducerAddress()
+		share, ok := shares[producer]
+		if !ok || len(blk.Header.DKGBlockSig) == 0 || signed[producer] {
+			continue
+		}
+		if !bytes.Equal(blk.Header.DKGID, iotxaddress.CreateID(producer)) {
+			continue
+		}
+		if err := crypto.BLS.Verify(share, prevSeed, blk.Header.DKGBlockSig); err != nil {
+			logger.Warn().
+				Err(err).
+				Uint64("height", height).
+				Str("producer", producer).
+				Msg("invalid DKG signature")
+			continue
+		}
+		signed[producer] = true
+		ids = append(ids, blk.Header.DKGID)
+		pks = append(pks, share)
+		sigs = append(sigs, blk.Header.DKGBlockSig)
+	}
+	if len(ids) < crypto.Degree+1 {
+		if tipHeight < startHeight+numBlks-1 {
+			return nil, errors.Errorf("epoch %d hasn't finished yet", epochNum-1)
+		}
+		return nil, errors.Wrapf(
+			ErrNotEnoughDKGSignatures,
+			"only %d valid DKG signatures in epoch %d",
+			len(ids),
+			epochNum-1,
+		)
+	}
+	seed, err := crypto.BLS.SignAggregate(ids, sigs)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when aggregating the DKG signatures")
+	}
+	if err := crypto.BLS.VerifyAggregate(ids, pks, prevSeed, seed); err != nil {
+		return nil, errors.Wrap(err, "error when verifying the aggregated DKG signature")
+	}
+	ctx.seeds.Store(epochNum, seed)
+	return seed, nil
+}
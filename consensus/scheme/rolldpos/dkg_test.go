@@ -0,0 +1,205 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/beacon"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+func TestDKG(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Consensus.RollDPoS.EnableDKG = true
+	cfg.Consensus.RollDPoS.NumDelegates = crypto.NumDKGNodes
+	cfg.Consensus.RollDPoS.NumSubEpochs = 2
+	chain := blockchain.NewBlockchain(&cfg, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(chain.Start(context.Background()))
+	defer func() {
+		require.NoError(chain.Stop(context.Background()))
+	}()
+
+	delegates := make([]string, crypto.NumDKGNodes)
+	candidates := make([]*state.Candidate, crypto.NumDKGNodes)
+	candidatesByHeightFunc := func(uint64) ([]*state.Candidate, error) { return candidates, nil }
+	ctxs := make([]*rollDPoSCtx, crypto.NumDKGNodes)
+	for i := range ctxs {
+		addr := newTestAddr()
+		delegates[i] = addr.RawAddress
+		candidates[i] = &state.Candidate{Address: addr.RawAddress, PubKey: addr.PublicKey[:]}
+		_, err := chain.CreateState(addr.RawAddress, 0)
+		require.NoError(err)
+		ctxs[i] = &rollDPoSCtx{
+			cfg:                    cfg.Consensus.RollDPoS,
+			addr:                   addr,
+			chain:                  chain,
+			candidatesByHeightFunc: candidatesByHeightFunc,
+		}
+	}
+	// Persist the delegates' states
+	require.NoError(chain.CommitBlock(chain.MintNewDummyBlock()))
+	for _, ctx := range ctxs {
+		ctx.epoch = epochCtx{
+			num:          1,
+			height:       2,
+			numSubEpochs: 2,
+			delegates:    delegates,
+			seed:         beacon.GenesisSeed(),
+		}
+		require.True(ctx.shouldHandleDKG())
+		require.NoError(ctx.generateDKGSecret())
+	}
+
+	// A share which doesn't match the witness is rejected by its recipient only
+	secrets := ctxs[0].epoch.secrets
+	ctxs[0].epoch.secrets = append([][]uint32{}, secrets...)
+	ctxs[0].epoch.secrets[1] = secrets[2]
+	ctxs[0].round.height = 2
+	blk, err := ctxs[0].mintSecretBlock()
+	require.NoError(err)
+	require.Equal(ErrInvalidSecretBlock, errors.Cause(ctxs[1].validateSecretBlock(blk)))
+	require.NoError(ctxs[2].validateSecretBlock(blk))
+	ctxs[0].epoch.secrets = secrets
+
+	// Each delegate except the last one commits its secret block
+	for i := 0; i < crypto.NumDKGNodes-1; i++ {
+		proposer := ctxs[i]
+		proposer.round.height = uint64(i + 2)
+		mint, err := proposer.shouldMintSecretBlock()
+		require.NoError(err)
+		require.True(mint)
+		blk, err := proposer.mintBlock()
+		require.NoError(err)
+		require.True(blk.IsSecretBlock())
+		// The shares are encrypted to the recipients
+		require.NotEqual(proposer.epoch.secrets[i+1], blk.SecretProposals[i+1].Secret())
+		require.NoError(ctxs[i+1].validateSecretBlock(blk))
+		require.NoError(chain.ValidateBlock(blk, false))
+		require.NoError(chain.CommitBlock(blk))
+
+		// The delegate won't propose another secret block in the epoch
+		proposer.round.height++
+		mint, err = proposer.shouldMintSecretBlock()
+		require.NoError(err)
+		require.False(mint)
+		blk, err = proposer.mintSecretBlock()
+		require.NoError(err)
+		require.Equal(ErrInvalidSecretBlock, errors.Cause(ctxs[i+1].validateSecretBlock(blk)))
+	}
+	require.False(ctxs[0].isDKGPhaseFinished())
+	require.NoError(chain.CommitBlock(chain.MintNewDummyBlock()))
+	require.True(ctxs[0].isDKGPhaseFinished())
+
+	// A secret block is not expected after the DKG phase
+	ctxs[crypto.NumDKGNodes-1].round.height = chain.TipHeight() + 1
+	blk, err = ctxs[crypto.NumDKGNodes-1].mintSecretBlock()
+	require.NoError(err)
+	require.Equal(ErrInvalidSecretBlock, errors.Cause(ctxs[0].validateSecretBlock(blk)))
+
+	for _, ctx := range ctxs {
+		require.NoError(ctx.generateDKGKeyPair())
+		require.Equal(iotxaddress.CreateID(ctx.addr.RawAddress), ctx.epoch.dkgAddress.ID)
+	}
+
+	// The delegates sign the seed in the following blocks
+	for i := 0; i < crypto.Degree+1; i++ {
+		blk, err := chain.MintNewDKGBlock(nil, nil, nil, ctxs[i].addr, &ctxs[i].epoch.dkgAddress, ctxs[i].epoch.seed, "")
+		require.NoError(err)
+		require.NoError(verifyDKGSignature(ctxs[i].epoch.dkgAddress.PublicKey, blk, ctxs[i].epoch.seed))
+		require.NoError(chain.CommitBlock(blk))
+	}
+	seed, err := ctxs[0].calcEpochSeed(2)
+	require.NoError(err)
+	require.NotEqual(beacon.GenesisSeed(), seed)
+
+	// Any crypto.Degree+1 signature shares aggregate into the same seed
+	ids := make([][]uint8, 0)
+	pks := make([][]byte, 0)
+	sigs := make([][]byte, 0)
+	for i := crypto.NumDKGNodes - crypto.Degree - 1; i < crypto.NumDKGNodes; i++ {
+		_, sig, err := crypto.BLS.SignShare(ctxs[i].epoch.dkgAddress.PrivateKey, beacon.GenesisSeed())
+		require.NoError(err)
+		ids = append(ids, ctxs[i].epoch.dkgAddress.ID)
+		pks = append(pks, ctxs[i].epoch.dkgAddress.PublicKey)
+		sigs = append(sigs, sig)
+	}
+	aggregated, err := crypto.BLS.SignAggregate(ids, sigs)
+	require.NoError(err)
+	require.Equal(seed, aggregated)
+	require.NoError(crypto.BLS.VerifyAggregate(ids, pks, beacon.GenesisSeed(), seed))
+
+	// The seed is calculated from the chain by another delegate or a full node
+	ctx := &rollDPoSCtx{cfg: cfg.Consensus.RollDPoS, chain: chain, candidatesByHeightFunc: candidatesByHeightFunc}
+	seed2, err := ctx.calcEpochSeed(2)
+	require.NoError(err)
+	require.Equal(seed, seed2)
+
+	// The DKG signature doesn't verify against another delegate's share
+	blk, err = chain.GetBlockByHeight(chain.TipHeight())
+	require.NoError(err)
+	require.Error(verifyDKGSignature(ctxs[0].epoch.dkgAddress.PublicKey, blk, ctxs[0].epoch.seed))
+}
+
+func TestCalcEpochSeed(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Consensus.RollDPoS.NumDelegates = crypto.NumDKGNodes
+	cfg.Consensus.RollDPoS.NumSubEpochs = 1
+	chain := blockchain.NewBlockchain(&cfg, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(chain.Start(context.Background()))
+	defer func() {
+		require.NoError(chain.Stop(context.Background()))
+	}()
+	candidates := make([]*state.Candidate, crypto.NumDKGNodes)
+	for i := range candidates {
+		addr := newTestAddr()
+		candidates[i] = &state.Candidate{Address: addr.RawAddress, PubKey: addr.PublicKey[:]}
+	}
+
+	// The hardcoded seed is used if the DKG is disabled
+	ctx := &rollDPoSCtx{
+		cfg:                    cfg.Consensus.RollDPoS,
+		chain:                  chain,
+		candidatesByHeightFunc: func(uint64) ([]*state.Candidate, error) { return candidates, nil },
+	}
+	seed, err := ctx.calcEpochSeed(2)
+	require.NoError(err)
+	require.Nil(seed)
+
+	ctx.cfg.EnableDKG = true
+	seed, err = ctx.calcEpochSeed(1)
+	require.NoError(err)
+	require.Equal(beacon.GenesisSeed(), seed)
+	// The previous epoch hasn't finished yet
+	_, err = ctx.calcEpochSeed(2)
+	require.Error(err)
+
+	// The seed can't be calculated if there are not enough DKG signatures
+	for i := 0; i < crypto.NumDKGNodes; i++ {
+		require.NoError(chain.CommitBlock(chain.MintNewDummyBlock()))
+	}
+	_, err = ctx.calcEpochSeed(2)
+	require.Equal(ErrNotEnoughDKGSignatures, errors.Cause(err))
+
+	// The previous seed is kept if the delegates don't run the DKG
+	ctx.cfg.NumDelegates = 4
+	seed, err = ctx.calcEpochSeed(2)
+	require.NoError(err)
+	require.Equal(beacon.GenesisSeed(), seed)
+}
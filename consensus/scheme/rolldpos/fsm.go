@@ -11,6 +11,7 @@ import (
 	"context"
 	"encoding/hex"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/facebookgo/clock"
@@ -43,10 +44,18 @@ var (
 		},
 		[]string{"result"},
 	)
+	roundMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_consensus_round",
+			Help: "Consensus round",
+		},
+		[]string{},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(consensusMtc)
+	prometheus.MustRegister(roundMtc)
 }
 
 const (
@@ -74,6 +83,7 @@ const (
 	eEndorseProposalTimeout fsm.EventType = "E_ENDORSE_PROPOSAL_TIMEOUT"
 	eEndorseCommit          fsm.EventType = "E_ENDORSE_COMMIT"
 	eEndorseCommitTimeout   fsm.EventType = "E_ENDORSE_COMMIT_TIMEOUT"
+	eRoundChange            fsm.EventType = "E_ROUND_CHANGE"
 	eFinishEpoch            fsm.EventType = "E_FINISH_EPOCH"
 
 	// eBackdoor indicates an backdoor event type
@@ -124,12 +134,14 @@ func (e *consensusEvt) timestamp() time.Time { return e.ts }
 type proposeBlkEvt struct {
 	consensusEvt
 	block *blockchain.Block
+	round uint32
 }
 
-func newProposeBlkEvt(block *blockchain.Block, c clock.Clock) *proposeBlkEvt {
+func newProposeBlkEvt(block *blockchain.Block, round uint32, c clock.Clock) *proposeBlkEvt {
 	return &proposeBlkEvt{
 		consensusEvt: *newCEvt(eProposeBlock, c),
 		block:        block,
+		round:        round,
 	}
 }
 
@@ -137,6 +149,7 @@ func (e *proposeBlkEvt) toProtoMsg() *iproto.ProposePb {
 	return &iproto.ProposePb{
 		Block:    e.block.ConvertToBlockPb(),
 		Proposer: e.block.ProducerAddress(),
+		Round:    e.round,
 	}
 }
 
@@ -145,39 +158,72 @@ func (e *proposeBlkEvt) fromProtoMsg(pMsg *iproto.ProposePb) error {
 		e.block = &blockchain.Block{}
 		e.block.ConvertFromBlockPb(pMsg.Block)
 	}
+	e.round = pMsg.Round
 	return nil
 }
 
+// endorseTopic indicates which phase of a round the endorse is for
+type endorseTopic uint8
+
 const (
-	endorseProposal = false
-	endorseCommit   = true
+	endorseProposal endorseTopic = iota
+	endorseCommit
+	// endorseRoundChange is the signed timeout of a round, which asks the delegates to move to the endorse's round
+	endorseRoundChange
 )
 
+// String returns the name of the endorse topic
+func (t endorseTopic) String() string {
+	switch t {
+	case endorseProposal:
+		return "proposal"
+	case endorseCommit:
+		return "commit"
+	case endorseRoundChange:
+		return "roundChange"
+	}
+	return "unknown"
+}
+
 type endorse struct {
-	topic          bool
+	topic          endorseTopic
 	height         uint64
+	round          uint32
 	blkHash        hash.Hash32B
 	decision       bool
 	endorser       string
 	endorserPubkey keypair.PublicKey
 	signature      []byte
+	// dkgID, dkgPubkey and dkgSignature are the BLS signature share on the block hash, which is only attached to the
+	// commit endorse to aggregate the commit certificate
+	dkgID        []byte
+	dkgPubkey    []byte
+	dkgSignature []byte
+	// lockedRound is the round in which the block of blkHash is locked, and lockProof is the proposal endorses of the
+	// block in that round reaching the quorum, which are only attached to the round change. Each proof is signed by its
+	// endorser, so it is not covered by the signature of the round change.
+	lockedRound uint32
+	lockProof   []*endorse
 }
 
 // ByteStream returns a raw byte stream
 func (en *endorse) ByteStream() []byte {
 	stream := make([]byte, 8)
 	enc.MachineEndian.PutUint64(stream, en.height)
-	if en.topic {
-		stream = append(stream, 1)
-	} else {
-		stream = append(stream, 0)
-	}
+	stream = append(stream, byte(en.topic))
 	stream = append(stream, en.blkHash[:]...)
 	if en.decision {
 		stream = append(stream, 1)
 	} else {
 		stream = append(stream, 0)
 	}
+	rounds := make([]byte, 8)
+	enc.MachineEndian.PutUint32(rounds[:4], en.round)
+	enc.MachineEndian.PutUint32(rounds[4:], en.lockedRound)
+	stream = append(stream, rounds...)
+	stream = append(stream, en.dkgID...)
+	stream = append(stream, en.dkgPubkey...)
+	stream = append(stream, en.dkgSignature...)
 	return stream
 }
 
@@ -198,6 +244,35 @@ func (en *endorse) Sign(endorser *iotxaddress.Address) error {
 	return nil
 }
 
+// SignDKG signs the block hash with the endorser's DKG private key share. It needs to be called before Sign, so that
+// the signature share is covered by the endorser's signature.
+func (en *endorse) SignDKG(dkgAddress *iotxaddress.DKGAddress) error {
+	if len(dkgAddress.PrivateKey) == 0 || len(dkgAddress.PublicKey) == 0 || len(dkgAddress.ID) == 0 {
+		return errors.New("The endorser's DKG key pair is empty")
+	}
+	_, sig, err := crypto.BLS.SignShare(dkgAddress.PrivateKey, en.blkHash[:])
+	if err != nil {
+		return errors.Wrap(err, "error when signing the block hash with DKG private key")
+	}
+	en.dkgID = dkgAddress.ID
+	en.dkgPubkey = dkgAddress.PublicKey
+	en.dkgSignature = sig
+	return nil
+}
+
+// hasDKGSignature returns true if the endorse carries a BLS signature share
+func (en *endorse) hasDKGSignature() bool {
+	return len(en.dkgID) > 0 && len(en.dkgPubkey) > 0 && len(en.dkgSignature) > 0
+}
+
+// VerifyDKGSignature verifies that the BLS signature share is signed by the endorser's DKG key
+func (en *endorse) VerifyDKGSignature() error {
+	if !bytes.Equal(en.dkgID, iotxaddress.CreateID(en.endorser)) {
+		return errors.New("The DKG ID does not belong to the endorser")
+	}
+	return crypto.BLS.VerifyShare(en.dkgPubkey, en.blkHash[:], en.dkgSignature)
+}
+
 // VerifySignature verifies that the endorse with pubkey
 func (en *endorse) VerifySignature(pubkey keypair.PublicKey) bool {
 	pubkeyHash := keypair.HashPubKey(pubkey)
@@ -213,21 +288,33 @@ func (en *endorse) VerifySignature(pubkey keypair.PublicKey) bool {
 }
 
 func (en *endorse) toProtoMsg() *iproto.EndorsePb {
+	var lockProof []*iproto.EndorsePb
+	for _, proof := range en.lockProof {
+		lockProof = append(lockProof, proof.toProtoMsg())
+	}
 	var topic iproto.EndorsePb_EndorsementTopic
 	switch en.topic {
 	case endorseProposal:
 		topic = iproto.EndorsePb_PROPOSAL
 	case endorseCommit:
 		topic = iproto.EndorsePb_COMMIT
+	case endorseRoundChange:
+		topic = iproto.EndorsePb_ROUND_CHANGE
 	}
 	return &iproto.EndorsePb{
 		Height:         en.height,
+		Round:          en.round,
+		LockedRound:    en.lockedRound,
 		BlockHash:      en.blkHash[:],
 		Topic:          topic,
 		Endorser:       en.endorser,
 		EndorserPubKey: en.endorserPubkey[:],
 		Decision:       en.decision,
 		Signature:      en.signature[:],
+		DkgID:          en.dkgID,
+		DkgPubkey:      en.dkgPubkey,
+		DkgSignature:   en.dkgSignature,
+		LockProof:      lockProof,
 	}
 }
 
@@ -238,6 +325,8 @@ func (en *endorse) fromProtoMsg(endorsePb *iproto.EndorsePb) error {
 		en.topic = endorseProposal
 	case iproto.EndorsePb_COMMIT:
 		en.topic = endorseCommit
+	case iproto.EndorsePb_ROUND_CHANGE:
+		en.topic = endorseRoundChange
 	}
 	pubKey, err := keypair.BytesToPublicKey(endorsePb.EndorserPubKey)
 	if err != nil {
@@ -249,9 +338,23 @@ func (en *endorse) fromProtoMsg(endorsePb *iproto.EndorsePb) error {
 	}
 	en.endorserPubkey = pubKey
 	en.height = endorsePb.Height
+	en.round = endorsePb.Round
+	en.lockedRound = endorsePb.LockedRound
 	en.endorser = endorsePb.Endorser
 	en.decision = endorsePb.Decision
+	en.signature = make([]byte, len(endorsePb.Signature))
 	copy(en.signature, endorsePb.Signature)
+	en.dkgID = endorsePb.DkgID
+	en.dkgPubkey = endorsePb.DkgPubkey
+	en.dkgSignature = endorsePb.DkgSignature
+	en.lockProof = nil
+	for _, proofPb := range endorsePb.LockProof {
+		proof := &endorse{}
+		if err := proof.fromProtoMsg(proofPb); err != nil {
+			return err
+		}
+		en.lockProof = append(en.lockProof, proof)
+	}
 	return nil
 }
 
@@ -260,9 +363,18 @@ type endorseEvt struct {
 	endorse *endorse
 }
 
-func newEndorseEvt(topic bool, blkHash hash.Hash32B, decision bool, height uint64, endorser *iotxaddress.Address, c clock.Clock) (*endorseEvt, error) {
+func newEndorseEvt(
+	topic endorseTopic,
+	blkHash hash.Hash32B,
+	decision bool,
+	height uint64,
+	round uint32,
+	endorser *iotxaddress.Address,
+	c clock.Clock,
+) (*endorseEvt, error) {
 	endorse := &endorse{
 		height:   height,
+		round:    round,
 		topic:    topic,
 		blkHash:  blkHash,
 		decision: decision,
@@ -277,10 +389,13 @@ func newEndorseEvt(topic bool, blkHash hash.Hash32B, decision bool, height uint6
 
 func newEndorseEvtWithEndorse(endorse *endorse, c clock.Clock) *endorseEvt {
 	var eventType fsm.EventType
-	if endorse.topic == endorseProposal {
+	switch endorse.topic {
+	case endorseProposal:
 		eventType = eEndorseProposal
-	} else {
+	case endorseCommit:
 		eventType = eEndorseCommit
+	case endorseRoundChange:
+		eventType = eRoundChange
 	}
 	return &endorseEvt{
 		consensusEvt: *newCEvt(eventType, c),
@@ -315,6 +430,9 @@ func newBackdoorEvt(dst fsm.State, c clock.Clock) *backdoorEvt {
 	}
 }
 
+// eventScheduler schedules the event to be handled by the FSM after the delay
+type eventScheduler func(evt iConsensusEvt, delay time.Duration)
+
 // cFSM wraps over the general purpose FSM and implements the consensusEvt logic
 type cFSM struct {
 	fsm   fsm.FSM
@@ -322,6 +440,9 @@ type cFSM struct {
 	close chan interface{}
 	ctx   *rollDPoSCtx
 	wg    sync.WaitGroup
+	// scheduler replaces the event queue and the event loop if it's set, so that the simulator could handle the events
+	// of all the delegates in a single goroutine by the virtual time
+	scheduler eventScheduler
 }
 
 func newConsensusFSM(ctx *rollDPoSCtx) (*cFSM, error) {
@@ -345,6 +466,7 @@ func newConsensusFSM(ctx *rollDPoSCtx) (*cFSM, error) {
 			[]fsm.State{
 				sAcceptPropose,         // proposed block invalid
 				sAcceptProposalEndorse, // proposed block valid
+				sRoundStart,            // proposed block agreed in an earlier round, jump to next round
 			}).
 		AddTransition(
 			sAcceptPropose,
@@ -383,6 +505,10 @@ func newConsensusFSM(ctx *rollDPoSCtx) (*cFSM, error) {
 			[]fsm.State{
 				sRoundStart, // timeout, jump to next round
 			})
+	// Enough delegates timing out in a later round could move the node to that round from any step of the current round
+	for _, state := range []fsm.State{sRoundStart, sAcceptPropose, sAcceptProposalEndorse, sAcceptCommitEndorse} {
+		b = b.AddTransition(state, eRoundChange, cm.roundChangeTransition(state), []fsm.State{state, sRoundStart})
+	}
 	// Add the backdoor transition so that we could unit test the transition from any given state
 	for _, state := range consensusStates {
 		b = b.AddTransition(state, eBackdoor, cm.handleBackdoorEvt, consensusStates)
@@ -396,6 +522,24 @@ func newConsensusFSM(ctx *rollDPoSCtx) (*cFSM, error) {
 }
 
 func (m *cFSM) Start(c context.Context) error {
+	if err := m.ctx.signed.Start(c); err != nil {
+		return errors.Wrap(err, "error when starting the signed message store")
+	}
+	if err := m.ctx.productivity.Start(c); err != nil {
+		return errors.Wrap(err, "error when starting the productivity store")
+	}
+	if height, round := m.ctx.signed.latest(); height > 0 && height > m.ctx.chain.TipHeight() {
+		// The delegate has signed messages for the next block before restarting. Resume from the round it has signed, so
+		// that it will move to the next round instead of signing the rounds it has gone through again.
+		m.ctx.round = roundCtx{height: height, number: round}
+		logger.Warn().
+			Uint64("height", height).
+			Uint32("round", round).
+			Msg("resume from the last signed round")
+	}
+	if m.scheduler != nil {
+		return nil
+	}
 	m.wg.Add(1)
 	go func() {
 		running := true
@@ -404,37 +548,7 @@ func (m *cFSM) Start(c context.Context) error {
 			case <-m.close:
 				running = false
 			case evt := <-m.evtq:
-				timeoutEvt, ok := evt.(*timeoutEvt)
-				if ok && timeoutEvt.timestamp().Before(m.ctx.round.timestamp) {
-					logger.Debug().Msg("timeoutEvt is stale")
-					continue
-				}
-				src := m.fsm.CurrentState()
-				if err := m.fsm.Handle(evt); err != nil {
-					if errors.Cause(err) == fsm.ErrTransitionNotFound {
-						if m.ctx.clock.Now().Sub(evt.timestamp()) <= m.ctx.cfg.UnmatchedEventTTL {
-							m.produce(evt, m.ctx.cfg.UnmatchedEventInterval)
-							logger.Debug().
-								Str("src", string(src)).
-								Str("evt", string(evt.Type())).
-								Err(err).
-								Msg("consensusEvt state transition could find the match")
-						}
-					} else {
-						logger.Error().
-							Str("src", string(src)).
-							Str("evt", string(evt.Type())).
-							Err(err).
-							Msg("consensusEvt state transition fails")
-					}
-				} else {
-					dst := m.fsm.CurrentState()
-					logger.Debug().
-						Str("src", string(src)).
-						Str("dst", string(dst)).
-						Str("evt", string(evt.Type())).
-						Msg("consensusEvt state transition happens")
-				}
+				m.handle(evt)
 			}
 		}
 		m.wg.Done()
@@ -442,10 +556,48 @@ func (m *cFSM) Start(c context.Context) error {
 	return nil
 }
 
-func (m *cFSM) Stop(_ context.Context) error {
+// handle moves the FSM with the event
+func (m *cFSM) handle(evt iConsensusEvt) {
+	timeoutEvt, ok := evt.(*timeoutEvt)
+	if ok && timeoutEvt.timestamp().Before(m.ctx.round.timestamp) {
+		logger.Debug().Msg("timeoutEvt is stale")
+		return
+	}
+	src := m.fsm.CurrentState()
+	if err := m.fsm.Handle(evt); err != nil {
+		if errors.Cause(err) == fsm.ErrTransitionNotFound {
+			if m.ctx.clock.Now().Sub(evt.timestamp()) <= m.ctx.cfg.UnmatchedEventTTL {
+				m.produce(evt, m.ctx.cfg.UnmatchedEventInterval)
+				logger.Debug().
+					Str("src", string(src)).
+					Str("evt", string(evt.Type())).
+					Err(err).
+					Msg("consensusEvt state transition could find the match")
+			}
+		} else {
+			logger.Error().
+				Str("src", string(src)).
+				Str("evt", string(evt.Type())).
+				Err(err).
+				Msg("consensusEvt state transition fails")
+		}
+	} else {
+		dst := m.fsm.CurrentState()
+		logger.Debug().
+			Str("src", string(src)).
+			Str("dst", string(dst)).
+			Str("evt", string(evt.Type())).
+			Msg("consensusEvt state transition happens")
+	}
+}
+
+func (m *cFSM) Stop(c context.Context) error {
 	close(m.close)
 	m.wg.Wait()
-	return nil
+	if err := m.ctx.productivity.Stop(c); err != nil {
+		return errors.Wrap(err, "error when stopping the productivity store")
+	}
+	return errors.Wrap(m.ctx.signed.Stop(c), "error when stopping the signed message store")
 }
 
 func (m *cFSM) currentState() fsm.State {
@@ -454,6 +606,10 @@ func (m *cFSM) currentState() fsm.State {
 
 // produce adds an event into the queue for the consensus FSM to process
 func (m *cFSM) produce(evt iConsensusEvt, delay time.Duration) {
+	if m.scheduler != nil {
+		m.scheduler(evt, delay)
+		return
+	}
 	if delay > 0 {
 		m.wg.Add(1)
 		go func() {
@@ -496,12 +652,19 @@ func (m *cFSM) handleRollDelegatesEvt(_ fsm.Event) (fsm.State, error) {
 			numSubEpochs = m.ctx.cfg.NumSubEpochs
 		}
 
+		seed, err := m.ctx.calcEpochSeed(epochNum)
+		if err != nil {
+			m.produce(m.newCEvt(eRollDelegates), m.ctx.cfg.DelegateInterval)
+			return sInvalid, errors.Wrapf(err, "error when calculating the seed of epoch %d", epochNum)
+		}
+
 		// The epochStart start height is going to be the next block to generate
 		m.ctx.epoch = epochCtx{
 			num:          epochNum,
 			height:       epochHeight,
 			delegates:    delegates,
 			numSubEpochs: numSubEpochs,
+			seed:         seed,
 		}
 
 		// Trigger the event to generate DKG
@@ -521,11 +684,14 @@ func (m *cFSM) handleRollDelegatesEvt(_ fsm.Event) (fsm.State, error) {
 }
 
 func (m *cFSM) handleGenerateDKGEvt(_ fsm.Event) (fsm.State, error) {
-	dkg, err := m.ctx.generateDKG()
-	if err != nil {
-		return sInvalid, err
+	if m.ctx.shouldHandleDKG() {
+		// The delegate still participates in the consensus without the DKG, but it won't propose a secret block
+		if err := m.ctx.generateDKGSecret(); err != nil {
+			logger.Error().Err(err).Uint64("epoch", m.ctx.epoch.num).Msg("error when generating the DKG secret")
+		}
+		// The node may restart after all the secret blocks are committed
+		m.tryGenerateDKGKeyPair()
 	}
-	m.ctx.epoch.dkg = dkg
 	if err := m.produceStartRoundEvt(); err != nil {
 		return sInvalid, errors.Wrapf(err, "error when producing %s", eStartRound)
 	}
@@ -533,24 +699,65 @@ func (m *cFSM) handleGenerateDKGEvt(_ fsm.Event) (fsm.State, error) {
 }
 
 func (m *cFSM) handleStartRoundEvt(_ fsm.Event) (fsm.State, error) {
-	proposer, height, err := m.ctx.rotatedProposer()
+	round := roundCtx{
+		height:           m.ctx.chain.TipHeight() + 1,
+		timestamp:        m.ctx.clock.Now(),
+		proposalEndorses: make(map[hash.Hash32B]map[string]*endorse),
+		commitEndorses:   make(map[hash.Hash32B]map[string]*endorse),
+		roundChanges:     make(map[uint32]map[string]*endorse),
+	}
+	if round.height == m.ctx.round.height {
+		// The block hasn't been committed in the last round, so move to the next round and carry over the lock
+		round.number = m.ctx.round.number + 1
+		if m.ctx.round.skipTo > round.number {
+			round.number = m.ctx.round.skipTo
+		}
+		round.lockedBlock = m.ctx.round.lockedBlock
+		round.lockedRound = m.ctx.round.lockedRound
+		round.lockProof = m.ctx.round.lockProof
+		if m.ctx.round.roundChanges != nil {
+			round.roundChanges = m.ctx.round.roundChanges
+		}
+		round.proposals = m.ctx.round.proposals
+		round.earlierCommits = m.ctx.round.earlierCommits
+		if round.earlierCommits == nil {
+			round.earlierCommits = make(map[uint32]map[hash.Hash32B]map[string]*endorse)
+		}
+		if m.ctx.round.commitEndorses != nil {
+			round.earlierCommits[m.ctx.round.number] = m.ctx.round.commitEndorses
+		}
+	}
+	if m.ctx.cfg.TimeBasedRotation {
+		// The round takes the next slot, and the rounds of the slots that have passed are skipped
+		slot, err := m.ctx.nextRoundSlot()
+		if err != nil {
+			return sInvalid, errors.Wrap(err, "error when calculating the slot of the next round")
+		}
+		parentSlot, err := m.ctx.blockSlot(round.height - 1)
+		if err != nil {
+			return sInvalid, errors.Wrap(err, "error when calculating the slot of the last block")
+		}
+		round.number = uint32(slot - parentSlot - 1)
+		round.slot = slot
+		timeSlotMtc.WithLabelValues().Set(float64(slot))
+	}
+	proposer, height, err := m.ctx.rotatedProposer(round.number)
 	if err != nil {
 		logger.Error().
 			Err(err).
 			Msg("error when getting the proposer")
 		return sInvalid, err
 	}
-	m.ctx.round = roundCtx{
-		height:           height,
-		timestamp:        m.ctx.clock.Now(),
-		proposalEndorses: make(map[hash.Hash32B]map[string]bool),
-		commitEndorses:   make(map[hash.Hash32B]map[string]bool),
-		proposer:         proposer,
-	}
+	round.height = height
+	round.proposer = proposer
+	m.ctx.round = round
+	atomic.StoreUint32(&m.ctx.latestRound, round.number)
+	roundMtc.WithLabelValues().Set(float64(round.number))
 	if proposer == m.ctx.addr.RawAddress {
 		logger.Info().
 			Str("proposer", proposer).
 			Uint64("height", height).
+			Uint32("round", round.number).
 			Msg("current node is the proposer")
 		m.produce(m.newCEvt(eInitBlock), 0)
 		// TODO: we may need timeout event for block producer too
@@ -559,16 +766,26 @@ func (m *cFSM) handleStartRoundEvt(_ fsm.Event) (fsm.State, error) {
 	logger.Info().
 		Str("proposer", proposer).
 		Uint64("height", height).
+		Uint32("round", round.number).
 		Msg("current node is not the proposer")
 	// Setup timeout for waiting for proposed block
-	m.produce(m.newTimeoutEvt(eProposeBlockTimeout, m.ctx.round.height), m.ctx.cfg.AcceptProposeTTL)
+	m.produce(m.newTimeoutEvt(eProposeBlockTimeout, m.ctx.round.height), m.ctx.roundTTL(m.ctx.cfg.AcceptProposeTTL))
 	return sAcceptPropose, nil
 }
 
 func (m *cFSM) handleInitBlockEvt(evt fsm.Event) (fsm.State, error) {
-	blk, err := m.ctx.mintBlock()
-	if err != nil {
-		return sInvalid, errors.Wrap(err, "error when minting a block")
+	// Propose the locked block again if any, otherwise mint a new one
+	blk := m.ctx.round.lockedBlock
+	if blk == nil {
+		var err error
+		if blk, err = m.ctx.mintBlock(); err != nil {
+			return sInvalid, errors.Wrap(err, "error when minting a block")
+		}
+	}
+	if !m.checkSigned(proposeTopic, blk.HashBlock(), true) {
+		// Wait for the proposal of the next round instead
+		m.produce(m.newTimeoutEvt(eProposeBlockTimeout, m.ctx.round.height), m.ctx.roundTTL(m.ctx.cfg.AcceptProposeTTL))
+		return sAcceptPropose, nil
 	}
 	proposeBlkEvt := m.newProposeBlkEvt(blk)
 	proposeBlkEvtProto := proposeBlkEvt.toProtoMsg()
@@ -596,11 +813,20 @@ func (m *cFSM) validateProposeBlock(blk *blockchain.Block, expectedProposer stri
 	}
 	producer := blk.ProducerAddress()
 
-	if producer == "" || producer != expectedProposer {
+	// A block locked in an earlier round could be proposed again by the proposer of the current round
+	if producer == "" ||
+		(producer != expectedProposer &&
+			!(m.isEpochDelegate(producer) && (m.ctx.round.isLocked(blkHash) || m.isEarlierProposer(producer)))) {
 		errorLog.Str("proposer", producer).
 			Msg("error when validating the block proposer")
 		return false
 	}
+	if m.ctx.cfg.TimeBasedRotation {
+		if err := m.ctx.validateBlockSlot(blk); err != nil {
+			errorLog.Err(err).Msg("error when validating the block slot")
+			return false
+		}
+	}
 	if !blk.VerifySignature() {
 		errorLog.Msg("error when validating the block signature")
 		return false
@@ -609,13 +835,37 @@ func (m *cFSM) validateProposeBlock(blk *blockchain.Block, expectedProposer stri
 		// If the block is self proposed, skip validation
 		return true
 	}
-	if err := m.ctx.chain.ValidateBlock(blk, true); err != nil {
+	if blk.IsSecretBlock() {
+		if err := m.ctx.validateSecretBlock(blk); err != nil {
+			errorLog.Err(err).Msg("error when validating the secret block")
+			return false
+		}
+	}
+	if err := m.ctx.chain.ValidateBlock(blk, !blk.IsSecretBlock()); err != nil {
 		errorLog.Err(err).Msg("error when validating the proposed block")
 		return false
 	}
+	// The DKG signature is optional, because a delegate may fail to generate the key pair
 	if len(blk.Header.DKGPubkey) > 0 && len(blk.Header.DKGBlockSig) > 0 {
-		// TODO failed if no dkg
-		if err := verifyDKGSignature(blk, m.ctx.epoch.seed); err != nil {
+		if !bytes.Equal(blk.Header.DKGID, iotxaddress.CreateID(producer)) {
+			errorLog.Msg("The DKG ID does not belong to the block producer")
+			return false
+		}
+		shares, err := m.ctx.pubkeyShares()
+		if err != nil {
+			errorLog.Err(err).Msg("error when getting the recorded DKG public key shares")
+			return false
+		}
+		share, ok := shares[producer]
+		if !ok {
+			// The first block of the producer after the DKG phase registers its share
+			share = blk.Header.DKGPubkey
+		}
+		if !bytes.Equal(blk.Header.DKGPubkey, share) {
+			errorLog.Msg("The DKG public key is not the share recorded for the block producer")
+			return false
+		}
+		if err := verifyDKGSignature(share, blk, m.ctx.epoch.seed); err != nil {
 			// Verify dkg signature failed
 			errorLog.Err(err).Msg("Failed to verify the DKG signature")
 			return false
@@ -627,7 +877,7 @@ func (m *cFSM) validateProposeBlock(blk *blockchain.Block, expectedProposer stri
 
 func (m *cFSM) moveToAcceptProposalEndorse() (fsm.State, error) {
 	// Setup timeout for waiting for endorse
-	m.produce(m.newTimeoutEvt(eEndorseProposalTimeout, m.ctx.round.height), m.ctx.cfg.AcceptProposalEndorseTTL)
+	m.produce(m.newTimeoutEvt(eEndorseProposalTimeout, m.ctx.round.height), m.ctx.roundTTL(m.ctx.cfg.AcceptProposalEndorseTTL))
 	return sAcceptProposalEndorse, nil
 }
 
@@ -640,15 +890,41 @@ func (m *cFSM) handleProposeBlockEvt(evt fsm.Event) (fsm.State, error) {
 	if !ok {
 		return sInvalid, errors.Wrap(ErrEvtCast, "the event is not a proposeBlkEvt")
 	}
-	proposer, err := m.ctx.calcProposer(proposeBlkEvt.block.Height(), m.ctx.epoch.delegates)
+	if proposeBlkEvt.round != m.ctx.round.number {
+		if proposeBlkEvt.round > m.ctx.round.number {
+			// The proposer has moved to a later round earlier than this node
+			m.produceLater(proposeBlkEvt)
+		} else if proposeBlkEvt.block.Height() == m.ctx.round.height {
+			return m.handleEarlierProposeBlock(proposeBlkEvt)
+		}
+		logger.Warn().
+			Uint32("round", m.ctx.round.number).
+			Uint32("proposalRound", proposeBlkEvt.round).
+			Msg("the proposed block is not for the current round")
+		return sAcceptPropose, nil
+	}
+	proposer, err := m.ctx.calcProposer(proposeBlkEvt.block.Height(), m.ctx.round.number, m.ctx.epoch.delegates)
 	if err != nil {
 		return sInvalid, errors.Wrap(err, "error when calculating the proposer")
 	}
 	if !m.validateProposeBlock(proposeBlkEvt.block, proposer) {
 		return sAcceptPropose, nil
 	}
+	blkHash := proposeBlkEvt.block.HashBlock()
+	m.ctx.round.addProposal(proposeBlkEvt.block)
+	if !m.ctx.round.canEndorse(blkHash) {
+		logger.Warn().
+			Uint64("height", m.ctx.round.height).
+			Uint32("round", m.ctx.round.number).
+			Uint32("lockedRound", m.ctx.round.lockedRound).
+			Msg("the proposed block is not the locked block")
+		return sAcceptPropose, nil
+	}
 	m.ctx.round.block = proposeBlkEvt.block
-	endorseEvt, err := m.newEndorseProposalEvt(m.ctx.round.block.HashBlock(), true)
+	if !m.checkSigned(endorseProposal.String(), blkHash, true) {
+		return m.moveToAcceptProposalEndorse()
+	}
+	endorseEvt, err := m.newEndorseProposalEvt(blkHash, true)
 	if err != nil {
 		return sInvalid, errors.Wrap(err, "error when generating new endorse proposal event")
 	}
@@ -665,6 +941,32 @@ func (m *cFSM) handleProposeBlockEvt(evt fsm.Event) (fsm.State, error) {
 	return m.moveToAcceptProposalEndorse()
 }
 
+// handleEarlierProposeBlock keeps the block proposed in an earlier round at the current height, and commits it if the
+// delegates have agreed on it in that round, which happens when the proposal arrives after the node has timed out
+func (m *cFSM) handleEarlierProposeBlock(proposeBlkEvt *proposeBlkEvt) (fsm.State, error) {
+	blk := proposeBlkEvt.block
+	proposer, err := m.ctx.calcProposer(blk.Height(), proposeBlkEvt.round, m.ctx.epoch.delegates)
+	if err != nil {
+		return sInvalid, errors.Wrap(err, "error when calculating the proposer")
+	}
+	if !m.validateProposeBlock(blk, proposer) {
+		return sAcceptPropose, nil
+	}
+	m.ctx.round.addProposal(blk)
+	commitEndorses := m.ctx.round.earlierCommits[proposeBlkEvt.round]
+	if yes, no := m.ctx.calcCommitQuorum(commitEndorses[blk.HashBlock()]); !yes || no {
+		return sAcceptPropose, nil
+	}
+	logger.Info().
+		Uint64("height", m.ctx.round.height).
+		Uint32("round", proposeBlkEvt.round).
+		Uint32("currentRound", m.ctx.round.number).
+		Msg("commit the block agreed in an earlier round")
+	m.ctx.round.block = blk
+	m.ctx.round.commitEndorses = commitEndorses
+	return m.processEndorseCommit(true)
+}
+
 func (m *cFSM) handleProposeBlockTimeout(evt fsm.Event) (fsm.State, error) {
 	if evt.Type() != eProposeBlockTimeout {
 		return sInvalid, errors.Errorf("invalid event type %s", evt.Type())
@@ -677,12 +979,18 @@ func (m *cFSM) handleProposeBlockTimeout(evt fsm.Event) (fsm.State, error) {
 	return m.moveToAcceptProposalEndorse()
 }
 
-func (m *cFSM) validateEndorse(en *endorse, expectedEndorseTopic bool) bool {
+func (m *cFSM) validateEndorse(en *endorse, expectedEndorseTopic endorseTopic) bool {
+	return m.validateEndorseOfRound(en, expectedEndorseTopic, m.ctx.round.number)
+}
+
+// validateEndorseOfRound validates the endorse for the given round at the current height
+func (m *cFSM) validateEndorseOfRound(en *endorse, expectedEndorseTopic endorseTopic, expectedRound uint32) bool {
 	errorLog := logger.Error().
 		Uint64("expectedHeight", m.ctx.round.height).
-		Bool("expectedEndorseTopic", expectedEndorseTopic)
+		Uint32("expectedRound", expectedRound).
+		Uint8("expectedEndorseTopic", uint8(expectedEndorseTopic))
 	if en.topic != expectedEndorseTopic {
-		errorLog.Bool("endorseTopic", en.topic).
+		errorLog.Uint8("endorseTopic", uint8(en.topic)).
 			Msg("error when validating the endorse topic")
 		return false
 	}
@@ -691,13 +999,69 @@ func (m *cFSM) validateEndorse(en *endorse, expectedEndorseTopic bool) bool {
 			Msg("error when validating the endorse height")
 		return false
 	}
-	// TODO verify that the endorser is one delegate, and verify signature via endorse.VerifySignature() with pub key
+	if en.round != expectedRound {
+		errorLog.Uint32("round", en.round).
+			Msg("error when validating the endorse round")
+		return false
+	}
+	if !m.isEpochDelegate(en.endorser) {
+		errorLog.Str("endorser", en.endorser).
+			Msg("error when validating the endorser, which is not a delegate of the current epoch")
+		return false
+	}
+	if !en.VerifySignature(en.endorserPubkey) {
+		errorLog.Str("endorser", en.endorser).
+			Msg("error when validating the endorse signature")
+		return false
+	}
+	if en.hasDKGSignature() {
+		if err := en.VerifyDKGSignature(); err != nil {
+			errorLog.Err(err).Str("endorser", en.endorser).
+				Msg("error when validating the endorse DKG signature")
+			return false
+		}
+	}
+	if en.topic == endorseCommit && en.decision && m.ctx.shouldHandleDKG() {
+		// The delegate with a recorded DKG public key share has to sign the commit certificate with it
+		shares, err := m.ctx.pubkeyShares()
+		if err != nil {
+			errorLog.Err(err).Msg("error when getting the recorded DKG public key shares")
+			return false
+		}
+		if share, ok := shares[en.endorser]; ok && !bytes.Equal(en.dkgPubkey, share) {
+			errorLog.Str("endorser", en.endorser).
+				Msg("error when validating the endorse DKG public key, which is not the recorded share")
+			return false
+		}
+	}
+	endorses := m.ctx.round.proposalEndorses
+	if en.topic == endorseCommit {
+		endorses = m.ctx.round.commitEndorses
+		if expectedRound != m.ctx.round.number {
+			endorses = m.ctx.round.earlierCommits[expectedRound]
+		}
+	}
+	if hasEndorsed(endorses, en.endorser) {
+		errorLog.Str("endorser", en.endorser).
+			Msg("error when validating the endorse, which is a duplicate from the same endorser")
+		return false
+	}
 	return true
 }
 
+// hasEndorsed checks if the endorser has already endorsed any block in the given endorse collection
+func hasEndorsed(endorses map[hash.Hash32B]map[string]*endorse, endorser string) bool {
+	for _, decisions := range endorses {
+		if _, ok := decisions[endorser]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *cFSM) moveToAcceptCommitEndorse() (fsm.State, error) {
 	// Setup timeout for waiting for commit
-	m.produce(m.newTimeoutEvt(eEndorseCommitTimeout, m.ctx.round.height), m.ctx.cfg.AcceptCommitEndorseTTL)
+	m.produce(m.newTimeoutEvt(eEndorseCommitTimeout, m.ctx.round.height), m.ctx.roundTTL(m.ctx.cfg.AcceptCommitEndorseTTL))
 	return sAcceptCommitEndorse, nil
 }
 
@@ -709,24 +1073,42 @@ func (m *cFSM) handleEndorseProposalEvt(evt fsm.Event) (fsm.State, error) {
 	if !ok {
 		return sInvalid, errors.Wrap(ErrEvtCast, "the event is not an endorseEvt")
 	}
-	endorse := endorseEvt.endorse
-	if !m.validateEndorse(endorse, endorseProposal) {
+	en := endorseEvt.endorse
+	if m.isLaterRound(en) {
+		m.produceLater(endorseEvt)
 		return sAcceptProposalEndorse, nil
 	}
-	blkHash := endorse.blkHash
+	if !m.validateEndorse(en, endorseProposal) {
+		return sAcceptProposalEndorse, nil
+	}
+	blkHash := en.blkHash
 	endorses := m.ctx.round.proposalEndorses[blkHash]
 	if endorses == nil {
-		endorses = map[string]bool{}
+		endorses = map[string]*endorse{}
 		m.ctx.round.proposalEndorses[blkHash] = endorses
 	}
-	endorses[endorse.endorser] = endorse.decision
+	endorses[en.endorser] = en
 	// if ether yes or no is true, block must exists and blkHash must be a valid one
 	yes, no := m.ctx.calcQuorum(m.ctx.round.proposalEndorses[blkHash])
 	if !yes && !no {
 		// Wait for more preCommits to come
 		return sAcceptProposalEndorse, nil
 	}
+	if yes && !no && m.ctx.round.block != nil && m.ctx.round.block.HashBlock() == blkHash {
+		// Lock on the block, which is carried over to the following rounds until it's committed
+		m.ctx.round.lockedBlock = m.ctx.round.block
+		m.ctx.round.lockedRound = m.ctx.round.number
+		m.ctx.round.lockProof = make([]*endorse, 0, len(endorses))
+		for _, proof := range endorses {
+			if proof.decision {
+				m.ctx.round.lockProof = append(m.ctx.round.lockProof, proof)
+			}
+		}
+	}
 	// Reached the agreement
+	if !m.checkSigned(endorseCommit.String(), blkHash, yes && !no) {
+		return m.moveToAcceptCommitEndorse()
+	}
 	cEvt, err := m.newEndorseCommitEvt(blkHash, yes && !no)
 	if err != nil {
 		return sInvalid, errors.Wrap(err, "failed to generate endorse commit event")
@@ -764,28 +1146,83 @@ func (m *cFSM) handleEndorseCommitEvt(evt fsm.Event) (fsm.State, error) {
 	if !ok {
 		return sInvalid, errors.Wrap(ErrEvtCast, "the event is not an endorseEvt")
 	}
-	endorse := endorseEvt.endorse
-	if endorse.topic != endorseCommit {
+	en := endorseEvt.endorse
+	if m.isLaterRound(en) {
+		m.produceLater(endorseEvt)
+		return sAcceptCommitEndorse, nil
+	}
+	if m.isEarlierRound(en) {
+		return m.handleEarlierEndorseCommit(en)
+	}
+	if !m.validateEndorse(en, endorseCommit) {
 		return sAcceptCommitEndorse, nil
 	}
-	// TODO verify that the endorse is one delegate, and verify signature via endorse.VerifySignature() with pub key
-	blkHash := endorse.blkHash
+	blkHash := en.blkHash
 	endorses := m.ctx.round.commitEndorses[blkHash]
 	if endorses == nil {
-		endorses = map[string]bool{}
+		endorses = map[string]*endorse{}
 		m.ctx.round.commitEndorses[blkHash] = endorses
 	}
-	endorses[endorse.endorser] = endorse.decision
+	endorses[en.endorser] = en
 	// if either yes or no is true, block must exists and blkHash must be a valid one
-	yes, no := m.ctx.calcQuorum(endorses)
+	yes, no := m.ctx.calcCommitQuorum(endorses)
 	if !yes && !no {
 		// Wait for more votes to come
 		return sAcceptCommitEndorse, nil
 	}
 
+	if yes && !no && (m.ctx.round.block == nil || m.ctx.round.block.HashBlock() != blkHash) {
+		blk, ok := m.ctx.round.proposals[blkHash]
+		if !ok {
+			// The delegates have agreed on a block which the node hasn't received yet. Move to the next round, in which
+			// the block will be committed once its late proposal arrives
+			logger.Warn().
+				Uint64("height", m.ctx.round.height).
+				Uint32("round", m.ctx.round.number).
+				Msg("consensus reached on a block which hasn't been received")
+			m.produce(m.newCEvt(eFinishEpoch), 0)
+			return sRoundStart, nil
+		}
+		m.ctx.round.block = blk
+	}
 	return m.processEndorseCommit(yes && !no)
 }
 
+// handleEarlierEndorseCommit collects the commit endorse of an earlier round at the current height, and commits the
+// block agreed in that round if there's a quorum, which the node has missed because it timed out of the round too early
+func (m *cFSM) handleEarlierEndorseCommit(en *endorse) (fsm.State, error) {
+	if !m.validateEndorseOfRound(en, endorseCommit, en.round) {
+		return sAcceptCommitEndorse, nil
+	}
+	if m.ctx.round.earlierCommits == nil {
+		m.ctx.round.earlierCommits = make(map[uint32]map[hash.Hash32B]map[string]*endorse)
+	}
+	commitEndorses := m.ctx.round.earlierCommits[en.round]
+	if commitEndorses == nil {
+		commitEndorses = make(map[hash.Hash32B]map[string]*endorse)
+		m.ctx.round.earlierCommits[en.round] = commitEndorses
+	}
+	endorses := commitEndorses[en.blkHash]
+	if endorses == nil {
+		endorses = map[string]*endorse{}
+		commitEndorses[en.blkHash] = endorses
+	}
+	endorses[en.endorser] = en
+	yes, no := m.ctx.calcCommitQuorum(endorses)
+	blk, ok := m.ctx.round.proposals[en.blkHash]
+	if !yes || no || !ok {
+		return sAcceptCommitEndorse, nil
+	}
+	logger.Info().
+		Uint64("height", m.ctx.round.height).
+		Uint32("round", en.round).
+		Uint32("currentRound", m.ctx.round.number).
+		Msg("commit the block agreed in an earlier round")
+	m.ctx.round.block = blk
+	m.ctx.round.commitEndorses = commitEndorses
+	return m.processEndorseCommit(true)
+}
+
 func (m *cFSM) handleEndorseCommitTimeout(evt fsm.Event) (fsm.State, error) {
 	if evt.Type() != eEndorseCommitTimeout {
 		return sInvalid, errors.Errorf("invalid event type %s", evt.Type())
@@ -807,18 +1244,17 @@ func (m *cFSM) processEndorseCommit(consensus bool) (fsm.State, error) {
 			Uint64("block", height).
 			Msg("consensus reached")
 		consensusMtc.WithLabelValues("true").Inc()
+		if pendingBlock != nil {
+			m.attachCertificate(pendingBlock)
+		}
 	} else {
 		logger.Warn().
 			Uint64("block", height).
 			Bool("consensus", consensus).
 			Msg("consensus did not reach")
 		consensusMtc.WithLabelValues("false").Inc()
-		if m.ctx.cfg.EnableDummyBlock {
-			pendingBlock = m.ctx.chain.MintNewDummyBlock()
-			logger.Warn().
-				Uint64("block", pendingBlock.Height()).
-				Msg("dummy block is generated")
-		}
+		// Gossip the timeout, and move to the next round, in which the next delegate will propose
+		m.broadcastRoundChange(m.ctx.round.number + 1)
 	}
 	if pendingBlock != nil {
 		// Commit and broadcast the pending block
@@ -826,8 +1262,14 @@ func (m *cFSM) processEndorseCommit(consensus bool) (fsm.State, error) {
 			logger.Error().
 				Err(err).
 				Uint64("block", pendingBlock.Height()).
-				Bool("dummy", pendingBlock.IsDummyBlock()).
 				Msg("error when committing a block")
+		} else {
+			if m.ctx.cfg.TimeBasedRotation {
+				m.ctx.recordSkippedSlots(pendingBlock)
+			}
+			if consensus {
+				m.ctx.recordConsensusBlock(pendingBlock, m.ctx.round.commitEndorses[pendingBlock.HashBlock()])
+			}
 		}
 		// Remove transfers in this block from ActPool and reset ActPool state
 		m.ctx.actPool.Reset()
@@ -837,13 +1279,11 @@ func (m *cFSM) processEndorseCommit(consensus bool) (fsm.State, error) {
 				logger.Error().
 					Err(err).
 					Uint64("block", pendingBlock.Height()).
-					Bool("dummy", pendingBlock.IsDummyBlock()).
 					Msg("error when broadcasting blkProto")
 			}
 		} else {
 			logger.Error().
 				Uint64("block", pendingBlock.Height()).
-				Bool("dummy", pendingBlock.IsDummyBlock()).
 				Msg("error when converting a block into a proto msg")
 		}
 	}
@@ -851,6 +1291,278 @@ func (m *cFSM) processEndorseCommit(consensus bool) (fsm.State, error) {
 	return sRoundStart, nil
 }
 
+// attachCertificate puts the signature shares carried by the commit endorses into the block's commit certificate. Only
+// the shares signed with the DKG public key shares recorded on chain are included. The block is committed without a
+// certificate only if they are not from a quorum of the delegates, which calcCommitQuorum prevents once the
+// certificate is expected.
+func (m *cFSM) attachCertificate(blk *blockchain.Block) {
+	var (
+		endorsers  []string
+		dkgIDs     [][]byte
+		dkgPubkeys [][]byte
+		dkgSigs    [][]byte
+	)
+	if !m.ctx.shouldHandleDKG() {
+		return
+	}
+	shares, err := m.ctx.pubkeyShares()
+	if err != nil {
+		logger.Error().
+			Err(err).
+			Uint64("block", blk.Height()).
+			Msg("error when getting the recorded DKG public key shares")
+		return
+	}
+	for endorser, en := range m.ctx.round.commitEndorses[blk.HashBlock()] {
+		if !en.decision || !en.hasDKGSignature() {
+			continue
+		}
+		if share, ok := shares[endorser]; !ok || !bytes.Equal(en.dkgPubkey, share) {
+			continue
+		}
+		endorsers = append(endorsers, endorser)
+		dkgIDs = append(dkgIDs, en.dkgID)
+		dkgPubkeys = append(dkgPubkeys, en.dkgPubkey)
+		dkgSigs = append(dkgSigs, en.dkgSignature)
+	}
+	if len(endorsers) < blockchain.CertificateQuorum(len(m.ctx.epoch.delegates)) {
+		logger.Debug().
+			Uint64("block", blk.Height()).
+			Int("numOfSignatureShares", len(endorsers)).
+			Msg("not enough signature shares to generate the commit certificate")
+		return
+	}
+	cert, err := blockchain.NewCommitCertificate(
+		blk,
+		len(m.ctx.epoch.delegates),
+		endorsers,
+		dkgIDs,
+		dkgPubkeys,
+		dkgSigs,
+	)
+	if err != nil {
+		logger.Error().
+			Err(err).
+			Uint64("block", blk.Height()).
+			Msg("error when generating the commit certificate")
+		return
+	}
+	blk.Certificate = cert
+}
+
+// broadcastRoundChange signs and gossips the timeout of the current round, which asks to move to the given round with
+// the block that the node has locked on
+func (m *cFSM) broadcastRoundChange(number uint32) {
+	en := &endorse{
+		topic:  endorseRoundChange,
+		height: m.ctx.round.height,
+		round:  number,
+	}
+	if m.ctx.round.lockedBlock != nil {
+		en.blkHash = m.ctx.round.lockedBlock.HashBlock()
+		en.lockedRound = m.ctx.round.lockedRound
+		en.lockProof = m.ctx.round.lockProof
+	}
+	if !m.checkSignedMsg(endorseRoundChange.String(), &signedMsg{height: en.height, round: number, blkHash: en.blkHash}) {
+		return
+	}
+	if err := en.Sign(m.ctx.addr); err != nil {
+		logger.Error().
+			Err(err).
+			Uint64("height", en.height).
+			Uint32("round", number).
+			Msg("error when signing the round change")
+		return
+	}
+	m.ctx.round.addRoundChange(en)
+	if err := m.ctx.p2p.Broadcast(m.ctx.chain.ChainID(), en.toProtoMsg()); err != nil {
+		logger.Error().
+			Err(err).
+			Msg("error when broadcasting round change")
+	}
+}
+
+func (m *cFSM) validateRoundChange(en *endorse) bool {
+	errorLog := logger.Error().
+		Uint64("expectedHeight", m.ctx.round.height).
+		Uint32("currentRound", m.ctx.round.number).
+		Str("endorser", en.endorser)
+	if en.topic != endorseRoundChange {
+		errorLog.Uint8("endorseTopic", uint8(en.topic)).
+			Msg("error when validating the round change topic")
+		return false
+	}
+	if en.height != m.ctx.round.height || en.round <= m.ctx.round.number {
+		logger.Debug().
+			Uint64("height", en.height).
+			Uint32("round", en.round).
+			Uint64("currentHeight", m.ctx.round.height).
+			Uint32("currentRound", m.ctx.round.number).
+			Msg("the round change is stale")
+		return false
+	}
+	if en.blkHash != hash.ZeroHash32B && en.lockedRound >= en.round {
+		errorLog.Uint32("round", en.round).
+			Uint32("lockedRound", en.lockedRound).
+			Msg("error when validating the locked round of the round change")
+		return false
+	}
+	if !m.isEpochDelegate(en.endorser) {
+		errorLog.Msg("error when validating the endorser, which is not a delegate of the current epoch")
+		return false
+	}
+	if !en.VerifySignature(en.endorserPubkey) {
+		errorLog.Msg("error when validating the round change signature")
+		return false
+	}
+	if _, ok := m.ctx.round.roundChanges[en.round][en.endorser]; ok {
+		errorLog.Uint32("round", en.round).
+			Msg("error when validating the round change, which is a duplicate from the same endorser")
+		return false
+	}
+	if en.blkHash != hash.ZeroHash32B {
+		if err := m.verifyLockProof(en); err != nil {
+			errorLog.Err(err).
+				Uint32("lockedRound", en.lockedRound).
+				Msg("error when validating the lock of the round change")
+			return false
+		}
+	}
+	return true
+}
+
+// verifyLockProof verifies that the lock of the round change is proved by the proposal endorses of the locked block in
+// the locked round, which reach the quorum of the delegates. Otherwise, a single delegate could unlock the others by
+// claiming a later lock.
+func (m *cFSM) verifyLockProof(en *endorse) error {
+	endorses := make(map[string]*endorse, len(en.lockProof))
+	for _, proof := range en.lockProof {
+		if proof.topic != endorseProposal ||
+			proof.height != en.height ||
+			proof.round != en.lockedRound ||
+			proof.blkHash != en.blkHash ||
+			!proof.decision {
+			return errors.Errorf("the proof from %s isn't for the locked block in the locked round", proof.endorser)
+		}
+		if !m.isEpochDelegate(proof.endorser) {
+			return errors.Errorf("the proof endorser %s is not a delegate of the current epoch", proof.endorser)
+		}
+		if !proof.VerifySignature(proof.endorserPubkey) {
+			return errors.Errorf("the signature of the proof from %s is invalid", proof.endorser)
+		}
+		endorses[proof.endorser] = proof
+	}
+	if yes, _ := m.ctx.calcQuorum(endorses); !yes {
+		return errors.Errorf("%d proposal endorses are not enough to prove the lock", len(endorses))
+	}
+	return nil
+}
+
+// roundChangeTransition binds the round change handler to the source state, because the FSM couldn't be queried for
+// its current state while handling an event
+func (m *cFSM) roundChangeTransition(state fsm.State) fsm.Transition {
+	return func(evt fsm.Event) (fsm.State, error) {
+		return m.handleRoundChangeEvt(state, evt)
+	}
+}
+
+func (m *cFSM) handleRoundChangeEvt(state fsm.State, evt fsm.Event) (fsm.State, error) {
+	if evt.Type() != eRoundChange {
+		return sInvalid, errors.Errorf("invalid event type %s", evt.Type())
+	}
+	endorseEvt, ok := evt.(*endorseEvt)
+	if !ok {
+		return sInvalid, errors.Wrap(ErrEvtCast, "the event is not an endorseEvt")
+	}
+	en := endorseEvt.endorse
+	if en.height > m.ctx.round.height {
+		// The round change is for the next block, which this node hasn't started yet
+		m.produceLater(endorseEvt)
+		return state, nil
+	}
+	if !m.validateRoundChange(en) {
+		return state, nil
+	}
+	roundChanges := m.ctx.round.addRoundChange(en)
+	if m.ctx.cfg.TimeBasedRotation {
+		// The rounds move with the time slots, so the round change only carries the lock
+		return state, nil
+	}
+	if !m.ctx.calcRoundChange(roundChanges) || en.round <= m.ctx.round.skipTo {
+		// Wait for more delegates to time out
+		return state, nil
+	}
+	logger.Warn().
+		Uint64("height", m.ctx.round.height).
+		Uint32("round", m.ctx.round.number).
+		Uint32("nextRound", en.round).
+		Int("numOfRoundChanges", len(roundChanges)).
+		Msg("enough delegates have timed out, move to the later round")
+	m.ctx.round.skipTo = en.round
+	if _, ok := roundChanges[m.ctx.addr.RawAddress]; !ok {
+		m.broadcastRoundChange(en.round)
+	}
+	if state == sRoundStart {
+		// The pending start round event will move to the later round
+		return sRoundStart, nil
+	}
+	m.produce(m.newCEvt(eStartRound), 0)
+	return sRoundStart, nil
+}
+
+// checkSigned records the message of the current round to sign, and refuses to sign it if it conflicts with a signed one
+func (m *cFSM) checkSigned(topic string, blkHash hash.Hash32B, decision bool) bool {
+	return m.checkSignedMsg(topic, &signedMsg{
+		height:   m.ctx.round.height,
+		round:    m.ctx.round.number,
+		blkHash:  blkHash,
+		decision: decision,
+	})
+}
+
+func (m *cFSM) checkSignedMsg(topic string, msg *signedMsg) bool {
+	if err := m.ctx.signed.checkAndPut(topic, msg); err != nil {
+		logger.Error().
+			Err(err).
+			Str("topic", topic).
+			Uint64("height", msg.height).
+			Uint32("round", msg.round).
+			Msg("refuse to sign the message")
+		return false
+	}
+	return true
+}
+
+// isLaterRound checks if the endorse is for a later round at the current height
+func (m *cFSM) isLaterRound(en *endorse) bool {
+	return en.height == m.ctx.round.height && en.round > m.ctx.round.number
+}
+
+// isEarlierProposer checks if the producer is the proposer of any earlier round at the current height, whose block
+// could be locked by other delegates without this node knowing it. The proposers repeat every len(delegates) rounds.
+func (m *cFSM) isEarlierProposer(producer string) bool {
+	for round := uint32(0); round < m.ctx.round.number && round < uint32(len(m.ctx.epoch.delegates)); round++ {
+		proposer, err := m.ctx.calcProposer(m.ctx.round.height, round, m.ctx.epoch.delegates)
+		if err == nil && proposer == producer {
+			return true
+		}
+	}
+	return false
+}
+
+// isEarlierRound checks if the endorse is for an earlier round at the current height
+func (m *cFSM) isEarlierRound(en *endorse) bool {
+	return en.height == m.ctx.round.height && en.round < m.ctx.round.number
+}
+
+// produceLater puts the event back into the queue, so that it could be handled after the node moves to the round that
+// the event is for, unless the event has been unmatched for too long
+func (m *cFSM) produceLater(evt iConsensusEvt) {
+	if m.ctx.clock.Now().Sub(evt.timestamp()) <= m.ctx.cfg.UnmatchedEventTTL {
+		m.produce(evt, m.ctx.cfg.UnmatchedEventInterval)
+	}
+}
+
 func (m *cFSM) handleFinishEpochEvt(evt fsm.Event) (fsm.State, error) {
 	finished, err := m.ctx.isEpochFinished()
 	if err != nil {
@@ -860,6 +1572,9 @@ func (m *cFSM) handleFinishEpochEvt(evt fsm.Event) (fsm.State, error) {
 		m.produce(m.newCEvt(eRollDelegates), 0)
 		return sEpochStart, nil
 	}
+	if m.ctx.shouldHandleDKG() {
+		m.tryGenerateDKGKeyPair()
+	}
 	if err := m.produceStartRoundEvt(); err != nil {
 		return sInvalid, errors.Wrapf(err, "error when producing %s", eStartRound)
 	}
@@ -867,9 +1582,31 @@ func (m *cFSM) handleFinishEpochEvt(evt fsm.Event) (fsm.State, error) {
 
 }
 
+// tryGenerateDKGKeyPair generates the DKG key pair once all the secret blocks of the epoch are committed
+func (m *cFSM) tryGenerateDKGKeyPair() {
+	if m.ctx.epoch.dkgFinished || !m.ctx.isDKGPhaseFinished() {
+		return
+	}
+	m.ctx.epoch.dkgFinished = true
+	if err := m.ctx.generateDKGKeyPair(); err != nil {
+		logger.Error().Err(err).Uint64("epoch", m.ctx.epoch.num).Msg("error when generating the DKG key pair")
+		return
+	}
+	logger.Info().Uint64("epoch", m.ctx.epoch.num).Msg("generated the DKG key pair")
+}
+
 func (m *cFSM) isDelegate(delegates []string) bool {
+	return isDelegate(m.ctx.addr.RawAddress, delegates)
+}
+
+// isEpochDelegate checks if the given address is one of the delegates of the current epoch
+func (m *cFSM) isEpochDelegate(addr string) bool {
+	return isDelegate(addr, m.ctx.epoch.delegates)
+}
+
+func isDelegate(addr string, delegates []string) bool {
 	for _, d := range delegates {
-		if m.ctx.addr.RawAddress == d {
+		if addr == d {
 			return true
 		}
 	}
@@ -877,6 +1614,15 @@ func (m *cFSM) isDelegate(delegates []string) bool {
 }
 
 func (m *cFSM) produceStartRoundEvt() error {
+	if m.ctx.cfg.TimeBasedRotation {
+		// The next round will be started at the beginning of its slot
+		slot, err := m.ctx.nextRoundSlot()
+		if err != nil {
+			return errors.Wrap(err, "error when calculating the slot of the next round")
+		}
+		m.produce(m.newCEvt(eStartRound), m.ctx.slotStartTime(slot).Sub(m.ctx.clock.Now()))
+		return nil
+	}
 	var (
 		duration time.Duration
 		err      error
@@ -913,7 +1659,7 @@ func (m *cFSM) newCEvt(t fsm.EventType) *consensusEvt {
 }
 
 func (m *cFSM) newProposeBlkEvt(blk *blockchain.Block) *proposeBlkEvt {
-	return newProposeBlkEvt(blk, m.ctx.clock)
+	return newProposeBlkEvt(blk, m.ctx.round.number, m.ctx.clock)
 }
 
 func (m *cFSM) newProposeBlkEvtFromProposePb(pb *iproto.ProposePb) (*proposeBlkEvt, error) {
@@ -933,11 +1679,36 @@ func (m *cFSM) newEndorseEvtWithEndorsePb(ePb *iproto.EndorsePb) (*endorseEvt, e
 }
 
 func (m *cFSM) newEndorseProposalEvt(blkHash hash.Hash32B, decision bool) (*endorseEvt, error) {
-	return newEndorseEvt(endorseProposal, blkHash, decision, m.ctx.round.height, m.ctx.addr, m.ctx.clock)
+	return newEndorseEvt(
+		endorseProposal,
+		blkHash,
+		decision,
+		m.ctx.round.height,
+		m.ctx.round.number,
+		m.ctx.addr,
+		m.ctx.clock,
+	)
 }
 
 func (m *cFSM) newEndorseCommitEvt(blkHash hash.Hash32B, decision bool) (*endorseEvt, error) {
-	return newEndorseEvt(endorseCommit, blkHash, decision, m.ctx.round.height, m.ctx.addr, m.ctx.clock)
+	en := &endorse{
+		height:   m.ctx.round.height,
+		round:    m.ctx.round.number,
+		topic:    endorseCommit,
+		blkHash:  blkHash,
+		decision: decision,
+	}
+	// Attach the signature share for the commit certificate if the DKG key pair of the epoch is ready
+	if decision && len(m.ctx.epoch.dkgAddress.PrivateKey) > 0 {
+		if err := en.SignDKG(&m.ctx.epoch.dkgAddress); err != nil {
+			logger.Error().Err(err).Bytes("Block Hash", blkHash[:]).Msg("failed to sign DKG signature share for block")
+		}
+	}
+	if err := en.Sign(m.ctx.addr); err != nil {
+		logger.Error().Err(err).Bytes("Block Hash", blkHash[:]).Str("endorser", m.ctx.addr.RawAddress).Msg("failed to sign endorse for block")
+		return nil, err
+	}
+	return newEndorseEvtWithEndorse(en, m.ctx.clock), nil
 }
 
 func (m *cFSM) newTimeoutEvt(t fsm.EventType, height uint64) *timeoutEvt {
@@ -948,46 +1719,7 @@ func (m *cFSM) newBackdoorEvt(dst fsm.State) *backdoorEvt {
 	return newBackdoorEvt(dst, m.ctx.clock)
 }
 
-func (m *cFSM) updateSeed() ([]byte, error) {
-	numDlgs := m.ctx.cfg.NumDelegates
-	epochNum, epochHeight, err := m.ctx.calcEpochNumAndHeight()
-	if err != nil {
-		return []byte{}, errors.Wrap(err, "Failed to do decode seed")
-	}
-	if epochNum <= 1 {
-		return []byte{}, nil
-	}
-	selectedID := make([][]uint8, 0)
-	selectedSig := make([][]byte, 0)
-	selectedPK := make([][]byte, 0)
-	endHeight := epochHeight - 1
-	startHeight := uint64(numDlgs)*uint64(m.ctx.cfg.NumSubEpochs)*(epochNum-2) + 1
-	for i := startHeight; i <= endHeight && len(selectedID) < crypto.Degree+1; i++ {
-		blk, err := m.ctx.chain.GetBlockByHeight(i)
-		if err != nil {
-			continue
-		}
-		if len(blk.Header.DKGID) > 0 && len(blk.Header.DKGPubkey) > 0 && len(blk.Header.DKGBlockSig) > 0 {
-			selectedID = append(selectedID, blk.Header.DKGID)
-			selectedSig = append(selectedSig, blk.Header.DKGBlockSig)
-			selectedPK = append(selectedPK, blk.Header.DKGPubkey)
-		}
-	}
-
-	if len(selectedID) < crypto.Degree+1 {
-		return []byte{}, errors.New("DKG signature/pubic key is not enough to aggregate")
-	}
-
-	aggregateSig, err := crypto.BLS.SignAggregate(selectedID, selectedSig)
-	if err != nil {
-		return []byte{}, errors.Wrap(err, "Failed to generate aggregate signature to update Seed")
-	}
-	if err = crypto.BLS.VerifyAggregate(selectedID, selectedPK, m.ctx.epoch.seed, aggregateSig); err != nil {
-		return []byte{}, errors.Wrap(err, "Failed to verify aggregate signature to update Seed")
-	}
-	return aggregateSig, nil
-}
-
-func verifyDKGSignature(blk *blockchain.Block, seedByte []byte) error {
-	return crypto.BLS.Verify(blk.Header.DKGPubkey, seedByte, blk.Header.DKGBlockSig)
+// verifyDKGSignature verifies the block's DKG signature of the seed against the producer's DKG public key share
+func verifyDKGSignature(pubkeyShare []byte, blk *blockchain.Block, seedByte []byte) error {
+	return crypto.BLS.Verify(pubkeyShare, seedByte, blk.Header.DKGBlockSig)
 }
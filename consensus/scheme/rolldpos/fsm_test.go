@@ -8,14 +8,14 @@ package rolldpos
 
 import (
 	"context"
-	"encoding/hex"
-	"fmt"
 	"math/big"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/facebookgo/clock"
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,7 +26,9 @@ import (
 	"github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/enc"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/proto"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
@@ -92,7 +94,7 @@ func TestRollDelegatesEvt(t *testing.T) {
 		assert.Equal(t, uint64(1), cfsm.ctx.epoch.height)
 		assert.Equal(t, uint64(1), cfsm.ctx.epoch.num)
 		assert.Equal(t, uint(1), cfsm.ctx.epoch.numSubEpochs)
-		crypto.SortCandidates(delegates, cfsm.ctx.epoch.num)
+		crypto.SortCandidates(delegates, cfsm.ctx.epoch.num, nil)
 		assert.Equal(t, delegates, cfsm.ctx.epoch.delegates)
 		assert.Equal(t, eGenerateDKG, (<-cfsm.evtq).Type())
 	})
@@ -280,8 +282,8 @@ func TestHandleInitBlockEvt(t *testing.T) {
 		numSubEpochs: uint(1),
 	}
 	cfsm.ctx.round = roundCtx{
-		proposalEndorses: make(map[hash.Hash32B]map[string]bool),
-		commitEndorses:   make(map[hash.Hash32B]map[string]bool),
+		proposalEndorses: make(map[hash.Hash32B]map[string]*endorse),
+		commitEndorses:   make(map[hash.Hash32B]map[string]*endorse),
 		proposer:         delegates[2],
 	}
 
@@ -317,10 +319,24 @@ func TestHandleProposeBlockEvt(t *testing.T) {
 	}
 	round := roundCtx{
 		height:           2,
-		proposalEndorses: make(map[hash.Hash32B]map[string]bool),
-		commitEndorses:   make(map[hash.Hash32B]map[string]bool),
+		proposalEndorses: make(map[hash.Hash32B]map[string]*endorse),
+		commitEndorses:   make(map[hash.Hash32B]map[string]*endorse),
 		proposer:         delegates[2],
 	}
+	// newSlotClock returns a clock at the beginning of the slot of the proposer interval
+	newSlotClock := func(slot uint64, interval time.Duration) *clock.Mock {
+		clk := clock.NewMock()
+		clk.Add(genesisTime().Add(time.Duration(slot) * interval).Sub(clk.Now()))
+		return clk
+	}
+	// newSlotBlock returns the block 2 produced at the current time
+	newSlotBlock := func(t *testing.T, cfsm *cFSM, producer *iotxaddress.Address) *blockchain.Block {
+		lastBlk, err := cfsm.ctx.chain.GetBlockByHeight(1)
+		require.NoError(t, err)
+		blk := blockchain.NewBlock(config.Default.Chain.ID, 2, lastBlk.HashBlock(), cfsm.ctx.clock, nil, nil, nil)
+		require.NoError(t, blk.SignBlock(producer))
+		return blk
+	}
 
 	t.Run("pass-validation", func(t *testing.T) {
 		cfsm := newTestCFSM(
@@ -341,7 +357,7 @@ func TestHandleProposeBlockEvt(t *testing.T) {
 		blk, err := cfsm.ctx.mintBlock()
 
 		assert.NoError(t, err)
-		state, err := cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, cfsm.ctx.clock))
+		state, err := cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, 0, cfsm.ctx.clock))
 
 		assert.NoError(t, err)
 		assert.Equal(t, sAcceptProposalEndorse, state)
@@ -354,7 +370,8 @@ func TestHandleProposeBlockEvt(t *testing.T) {
 	})
 
 	t.Run("pass-validation-time-rotation", func(t *testing.T) {
-		clock := clock.NewMock()
+		// The block 1 is committed in the slot 1
+		clk := newSlotClock(1, 10*time.Second)
 		cfsm := newTestCFSM(
 			t,
 			testAddrs[0],
@@ -365,17 +382,18 @@ func TestHandleProposeBlockEvt(t *testing.T) {
 			func(p2p *mock_network.MockOverlay) {
 				p2p.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Return(nil).Times(2)
 			},
-			clock,
+			clk,
 		)
 		cfsm.ctx.cfg.TimeBasedRotation = true
 		cfsm.ctx.cfg.ProposerInterval = 10 * time.Second
 		cfsm.ctx.epoch = epoch
 		cfsm.ctx.round = round
 
-		clock.Add(11 * time.Second)
-		blk, err := cfsm.ctx.mintBlock()
-		assert.NoError(t, err)
-		state, err := cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, cfsm.ctx.clock))
+		// The round 0 takes the slot 2, whose proposer is delegates[2]
+		clk.Add(10 * time.Second)
+		cfsm.ctx.round.slot = 2
+		blk := newSlotBlock(t, cfsm, testAddrs[2])
+		state, err := cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, 0, cfsm.ctx.clock))
 		assert.NoError(t, err)
 		assert.Equal(t, sAcceptProposalEndorse, state)
 		e := <-cfsm.evtq
@@ -385,10 +403,12 @@ func TestHandleProposeBlockEvt(t *testing.T) {
 		assert.True(t, evt.endorse.decision)
 		assert.Equal(t, eEndorseProposalTimeout, (<-cfsm.evtq).Type())
 
-		clock.Add(10 * time.Second)
-		err = blk.SignBlock(testAddrs[3])
-		assert.NoError(t, err)
-		state, err = cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, cfsm.ctx.clock))
+		// The round 1 takes the slot 3, whose proposer is delegates[3]
+		clk.Add(10 * time.Second)
+		cfsm.ctx.round.number = 1
+		cfsm.ctx.round.slot = 3
+		blk = newSlotBlock(t, cfsm, testAddrs[3])
+		state, err = cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, 1, cfsm.ctx.clock))
 		assert.NoError(t, err)
 		assert.Equal(t, sAcceptProposalEndorse, state)
 		e = <-cfsm.evtq
@@ -417,7 +437,7 @@ func TestHandleProposeBlockEvt(t *testing.T) {
 
 		blk, err := cfsm.ctx.mintBlock()
 		assert.NoError(t, err)
-		state, err := cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, cfsm.ctx.clock))
+		state, err := cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, 0, cfsm.ctx.clock))
 		assert.NoError(t, err)
 		assert.Equal(t, sAcceptPropose, state)
 	})
@@ -442,7 +462,7 @@ func TestHandleProposeBlockEvt(t *testing.T) {
 
 		blk, err := cfsm.ctx.mintBlock()
 		assert.NoError(t, err)
-		state, err := cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, cfsm.ctx.clock))
+		state, err := cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, 0, cfsm.ctx.clock))
 		assert.NoError(t, err)
 		assert.Equal(t, sAcceptProposalEndorse, state)
 		e := <-cfsm.evtq
@@ -469,7 +489,7 @@ func TestHandleProposeBlockEvt(t *testing.T) {
 
 		blk, err := cfsm.ctx.mintBlock()
 		assert.NoError(t, err)
-		state, err := cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, cfsm.ctx.clock))
+		state, err := cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, 0, cfsm.ctx.clock))
 		assert.NoError(t, err)
 		assert.Equal(t, sAcceptPropose, state)
 		state, err = cfsm.handleProposeBlockTimeout(cfsm.newCEvt(eProposeBlockTimeout))
@@ -478,7 +498,7 @@ func TestHandleProposeBlockEvt(t *testing.T) {
 	})
 
 	t.Run("invalid-proposer-time-rotation", func(t *testing.T) {
-		clock := clock.NewMock()
+		clk := newSlotClock(1, 10*time.Second)
 		cfsm := newTestCFSM(
 			t,
 			testAddrs[2],
@@ -487,17 +507,18 @@ func TestHandleProposeBlockEvt(t *testing.T) {
 			delegates,
 			nil,
 			nil,
-			clock,
+			clk,
 		)
 		cfsm.ctx.cfg.TimeBasedRotation = true
 		cfsm.ctx.cfg.ProposerInterval = 10 * time.Second
 		cfsm.ctx.epoch = epoch
 		cfsm.ctx.round = round
 
-		clock.Add(11 * time.Second)
-		blk, err := cfsm.ctx.mintBlock()
-		assert.NoError(t, err)
-		state, err := cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, cfsm.ctx.clock))
+		// delegates[3] is not the proposer of the slot 2
+		clk.Add(10 * time.Second)
+		cfsm.ctx.round.slot = 2
+		blk := newSlotBlock(t, cfsm, testAddrs[3])
+		state, err := cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, 0, cfsm.ctx.clock))
 		assert.NoError(t, err)
 		assert.Equal(t, sAcceptPropose, state)
 		state, err = cfsm.handleProposeBlockTimeout(cfsm.newCEvt(eProposeBlockTimeout))
@@ -505,6 +526,42 @@ func TestHandleProposeBlockEvt(t *testing.T) {
 		assert.Equal(t, sAcceptProposalEndorse, state)
 	})
 
+	t.Run("block-out-of-slot-time-rotation", func(t *testing.T) {
+		clk := newSlotClock(1, 10*time.Second)
+		cfsm := newTestCFSM(
+			t,
+			testAddrs[0],
+			testAddrs[2],
+			ctrl,
+			delegates,
+			nil,
+			func(p2p *mock_network.MockOverlay) {
+				p2p.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Times(0)
+			},
+			clk,
+		)
+		cfsm.ctx.cfg.TimeBasedRotation = true
+		cfsm.ctx.cfg.ProposerInterval = 10 * time.Second
+		cfsm.ctx.epoch = epoch
+		cfsm.ctx.round = round
+		cfsm.ctx.round.slot = 2
+
+		// The block is in the same slot as the block 1
+		blk := newSlotBlock(t, cfsm, testAddrs[2])
+		state, err := cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, 0, cfsm.ctx.clock))
+		assert.NoError(t, err)
+		assert.Equal(t, sAcceptPropose, state)
+		require.Equal(t, ErrBlockOutOfSlot, errors.Cause(cfsm.ctx.validateBlockSlot(blk)))
+
+		// The block is in the slot 3, which the current round hasn't reached
+		clk.Add(20 * time.Second)
+		blk = newSlotBlock(t, cfsm, testAddrs[2])
+		state, err = cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, 0, cfsm.ctx.clock))
+		assert.NoError(t, err)
+		assert.Equal(t, sAcceptPropose, state)
+		require.Equal(t, ErrBlockOutOfSlot, errors.Cause(cfsm.ctx.validateBlockSlot(blk)))
+	})
+
 	t.Run("timeout", func(t *testing.T) {
 		cfsm := newTestCFSM(
 			t,
@@ -548,8 +605,8 @@ func TestHandleProposalEndorseEvt(t *testing.T) {
 		numSubEpochs: uint(1),
 	}
 	round := roundCtx{
-		proposalEndorses: make(map[hash.Hash32B]map[string]bool),
-		commitEndorses:   make(map[hash.Hash32B]map[string]bool),
+		proposalEndorses: make(map[hash.Hash32B]map[string]*endorse),
+		commitEndorses:   make(map[hash.Hash32B]map[string]*endorse),
 		proposer:         delegates[2],
 	}
 
@@ -576,21 +633,21 @@ func TestHandleProposalEndorseEvt(t *testing.T) {
 		cfsm.ctx.round.block = blk
 
 		// First endorse prepare
-		eEvt, err := newEndorseEvt(endorseProposal, blk.HashBlock(), true, round.height, testAddrs[0], cfsm.ctx.clock)
+		eEvt, err := newEndorseEvt(endorseProposal, blk.HashBlock(), true, round.height, 0, testAddrs[0], cfsm.ctx.clock)
 		assert.NoError(t, err)
 		state, err := cfsm.handleEndorseProposalEvt(eEvt)
 		assert.NoError(t, err)
 		assert.Equal(t, sAcceptProposalEndorse, state)
 
 		// Second endorse prepare
-		eEvt, err = newEndorseEvt(endorseProposal, blk.HashBlock(), true, round.height, testAddrs[1], cfsm.ctx.clock)
+		eEvt, err = newEndorseEvt(endorseProposal, blk.HashBlock(), true, round.height, 0, testAddrs[1], cfsm.ctx.clock)
 		assert.NoError(t, err)
 		state, err = cfsm.handleEndorseProposalEvt(eEvt)
 		assert.NoError(t, err)
 		assert.Equal(t, sAcceptProposalEndorse, state)
 
 		// Third endorse prepare, could move on
-		eEvt, err = newEndorseEvt(endorseProposal, blk.HashBlock(), true, round.height, testAddrs[2], cfsm.ctx.clock)
+		eEvt, err = newEndorseEvt(endorseProposal, blk.HashBlock(), true, round.height, 0, testAddrs[2], cfsm.ctx.clock)
 		assert.NoError(t, err)
 		state, err = cfsm.handleEndorseProposalEvt(eEvt)
 		assert.NoError(t, err)
@@ -633,6 +690,110 @@ func TestHandleProposalEndorseEvt(t *testing.T) {
 	})
 }
 
+func TestHandleByzantineEndorseEvt(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	delegates := make([]string, 4)
+	for i := 0; i < 4; i++ {
+		delegates[i] = testAddrs[i].RawAddress
+	}
+
+	epoch := epochCtx{
+		delegates:    delegates,
+		num:          uint64(1),
+		height:       uint64(1),
+		numSubEpochs: uint(1),
+	}
+	newRound := func() roundCtx {
+		return roundCtx{
+			proposalEndorses: make(map[hash.Hash32B]map[string]*endorse),
+			commitEndorses:   make(map[hash.Hash32B]map[string]*endorse),
+			proposer:         delegates[2],
+		}
+	}
+
+	t.Run("non-delegate-endorses", func(t *testing.T) {
+		cfsm := newTestCFSM(t, testAddrs[0], testAddrs[2], ctrl, delegates, nil, nil, clock.New())
+		cfsm.ctx.epoch = epoch
+		cfsm.ctx.round = newRound()
+
+		blk, err := cfsm.ctx.mintBlock()
+		require.NoError(t, err)
+		// testAddrs[4] is not a delegate of the current epoch
+		eEvt, err := newEndorseEvt(endorseProposal, blk.HashBlock(), true, 0, 0, testAddrs[4], cfsm.ctx.clock)
+		require.NoError(t, err)
+		state, err := cfsm.handleEndorseProposalEvt(eEvt)
+		assert.NoError(t, err)
+		assert.Equal(t, sAcceptProposalEndorse, state)
+		assert.Equal(t, 0, len(cfsm.ctx.round.proposalEndorses))
+
+		eEvt, err = newEndorseEvt(endorseCommit, blk.HashBlock(), true, 0, 0, testAddrs[4], cfsm.ctx.clock)
+		require.NoError(t, err)
+		state, err = cfsm.handleEndorseCommitEvt(eEvt)
+		assert.NoError(t, err)
+		assert.Equal(t, sAcceptCommitEndorse, state)
+		assert.Equal(t, 0, len(cfsm.ctx.round.commitEndorses))
+	})
+
+	t.Run("forged-signature", func(t *testing.T) {
+		cfsm := newTestCFSM(t, testAddrs[0], testAddrs[2], ctrl, delegates, nil, nil, clock.New())
+		cfsm.ctx.epoch = epoch
+		cfsm.ctx.round = newRound()
+
+		blk, err := cfsm.ctx.mintBlock()
+		require.NoError(t, err)
+		// testAddrs[4] signs the endorse, but claims to be testAddrs[1]
+		eEvt, err := newEndorseEvt(endorseProposal, blk.HashBlock(), true, 0, 0, testAddrs[4], cfsm.ctx.clock)
+		require.NoError(t, err)
+		eEvt.endorse.endorser = testAddrs[1].RawAddress
+		state, err := cfsm.handleEndorseProposalEvt(eEvt)
+		assert.NoError(t, err)
+		assert.Equal(t, sAcceptProposalEndorse, state)
+		assert.Equal(t, 0, len(cfsm.ctx.round.proposalEndorses))
+
+		// testAddrs[1]'s endorse with a tampered decision
+		eEvt, err = newEndorseEvt(endorseProposal, blk.HashBlock(), true, 0, 0, testAddrs[1], cfsm.ctx.clock)
+		require.NoError(t, err)
+		eEvt.endorse.decision = false
+		state, err = cfsm.handleEndorseProposalEvt(eEvt)
+		assert.NoError(t, err)
+		assert.Equal(t, sAcceptProposalEndorse, state)
+		assert.Equal(t, 0, len(cfsm.ctx.round.proposalEndorses))
+	})
+
+	t.Run("duplicate-endorses", func(t *testing.T) {
+		cfsm := newTestCFSM(t, testAddrs[0], testAddrs[2], ctrl, delegates, nil, nil, clock.New())
+		cfsm.ctx.epoch = epoch
+		cfsm.ctx.round = newRound()
+
+		blk, err := cfsm.ctx.mintBlock()
+		require.NoError(t, err)
+		cfsm.ctx.round.block = blk
+
+		// The same delegate endorses the same block three times, which must not make the quorum
+		for i := 0; i < 3; i++ {
+			eEvt, err := newEndorseEvt(endorseProposal, blk.HashBlock(), true, 0, 0, testAddrs[1], cfsm.ctx.clock)
+			require.NoError(t, err)
+			state, err := cfsm.handleEndorseProposalEvt(eEvt)
+			assert.NoError(t, err)
+			assert.Equal(t, sAcceptProposalEndorse, state)
+		}
+		assert.Equal(t, 1, len(cfsm.ctx.round.proposalEndorses[blk.HashBlock()]))
+
+		// The same delegate endorses a conflicting block
+		var fakeHash hash.Hash32B
+		eEvt, err := newEndorseEvt(endorseProposal, fakeHash, true, 0, 0, testAddrs[1], cfsm.ctx.clock)
+		require.NoError(t, err)
+		state, err := cfsm.handleEndorseProposalEvt(eEvt)
+		assert.NoError(t, err)
+		assert.Equal(t, sAcceptProposalEndorse, state)
+		assert.Nil(t, cfsm.ctx.round.proposalEndorses[fakeHash])
+	})
+}
+
 func TestHandleCommitEndorseEvt(t *testing.T) {
 	t.Parallel()
 
@@ -651,8 +812,8 @@ func TestHandleCommitEndorseEvt(t *testing.T) {
 		numSubEpochs: uint(1),
 	}
 	round := roundCtx{
-		proposalEndorses: make(map[hash.Hash32B]map[string]bool),
-		commitEndorses:   make(map[hash.Hash32B]map[string]bool),
+		proposalEndorses: make(map[hash.Hash32B]map[string]*endorse),
+		commitEndorses:   make(map[hash.Hash32B]map[string]*endorse),
 		proposer:         delegates[2],
 	}
 
@@ -680,28 +841,28 @@ func TestHandleCommitEndorseEvt(t *testing.T) {
 		cfsm.ctx.round.block = blk
 
 		// First endorse prepare
-		eEvt, err := newEndorseEvt(endorseCommit, blk.HashBlock(), true, round.height, testAddrs[0], cfsm.ctx.clock)
+		eEvt, err := newEndorseEvt(endorseCommit, blk.HashBlock(), true, round.height, 0, testAddrs[0], cfsm.ctx.clock)
 		assert.NoError(t, err)
 		state, err := cfsm.handleEndorseCommitEvt(eEvt)
 		assert.NoError(t, err)
 		assert.Equal(t, sAcceptCommitEndorse, state)
 
 		// Second endorse prepare
-		eEvt, err = newEndorseEvt(endorseCommit, blk.HashBlock(), true, round.height, testAddrs[1], cfsm.ctx.clock)
+		eEvt, err = newEndorseEvt(endorseCommit, blk.HashBlock(), true, round.height, 0, testAddrs[1], cfsm.ctx.clock)
 		assert.NoError(t, err)
 		state, err = cfsm.handleEndorseCommitEvt(eEvt)
 		assert.NoError(t, err)
 		assert.Equal(t, sAcceptCommitEndorse, state)
 
 		// Third endorse prepare, could move on
-		eEvt, err = newEndorseEvt(endorseCommit, blk.HashBlock(), true, round.height, testAddrs[2], cfsm.ctx.clock)
+		eEvt, err = newEndorseEvt(endorseCommit, blk.HashBlock(), true, round.height, 0, testAddrs[2], cfsm.ctx.clock)
 		assert.NoError(t, err)
 		state, err = cfsm.handleEndorseCommitEvt(eEvt)
 		assert.NoError(t, err)
 		assert.Equal(t, sRoundStart, state)
 		assert.Equal(t, eFinishEpoch, (<-cfsm.evtq).Type())
 	})
-	t.Run("timeout-blocking", func(t *testing.T) {
+	t.Run("gather-commits-of-earlier-round", func(t *testing.T) {
 		cfsm := newTestCFSM(
 			t,
 			testAddrs[0],
@@ -709,31 +870,40 @@ func TestHandleCommitEndorseEvt(t *testing.T) {
 			ctrl,
 			delegates,
 			func(chain *mock_blockchain.MockBlockchain) {
-				chain.EXPECT().CommitBlock(gomock.Any()).Return(nil).Times(0)
-				chain.EXPECT().
-					MintNewDummyBlock().
-					Return(blockchain.NewBlock(0, 0, hash.ZeroHash32B, clock.New(), nil, nil, nil)).Times(0)
+				chain.EXPECT().CommitBlock(gomock.Any()).Return(nil).Times(1)
 				chain.EXPECT().ChainID().AnyTimes().Return(config.Default.Chain.ID)
 			},
 			func(p2p *mock_network.MockOverlay) {
-				p2p.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Return(nil).Times(0)
+				p2p.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 			},
 			clock.New(),
 		)
-		cfsm.ctx.cfg.EnableDummyBlock = false
 		cfsm.ctx.epoch = epoch
 		cfsm.ctx.round = round
-
+		// The node has timed out of round 0, in which the other delegates agreed on the block
+		cfsm.ctx.round.number = 1
 		blk, err := cfsm.ctx.mintBlock()
 		assert.NoError(t, err)
-		cfsm.ctx.round.block = blk
-
-		state, err := cfsm.handleEndorseCommitTimeout(cfsm.newCEvt(eEndorseCommitTimeout))
-		assert.NoError(t, err)
-		assert.Equal(t, sRoundStart, state)
+		cfsm.ctx.round.addProposal(blk)
+
+		for i := 0; i < 3; i++ {
+			eEvt, err := newEndorseEvt(endorseCommit, blk.HashBlock(), true, round.height, 0, testAddrs[i], cfsm.ctx.clock)
+			assert.NoError(t, err)
+			state, err := cfsm.handleEndorseCommitEvt(eEvt)
+			assert.NoError(t, err)
+			if i < 2 {
+				assert.Equal(t, sAcceptCommitEndorse, state)
+			} else {
+				assert.Equal(t, sRoundStart, state)
+			}
+		}
 		assert.Equal(t, eFinishEpoch, (<-cfsm.evtq).Type())
+		assert.Equal(t, blk, cfsm.ctx.round.block)
+		assert.Equal(t, 0, len(cfsm.ctx.round.commitEndorses[hash.ZeroHash32B]))
+		assert.Equal(t, 3, len(cfsm.ctx.round.commitEndorses[blk.HashBlock()]))
 	})
-	t.Run("timeout-dummy-block", func(t *testing.T) {
+	t.Run("timeout-round-change", func(t *testing.T) {
+		var roundChange *iproto.EndorsePb
 		cfsm := newTestCFSM(
 			t,
 			testAddrs[0],
@@ -741,14 +911,13 @@ func TestHandleCommitEndorseEvt(t *testing.T) {
 			ctrl,
 			delegates,
 			func(chain *mock_blockchain.MockBlockchain) {
-				chain.EXPECT().CommitBlock(gomock.Any()).Return(nil).Times(1)
-				chain.EXPECT().
-					MintNewDummyBlock().
-					Return(blockchain.NewBlock(0, 0, hash.ZeroHash32B, clock.New(), nil, nil, nil)).Times(1)
+				chain.EXPECT().CommitBlock(gomock.Any()).Return(nil).Times(0)
 				chain.EXPECT().ChainID().AnyTimes().Return(config.Default.Chain.ID)
 			},
 			func(p2p *mock_network.MockOverlay) {
-				p2p.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				p2p.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Do(func(_ uint32, msg proto.Message) {
+					roundChange, _ = msg.(*iproto.EndorsePb)
+				}).Return(nil).Times(1)
 			},
 			clock.New(),
 		)
@@ -763,7 +932,205 @@ func TestHandleCommitEndorseEvt(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, sRoundStart, state)
 		assert.Equal(t, eFinishEpoch, (<-cfsm.evtq).Type())
+		// The timeout is gossiped as a round change to the next round
+		require.NotNil(t, roundChange)
+		assert.Equal(t, iproto.EndorsePb_ROUND_CHANGE, roundChange.Topic)
+		assert.Equal(t, uint32(1), roundChange.Round)
+		assert.Equal(t, 1, len(cfsm.ctx.round.roundChanges[1]))
+	})
+}
+
+func TestHandleRoundChangeEvt(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	delegates := make([]string, 4)
+	for i := 0; i < 4; i++ {
+		delegates[i] = testAddrs[i].RawAddress
+	}
+
+	epoch := epochCtx{
+		delegates:    delegates,
+		num:          uint64(1),
+		height:       uint64(1),
+		numSubEpochs: uint(1),
+	}
+
+	t.Run("move-to-later-round", func(t *testing.T) {
+		cfsm := newTestCFSM(t, testAddrs[0], testAddrs[2], ctrl, delegates, nil, nil, clock.New())
+		cfsm.ctx.epoch = epoch
+		_, err := cfsm.handleStartRoundEvt(cfsm.newCEvt(eStartRound))
+		require.NoError(t, err)
+		assert.Equal(t, eProposeBlockTimeout, (<-cfsm.evtq).Type())
+		assert.Equal(t, uint64(2), cfsm.ctx.round.height)
+		assert.Equal(t, uint32(0), cfsm.ctx.round.number)
+		assert.Equal(t, delegates[2], cfsm.ctx.round.proposer)
+
+		blk, err := cfsm.ctx.mintBlock()
+		require.NoError(t, err)
+		cfsm.ctx.round.lockedBlock = blk
+
+		// One round change is not enough to move on
+		eEvt, err := newEndorseEvt(endorseRoundChange, hash.ZeroHash32B, false, 2, 1, testAddrs[1], cfsm.ctx.clock)
+		require.NoError(t, err)
+		state, err := cfsm.handleRoundChangeEvt(sAcceptPropose, eEvt)
+		assert.NoError(t, err)
+		assert.Equal(t, sAcceptPropose, state)
+		assert.Equal(t, 1, len(cfsm.ctx.round.roundChanges[1]))
+
+		// The duplicate round change is ignored
+		state, err = cfsm.handleRoundChangeEvt(sAcceptPropose, eEvt)
+		assert.NoError(t, err)
+		assert.Equal(t, sAcceptPropose, state)
+		assert.Equal(t, 1, len(cfsm.ctx.round.roundChanges[1]))
+
+		// The round change to the current round is stale
+		eEvt, err = newEndorseEvt(endorseRoundChange, hash.ZeroHash32B, false, 2, 0, testAddrs[2], cfsm.ctx.clock)
+		require.NoError(t, err)
+		state, err = cfsm.handleRoundChangeEvt(sAcceptPropose, eEvt)
+		assert.NoError(t, err)
+		assert.Equal(t, sAcceptPropose, state)
+		assert.Equal(t, 0, len(cfsm.ctx.round.roundChanges[0]))
+
+		// f+1 round changes move the node to the later round, and it joins the round change too
+		eEvt, err = newEndorseEvt(endorseRoundChange, hash.ZeroHash32B, false, 2, 1, testAddrs[2], cfsm.ctx.clock)
+		require.NoError(t, err)
+		state, err = cfsm.handleRoundChangeEvt(sAcceptPropose, eEvt)
+		assert.NoError(t, err)
+		assert.Equal(t, sRoundStart, state)
+		assert.Equal(t, uint32(1), cfsm.ctx.round.skipTo)
+		assert.Equal(t, 3, len(cfsm.ctx.round.roundChanges[1]))
+		assert.Equal(t, eStartRound, (<-cfsm.evtq).Type())
+
+		// The next round is at the same height, with the next proposer and the lock carried over
+		state, err = cfsm.handleStartRoundEvt(cfsm.newCEvt(eStartRound))
+		assert.NoError(t, err)
+		assert.Equal(t, sAcceptPropose, state)
+		assert.Equal(t, eProposeBlockTimeout, (<-cfsm.evtq).Type())
+		assert.Equal(t, uint64(2), cfsm.ctx.round.height)
+		assert.Equal(t, uint32(1), cfsm.ctx.round.number)
+		assert.Equal(t, delegates[3], cfsm.ctx.round.proposer)
+		assert.Equal(t, blk, cfsm.ctx.round.lockedBlock)
+		assert.Equal(t, uint32(1), atomic.LoadUint32(&cfsm.ctx.latestRound))
+	})
+	t.Run("prove-lock", func(t *testing.T) {
+		cfsm := newTestCFSM(t, testAddrs[0], testAddrs[2], ctrl, delegates, nil, nil, clock.New())
+		cfsm.ctx.epoch = epoch
+		_, err := cfsm.handleStartRoundEvt(cfsm.newCEvt(eStartRound))
+		require.NoError(t, err)
+		assert.Equal(t, eProposeBlockTimeout, (<-cfsm.evtq).Type())
+		blk, err := cfsm.ctx.mintBlock()
+		require.NoError(t, err)
+
+		handle := func(en *endorse) {
+			state, err := cfsm.handleRoundChangeEvt(sAcceptPropose, newEndorseEvtWithEndorse(en, cfsm.ctx.clock))
+			assert.NoError(t, err)
+			assert.Equal(t, sAcceptPropose, state)
+		}
+		newProof := func(addr *iotxaddress.Address, round uint32) *endorse {
+			proof := &endorse{topic: endorseProposal, height: 2, round: round, blkHash: blk.HashBlock(), decision: true}
+			require.NoError(t, proof.Sign(addr))
+			return proof
+		}
+
+		// The round change claiming a lock without the proof doesn't unlock the node
+		en := &endorse{topic: endorseRoundChange, height: 2, round: 2, blkHash: blk.HashBlock(), lockedRound: 1}
+		require.NoError(t, en.Sign(testAddrs[1]))
+		handle(en)
+		assert.Equal(t, 0, len(cfsm.ctx.round.roundChanges[2]))
+		assert.False(t, cfsm.ctx.round.isLocked(blk.HashBlock()))
+
+		// The proposal endorses need to reach the quorum, and be the ones in the locked round
+		en.lockProof = []*endorse{newProof(testAddrs[1], 1), newProof(testAddrs[2], 1), newProof(testAddrs[2], 1)}
+		handle(en)
+		assert.Equal(t, 0, len(cfsm.ctx.round.roundChanges[2]))
+		en.lockProof = append(en.lockProof, newProof(testAddrs[3], 0))
+		handle(en)
+		assert.Equal(t, 0, len(cfsm.ctx.round.roundChanges[2]))
+
+		// The proof survives the protobuf conversion
+		en.lockProof[len(en.lockProof)-1] = newProof(testAddrs[3], 1)
+		restored := &endorse{}
+		require.NoError(t, restored.fromProtoMsg(en.toProtoMsg()))
+		require.Equal(t, len(en.lockProof), len(restored.lockProof))
+		handle(restored)
+		assert.Equal(t, 1, len(cfsm.ctx.round.roundChanges[2]))
+		assert.True(t, cfsm.ctx.round.isLocked(blk.HashBlock()))
+	})
+	t.Run("round-change-at-later-height", func(t *testing.T) {
+		cfsm := newTestCFSM(t, testAddrs[0], testAddrs[2], ctrl, delegates, nil, nil, clock.New())
+		cfsm.ctx.epoch = epoch
+		cfsm.ctx.cfg.UnmatchedEventTTL = time.Second
+		cfsm.ctx.round = roundCtx{height: 2}
+
+		eEvt, err := newEndorseEvt(endorseRoundChange, hash.ZeroHash32B, false, 3, 1, testAddrs[1], cfsm.ctx.clock)
+		require.NoError(t, err)
+		state, err := cfsm.handleRoundChangeEvt(sAcceptPropose, eEvt)
+		assert.NoError(t, err)
+		assert.Equal(t, sAcceptPropose, state)
+		assert.Equal(t, 0, len(cfsm.ctx.round.roundChanges))
+		assert.Equal(t, eRoundChange, (<-cfsm.evtq).Type())
+	})
+	t.Run("propose-locked-block", func(t *testing.T) {
+		cfsm := newTestCFSM(t, testAddrs[3], testAddrs[2], ctrl, delegates, nil, nil, clock.New())
+		cfsm.ctx.epoch = epoch
+		blk, err := cfsm.ctx.mintBlock()
+		require.NoError(t, err)
+		cfsm.ctx.round = roundCtx{
+			height:      2,
+			number:      1,
+			proposer:    delegates[3],
+			lockedBlock: blk,
+		}
+
+		state, err := cfsm.handleInitBlockEvt(cfsm.newCEvt(eInitBlock))
+		assert.NoError(t, err)
+		assert.Equal(t, sAcceptPropose, state)
+		evt, ok := (<-cfsm.evtq).(*proposeBlkEvt)
+		require.True(t, ok)
+		assert.Equal(t, blk, evt.block)
+		assert.Equal(t, uint32(1), evt.round)
+	})
+}
+
+func TestRoundCtxLock(t *testing.T) {
+	t.Parallel()
+
+	blk := blockchain.NewBlock(config.Default.Chain.ID, 2, hash.ZeroHash32B, clock.New(), nil, nil, nil)
+	otherBlk := blockchain.NewBlock(config.Default.Chain.ID, 2, blk.HashBlock(), clock.New(), nil, nil, nil)
+
+	round := roundCtx{number: 2}
+	assert.True(t, round.canEndorse(blk.HashBlock()))
+	assert.False(t, round.isLocked(blk.HashBlock()))
+
+	round.lockedBlock = blk
+	round.lockedRound = 1
+	assert.True(t, round.isLocked(blk.HashBlock()))
+	assert.True(t, round.canEndorse(blk.HashBlock()))
+	assert.False(t, round.canEndorse(otherBlk.HashBlock()))
+
+	// A lock on the other block in the same round doesn't unlock the node
+	round.addRoundChange(&endorse{
+		topic:       endorseRoundChange,
+		endorser:    testAddrs[1].RawAddress,
+		round:       2,
+		blkHash:     otherBlk.HashBlock(),
+		lockedRound: 1,
+	})
+	assert.True(t, round.isLocked(otherBlk.HashBlock()))
+	assert.False(t, round.canEndorse(otherBlk.HashBlock()))
+
+	// A lock on the other block in a later round does
+	round.addRoundChange(&endorse{
+		topic:       endorseRoundChange,
+		endorser:    testAddrs[2].RawAddress,
+		round:       3,
+		blkHash:     otherBlk.HashBlock(),
+		lockedRound: 2,
 	})
+	assert.True(t, round.canEndorse(otherBlk.HashBlock()))
 }
 
 func TestHandleFinishEpochEvt(t *testing.T) {
@@ -784,8 +1151,8 @@ func TestHandleFinishEpochEvt(t *testing.T) {
 		numSubEpochs: uint(1),
 	}
 	round := roundCtx{
-		proposalEndorses: make(map[hash.Hash32B]map[string]bool),
-		commitEndorses:   make(map[hash.Hash32B]map[string]bool),
+		proposalEndorses: make(map[hash.Hash32B]map[string]*endorse),
+		commitEndorses:   make(map[hash.Hash32B]map[string]*endorse),
 		proposer:         delegates[2],
 	}
 
@@ -876,9 +1243,8 @@ func newTestCFSM(
 		addr,
 		ctrl,
 		config.RollDPoS{
-			EventChanSize:    2,
-			NumDelegates:     uint(len(delegates)),
-			EnableDummyBlock: true,
+			EventChanSize: 2,
+			NumDelegates:  uint(len(delegates)),
 		},
 		func(blockchain *mock_blockchain.MockBlockchain) {
 			blockchain.EXPECT().ChainID().AnyTimes().Return(config.Default.Chain.ID)
@@ -930,90 +1296,151 @@ func newTestAddr() *iotxaddress.Address {
 	return addr
 }
 
-func TestUpdateSeed(t *testing.T) {
+func TestAttachCertificate(t *testing.T) {
 	require := require.New(t)
-	lastSeed, _ := hex.DecodeString("9de6306b08158c423330f7a27243a1a5cbe39bfd764f07818437882d21241567")
-	chain := blockchain.NewBlockchain(&config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
-	require.NoError(chain.Start(context.Background()))
-	ctx := rollDPoSCtx{cfg: config.Default.Consensus.RollDPoS, chain: chain, epoch: epochCtx{seed: lastSeed}}
-	fsm := cFSM{ctx: &ctx}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-	var err error
 	const numNodes = 21
-	addresses := make([]*iotxaddress.Address, numNodes)
-	skList := make([][]uint32, numNodes)
+	addrs := make([]*iotxaddress.Address, numNodes)
 	idList := make([][]uint8, numNodes)
-	coeffsList := make([][][]uint32, numNodes)
+	skList := make([][]uint32, numNodes)
 	sharesList := make([][][]uint32, numNodes)
-	shares := make([][]uint32, numNodes)
 	witnessesList := make([][][]byte, numNodes)
-	sharestatusmatrix := make([][numNodes]bool, numNodes)
-	qsList := make([][]byte, numNodes)
-	pkList := make([][]byte, numNodes)
-	askList := make([][]uint32, numNodes)
-
-	// Generate 21 identifiers for the delegates
 	for i := 0; i < numNodes; i++ {
-		addresses[i], _ = iotxaddress.NewAddress(iotxaddress.IsTestnet, iotxaddress.ChainID)
-		idList[i] = iotxaddress.CreateID(addresses[i].RawAddress)
+		addrs[i] = newTestAddr()
+		idList[i] = iotxaddress.CreateID(addrs[i].RawAddress)
 		skList[i] = crypto.DKG.SkGeneration()
 	}
-
-	// Initialize DKG and generate secret shares
 	for i := 0; i < numNodes; i++ {
-		coeffsList[i], sharesList[i], witnessesList[i], err = crypto.DKG.Init(skList[i], idList)
+		var err error
+		_, sharesList[i], witnessesList[i], err = crypto.DKG.Init(skList[i], idList)
 		require.NoError(err)
 	}
-
-	// Verify all the received secret shares
+	shares := make([][]uint32, numNodes)
+	sharestatusmatrix := make([][numNodes]bool, numNodes)
 	for i := 0; i < numNodes; i++ {
 		for j := 0; j < numNodes; j++ {
-			result, err := crypto.DKG.ShareVerify(idList[i], sharesList[j][i], witnessesList[j])
-			require.NoError(err)
-			require.True(result)
 			shares[j] = sharesList[j][i]
 		}
+		var err error
 		sharestatusmatrix[i], err = crypto.DKG.SharesCollect(idList[i], shares, witnessesList)
 		require.NoError(err)
-		for _, b := range sharestatusmatrix[i] {
-			require.True(b)
-		}
 	}
-
-	// Generate private and public key shares of a group key
+	dkgAddrs := make([]*iotxaddress.DKGAddress, numNodes)
 	for i := 0; i < numNodes; i++ {
 		for j := 0; j < numNodes; j++ {
 			shares[j] = sharesList[j][i]
 		}
-		qsList[i], pkList[i], askList[i], err = crypto.DKG.KeyPairGeneration(shares, sharestatusmatrix)
+		_, pk, ask, err := crypto.DKG.KeyPairGeneration(shares, sharestatusmatrix)
 		require.NoError(err)
+		dkgAddrs[i] = &iotxaddress.DKGAddress{PrivateKey: ask, PublicKey: pk, ID: idList[i]}
 	}
 
-	// Generate dkg signature for each block
-	require.NoError(err)
-	dummy := chain.MintNewDummyBlock()
-	err = chain.CommitBlock(dummy)
-	require.NoError(err)
-	for i := 1; i < numNodes; i++ {
-		blk, err := chain.MintNewDKGBlock(nil, nil, nil, addresses[i],
-			&iotxaddress.DKGAddress{PrivateKey: askList[i], PublicKey: pkList[i], ID: idList[i]},
-			lastSeed, "")
-		require.NoError(err)
-		err = verifyDKGSignature(blk, lastSeed)
-		require.NoError(err)
-		err = chain.CommitBlock(blk)
-		require.NoError(err)
-		require.Equal(pkList[i], blk.Header.DKGPubkey)
-		require.Equal(idList[i], blk.Header.DKGID)
-		require.True(len(blk.Header.DKGBlockSig) > 0)
+	// The delegates record their DKG public key shares in the blocks following the DKG phase of the epoch, except the
+	// last one
+	cfg := config.Default.Consensus.RollDPoS
+	cfg.EnableDKG = true
+	cfg.NumSubEpochs = 2
+	chainID := enc.MachineEndian.Uint32(iotxaddress.ChainID)
+	candidates := make([]*state.Candidate, numNodes)
+	delegates := make([]string, numNodes)
+	for i, addr := range addrs {
+		candidates[i] = &state.Candidate{Address: addr.RawAddress}
+		delegates[i] = addr.RawAddress
+	}
+	mockChain := mock_blockchain.NewMockBlockchain(ctrl)
+	tipHeight := uint64(2*numNodes - 1)
+	mockChain.EXPECT().TipHeight().Return(tipHeight).AnyTimes()
+	for i := 0; i < numNodes-1; i++ {
+		recorded := blockchain.NewBlock(chainID, uint64(numNodes+1+i), hash.ZeroHash32B, clock.New(), nil, nil, nil)
+		recorded.Header.DKGID = dkgAddrs[i].ID
+		recorded.Header.DKGPubkey = dkgAddrs[i].PublicKey
+		require.NoError(recorded.SignBlock(addrs[i]))
+		mockChain.EXPECT().GetBlockByHeight(recorded.Height()).Return(recorded, nil).AnyTimes()
 	}
-	height := chain.TipHeight()
-	require.Equal(int(height), 21)
 
-	newSeed, err := fsm.updateSeed()
+	blk := blockchain.NewBlock(chainID, tipHeight+1, hash.ZeroHash32B, clock.New(), nil, nil, nil)
+	blkHash := blk.HashBlock()
+	endorses := make(map[string]*endorse)
+	cfsm := cFSM{ctx: &rollDPoSCtx{
+		cfg:   cfg,
+		chain: mockChain,
+		epoch: epochCtx{num: 1, height: 1, numSubEpochs: 2, delegates: delegates},
+		round: roundCtx{commitEndorses: map[hash.Hash32B]map[string]*endorse{blkHash: endorses}},
+	}}
+	for i := 0; i < numNodes; i++ {
+		en := &endorse{topic: endorseCommit, height: blk.Height(), blkHash: blkHash, decision: true}
+		require.NoError(en.SignDKG(dkgAddrs[i]))
+		require.NoError(en.Sign(addrs[i]))
+		require.True(en.VerifySignature(en.endorserPubkey))
+		require.NoError(en.VerifyDKGSignature())
+		endorses[en.endorser] = en
+
+		// The block is committed without a certificate until a quorum of the delegates have signed the shares
+		cfsm.attachCertificate(blk)
+		if i+1 < blockchain.CertificateQuorum(numNodes) {
+			require.Nil(blk.Certificate)
+		} else {
+			require.NotNil(blk.Certificate)
+		}
+	}
+	shares, err := cfsm.ctx.pubkeyShares()
 	require.NoError(err)
-	require.True(len(newSeed) > 0)
-	require.NotEqual(fsm.ctx.epoch.seed, newSeed)
-	fmt.Println(fsm.ctx.epoch.seed)
-	fmt.Println(newSeed)
+	require.Equal(numNodes-1, len(shares))
+	require.NoError(blk.VerifyCertificate(numNodes, shares))
+
+	// The signature share of another delegate's DKG key is rejected
+	forged := &endorse{topic: endorseCommit, height: blk.Height(), blkHash: blkHash, decision: true}
+	require.NoError(forged.SignDKG(dkgAddrs[1]))
+	require.NoError(forged.Sign(addrs[0]))
+	require.Error(forged.VerifyDKGSignature())
+
+	// The shares signed with the keys of another DKG run, which are not the recorded ones, are not aggregated
+	otherDKGAddr := *dkgAddrs[0]
+	otherDKGAddr.PrivateKey = dkgAddrs[1].PrivateKey
+	otherDKGAddr.PublicKey = dkgAddrs[1].PublicKey
+	selfSigned := &endorse{topic: endorseCommit, height: blk.Height(), blkHash: blkHash, decision: true}
+	require.NoError(selfSigned.SignDKG(&otherDKGAddr))
+	require.NoError(selfSigned.Sign(addrs[0]))
+	require.NoError(selfSigned.VerifyDKGSignature())
+	endorses[selfSigned.endorser] = selfSigned
+	blk.Certificate = nil
+	cfsm.attachCertificate(blk)
+	require.NotNil(blk.Certificate)
+	for _, endorser := range blk.Certificate.Endorsers {
+		require.NotEqual(addrs[0].RawAddress, endorser)
+	}
+	require.NoError(blk.VerifyCertificate(numNodes, shares))
+
+	// Once the certificate is expected, the commit endorses without the signature shares don't count to the quorum
+	unsigned := make(map[string]*endorse)
+	for i := 0; i < numNodes; i++ {
+		en := &endorse{topic: endorseCommit, height: blk.Height(), blkHash: blkHash, decision: true}
+		require.NoError(en.Sign(addrs[i]))
+		unsigned[en.endorser] = en
+	}
+	yes, _ := cfsm.ctx.calcQuorum(unsigned)
+	require.True(yes)
+	yes, no := cfsm.ctx.calcCommitQuorum(unsigned)
+	require.False(yes)
+	require.False(no)
+	yes, _ = cfsm.ctx.calcCommitQuorum(endorses)
+	require.True(yes)
+
+	// The endorsers of the certificate need to have their shares recorded by the delegates of the epoch
+	mockChain.EXPECT().CandidatesByHeight(uint64(0)).Return(candidates, nil).Times(1)
+	validate := NewCertificateValidator(cfg, mockChain)
+	require.NoError(validate(blk))
+
+	// The block without a certificate is rejected once enough shares are recorded
+	uncertified := blockchain.NewBlock(chainID, tipHeight+1, hash.ZeroHash32B, clock.New(), nil, nil, nil)
+	mockChain.EXPECT().CandidatesByHeight(uint64(0)).Return(candidates, nil).Times(1)
+	require.Equal(blockchain.ErrInvalidCertificate, errors.Cause(validate(uncertified)))
+
+	for i := range candidates {
+		candidates[i] = &state.Candidate{Address: newTestAddr().RawAddress}
+	}
+	mockChain.EXPECT().CandidatesByHeight(uint64(0)).Return(candidates, nil).Times(1)
+	require.Equal(blockchain.ErrInvalidCertificate, errors.Cause(validate(blk)))
 }
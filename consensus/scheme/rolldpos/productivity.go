@@ -0,0 +1,374 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/enc"
+)
+
+const (
+	// productivityNS is the namespace of the delegates' productivity of each epoch
+	productivityNS = "productivity"
+	// productivityStatsLen is the length of a serialized delegate's productivity, besides the address
+	productivityStatsLen = 8 * 3
+)
+
+var delegateProductivityMtc = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "iotex_consensus_delegate_productivity",
+		Help: "Number of blocks that each delegate has produced, missed or endorsed in the current epoch",
+	},
+	[]string{"delegate", "type"},
+)
+
+func init() {
+	prometheus.MustRegister(delegateProductivityMtc)
+}
+
+// serializeProductivity returns the byte stream of the delegates' productivity, which is sorted by the addresses
+func serializeProductivity(stats map[string]scheme.DelegateProductivity) []byte {
+	delegates := make([]string, 0, len(stats))
+	for delegate := range stats {
+		delegates = append(delegates, delegate)
+	}
+	sort.Strings(delegates)
+	var stream []byte
+	for _, delegate := range delegates {
+		entry := make([]byte, 4+len(delegate)+productivityStatsLen)
+		enc.MachineEndian.PutUint32(entry, uint32(len(delegate)))
+		copy(entry[4:], delegate)
+		offset := 4 + len(delegate)
+		enc.MachineEndian.PutUint64(entry[offset:], stats[delegate].Produced)
+		enc.MachineEndian.PutUint64(entry[offset+8:], stats[delegate].Missed)
+		enc.MachineEndian.PutUint64(entry[offset+16:], stats[delegate].Endorsed)
+		stream = append(stream, entry...)
+	}
+	return stream
+}
+
+// deserializeProductivity parses the byte stream into the delegates' productivity
+func deserializeProductivity(stream []byte) (map[string]scheme.DelegateProductivity, error) {
+	stats := make(map[string]scheme.DelegateProductivity)
+	for len(stream) > 0 {
+		if len(stream) < 4 {
+			return nil, errors.Errorf("invalid length of productivity entry %d", len(stream))
+		}
+		addrLen := int(enc.MachineEndian.Uint32(stream))
+		if len(stream) < 4+addrLen+productivityStatsLen {
+			return nil, errors.Errorf("invalid length of productivity entry %d", len(stream))
+		}
+		offset := 4 + addrLen
+		stats[string(stream[4:offset])] = scheme.DelegateProductivity{
+			Produced: enc.MachineEndian.Uint64(stream[offset:]),
+			Missed:   enc.MachineEndian.Uint64(stream[offset+8:]),
+			Endorsed: enc.MachineEndian.Uint64(stream[offset+16:]),
+		}
+		stream = stream[offset+productivityStatsLen:]
+	}
+	return stats, nil
+}
+
+// productivityStore keeps the numbers of the blocks that each delegate has produced, missed and endorsed per epoch.
+// The stats of the epoch being recorded are kept in memory and written through to the KV store, and those of the
+// earlier epochs are loaded from the KV store on demand.
+type productivityStore struct {
+	kvStore  db.KVStore
+	mutex    sync.RWMutex
+	epochNum uint64
+	stats    map[string]scheme.DelegateProductivity
+}
+
+// newProductivityStore creates a productivity store on top of the KV store
+func newProductivityStore(kvStore db.KVStore) *productivityStore {
+	return &productivityStore{
+		kvStore: kvStore,
+		stats:   make(map[string]scheme.DelegateProductivity),
+	}
+}
+
+// Start starts the KV store
+func (s *productivityStore) Start(ctx context.Context) error {
+	return errors.Wrap(s.kvStore.Start(ctx), "error when starting the KV store")
+}
+
+// Stop stops the KV store
+func (s *productivityStore) Stop(ctx context.Context) error {
+	return s.kvStore.Stop(ctx)
+}
+
+// load reads the delegates' productivity of the epoch from the KV store, which is empty if it has not been recorded
+func (s *productivityStore) load(epochNum uint64) (map[string]scheme.DelegateProductivity, error) {
+	key := make([]byte, 8)
+	enc.MachineEndian.PutUint64(key, epochNum)
+	value, err := s.kvStore.Get(productivityNS, key)
+	if err != nil {
+		if cause := errors.Cause(err); cause == db.ErrNotExist || cause == bolt.ErrBucketNotFound {
+			return make(map[string]scheme.DelegateProductivity), nil
+		}
+		return nil, errors.Wrapf(err, "error when getting the productivity of epoch %d", epochNum)
+	}
+	stats, err := deserializeProductivity(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error when deserializing the productivity of epoch %d", epochNum)
+	}
+	return stats, nil
+}
+
+// get returns a copy of the delegates' productivity of the epoch
+func (s *productivityStore) get(epochNum uint64) (map[string]scheme.DelegateProductivity, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if epochNum != s.epochNum {
+		return s.load(epochNum)
+	}
+	stats := make(map[string]scheme.DelegateProductivity, len(s.stats))
+	for delegate, p := range s.stats {
+		stats[delegate] = p
+	}
+	return stats, nil
+}
+
+// add adds the deltas to the delegates' productivity of the epoch, and persists the result
+func (s *productivityStore) add(epochNum uint64, deltas map[string]scheme.DelegateProductivity) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats := s.stats
+	if epochNum != s.epochNum {
+		var err error
+		if stats, err = s.load(epochNum); err != nil {
+			return err
+		}
+	}
+	updated := make(map[string]scheme.DelegateProductivity, len(stats))
+	for delegate, p := range stats {
+		updated[delegate] = p
+	}
+	for delegate, delta := range deltas {
+		p := updated[delegate]
+		p.Produced += delta.Produced
+		p.Missed += delta.Missed
+		p.Endorsed += delta.Endorsed
+		updated[delegate] = p
+	}
+	key := make([]byte, 8)
+	enc.MachineEndian.PutUint64(key, epochNum)
+	if err := s.kvStore.Put(productivityNS, key, serializeProductivity(updated)); err != nil {
+		return errors.Wrapf(err, "error when putting the productivity of epoch %d", epochNum)
+	}
+	if epochNum != s.epochNum {
+		// Only the current epoch's productivity is exposed by the gauges
+		delegateProductivityMtc.Reset()
+	}
+	s.epochNum = epochNum
+	s.stats = updated
+	for delegate, p := range updated {
+		delegateProductivityMtc.WithLabelValues(delegate, "produced").Set(float64(p.Produced))
+		delegateProductivityMtc.WithLabelValues(delegate, "missed").Set(float64(p.Missed))
+		delegateProductivityMtc.WithLabelValues(delegate, "endorsed").Set(float64(p.Endorsed))
+	}
+	return nil
+}
+
+// missedProposals returns the number of turns that each delegate of the epoch has missed to propose before the block
+// is produced. With the time based rotation, they are the proposers of the slots in the epoch between the block and
+// the last one. Otherwise, they are the proposers of the earlier rounds at the height, which are derived from the
+// position of the block producer.
+func (ctx *rollDPoSCtx) missedProposals(
+	blk *blockchain.Block,
+	epochNum uint64,
+	delegates []string,
+) (map[string]uint64, error) {
+	missed := make(map[string]uint64)
+	numDelegates := uint64(len(delegates))
+	if numDelegates == 0 {
+		return missed, nil
+	}
+	if ctx.cfg.TimeBasedRotation {
+		parentSlot, err := ctx.blockSlot(blk.Height() - 1)
+		if err != nil {
+			return nil, err
+		}
+		// The slots of the earlier epoch belong to the other delegates
+		start := parentSlot + 1
+		if epochStart := ctx.epochStartSlot(epochNum); start < epochStart {
+			start = epochStart
+		}
+		for slot := start; slot < ctx.calcSlot(blk.Header.Timestamp()); slot++ {
+			missed[delegates[slot%numDelegates]]++
+		}
+		return missed, nil
+	}
+	pos := -1
+	for i, delegate := range delegates {
+		if delegate == blk.ProducerAddress() {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		return missed, nil
+	}
+	height := blk.Height()
+	numRounds := (uint64(pos) + numDelegates - height%numDelegates) % numDelegates
+	for round := uint64(0); round < numRounds; round++ {
+		missed[delegates[(height+round)%numDelegates]]++
+	}
+	return missed, nil
+}
+
+// recordProductivity records the productivity of the epoch's delegates when the block is committed, including the
+// producer, the proposers which have missed their turns before it, and the delegates which have endorsed it
+func (ctx *rollDPoSCtx) recordProductivity(
+	blk *blockchain.Block,
+	epochNum uint64,
+	delegates []string,
+	endorsers []string,
+) {
+	missed, err := ctx.missedProposals(blk, epochNum, delegates)
+	if err != nil {
+		logger.Error().Err(err).Uint64("block", blk.Height()).Msg("error when counting the missed proposals")
+		return
+	}
+	deltas := make(map[string]scheme.DelegateProductivity)
+	for delegate, num := range missed {
+		deltas[delegate] = scheme.DelegateProductivity{Missed: num}
+	}
+	producer := deltas[blk.ProducerAddress()]
+	producer.Produced++
+	deltas[blk.ProducerAddress()] = producer
+	for _, endorser := range endorsers {
+		p := deltas[endorser]
+		p.Endorsed++
+		deltas[endorser] = p
+	}
+	if err := ctx.productivity.add(epochNum, deltas); err != nil {
+		logger.Error().Err(err).Uint64("block", blk.Height()).Msg("error when recording the productivity")
+	}
+}
+
+// recordConsensusBlock records the productivity of the current epoch's delegates when the block is committed by the
+// consensus, in which the delegates with the yes commit endorses have endorsed the block
+func (ctx *rollDPoSCtx) recordConsensusBlock(blk *blockchain.Block, endorses map[string]*endorse) {
+	endorsers := make([]string, 0, len(endorses))
+	for endorser, en := range endorses {
+		if en.decision {
+			endorsers = append(endorsers, endorser)
+		}
+	}
+	ctx.recordProductivity(blk, ctx.epoch.num, ctx.epoch.delegates, endorsers)
+}
+
+// recordCommittedBlock records the productivity of the delegates of the block's epoch when the block is committed out
+// of the consensus, in which the endorsers of the block's commit certificate, if any, have endorsed the block. It
+// doesn't touch the consensus state, so that it's safe to call from other goroutines.
+func (ctx *rollDPoSCtx) recordCommittedBlock(blk *blockchain.Block) {
+	if blk.IsDummyBlock() {
+		return
+	}
+	epochNum := ctx.blockEpochNum(blk)
+	delegates, err := ctx.rollingDelegates(epochNum)
+	if err != nil {
+		logger.Error().Err(err).Uint64("block", blk.Height()).Msg("error when getting the delegates of the block")
+		return
+	}
+	var endorsers []string
+	if blk.Certificate != nil {
+		endorsers = blk.Certificate.Endorsers
+	}
+	ctx.recordProductivity(blk, epochNum, delegates, endorsers)
+}
+
+// epochHeights returns the range of the heights of the blocks committed in the epoch so far, and whether the epoch
+// has finished
+func (ctx *rollDPoSCtx) epochHeights(epochNum uint64) (uint64, uint64, bool, error) {
+	tipHeight := ctx.chain.TipHeight()
+	if ctx.cfg.TimeBasedRotation {
+		start, err := ctx.lastHeightBeforeSlot(ctx.epochStartSlot(epochNum))
+		if err != nil {
+			return 0, 0, false, err
+		}
+		nextEpochStart := ctx.epochStartSlot(epochNum + 1)
+		end, err := ctx.lastHeightBeforeSlot(nextEpochStart)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		tipSlot, err := ctx.blockSlot(tipHeight)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		return start + 1, end, tipSlot >= nextEpochStart, nil
+	}
+	numBlks := uint64(ctx.cfg.NumDelegates) * uint64(ctx.getNumSubEpochs())
+	start := numBlks*(epochNum-1) + 1
+	end := start + numBlks - 1
+	if tipHeight < end {
+		return start, tipHeight, false, nil
+	}
+	return start, end, true, nil
+}
+
+// calcProbations returns the delegates of the previous epoch which have produced less than the probation threshold
+// out of their turns. It is derived from the blocks on the chain, so that all the nodes agree on the delegates.
+func (ctx *rollDPoSCtx) calcProbations(epochNum uint64) (map[string]bool, error) {
+	if ctx.cfg.ProbationThreshold == 0 || epochNum <= 1 {
+		return nil, nil
+	}
+	if probations, ok := ctx.probations.Load(epochNum); ok {
+		return probations.(map[string]bool), nil
+	}
+	prevEpochNum := epochNum - 1
+	delegates, err := ctx.rollingDelegates(prevEpochNum)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error when getting the delegates of epoch %d", prevEpochNum)
+	}
+	start, end, finished, err := ctx.epochHeights(prevEpochNum)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error when getting the heights of epoch %d", prevEpochNum)
+	}
+	produced := make(map[string]uint64)
+	missed := make(map[string]uint64)
+	for height := start; height <= end; height++ {
+		blk, err := ctx.chain.GetBlockByHeight(height)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when getting the block at height %d", height)
+		}
+		if blk.IsDummyBlock() {
+			continue
+		}
+		blkMissed, err := ctx.missedProposals(blk, prevEpochNum, delegates)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when counting the missed proposals of block %d", height)
+		}
+		for delegate, num := range blkMissed {
+			missed[delegate] += num
+		}
+		produced[blk.ProducerAddress()]++
+	}
+	probations := make(map[string]bool)
+	for _, delegate := range delegates {
+		total := produced[delegate] + missed[delegate]
+		if total > 0 && produced[delegate]*100 < uint64(ctx.cfg.ProbationThreshold)*total {
+			probations[delegate] = true
+		}
+	}
+	if finished {
+		ctx.probations.Store(epochNum, probations)
+	}
+	return probations, nil
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestProductivityStore(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	path := "/tmp/test-productivity-store-" + strconv.Itoa(rand.Int())
+	testutil.CleanupPath(t, path)
+	defer testutil.CleanupPath(t, path)
+
+	s := newProductivityStore(db.NewBoltDB(path, &config.Default.DB))
+	require.NoError(s.Start(ctx))
+	stats, err := s.get(1)
+	require.NoError(err)
+	require.Empty(stats)
+
+	require.NoError(s.add(1, map[string]scheme.DelegateProductivity{"a": {Produced: 1}, "b": {Missed: 1}}))
+	require.NoError(s.add(1, map[string]scheme.DelegateProductivity{"a": {Produced: 1, Endorsed: 2}}))
+	require.NoError(s.add(2, map[string]scheme.DelegateProductivity{"b": {Produced: 1}}))
+	require.NoError(s.Stop(ctx))
+
+	// The productivity of all the epochs survives the restart
+	s = newProductivityStore(db.NewBoltDB(path, &config.Default.DB))
+	require.NoError(s.Start(ctx))
+	defer func() {
+		require.NoError(s.Stop(ctx))
+	}()
+	stats, err = s.get(1)
+	require.NoError(err)
+	require.Equal(map[string]scheme.DelegateProductivity{
+		"a": {Produced: 2, Endorsed: 2},
+		"b": {Missed: 1},
+	}, stats)
+	require.NoError(s.add(2, map[string]scheme.DelegateProductivity{"b": {Endorsed: 1}}))
+	stats, err = s.get(2)
+	require.NoError(err)
+	require.Equal(map[string]scheme.DelegateProductivity{"b": {Produced: 1, Endorsed: 1}}, stats)
+}
+
+func TestRecordProductivity(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	chain := blockchain.NewBlockchain(&cfg, blockchain.InMemDaoOption(), blockchain.InMemStateFactoryOption())
+	require.NoError(chain.Start(context.Background()))
+	defer func() {
+		require.NoError(chain.Stop(context.Background()))
+	}()
+	delegates := []string{
+		testaddress.Addrinfo["alfa"].RawAddress,
+		testaddress.Addrinfo["bravo"].RawAddress,
+		testaddress.Addrinfo["charlie"].RawAddress,
+		testaddress.Addrinfo["delta"].RawAddress,
+	}
+	ctx := &rollDPoSCtx{
+		cfg:          config.RollDPoS{NumDelegates: 4, NumSubEpochs: 1},
+		chain:        chain,
+		epoch:        epochCtx{num: 1, delegates: delegates},
+		productivity: newProductivityStore(db.NewMemKVStore()),
+	}
+
+	// The block at height 1 is produced by charlie in round 1 after bravo has missed the round 0
+	blk, err := chain.MintNewBlock(nil, nil, nil, testaddress.Addrinfo["charlie"], "")
+	require.NoError(err)
+	missed, err := ctx.missedProposals(blk, 1, delegates)
+	require.NoError(err)
+	require.Equal(map[string]uint64{delegates[1]: 1}, missed)
+	ctx.recordConsensusBlock(blk, map[string]*endorse{
+		delegates[0]: {decision: true},
+		delegates[2]: {decision: true},
+		delegates[3]: {decision: false},
+	})
+	stats, err := (&RollDPoS{ctx: ctx}).Productivity(1)
+	require.NoError(err)
+	require.Equal(map[string]scheme.DelegateProductivity{
+		delegates[0]: {Endorsed: 1},
+		delegates[1]: {Missed: 1},
+		delegates[2]: {Produced: 1, Endorsed: 1},
+	}, stats)
+
+	// The producer which isn't a delegate doesn't take any turn
+	blk, err = chain.MintNewBlock(nil, nil, nil, testaddress.Addrinfo["echo"], "")
+	require.NoError(err)
+	missed, err = ctx.missedProposals(blk, 1, delegates)
+	require.NoError(err)
+	require.Empty(missed)
+
+	// The block committed by the block sync is credited to the delegates of its epoch and the endorsers of its
+	// certificate
+	candidates := make([]*state.Candidate, 0, len(delegates))
+	for _, delegate := range delegates {
+		candidates = append(candidates, &state.Candidate{Address: delegate})
+	}
+	ctx.candidatesByHeightFunc = func(uint64) ([]*state.Candidate, error) { return candidates, nil }
+	epochDelegates, err := ctx.rollingDelegates(1)
+	require.NoError(err)
+	blk, err = chain.MintNewBlock(nil, nil, nil, testaddress.Addrinfo["delta"], "")
+	require.NoError(err)
+	blk.Certificate = &blockchain.CommitCertificate{Endorsers: []string{delegates[0]}}
+	missed, err = ctx.missedProposals(blk, 1, epochDelegates)
+	require.NoError(err)
+	expected, err := ctx.productivity.get(1)
+	require.NoError(err)
+	for delegate, num := range missed {
+		p := expected[delegate]
+		p.Missed += num
+		expected[delegate] = p
+	}
+	p := expected[delegates[3]]
+	p.Produced++
+	expected[delegates[3]] = p
+	p = expected[delegates[0]]
+	p.Endorsed++
+	expected[delegates[0]] = p
+	(&RollDPoS{ctx: ctx}).HandleCommittedBlock(blk)
+	stats, err = ctx.productivity.get(1)
+	require.NoError(err)
+	require.Equal(expected, stats)
+}
+
+func TestMissedSlots(t *testing.T) {
+	require := require.New(t)
+
+	clk := clock.NewMock()
+	clk.Add(genesisTime().Sub(clk.Now()))
+	cfg := config.Default
+	chain := blockchain.NewBlockchain(
+		&cfg,
+		blockchain.InMemDaoOption(),
+		blockchain.InMemStateFactoryOption(),
+		blockchain.ClockOption(clk),
+	)
+	require.NoError(chain.Start(context.Background()))
+	defer func() {
+		require.NoError(chain.Stop(context.Background()))
+	}()
+	ctx := &rollDPoSCtx{
+		cfg: config.RollDPoS{
+			ProposerInterval:  10 * time.Second,
+			NumDelegates:      4,
+			NumSubEpochs:      1,
+			TimeBasedRotation: true,
+		},
+		chain: chain,
+		clock: clk,
+	}
+	delegates := []string{"a", "b", "c", "d"}
+
+	// The block in slot 2 is produced after the proposer of slot 1 has missed it
+	clk.Add(ctx.slotStartTime(2).Sub(clk.Now()))
+	blk, err := chain.MintNewBlock(nil, nil, nil, testaddress.Addrinfo["producer"], "")
+	require.NoError(err)
+	require.NoError(chain.CommitBlock(blk))
+	missed, err := ctx.missedProposals(blk, 1, delegates)
+	require.NoError(err)
+	require.Equal(map[string]uint64{"b": 1}, missed)
+
+	// The slots 3 and 4 of the epoch 1 are not counted in the epoch 2, which starts from the slot 5
+	clk.Add(ctx.slotStartTime(6).Sub(clk.Now()))
+	blk, err = chain.MintNewBlock(nil, nil, nil, testaddress.Addrinfo["producer"], "")
+	require.NoError(err)
+	missed, err = ctx.missedProposals(blk, 2, delegates)
+	require.NoError(err)
+	require.Equal(map[string]uint64{"b": 1}, missed)
+}
+
+func TestProbation(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	chain := blockchain.NewBlockchain(&cfg, blockchain.InMemDaoOption(), blockchain.InMemStateFactoryOption())
+	require.NoError(chain.Start(context.Background()))
+	defer func() {
+		require.NoError(chain.Stop(context.Background()))
+	}()
+	addrs := make(map[string]*iotxaddress.Address)
+	var candidates []*state.Candidate
+	for _, name := range []string{"alfa", "bravo", "charlie"} {
+		addr := testaddress.Addrinfo[name]
+		addrs[addr.RawAddress] = addr
+		candidates = append(candidates, &state.Candidate{Address: addr.RawAddress, Votes: big.NewInt(1)})
+	}
+	ctx := &rollDPoSCtx{
+		cfg:   config.RollDPoS{NumDelegates: 2, NumSubEpochs: 2, ProbationThreshold: 50},
+		chain: chain,
+		candidatesByHeightFunc: func(uint64) ([]*state.Candidate, error) {
+			return candidates, nil
+		},
+	}
+	delegates, err := ctx.rollingDelegates(1)
+	require.NoError(err)
+	require.Len(delegates, 2)
+
+	// The first delegate produces all the blocks of the epoch 1, while the second one misses all its turns
+	for i := 0; i < 4; i++ {
+		blk, err := chain.MintNewBlock(nil, nil, nil, addrs[delegates[0]], "")
+		require.NoError(err)
+		require.NoError(chain.CommitBlock(blk))
+	}
+	probations, err := ctx.calcProbations(2)
+	require.NoError(err)
+	require.Equal(map[string]bool{delegates[1]: true}, probations)
+	nextDelegates, err := ctx.rollingDelegates(2)
+	require.NoError(err)
+	require.Len(nextDelegates, 2)
+	require.Contains(nextDelegates, delegates[0])
+	require.NotContains(nextDelegates, delegates[1])
+
+	// Nobody is on probation if it's disabled
+	ctx = &rollDPoSCtx{
+		cfg:                    config.RollDPoS{NumDelegates: 2, NumSubEpochs: 2},
+		chain:                  chain,
+		candidatesByHeightFunc: ctx.candidatesByHeightFunc,
+	}
+	probations, err = ctx.calcProbations(2)
+	require.NoError(err)
+	require.Empty(probations)
+}
@@ -8,6 +8,9 @@ package rolldpos
 
 import (
 	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/facebookgo/clock"
@@ -21,6 +24,7 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
 	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network"
@@ -37,10 +41,18 @@ var (
 		},
 		[]string{},
 	)
+	skippedSlotsMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_consensus_skipped_slots",
+			Help: "Number of consensus time slots without a block",
+		},
+		[]string{},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(timeSlotMtc)
+	prometheus.MustRegister(skippedSlotsMtc)
 }
 
 var (
@@ -48,6 +60,8 @@ var (
 	ErrNewRollDPoS = errors.New("error when constructing RollDPoS")
 	// ErrZeroDelegate indicates seeing 0 delegates in the network
 	ErrZeroDelegate = errors.New("zero delegates in the network")
+	// ErrBlockOutOfSlot indicates that the block's timestamp falls outside the time slots it could be proposed in
+	ErrBlockOutOfSlot = errors.New("block timestamp is out of slot")
 )
 
 type rollDPoSCtx struct {
@@ -62,6 +76,16 @@ type rollDPoSCtx struct {
 	// candidatesByHeightFunc is only used for testing purpose
 	candidatesByHeightFunc func(uint64) ([]*state.Candidate, error)
 	sync                   blocksync.BlockSync
+	// latestRound is the number of the current round, which is read by the metrics concurrently
+	latestRound uint32
+	// signed keeps the messages signed by the delegate across restarts
+	signed *signedMsgStore
+	// seeds caches the random seed of each epoch calculated from the DKG signatures
+	seeds sync.Map
+	// productivity keeps the delegates' productivity of each epoch, and probations caches the delegates on probation
+	// in each epoch
+	productivity *productivityStore
+	probations   sync.Map
 }
 
 var (
@@ -69,18 +93,27 @@ var (
 	ErrNotEnoughCandidates = errors.New("Candidate pool does not have enough candidates")
 )
 
-// rollingDelegates will only allows the delegates chosen for given epoch to enter the epoch
-func (ctx *rollDPoSCtx) rollingDelegates(epochNum uint64) ([]string, error) {
-	numDlgs := ctx.cfg.NumDelegates
-	height := uint64(numDlgs) * uint64(ctx.cfg.NumSubEpochs) * (epochNum - 1)
-	var candidates []*state.Candidate
+// epochCandidates returns the candidates from which the delegates of the given epoch are elected
+func (ctx *rollDPoSCtx) epochCandidates(epochNum uint64) ([]*state.Candidate, error) {
+	height := uint64(ctx.cfg.NumDelegates) * uint64(ctx.cfg.NumSubEpochs) * (epochNum - 1)
 	var err error
+	if ctx.cfg.TimeBasedRotation {
+		// The delegates are elected with the candidates as of the last block before the epoch starts
+		if height, err = ctx.lastHeightBeforeSlot(ctx.epochStartSlot(epochNum)); err != nil {
+			return nil, errors.Wrapf(err, "error when getting the last height before epoch %d", epochNum)
+		}
+	}
 	if ctx.candidatesByHeightFunc != nil {
 		// Test only
-		candidates, err = ctx.candidatesByHeightFunc(height)
-	} else {
-		candidates, err = ctx.chain.CandidatesByHeight(height)
+		return ctx.candidatesByHeightFunc(height)
 	}
+	return ctx.chain.CandidatesByHeight(height)
+}
+
+// rollingDelegates will only allows the delegates chosen for given epoch to enter the epoch
+func (ctx *rollDPoSCtx) rollingDelegates(epochNum uint64) ([]string, error) {
+	numDlgs := ctx.cfg.NumDelegates
+	candidates, err := ctx.epochCandidates(epochNum)
 	if err != nil {
 		return []string{}, errors.Wrap(err, "error when getting delegates from the candidate pool")
 	}
@@ -92,14 +125,106 @@ func (ctx *rollDPoSCtx) rollingDelegates(epochNum uint64) ([]string, error) {
 	for _, candidate := range candidates {
 		candidatesAddress = append(candidatesAddress, candidate.Address)
 	}
-	crypto.SortCandidates(candidatesAddress, epochNum)
+	seed, err := ctx.calcEpochSeed(epochNum)
+	if err != nil {
+		return []string{}, errors.Wrapf(err, "error when calculating the seed of epoch %d", epochNum)
+	}
+	crypto.SortCandidates(candidatesAddress, epochNum, seed)
+	probations, err := ctx.calcProbations(epochNum)
+	if err != nil {
+		return []string{}, errors.Wrapf(err, "error when calculating the probations of epoch %d", epochNum)
+	}
+	if len(probations) > 0 {
+		// The candidates on probation are only elected if there are not enough other candidates
+		sort.SliceStable(candidatesAddress, func(i, j int) bool {
+			return !probations[candidatesAddress[i]] && probations[candidatesAddress[j]]
+		})
+	}
 
 	return candidatesAddress[:numDlgs], nil
 }
 
+// NewCertificateValidator returns a function validating a block's commit certificate against the DKG public key
+// shares of the delegates recorded in the blocks of the epoch which the block belongs to. It allows a node that does
+// not participate in the consensus, e.g., a full node, to verify the finality of the synced blocks. A block without a
+// certificate is rejected once a quorum of the delegates have recorded their shares, whether or not the node itself
+// runs the DKG.
+func NewCertificateValidator(cfg config.RollDPoS, chain blockchain.Blockchain) func(*blockchain.Block) error {
+	ctx := &rollDPoSCtx{cfg: cfg, chain: chain}
+	return func(blk *blockchain.Block) error {
+		epochNum := ctx.blockEpochNum(blk)
+		delegates, err := ctx.rollingDelegates(epochNum)
+		if err != nil {
+			return errors.Wrapf(err, "error when getting the delegates of epoch %d", epochNum)
+		}
+		epochHeight, _, _, err := ctx.epochHeights(epochNum)
+		if err != nil {
+			return errors.Wrapf(err, "error when getting the heights of epoch %d", epochNum)
+		}
+		shares := make(map[string][]byte)
+		if err := ctx.recordPubkeyShares(shares, delegates, epochHeight+uint64(len(delegates)), blk.Height()-1); err != nil {
+			return err
+		}
+		if blk.Certificate == nil {
+			if certificateExpected(len(delegates), len(shares)) {
+				return errors.Wrapf(
+					blockchain.ErrInvalidCertificate,
+					"block %d of epoch %d does not have a commit certificate",
+					blk.Height(),
+					epochNum,
+				)
+			}
+			return nil
+		}
+		return blk.VerifyCertificate(len(delegates), shares)
+	}
+}
+
+// NewProducerValidator returns a function validating that a block is produced by one of the delegates of the epoch
+// which the block belongs to. It returns an error wrapping blockchain.ErrInvalidProducer if not, and any other error if
+// the delegates of the epoch can't be determined yet, e.g., when the candidates they are elected from are not known.
+func NewProducerValidator(cfg config.RollDPoS, chain blockchain.Blockchain) func(*blockchain.Block) error {
+	ctx := &rollDPoSCtx{cfg: cfg, chain: chain}
+	return func(blk *blockchain.Block) error {
+		epochNum := ctx.blockEpochNum(blk)
+		delegates, err := ctx.rollingDelegates(epochNum)
+		if err != nil {
+			return errors.Wrapf(err, "error when getting the delegates of epoch %d", epochNum)
+		}
+		producer := blk.ProducerAddress()
+		for _, delegate := range delegates {
+			if delegate == producer {
+				return nil
+			}
+		}
+		return errors.Wrapf(
+			blockchain.ErrInvalidProducer,
+			"producer %s of block %d is not a delegate of epoch %d",
+			producer,
+			blk.Height(),
+			epochNum,
+		)
+	}
+}
+
+// blockEpochNum returns the ordinal number of the epoch which the block belongs to
+func (ctx *rollDPoSCtx) blockEpochNum(blk *blockchain.Block) uint64 {
+	if ctx.cfg.TimeBasedRotation {
+		return ctx.calcEpochNumOfSlot(ctx.calcSlot(blk.Header.Timestamp()))
+	}
+	return (blk.Height()-1)/(uint64(ctx.cfg.NumDelegates)*uint64(ctx.getNumSubEpochs())) + 1
+}
+
 // calcEpochNum calculates the epoch ordinal number and the epoch start height offset, which is based on the height of
-// the next block to be produced
+// the next block to be produced, or the slot of the next round with the time based rotation
 func (ctx *rollDPoSCtx) calcEpochNumAndHeight() (uint64, uint64, error) {
+	if ctx.cfg.TimeBasedRotation {
+		slot, err := ctx.nextRoundSlot()
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "error when calculating the slot of the next round")
+		}
+		return ctx.calcEpochNumAndHeightOfSlot(slot)
+	}
 	height := ctx.chain.TipHeight()
 	numDlgs := ctx.cfg.NumDelegates
 	subEpochNum := ctx.getNumSubEpochs()
@@ -108,11 +233,15 @@ func (ctx *rollDPoSCtx) calcEpochNumAndHeight() (uint64, uint64, error) {
 	return epochNum, epochHeight, nil
 }
 
-// generateDKG generates a pseudo DKG bytes
-func (ctx *rollDPoSCtx) generateDKG() (hash.DKGHash, error) {
-	var dkg hash.DKGHash
-	// TODO: fill the logic to generate DKG
-	return dkg, nil
+// calcEpochNumAndHeightOfSlot calculates the ordinal number of the epoch that the slot belongs to, and the height of
+// the first block in the epoch
+func (ctx *rollDPoSCtx) calcEpochNumAndHeightOfSlot(slot uint64) (uint64, uint64, error) {
+	epochNum := ctx.calcEpochNumOfSlot(slot)
+	height, err := ctx.lastHeightBeforeSlot(ctx.epochStartSlot(epochNum))
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "error when getting the last height before epoch %d", epochNum)
+	}
+	return epochNum, height + 1, nil
 }
 
 // getNumSubEpochs returns max(configured number, 1)
@@ -125,46 +254,60 @@ func (ctx *rollDPoSCtx) getNumSubEpochs() uint {
 }
 
 // rotatedProposer will rotate among the delegates to choose the proposer. It is pseudo order based on the position
-// in the delegate list and the block height
-func (ctx *rollDPoSCtx) rotatedProposer() (string, uint64, error) {
+// in the delegate list, the block height and the round number, so that the next delegate takes over if the round fails
+func (ctx *rollDPoSCtx) rotatedProposer(round uint32) (string, uint64, error) {
 	height := ctx.chain.TipHeight()
 	// Next block height
 	height++
-	proposer, err := ctx.calcProposer(height, ctx.epoch.delegates)
+	proposer, err := ctx.calcProposer(height, round, ctx.epoch.delegates)
 	return proposer, height, err
 }
 
-// calcProposer calculates the proposer for the block at a given height
-func (ctx *rollDPoSCtx) calcProposer(height uint64, delegates []string) (string, error) {
+// calcProposer calculates the proposer for the block at a given height and round. With the time based rotation, it is
+// the proposer of the slot that the round takes.
+func (ctx *rollDPoSCtx) calcProposer(height uint64, round uint32, delegates []string) (string, error) {
 	numDelegates := len(delegates)
 	if numDelegates == 0 {
 		return "", ErrZeroDelegate
 	}
 	if !ctx.cfg.TimeBasedRotation {
-		return delegates[(height)%uint64(numDelegates)], nil
+		return delegates[(height+uint64(round))%uint64(numDelegates)], nil
 	}
-	duration, err := ctx.calcDurationSinceLastBlock()
+	slot, err := ctx.roundSlot(height, round)
 	if err != nil {
-		return "", errors.Wrap(err, "error when computing the duration since last block time")
-	}
-	timeSlotIndex := int64(duration/ctx.cfg.ProposerInterval) - 1
-	if timeSlotIndex < 0 {
-		timeSlotIndex = 0
+		return "", errors.Wrapf(err, "error when calculating the slot of round %d at height %d", round, height)
 	}
-	// TODO: should downgrade to debug level in the future
-	logger.Info().Int64("slot", timeSlotIndex).Msg("calculate time slot offset")
-	timeSlotMtc.WithLabelValues().Set(float64(timeSlotIndex))
-	return delegates[(height+uint64(timeSlotIndex))%uint64(numDelegates)], nil
+	return delegates[slot%uint64(numDelegates)], nil
 }
 
 // mintBlock picks the actions and creates an block to propose
 func (ctx *rollDPoSCtx) mintBlock() (*blockchain.Block, error) {
+	mintSecret, err := ctx.shouldMintSecretBlock()
+	if err != nil {
+		return nil, errors.Wrap(err, "error when checking whether to mint a secret block")
+	}
+	if mintSecret {
+		return ctx.mintSecretBlock()
+	}
 	transfers, votes, executions := ctx.actPool.PickActs()
 	logger.Debug().
 		Int("transfer", len(transfers)).
 		Int("votes", len(votes)).
 		Msg("pick actions from the action pool")
-	blk, err := ctx.chain.MintNewBlock(transfers, votes, executions, ctx.addr, "")
+	var blk *blockchain.Block
+	if len(ctx.epoch.dkgAddress.PrivateKey) > 0 {
+		blk, err = ctx.chain.MintNewDKGBlock(
+			transfers,
+			votes,
+			executions,
+			ctx.addr,
+			&ctx.epoch.dkgAddress,
+			ctx.epoch.seed,
+			"",
+		)
+	} else {
+		blk, err = ctx.chain.MintNewBlock(transfers, votes, executions, ctx.addr, "")
+	}
 	if err != nil {
 		logger.Error().Msg("error when minting a block")
 		return nil, err
@@ -189,11 +332,11 @@ func (ctx *rollDPoSCtx) calcDurationSinceLastBlock() (time.Duration, error) {
 }
 
 // calcQuorum calculates if more than 2/3 vote yes or no including self's vote
-func (ctx *rollDPoSCtx) calcQuorum(decisions map[string]bool) (bool, bool) {
+func (ctx *rollDPoSCtx) calcQuorum(endorses map[string]*endorse) (bool, bool) {
 	yes := 0
 	no := 0
-	for _, decision := range decisions {
-		if decision {
+	for _, endorse := range endorses {
+		if endorse.decision {
 			yes++
 		} else {
 			no++
@@ -203,8 +346,32 @@ func (ctx *rollDPoSCtx) calcQuorum(decisions map[string]bool) (bool, bool) {
 	return yes >= numDelegates*2/3+1, no >= numDelegates*1/3
 }
 
+// calcRoundChange calculates if more than 1/3 of the delegates have timed out and asked to move to a round, so that
+// at least one honest delegate is in the round
+func (ctx *rollDPoSCtx) calcRoundChange(roundChanges map[string]*endorse) bool {
+	return len(roundChanges) >= len(ctx.epoch.delegates)/3+1
+}
+
+// roundTTL grows the timeout of each step linearly with the round number, so that the delegates which have moved to
+// different rounds will eventually overlap in the same round. With the time based rotation, the delegates are already
+// synchronized by the time slots.
+func (ctx *rollDPoSCtx) roundTTL(ttl time.Duration) time.Duration {
+	if ctx.cfg.TimeBasedRotation {
+		return ttl
+	}
+	return ttl * time.Duration(ctx.round.number+1)
+}
+
 // isEpochFinished checks the epoch is finished or not
 func (ctx *rollDPoSCtx) isEpochFinished() (bool, error) {
+	if ctx.cfg.TimeBasedRotation {
+		// The epoch is finished once its last slot has passed, no matter how many blocks are committed in it
+		slot, err := ctx.nextRoundSlot()
+		if err != nil {
+			return false, errors.Wrap(err, "error when calculating the slot of the next round")
+		}
+		return ctx.calcEpochNumOfSlot(slot) > ctx.epoch.num, nil
+	}
 	height := ctx.chain.TipHeight()
 	// if the height of the last committed block is already the last one should be minted from this epochStart, go back
 	// to epochStart start
@@ -222,20 +389,103 @@ type epochCtx struct {
 	height uint64
 	// numSubEpochs defines number of sub-epochs/rotations will happen in an epochStart
 	numSubEpochs uint
-	dkg          hash.DKGHash
 	delegates    []string
-	dkgAddress   iotxaddress.DKGAddress
-	seed         []byte
+	// secrets are the delegate's DKG secret shares for all the delegates, and witness is the commitment of the secret
+	secrets [][]uint32
+	witness [][]byte
+	// dkgFinished is set once the DKG of the epoch is over, no matter whether dkgAddress is generated successfully
+	dkgFinished bool
+	dkgAddress  iotxaddress.DKGAddress
+	// seed is the random seed of the epoch, which is signed with the DKG key pair in each block after the DKG
+	seed []byte
+	// pubkeyShares are the DKG public key shares of the delegates recorded in the blocks up to sharesHeight
+	pubkeyShares map[string][]byte
+	sharesHeight uint64
 }
 
 // roundCtx keeps the context data for the current round and block.
 type roundCtx struct {
-	height           uint64
+	height uint64
+	// number is the ordinal number of the round at the height, starting from 0
+	number uint32
+	// slot is the time slot that the round takes, which is only set with the time based rotation
+	slot             uint64
 	timestamp        time.Time
 	block            *blockchain.Block
-	proposalEndorses map[hash.Hash32B]map[string]bool
-	commitEndorses   map[hash.Hash32B]map[string]bool
+	proposalEndorses map[hash.Hash32B]map[string]*endorse
+	commitEndorses   map[hash.Hash32B]map[string]*endorse
 	proposer         string
+	// lockedBlock is the block that the node has endorsed to commit in lockedRound. It is carried over to the following
+	// rounds at the same height, and the node will only endorse this block unless it's unlocked by a later lock.
+	// lockProof is the proposal endorses of the block in lockedRound, which proves the lock to the other delegates.
+	lockedBlock *blockchain.Block
+	lockedRound uint32
+	lockProof   []*endorse
+	// roundChanges keeps the signed timeouts at the height by the round that they ask to move to, whose locks have
+	// been proved
+	roundChanges map[uint32]map[string]*endorse
+	// skipTo is the round to move to directly, when enough delegates have timed out in a later round
+	skipTo uint32
+	// proposals keeps the valid blocks proposed at the height, including those the node hasn't endorsed, and
+	// earlierCommits keeps the commit endorses of the earlier rounds at the height, so that the node could still commit
+	// the block which the other delegates have agreed on
+	proposals      map[hash.Hash32B]*blockchain.Block
+	earlierCommits map[uint32]map[hash.Hash32B]map[string]*endorse
+}
+
+// isLocked checks if the block is locked by the node itself or by any delegate which has timed out
+func (round *roundCtx) isLocked(blkHash hash.Hash32B) bool {
+	if round.lockedBlock != nil && round.lockedBlock.HashBlock() == blkHash {
+		return true
+	}
+	return round.hasLaterLock(blkHash, 0)
+}
+
+// hasLaterLock checks if any delegate which has timed out locked on the block no earlier than the given round, which
+// is proved by the lock proof of its round change
+func (round *roundCtx) hasLaterLock(blkHash hash.Hash32B, lockedRound uint32) bool {
+	if blkHash == hash.ZeroHash32B {
+		return false
+	}
+	for _, roundChanges := range round.roundChanges {
+		for _, rc := range roundChanges {
+			if rc.blkHash == blkHash && rc.lockedRound >= lockedRound {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// addRoundChange keeps the round change, and returns all the round changes to the same round
+func (round *roundCtx) addRoundChange(en *endorse) map[string]*endorse {
+	if round.roundChanges == nil {
+		round.roundChanges = make(map[uint32]map[string]*endorse)
+	}
+	roundChanges := round.roundChanges[en.round]
+	if roundChanges == nil {
+		roundChanges = make(map[string]*endorse)
+		round.roundChanges[en.round] = roundChanges
+	}
+	roundChanges[en.endorser] = en
+	return roundChanges
+}
+
+// canEndorse checks if the node could endorse the block. A locked node only endorses its locked block, unless another
+// delegate has locked on the block in a later round.
+func (round *roundCtx) canEndorse(blkHash hash.Hash32B) bool {
+	if round.lockedBlock == nil || round.lockedBlock.HashBlock() == blkHash {
+		return true
+	}
+	return round.hasLaterLock(blkHash, round.lockedRound+1)
+}
+
+// addProposal keeps the valid block proposed at the height
+func (round *roundCtx) addProposal(blk *blockchain.Block) {
+	if round.proposals == nil {
+		round.proposals = make(map[hash.Hash32B]*blockchain.Block)
+	}
+	round.proposals[blk.HashBlock()] = blk
 }
 
 // RollDPoS is Roll-DPoS consensus main entrance
@@ -285,7 +535,19 @@ func (r *RollDPoS) SetDoneStream(simMsgReady chan bool) {}
 func (r *RollDPoS) Metrics() (scheme.ConsensusMetrics, error) {
 	var metrics scheme.ConsensusMetrics
 	// Compute the epoch ordinal number
-	epochNum, _, err := r.ctx.calcEpochNumAndHeight()
+	var (
+		epochNum uint64
+		err      error
+	)
+	if r.ctx.cfg.TimeBasedRotation {
+		// The round context is owned by the FSM, so the slot is derived from the clock and the chain only
+		var slot uint64
+		if slot, err = r.ctx.calcNextSlot(); err == nil {
+			epochNum, _, err = r.ctx.calcEpochNumAndHeightOfSlot(slot)
+		}
+	} else {
+		epochNum, _, err = r.ctx.calcEpochNumAndHeight()
+	}
 	if err != nil {
 		return metrics, errors.Wrap(err, "error when calculating the epoch ordinal number")
 	}
@@ -294,13 +556,25 @@ func (r *RollDPoS) Metrics() (scheme.ConsensusMetrics, error) {
 	if err != nil {
 		return metrics, errors.Wrap(err, "error when getting the rolling delegates")
 	}
-	// Compute the height
+	// Compute the height and the round of the next block
 	height := r.ctx.chain.TipHeight()
+	round := atomic.LoadUint32(&r.ctx.latestRound)
 	// Compute block producer
-	producer, err := r.ctx.calcProposer(height+1, delegates)
+	producer, err := r.ctx.calcProposer(height+1, round, delegates)
 	if err != nil {
 		return metrics, errors.Wrap(err, "error when calculating the block producer")
 	}
+	// Compute the time slots
+	var latestSlot, skippedSlots uint64
+	if r.ctx.cfg.TimeBasedRotation {
+		latestSlot = r.ctx.calcSlot(r.ctx.clock.Now())
+		tipSlot, err := r.ctx.blockSlot(height)
+		if err != nil {
+			return metrics, errors.Wrap(err, "error when calculating the slot of the last block")
+		}
+		// Each slot up to the last block's has either a block or nothing
+		skippedSlots = tipSlot - height
+	}
 	// Get all candidates
 	candidates, err := r.ctx.chain.CandidatesByHeight(height)
 	if err != nil {
@@ -311,17 +585,36 @@ func (r *RollDPoS) Metrics() (scheme.ConsensusMetrics, error) {
 		candidateAddresses[i] = c.Address
 	}
 
-	crypto.SortCandidates(candidateAddresses, epochNum)
+	seed, err := r.ctx.calcEpochSeed(epochNum)
+	if err != nil {
+		return metrics, errors.Wrap(err, "error when calculating the epoch seed")
+	}
+	crypto.SortCandidates(candidateAddresses, epochNum, seed)
 
 	return scheme.ConsensusMetrics{
 		LatestEpoch:         epochNum,
 		LatestHeight:        height,
+		LatestRound:         round,
 		LatestDelegates:     delegates,
 		LatestBlockProducer: producer,
+		LatestSlot:          latestSlot,
+		SkippedSlots:        skippedSlots,
 		Candidates:          candidateAddresses,
 	}, nil
 }
 
+// Productivity returns the numbers of the blocks that each delegate has produced, missed and endorsed in the epoch,
+// which are observed by the node in the consensus or the block sync
+func (r *RollDPoS) Productivity(epochNum uint64) (map[string]scheme.DelegateProductivity, error) {
+	return r.ctx.productivity.get(epochNum)
+}
+
+// HandleCommittedBlock records the delegates' productivity of a block committed out of the consensus, e.g., by the
+// block sync
+func (r *RollDPoS) HandleCommittedBlock(blk *blockchain.Block) {
+	r.ctx.recordCommittedBlock(blk)
+}
+
 // NumPendingEvts returns the number of pending events
 func (r *RollDPoS) NumPendingEvts() int {
 	return len(r.cfsm.evtq)
@@ -342,6 +635,8 @@ type Builder struct {
 	p2p                    network.Overlay
 	clock                  clock.Clock
 	candidatesByHeightFunc func(uint64) ([]*state.Candidate, error)
+	signedMsgKVStore       db.KVStore
+	productivityKVStore    db.KVStore
 }
 
 // NewRollDPoSBuilder instantiates a Builder instance
@@ -393,6 +688,18 @@ func (b *Builder) SetCandidatesByHeightFunc(
 	return b
 }
 
+// SetSignedMsgStore sets the KV store to persist the messages signed by the delegate. It's in memory by default.
+func (b *Builder) SetSignedMsgStore(kvStore db.KVStore) *Builder {
+	b.signedMsgKVStore = kvStore
+	return b
+}
+
+// SetProductivityStore sets the KV store to persist the delegates' productivity. It's in memory by default.
+func (b *Builder) SetProductivityStore(kvStore db.KVStore) *Builder {
+	b.productivityKVStore = kvStore
+	return b
+}
+
 // Build builds a RollDPoS consensus module
 func (b *Builder) Build() (*RollDPoS, error) {
 	if b.chain == nil {
@@ -407,14 +714,22 @@ func (b *Builder) Build() (*RollDPoS, error) {
 	if b.clock == nil {
 		b.clock = clock.New()
 	}
+	if b.signedMsgKVStore == nil {
+		b.signedMsgKVStore = db.NewMemKVStore()
+	}
+	if b.productivityKVStore == nil {
+		b.productivityKVStore = db.NewMemKVStore()
+	}
 	ctx := rollDPoSCtx{
-		cfg:     b.cfg,
-		addr:    b.addr,
-		chain:   b.chain,
-		actPool: b.actPool,
-		p2p:     b.p2p,
-		clock:   b.clock,
+		cfg:                    b.cfg,
+		addr:                   b.addr,
+		chain:                  b.chain,
+		actPool:                b.actPool,
+		p2p:                    b.p2p,
+		clock:                  b.clock,
 		candidatesByHeightFunc: b.candidatesByHeightFunc,
+		signed:                 newSignedMsgStore(b.signedMsgKVStore),
+		productivity:           newProductivityStore(b.productivityKVStore),
 	}
 	cfsm, err := newConsensusFSM(&ctx)
 	if err != nil {
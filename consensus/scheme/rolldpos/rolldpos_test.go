@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/action"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/network/node"
 	"github.com/iotexproject/iotex-core/pkg/hash"
@@ -68,7 +70,7 @@ func TestRollDPoSCtx(t *testing.T) {
 			NumDelegates: 4,
 		},
 		func(blockchain *mock_blockchain.MockBlockchain) {
-			blockchain.EXPECT().TipHeight().Return(uint64(8)).Times(3)
+			blockchain.EXPECT().TipHeight().Return(uint64(8)).Times(4)
 			blockchain.EXPECT().GetBlockByHeight(uint64(8)).Return(blk, nil).Times(1)
 			blockchain.EXPECT().CandidatesByHeight(gomock.Any()).Return([]*state.Candidate{
 				{Address: candidates[0]},
@@ -89,7 +91,7 @@ func TestRollDPoSCtx(t *testing.T) {
 
 	delegates, err := ctx.rollingDelegates(epoch)
 	require.NoError(t, err)
-	crypto.SortCandidates(candidates, epoch)
+	crypto.SortCandidates(candidates, epoch, nil)
 	assert.Equal(t, candidates, delegates)
 
 	ctx.epoch = epochCtx{
@@ -98,37 +100,43 @@ func TestRollDPoSCtx(t *testing.T) {
 		numSubEpochs: 1,
 		delegates:    delegates,
 	}
-	proposer, height, err := ctx.rotatedProposer()
+	proposer, height, err := ctx.rotatedProposer(0)
 	require.NoError(t, err)
 	assert.Equal(t, candidates[1], proposer)
 	assert.Equal(t, uint64(9), height)
 
+	// The next delegate takes over in the next round
+	proposer, height, err = ctx.rotatedProposer(1)
+	require.NoError(t, err)
+	assert.Equal(t, candidates[2], proposer)
+	assert.Equal(t, uint64(9), height)
+
 	clock.Add(time.Second)
 	duration, err := ctx.calcDurationSinceLastBlock()
 	require.NoError(t, err)
 	assert.Equal(t, time.Second, duration)
 
-	yes, no := ctx.calcQuorum(map[string]bool{
-		candidates[0]: true,
-		candidates[1]: true,
-		candidates[2]: true,
+	yes, no := ctx.calcQuorum(map[string]*endorse{
+		candidates[0]: {decision: true},
+		candidates[1]: {decision: true},
+		candidates[2]: {decision: true},
 	})
 	assert.True(t, yes)
 	assert.False(t, no)
 
-	yes, no = ctx.calcQuorum(map[string]bool{
-		candidates[0]: false,
-		candidates[1]: false,
-		candidates[2]: false,
+	yes, no = ctx.calcQuorum(map[string]*endorse{
+		candidates[0]: {decision: false},
+		candidates[1]: {decision: false},
+		candidates[2]: {decision: false},
 	})
 	assert.False(t, yes)
 	assert.True(t, no)
 
-	yes, no = ctx.calcQuorum(map[string]bool{
-		candidates[0]: true,
-		candidates[1]: true,
-		candidates[2]: false,
-		candidates[3]: false,
+	yes, no = ctx.calcQuorum(map[string]*endorse{
+		candidates[0]: {decision: true},
+		candidates[1]: {decision: true},
+		candidates[2]: {decision: false},
+		candidates[3]: {decision: false},
 	})
 	assert.False(t, yes)
 	assert.True(t, no)
@@ -183,6 +191,33 @@ func TestIsEpochFinished(t *testing.T) {
 	})
 }
 
+func TestProducerValidator(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blk := blockchain.NewBlock(1, 1, hash.ZeroHash32B, clock.NewMock(), nil, nil, nil)
+	require.NoError(blk.SignBlock(testAddrs[0]))
+	other := blockchain.NewBlock(1, 2, hash.ZeroHash32B, clock.NewMock(), nil, nil, nil)
+	require.NoError(other.SignBlock(testAddrs[1]))
+	candidates := []*state.Candidate{{Address: blk.ProducerAddress()}, {Address: testAddrs[2].RawAddress}}
+	mockChain := mock_blockchain.NewMockBlockchain(ctrl)
+	validate := NewProducerValidator(config.RollDPoS{NumDelegates: 2, NumSubEpochs: 1}, mockChain)
+
+	// The block produced by a delegate of its epoch is accepted, and the one produced by others is rejected
+	mockChain.EXPECT().CandidatesByHeight(uint64(0)).Return(candidates, nil).Times(2)
+	require.NoError(validate(blk))
+	require.Equal(blockchain.ErrInvalidProducer, errors.Cause(validate(other)))
+
+	// The block whose delegates are not known yet is not taken as produced by others
+	mockChain.EXPECT().CandidatesByHeight(uint64(0)).Return(nil, errors.New("candidates not found")).Times(1)
+	err := validate(blk)
+	require.Error(err)
+	require.NotEqual(blockchain.ErrInvalidProducer, errors.Cause(err))
+}
+
 func TestNewRollDPoS(t *testing.T) {
 	t.Parallel()
 
@@ -238,7 +273,7 @@ func TestRollDPoS_Metrics(t *testing.T) {
 	}
 
 	blockchain := mock_blockchain.NewMockBlockchain(ctrl)
-	blockchain.EXPECT().TipHeight().Return(uint64(8)).Times(2)
+	blockchain.EXPECT().TipHeight().Return(uint64(8)).Times(4)
 	blockchain.EXPECT().CandidatesByHeight(gomock.Any()).Return([]*state.Candidate{
 		{Address: candidates[0]},
 		{Address: candidates[1]},
@@ -260,10 +295,18 @@ func TestRollDPoS_Metrics(t *testing.T) {
 	m, err := r.Metrics()
 	require.NoError(t, err)
 	assert.Equal(t, uint64(3), m.LatestEpoch)
-	crypto.SortCandidates(candidates, m.LatestEpoch)
+	crypto.SortCandidates(candidates, m.LatestEpoch, nil)
 	assert.Equal(t, candidates[:4], m.LatestDelegates)
+	assert.Equal(t, uint32(0), m.LatestRound)
 	assert.Equal(t, candidates[1], m.LatestBlockProducer)
 	assert.Equal(t, candidates, m.Candidates)
+
+	// The next delegate proposes in the next round
+	atomic.StoreUint32(&r.ctx.latestRound, 1)
+	m, err = r.Metrics()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), m.LatestRound)
+	assert.Equal(t, candidates[2], m.LatestBlockProducer)
 }
 
 func TestRollDPoS_convertToConsensusEvt(t *testing.T) {
@@ -362,6 +405,9 @@ func makeTestRollDPoSCtx(
 		actPool: actPool,
 		p2p:     p2p,
 		clock:   clock,
+		signed:  newSignedMsgStore(db.NewMemKVStore()),
+
+		productivity: newProductivityStore(db.NewMemKVStore()),
 	}
 }
 
@@ -406,6 +452,8 @@ func (o *directOverlay) GetPeers() []net.Addr {
 	return addrs
 }
 
+func (o *directOverlay) BannedPeers() map[string]time.Time { return nil }
+
 func TestRollDPoSConsensus(t *testing.T) {
 	t.Parallel()
 
@@ -431,7 +479,7 @@ func TestRollDPoSConsensus(t *testing.T) {
 			chainRawAddrs = append(chainRawAddrs, addr.RawAddress)
 			addressMap[addr.RawAddress] = addr
 		}
-		crypto.SortCandidates(chainRawAddrs, 1)
+		crypto.SortCandidates(chainRawAddrs, 1, nil)
 		for i, rawAddress := range chainRawAddrs {
 			chainAddrs[i] = addressMap[rawAddress]
 		}
@@ -539,32 +587,9 @@ func TestRollDPoSConsensus(t *testing.T) {
 		}))
 	})
 
-	checkChains := func(chains []blockchain.Blockchain, height uint64) {
-		assert.NoError(t, testutil.WaitUntil(100*time.Millisecond, 10*time.Second, func() (bool, error) {
-			for _, chain := range chains {
-				blk, err := chain.GetBlockByHeight(height)
-				if blk == nil || err != nil {
-					return false, nil
-				}
-				if !blk.IsDummyBlock() {
-					return true, errors.New("not a dummy block")
-				}
-			}
-			return true, nil
-		}))
-	}
-
-	t.Run("proposer-network-partition-dummy-block", func(t *testing.T) {
+	t.Run("byzantine-endorses", func(t *testing.T) {
 		ctx := context.Background()
 		cs, p2ps, chains := newConsensusComponents(4)
-		// 1 should be the block 1's proposer
-		for i, p2p := range p2ps {
-			if i == 1 {
-				p2p.peers = make(map[net.Addr]*RollDPoS)
-			} else {
-				delete(p2p.peers, p2ps[1].addr)
-			}
-		}
 
 		for i := 0; i < 4; i++ {
 			require.NoError(t, chains[i].Start(ctx))
@@ -580,36 +605,35 @@ func TestRollDPoSConsensus(t *testing.T) {
 			}
 		}()
 
-		checkChains(chains, 1)
-	})
-
-	t.Run("non-proposer-network-partition-dummy-block", func(t *testing.T) {
-		ctx := context.Background()
-		cs, p2ps, chains := newConsensusComponents(4)
-		// 1 should be the block 1's proposer
-		for i, p2p := range p2ps {
-			if i == 0 {
-				p2p.peers = make(map[net.Addr]*RollDPoS)
-			} else {
-				delete(p2p.peers, p2ps[0].addr)
-			}
-		}
-
+		// A non-delegate floods commit endorses of a fake block, and replays them on behalf of a delegate
+		byzantine := newTestAddr()
+		var fakeHash hash.Hash32B
+		fakeHash[0] = 1
 		for i := 0; i < 4; i++ {
-			require.NoError(t, chains[i].Start(ctx))
-			require.NoError(t, p2ps[i].Start(ctx))
-			require.NoError(t, cs[i].Start(ctx))
+			en := &endorse{height: 1, topic: endorseCommit, blkHash: fakeHash, decision: true}
+			require.NoError(t, en.Sign(byzantine))
+			require.NoError(t, p2ps[0].Broadcast(config.Default.Chain.ID, en.toProtoMsg()))
+			en.endorser = cs[i].ctx.addr.RawAddress
+			require.NoError(t, p2ps[0].Broadcast(config.Default.Chain.ID, en.toProtoMsg()))
 		}
 
-		defer func() {
-			for i := 0; i < 4; i++ {
-				require.NoError(t, cs[i].Stop(ctx))
-				require.NoError(t, p2ps[i].Stop(ctx))
-				require.NoError(t, chains[i].Stop(ctx))
+		assert.NoError(t, testutil.WaitUntil(100*time.Millisecond, 10*time.Second, func() (bool, error) {
+			var blkHash hash.Hash32B
+			for i, chain := range chains {
+				blk, err := chain.GetBlockByHeight(1)
+				if blk == nil || err != nil {
+					return false, nil
+				}
+				if blk.HashBlock() == fakeHash {
+					return false, errors.New("fake block is committed")
+				}
+				if i > 0 && blk.HashBlock() != blkHash {
+					return false, errors.New("conflicting blocks are committed")
+				}
+				blkHash = blk.HashBlock()
 			}
-		}()
-
-		checkChains(chains, 1)
+			return true, nil
+		}))
 	})
 
 	t.Run("network-partition-time-rotation", func(t *testing.T) {
@@ -626,7 +650,9 @@ func TestRollDPoSConsensus(t *testing.T) {
 
 		for i := 0; i < 4; i++ {
 			cs[i].ctx.cfg.TimeBasedRotation = true
-			cs[i].ctx.cfg.EnableDummyBlock = false
+			// The delegates start each round at the beginning of the slot together, so the messages arriving a bit
+			// earlier than the round starts shouldn't wait as long as a step
+			cs[i].ctx.cfg.UnmatchedEventInterval = 10 * time.Millisecond
 			require.NoError(t, chains[i].Start(ctx))
 			require.NoError(t, p2ps[i].Start(ctx))
 			require.NoError(t, cs[i].Start(ctx))
@@ -640,10 +666,25 @@ func TestRollDPoSConsensus(t *testing.T) {
 			}
 		}()
 
-		checkChains(chains, 4)
+		// The others skip the slots of the isolated node, and keep committing real blocks in the other slots
+		assert.NoError(t, testutil.WaitUntil(100*time.Millisecond, 20*time.Second, func() (bool, error) {
+			for i, chain := range chains {
+				if i == 1 {
+					continue
+				}
+				blk, err := chain.GetBlockByHeight(4)
+				if blk == nil || err != nil {
+					return false, nil
+				}
+				if blk.IsDummyBlock() {
+					return false, errors.New("a dummy block is committed")
+				}
+			}
+			return true, nil
+		}))
 	})
 
-	t.Run("proposer-network-partition-blocking", func(t *testing.T) {
+	t.Run("proposer-network-partition-round-change", func(t *testing.T) {
 		ctx := context.Background()
 		cs, p2ps, chains := newConsensusComponents(4)
 		// 1 should be the block 1's proposer
@@ -656,7 +697,6 @@ func TestRollDPoSConsensus(t *testing.T) {
 		}
 
 		for i := 0; i < 4; i++ {
-			cs[i].ctx.cfg.EnableDummyBlock = false
 			require.NoError(t, chains[i].Start(ctx))
 			require.NoError(t, p2ps[i].Start(ctx))
 			require.NoError(t, cs[i].Start(ctx))
@@ -669,12 +709,25 @@ func TestRollDPoSConsensus(t *testing.T) {
 				require.NoError(t, chains[i].Stop(ctx))
 			}
 		}()
-		time.Sleep(2 * time.Second)
-		for _, chain := range chains {
-			blk, err := chain.GetBlockByHeight(1)
-			assert.Nil(t, blk)
-			assert.Error(t, err)
-		}
+		// The others time out, move to the next round and commit the block proposed by the next proposer
+		assert.NoError(t, testutil.WaitUntil(100*time.Millisecond, 10*time.Second, func() (bool, error) {
+			for i, chain := range chains {
+				if i == 1 {
+					continue
+				}
+				blk, err := chain.GetBlockByHeight(1)
+				if blk == nil || err != nil {
+					return false, nil
+				}
+				if blk.IsDummyBlock() {
+					return false, errors.New("a dummy block is committed")
+				}
+			}
+			return true, nil
+		}))
+		blk, err := chains[1].GetBlockByHeight(1)
+		assert.Nil(t, blk)
+		assert.Error(t, err)
 	})
 
 	t.Run("non-proposer-network-partition-blocking", func(t *testing.T) {
@@ -690,7 +743,6 @@ func TestRollDPoSConsensus(t *testing.T) {
 		}
 
 		for i := 0; i < 4; i++ {
-			cs[i].ctx.cfg.EnableDummyBlock = false
 			require.NoError(t, chains[i].Start(ctx))
 			require.NoError(t, p2ps[i].Start(ctx))
 			require.NoError(t, cs[i].Start(ctx))
@@ -0,0 +1,165 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/enc"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+const (
+	// signedMsgNS is the namespace of the messages signed by the delegate
+	signedMsgNS = "signed"
+	// proposeTopic is the topic of the block proposals, besides the endorse topics
+	proposeTopic = "propose"
+	// signedMsgLen is the length of a serialized signed message
+	signedMsgLen = 8 + 4 + 1 + 32
+)
+
+var (
+	// ErrDoubleSign indicates the error of signing a message conflicting with what the delegate has signed before
+	ErrDoubleSign = errors.New("the message conflicts with a signed one")
+)
+
+// signedMsg is the last message signed by the delegate for a topic
+type signedMsg struct {
+	height   uint64
+	round    uint32
+	blkHash  hash.Hash32B
+	decision bool
+}
+
+// serialize returns the byte stream of the signed message
+func (msg *signedMsg) serialize() []byte {
+	stream := make([]byte, 12)
+	enc.MachineEndian.PutUint64(stream[:8], msg.height)
+	enc.MachineEndian.PutUint32(stream[8:], msg.round)
+	if msg.decision {
+		stream = append(stream, 1)
+	} else {
+		stream = append(stream, 0)
+	}
+	return append(stream, msg.blkHash[:]...)
+}
+
+// deserialize parses the byte stream into the signed message
+func (msg *signedMsg) deserialize(stream []byte) error {
+	if len(stream) != signedMsgLen {
+		return errors.Errorf("invalid length of signed message %d", len(stream))
+	}
+	msg.height = enc.MachineEndian.Uint64(stream[:8])
+	msg.round = enc.MachineEndian.Uint32(stream[8:12])
+	msg.decision = stream[12] == 1
+	copy(msg.blkHash[:], stream[13:])
+	return nil
+}
+
+// before checks if the message is at an earlier height, or at an earlier round of the same height
+func (msg *signedMsg) before(other *signedMsg) bool {
+	if msg.height != other.height {
+		return msg.height < other.height
+	}
+	return msg.round < other.round
+}
+
+// signedMsgStore is the write-ahead store of the last proposal and endorses signed by the delegate per topic. A message
+// is recorded before it is signed, so that the delegate will not sign a conflicting message at the same height and
+// round, or go back to an earlier one, even after it restarts.
+type signedMsgStore struct {
+	kvStore db.KVStore
+	msgs    map[string]*signedMsg
+}
+
+// newSignedMsgStore creates a signed message store on top of the KV store
+func newSignedMsgStore(kvStore db.KVStore) *signedMsgStore {
+	return &signedMsgStore{
+		kvStore: kvStore,
+		msgs:    make(map[string]*signedMsg),
+	}
+}
+
+// Start starts the KV store and loads the signed messages of all topics
+func (s *signedMsgStore) Start(ctx context.Context) error {
+	if err := s.kvStore.Start(ctx); err != nil {
+		return errors.Wrap(err, "error when starting the KV store")
+	}
+	for _, topic := range []string{
+		proposeTopic,
+		endorseProposal.String(),
+		endorseCommit.String(),
+		endorseRoundChange.String(),
+	} {
+		value, err := s.kvStore.Get(signedMsgNS, []byte(topic))
+		if err != nil {
+			if cause := errors.Cause(err); cause == db.ErrNotExist || cause == bolt.ErrBucketNotFound {
+				continue
+			}
+			return errors.Wrapf(err, "error when getting the signed message of topic %s", topic)
+		}
+		msg := &signedMsg{}
+		if err := msg.deserialize(value); err != nil {
+			return errors.Wrapf(err, "error when deserializing the signed message of topic %s", topic)
+		}
+		s.msgs[topic] = msg
+	}
+	return nil
+}
+
+// Stop stops the KV store
+func (s *signedMsgStore) Stop(ctx context.Context) error {
+	return s.kvStore.Stop(ctx)
+}
+
+// latest returns the height and the round of the latest signed message of any topic
+func (s *signedMsgStore) latest() (uint64, uint32) {
+	var latest signedMsg
+	for _, msg := range s.msgs {
+		if latest.before(msg) {
+			latest = *msg
+		}
+	}
+	return latest.height, latest.round
+}
+
+// checkAndPut records the message of the topic if it doesn't conflict with the signed one, otherwise returns
+// ErrDoubleSign. Signing the same message again is allowed.
+func (s *signedMsgStore) checkAndPut(topic string, msg *signedMsg) error {
+	if signed, ok := s.msgs[topic]; ok {
+		if msg.before(signed) {
+			return errors.Wrapf(
+				ErrDoubleSign,
+				"topic %s has been signed at height %d round %d",
+				topic,
+				signed.height,
+				signed.round,
+			)
+		}
+		if !signed.before(msg) {
+			if signed.blkHash != msg.blkHash || signed.decision != msg.decision {
+				return errors.Wrapf(
+					ErrDoubleSign,
+					"topic %s has been signed for a different block at height %d round %d",
+					topic,
+					signed.height,
+					signed.round,
+				)
+			}
+			return nil
+		}
+	}
+	if err := s.kvStore.Put(signedMsgNS, []byte(topic), msg.serialize()); err != nil {
+		return errors.Wrapf(err, "error when putting the signed message of topic %s", topic)
+	}
+	s.msgs[topic] = msg
+	return nil
+}
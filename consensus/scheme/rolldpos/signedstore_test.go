@@ -0,0 +1,161 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/facebookgo/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/test/mock/mock_network"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestSignedMsgStore(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	path := "/tmp/test-signed-msg-store-" + strconv.Itoa(rand.Int())
+	testutil.CleanupPath(t, path)
+	defer testutil.CleanupPath(t, path)
+
+	var hash1, hash2 hash.Hash32B
+	hash1[0] = 1
+	hash2[0] = 2
+
+	s := newSignedMsgStore(db.NewBoltDB(path, &config.Default.DB))
+	require.NoError(s.Start(ctx))
+	height, round := s.latest()
+	require.Equal(uint64(0), height)
+	require.Equal(uint32(0), round)
+
+	msg := &signedMsg{height: 2, round: 1, blkHash: hash1, decision: true}
+	require.NoError(s.checkAndPut(endorseProposal.String(), msg))
+	// Signing the same message again is fine
+	require.NoError(s.checkAndPut(endorseProposal.String(), msg))
+	// Another block or decision at the same round conflicts
+	require.Equal(ErrDoubleSign, errors.Cause(s.checkAndPut(
+		endorseProposal.String(),
+		&signedMsg{height: 2, round: 1, blkHash: hash2, decision: true},
+	)))
+	require.Equal(ErrDoubleSign, errors.Cause(s.checkAndPut(
+		endorseProposal.String(),
+		&signedMsg{height: 2, round: 1, blkHash: hash1, decision: false},
+	)))
+	// Going back to an earlier round or height conflicts too
+	require.Equal(ErrDoubleSign, errors.Cause(s.checkAndPut(
+		endorseProposal.String(),
+		&signedMsg{height: 2, round: 0, blkHash: hash1, decision: true},
+	)))
+	require.Equal(ErrDoubleSign, errors.Cause(s.checkAndPut(
+		endorseProposal.String(),
+		&signedMsg{height: 1, round: 3, blkHash: hash1, decision: true},
+	)))
+	// The other topics are independent
+	require.NoError(s.checkAndPut(endorseCommit.String(), &signedMsg{height: 2, round: 0, blkHash: hash2}))
+	// A later round could sign another block
+	require.NoError(s.checkAndPut(
+		endorseProposal.String(),
+		&signedMsg{height: 2, round: 2, blkHash: hash2, decision: true},
+	))
+	require.NoError(s.Stop(ctx))
+
+	// The signed messages survive the restart
+	s = newSignedMsgStore(db.NewBoltDB(path, &config.Default.DB))
+	require.NoError(s.Start(ctx))
+	defer func() {
+		require.NoError(s.Stop(ctx))
+	}()
+	height, round = s.latest()
+	require.Equal(uint64(2), height)
+	require.Equal(uint32(2), round)
+	require.Equal(ErrDoubleSign, errors.Cause(s.checkAndPut(
+		endorseProposal.String(),
+		&signedMsg{height: 2, round: 2, blkHash: hash1, decision: true},
+	)))
+	require.Equal(ErrDoubleSign, errors.Cause(s.checkAndPut(
+		endorseCommit.String(),
+		&signedMsg{height: 2, round: 0, blkHash: hash1},
+	)))
+	require.NoError(s.checkAndPut(endorseCommit.String(), &signedMsg{height: 2, round: 0, blkHash: hash2}))
+}
+
+func TestRefuseDoubleSign(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	delegates := make([]string, 4)
+	for i := 0; i < 4; i++ {
+		delegates[i] = testAddrs[i].RawAddress
+	}
+	epoch := epochCtx{
+		delegates:    delegates,
+		num:          uint64(1),
+		height:       uint64(1),
+		numSubEpochs: uint(1),
+	}
+
+	t.Run("resume-from-signed-round", func(t *testing.T) {
+		ctx := context.Background()
+		cfsm := newTestCFSM(t, testAddrs[0], testAddrs[2], ctrl, delegates, nil, nil, clock.New())
+		require.NoError(t, cfsm.ctx.signed.checkAndPut(endorseCommit.String(), &signedMsg{height: 2, round: 1}))
+		require.NoError(t, cfsm.Start(ctx))
+		defer func() {
+			require.NoError(t, cfsm.Stop(ctx))
+		}()
+		assert.Equal(t, uint64(2), cfsm.ctx.round.height)
+		assert.Equal(t, uint32(1), cfsm.ctx.round.number)
+	})
+	t.Run("refuse-conflicting-proposal-endorse", func(t *testing.T) {
+		cfsm := newTestCFSM(
+			t,
+			testAddrs[0],
+			testAddrs[2],
+			ctrl,
+			delegates,
+			nil,
+			func(p2p *mock_network.MockOverlay) {
+				p2p.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Return(nil).Times(0)
+			},
+			clock.New(),
+		)
+		cfsm.ctx.epoch = epoch
+		cfsm.ctx.round = roundCtx{
+			height:           2,
+			proposalEndorses: make(map[hash.Hash32B]map[string]*endorse),
+			commitEndorses:   make(map[hash.Hash32B]map[string]*endorse),
+			proposer:         delegates[2],
+		}
+		// The delegate has endorsed another block at the same round before restarting
+		var otherHash hash.Hash32B
+		otherHash[0] = 1
+		require.NoError(t, cfsm.ctx.signed.checkAndPut(
+			endorseProposal.String(),
+			&signedMsg{height: 2, round: 0, blkHash: otherHash, decision: true},
+		))
+
+		blk, err := cfsm.ctx.mintBlock()
+		require.NoError(t, err)
+		state, err := cfsm.handleProposeBlockEvt(newProposeBlkEvt(blk, 0, cfsm.ctx.clock))
+		assert.NoError(t, err)
+		assert.Equal(t, sAcceptProposalEndorse, state)
+		// No endorse but the timeout
+		assert.Equal(t, eEndorseProposalTimeout, (<-cfsm.evtq).Type())
+	})
+}
@@ -0,0 +1,586 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/network/node"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+// simNodeKeys are the names of the fixed test key pairs of the delegates. The random key pairs would change the
+// delegates' order and the proposers from run to run.
+var simNodeKeys = []string{"alfa", "bravo", "charlie", "delta", "echo", "foxtrot", "galilei", "producer"}
+
+// SimConfig is the config of the consensus simulator
+type SimConfig struct {
+	// NumNodes is the number of the delegates
+	NumNodes int
+	// Seed seeds the latencies, the drops and the byzantine behaviors, so that a seed replays the same schedule
+	Seed int64
+	// MinLatency and MaxLatency bound the uniformly random latency of delivering a message to a peer
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	// DropRate is the probability that a message to a peer is lost
+	DropRate float64
+	// Byzantine are the indexes of the delegates that equivocate. They send a conflicting block or endorse to every
+	// other peer while sending the honest ones to the rest.
+	Byzantine []int
+	// SyncInterval is the interval that a node pulls the missing blocks from the peers it could reach, which simulates
+	// the block sync
+	SyncInterval time.Duration
+	RollDPoS     config.RollDPoS
+}
+
+// DefaultSimConfig is the default config of the consensus simulator
+var DefaultSimConfig = SimConfig{
+	NumNodes:     4,
+	MinLatency:   10 * time.Millisecond,
+	MaxLatency:   500 * time.Millisecond,
+	SyncInterval: 5 * time.Second,
+	RollDPoS:     config.Default.Consensus.RollDPoS,
+}
+
+// Simulator runs a number of RollDPoS delegates in process on a simulated network. All the delegates share a mock
+// clock, and their events as well as the message deliveries are handled one by one in a single goroutine in the order
+// of the virtual time, so a run is determined by the config and the seed. The simulator isn't safe for concurrent use.
+type Simulator struct {
+	cfg    SimConfig
+	clock  *clock.Mock
+	rand   *rand.Rand
+	queue  simEventQueue
+	seq    uint64
+	nodes  []*simNode
+	groups []int
+}
+
+type simNode struct {
+	addr      *iotxaddress.Address
+	netAddr   net.Addr
+	clock     *offsetClock
+	chain     blockchain.Blockchain
+	consensus *RollDPoS
+	byzantine bool
+	// conflicts are the conflicting blocks that the byzantine node proposes keyed by height
+	conflicts map[uint64]*blockchain.Block
+}
+
+// offsetClock shifts the time of the clock, which lets a byzantine node mint a conflicting block at the same height
+type offsetClock struct {
+	clock.Clock
+	offset time.Duration
+}
+
+// Now returns the shifted current time
+func (c *offsetClock) Now() time.Time {
+	return c.Clock.Now().Add(c.offset)
+}
+
+type simEvent struct {
+	at  time.Time
+	seq uint64
+	f   func()
+}
+
+// simEventQueue is a priority queue of the events by the virtual time and the order they're scheduled
+type simEventQueue []*simEvent
+
+func (q simEventQueue) Len() int { return len(q) }
+
+func (q simEventQueue) Less(i, j int) bool {
+	if q[i].at.Equal(q[j].at) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at.Before(q[j].at)
+}
+
+func (q simEventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *simEventQueue) Push(x interface{}) { *q = append(*q, x.(*simEvent)) }
+
+func (q *simEventQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	*q = old[:n-1]
+	return e
+}
+
+// NewSimulator creates a simulator of the delegates
+func NewSimulator(cfg SimConfig) (*Simulator, error) {
+	if cfg.NumNodes <= 0 || cfg.NumNodes > len(simNodeKeys) {
+		return nil, errors.Errorf("the number of nodes should be between 1 and %d", len(simNodeKeys))
+	}
+	if cfg.MaxLatency < cfg.MinLatency {
+		return nil, errors.New("the max latency should not be less than the min latency")
+	}
+	cfg.RollDPoS.NumDelegates = uint(cfg.NumNodes)
+	s := &Simulator{
+		cfg:   cfg,
+		clock: clock.NewMock(),
+		rand:  rand.New(rand.NewSource(cfg.Seed)),
+	}
+	// Start from the genesis time so that the first round starts after the proposer interval
+	s.clock.Add(time.Unix(int64(blockchain.Gen.Timestamp), 0).Sub(s.clock.Now()))
+
+	chainCfg := config.Default
+	chainCfg.Consensus.RollDPoS = cfg.RollDPoS
+	candidates := make([]*state.Candidate, 0, cfg.NumNodes)
+	for i := 0; i < cfg.NumNodes; i++ {
+		addr := testaddress.Addrinfo[simNodeKeys[i]]
+		candidates = append(candidates, &state.Candidate{Address: addr.RawAddress})
+		clk := &offsetClock{Clock: s.clock}
+		chain := blockchain.NewBlockchain(
+			&chainCfg,
+			blockchain.InMemDaoOption(),
+			blockchain.InMemStateFactoryOption(),
+			blockchain.ClockOption(clk),
+		)
+		s.nodes = append(s.nodes, &simNode{
+			addr:      addr,
+			netAddr:   node.NewTCPNode(fmt.Sprintf("127.0.0.%d:4689", i+1)),
+			clock:     clk,
+			chain:     chain,
+			conflicts: make(map[uint64]*blockchain.Block),
+		})
+	}
+	for _, i := range cfg.Byzantine {
+		if i < 0 || i >= cfg.NumNodes {
+			return nil, errors.Errorf("byzantine node %d is out of range", i)
+		}
+		s.nodes[i].byzantine = true
+	}
+	candidatesByHeightFunc := func(uint64) ([]*state.Candidate, error) {
+		return candidates, nil
+	}
+	for i, n := range s.nodes {
+		ap, err := actpool.NewActPool(n.chain, chainCfg.ActPool)
+		if err != nil {
+			return nil, errors.Wrap(err, "error when creating the action pool")
+		}
+		n.consensus, err = NewRollDPoSBuilder().
+			SetAddr(n.addr).
+			SetConfig(cfg.RollDPoS).
+			SetBlockchain(n.chain).
+			SetActPool(ap).
+			SetP2P(&simOverlay{sim: s, idx: i}).
+			SetClock(s.clock).
+			SetCandidatesByHeightFunc(candidatesByHeightFunc).
+			Build()
+		if err != nil {
+			return nil, errors.Wrap(err, "error when creating the consensus of the node")
+		}
+		m := n.consensus.cfsm
+		m.scheduler = func(evt iConsensusEvt, delay time.Duration) {
+			s.schedule(delay, func() { m.handle(evt) })
+		}
+	}
+	return s, nil
+}
+
+// Start starts the delegates
+func (s *Simulator) Start(ctx context.Context) error {
+	for i, n := range s.nodes {
+		if err := n.chain.Start(ctx); err != nil {
+			return errors.Wrap(err, "error when starting the blockchain")
+		}
+		if err := n.consensus.Start(ctx); err != nil {
+			return errors.Wrap(err, "error when starting the consensus")
+		}
+		idx := i
+		s.schedule(s.cfg.SyncInterval, func() { s.sync(idx) })
+	}
+	return nil
+}
+
+// Stop stops the delegates
+func (s *Simulator) Stop(ctx context.Context) error {
+	for _, n := range s.nodes {
+		if err := n.consensus.Stop(ctx); err != nil {
+			return errors.Wrap(err, "error when stopping the consensus")
+		}
+		if err := n.chain.Stop(ctx); err != nil {
+			return errors.Wrap(err, "error when stopping the blockchain")
+		}
+	}
+	return nil
+}
+
+// Now returns the virtual time
+func (s *Simulator) Now() time.Time {
+	return s.clock.Now()
+}
+
+// Run handles the events in the virtual duration
+func (s *Simulator) Run(d time.Duration) {
+	s.RunUntil(func() bool { return false }, d)
+}
+
+// RunUntil handles the events until the condition is met or the virtual duration elapses, and returns whether the
+// condition is met
+func (s *Simulator) RunUntil(cond func() bool, d time.Duration) bool {
+	end := s.clock.Now().Add(d)
+	for s.queue.Len() > 0 && !s.queue[0].at.After(end) {
+		e := heap.Pop(&s.queue).(*simEvent)
+		s.clock.Add(e.at.Sub(s.clock.Now()))
+		e.f()
+		if cond() {
+			return true
+		}
+	}
+	s.clock.Add(end.Sub(s.clock.Now()))
+	return cond()
+}
+
+// Partition splits the network into the groups of node indexes. The nodes in different groups, or in none of the
+// groups, can't reach each other.
+func (s *Simulator) Partition(groups ...[]int) {
+	s.groups = make([]int, len(s.nodes))
+	for i := range s.groups {
+		// The nodes not in any group are isolated
+		s.groups[i] = len(groups) + i
+	}
+	for g, group := range groups {
+		for _, i := range group {
+			s.groups[i] = g
+		}
+	}
+}
+
+// Heal removes the partitions
+func (s *Simulator) Heal() {
+	s.groups = nil
+}
+
+// Heights returns the tip heights of the nodes
+func (s *Simulator) Heights() []uint64 {
+	heights := make([]uint64, len(s.nodes))
+	for i, n := range s.nodes {
+		heights[i] = n.chain.TipHeight()
+	}
+	return heights
+}
+
+// MinHeight returns the lowest tip height of the honest nodes
+func (s *Simulator) MinHeight() uint64 {
+	var min uint64
+	first := true
+	for _, n := range s.nodes {
+		if n.byzantine {
+			continue
+		}
+		if height := n.chain.TipHeight(); first || height < min {
+			min = height
+			first = false
+		}
+	}
+	return min
+}
+
+// CheckSafety returns an error if two nodes have committed different blocks at the same height
+func (s *Simulator) CheckSafety() error {
+	for height := uint64(1); ; height++ {
+		var (
+			committed hash.Hash32B
+			by        = -1
+		)
+		for i, n := range s.nodes {
+			if n.chain.TipHeight() < height {
+				continue
+			}
+			blkHash, err := n.chain.GetHashByHeight(height)
+			if err != nil {
+				return errors.Wrapf(err, "error when getting the block hash of node %d at height %d", i, height)
+			}
+			if by < 0 {
+				committed, by = blkHash, i
+				continue
+			}
+			if blkHash != committed {
+				return errors.Errorf("node %d and %d committed different blocks at height %d", by, i, height)
+			}
+		}
+		if by < 0 {
+			return nil
+		}
+	}
+}
+
+func (s *Simulator) schedule(delay time.Duration, f func()) {
+	heap.Push(&s.queue, &simEvent{at: s.clock.Now().Add(delay), seq: s.seq, f: f})
+	s.seq++
+}
+
+func (s *Simulator) reachable(from int, to int) bool {
+	return s.groups == nil || s.groups[from] == s.groups[to]
+}
+
+func (s *Simulator) broadcast(from int, msg proto.Message) {
+	for to := range s.nodes {
+		if to == from {
+			continue
+		}
+		m := msg
+		// The byzantine node equivocates to every other peer
+		if s.nodes[from].byzantine && to%2 == 1 {
+			m = s.equivocate(from, msg)
+		}
+		s.send(from, to, m)
+	}
+}
+
+func (s *Simulator) send(from int, to int, msg proto.Message) {
+	if !s.reachable(from, to) || s.rand.Float64() < s.cfg.DropRate {
+		return
+	}
+	latency := s.cfg.MinLatency + time.Duration(s.rand.Int63n(int64(s.cfg.MaxLatency-s.cfg.MinLatency)+1))
+	s.schedule(latency, func() { s.deliver(to, msg) })
+}
+
+func (s *Simulator) deliver(to int, msg proto.Message) {
+	n := s.nodes[to]
+	var err error
+	switch m := msg.(type) {
+	case *iproto.ProposePb:
+		err = n.consensus.HandleBlockPropose(m)
+	case *iproto.EndorsePb:
+		err = n.consensus.HandleEndorse(m)
+	case *iproto.BlockPb:
+		blk := &blockchain.Block{}
+		blk.ConvertFromBlockPb(m)
+		if blk.Height() == n.chain.TipHeight()+1 {
+			err = s.commit(to, blk)
+		}
+	}
+	if err != nil {
+		logger.Debug().Err(err).Int("node", to).Msg("error when delivering the message")
+	}
+}
+
+// equivocate returns the message conflicting with the given one
+func (s *Simulator) equivocate(from int, msg proto.Message) proto.Message {
+	n := s.nodes[from]
+	switch m := msg.(type) {
+	case *iproto.ProposePb:
+		blk, err := s.conflict(from)
+		if err != nil {
+			logger.Error().Err(err).Msg("error when minting the conflicting block")
+			return msg
+		}
+		return &iproto.ProposePb{Proposer: m.Proposer, Block: blk.ConvertToBlockPb(), Round: m.Round}
+	case *iproto.EndorsePb:
+		if m.Topic == iproto.EndorsePb_ROUND_CHANGE {
+			return msg
+		}
+		en := &endorse{}
+		if err := en.fromProtoMsg(m); err != nil {
+			return msg
+		}
+		if blk, ok := n.conflicts[en.height]; ok {
+			en.blkHash = blk.HashBlock()
+		} else {
+			en.decision = !en.decision
+		}
+		en.dkgID, en.dkgPubkey, en.dkgSignature = nil, nil, nil
+		if err := en.Sign(n.addr); err != nil {
+			return msg
+		}
+		return en.toProtoMsg()
+	}
+	return msg
+}
+
+// conflict mints a block at the next height of the byzantine node, which differs from the one it proposes
+func (s *Simulator) conflict(from int) (*blockchain.Block, error) {
+	n := s.nodes[from]
+	height := n.chain.TipHeight() + 1
+	if blk, ok := n.conflicts[height]; ok {
+		return blk, nil
+	}
+	n.clock.offset = time.Second
+	defer func() { n.clock.offset = 0 }()
+	blk, err := n.chain.MintNewBlock(nil, nil, nil, n.addr, "")
+	if err != nil {
+		return nil, err
+	}
+	n.conflicts[height] = blk
+	return blk, nil
+}
+
+// sync pulls the missing blocks from the peers that the node could reach
+func (s *Simulator) sync(to int) {
+	defer s.schedule(s.cfg.SyncInterval, func() { s.sync(to) })
+	n := s.nodes[to]
+	for from, peer := range s.nodes {
+		if from == to || !s.reachable(from, to) {
+			continue
+		}
+		for n.chain.TipHeight() < peer.chain.TipHeight() {
+			blk, err := peer.chain.GetBlockByHeight(n.chain.TipHeight() + 1)
+			if err != nil {
+				break
+			}
+			if err := s.commit(to, blk); err != nil {
+				logger.Debug().Err(err).Int("node", to).Msg("error when syncing the block")
+				break
+			}
+		}
+	}
+}
+
+func (s *Simulator) commit(to int, blk *blockchain.Block) error {
+	chain := s.nodes[to].chain
+	if err := chain.ValidateBlock(blk, true); err != nil {
+		return errors.Wrap(err, "error when validating the block")
+	}
+	return chain.CommitBlock(blk)
+}
+
+// simOverlay delivers the messages of a node through the simulator
+type simOverlay struct {
+	sim *Simulator
+	idx int
+}
+
+func (o *simOverlay) Start(_ context.Context) error { return nil }
+
+func (o *simOverlay) Stop(_ context.Context) error { return nil }
+
+func (o *simOverlay) Broadcast(_ uint32, msg proto.Message) error {
+	o.sim.broadcast(o.idx, msg)
+	return nil
+}
+
+func (o *simOverlay) Tell(_ uint32, addr net.Addr, msg proto.Message) error {
+	for to, n := range o.sim.nodes {
+		if n.netAddr.String() == addr.String() {
+			o.sim.send(o.idx, to, msg)
+			return nil
+		}
+	}
+	return errors.Errorf("peer %s is not found", addr)
+}
+
+func (o *simOverlay) Self() net.Addr { return o.sim.nodes[o.idx].netAddr }
+
+func (o *simOverlay) GetPeers() []net.Addr {
+	addrs := make([]net.Addr, 0, len(o.sim.nodes)-1)
+	for i, n := range o.sim.nodes {
+		if i != o.idx {
+			addrs = append(addrs, n.netAddr)
+		}
+	}
+	return addrs
+}
+
+func (o *simOverlay) BannedPeers() map[string]time.Time { return nil }
+
+func runSimulation(t *testing.T, cfg SimConfig, f func(*Simulator)) {
+	ctx := context.Background()
+	sim, err := NewSimulator(cfg)
+	require.NoError(t, err)
+	require.NoError(t, sim.Start(ctx))
+	defer func() {
+		require.NoError(t, sim.Stop(ctx))
+	}()
+	f(sim)
+	require.NoError(t, sim.CheckSafety(), "seed %d", cfg.Seed)
+}
+
+func TestSimulator(t *testing.T) {
+	t.Parallel()
+
+	// Each scenario runs through many seeded schedules of the message delays, drops and timer firings, because a
+	// safety or liveness bug usually only shows up in a few of them
+	numSchedules := 1000
+	if testing.Short() {
+		numSchedules = 3
+	}
+	reached := func(sim *Simulator, height uint64) func() bool {
+		return func() bool { return sim.MinHeight() >= height }
+	}
+
+	t.Run("deterministic", func(t *testing.T) {
+		var heights []uint64
+		for i := 0; i < 2; i++ {
+			cfg := DefaultSimConfig
+			cfg.Seed = 7
+			cfg.DropRate = 0.05
+			runSimulation(t, cfg, func(sim *Simulator) {
+				sim.Run(2 * time.Minute)
+				if i == 0 {
+					heights = sim.Heights()
+				} else {
+					require.Equal(t, heights, sim.Heights())
+				}
+			})
+		}
+	})
+
+	t.Run("lossy-network", func(t *testing.T) {
+		t.Parallel()
+
+		for seed := int64(0); seed < int64(numSchedules); seed++ {
+			cfg := DefaultSimConfig
+			cfg.Seed = seed
+			cfg.DropRate = 0.05
+			runSimulation(t, cfg, func(sim *Simulator) {
+				require.True(t, sim.RunUntil(reached(sim, 3), 5*time.Minute), "seed %d", seed)
+			})
+		}
+	})
+
+	t.Run("partition", func(t *testing.T) {
+		t.Parallel()
+
+		for seed := int64(0); seed < int64(numSchedules); seed++ {
+			cfg := DefaultSimConfig
+			cfg.Seed = seed
+			runSimulation(t, cfg, func(sim *Simulator) {
+				// No quorum on either side
+				sim.Partition([]int{0, 1}, []int{2, 3})
+				sim.Run(time.Minute)
+				require.Equal(t, []uint64{0, 0, 0, 0}, sim.Heights(), "seed %d", seed)
+				sim.Heal()
+				require.True(t, sim.RunUntil(reached(sim, 2), 5*time.Minute), "seed %d", seed)
+			})
+		}
+	})
+
+	t.Run("byzantine", func(t *testing.T) {
+		t.Parallel()
+
+		for seed := int64(0); seed < int64(numSchedules); seed++ {
+			cfg := DefaultSimConfig
+			cfg.Seed = seed
+			cfg.Byzantine = []int{int(seed % 4)}
+			runSimulation(t, cfg, func(sim *Simulator) {
+				require.True(t, sim.RunUntil(reached(sim, 3), 5*time.Minute), "seed %d", seed)
+			})
+		}
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/logger"
+)
+
+// With the time based rotation, the time since the genesis block is divided into the slots of the proposer interval.
+// The genesis block takes the slot 0, each slot has at most one block whose timestamp falls in it, and each epoch
+// covers a fixed number of slots. The proposer of a slot is determined by the slot number, so that the delegates
+// agree on it no matter how many blocks are missing.
+
+// genesisTime returns the start time of the slot 0
+func genesisTime() time.Time {
+	return time.Unix(int64(blockchain.Gen.Timestamp), 0)
+}
+
+// calcSlot returns the slot that the given time falls in
+func (ctx *rollDPoSCtx) calcSlot(t time.Time) uint64 {
+	if !t.After(genesisTime()) {
+		return 0
+	}
+	return uint64(t.Sub(genesisTime()) / ctx.cfg.ProposerInterval)
+}
+
+// slotStartTime returns the start time of the given slot
+func (ctx *rollDPoSCtx) slotStartTime(slot uint64) time.Time {
+	return genesisTime().Add(time.Duration(slot) * ctx.cfg.ProposerInterval)
+}
+
+// blockSlot returns the slot of the block at the given height
+func (ctx *rollDPoSCtx) blockSlot(height uint64) (uint64, error) {
+	if height == 0 {
+		return 0, nil
+	}
+	blk, err := ctx.chain.GetBlockByHeight(height)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error when getting the block at height %d", height)
+	}
+	return ctx.calcSlot(blk.Header.Timestamp()), nil
+}
+
+// roundSlot returns the slot of the given round at the given height. The round 0 takes the slot right after the one of
+// the last block, and each following round takes the next slot.
+func (ctx *rollDPoSCtx) roundSlot(height uint64, round uint32) (uint64, error) {
+	parentSlot, err := ctx.blockSlot(height - 1)
+	if err != nil {
+		return 0, err
+	}
+	return parentSlot + 1 + uint64(round), nil
+}
+
+// calcNextSlot returns the slot in which the next block could be proposed, which is the current slot unless it is not
+// later than the one of the last block
+func (ctx *rollDPoSCtx) calcNextSlot() (uint64, error) {
+	tipSlot, err := ctx.blockSlot(ctx.chain.TipHeight())
+	if err != nil {
+		return 0, err
+	}
+	slot := ctx.calcSlot(ctx.clock.Now())
+	if slot <= tipSlot {
+		slot = tipSlot + 1
+	}
+	return slot, nil
+}
+
+// nextRoundSlot returns the slot of the next round to start. It is the slot after the one of the current round if the
+// block at the height hasn't been committed before the current slot ends.
+func (ctx *rollDPoSCtx) nextRoundSlot() (uint64, error) {
+	slot, err := ctx.calcNextSlot()
+	if err != nil {
+		return 0, err
+	}
+	if ctx.round.height == ctx.chain.TipHeight()+1 && ctx.round.slot >= slot {
+		slot = ctx.round.slot + 1
+	}
+	return slot, nil
+}
+
+// numSlotsPerEpoch returns the number of slots in an epoch, in which each delegate takes a slot per sub-epoch
+func (ctx *rollDPoSCtx) numSlotsPerEpoch() uint64 {
+	return uint64(ctx.cfg.NumDelegates) * uint64(ctx.getNumSubEpochs())
+}
+
+// calcEpochNumOfSlot returns the ordinal number of the epoch that the given slot belongs to
+func (ctx *rollDPoSCtx) calcEpochNumOfSlot(slot uint64) uint64 {
+	if slot == 0 {
+		return 1
+	}
+	return (slot-1)/ctx.numSlotsPerEpoch() + 1
+}
+
+// epochStartSlot returns the first slot of the given epoch
+func (ctx *rollDPoSCtx) epochStartSlot(epochNum uint64) uint64 {
+	return (epochNum-1)*ctx.numSlotsPerEpoch() + 1
+}
+
+// lastHeightBeforeSlot returns the height of the last block committed before the given slot
+func (ctx *rollDPoSCtx) lastHeightBeforeSlot(slot uint64) (uint64, error) {
+	// The slots of the blocks are strictly increasing with the heights
+	low, high := uint64(0), ctx.chain.TipHeight()
+	for low < high {
+		mid := (low + high + 1) / 2
+		midSlot, err := ctx.blockSlot(mid)
+		if err != nil {
+			return 0, err
+		}
+		if midSlot < slot {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	return low, nil
+}
+
+// validateBlockSlot checks that the block's timestamp falls in a slot between the one of the last block and the one of
+// the current round, and that the block is produced by the proposer of that slot
+func (ctx *rollDPoSCtx) validateBlockSlot(blk *blockchain.Block) error {
+	slot := ctx.calcSlot(blk.Header.Timestamp())
+	parentSlot, err := ctx.blockSlot(blk.Height() - 1)
+	if err != nil {
+		return err
+	}
+	if slot <= parentSlot || slot > ctx.round.slot {
+		return errors.Wrapf(
+			ErrBlockOutOfSlot,
+			"block slot %d is not in between the last block's slot %d and the current slot %d",
+			slot,
+			parentSlot,
+			ctx.round.slot,
+		)
+	}
+	delegates := ctx.epoch.delegates
+	if epochNum := ctx.calcEpochNumOfSlot(slot); epochNum != ctx.epoch.num {
+		// The block locked in an earlier epoch could be proposed again, whose proposer is one of that epoch's delegates
+		if delegates, err = ctx.rollingDelegates(epochNum); err != nil {
+			return errors.Wrapf(err, "error when getting the delegates of epoch %d", epochNum)
+		}
+	}
+	proposer, err := ctx.calcProposer(blk.Height(), uint32(slot-parentSlot-1), delegates)
+	if err != nil {
+		return err
+	}
+	if producer := blk.ProducerAddress(); producer != proposer {
+		return errors.Wrapf(
+			ErrBlockOutOfSlot,
+			"block producer %s is not the proposer %s of slot %d",
+			producer,
+			proposer,
+			slot,
+		)
+	}
+	return nil
+}
+
+// recordSkippedSlots counts the slots between the committed block and the last one, in which no block is committed
+func (ctx *rollDPoSCtx) recordSkippedSlots(blk *blockchain.Block) {
+	parentSlot, err := ctx.blockSlot(blk.Height() - 1)
+	if err != nil {
+		logger.Error().Err(err).Uint64("block", blk.Height()).Msg("error when counting the skipped slots")
+		return
+	}
+	if slot := ctx.calcSlot(blk.Header.Timestamp()); slot > parentSlot+1 {
+		skippedSlotsMtc.WithLabelValues().Add(float64(slot - parentSlot - 1))
+	}
+}
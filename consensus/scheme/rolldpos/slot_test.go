@@ -0,0 +1,117 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestTimeSlots(t *testing.T) {
+	require := require.New(t)
+
+	clk := clock.NewMock()
+	clk.Add(genesisTime().Sub(clk.Now()))
+	cfg := config.Default
+	chain := blockchain.NewBlockchain(
+		&cfg,
+		blockchain.InMemDaoOption(),
+		blockchain.InMemStateFactoryOption(),
+		blockchain.ClockOption(clk),
+	)
+	require.NoError(chain.Start(context.Background()))
+	defer func() {
+		require.NoError(chain.Stop(context.Background()))
+	}()
+	ctx := &rollDPoSCtx{
+		cfg: config.RollDPoS{
+			ProposerInterval:  10 * time.Second,
+			NumDelegates:      4,
+			NumSubEpochs:      1,
+			TimeBasedRotation: true,
+		},
+		chain: chain,
+		clock: clk,
+	}
+
+	require.Equal(uint64(0), ctx.calcSlot(genesisTime().Add(-time.Hour)))
+	require.Equal(uint64(0), ctx.calcSlot(genesisTime()))
+	require.Equal(uint64(1), ctx.calcSlot(genesisTime().Add(19*time.Second)))
+	require.Equal(genesisTime().Add(20*time.Second), ctx.slotStartTime(2))
+	require.Equal(uint64(1), ctx.calcEpochNumOfSlot(0))
+	require.Equal(uint64(1), ctx.calcEpochNumOfSlot(4))
+	require.Equal(uint64(2), ctx.calcEpochNumOfSlot(5))
+	require.Equal(uint64(5), ctx.epochStartSlot(2))
+
+	// Commit the blocks in the slots 2, 3 and 6, so that the slots 1, 4 and 5 are skipped
+	for _, slot := range []uint64{2, 3, 6} {
+		clk.Add(ctx.slotStartTime(slot).Sub(clk.Now()))
+		blk, err := chain.MintNewBlock(nil, nil, nil, testaddress.Addrinfo["producer"], "")
+		require.NoError(err)
+		require.NoError(chain.CommitBlock(blk))
+	}
+	for height, expected := range []uint64{0, 2, 3, 6} {
+		slot, err := ctx.blockSlot(uint64(height))
+		require.NoError(err)
+		require.Equal(expected, slot)
+	}
+	for slot, expected := range map[uint64]uint64{1: 0, 2: 0, 3: 1, 4: 2, 6: 2, 7: 3, 100: 3} {
+		height, err := ctx.lastHeightBeforeSlot(slot)
+		require.NoError(err)
+		require.Equal(expected, height, "slot %d", slot)
+	}
+
+	// The round 0 takes the slot after the last block's
+	delegates := []string{"a", "b", "c", "d"}
+	proposer, err := ctx.calcProposer(4, 0, delegates)
+	require.NoError(err)
+	require.Equal("d", proposer)
+	proposer, err = ctx.calcProposer(4, 2, delegates)
+	require.NoError(err)
+	require.Equal("b", proposer)
+	proposer, err = ctx.calcProposer(3, 0, delegates)
+	require.NoError(err)
+	require.Equal("a", proposer)
+
+	// The next round takes the current slot if the slots after the last block have passed
+	clk.Add(ctx.slotStartTime(9).Sub(clk.Now()) + time.Second)
+	slot, err := ctx.nextRoundSlot()
+	require.NoError(err)
+	require.Equal(uint64(9), slot)
+	ctx.round = roundCtx{height: 4, slot: 7}
+	slot, err = ctx.nextRoundSlot()
+	require.NoError(err)
+	require.Equal(uint64(9), slot)
+
+	// Otherwise, it takes the slot after the current round's
+	ctx.round = roundCtx{height: 4, slot: 9}
+	slot, err = ctx.nextRoundSlot()
+	require.NoError(err)
+	require.Equal(uint64(10), slot)
+
+	// The epoch 3 takes the slots 9 to 12, and starts from the block 4
+	epochNum, epochHeight, err := ctx.calcEpochNumAndHeight()
+	require.NoError(err)
+	require.Equal(uint64(3), epochNum)
+	require.Equal(uint64(4), epochHeight)
+	ctx.epoch = epochCtx{num: 2}
+	finished, err := ctx.isEpochFinished()
+	require.NoError(err)
+	require.True(finished)
+	ctx.epoch = epochCtx{num: 3}
+	finished, err = ctx.isEpochFinished()
+	require.NoError(err)
+	require.False(finished)
+}
@@ -40,7 +40,27 @@ type Scheme interface {
 type ConsensusMetrics struct {
 	LatestEpoch         uint64
 	LatestHeight        uint64
+	LatestRound         uint32
 	LatestDelegates     []string
 	LatestBlockProducer string
-	Candidates          []string
+	// LatestSlot and SkippedSlots are the current time slot and the number of the slots without a block, which are
+	// only set with the time based rotation
+	LatestSlot   uint64
+	SkippedSlots uint64
+	Candidates   []string
+}
+
+// DelegateProductivity contains the numbers of the blocks that a delegate has produced, missed in its turns and
+// endorsed to commit in an epoch
+type DelegateProductivity struct {
+	Produced uint64
+	Missed   uint64
+	Endorsed uint64
+}
+
+// ProductivityReporter is the interface that consensus schemes tracking the delegates' productivity should implement
+type ProductivityReporter interface {
+	Productivity(epochNum uint64) (map[string]DelegateProductivity, error)
+	// HandleCommittedBlock tracks a block committed out of the consensus, e.g., by the block sync
+	HandleCommittedBlock(blk *blockchain.Block)
 }
@@ -107,6 +107,7 @@ func (s *server) Init(in *pb.InitRequest, stream pb.Simulator_InitServer) error
 		}
 
 		overlay := network.NewOverlay(&cfg.Network)
+		overlay.Identity.ChainID = cfg.Chain.ID
 		ap, err := actpool.NewActPool(bc, cfg.ActPool)
 		if err != nil {
 			logger.Fatal().Err(err).Msg("Fail to create actpool")
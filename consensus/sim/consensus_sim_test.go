@@ -0,0 +1,199 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package sim
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/network/node"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// simOverlay relays the consensus messages of a player to the other players, as the simulator does through Ping. The
+// overlay of a byzantine player also relays the forged copies of the endorses of the player.
+type simOverlay struct {
+	addr  net.Addr
+	peers map[net.Addr]Sim
+	forge func(*iproto.EndorsePb) []*iproto.EndorsePb
+}
+
+func (o *simOverlay) Start(_ context.Context) error { return nil }
+
+func (o *simOverlay) Stop(_ context.Context) error { return nil }
+
+func (o *simOverlay) Broadcast(chainID uint32, msg proto.Message) error {
+	switch m := msg.(type) {
+	case *iproto.ProposePb:
+		for _, p := range o.peers {
+			if err := p.HandleBlockPropose(m, make(chan bool, 1)); err != nil {
+				return err
+			}
+		}
+	case *iproto.EndorsePb:
+		endorses := []*iproto.EndorsePb{m}
+		if o.forge != nil {
+			endorses = append(endorses, o.forge(m)...)
+		}
+		for _, en := range endorses {
+			for _, p := range o.peers {
+				if err := p.HandleEndorse(en, make(chan bool, 1)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (o *simOverlay) Tell(uint32, net.Addr, proto.Message) error { return nil }
+
+func (o *simOverlay) Self() net.Addr { return o.addr }
+
+func (o *simOverlay) GetPeers() []net.Addr {
+	addrs := make([]net.Addr, 0, len(o.peers))
+	for addr := range o.peers {
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+func (o *simOverlay) BannedPeers() map[string]time.Time { return nil }
+
+func TestSimByzantineEndorser(t *testing.T) {
+	require := require.New(t)
+
+	const numPlayers = 4
+	cfg := config.Default
+	cfg.Consensus.RollDPoS.Delay = 300 * time.Millisecond
+	cfg.Consensus.RollDPoS.ProposerInterval = time.Second
+	cfg.Consensus.RollDPoS.AcceptProposeTTL = 100 * time.Millisecond
+	cfg.Consensus.RollDPoS.AcceptProposalEndorseTTL = 100 * time.Millisecond
+	cfg.Consensus.RollDPoS.AcceptCommitEndorseTTL = 100 * time.Millisecond
+	cfg.Consensus.RollDPoS.NumDelegates = numPlayers
+
+	addrs := make([]*iotxaddress.Address, 0, numPlayers)
+	rawAddrs := make([]string, 0, numPlayers)
+	addrMap := make(map[string]*iotxaddress.Address)
+	for i := 0; i < numPlayers; i++ {
+		addr, err := iotxaddress.NewAddress(iotxaddress.IsTestnet, iotxaddress.ChainID)
+		require.NoError(err)
+		addrs = append(addrs, addr)
+		rawAddrs = append(rawAddrs, addr.RawAddress)
+		addrMap[addr.RawAddress] = addr
+	}
+	crypto.SortCandidates(rawAddrs, 1, nil)
+	for i, rawAddr := range rawAddrs {
+		addrs[i] = addrMap[rawAddr]
+	}
+	candidatesByHeight := func(_ uint64) ([]*state.Candidate, error) {
+		candidates := make([]*state.Candidate, 0, numPlayers)
+		for _, addr := range addrs {
+			candidates = append(candidates, &state.Candidate{Address: addr.RawAddress})
+		}
+		return candidates, nil
+	}
+
+	// The last player is a delegate which endorses on behalf of the other delegates, and endorses a fake block with
+	// the replayed signatures of its endorses
+	var fakeHash hash.Hash32B
+	fakeHash[0] = 1
+	forge := func(en *iproto.EndorsePb) []*iproto.EndorsePb {
+		forged := make([]*iproto.EndorsePb, 0, numPlayers+1)
+		for _, addr := range addrs {
+			onBehalf := proto.Clone(en).(*iproto.EndorsePb)
+			onBehalf.Endorser = addr.RawAddress
+			forged = append(forged, onBehalf)
+		}
+		fake := proto.Clone(en).(*iproto.EndorsePb)
+		fake.BlockHash = fakeHash[:]
+		return append(forged, fake)
+	}
+
+	ctx := context.Background()
+	chains := make([]blockchain.Blockchain, 0, numPlayers)
+	p2ps := make([]*simOverlay, 0, numPlayers)
+	players := make([]Sim, 0, numPlayers)
+	for i := 0; i < numPlayers; i++ {
+		chain := blockchain.NewBlockchain(&cfg, blockchain.InMemDaoOption(), blockchain.InMemStateFactoryOption())
+		chains = append(chains, chain)
+		ap, err := actpool.NewActPool(chain, cfg.ActPool)
+		require.NoError(err)
+		p2p := &simOverlay{
+			addr:  node.NewTCPNode(fmt.Sprintf("127.0.0.%d:4689", i+1)),
+			peers: make(map[net.Addr]Sim),
+		}
+		if i == numPlayers-1 {
+			p2p.forge = forge
+		}
+		p2ps = append(p2ps, p2p)
+		cs, err := rolldpos.NewRollDPoSBuilder().
+			SetAddr(addrs[i]).
+			SetConfig(cfg.Consensus.RollDPoS).
+			SetBlockchain(chain).
+			SetActPool(ap).
+			SetP2P(p2p).
+			SetCandidatesByHeightFunc(candidatesByHeight).
+			Build()
+		require.NoError(err)
+		players = append(players, &sim{cfg: &cfg.Consensus, scheme: cs})
+	}
+	for i := 0; i < numPlayers; i++ {
+		for j := 0; j < numPlayers; j++ {
+			if i != j {
+				p2ps[i].peers[p2ps[j].addr] = players[j]
+			}
+		}
+	}
+
+	for i := 0; i < numPlayers; i++ {
+		require.NoError(chains[i].Start(ctx))
+		require.NoError(players[i].Start(ctx))
+	}
+	defer func() {
+		for i := 0; i < numPlayers; i++ {
+			require.NoError(players[i].Stop(ctx))
+			require.NoError(chains[i].Stop(ctx))
+		}
+	}()
+
+	// The honest players still agree on the same real block
+	assert.NoError(t, testutil.WaitUntil(100*time.Millisecond, 10*time.Second, func() (bool, error) {
+		var blkHash hash.Hash32B
+		for i, chain := range chains {
+			blk, err := chain.GetBlockByHeight(1)
+			if blk == nil || err != nil {
+				return false, nil
+			}
+			if blk.HashBlock() == fakeHash {
+				return true, errors.New("fake block is committed")
+			}
+			if i > 0 && blk.HashBlock() != blkHash {
+				return true, errors.New("conflicting blocks are committed")
+			}
+			blkHash = blk.HashBlock()
+		}
+		return true, nil
+	}))
+}
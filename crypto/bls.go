@@ -10,12 +10,14 @@ package crypto
 //#include "lib/blslib/blskey.h"
 //#include "lib/blslib/tbls.h"
 //#include "lib/blslib/mnt160.h"
+//extern uint32_t mnt160_n[5];
 //#cgo darwin LDFLAGS: -L${SRCDIR}/lib/blslib -ltblsmnt_macos
 //#cgo linux LDFLAGS: -L${SRCDIR}/lib/blslib -ltblsmnt_ubuntu
 import "C"
 import (
 	"bytes"
 	"encoding/binary"
+	"math/big"
 
 	"github.com/iotexproject/iotex-core/pkg/enc"
 	"github.com/pkg/errors"
@@ -28,6 +30,8 @@ const (
 	sigSize     = 5 // number of uint32s in sig
 	privkeySize = 5
 	numnodes    = 21
+	// NumDKGNodes is the number of nodes participating in a DKG run
+	NumDKGNodes = numnodes
 )
 
 var (
@@ -129,7 +133,8 @@ func (b *bls) VerifyShare(pubkey []byte, msg []byte, sig []byte) error {
 	return b.Verify(pubkey, msg, sig)
 }
 
-// SignAggregate generates an aggregate signature
+// SignAggregate generates an aggregate signature. The result is unique for any Degree+1 signature shares of the same
+// message.
 func (b *bls) SignAggregate(ids [][]uint8, sigs [][]byte) ([]byte, error) {
 	var aggsig [sigSize]C.uint32_t
 	var idsSer [Degree + 1][idlength]C.uint8_t
@@ -147,6 +152,9 @@ func (b *bls) SignAggregate(ids [][]uint8, sigs [][]byte) ([]byte, error) {
 	}
 	ok := C.TBLS_sign_aggregate(&idsSer[0], &sigsSer[0], &aggsig[0])
 	if ok == 1 {
+		if aggsig, err = b.clearCofactor(aggsig); err != nil {
+			return []byte{}, err
+		}
 		sig, err := b.signatureSerialization(aggsig)
 		if err != nil {
 			return []byte{}, err
@@ -156,6 +164,32 @@ func (b *bls) SignAggregate(ids [][]uint8, sigs [][]byte) ([]byte, error) {
 	return []byte{}, errors.Wrap(ErrInvalidSignature, "Failed to generate aggregate signature")
 }
 
+// clearCofactor maps the aggregate signature into the subgroup of order n. The curve has cofactor 2, and depending on
+// the picked shares, the aggregation may return the signature plus the point of order 2, which passes the
+// verification as well. Multiplying it by 2 and then by the inverse of 2 mod n, i.e., (n+1)/2, removes that point.
+func (b *bls) clearCofactor(sig [sigSize]C.uint32_t) ([sigSize]C.uint32_t, error) {
+	var p, q C.ec160_point_aff
+	if C.point_decompression_mnt(&sig[0], &p) != 1 {
+		return sig, errors.Wrap(ErrInvalidSignature, "Failed to decompress aggregate signature")
+	}
+	n := new(big.Int)
+	for i := sigSize - 1; i >= 0; i-- {
+		n.Lsh(n, 32)
+		n.Or(n, big.NewInt(int64(C.mnt160_n[i])))
+	}
+	halfInv := new(big.Int).Rsh(n.Add(n, big.NewInt(1)), 1)
+	var two, k [sigSize]C.uint32_t
+	two[0] = 2
+	for i := 0; i < sigSize; i++ {
+		k[i] = (C.uint32_t)(new(big.Int).Rsh(halfInv, uint(32*i)).Uint64())
+	}
+	C.multibase_scalarmul(&two[0], &p, &q)
+	C.multibase_scalarmul(&k[0], &q, &p)
+	var res [sigSize]C.uint32_t
+	C.point_compression_mnt(&p, &res[0])
+	return res, nil
+}
+
 // VerifyAggregate verifies the aggregate signature given that there are at least Degree+1 signers
 func (b *bls) VerifyAggregate(ids [][]uint8, pubkeys [][]byte, msg []byte, aggsig []byte) error {
 	var idsSer [Degree + 1][idlength]C.uint8_t
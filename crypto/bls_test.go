@@ -73,8 +73,9 @@ func TestTBLS(t *testing.T) {
 		require.True(ok)
 	}
 
-	// Randomly select BLS signature shares for aggregation
+	// Randomly select BLS signature shares for aggregation, which all aggregate into the same signature
 	selected := make([]int, numnodes)
+	var firstAggsig []byte
 	for c := 0; c < 20; c++ {
 		check := make(map[int]bool)
 		for len(check) < Degree+1 {
@@ -96,5 +97,9 @@ func TestTBLS(t *testing.T) {
 		require.NoError(err)
 		err = BLS.VerifyAggregate(selectedID, selectedPK, message, aggsig)
 		require.NoError(err)
+		if firstAggsig == nil {
+			firstAggsig = aggsig
+		}
+		require.Equal(firstAggsig, aggsig)
 	}
 }
@@ -32,14 +32,18 @@ func Sort(hashes [][]byte, nonce uint64) {
 	})
 }
 
-// SortCandidates sorts a given slices of hashes cryptographically using blake2b hash function
-func SortCandidates(candidates []string, epochNum uint64) {
+// SortCandidates sorts a given slices of hashes cryptographically using blake2b hash function. The seed is the random
+// beacon of the epoch, and the hardcoded one is used if it's empty.
+func SortCandidates(candidates []string, epochNum uint64, seed []byte) {
 	nb := make([]byte, 8)
 	enc.MachineEndian.PutUint64(nb, epochNum)
+	if len(seed) == 0 {
+		seed = cryptoSeed
+	}
 
 	sort.Slice(candidates[:], func(i, j int) bool {
-		hi := blake2b.Sum256(append(append([]byte(candidates[i]), cryptoSeed...), nb...))
-		hj := blake2b.Sum256(append(append([]byte(candidates[j]), cryptoSeed...), nb...))
+		hi := blake2b.Sum256(append(append([]byte(candidates[i]), seed...), nb...))
+		hj := blake2b.Sum256(append(append([]byte(candidates[j]), seed...), nb...))
 		return bytes.Compare(hi[:], hj[:]) < 0
 	})
 }
@@ -38,3 +38,23 @@ func TestCryptoSort(t *testing.T) {
 	}
 	assert.False(t, same)
 }
+
+func TestSortCandidates(t *testing.T) {
+	var candidates []string
+	for i := 0; i < 21; i++ {
+		candidates = append(candidates, "candidate"+string(rune('a'+i)))
+	}
+	withoutSeed := make([]string, len(candidates))
+	copy(withoutSeed, candidates)
+	SortCandidates(withoutSeed, 1, nil)
+	withHardcodedSeed := make([]string, len(candidates))
+	copy(withHardcodedSeed, candidates)
+	SortCandidates(withHardcodedSeed, 1, cryptoSeed)
+	assert.Equal(t, withoutSeed, withHardcodedSeed)
+
+	withSeed := make([]string, len(candidates))
+	copy(withSeed, candidates)
+	SortCandidates(withSeed, 1, []byte{0x01, 0x02, 0x03})
+	assert.ElementsMatch(t, withoutSeed, withSeed)
+	assert.NotEqual(t, withoutSeed, withSeed)
+}
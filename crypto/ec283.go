@@ -98,6 +98,33 @@ func (c *ec283) Verify(pub keypair.PublicKey, msg []byte, sig []byte) bool {
 	return C.ECDSA_verify(&pubKey, (*C.uint8_t)(&msg[0]), (C.uint64_t)(len(msgString)), &signature) == 1
 }
 
+// SharedKey derives the Diffie-Hellman shared key of the private key and the other party's public key, which is the
+// x-coordinate of their product
+func (c *ec283) SharedKey(priv keypair.PrivateKey, pub keypair.PublicKey) ([]byte, error) {
+	privKey, err := c.privateKeyDeserialization(priv)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := c.publicKeyDeserialization(pub)
+	if err != nil {
+		return nil, err
+	}
+	if C.pk_validation(&pubKey) == 0 {
+		return nil, errors.New("invalid public key")
+	}
+	var shared C.ec283_point_lambda_aff
+	C.TNAF5_random_scalarmul(&privKey[0], &pubKey, &shared)
+	var x [9]uint32
+	for i := 0; i < 9; i++ {
+		x[i] = (uint32)(shared.x[i])
+	}
+	buf := new(bytes.Buffer)
+	if err := binary.Write(buf, enc.MachineEndian, x); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func (*ec283) publicKeySerialization(pubKey C.ec283_point_lambda_aff) (keypair.PublicKey, error) {
 	var xl [18]uint32
 	for i := 0; i < 9; i++ {
@@ -40,3 +40,23 @@ func TestPubKeyGeneration(t *testing.T) {
 	wrongMessage := []byte("wrong message")
 	require.False(EC283.Verify(actualPuk, wrongMessage, sig))
 }
+
+func TestSharedKey(t *testing.T) {
+	require := require.New(t)
+	pub1, pri1, err := EC283.NewKeyPair()
+	require.NoError(err)
+	pub2, pri2, err := EC283.NewKeyPair()
+	require.NoError(err)
+
+	key1, err := EC283.SharedKey(pri1, pub2)
+	require.NoError(err)
+	key2, err := EC283.SharedKey(pri2, pub1)
+	require.NoError(err)
+	require.Equal(key1, key2)
+
+	pub3, _, err := EC283.NewKeyPair()
+	require.NoError(err)
+	key3, err := EC283.SharedKey(pri1, pub3)
+	require.NoError(err)
+	require.NotEqual(key1, key3)
+}
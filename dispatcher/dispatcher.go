@@ -9,6 +9,7 @@ package dispatcher
 import (
 	"context"
 	"net"
+	"strconv"
 	"sync"
 	"sync/atomic"
 
@@ -22,109 +23,231 @@ import (
 	pb "github.com/iotexproject/iotex-core/proto"
 )
 
-// Subscriber is the dispatcher subscriber interface
-type Subscriber interface {
-	HandleAction(*pb.ActionPb) error
-	HandleBlock(*pb.BlockPb) error
-	HandleBlockSync(*pb.BlockPb) error
-	HandleSyncRequest(string, *pb.BlockSync) error
-	HandleBlockPropose(*pb.ProposePb) error
-	HandleEndorse(*pb.EndorsePb) error
-}
+// ErrInvalidMsg indicates that the message sent by the peer is invalid. The sender of the message whose handler fails
+// with it is reported to the peer reporter.
+var ErrInvalidMsg = errors.New("invalid message")
+
+// Handler handles a message of the type which it is registered for. The sender is the address of the peer which tells
+// the message, and is empty for a broadcast message.
+type Handler func(sender string, msg proto.Message) error
+
+// PeerReporter is notified of the peer which has sent the data failing to be handled
+type PeerReporter func(sender string, err error)
+
+// Lane is the class of messages, each of which is queued separately
+type Lane int
+
+const (
+	// ConsensusLane queues the consensus messages, which are handled in order
+	ConsensusLane Lane = iota
+	// BlockLane queues the committed blocks, which are handled in order
+	BlockLane
+	// SyncLane queues the block sync requests and data, which are handled in order
+	SyncLane
+	// ActionLane queues the actions, which are handled concurrently, and dropped when the lane is full
+	ActionLane
+	numLanes
+)
+
+var laneNames = [numLanes]string{"consensus", "block", "sync", "action"}
 
 // Dispatcher is used by peers, handles incoming block and header notifications and relays announcements of new blocks.
 type Dispatcher interface {
 	lifecycle.StartStopper
 
-	// AddSubscriber adds to dispatcher
-	AddSubscriber(uint32, Subscriber)
+	// RegisterHandler registers the handler of the message type on the chain, and the lane in which the messages are
+	// queued
+	RegisterHandler(chainID uint32, msgType uint32, lane Lane, handler Handler) error
 	// HandleBroadcast handles the incoming broadcast message. The transportation layer semantics is at least once.
 	// That said, the handler is likely to receive duplicate messages.
 	HandleBroadcast(uint32, proto.Message, chan bool)
 	// HandleTell handles the incoming tell message. The transportation layer semantics is exact once. The sender is
 	// given for the sake of replying the message
 	HandleTell(uint32, net.Addr, proto.Message, chan bool)
+	// SetPeerReporter sets the reporter of the peers which have sent the invalid data
+	SetPeerReporter(PeerReporter)
 }
 
-var requestMtc = prometheus.NewCounterVec(
-	prometheus.CounterOpts{
-		Name: "iotex_dispatch_request",
-		Help: "Dispatcher request counter.",
-	},
-	[]string{"method", "succeed"},
+var (
+	requestMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_dispatch_request",
+			Help: "Dispatcher request counter.",
+		},
+		[]string{"method", "succeed"},
+	)
+	droppedEventMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_dispatch_dropped_event",
+			Help: "Counter of the events dropped by the dispatcher when the lane is full.",
+		},
+		[]string{"lane"},
+	)
+	pendingEventMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_dispatch_pending_event",
+			Help: "Number of the events pending in the dispatcher lane.",
+		},
+		[]string{"lane"},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(requestMtc)
+	prometheus.MustRegister(droppedEventMtc)
+	prometheus.MustRegister(pendingEventMtc)
 }
 
-// blockMsg packages a proto block message.
-type blockMsg struct {
-	chainID uint32
-	block   *pb.BlockPb
-	blkType uint32
-	done    chan bool
+// lane is the bounded queue of a class of messages. The messages of an ordered lane are handled one at a time in the
+// order that they are queued, while those of an unordered lane could be handled by the workers concurrently.
+type lane struct {
+	name    string
+	queue   chan interface{}
+	ordered bool
+	// busy is set when a worker is handling a message of the ordered lane
+	busy int32
+	// droppable is true if a message is dropped instead of waiting for room when the lane is full
+	droppable bool
+}
+
+// take returns the next message of the lane if there is one, and it could be handled now
+func (l *lane) take() (interface{}, bool) {
+	if l.ordered && !atomic.CompareAndSwapInt32(&l.busy, 0, 1) {
+		return nil, false
+	}
+	select {
+	case m := <-l.queue:
+		return m, true
+	default:
+		l.done()
+		return nil, false
+	}
 }
 
-func (m blockMsg) ChainID() uint32 {
-	return m.chainID
+// done marks that the message taken from the lane has been handled
+func (l *lane) done() {
+	if l.ordered {
+		atomic.StoreInt32(&l.busy, 0)
+	}
 }
 
-// blockSyncMsg packages a proto block sync message.
-type blockSyncMsg struct {
-	chainID uint32
-	sender  string
-	sync    *pb.BlockSync
-	done    chan bool
+// newSchedule spreads the turns of the lanes over a round in proportion to their weights, by the smooth weighted
+// round-robin, so that a heavy lane doesn't take all its turns in a row
+func newSchedule(weights []uint) []Lane {
+	var total int
+	for _, w := range weights {
+		total += int(w)
+	}
+	current := make([]int, len(weights))
+	schedule := make([]Lane, 0, total)
+	for i := 0; i < total; i++ {
+		best := 0
+		for j, w := range weights {
+			current[j] += int(w)
+			if current[j] > current[best] {
+				best = j
+			}
+		}
+		current[best] -= total
+		schedule = append(schedule, Lane(best))
+	}
+	return schedule
 }
 
-func (m blockSyncMsg) ChainID() uint32 {
-	return m.chainID
+// route is where the messages of a type on a chain go
+type route struct {
+	lane    Lane
+	handler Handler
 }
 
-// actionMsg packages a proto action message.
-type actionMsg struct {
+// message packages a proto message with the handler which it is routed to.
+type message struct {
 	chainID uint32
-	action  *pb.ActionPb
+	msgType uint32
+	sender  string
+	msg     proto.Message
+	handler Handler
 	done    chan bool
 }
 
-func (m actionMsg) ChainID() uint32 {
-	return m.chainID
-}
-
-// IotxDispatcher is the request and event dispatcher for iotx node.
+// IotxDispatcher is the request and event dispatcher for iotx node. The messages are routed to the handlers registered
+// by their chain IDs and types. They are queued in the lanes of the handlers, so that a flood of actions doesn't delay
+// the consensus and the blocks, and the workers take them from the lanes by the weighted schedule.
 type IotxDispatcher struct {
 	started        int32
 	shutdown       int32
-	eventChan      chan interface{}
+	lanes          [numLanes]*lane
+	schedule       []Lane
+	tick           uint64
+	workers        int
+	notify         chan struct{}
 	eventAudit     map[uint32]int
+	droppedAudit   map[uint32]int
 	eventAuditLock sync.RWMutex
 	wg             sync.WaitGroup
 	quit           chan struct{}
 
-	subscribers map[uint32]Subscriber
+	routes       map[uint32]map[uint32]route
+	routesLock   sync.RWMutex
+	reportPeerFn PeerReporter
 }
 
 // NewDispatcher creates a new Dispatcher
 func NewDispatcher(
 	cfg *config.Config,
 ) (Dispatcher, error) {
+	laneCfgs := [numLanes]config.DispatcherLane{
+		ConsensusLane: cfg.Dispatcher.ConsensusLane,
+		BlockLane:     cfg.Dispatcher.BlockLane,
+		SyncLane:      cfg.Dispatcher.SyncLane,
+		ActionLane:    cfg.Dispatcher.ActionLane,
+	}
 	d := &IotxDispatcher{
-		eventChan:   make(chan interface{}, cfg.Dispatcher.EventChanSize),
-		eventAudit:  make(map[uint32]int),
-		quit:        make(chan struct{}),
-		subscribers: make(map[uint32]Subscriber),
+		workers:      int(cfg.Dispatcher.Workers),
+		notify:       make(chan struct{}, cfg.Dispatcher.Workers),
+		eventAudit:   make(map[uint32]int),
+		droppedAudit: make(map[uint32]int),
+		quit:         make(chan struct{}),
+		routes:       make(map[uint32]map[uint32]route),
+	}
+	weights := make([]uint, numLanes)
+	for i, laneCfg := range laneCfgs {
+		d.lanes[i] = &lane{
+			name:      laneNames[i],
+			queue:     make(chan interface{}, laneCfg.QueueSize),
+			ordered:   Lane(i) != ActionLane,
+			droppable: Lane(i) == ActionLane,
+		}
+		weights[i] = laneCfg.Weight
 	}
+	d.schedule = newSchedule(weights)
 	return d, nil
 }
 
-// AddSubscriber adds a subscriber to dispatcher
-func (d *IotxDispatcher) AddSubscriber(
-	chainID uint32,
-	subscriber Subscriber,
-) {
-	d.subscribers[chainID] = subscriber
+// RegisterHandler registers the handler of the message type on the chain, and the lane in which the messages are
+// queued. A message type could only be handled by one handler on a chain.
+func (d *IotxDispatcher) RegisterHandler(chainID uint32, msgType uint32, lane Lane, handler Handler) error {
+	if lane < 0 || lane >= numLanes {
+		return errors.Errorf("unknown dispatcher lane %d", lane)
+	}
+	d.routesLock.Lock()
+	defer d.routesLock.Unlock()
+
+	routes, ok := d.routes[chainID]
+	if !ok {
+		routes = make(map[uint32]route)
+		d.routes[chainID] = routes
+	}
+	if _, ok := routes[msgType]; ok {
+		return errors.Errorf("handler of message type %d on chain %d has already been registered", msgType, chainID)
+	}
+	routes[msgType] = route{lane: lane, handler: handler}
+	return nil
+}
+
+// SetPeerReporter sets the reporter of the peers which have sent the invalid data
+func (d *IotxDispatcher) SetPeerReporter(reporter PeerReporter) {
+	d.reportPeerFn = reporter
 }
 
 // Start starts the dispatcher.
@@ -132,9 +255,11 @@ func (d *IotxDispatcher) Start(ctx context.Context) error {
 	if atomic.AddInt32(&d.started, 1) != 1 {
 		return errors.New("Dispatcher already started")
 	}
-	logger.Info().Msg("Starting dispatcher")
-	d.wg.Add(1)
-	go d.newsHandler()
+	logger.Info().Int("workers", d.workers).Msg("Starting dispatcher")
+	for i := 0; i < d.workers; i++ {
+		d.wg.Add(1)
+		go d.newsHandler()
+	}
 	return nil
 }
 
@@ -150,9 +275,13 @@ func (d *IotxDispatcher) Stop(ctx context.Context) error {
 	return nil
 }
 
-// EventChan returns the event chan
-func (d *IotxDispatcher) EventChan() *chan interface{} {
-	return &d.eventChan
+// PendingEvents returns the number of the events pending in each lane
+func (d *IotxDispatcher) PendingEvents() map[string]int {
+	pending := make(map[string]int)
+	for _, l := range d.lanes {
+		pending[l.name] = len(l.queue)
+	}
+	return pending
 }
 
 // EventAudit returns the event audit map
@@ -166,220 +295,169 @@ func (d *IotxDispatcher) EventAudit() map[uint32]int {
 	return snapshot
 }
 
-// newsHandler is the main handler for handling all news from peers.
+// DroppedEventAudit returns the number of the events of each message type dropped when the lane is full
+func (d *IotxDispatcher) DroppedEventAudit() map[uint32]int {
+	d.eventAuditLock.RLock()
+	defer d.eventAuditLock.RUnlock()
+	snapshot := make(map[uint32]int)
+	for k, v := range d.droppedAudit {
+		snapshot[k] = v
+	}
+	return snapshot
+}
+
+// newsHandler is the worker handling the news from peers. It handles the messages one after another as long as any
+// lane has one, and waits for the notification of the new messages otherwise.
 func (d *IotxDispatcher) newsHandler() {
-loop:
+	defer d.wg.Done()
 	for {
 		select {
-		case m := <-d.eventChan:
-			switch msg := m.(type) {
-			case *actionMsg:
-				d.handleActionMsg(msg)
-			case *blockMsg:
-				d.handleBlockMsg(msg)
-			case *blockSyncMsg:
-				d.handleBlockSyncMsg(msg)
-
-			default:
-				logger.Warn().
-					Str("msg", msg.(string)).
-					Msg("Invalid message type in block handler")
-			}
-
 		case <-d.quit:
-			break loop
+			logger.Info().Msg("News handler done")
+			return
+		default:
+		}
+		if m, l, ok := d.next(); ok {
+			d.handleMsg(m.(*message))
+			l.done()
+			continue
+		}
+		select {
+		case <-d.notify:
+		case <-d.quit:
+			logger.Info().Msg("News handler done")
+			return
 		}
 	}
-
-	d.wg.Done()
-	logger.Info().Msg("News handler done")
 }
 
-// handleActionMsg handles actionMsg from all peers.
-func (d *IotxDispatcher) handleActionMsg(m *actionMsg) {
-	d.updateEventAudit(pb.MsgActionType)
-	if subscriber, ok := d.subscribers[m.ChainID()]; ok {
-		if err := subscriber.HandleAction(m.action); err != nil {
-			requestMtc.WithLabelValues("AddAction", "false").Inc()
-			logger.Debug().Err(err)
+// next takes the message from the lane of the coming turn in the schedule, or from the following lanes if the lane
+// has no message to be handled now
+func (d *IotxDispatcher) next() (interface{}, *lane, bool) {
+	start := atomic.AddUint64(&d.tick, 1) - 1
+	for i := 0; i < len(d.schedule); i++ {
+		l := d.lanes[d.schedule[(start+uint64(i))%uint64(len(d.schedule))]]
+		if m, ok := l.take(); ok {
+			pendingEventMtc.WithLabelValues(l.name).Set(float64(len(l.queue)))
+			return m, l, true
 		}
-	} else {
-		logger.Info().Uint32("ChainID", m.ChainID()).Msg("No subscriber specified in the dispatcher")
-	}
-	// signal to let caller know we are done
-	if m.done != nil {
-		m.done <- true
 	}
+	return nil, nil, false
 }
 
-// handleBlockMsg handles blockMsg from peers.
-func (d *IotxDispatcher) handleBlockMsg(m *blockMsg) {
-	if subscriber, ok := d.subscribers[m.ChainID()]; ok {
-		if m.blkType == pb.MsgBlockProtoMsgType {
-			d.updateEventAudit(pb.MsgBlockProtoMsgType)
-			if err := subscriber.HandleBlock(m.block); err != nil {
-				logger.Error().Err(err).Msg("Fail to handle the block")
-			}
-		} else if m.blkType == pb.MsgBlockSyncDataType {
-			d.updateEventAudit(pb.MsgBlockSyncDataType)
-			if err := subscriber.HandleBlockSync(m.block); err != nil {
-				logger.Error().Err(err).Msg("Fail to sync the block")
-			}
+// handleMsg passes the message to its handler, and reports the sender if the message is invalid
+func (d *IotxDispatcher) handleMsg(m *message) {
+	d.updateEventAudit(m.msgType)
+	method := strconv.FormatUint(uint64(m.msgType), 10)
+	if err := m.handler(m.sender, m.msg); err != nil {
+		requestMtc.WithLabelValues(method, "false").Inc()
+		logger.Debug().
+			Err(err).
+			Uint32("chainID", m.chainID).
+			Uint32("msgType", m.msgType).
+			Str("src", m.sender).
+			Msg("Failed to handle the message")
+		if m.sender != "" && errors.Cause(err) == ErrInvalidMsg {
+			d.reportPeer(m.sender, err)
 		}
 	} else {
-		logger.Info().Uint32("ChainID", m.ChainID()).Msg("No subscriber specified in the dispatcher")
+		requestMtc.WithLabelValues(method, "true").Inc()
 	}
-
 	// signal to let caller know we are done
 	if m.done != nil {
 		m.done <- true
 	}
 }
 
-// handleBlockSyncMsg handles block messages from peers.
-func (d *IotxDispatcher) handleBlockSyncMsg(m *blockSyncMsg) {
-	logger.Info().
-		Str("src", m.sender).
-		Uint64("start", m.sync.Start).
-		Uint64("end", m.sync.End).
-		Msg("receive blockSyncMsg")
-
-	d.updateEventAudit(pb.MsgBlockSyncReqType)
-	if subscriber, ok := d.subscribers[m.ChainID()]; ok {
-		// dispatch to block sync
-		if err := subscriber.HandleSyncRequest(m.sender, m.sync); err != nil {
-			logger.Error().Err(err)
-		}
-	} else {
-		logger.Info().Uint32("ChainID", m.ChainID()).Msg("No subscriber specified in the dispatcher")
+// dispatch routes the message to the handler registered for its type on the chain, and adds it to the lane of the
+// handler
+func (d *IotxDispatcher) dispatch(chainID uint32, sender string, msg proto.Message, done chan bool) {
+	msgType, err := pb.GetTypeFromProtoMsg(msg)
+	if err != nil {
+		logger.Warn().
+			Str("error", err.Error()).
+			Msg("unexpected message handled by dispatcher")
+		return
 	}
-	// signal to let caller know we are done
-	if m.done != nil {
-		m.done <- true
+	d.routesLock.RLock()
+	r, ok := d.routes[chainID][msgType]
+	d.routesLock.RUnlock()
+	if !ok {
+		logger.Warn().
+			Uint32("chainID", chainID).
+			Uint32("msgType", msgType).
+			Msg("no handler has been registered in dispatcher")
+		return
 	}
+	d.enqueueEvent(r.lane, &message{
+		chainID: chainID,
+		msgType: msgType,
+		sender:  sender,
+		msg:     msg,
+		handler: r.handler,
+		done:    done,
+	})
 }
 
-// dispatchAction adds the passed action message to the news handling queue.
-func (d *IotxDispatcher) dispatchAction(chainID uint32, msg proto.Message, done chan bool) {
-	if atomic.LoadInt32(&d.shutdown) != 0 {
-		if done != nil {
-			close(done)
-		}
-		return
-	}
-	d.enqueueEvent(&actionMsg{chainID, (msg).(*pb.ActionPb), done})
+// HandleBroadcast handles incoming broadcast message
+func (d *IotxDispatcher) HandleBroadcast(chainID uint32, message proto.Message, done chan bool) {
+	d.dispatch(chainID, "", message, done)
 }
 
-// dispatchBlockCommit adds the passed block message to the news handling queue.
-func (d *IotxDispatcher) dispatchBlockCommit(chainID uint32, msg proto.Message, done chan bool) {
-	if atomic.LoadInt32(&d.shutdown) != 0 {
-		if done != nil {
-			close(done)
-		}
-		return
-	}
-	d.enqueueEvent(&blockMsg{chainID, (msg).(*pb.BlockPb), pb.MsgBlockProtoMsgType, done})
+// HandleTell handles incoming unicast message
+func (d *IotxDispatcher) HandleTell(chainID uint32, sender net.Addr, message proto.Message, done chan bool) {
+	d.dispatch(chainID, sender.String(), message, done)
 }
 
-// dispatchBlockSyncReq adds the passed block sync request to the news handling queue.
-func (d *IotxDispatcher) dispatchBlockSyncReq(chainID uint32, sender string, msg proto.Message, done chan bool) {
-	if atomic.LoadInt32(&d.shutdown) != 0 {
-		if done != nil {
-			close(done)
-		}
-		return
+func (d *IotxDispatcher) reportPeer(sender string, err error) {
+	if d.reportPeerFn != nil {
+		d.reportPeerFn(sender, err)
 	}
-	d.enqueueEvent(&blockSyncMsg{chainID, sender, (msg).(*pb.BlockSync), done})
 }
 
-// dispatchBlockSyncData handles block sync data
-func (d *IotxDispatcher) dispatchBlockSyncData(chainID uint32, msg proto.Message, done chan bool) {
+// enqueueEvent adds the message into the lane. If the lane is full, the message is dropped when the lane is
+// droppable, or the caller waits for the room otherwise, which slows down the peer sending the messages too fast. The
+// done chan is closed if the message is not going to be handled.
+func (d *IotxDispatcher) enqueueEvent(laneID Lane, m *message) {
 	if atomic.LoadInt32(&d.shutdown) != 0 {
-		if done != nil {
-			close(done)
+		if m.done != nil {
+			close(m.done)
 		}
 		return
 	}
-	data := (msg).(*pb.BlockContainer)
-	d.enqueueEvent(&blockMsg{chainID, data.Block, pb.MsgBlockSyncDataType, done})
-}
-
-// HandleBroadcast handles incoming broadcast message
-func (d *IotxDispatcher) HandleBroadcast(chainID uint32, message proto.Message, done chan bool) {
-	msgType, err := pb.GetTypeFromProtoMsg(message)
-	if err != nil {
-		logger.Warn().
-			Str("error", err.Error()).
-			Msg("unexpected message handled by HandleBroadcast")
-	}
-	subscriber, ok := d.subscribers[chainID]
-	if !ok {
-		logger.Warn().
-			Uint32("chainID", chainID).
-			Msg("chainID has not been registered in dispatcher")
-		return
-	}
-
-	switch msgType {
-	case pb.MsgProposeProtoMsgType:
-		err := subscriber.HandleBlockPropose(message.(*pb.ProposePb))
-		if err != nil {
-			logger.Error().
-				Err(err).
-				Msg("failed to handle block propose")
-		}
-		if done != nil {
-			done <- true
-		}
-	case pb.MsgEndorseProtoMsgType:
-		err := subscriber.HandleEndorse(message.(*pb.EndorsePb))
-		if err != nil {
-			logger.Error().
-				Err(err).
-				Msg("failed to handle endorse")
+	l := d.lanes[laneID]
+	select {
+	case l.queue <- m:
+	default:
+		if l.droppable {
+			d.dropEvent(l, m)
+			return
 		}
-		if done != nil {
-			done <- true
+		select {
+		case l.queue <- m:
+		case <-d.quit:
+			if m.done != nil {
+				close(m.done)
+			}
+			return
 		}
-	case pb.MsgActionType:
-		d.dispatchAction(chainID, message, done)
-	case pb.MsgBlockProtoMsgType:
-		d.dispatchBlockCommit(chainID, message, done)
-	default:
-		logger.Warn().
-			Uint32("msgType", msgType).
-			Msg("unexpected msgType handled by HandleBroadcast")
-	}
-}
-
-// HandleTell handles incoming unicast message
-func (d *IotxDispatcher) HandleTell(chainID uint32, sender net.Addr, message proto.Message, done chan bool) {
-	msgType, err := pb.GetTypeFromProtoMsg(message)
-	if err != nil {
-		logger.Warn().
-			Str("error", err.Error()).
-			Msg("unexpected message handled by HandleTell")
 	}
-	switch msgType {
-	case pb.MsgBlockSyncReqType:
-		d.dispatchBlockSyncReq(chainID, sender.String(), message, done)
-	case pb.MsgBlockSyncDataType:
-		d.dispatchBlockSyncData(chainID, message, done)
+	pendingEventMtc.WithLabelValues(l.name).Set(float64(len(l.queue)))
+	select {
+	case d.notify <- struct{}{}:
 	default:
-		logger.Warn().
-			Uint32("msgType", msgType).
-			Msg("unexpected msgType handled by HandleTell")
 	}
 }
 
-func (d *IotxDispatcher) enqueueEvent(event interface{}) {
-	go func() {
-		if len(d.eventChan) == cap(d.eventChan) {
-			logger.Warn().Msg("dispatcher event chan is full, drop an event")
-			return
-		}
-		d.eventChan <- event
-	}()
+func (d *IotxDispatcher) dropEvent(l *lane, m *message) {
+	logger.Warn().Str("lane", l.name).Msg("dispatcher lane is full, drop an event")
+	droppedEventMtc.WithLabelValues(l.name).Inc()
+	d.eventAuditLock.Lock()
+	d.droppedAudit[m.msgType]++
+	d.eventAuditLock.Unlock()
+	if m.done != nil {
+		close(m.done)
+	}
 }
 
 func (d *IotxDispatcher) updateEventAudit(t uint32) {
@@ -9,10 +9,13 @@ package dispatcher
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/network/node"
@@ -22,11 +25,11 @@ import (
 func createDispatcher(t *testing.T, chainID uint32) Dispatcher {
 	cfg := &config.Config{
 		Consensus:  config.Consensus{Scheme: config.NOOPScheme},
-		Dispatcher: config.Dispatcher{EventChanSize: 1024},
+		Dispatcher: config.Default.Dispatcher,
 	}
 	dp, err := NewDispatcher(cfg)
 	assert.NoError(t, err)
-	dp.AddSubscriber(chainID, &DummySubscriber{})
+	registerDummyHandlers(t, dp, chainID)
 	return dp
 }
 
@@ -53,6 +56,9 @@ func setTestCase() []proto.Message {
 		&pb.BlockSync{},
 		&pb.BlockContainer{},
 		&pb.BlockContainer{Block: &pb.BlockPb{}},
+		&pb.BlockContainer{Blocks: []*pb.BlockPb{{}, {}}},
+		&pb.BlockContainer{Headers: []*pb.BlockHeaderPb{{}, {}}},
+		&pb.BlockContainer{Block: &pb.BlockPb{}, Snapshot: &pb.StateSnapshotPb{}},
 		&pb.TestPayload{},
 	}
 }
@@ -89,29 +95,210 @@ func TestHandleTell(t *testing.T) {
 	}
 }
 
-type DummySubscriber struct {
+func TestHandleTellReportPeer(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	d, err := NewDispatcher(&cfg)
+	require.NoError(err)
+	require.NoError(d.RegisterHandler(
+		config.Default.Chain.ID,
+		pb.MsgBlockSyncDataType,
+		SyncLane,
+		func(string, proto.Message) error { return errors.Wrap(ErrInvalidMsg, "invalid block") },
+	))
+	require.NoError(d.RegisterHandler(
+		config.Default.Chain.ID,
+		pb.MsgBlockSyncReqType,
+		SyncLane,
+		func(string, proto.Message) error { return errors.New("block not found") },
+	))
+	require.NoError(d.Start(ctx))
+	defer stopDispatcher(ctx, d, t)
+	reported := make(chan string, 2)
+	d.SetPeerReporter(func(sender string, err error) {
+		reported <- sender
+	})
+
+	// The sender of the request failing to be handled is not blamed, while the sender of the invalid block is
+	done := make(chan bool, 2)
+	sender := node.NewTCPNode("192.168.0.0:10000")
+	d.HandleTell(config.Default.Chain.ID, sender, &pb.BlockSync{}, done)
+	d.HandleTell(config.Default.Chain.ID, sender, &pb.BlockContainer{Block: &pb.BlockPb{}}, done)
+	<-done
+	<-done
+	require.Equal(sender.String(), <-reported)
+	require.Len(reported, 0)
 }
 
-func (s *DummySubscriber) HandleBlock(*pb.BlockPb) error {
-	return nil
+func TestRegisterHandler(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	d, err := NewDispatcher(&cfg)
+	require.NoError(err)
+	received := make(chan string, 2)
+	handler := func(sender string, msg proto.Message) error {
+		received <- sender
+		return nil
+	}
+	require.NoError(d.RegisterHandler(1, pb.TestPayloadType, BlockLane, handler))
+	require.NoError(d.RegisterHandler(2, pb.TestPayloadType, ActionLane, handler))
+	require.Error(d.RegisterHandler(1, pb.TestPayloadType, ActionLane, handler))
+	require.Error(d.RegisterHandler(3, pb.TestPayloadType, numLanes, handler))
+	require.NoError(d.Start(ctx))
+	defer stopDispatcher(ctx, d, t)
+
+	// The message is routed to the handler registered on its chain
+	done := make(chan bool, 1)
+	d.HandleTell(2, node.NewTCPNode("192.168.0.0:10000"), &pb.TestPayload{}, done)
+	<-done
+	require.Equal("192.168.0.0:10000", <-received)
+	d.HandleBroadcast(1, &pb.TestPayload{}, done)
+	<-done
+	require.Equal("", <-received)
+
+	// The message without the handler is ignored
+	d.HandleBroadcast(3, &pb.TestPayload{}, done)
+	d.HandleBroadcast(1, &pb.ActionPb{}, done)
+	require.Len(received, 0)
+	require.Equal(map[uint32]int{pb.TestPayloadType: 2}, d.(*IotxDispatcher).EventAudit())
 }
 
-func (s *DummySubscriber) HandleBlockSync(*pb.BlockPb) error {
-	return nil
+func TestLaneSchedule(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Dispatcher.ConsensusLane.Weight = 1
+	cfg.Dispatcher.SyncLane.Weight = 1
+	cfg.Dispatcher.BlockLane.Weight = 3
+	cfg.Dispatcher.ActionLane.Weight = 1
+	dp, err := NewDispatcher(&cfg)
+	require.NoError(err)
+	registerDummyHandlers(t, dp, config.Default.Chain.ID)
+	d := dp.(*IotxDispatcher)
+
+	// The turns are spread over a round in proportion to the weights
+	require.Equal([]Lane{BlockLane, ConsensusLane, BlockLane, SyncLane, ActionLane, BlockLane}, d.schedule)
+
+	// The turn of an empty lane passes on to the following lanes in the schedule
+	for i := 0; i < 10; i++ {
+		d.HandleBroadcast(config.Default.Chain.ID, &pb.ActionPb{}, nil)
+		d.HandleBroadcast(config.Default.Chain.ID, &pb.BlockPb{}, nil)
+	}
+	counts := make(map[Lane]int)
+	for i := 0; i < 12; i++ {
+		m, l, ok := d.next()
+		require.True(ok)
+		l.done()
+		switch m.(*message).msg.(type) {
+		case *pb.ActionPb:
+			counts[ActionLane]++
+		case *pb.BlockPb:
+			counts[BlockLane]++
+		}
+	}
+	require.Equal(4, counts[ActionLane])
+	require.Equal(8, counts[BlockLane])
+
+	// The messages of an ordered lane are handled one at a time
+	_, l, ok := d.next()
+	require.True(ok)
+	require.Equal(BlockLane, indexOfLane(d, l))
+	for i := 0; i < 6; i++ {
+		m, _, ok := d.next()
+		require.True(ok)
+		require.IsType(&pb.ActionPb{}, m.(*message).msg)
+	}
+	_, _, ok = d.next()
+	require.False(ok)
+	l.done()
+	_, _, ok = d.next()
+	require.True(ok)
 }
 
-func (s *DummySubscriber) HandleSyncRequest(string, *pb.BlockSync) error {
-	return nil
+func TestLaneBackpressure(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Dispatcher.ActionLane.QueueSize = 2
+	cfg.Dispatcher.BlockLane.QueueSize = 1
+	dp, err := NewDispatcher(&cfg)
+	require.NoError(err)
+	registerDummyHandlers(t, dp, config.Default.Chain.ID)
+	d := dp.(*IotxDispatcher)
+
+	// The action is dropped when the action lane is full
+	for i := 0; i < 3; i++ {
+		d.HandleBroadcast(config.Default.Chain.ID, &pb.ActionPb{}, nil)
+	}
+	done := make(chan bool, 1)
+	d.HandleBroadcast(config.Default.Chain.ID, &pb.ActionPb{}, done)
+	_, ok := <-done
+	require.False(ok)
+	require.Equal(map[uint32]int{pb.MsgActionType: 2}, d.DroppedEventAudit())
+	require.Equal(2, d.PendingEvents()["action"])
+
+	// The block waits for the room in the block lane instead of being dropped
+	d.HandleBroadcast(config.Default.Chain.ID, &pb.BlockPb{}, nil)
+	queued := make(chan bool, 1)
+	go func() {
+		d.HandleBroadcast(config.Default.Chain.ID, &pb.BlockPb{}, nil)
+		queued <- true
+	}()
+	select {
+	case <-queued:
+		require.Fail("the block is not blocked by the full lane")
+	case <-time.After(100 * time.Millisecond):
+	}
+	ctx := context.Background()
+	require.NoError(d.Start(ctx))
+	defer func() {
+		require.NoError(d.Stop(ctx))
+	}()
+	select {
+	case <-queued:
+	case <-time.After(5 * time.Second):
+		require.Fail("the block is not queued after the lane has room")
+	}
+	require.NoError(waitUntil(func() bool { return d.EventAudit()[pb.MsgBlockProtoMsgType] == 2 }))
+	require.Equal(2, d.EventAudit()[pb.MsgActionType])
 }
 
-func (s *DummySubscriber) HandleAction(*pb.ActionPb) error {
-	return nil
+func indexOfLane(d *IotxDispatcher, l *lane) Lane {
+	for i, lane := range d.lanes {
+		if lane == l {
+			return Lane(i)
+		}
+	}
+	return numLanes
 }
 
-func (s *DummySubscriber) HandleBlockPropose(*pb.ProposePb) error {
-	return nil
+func waitUntil(cond func() bool) error {
+	for i := 0; i < 500; i++ {
+		if cond() {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return errors.New("timed out")
 }
 
-func (s *DummySubscriber) HandleEndorse(*pb.EndorsePb) error {
-	return nil
+// registerDummyHandlers registers the handlers doing nothing for the messages of the chain
+func registerDummyHandlers(t *testing.T, d Dispatcher, chainID uint32) {
+	lanes := map[uint32]Lane{
+		pb.MsgProposeProtoMsgType: ConsensusLane,
+		pb.MsgEndorseProtoMsgType: ConsensusLane,
+		pb.MsgBlockProtoMsgType:   BlockLane,
+		pb.MsgBlockSyncReqType:    SyncLane,
+		pb.MsgBlockSyncDataType:   SyncLane,
+		pb.MsgActionType:          ActionLane,
+	}
+	for msgType, lane := range lanes {
+		assert.NoError(t, d.RegisterHandler(chainID, msgType, lane, func(string, proto.Message) error {
+			return nil
+		}))
+	}
 }
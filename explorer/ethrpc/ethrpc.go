@@ -0,0 +1,198 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package ethrpc serves a subset of the Ethereum JSON-RPC API, so that the web3 tooling could work against the EVM of
+// the chain
+package ethrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/dispatcher"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/network"
+)
+
+// The error codes defined by the JSON-RPC 2.0 spec, and the one that Ethereum clients use for the server errors
+const (
+	parseErrorCode     = -32700
+	invalidRequestCode = -32600
+	methodNotFoundCode = -32601
+	invalidParamsCode  = -32602
+	serverErrorCode    = -32000
+)
+
+// maxRequestBytes limits the size of a request body
+const maxRequestBytes = 5 * 1024 * 1024
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result"`
+}
+
+type errorResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   *Error          `json:"error"`
+}
+
+// Error is a JSON-RPC error
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func invalidParams(err error) *Error {
+	return &Error{Code: invalidParamsCode, Message: err.Error()}
+}
+
+// handler handles the positional params of a method
+type handler func(params []json.RawMessage) (interface{}, error)
+
+// Server is the HTTP server of the Ethereum JSON-RPC API
+type Server struct {
+	cfg      config.Explorer
+	svc      *Service
+	handlers map[string]handler
+	httpSvr  *http.Server
+	port     int
+}
+
+// NewServer creates an Ethereum JSON-RPC server
+func NewServer(
+	cfg config.Explorer,
+	chain blockchain.Blockchain,
+	dispatcher dispatcher.Dispatcher,
+	p2p network.Overlay,
+) *Server {
+	svc := &Service{bc: chain, dp: dispatcher, p2p: p2p, cfg: cfg}
+	s := &Server{cfg: cfg, svc: svc}
+	s.handlers = map[string]handler{
+		"eth_blockNumber":           svc.blockNumber,
+		"eth_getBalance":            svc.getBalance,
+		"eth_getCode":               svc.getCode,
+		"eth_call":                  svc.call,
+		"eth_estimateGas":           svc.estimateGas,
+		"eth_getTransactionReceipt": svc.getTransactionReceipt,
+		"eth_getLogs":               svc.getLogs,
+		"eth_sendRawTransaction":    svc.sendRawTransaction,
+	}
+	s.httpSvr = &http.Server{Handler: s}
+	return s
+}
+
+// Start starts serving the JSON-RPC requests
+func (s *Server) Start(_ context.Context) error {
+	lis, err := net.Listen("tcp", ":"+strconv.Itoa(s.cfg.EthPort))
+	if err != nil {
+		return errors.Wrap(err, "error when creating the Ethereum JSON-RPC listener")
+	}
+	s.port = lis.Addr().(*net.TCPAddr).Port
+	logger.Info().Int("port", s.port).Msg("Starting Ethereum JSON-RPC server")
+	go func() {
+		if err := s.httpSvr.Serve(lis); err != nil && err != http.ErrServerClosed {
+			logger.Error().Err(err).Msg("error when serving Ethereum JSON-RPC requests")
+		}
+	}()
+	return nil
+}
+
+// Stop stops the server
+func (s *Server) Stop(ctx context.Context) error {
+	if err := s.httpSvr.Shutdown(ctx); err != nil {
+		return errors.Wrap(err, "error when shutting down Ethereum JSON-RPC server")
+	}
+	return nil
+}
+
+// Port returns the port that the server is actually bound to
+func (s *Server) Port() int {
+	return s.port
+}
+
+// ServeHTTP handles a single request or a batch of requests
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	var res interface{}
+	if body = bytes.TrimSpace(body); len(body) > 0 && body[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err != nil || len(batch) == 0 {
+			res = errorResponse{JSONRPC: "2.0", Error: &Error{Code: invalidRequestCode, Message: "invalid batch"}}
+		} else {
+			results := make([]interface{}, 0, len(batch))
+			for _, req := range batch {
+				results = append(results, s.handle(req))
+			}
+			res = results
+		}
+	} else {
+		res = s.handle(body)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		logger.Error().Err(err).Msg("error when writing Ethereum JSON-RPC response")
+	}
+}
+
+func (s *Server) handle(raw json.RawMessage) interface{} {
+	var req request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return errorResponse{JSONRPC: "2.0", Error: &Error{Code: parseErrorCode, Message: err.Error()}}
+	}
+	h, ok := s.handlers[req.Method]
+	if !ok {
+		return errorResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error:   &Error{Code: methodNotFoundCode, Message: "method " + req.Method + " is not supported"},
+		}
+	}
+	var params []json.RawMessage
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return errorResponse{JSONRPC: "2.0", ID: req.ID, Error: invalidParams(err)}
+		}
+	}
+	result, err := h(params)
+	if err != nil {
+		rpcErr, ok := errors.Cause(err).(*Error)
+		if !ok {
+			rpcErr = &Error{Code: serverErrorCode, Message: err.Error()}
+		}
+		return errorResponse{JSONRPC: "2.0", ID: req.ID, Error: rpcErr}
+	}
+	return response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
@@ -0,0 +1,314 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package ethrpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CoderZhi/go-ethereum/common"
+	"github.com/CoderZhi/go-ethereum/core/types"
+	"github.com/CoderZhi/go-ethereum/rlp"
+	"github.com/facebookgo/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_dispatcher"
+	"github.com/iotexproject/iotex-core/test/mock/mock_network"
+	"github.com/iotexproject/iotex-core/test/mock/mock_state"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+type rpcResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+func call(t *testing.T, url string, method string, params ...interface{}) rpcResponse {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	require.NoError(t, err)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var res rpcResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	return res
+}
+
+func TestServer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	dp := mock_dispatcher.NewMockDispatcher(ctrl)
+	p2p := mock_network.NewMockOverlay(ctrl)
+	s := NewServer(config.Default.Explorer, bc, dp, p2p)
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	bc.EXPECT().TipHeight().Return(uint64(26)).AnyTimes()
+	res := call(t, ts.URL, "eth_blockNumber")
+	require.Nil(res.Error)
+	require.Equal(`"0x1a"`, string(res.Result))
+
+	res = call(t, ts.URL, "eth_mining")
+	require.Equal(methodNotFoundCode, res.Error.Code)
+
+	// The balance of the account is looked up by the IoTeX address mapped from the EVM address
+	producer := ta.Addrinfo["producer"].RawAddress
+	evmAddr, err := toEthAddress(producer)
+	require.NoError(err)
+	balance, ok := new(big.Int).SetString("123456789012345678901234567890", 10)
+	require.True(ok)
+	bc.EXPECT().StateByAddr(producer).Return(&state.State{Balance: balance}, nil)
+	res = call(t, ts.URL, "eth_getBalance", evmAddr.Hex(), "latest")
+	require.Nil(res.Error)
+	require.Equal(`"0x18ee90ff6c373e0ee4e3f0ad2"`, string(res.Result))
+	res = call(t, ts.URL, "eth_getBalance", evmAddr.Hex(), "0x1")
+	require.Equal(serverErrorCode, res.Error.Code)
+	require.Equal(ErrStateNotAvailable.Error(), res.Error.Message)
+	res = call(t, ts.URL, "eth_getBalance", "0x1234")
+	require.Equal(invalidParamsCode, res.Error.Code)
+
+	// Only the account which doesn't exist has zero balance, while the other failures are reported
+	bc.EXPECT().StateByAddr(producer).Return(nil, errors.Wrap(state.ErrAccountNotExist, "failed to get the state"))
+	res = call(t, ts.URL, "eth_getBalance", evmAddr.Hex(), "latest")
+	require.Nil(res.Error)
+	require.Equal(`"0x0"`, string(res.Result))
+	bc.EXPECT().StateByAddr(producer).Return(nil, errors.New("db is closed"))
+	res = call(t, ts.URL, "eth_getBalance", evmAddr.Hex(), "latest")
+	require.Equal(serverErrorCode, res.Error.Code)
+
+	// The account which doesn't exist or isn't a contract has no code
+	sf := mock_state.NewMockFactory(ctrl)
+	bc.EXPECT().GetFactory().Return(sf).AnyTimes()
+	sf.EXPECT().GetCodeHash(gomock.Any()).Return(hash.ZeroHash32B, errors.Wrap(state.ErrAccountNotExist, "no state"))
+	res = call(t, ts.URL, "eth_getCode", evmAddr.Hex(), "latest")
+	require.Nil(res.Error)
+	require.Equal(`"0x"`, string(res.Result))
+	sf.EXPECT().GetCodeHash(gomock.Any()).Return(hash.ZeroHash32B, nil)
+	res = call(t, ts.URL, "eth_getCode", evmAddr.Hex(), "latest")
+	require.Nil(res.Error)
+	require.Equal(`"0x"`, string(res.Result))
+	sf.EXPECT().GetCodeHash(gomock.Any()).Return(hash.Hash32B{1}, nil)
+	sf.EXPECT().GetCode(gomock.Any()).Return([]byte{0x60, 0x80}, nil)
+	res = call(t, ts.URL, "eth_getCode", evmAddr.Hex(), "latest")
+	require.Nil(res.Error)
+	require.Equal(`"0x6080"`, string(res.Result))
+	sf.EXPECT().GetCodeHash(gomock.Any()).Return(hash.ZeroHash32B, errors.New("db is closed"))
+	res = call(t, ts.URL, "eth_getCode", evmAddr.Hex(), "latest")
+	require.Equal(serverErrorCode, res.Error.Code)
+
+	// The unknown transaction has a null receipt
+	bc.EXPECT().GetReceiptByExecutionHash(gomock.Any()).Return(nil, blockchain.ErrInvalidBlock)
+	res = call(t, ts.URL, "eth_getTransactionReceipt", common.Hash{}.Hex())
+	require.Nil(res.Error)
+	require.Equal("null", string(res.Result))
+
+	// The raw transaction is a serialized action
+	tsf, err := action.NewTransfer(1, big.NewInt(10), producer, ta.Addrinfo["alfa"].RawAddress, nil, 100000, big.NewInt(0))
+	require.NoError(err)
+	raw, err := proto.Marshal(tsf.ConvertToActionPb())
+	require.NoError(err)
+	bc.EXPECT().ChainID().Return(uint32(1))
+	p2p.EXPECT().Broadcast(uint32(1), gomock.Any()).Return(nil)
+	dp.EXPECT().HandleBroadcast(uint32(1), gomock.Any(), gomock.Any())
+	res = call(t, ts.URL, "eth_sendRawTransaction", "0x"+hex.EncodeToString(raw))
+	require.Nil(res.Error)
+	h := tsf.Hash()
+	require.Equal(fmt.Sprintf(`"0x%x"`, h[:]), string(res.Result))
+
+	// The RLP encoded Ethereum transaction is rejected
+	ethTx := types.NewTransaction(1, evmAddr, big.NewInt(10), 100000, big.NewInt(0), nil)
+	raw, err = rlp.EncodeToBytes(ethTx)
+	require.NoError(err)
+	res = call(t, ts.URL, "eth_sendRawTransaction", "0x"+hex.EncodeToString(raw))
+	require.Equal(invalidParamsCode, res.Error.Code)
+	require.Equal(ErrEthTxNotSupported.Error(), res.Error.Message)
+
+	// The transfer whose payload is longer than the limit is rejected
+	tsf, err = action.NewTransfer(
+		2,
+		big.NewInt(10),
+		producer,
+		ta.Addrinfo["alfa"].RawAddress,
+		make([]byte, config.Default.Explorer.MaxTransferPayloadBytes+1),
+		100000,
+		big.NewInt(0),
+	)
+	require.NoError(err)
+	raw, err = proto.Marshal(tsf.ConvertToActionPb())
+	require.NoError(err)
+	res = call(t, ts.URL, "eth_sendRawTransaction", "0x"+hex.EncodeToString(raw))
+	require.Equal(invalidParamsCode, res.Error.Code)
+
+	// The requests of a batch are answered in order
+	resp, err := http.Post(
+		ts.URL,
+		"application/json",
+		bytes.NewBufferString(`[{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"},{"jsonrpc":"2.0","id":2,"method":"x"}]`),
+	)
+	require.NoError(err)
+	defer resp.Body.Close()
+	var batch []rpcResponse
+	require.NoError(json.NewDecoder(resp.Body).Decode(&batch))
+	require.Equal(2, len(batch))
+	require.Equal(1, batch[0].ID)
+	require.Equal(`"0x1a"`, string(batch[0].Result))
+	require.Equal(methodNotFoundCode, batch[1].Error.Code)
+}
+
+func TestGetLogsAndReceipt(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	cfg := config.Default.Explorer
+	cfg.EthMaxLogBlockRange = 5
+	s := NewServer(cfg, bc, nil, nil)
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	executor := ta.Addrinfo["producer"].RawAddress
+	contract := ta.Addrinfo["alfa"].RawAddress
+	execution, err := action.NewExecution(executor, contract, 1, big.NewInt(0), 100000, big.NewInt(0), []byte{1})
+	require.NoError(err)
+	exHash := execution.Hash()
+	blkHash := hash.Hash32B{1}
+	topic := hash.Hash32B{2}
+	receipt := &blockchain.Receipt{
+		Status:      blockchain.SuccessStatus,
+		Hash:        exHash,
+		GasConsumed: 21000,
+		Logs: []*blockchain.Log{
+			{Address: contract, Topics: []hash.Hash32B{topic}, BlockNumber: 3, TxnHash: exHash, BlockHash: blkHash},
+			{Address: contract, BlockNumber: 3, TxnHash: exHash, BlockHash: blkHash},
+		},
+	}
+	// The logs of the second execution are indexed after the ones of the first
+	execution2, err := action.NewExecution(executor, contract, 2, big.NewInt(0), 100000, big.NewInt(0), []byte{2})
+	require.NoError(err)
+	ex2Hash := execution2.Hash()
+	receipt2 := &blockchain.Receipt{
+		Status:      blockchain.SuccessStatus,
+		Hash:        ex2Hash,
+		GasConsumed: 21000,
+		Logs:        []*blockchain.Log{{Address: contract, BlockNumber: 3, TxnHash: ex2Hash, BlockHash: blkHash}},
+	}
+	blk := blockchain.NewBlock(
+		1,
+		3,
+		hash.ZeroHash32B,
+		clock.New(),
+		nil,
+		nil,
+		[]*action.Execution{execution, execution2},
+	)
+	bc.EXPECT().TipHeight().Return(uint64(3)).AnyTimes()
+	// The block at the tip contains the execution, and the others are empty
+	bc.EXPECT().GetBlockByHeight(uint64(3)).Return(blk, nil).AnyTimes()
+	bc.EXPECT().GetBlockByHeight(gomock.Any()).Return(&blockchain.Block{}, nil).AnyTimes()
+	bc.EXPECT().GetReceiptByExecutionHash(exHash).Return(receipt, nil).AnyTimes()
+	bc.EXPECT().GetReceiptByExecutionHash(ex2Hash).Return(receipt2, nil).AnyTimes()
+
+	contractAddr, err := toEthAddress(contract)
+	require.NoError(err)
+	res := call(t, ts.URL, "eth_getLogs", map[string]interface{}{
+		"fromBlock": "earliest",
+		"address":   contractAddr.Hex(),
+		"topics":    []interface{}{common.BytesToHash(topic[:]).Hex()},
+	})
+	require.Nil(res.Error)
+	var logs []map[string]interface{}
+	require.NoError(json.Unmarshal(res.Result, &logs))
+	require.Equal(1, len(logs))
+	require.Equal("0x3", logs[0]["blockNumber"])
+	require.Equal(common.BytesToHash(exHash[:]).Hex(), logs[0]["transactionHash"])
+
+	res = call(t, ts.URL, "eth_getLogs", map[string]interface{}{"fromBlock": "0x0", "toBlock": "0x9"})
+	require.Nil(res.Error)
+	require.NoError(json.Unmarshal(res.Result, &logs))
+	require.Equal(3, len(logs))
+	for i, log := range logs {
+		require.Equal(fmt.Sprintf("0x%x", i), log["logIndex"])
+	}
+	require.Equal(common.BytesToHash(ex2Hash[:]).Hex(), logs[2]["transactionHash"])
+
+	cfg.EthMaxLogBlockRange = 2
+	s.svc.cfg = cfg
+	res = call(t, ts.URL, "eth_getLogs", map[string]interface{}{"fromBlock": "0x0"})
+	require.Equal(invalidParamsCode, res.Error.Code)
+
+	bc.EXPECT().GetExecutionByExecutionHash(exHash).Return(execution, nil)
+	bc.EXPECT().GetBlockHashByExecutionHash(exHash).Return(blkHash, nil)
+	bc.EXPECT().GetBlockByHash(blkHash).Return(blk, nil)
+	res = call(t, ts.URL, "eth_getTransactionReceipt", common.BytesToHash(exHash[:]).Hex())
+	require.Nil(res.Error)
+	var r struct {
+		Status           string                   `json:"status"`
+		TransactionIndex string                   `json:"transactionIndex"`
+		BlockNumber      string                   `json:"blockNumber"`
+		GasUsed          string                   `json:"gasUsed"`
+		From             common.Address           `json:"from"`
+		To               *common.Address          `json:"to"`
+		Logs             []map[string]interface{} `json:"logs"`
+	}
+	require.NoError(json.Unmarshal(res.Result, &r))
+	require.Equal("0x1", r.Status)
+	require.Equal("0x3", r.BlockNumber)
+	require.Equal("0x5208", r.GasUsed)
+	executorAddr, err := toEthAddress(executor)
+	require.NoError(err)
+	require.Equal(executorAddr, r.From)
+	require.Equal(contractAddr, *r.To)
+	require.Equal(2, len(r.Logs))
+	require.Equal("0x1", r.Logs[1]["logIndex"])
+
+	bc.EXPECT().GetExecutionByExecutionHash(ex2Hash).Return(execution2, nil)
+	bc.EXPECT().GetBlockHashByExecutionHash(ex2Hash).Return(blkHash, nil)
+	bc.EXPECT().GetBlockByHash(blkHash).Return(blk, nil)
+	res = call(t, ts.URL, "eth_getTransactionReceipt", common.BytesToHash(ex2Hash[:]).Hex())
+	require.Nil(res.Error)
+	require.NoError(json.Unmarshal(res.Result, &r))
+	require.Equal("0x1", r.TransactionIndex)
+	require.Equal(1, len(r.Logs))
+	require.Equal("0x2", r.Logs[0]["logIndex"])
+}
+
+func TestAddressMapping(t *testing.T) {
+	require := require.New(t)
+
+	raw := ta.Addrinfo["producer"].RawAddress
+	addr, err := toEthAddress(raw)
+	require.NoError(err)
+	back, err := toIotxAddress(addr)
+	require.NoError(err)
+	require.Equal(raw, back)
+
+	_, err = toEthAddress("io1invalid")
+	require.Error(err)
+}
@@ -0,0 +1,353 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package ethrpc
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/CoderZhi/go-ethereum/common"
+	"github.com/CoderZhi/go-ethereum/common/hexutil"
+	"github.com/CoderZhi/go-ethereum/core/types"
+	"github.com/CoderZhi/go-ethereum/rlp"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/dispatcher"
+	"github.com/iotexproject/iotex-core/network"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+var (
+	// ErrStateNotAvailable indicates that the state is queried at a height other than the tip
+	ErrStateNotAvailable = errors.New("only the state at the tip is available")
+	// ErrEthTxNotSupported indicates that a raw transaction is an RLP encoded Ethereum transaction, which can't be
+	// sent since the actions are signed with the IoTeX keys
+	ErrEthTxNotSupported = errors.New("RLP encoded Ethereum transactions are not supported, send a serialized action")
+)
+
+// Service implements the Ethereum JSON-RPC methods on top of the blockchain. The receipts and the logs are only
+// available if the explorer index is enabled.
+type Service struct {
+	bc  blockchain.Blockchain
+	dp  dispatcher.Dispatcher
+	p2p network.Overlay
+	cfg config.Explorer
+}
+
+// callArgs is the message call of eth_call and eth_estimateGas
+type callArgs struct {
+	From     *common.Address `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      *hexutil.Uint64 `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     hexutil.Bytes   `json:"data"`
+}
+
+// filterQuery is the filter of eth_getLogs. The address is either an address or a list of addresses, and each topic is
+// either null matching any topic, a topic, or a list of topics matching any of them.
+type filterQuery struct {
+	BlockHash *common.Hash      `json:"blockHash"`
+	FromBlock string            `json:"fromBlock"`
+	ToBlock   string            `json:"toBlock"`
+	Address   json.RawMessage   `json:"address"`
+	Topics    []json.RawMessage `json:"topics"`
+}
+
+// parseParams decodes the positional params into the values, of which those after the required ones are optional
+func parseParams(params []json.RawMessage, required int, values ...interface{}) error {
+	if len(params) < required || len(params) > len(values) {
+		return invalidParams(errors.Errorf("expected %d to %d params, got %d", required, len(values), len(params)))
+	}
+	for i, p := range params {
+		if err := json.Unmarshal(p, values[i]); err != nil {
+			return invalidParams(errors.Wrapf(err, "invalid param %d", i))
+		}
+	}
+	return nil
+}
+
+// blockHeight resolves a block tag or a hex encoded block number
+func (svc *Service) blockHeight(tag string) (uint64, error) {
+	switch tag {
+	case "", "latest", "pending":
+		return svc.bc.TipHeight(), nil
+	case "earliest":
+		return 0, nil
+	}
+	height, err := hexutil.DecodeUint64(tag)
+	if err != nil {
+		return 0, invalidParams(errors.Wrapf(err, "invalid block number %s", tag))
+	}
+	return height, nil
+}
+
+// checkTip checks that the block tag refers to the tip, since the state is not kept at the other heights
+func (svc *Service) checkTip(tag string) error {
+	height, err := svc.blockHeight(tag)
+	if err != nil {
+		return err
+	}
+	if height != svc.bc.TipHeight() {
+		return ErrStateNotAvailable
+	}
+	return nil
+}
+
+func (svc *Service) blockNumber(params []json.RawMessage) (interface{}, error) {
+	return hexutil.Uint64(svc.bc.TipHeight()), nil
+}
+
+func (svc *Service) getBalance(params []json.RawMessage) (interface{}, error) {
+	var (
+		addr common.Address
+		tag  string
+	)
+	if err := parseParams(params, 1, &addr, &tag); err != nil {
+		return nil, err
+	}
+	if err := svc.checkTip(tag); err != nil {
+		return nil, err
+	}
+	rawAddr, err := toIotxAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	// The account which has never been touched has no state, and has zero balance as in Ethereum
+	st, err := svc.bc.StateByAddr(rawAddr)
+	if errors.Cause(err) == state.ErrAccountNotExist {
+		return (*hexutil.Big)(big.NewInt(0)), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(st.Balance), nil
+}
+
+func (svc *Service) getCode(params []json.RawMessage) (interface{}, error) {
+	var (
+		addr common.Address
+		tag  string
+	)
+	if err := parseParams(params, 1, &addr, &tag); err != nil {
+		return nil, err
+	}
+	if err := svc.checkTip(tag); err != nil {
+		return nil, err
+	}
+	// The account which has never been touched or isn't a contract has no code
+	sf := svc.bc.GetFactory()
+	addrHash := byteutil.BytesTo20B(addr[:])
+	codeHash, err := sf.GetCodeHash(addrHash)
+	if errors.Cause(err) == state.ErrAccountNotExist {
+		return hexutil.Bytes{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if codeHash == hash.ZeroHash32B {
+		return hexutil.Bytes{}, nil
+	}
+	code, err := sf.GetCode(addrHash)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(code), nil
+}
+
+func (svc *Service) call(params []json.RawMessage) (interface{}, error) {
+	receipt, err := svc.runCall(params)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(receipt.ReturnValue), nil
+}
+
+func (svc *Service) estimateGas(params []json.RawMessage) (interface{}, error) {
+	receipt, err := svc.runCall(params)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Uint64(receipt.GasConsumed), nil
+}
+
+// runCall executes the message call at the tip without committing it
+func (svc *Service) runCall(params []json.RawMessage) (*blockchain.Receipt, error) {
+	var (
+		args callArgs
+		tag  string
+	)
+	if err := parseParams(params, 1, &args, &tag); err != nil {
+		return nil, err
+	}
+	if err := svc.checkTip(tag); err != nil {
+		return nil, err
+	}
+	execution, err := args.toExecution()
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	receipt, err := svc.bc.ExecuteContractRead(execution)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != blockchain.SuccessStatus {
+		return nil, errors.New("execution reverted or ran out of gas")
+	}
+	return receipt, nil
+}
+
+func (svc *Service) getTransactionReceipt(params []json.RawMessage) (interface{}, error) {
+	var txHash common.Hash
+	if err := parseParams(params, 1, &txHash); err != nil {
+		return nil, err
+	}
+	h := byteutil.BytesTo32B(txHash[:])
+	// The unknown transaction has a null receipt as in Ethereum
+	receipt, err := svc.bc.GetReceiptByExecutionHash(h)
+	if err != nil {
+		return nil, nil
+	}
+	execution, err := svc.bc.GetExecutionByExecutionHash(h)
+	if err != nil {
+		return nil, err
+	}
+	blkHash, err := svc.bc.GetBlockHashByExecutionHash(h)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := svc.bc.GetBlockByHash(blkHash)
+	if err != nil {
+		return nil, err
+	}
+	index, logIndex := 0, uint(0)
+	for i, ex := range blk.Executions {
+		if ex.Hash() == h {
+			index = i
+			break
+		}
+		// The failed execution may have no receipt, and then no logs
+		if r, err := svc.bc.GetReceiptByExecutionHash(ex.Hash()); err == nil {
+			logIndex += uint(len(r.Logs))
+		}
+	}
+	return toEthReceipt(receipt, execution, blk.Height(), blkHash, uint(index), logIndex)
+}
+
+func (svc *Service) getLogs(params []json.RawMessage) (interface{}, error) {
+	var query filterQuery
+	if err := parseParams(params, 1, &query); err != nil {
+		return nil, err
+	}
+	filter, err := query.toFilter()
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+	var from, to uint64
+	if query.BlockHash != nil {
+		if from, err = svc.bc.GetHeightByHash(byteutil.BytesTo32B(query.BlockHash[:])); err != nil {
+			return nil, err
+		}
+		to = from
+	} else {
+		if from, err = svc.blockHeight(query.FromBlock); err != nil {
+			return nil, err
+		}
+		if to, err = svc.blockHeight(query.ToBlock); err != nil {
+			return nil, err
+		}
+		if tip := svc.bc.TipHeight(); to > tip {
+			to = tip
+		}
+	}
+	if from > to {
+		return []*types.Log{}, nil
+	}
+	if to-from >= svc.cfg.EthMaxLogBlockRange {
+		return nil, invalidParams(errors.Errorf(
+			"the block range %d to %d exceeds the limit of %d blocks",
+			from,
+			to,
+			svc.cfg.EthMaxLogBlockRange,
+		))
+	}
+
+	logs := []*types.Log{}
+	for height := from; height <= to; height++ {
+		blk, err := svc.bc.GetBlockByHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		// The logs are indexed across all the executions of the block
+		var logIndex uint
+		for _, execution := range blk.Executions {
+			receipt, err := svc.bc.GetReceiptByExecutionHash(execution.Hash())
+			if err != nil {
+				// The failed execution may have no receipt
+				continue
+			}
+			for _, log := range receipt.Logs {
+				ethLog, err := toEthLog(log, logIndex)
+				if err != nil {
+					return nil, err
+				}
+				logIndex++
+				if filter.match(ethLog) {
+					logs = append(logs, ethLog)
+				}
+			}
+		}
+	}
+	return logs, nil
+}
+
+// sendRawTransaction takes the serialized ActionPb of a transfer or an execution, since the actions are signed with the
+// IoTeX keys instead of the Ethereum ones. An RLP encoded Ethereum transaction is rejected with ErrEthTxNotSupported,
+// since its sender recovered from the Ethereum signature has no IoTeX key to act with. As in the explorer, the payload
+// of a transfer can't be longer than the configured limit.
+func (svc *Service) sendRawTransaction(params []json.RawMessage) (interface{}, error) {
+	var raw hexutil.Bytes
+	if err := parseParams(params, 1, &raw); err != nil {
+		return nil, err
+	}
+	if err := rlp.DecodeBytes(raw, new(types.Transaction)); err == nil {
+		return nil, invalidParams(ErrEthTxNotSupported)
+	}
+	act := &pb.ActionPb{}
+	if err := proto.Unmarshal(raw, act); err != nil {
+		return nil, invalidParams(errors.Wrap(err, "failed to unmarshal the action"))
+	}
+	var h hash.Hash32B
+	switch {
+	case act.GetTransfer() != nil:
+		if err := action.ValidateTransferPayload(act.GetTransfer().Payload, svc.cfg.MaxTransferPayloadBytes); err != nil {
+			return nil, invalidParams(err)
+		}
+		tsf := &action.Transfer{}
+		tsf.ConvertFromActionPb(act)
+		h = tsf.Hash()
+	case act.GetExecution() != nil:
+		execution := &action.Execution{}
+		execution.ConvertFromActionPb(act)
+		h = execution.Hash()
+	default:
+		return nil, invalidParams(errors.New("action should be a transfer or an execution"))
+	}
+	chainID := svc.bc.ChainID()
+	if err := svc.p2p.Broadcast(chainID, act); err != nil {
+		return nil, err
+	}
+	svc.dp.HandleBroadcast(chainID, act, nil)
+	return common.BytesToHash(h[:]), nil
+}
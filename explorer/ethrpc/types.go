@@ -0,0 +1,227 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package ethrpc
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/CoderZhi/go-ethereum/common"
+	"github.com/CoderZhi/go-ethereum/common/hexutil"
+	"github.com/CoderZhi/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/action"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// receipt is the transaction receipt in the Ethereum shape
+type receipt struct {
+	TransactionHash   common.Hash     `json:"transactionHash"`
+	TransactionIndex  hexutil.Uint    `json:"transactionIndex"`
+	BlockHash         common.Hash     `json:"blockHash"`
+	BlockNumber       hexutil.Uint64  `json:"blockNumber"`
+	From              common.Address  `json:"from"`
+	To                *common.Address `json:"to"`
+	GasUsed           hexutil.Uint64  `json:"gasUsed"`
+	CumulativeGasUsed hexutil.Uint64  `json:"cumulativeGasUsed"`
+	ContractAddress   *common.Address `json:"contractAddress"`
+	Logs              []*types.Log    `json:"logs"`
+	LogsBloom         types.Bloom     `json:"logsBloom"`
+	Status            hexutil.Uint64  `json:"status"`
+}
+
+// logFilter matches the logs by the addresses and the topics
+type logFilter struct {
+	addresses []common.Address
+	topics    [][]common.Hash
+}
+
+// toEthAddress converts an IoTeX raw address into the 20-byte EVM address, in the same way as EVMStateDBAdapter
+func toEthAddress(rawAddr string) (common.Address, error) {
+	pkHash, err := iotxaddress.GetPubkeyHash(rawAddr)
+	if err != nil {
+		return common.Address{}, errors.Wrapf(err, "invalid address %s", rawAddr)
+	}
+	return common.BytesToAddress(pkHash), nil
+}
+
+// toIotxAddress converts a 20-byte EVM address into the IoTeX raw address, in the same way as EVMStateDBAdapter
+func toIotxAddress(addr common.Address) (string, error) {
+	iotxAddr, err := iotxaddress.GetAddressByHash(iotxaddress.IsTestnet, iotxaddress.ChainID, addr.Bytes())
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid address %s", addr.Hex())
+	}
+	return iotxAddr.RawAddress, nil
+}
+
+// toExecution converts the message call into an unsigned execution. The call without a recipient creates a contract.
+func (args *callArgs) toExecution() (*action.Execution, error) {
+	var from common.Address
+	if args.From != nil {
+		from = *args.From
+	}
+	executor, err := toIotxAddress(from)
+	if err != nil {
+		return nil, err
+	}
+	contract := action.EmptyAddress
+	if args.To != nil {
+		if contract, err = toIotxAddress(*args.To); err != nil {
+			return nil, err
+		}
+	}
+	gas := action.GasLimit
+	if args.Gas != nil {
+		gas = uint64(*args.Gas)
+	}
+	gasPrice := big.NewInt(0)
+	if args.GasPrice != nil {
+		gasPrice = args.GasPrice.ToInt()
+	}
+	amount := big.NewInt(0)
+	if args.Value != nil {
+		amount = args.Value.ToInt()
+	}
+	return action.NewExecution(executor, contract, 0, amount, gas, gasPrice, args.Data)
+}
+
+// toEthLog converts a log of an execution, whose index is its position among the logs of the block
+func toEthLog(log *blockchain.Log, index uint) (*types.Log, error) {
+	addr, err := toEthAddress(log.Address)
+	if err != nil {
+		return nil, err
+	}
+	topics := make([]common.Hash, 0, len(log.Topics))
+	for _, topic := range log.Topics {
+		topics = append(topics, common.BytesToHash(topic[:]))
+	}
+	return &types.Log{
+		Address:     addr,
+		Topics:      topics,
+		Data:        log.Data,
+		BlockNumber: log.BlockNumber,
+		TxHash:      common.BytesToHash(log.TxnHash[:]),
+		TxIndex:     log.Index,
+		BlockHash:   common.BytesToHash(log.BlockHash[:]),
+		Index:       index,
+	}, nil
+}
+
+// toEthReceipt converts the receipt of an execution. Since the gas isn't accumulated across the executions of a block,
+// the cumulative gas used is the gas used by the execution. The logs are indexed from the given log index, which is the
+// number of logs of the executions before it in the block.
+func toEthReceipt(
+	r *blockchain.Receipt,
+	execution *action.Execution,
+	height uint64,
+	blkHash hash.Hash32B,
+	index uint,
+	logIndex uint,
+) (*receipt, error) {
+	from, err := toEthAddress(execution.Executor())
+	if err != nil {
+		return nil, err
+	}
+	res := &receipt{
+		TransactionHash:   common.BytesToHash(r.Hash[:]),
+		TransactionIndex:  hexutil.Uint(index),
+		BlockHash:         common.BytesToHash(blkHash[:]),
+		BlockNumber:       hexutil.Uint64(height),
+		From:              from,
+		GasUsed:           hexutil.Uint64(r.GasConsumed),
+		CumulativeGasUsed: hexutil.Uint64(r.GasConsumed),
+		Logs:              make([]*types.Log, 0, len(r.Logs)),
+		Status:            hexutil.Uint64(r.Status),
+	}
+	if execution.Contract() != action.EmptyAddress {
+		to, err := toEthAddress(execution.Contract())
+		if err != nil {
+			return nil, err
+		}
+		res.To = &to
+	}
+	if r.ContractAddress != action.EmptyAddress {
+		contract, err := toEthAddress(r.ContractAddress)
+		if err != nil {
+			return nil, err
+		}
+		res.ContractAddress = &contract
+	}
+	for i, log := range r.Logs {
+		ethLog, err := toEthLog(log, logIndex+uint(i))
+		if err != nil {
+			return nil, err
+		}
+		res.Logs = append(res.Logs, ethLog)
+	}
+	res.LogsBloom = types.CreateBloom(types.Receipts{{Logs: res.Logs}})
+	return res, nil
+}
+
+// toFilter decodes the addresses and the topics of the query
+func (q *filterQuery) toFilter() (*logFilter, error) {
+	f := &logFilter{}
+	if len(q.Address) > 0 && string(q.Address) != "null" {
+		var addr common.Address
+		if err := json.Unmarshal(q.Address, &addr); err == nil {
+			f.addresses = []common.Address{addr}
+		} else if err := json.Unmarshal(q.Address, &f.addresses); err != nil {
+			return nil, errors.Wrap(err, "invalid address of the filter")
+		}
+	}
+	for i, raw := range q.Topics {
+		var topics []common.Hash
+		if len(raw) > 0 && string(raw) != "null" {
+			var topic common.Hash
+			if err := json.Unmarshal(raw, &topic); err == nil {
+				topics = []common.Hash{topic}
+			} else if err := json.Unmarshal(raw, &topics); err != nil {
+				return nil, errors.Wrapf(err, "invalid topic %d of the filter", i)
+			}
+		}
+		f.topics = append(f.topics, topics)
+	}
+	return f, nil
+}
+
+// match checks if the log is emitted by one of the addresses, and has the topics at the positions
+func (f *logFilter) match(log *types.Log) bool {
+	if len(f.addresses) > 0 {
+		found := false
+		for _, addr := range f.addresses {
+			if addr == log.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.topics) > len(log.Topics) {
+		return false
+	}
+	for i, topics := range f.topics {
+		if len(topics) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range topics {
+			if topic == log.Topics[i] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
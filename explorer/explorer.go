@@ -9,6 +9,8 @@ package explorer
 import (
 	"encoding/hex"
 	"math/big"
+	"sort"
+	"strconv"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -38,6 +40,9 @@ var (
 	ErrExecution = errors.New("invalid execution")
 	// ErrReceipt indicates the error of receipt
 	ErrReceipt = errors.New("invalid receipt")
+	// ErrInt64Overflow indicates that an amount overflows the int64 of a deprecated method, whose v2 method should be
+	// used instead
+	ErrInt64Overflow = errors.New("overflows int64, use the v2 method instead")
 )
 
 var (
@@ -71,27 +76,62 @@ func (exp *Service) GetBlockchainHeight() (int64, error) {
 }
 
 // GetAddressBalance returns the balance of an address
+//
+// Deprecated: the balance may overflow int64, use GetAddressBalanceV2 instead
 func (exp *Service) GetAddressBalance(address string) (int64, error) {
 	state, err := exp.bc.StateByAddr(address)
 	if err != nil {
 		return int64(0), err
 	}
+	if !state.Balance.IsInt64() {
+		return int64(0), errors.Wrap(ErrInt64Overflow, state.Balance.String())
+	}
 	return state.Balance.Int64(), nil
 }
 
+// GetAddressBalanceV2 returns the balance of an address as a decimal string
+func (exp *Service) GetAddressBalanceV2(address string) (string, error) {
+	state, err := exp.bc.StateByAddr(address)
+	if err != nil {
+		return "", err
+	}
+	return state.Balance.String(), nil
+}
+
 // GetAddressDetails returns the properties of an address
+//
+// Deprecated: the balance may overflow int64, use GetAddressDetailsV2 instead
 func (exp *Service) GetAddressDetails(address string) (explorer.AddressDetails, error) {
-	state, err := exp.bc.StateByAddr(address)
+	details, err := exp.GetAddressDetailsV2(address)
 	if err != nil {
 		return explorer.AddressDetails{}, err
 	}
+	totalBalance, err := stringToInt64(details.TotalBalance)
+	if err != nil {
+		return explorer.AddressDetails{}, errors.Wrap(err, "invalid balance")
+	}
+	return explorer.AddressDetails{
+		Address:      details.Address,
+		TotalBalance: totalBalance,
+		Nonce:        details.Nonce,
+		PendingNonce: details.PendingNonce,
+		IsCandidate:  details.IsCandidate,
+	}, nil
+}
+
+// GetAddressDetailsV2 returns the properties of an address, of which the balance is a decimal string
+func (exp *Service) GetAddressDetailsV2(address string) (explorer.AddressDetailsV2, error) {
+	state, err := exp.bc.StateByAddr(address)
+	if err != nil {
+		return explorer.AddressDetailsV2{}, err
+	}
 	pendingNonce, err := exp.ap.GetPendingNonce(address)
 	if err != nil {
-		return explorer.AddressDetails{}, err
+		return explorer.AddressDetailsV2{}, err
 	}
-	details := explorer.AddressDetails{
+	details := explorer.AddressDetailsV2{
 		Address:      address,
-		TotalBalance: (*state).Balance.Int64(),
+		TotalBalance: (*state).Balance.String(),
 		Nonce:        int64((*state).Nonce),
 		PendingNonce: int64(pendingNonce),
 		IsCandidate:  (*state).IsCandidate,
@@ -102,8 +142,20 @@ func (exp *Service) GetAddressDetails(address string) (explorer.AddressDetails,
 
 // GetLastTransfersByRange returns transfers in [-(offset+limit-1), -offset] from block
 // with height startBlockHeight
+//
+// Deprecated: the amounts may overflow int64, use GetLastTransfersByRangeV2 instead
 func (exp *Service) GetLastTransfersByRange(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) ([]explorer.Transfer, error) {
-	var res []explorer.Transfer
+	transfers, err := exp.GetLastTransfersByRangeV2(startBlockHeight, offset, limit, showCoinBase)
+	if err != nil {
+		return []explorer.Transfer{}, err
+	}
+	return convertTsfsV2ToTsfs(transfers)
+}
+
+// GetLastTransfersByRangeV2 returns transfers in [-(offset+limit-1), -offset] from block
+// with height startBlockHeight
+func (exp *Service) GetLastTransfersByRangeV2(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) ([]explorer.TransferV2, error) {
+	var res []explorer.TransferV2
 	transferCount := int64(0)
 
 ChainLoop:
@@ -111,13 +163,13 @@ ChainLoop:
 		var blkID string
 		hash, err := exp.bc.GetHashByHeight(uint64(height))
 		if err != nil {
-			return []explorer.Transfer{}, err
+			return []explorer.TransferV2{}, err
 		}
 		blkID = hex.EncodeToString(hash[:])
 
 		blk, err := exp.bc.GetBlockByHeight(uint64(height))
 		if err != nil {
-			return []explorer.Transfer{}, err
+			return []explorer.TransferV2{}, err
 		}
 
 		for i := len(blk.Transfers) - 1; i >= 0; i-- {
@@ -137,7 +189,7 @@ ChainLoop:
 
 				explorerTransfer, err := convertTsfToExplorerTsf(blk.Transfers[i], false)
 				if err != nil {
-					return []explorer.Transfer{}, errors.Wrapf(err, "failed to convert transfer %v to explorer's JSON transfer", blk.Transfers[i])
+					return []explorer.TransferV2{}, errors.Wrapf(err, "failed to convert transfer %v to explorer's JSON transfer", blk.Transfers[i])
 				}
 				explorerTransfer.Timestamp = int64(blk.ConvertToBlockHeaderPb().Timestamp)
 				explorerTransfer.BlockID = blkID
@@ -150,11 +202,22 @@ ChainLoop:
 }
 
 // GetTransferByID returns transfer by transfer id
+//
+// Deprecated: the amounts may overflow int64, use GetTransferByIDV2 instead
 func (exp *Service) GetTransferByID(transferID string) (explorer.Transfer, error) {
-	bytes, err := hex.DecodeString(transferID)
+	transfer, err := exp.GetTransferByIDV2(transferID)
 	if err != nil {
 		return explorer.Transfer{}, err
 	}
+	return convertTsfV2ToTsf(transfer)
+}
+
+// GetTransferByIDV2 returns transfer by transfer id
+func (exp *Service) GetTransferByIDV2(transferID string) (explorer.TransferV2, error) {
+	bytes, err := hex.DecodeString(transferID)
+	if err != nil {
+		return explorer.TransferV2{}, err
+	}
 	var transferHash hash.Hash32B
 	copy(transferHash[:], bytes)
 
@@ -162,31 +225,29 @@ func (exp *Service) GetTransferByID(transferID string) (explorer.Transfer, error
 }
 
 // GetTransfersByAddress returns all transfers associated with an address
+//
+// Deprecated: the amounts may overflow int64, use GetTransfersByAddressV2 instead
 func (exp *Service) GetTransfersByAddress(address string, offset int64, limit int64) ([]explorer.Transfer, error) {
-	var res []explorer.Transfer
-	transfersFromAddress, err := exp.bc.GetTransfersFromAddress(address)
+	transfers, err := exp.GetTransfersByAddressV2(address, offset, limit)
 	if err != nil {
 		return []explorer.Transfer{}, err
 	}
+	return convertTsfsV2ToTsfs(transfers)
+}
 
-	transfersToAddress, err := exp.bc.GetTransfersToAddress(address)
+// GetTransfersByAddressV2 returns all transfers associated with an address
+func (exp *Service) GetTransfersByAddressV2(address string, offset int64, limit int64) ([]explorer.TransferV2, error) {
+	var res []explorer.TransferV2
+	sender, recipient := transferIndexes(exp.bc)
+	transferHashes, err := getHashesByAddress(address, offset, limit, sender, recipient)
 	if err != nil {
-		return []explorer.Transfer{}, err
+		return []explorer.TransferV2{}, err
 	}
 
-	transfersFromAddress = append(transfersFromAddress, transfersToAddress...)
-	for i, transferHash := range transfersFromAddress {
-		if int64(i) < offset {
-			continue
-		}
-
-		if int64(len(res)) >= limit {
-			break
-		}
-
+	for _, transferHash := range transferHashes {
 		explorerTransfer, err := getTransfer(exp.bc, exp.ap, transferHash)
 		if err != nil {
-			return []explorer.Transfer{}, err
+			return []explorer.TransferV2{}, err
 		}
 
 		res = append(res, explorerTransfer)
@@ -195,12 +256,62 @@ func (exp *Service) GetTransfersByAddress(address string, offset int64, limit in
 	return res, nil
 }
 
+// GetTransfersByAddressPage returns a page of the transfers associated with an address and the cursor of the next
+// page. The transfers are newest first unless oldestFirst is set.
+func (exp *Service) GetTransfersByAddressPage(
+	address string,
+	cursor string,
+	limit int64,
+	oldestFirst bool,
+) (explorer.TransferPage, error) {
+	sender, recipient := transferIndexes(exp.bc)
+	refs, next, err := getPageByAddress(
+		address,
+		cursor,
+		limit,
+		exp.cfg.MaxPageSize,
+		oldestFirst,
+		sender,
+		recipient,
+		locateTransfer(exp.bc),
+	)
+	if err != nil {
+		return explorer.TransferPage{}, err
+	}
+
+	res := explorer.TransferPage{Transfers: make([]explorer.TransferV2, 0, len(refs)), Next: next}
+	for _, ref := range refs {
+		transfer := ref.blk.Transfers[ref.index]
+		explorerTransfer, err := convertTsfToExplorerTsf(transfer, false)
+		if err != nil {
+			return explorer.TransferPage{}, errors.Wrapf(err, "failed to convert transfer %v to explorer's JSON transfer", transfer)
+		}
+		blkHash := ref.blk.HashBlock()
+		explorerTransfer.Timestamp = int64(ref.blk.ConvertToBlockHeaderPb().Timestamp)
+		explorerTransfer.BlockID = hex.EncodeToString(blkHash[:])
+		res.Transfers = append(res.Transfers, explorerTransfer)
+	}
+
+	return res, nil
+}
+
 // GetUnconfirmedTransfersByAddress returns all unconfirmed transfers in actpool associated with an address
+//
+// Deprecated: the amounts may overflow int64, use GetUnconfirmedTransfersByAddressV2 instead
 func (exp *Service) GetUnconfirmedTransfersByAddress(address string, offset int64, limit int64) ([]explorer.Transfer, error) {
-	res := make([]explorer.Transfer, 0)
-	if _, err := exp.bc.StateByAddr(address); err != nil {
+	transfers, err := exp.GetUnconfirmedTransfersByAddressV2(address, offset, limit)
+	if err != nil {
 		return []explorer.Transfer{}, err
 	}
+	return convertTsfsV2ToTsfs(transfers)
+}
+
+// GetUnconfirmedTransfersByAddressV2 returns all unconfirmed transfers in actpool associated with an address
+func (exp *Service) GetUnconfirmedTransfersByAddressV2(address string, offset int64, limit int64) ([]explorer.TransferV2, error) {
+	res := make([]explorer.TransferV2, 0)
+	if _, err := exp.bc.StateByAddr(address); err != nil {
+		return []explorer.TransferV2{}, err
+	}
 
 	acts := exp.ap.GetUnconfirmedActs(address)
 	tsfIndex := int64(0)
@@ -222,7 +333,7 @@ func (exp *Service) GetUnconfirmedTransfersByAddress(address string, offset int6
 		transfer.ConvertFromActionPb(act)
 		explorerTransfer, err := convertTsfToExplorerTsf(transfer, true)
 		if err != nil {
-			return []explorer.Transfer{}, errors.Wrapf(err, "failed to convert transfer %v to explorer's JSON transfer", transfer)
+			return []explorer.TransferV2{}, errors.Wrapf(err, "failed to convert transfer %v to explorer's JSON transfer", transfer)
 		}
 		res = append(res, explorerTransfer)
 	}
@@ -231,19 +342,30 @@ func (exp *Service) GetUnconfirmedTransfersByAddress(address string, offset int6
 }
 
 // GetTransfersByBlockID returns transfers in a block
+//
+// Deprecated: the amounts may overflow int64, use GetTransfersByBlockIDV2 instead
 func (exp *Service) GetTransfersByBlockID(blkID string, offset int64, limit int64) ([]explorer.Transfer, error) {
-	var res []explorer.Transfer
+	transfers, err := exp.GetTransfersByBlockIDV2(blkID, offset, limit)
+	if err != nil {
+		return []explorer.Transfer{}, err
+	}
+	return convertTsfsV2ToTsfs(transfers)
+}
+
+// GetTransfersByBlockIDV2 returns transfers in a block
+func (exp *Service) GetTransfersByBlockIDV2(blkID string, offset int64, limit int64) ([]explorer.TransferV2, error) {
+	var res []explorer.TransferV2
 	bytes, err := hex.DecodeString(blkID)
 
 	if err != nil {
-		return []explorer.Transfer{}, err
+		return []explorer.TransferV2{}, err
 	}
 	var hash hash.Hash32B
 	copy(hash[:], bytes)
 
 	blk, err := exp.bc.GetBlockByHash(hash)
 	if err != nil {
-		return []explorer.Transfer{}, err
+		return []explorer.TransferV2{}, err
 	}
 
 	for i, transfer := range blk.Transfers {
@@ -257,7 +379,7 @@ func (exp *Service) GetTransfersByBlockID(blkID string, offset int64, limit int6
 
 		explorerTransfer, err := convertTsfToExplorerTsf(transfer, false)
 		if err != nil {
-			return []explorer.Transfer{}, errors.Wrapf(err, "failed to convert transfer %v to explorer's JSON transfer", transfer)
+			return []explorer.TransferV2{}, errors.Wrapf(err, "failed to convert transfer %v to explorer's JSON transfer", transfer)
 		}
 		explorerTransfer.Timestamp = int64(blk.ConvertToBlockHeaderPb().Timestamp)
 		explorerTransfer.BlockID = blkID
@@ -268,21 +390,33 @@ func (exp *Service) GetTransfersByBlockID(blkID string, offset int64, limit int6
 
 // GetLastVotesByRange returns votes in [-(offset+limit-1), -offset] from block
 // with height startBlockHeight
+//
+// Deprecated: the gas prices may overflow int64, use GetLastVotesByRangeV2 instead
 func (exp *Service) GetLastVotesByRange(startBlockHeight int64, offset int64, limit int64) ([]explorer.Vote, error) {
-	var res []explorer.Vote
+	votes, err := exp.GetLastVotesByRangeV2(startBlockHeight, offset, limit)
+	if err != nil {
+		return []explorer.Vote{}, err
+	}
+	return convertVotesV2ToVotes(votes)
+}
+
+// GetLastVotesByRangeV2 returns votes in [-(offset+limit-1), -offset] from block
+// with height startBlockHeight
+func (exp *Service) GetLastVotesByRangeV2(startBlockHeight int64, offset int64, limit int64) ([]explorer.VoteV2, error) {
+	var res []explorer.VoteV2
 	voteCount := uint64(0)
 
 ChainLoop:
 	for height := startBlockHeight; height >= 0; height-- {
 		hash, err := exp.bc.GetHashByHeight(uint64(height))
 		if err != nil {
-			return []explorer.Vote{}, err
+			return []explorer.VoteV2{}, err
 		}
 		blkID := hex.EncodeToString(hash[:])
 
 		blk, err := exp.bc.GetBlockByHeight(uint64(height))
 		if err != nil {
-			return []explorer.Vote{}, err
+			return []explorer.VoteV2{}, err
 		}
 
 		for i := int64(len(blk.Votes) - 1); i >= 0; i-- {
@@ -298,7 +432,7 @@ ChainLoop:
 
 			explorerVote, err := convertVoteToExplorerVote(blk.Votes[i], false)
 			if err != nil {
-				return []explorer.Vote{}, errors.Wrapf(err, "failed to convert vote %v to explorer's JSON vote", blk.Votes[i])
+				return []explorer.VoteV2{}, errors.Wrapf(err, "failed to convert vote %v to explorer's JSON vote", blk.Votes[i])
 			}
 			explorerVote.Timestamp = int64(blk.ConvertToBlockHeaderPb().Timestamp)
 			explorerVote.BlockID = blkID
@@ -310,11 +444,22 @@ ChainLoop:
 }
 
 // GetVoteByID returns vote by vote id
+//
+// Deprecated: the gas price may overflow int64, use GetVoteByIDV2 instead
 func (exp *Service) GetVoteByID(voteID string) (explorer.Vote, error) {
-	bytes, err := hex.DecodeString(voteID)
+	vote, err := exp.GetVoteByIDV2(voteID)
 	if err != nil {
 		return explorer.Vote{}, err
 	}
+	return convertVoteV2ToVote(vote)
+}
+
+// GetVoteByIDV2 returns vote by vote id
+func (exp *Service) GetVoteByIDV2(voteID string) (explorer.VoteV2, error) {
+	bytes, err := hex.DecodeString(voteID)
+	if err != nil {
+		return explorer.VoteV2{}, err
+	}
 	var voteHash hash.Hash32B
 	copy(voteHash[:], bytes)
 
@@ -322,31 +467,29 @@ func (exp *Service) GetVoteByID(voteID string) (explorer.Vote, error) {
 }
 
 // GetVotesByAddress returns all votes associated with an address
+//
+// Deprecated: the gas prices may overflow int64, use GetVotesByAddressV2 instead
 func (exp *Service) GetVotesByAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
-	var res []explorer.Vote
-	votesFromAddress, err := exp.bc.GetVotesFromAddress(address)
+	votes, err := exp.GetVotesByAddressV2(address, offset, limit)
 	if err != nil {
 		return []explorer.Vote{}, err
 	}
+	return convertVotesV2ToVotes(votes)
+}
 
-	votesToAddress, err := exp.bc.GetVotesToAddress(address)
+// GetVotesByAddressV2 returns all votes associated with an address
+func (exp *Service) GetVotesByAddressV2(address string, offset int64, limit int64) ([]explorer.VoteV2, error) {
+	var res []explorer.VoteV2
+	sender, recipient := voteIndexes(exp.bc)
+	voteHashes, err := getHashesByAddress(address, offset, limit, sender, recipient)
 	if err != nil {
-		return []explorer.Vote{}, err
+		return []explorer.VoteV2{}, err
 	}
 
-	votesFromAddress = append(votesFromAddress, votesToAddress...)
-	for i, voteHash := range votesFromAddress {
-		if int64(i) < offset {
-			continue
-		}
-
-		if int64(len(res)) >= limit {
-			break
-		}
-
+	for _, voteHash := range voteHashes {
 		explorerVote, err := getVote(exp.bc, exp.ap, voteHash)
 		if err != nil {
-			return []explorer.Vote{}, err
+			return []explorer.VoteV2{}, err
 		}
 
 		res = append(res, explorerVote)
@@ -355,12 +498,66 @@ func (exp *Service) GetVotesByAddress(address string, offset int64, limit int64)
 	return res, nil
 }
 
+// GetVotesByAddressPage returns a page of the votes associated with an address and the cursor of the next
+// page. The votes are newest first unless oldestFirst is set.
+func (exp *Service) GetVotesByAddressPage(
+	address string,
+	cursor string,
+	limit int64,
+	oldestFirst bool,
+) (explorer.VotePage, error) {
+	sender, recipient := voteIndexes(exp.bc)
+	refs, next, err := getPageByAddress(
+		address,
+		cursor,
+		limit,
+		exp.cfg.MaxPageSize,
+		oldestFirst,
+		sender,
+		recipient,
+		locateVote(exp.bc),
+	)
+	if err != nil {
+		return explorer.VotePage{}, err
+	}
+
+	res := explorer.VotePage{Votes: make([]explorer.Vote, 0, len(refs)), Next: next}
+	for _, ref := range refs {
+		vote := ref.blk.Votes[ref.index]
+		explorerVote, err := convertVoteToExplorerVote(vote, false)
+		if err != nil {
+			return explorer.VotePage{}, errors.Wrapf(err, "failed to convert vote %v to explorer's JSON vote", vote)
+		}
+		blkHash := ref.blk.HashBlock()
+		explorerVote.Timestamp = int64(ref.blk.ConvertToBlockHeaderPb().Timestamp)
+		explorerVote.BlockID = hex.EncodeToString(blkHash[:])
+		v, err := convertVoteV2ToVote(explorerVote)
+		if err != nil {
+			return explorer.VotePage{}, err
+		}
+		res.Votes = append(res.Votes, v)
+	}
+
+	return res, nil
+}
+
 // GetUnconfirmedVotesByAddress returns all unconfirmed votes in actpool associated with an address
+//
+// Deprecated: the gas prices may overflow int64, use GetUnconfirmedVotesByAddressV2 instead
 func (exp *Service) GetUnconfirmedVotesByAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
-	res := make([]explorer.Vote, 0)
-	if _, err := exp.bc.StateByAddr(address); err != nil {
+	votes, err := exp.GetUnconfirmedVotesByAddressV2(address, offset, limit)
+	if err != nil {
 		return []explorer.Vote{}, err
 	}
+	return convertVotesV2ToVotes(votes)
+}
+
+// GetUnconfirmedVotesByAddressV2 returns all unconfirmed votes in actpool associated with an address
+func (exp *Service) GetUnconfirmedVotesByAddressV2(address string, offset int64, limit int64) ([]explorer.VoteV2, error) {
+	res := make([]explorer.VoteV2, 0)
+	if _, err := exp.bc.StateByAddr(address); err != nil {
+		return []explorer.VoteV2{}, err
+	}
 
 	acts := exp.ap.GetUnconfirmedActs(address)
 	voteIndex := int64(0)
@@ -382,7 +579,7 @@ func (exp *Service) GetUnconfirmedVotesByAddress(address string, offset int64, l
 		vote.ConvertFromActionPb(act)
 		explorerVote, err := convertVoteToExplorerVote(vote, true)
 		if err != nil {
-			return []explorer.Vote{}, errors.Wrapf(err, "failed to convert vote %v to explorer's JSON vote", vote)
+			return []explorer.VoteV2{}, errors.Wrapf(err, "failed to convert vote %v to explorer's JSON vote", vote)
 		}
 		res = append(res, explorerVote)
 	}
@@ -391,18 +588,29 @@ func (exp *Service) GetUnconfirmedVotesByAddress(address string, offset int64, l
 }
 
 // GetVotesByBlockID returns votes in a block
+//
+// Deprecated: the gas prices may overflow int64, use GetVotesByBlockIDV2 instead
 func (exp *Service) GetVotesByBlockID(blkID string, offset int64, limit int64) ([]explorer.Vote, error) {
-	var res []explorer.Vote
-	bytes, err := hex.DecodeString(blkID)
+	votes, err := exp.GetVotesByBlockIDV2(blkID, offset, limit)
 	if err != nil {
 		return []explorer.Vote{}, err
 	}
+	return convertVotesV2ToVotes(votes)
+}
+
+// GetVotesByBlockIDV2 returns votes in a block
+func (exp *Service) GetVotesByBlockIDV2(blkID string, offset int64, limit int64) ([]explorer.VoteV2, error) {
+	var res []explorer.VoteV2
+	bytes, err := hex.DecodeString(blkID)
+	if err != nil {
+		return []explorer.VoteV2{}, err
+	}
 	var hash hash.Hash32B
 	copy(hash[:], bytes)
 
 	blk, err := exp.bc.GetBlockByHash(hash)
 	if err != nil {
-		return []explorer.Vote{}, err
+		return []explorer.VoteV2{}, err
 	}
 
 	for i, vote := range blk.Votes {
@@ -416,7 +624,7 @@ func (exp *Service) GetVotesByBlockID(blkID string, offset int64, limit int64) (
 
 		explorerVote, err := convertVoteToExplorerVote(vote, false)
 		if err != nil {
-			return []explorer.Vote{}, errors.Wrapf(err, "failed to convert vote %v to explorer's JSON vote", vote)
+			return []explorer.VoteV2{}, errors.Wrapf(err, "failed to convert vote %v to explorer's JSON vote", vote)
 		}
 		explorerVote.Timestamp = int64(blk.ConvertToBlockHeaderPb().Timestamp)
 		explorerVote.BlockID = blkID
@@ -427,21 +635,33 @@ func (exp *Service) GetVotesByBlockID(blkID string, offset int64, limit int64) (
 
 // GetLastExecutionsByRange returns executions in [-(offset+limit-1), -offset] from block
 // with height startBlockHeight
+//
+// Deprecated: the amounts and the gas prices may overflow int64, use GetLastExecutionsByRangeV2 instead
 func (exp *Service) GetLastExecutionsByRange(startBlockHeight int64, offset int64, limit int64) ([]explorer.Execution, error) {
-	var res []explorer.Execution
+	executions, err := exp.GetLastExecutionsByRangeV2(startBlockHeight, offset, limit)
+	if err != nil {
+		return []explorer.Execution{}, err
+	}
+	return convertExecutionsV2ToExecutions(executions)
+}
+
+// GetLastExecutionsByRangeV2 returns executions in [-(offset+limit-1), -offset] from block
+// with height startBlockHeight
+func (exp *Service) GetLastExecutionsByRangeV2(startBlockHeight int64, offset int64, limit int64) ([]explorer.ExecutionV2, error) {
+	var res []explorer.ExecutionV2
 	executionCount := uint64(0)
 
 ChainLoop:
 	for height := startBlockHeight; height >= 0; height-- {
 		hash, err := exp.bc.GetHashByHeight(uint64(height))
 		if err != nil {
-			return []explorer.Execution{}, err
+			return []explorer.ExecutionV2{}, err
 		}
 		blkID := hex.EncodeToString(hash[:])
 
 		blk, err := exp.bc.GetBlockByHeight(uint64(height))
 		if err != nil {
-			return []explorer.Execution{}, err
+			return []explorer.ExecutionV2{}, err
 		}
 
 		for i := int64(len(blk.Executions) - 1); i >= 0; i-- {
@@ -457,7 +677,7 @@ ChainLoop:
 
 			explorerExecution, err := convertExecutionToExplorerExecution(blk.Executions[i], false)
 			if err != nil {
-				return []explorer.Execution{}, errors.Wrapf(err, "failed to convert execution %v to explorer's JSON execution", blk.Executions[i])
+				return []explorer.ExecutionV2{}, errors.Wrapf(err, "failed to convert execution %v to explorer's JSON execution", blk.Executions[i])
 			}
 			explorerExecution.Timestamp = int64(blk.ConvertToBlockHeaderPb().Timestamp)
 			explorerExecution.BlockID = blkID
@@ -469,11 +689,22 @@ ChainLoop:
 }
 
 // GetExecutionByID returns execution by execution id
+//
+// Deprecated: the amount and the gas price may overflow int64, use GetExecutionByIDV2 instead
 func (exp *Service) GetExecutionByID(executionID string) (explorer.Execution, error) {
-	bytes, err := hex.DecodeString(executionID)
+	execution, err := exp.GetExecutionByIDV2(executionID)
 	if err != nil {
 		return explorer.Execution{}, err
 	}
+	return convertExecutionV2ToExecution(execution)
+}
+
+// GetExecutionByIDV2 returns execution by execution id
+func (exp *Service) GetExecutionByIDV2(executionID string) (explorer.ExecutionV2, error) {
+	bytes, err := hex.DecodeString(executionID)
+	if err != nil {
+		return explorer.ExecutionV2{}, err
+	}
 	var executionHash hash.Hash32B
 	copy(executionHash[:], bytes)
 
@@ -481,31 +712,29 @@ func (exp *Service) GetExecutionByID(executionID string) (explorer.Execution, er
 }
 
 // GetExecutionsByAddress returns all executions associated with an address
+//
+// Deprecated: the amounts and the gas prices may overflow int64, use GetExecutionsByAddressV2 instead
 func (exp *Service) GetExecutionsByAddress(address string, offset int64, limit int64) ([]explorer.Execution, error) {
-	var res []explorer.Execution
-	executionsFromAddress, err := exp.bc.GetExecutionsFromAddress(address)
+	executions, err := exp.GetExecutionsByAddressV2(address, offset, limit)
 	if err != nil {
 		return []explorer.Execution{}, err
 	}
+	return convertExecutionsV2ToExecutions(executions)
+}
 
-	executionsToAddress, err := exp.bc.GetExecutionsToAddress(address)
+// GetExecutionsByAddressV2 returns all executions associated with an address
+func (exp *Service) GetExecutionsByAddressV2(address string, offset int64, limit int64) ([]explorer.ExecutionV2, error) {
+	var res []explorer.ExecutionV2
+	sender, recipient := executionIndexes(exp.bc)
+	executionHashes, err := getHashesByAddress(address, offset, limit, sender, recipient)
 	if err != nil {
-		return []explorer.Execution{}, err
+		return []explorer.ExecutionV2{}, err
 	}
 
-	executionsFromAddress = append(executionsFromAddress, executionsToAddress...)
-	for i, executionHash := range executionsFromAddress {
-		if int64(i) < offset {
-			continue
-		}
-
-		if int64(len(res)) >= limit {
-			break
-		}
-
+	for _, executionHash := range executionHashes {
 		explorerExecution, err := getExecution(exp.bc, exp.ap, executionHash)
 		if err != nil {
-			return []explorer.Execution{}, err
+			return []explorer.ExecutionV2{}, err
 		}
 
 		res = append(res, explorerExecution)
@@ -514,12 +743,66 @@ func (exp *Service) GetExecutionsByAddress(address string, offset int64, limit i
 	return res, nil
 }
 
+// GetExecutionsByAddressPage returns a page of the executions associated with an address and the cursor of the next
+// page. The executions are newest first unless oldestFirst is set.
+func (exp *Service) GetExecutionsByAddressPage(
+	address string,
+	cursor string,
+	limit int64,
+	oldestFirst bool,
+) (explorer.ExecutionPage, error) {
+	sender, recipient := executionIndexes(exp.bc)
+	refs, next, err := getPageByAddress(
+		address,
+		cursor,
+		limit,
+		exp.cfg.MaxPageSize,
+		oldestFirst,
+		sender,
+		recipient,
+		locateExecution(exp.bc),
+	)
+	if err != nil {
+		return explorer.ExecutionPage{}, err
+	}
+
+	res := explorer.ExecutionPage{Executions: make([]explorer.Execution, 0, len(refs)), Next: next}
+	for _, ref := range refs {
+		execution := ref.blk.Executions[ref.index]
+		explorerExecution, err := convertExecutionToExplorerExecution(execution, false)
+		if err != nil {
+			return explorer.ExecutionPage{}, errors.Wrapf(err, "failed to convert execution %v to explorer's JSON execution", execution)
+		}
+		blkHash := ref.blk.HashBlock()
+		explorerExecution.Timestamp = int64(ref.blk.ConvertToBlockHeaderPb().Timestamp)
+		explorerExecution.BlockID = hex.EncodeToString(blkHash[:])
+		e, err := convertExecutionV2ToExecution(explorerExecution)
+		if err != nil {
+			return explorer.ExecutionPage{}, err
+		}
+		res.Executions = append(res.Executions, e)
+	}
+
+	return res, nil
+}
+
 // GetUnconfirmedExecutionsByAddress returns all unconfirmed executions in actpool associated with an address
+//
+// Deprecated: the amounts and the gas prices may overflow int64, use GetUnconfirmedExecutionsByAddressV2 instead
 func (exp *Service) GetUnconfirmedExecutionsByAddress(address string, offset int64, limit int64) ([]explorer.Execution, error) {
-	res := make([]explorer.Execution, 0)
-	if _, err := exp.bc.StateByAddr(address); err != nil {
+	executions, err := exp.GetUnconfirmedExecutionsByAddressV2(address, offset, limit)
+	if err != nil {
 		return []explorer.Execution{}, err
 	}
+	return convertExecutionsV2ToExecutions(executions)
+}
+
+// GetUnconfirmedExecutionsByAddressV2 returns all unconfirmed executions in actpool associated with an address
+func (exp *Service) GetUnconfirmedExecutionsByAddressV2(address string, offset int64, limit int64) ([]explorer.ExecutionV2, error) {
+	res := make([]explorer.ExecutionV2, 0)
+	if _, err := exp.bc.StateByAddr(address); err != nil {
+		return []explorer.ExecutionV2{}, err
+	}
 
 	acts := exp.ap.GetUnconfirmedActs(address)
 	executionIndex := int64(0)
@@ -541,7 +824,7 @@ func (exp *Service) GetUnconfirmedExecutionsByAddress(address string, offset int
 		execution.ConvertFromActionPb(act)
 		explorerExecution, err := convertExecutionToExplorerExecution(execution, true)
 		if err != nil {
-			return []explorer.Execution{}, errors.Wrapf(err, "failed to convert execution %v to explorer's JSON execution", execution)
+			return []explorer.ExecutionV2{}, errors.Wrapf(err, "failed to convert execution %v to explorer's JSON execution", execution)
 		}
 		res = append(res, explorerExecution)
 	}
@@ -550,19 +833,30 @@ func (exp *Service) GetUnconfirmedExecutionsByAddress(address string, offset int
 }
 
 // GetExecutionsByBlockID returns executions in a block
+//
+// Deprecated: the amounts and the gas prices may overflow int64, use GetExecutionsByBlockIDV2 instead
 func (exp *Service) GetExecutionsByBlockID(blkID string, offset int64, limit int64) ([]explorer.Execution, error) {
-	var res []explorer.Execution
+	executions, err := exp.GetExecutionsByBlockIDV2(blkID, offset, limit)
+	if err != nil {
+		return []explorer.Execution{}, err
+	}
+	return convertExecutionsV2ToExecutions(executions)
+}
+
+// GetExecutionsByBlockIDV2 returns executions in a block
+func (exp *Service) GetExecutionsByBlockIDV2(blkID string, offset int64, limit int64) ([]explorer.ExecutionV2, error) {
+	var res []explorer.ExecutionV2
 	bytes, err := hex.DecodeString(blkID)
 
 	if err != nil {
-		return []explorer.Execution{}, err
+		return []explorer.ExecutionV2{}, err
 	}
 	var hash hash.Hash32B
 	copy(hash[:], bytes)
 
 	blk, err := exp.bc.GetBlockByHash(hash)
 	if err != nil {
-		return []explorer.Execution{}, err
+		return []explorer.ExecutionV2{}, err
 	}
 
 	for i, execution := range blk.Executions {
@@ -576,7 +870,7 @@ func (exp *Service) GetExecutionsByBlockID(blkID string, offset int64, limit int
 
 		explorerExecution, err := convertExecutionToExplorerExecution(execution, false)
 		if err != nil {
-			return []explorer.Execution{}, errors.Wrapf(err, "failed to convert execution %v to explorer's JSON execution", execution)
+			return []explorer.ExecutionV2{}, errors.Wrapf(err, "failed to convert execution %v to explorer's JSON execution", execution)
 		}
 		explorerExecution.Timestamp = int64(blk.ConvertToBlockHeaderPb().Timestamp)
 		explorerExecution.BlockID = blkID
@@ -602,36 +896,49 @@ func (exp *Service) GetReceiptByExecutionID(id string) (explorer.Receipt, error)
 }
 
 // GetLastBlocksByRange get block with height [offset-limit+1, offset]
+//
+// Deprecated: the amounts may overflow int64, use GetLastBlocksByRangeV2 instead
 func (exp *Service) GetLastBlocksByRange(offset int64, limit int64) ([]explorer.Block, error) {
-	var res []explorer.Block
+	blocks, err := exp.GetLastBlocksByRangeV2(offset, limit)
+	if err != nil {
+		return []explorer.Block{}, err
+	}
+	return convertBlocksV2ToBlocks(blocks)
+}
+
+// GetLastBlocksByRangeV2 get block with height [offset-limit+1, offset]
+func (exp *Service) GetLastBlocksByRangeV2(offset int64, limit int64) ([]explorer.BlockV2, error) {
+	var res []explorer.BlockV2
 
 	for height := offset; height >= 0 && int64(len(res)) < limit; height-- {
 		blk, err := exp.bc.GetBlockByHeight(uint64(height))
 		if err != nil {
-			return []explorer.Block{}, err
+			return []explorer.BlockV2{}, err
 		}
 
 		blockHeaderPb := blk.ConvertToBlockHeaderPb()
 		hash, err := exp.bc.GetHashByHeight(uint64(height))
 		if err != nil {
-			return []explorer.Block{}, err
+			return []explorer.BlockV2{}, err
 		}
 
-		totalAmount := int64(0)
+		totalAmount := big.NewInt(0)
 		totalSize := uint32(0)
 		for _, transfer := range blk.Transfers {
-			totalAmount += transfer.Amount().Int64()
+			if transfer.Amount() != nil {
+				totalAmount.Add(totalAmount, transfer.Amount())
+			}
 			totalSize += transfer.TotalSize()
 		}
 
-		explorerBlock := explorer.Block{
+		explorerBlock := explorer.BlockV2{
 			ID:         hex.EncodeToString(hash[:]),
 			Height:     int64(blockHeaderPb.Height),
 			Timestamp:  int64(blockHeaderPb.Timestamp),
 			Transfers:  int64(len(blk.Transfers)),
 			Votes:      int64(len(blk.Votes)),
 			Executions: int64(len(blk.Executions)),
-			Amount:     totalAmount,
+			Amount:     totalAmount.String(),
 			Size:       int64(totalSize),
 			GenerateBy: explorer.BlockGenerator{
 				Name:    "",
@@ -646,36 +953,49 @@ func (exp *Service) GetLastBlocksByRange(offset int64, limit int64) ([]explorer.
 }
 
 // GetBlockByID returns block by block id
+//
+// Deprecated: the amount may overflow int64, use GetBlockByIDV2 instead
 func (exp *Service) GetBlockByID(blkID string) (explorer.Block, error) {
-	bytes, err := hex.DecodeString(blkID)
+	block, err := exp.GetBlockByIDV2(blkID)
 	if err != nil {
 		return explorer.Block{}, err
 	}
+	return convertBlockV2ToBlock(block)
+}
+
+// GetBlockByIDV2 returns block by block id
+func (exp *Service) GetBlockByIDV2(blkID string) (explorer.BlockV2, error) {
+	bytes, err := hex.DecodeString(blkID)
+	if err != nil {
+		return explorer.BlockV2{}, err
+	}
 	var hash hash.Hash32B
 	copy(hash[:], bytes)
 
 	blk, err := exp.bc.GetBlockByHash(hash)
 	if err != nil {
-		return explorer.Block{}, err
+		return explorer.BlockV2{}, err
 	}
 
 	blkHeaderPb := blk.ConvertToBlockHeaderPb()
 
-	totalAmount := int64(0)
+	totalAmount := big.NewInt(0)
 	totalSize := uint32(0)
 	for _, transfer := range blk.Transfers {
-		totalAmount += transfer.Amount().Int64()
+		if transfer.Amount() != nil {
+			totalAmount.Add(totalAmount, transfer.Amount())
+		}
 		totalSize += transfer.TotalSize()
 	}
 
-	explorerBlock := explorer.Block{
+	explorerBlock := explorer.BlockV2{
 		ID:         blkID,
 		Height:     int64(blkHeaderPb.Height),
 		Timestamp:  int64(blkHeaderPb.Timestamp),
 		Transfers:  int64(len(blk.Transfers)),
 		Votes:      int64(len(blk.Votes)),
 		Executions: int64(len(blk.Executions)),
-		Amount:     totalAmount,
+		Amount:     totalAmount.String(),
 		Size:       int64(totalSize),
 		GenerateBy: explorer.BlockGenerator{
 			Name:    "",
@@ -687,8 +1007,30 @@ func (exp *Service) GetBlockByID(blkID string) (explorer.Block, error) {
 }
 
 // GetCoinStatistic returns stats in blockchain
+//
+// Deprecated: the supply may overflow int64, use GetCoinStatisticV2 instead
 func (exp *Service) GetCoinStatistic() (explorer.CoinStatistic, error) {
-	stat := explorer.CoinStatistic{}
+	stat, err := exp.GetCoinStatisticV2()
+	if err != nil {
+		return explorer.CoinStatistic{}, err
+	}
+	supply, err := stringToInt64(stat.Supply)
+	if err != nil {
+		return explorer.CoinStatistic{}, errors.Wrap(err, "invalid supply")
+	}
+	return explorer.CoinStatistic{
+		Height:     stat.Height,
+		Supply:     supply,
+		Transfers:  stat.Transfers,
+		Votes:      stat.Votes,
+		Executions: stat.Executions,
+		Aps:        stat.Aps,
+	}, nil
+}
+
+// GetCoinStatisticV2 returns stats in blockchain, of which the supply is a decimal string
+func (exp *Service) GetCoinStatisticV2() (explorer.CoinStatisticV2, error) {
+	stat := explorer.CoinStatisticV2{}
 
 	tipHeight := exp.bc.TipHeight()
 
@@ -716,7 +1058,7 @@ func (exp *Service) GetCoinStatistic() (explorer.CoinStatistic, error) {
 	if int64(tipHeight) < blockLimit {
 		blockLimit = int64(tipHeight)
 	}
-	blks, err := exp.GetLastBlocksByRange(int64(tipHeight), blockLimit)
+	blks, err := exp.GetLastBlocksByRangeV2(int64(tipHeight), blockLimit)
 	if err != nil {
 		return stat, err
 	}
@@ -736,9 +1078,9 @@ func (exp *Service) GetCoinStatistic() (explorer.CoinStatistic, error) {
 	}
 	aps := actionNumber / timeDuration
 
-	explorerCoinStats := explorer.CoinStatistic{
+	explorerCoinStats := explorer.CoinStatisticV2{
 		Height:     int64(tipHeight),
-		Supply:     int64(blockchain.Gen.TotalSupply),
+		Supply:     strconv.FormatUint(blockchain.Gen.TotalSupply, 10),
 		Transfers:  int64(totalTransfers),
 		Votes:      int64(totalVotes),
 		Executions: int64(totalExecutions),
@@ -766,14 +1108,26 @@ func (exp *Service) GetConsensusMetrics() (explorer.ConsensusMetrics, error) {
 		LatestDelegates:     dStrs,
 		LatestBlockProducer: bpStr,
 		Candidates:          cStrs,
+		LatestRound:         int64(cm.LatestRound),
 	}, nil
 }
 
 // GetCandidateMetrics returns the latest delegates metrics
+//
+// Deprecated: the votes may overflow int64, use GetCandidateMetricsV2 instead
 func (exp *Service) GetCandidateMetrics() (explorer.CandidateMetrics, error) {
+	metrics, err := exp.GetCandidateMetricsV2()
+	if err != nil {
+		return explorer.CandidateMetrics{}, err
+	}
+	return convertCandidateMetricsV2ToCandidateMetrics(metrics)
+}
+
+// GetCandidateMetricsV2 returns the latest delegates metrics, of which the votes are decimal strings
+func (exp *Service) GetCandidateMetricsV2() (explorer.CandidateMetricsV2, error) {
 	cm, err := exp.c.Metrics()
 	if err != nil {
-		return explorer.CandidateMetrics{}, errors.Wrapf(
+		return explorer.CandidateMetricsV2{}, errors.Wrapf(
 			err,
 			"Failed to get the candidate metrics")
 	}
@@ -783,14 +1137,14 @@ func (exp *Service) GetCandidateMetrics() (explorer.CandidateMetrics, error) {
 	}
 	allCandidates, err := exp.bc.CandidatesByHeight(cm.LatestHeight)
 	if err != nil {
-		return explorer.CandidateMetrics{}, errors.Wrapf(err,
+		return explorer.CandidateMetricsV2{}, errors.Wrapf(err,
 			"Failed to get the candidate metrics")
 	}
-	candidates := make([]explorer.Candidate, len(cm.Candidates))
+	candidates := make([]explorer.CandidateV2, len(allCandidates))
 	for i, c := range allCandidates {
-		candidates[i] = explorer.Candidate{
+		candidates[i] = explorer.CandidateV2{
 			Address:          c.Address,
-			TotalVote:        c.Votes.Int64(),
+			TotalVote:        bigIntToString(c.Votes),
 			CreationHeight:   int64(c.CreationHeight),
 			LastUpdateHeight: int64(c.LastUpdateHeight),
 			IsDelegate:       false,
@@ -804,7 +1158,7 @@ func (exp *Service) GetCandidateMetrics() (explorer.CandidateMetrics, error) {
 		}
 	}
 
-	return explorer.CandidateMetrics{
+	return explorer.CandidateMetricsV2{
 		Candidates:   candidates,
 		LatestEpoch:  int64(cm.LatestEpoch),
 		LatestHeight: int64(cm.LatestHeight),
@@ -812,37 +1166,76 @@ func (exp *Service) GetCandidateMetrics() (explorer.CandidateMetrics, error) {
 }
 
 // GetCandidateMetricsByHeight returns the candidates metrics for given height.
+//
+// Deprecated: the votes may overflow int64, use GetCandidateMetricsByHeightV2 instead
 func (exp *Service) GetCandidateMetricsByHeight(h int64) (explorer.CandidateMetrics, error) {
+	metrics, err := exp.GetCandidateMetricsByHeightV2(h)
+	if err != nil {
+		return explorer.CandidateMetrics{}, err
+	}
+	return convertCandidateMetricsV2ToCandidateMetrics(metrics)
+}
+
+// GetCandidateMetricsByHeightV2 returns the candidates metrics for given height, of which the votes are decimal strings
+func (exp *Service) GetCandidateMetricsByHeightV2(h int64) (explorer.CandidateMetricsV2, error) {
 	if h < 0 {
-		return explorer.CandidateMetrics{}, errors.New("Invalid height")
+		return explorer.CandidateMetricsV2{}, errors.New("Invalid height")
 	}
 	allCandidates, err := exp.bc.CandidatesByHeight(uint64(h))
 	if err != nil {
-		return explorer.CandidateMetrics{}, errors.Wrapf(err,
+		return explorer.CandidateMetricsV2{}, errors.Wrapf(err,
 			"Failed to get the candidate metrics")
 	}
-	candidates := make([]explorer.Candidate, 0, len(allCandidates))
+	candidates := make([]explorer.CandidateV2, 0, len(allCandidates))
 	for _, c := range allCandidates {
 		pubKey, err := keypair.BytesToPubKeyString(c.PubKey)
 		if err != nil {
-			return explorer.CandidateMetrics{}, errors.Wrapf(err,
+			return explorer.CandidateMetricsV2{}, errors.Wrapf(err,
 				"Invalid candidate pub key")
 		}
-		candidates = append(candidates, explorer.Candidate{
+		candidates = append(candidates, explorer.CandidateV2{
 			Address:          c.Address,
 			PubKey:           pubKey,
-			TotalVote:        c.Votes.Int64(),
+			TotalVote:        bigIntToString(c.Votes),
 			CreationHeight:   int64(c.CreationHeight),
 			LastUpdateHeight: int64(c.LastUpdateHeight),
 		})
 	}
 
-	return explorer.CandidateMetrics{
+	return explorer.CandidateMetricsV2{
 		Candidates: candidates,
 	}, nil
 }
 
+// GetDelegateProductivity returns the numbers of the blocks that each delegate has produced, missed and endorsed in
+// the given epoch, which are sorted by the delegates' addresses
+func (exp *Service) GetDelegateProductivity(epoch int64) ([]explorer.DelegateProductivity, error) {
+	if epoch <= 0 {
+		return []explorer.DelegateProductivity{}, errors.New("Invalid epoch")
+	}
+	stats, err := exp.c.Productivity(uint64(epoch))
+	if err != nil {
+		return []explorer.DelegateProductivity{}, errors.Wrapf(err,
+			"Failed to get the delegate productivity")
+	}
+	productivity := make([]explorer.DelegateProductivity, 0, len(stats))
+	for delegate, p := range stats {
+		productivity = append(productivity, explorer.DelegateProductivity{
+			Delegate: delegate,
+			Produced: int64(p.Produced),
+			Missed:   int64(p.Missed),
+			Endorsed: int64(p.Endorsed),
+		})
+	}
+	sort.Slice(productivity, func(i, j int) bool {
+		return productivity[i].Delegate < productivity[j].Delegate
+	})
+	return productivity, nil
+}
+
 // SendTransfer sends a transfer
+//
+// Deprecated: the amount and the gas price may overflow int64, use SendTransferV2 instead
 func (exp *Service) SendTransfer(tsfJSON explorer.SendTransferRequest) (resp explorer.SendTransferResponse, err error) {
 	logger.Debug().Msg("receive send transfer request")
 
@@ -854,19 +1247,52 @@ func (exp *Service) SendTransfer(tsfJSON explorer.SendTransferRequest) (resp exp
 		requestMtc.WithLabelValues("SendTransfer", succeed).Inc()
 	}()
 
-	amount := big.NewInt(tsfJSON.Amount).Bytes()
+	return exp.sendTransfer(explorer.SendTransferRequestV2{
+		Version:      tsfJSON.Version,
+		Nonce:        tsfJSON.Nonce,
+		Sender:       tsfJSON.Sender,
+		Recipient:    tsfJSON.Recipient,
+		Amount:       strconv.FormatInt(tsfJSON.Amount, 10),
+		SenderPubKey: tsfJSON.SenderPubKey,
+		Signature:    tsfJSON.Signature,
+		Payload:      tsfJSON.Payload,
+		GasLimit:     tsfJSON.GasLimit,
+		GasPrice:     strconv.FormatInt(tsfJSON.GasPrice, 10),
+		IsCoinbase:   tsfJSON.IsCoinbase,
+	})
+}
+
+// SendTransferV2 sends a transfer, of which the amount and the gas price are decimal strings
+func (exp *Service) SendTransferV2(tsfJSON explorer.SendTransferRequestV2) (resp explorer.SendTransferResponse, err error) {
+	logger.Debug().Msg("receive send transfer v2 request")
+
+	defer func() {
+		succeed := "true"
+		if err != nil {
+			succeed = "false"
+		}
+		requestMtc.WithLabelValues("SendTransferV2", succeed).Inc()
+	}()
+
+	return exp.sendTransfer(tsfJSON)
+}
+
+func (exp *Service) sendTransfer(tsfJSON explorer.SendTransferRequestV2) (explorer.SendTransferResponse, error) {
+	amount, err := stringToBigInt(tsfJSON.Amount)
+	if err != nil {
+		return explorer.SendTransferResponse{}, errors.Wrapf(ErrTransfer, "invalid amount, %v", err)
+	}
+	gasPrice, err := stringToBigInt(tsfJSON.GasPrice)
+	if err != nil {
+		return explorer.SendTransferResponse{}, errors.Wrapf(ErrTransfer, "invalid gas price, %v", err)
+	}
 
 	payload, err := hex.DecodeString(tsfJSON.Payload)
 	if err != nil {
 		return explorer.SendTransferResponse{}, err
 	}
-	if uint64(len(payload)) > exp.cfg.MaxTransferPayloadBytes {
-		return explorer.SendTransferResponse{}, errors.Wrapf(
-			ErrTransfer,
-			"transfer payload contains %d bytes, and is longer than %d bytes limit",
-			len(payload),
-			exp.cfg.MaxTransferPayloadBytes,
-		)
+	if err := action.ValidateTransferPayload(payload, exp.cfg.MaxTransferPayloadBytes); err != nil {
+		return explorer.SendTransferResponse{}, errors.Wrapf(ErrTransfer, "invalid payload, %v", err)
 	}
 	senderPubKey, err := keypair.StringToPubKeyBytes(tsfJSON.SenderPubKey)
 	if err != nil {
@@ -879,7 +1305,7 @@ func (exp *Service) SendTransfer(tsfJSON explorer.SendTransferRequest) (resp exp
 	actPb := &pb.ActionPb{
 		Action: &pb.ActionPb_Transfer{
 			Transfer: &pb.TransferPb{
-				Amount:       amount,
+				Amount:       amount.Bytes(),
 				Sender:       tsfJSON.Sender,
 				Recipient:    tsfJSON.Recipient,
 				Payload:      payload,
@@ -890,11 +1316,11 @@ func (exp *Service) SendTransfer(tsfJSON explorer.SendTransferRequest) (resp exp
 		Version:   uint32(tsfJSON.Version),
 		Nonce:     uint64(tsfJSON.Nonce),
 		GasLimit:  uint64(tsfJSON.GasLimit),
-		GasPrice:  big.NewInt(tsfJSON.GasPrice).Bytes(),
+		GasPrice:  gasPrice.Bytes(),
 		Signature: signature,
 	}
 	// broadcast to the network
-	if err = exp.p2p.Broadcast(config.Default.Chain.ID, actPb); err != nil {
+	if err := exp.p2p.Broadcast(config.Default.Chain.ID, actPb); err != nil {
 		return explorer.SendTransferResponse{}, err
 	}
 	// send to actpool via dispatcher
@@ -907,6 +1333,8 @@ func (exp *Service) SendTransfer(tsfJSON explorer.SendTransferRequest) (resp exp
 }
 
 // SendVote sends a vote
+//
+// Deprecated: the gas price may overflow int64, use SendVoteV2 instead
 func (exp *Service) SendVote(voteJSON explorer.SendVoteRequest) (resp explorer.SendVoteResponse, err error) {
 	logger.Debug().Msg("receive send vote request")
 
@@ -918,6 +1346,39 @@ func (exp *Service) SendVote(voteJSON explorer.SendVoteRequest) (resp explorer.S
 		requestMtc.WithLabelValues("SendVote", succeed).Inc()
 	}()
 
+	return exp.sendVote(explorer.SendVoteRequestV2{
+		Version:     voteJSON.Version,
+		Nonce:       voteJSON.Nonce,
+		Voter:       voteJSON.Voter,
+		Votee:       voteJSON.Votee,
+		VoterPubKey: voteJSON.VoterPubKey,
+		GasLimit:    voteJSON.GasLimit,
+		GasPrice:    strconv.FormatInt(voteJSON.GasPrice, 10),
+		Signature:   voteJSON.Signature,
+	})
+}
+
+// SendVoteV2 sends a vote, of which the gas price is a decimal string
+func (exp *Service) SendVoteV2(voteJSON explorer.SendVoteRequestV2) (resp explorer.SendVoteResponse, err error) {
+	logger.Debug().Msg("receive send vote v2 request")
+
+	defer func() {
+		succeed := "true"
+		if err != nil {
+			succeed = "false"
+		}
+		requestMtc.WithLabelValues("SendVoteV2", succeed).Inc()
+	}()
+
+	return exp.sendVote(voteJSON)
+}
+
+func (exp *Service) sendVote(voteJSON explorer.SendVoteRequestV2) (explorer.SendVoteResponse, error) {
+	gasPrice, err := stringToBigInt(voteJSON.GasPrice)
+	if err != nil {
+		return explorer.SendVoteResponse{}, errors.Wrapf(ErrVote, "invalid gas price, %v", err)
+	}
+
 	selfPubKey, err := keypair.StringToPubKeyBytes(voteJSON.VoterPubKey)
 	if err != nil {
 		return explorer.SendVoteResponse{}, err
@@ -937,11 +1398,11 @@ func (exp *Service) SendVote(voteJSON explorer.SendVoteRequest) (resp explorer.S
 		Version:   uint32(voteJSON.Version),
 		Nonce:     uint64(voteJSON.Nonce),
 		GasLimit:  uint64(voteJSON.GasLimit),
-		GasPrice:  big.NewInt(voteJSON.GasPrice).Bytes(),
+		GasPrice:  gasPrice.Bytes(),
 		Signature: signature,
 	}
 	// broadcast to the network
-	if err = exp.p2p.Broadcast(config.Default.Chain.ID, actPb); err != nil {
+	if err := exp.p2p.Broadcast(config.Default.Chain.ID, actPb); err != nil {
 		return explorer.SendVoteResponse{}, err
 	}
 	// send to actpool via dispatcher
@@ -961,13 +1422,21 @@ func (exp *Service) GetPeers() (explorer.GetPeersResponse, error) {
 			Address: p.String(),
 		})
 	}
+	banned := []explorer.BannedNode{}
+	for addr, until := range exp.p2p.BannedPeers() {
+		banned = append(banned, explorer.BannedNode{Address: addr, BannedUntil: until.Unix()})
+	}
+	sort.Slice(banned, func(i, j int) bool { return banned[i].Address < banned[j].Address })
 	return explorer.GetPeersResponse{
-		Self:  explorer.Node{Address: exp.p2p.Self().String()},
-		Peers: peers,
+		Self:   explorer.Node{Address: exp.p2p.Self().String()},
+		Peers:  peers,
+		Banned: banned,
 	}, nil
 }
 
 // SendSmartContract sends a smart contract
+//
+// Deprecated: the amount and the gas price may overflow int64, use SendSmartContractV2 instead
 func (exp *Service) SendSmartContract(execution explorer.Execution) (resp explorer.SendSmartContractResponse, err error) {
 	logger.Debug().Msg("receive send smart contract request")
 
@@ -979,6 +1448,34 @@ func (exp *Service) SendSmartContract(execution explorer.Execution) (resp explor
 		requestMtc.WithLabelValues("SendSmartContract", succeed).Inc()
 	}()
 
+	return exp.sendSmartContract(convertExecutionToExecutionV2(execution))
+}
+
+// SendSmartContractV2 sends a smart contract, of which the amount and the gas price are decimal strings
+func (exp *Service) SendSmartContractV2(execution explorer.ExecutionV2) (resp explorer.SendSmartContractResponse, err error) {
+	logger.Debug().Msg("receive send smart contract v2 request")
+
+	defer func() {
+		succeed := "true"
+		if err != nil {
+			succeed = "false"
+		}
+		requestMtc.WithLabelValues("SendSmartContractV2", succeed).Inc()
+	}()
+
+	return exp.sendSmartContract(execution)
+}
+
+func (exp *Service) sendSmartContract(execution explorer.ExecutionV2) (explorer.SendSmartContractResponse, error) {
+	amount, err := stringToBigInt(execution.Amount)
+	if err != nil {
+		return explorer.SendSmartContractResponse{}, errors.Wrapf(ErrExecution, "invalid amount, %v", err)
+	}
+	gasPrice, err := stringToBigInt(execution.GasPrice)
+	if err != nil {
+		return explorer.SendSmartContractResponse{}, errors.Wrapf(ErrExecution, "invalid gas price, %v", err)
+	}
+
 	executorPubKey, err := keypair.StringToPubKeyBytes(execution.ExecutorPubKey)
 	if err != nil {
 		return explorer.SendSmartContractResponse{}, err
@@ -994,7 +1491,7 @@ func (exp *Service) SendSmartContract(execution explorer.Execution) (resp explor
 	actPb := &pb.ActionPb{
 		Action: &pb.ActionPb_Execution{
 			Execution: &pb.ExecutionPb{
-				Amount:         big.NewInt(execution.Amount).Bytes(),
+				Amount:         amount.Bytes(),
 				Executor:       execution.Executor,
 				Contract:       execution.Contract,
 				ExecutorPubKey: executorPubKey,
@@ -1004,11 +1501,11 @@ func (exp *Service) SendSmartContract(execution explorer.Execution) (resp explor
 		Version:   uint32(execution.Version),
 		Nonce:     uint64(execution.Nonce),
 		GasLimit:  uint64(execution.GasLimit),
-		GasPrice:  big.NewInt(execution.GasPrice).Bytes(),
+		GasPrice:  gasPrice.Bytes(),
 		Signature: signature,
 	}
 	// broadcast to the network
-	if err = exp.p2p.Broadcast(config.Default.Chain.ID, actPb); err != nil {
+	if err := exp.p2p.Broadcast(config.Default.Chain.ID, actPb); err != nil {
 		return explorer.SendSmartContractResponse{}, err
 	}
 	// send to actpool via dispatcher
@@ -1021,9 +1518,32 @@ func (exp *Service) SendSmartContract(execution explorer.Execution) (resp explor
 }
 
 // ReadExecutionState reads the state in a contract address specified by the slot
+//
+// Deprecated: the amount and the gas price may overflow int64, use ReadExecutionStateV2 instead
 func (exp *Service) ReadExecutionState(execution explorer.Execution) (string, error) {
 	logger.Debug().Msg("receive read smart contract request")
 
+	return exp.readExecutionState(convertExecutionToExecutionV2(execution))
+}
+
+// ReadExecutionStateV2 reads the state in a contract address specified by the slot, of which the amount and the gas
+// price are decimal strings
+func (exp *Service) ReadExecutionStateV2(execution explorer.ExecutionV2) (string, error) {
+	logger.Debug().Msg("receive read smart contract v2 request")
+
+	return exp.readExecutionState(execution)
+}
+
+func (exp *Service) readExecutionState(execution explorer.ExecutionV2) (string, error) {
+	amount, err := stringToBigInt(execution.Amount)
+	if err != nil {
+		return "", errors.Wrapf(ErrExecution, "invalid amount, %v", err)
+	}
+	gasPrice, err := stringToBigInt(execution.GasPrice)
+	if err != nil {
+		return "", errors.Wrapf(ErrExecution, "invalid gas price, %v", err)
+	}
+
 	data, err := hex.DecodeString(execution.Data)
 	if err != nil {
 		return "", err
@@ -1035,7 +1555,7 @@ func (exp *Service) ReadExecutionState(execution explorer.Execution) (string, er
 	actPb := &pb.ActionPb{
 		Action: &pb.ActionPb_Execution{
 			Execution: &pb.ExecutionPb{
-				Amount:         big.NewInt(execution.Amount).Bytes(),
+				Amount:         amount.Bytes(),
 				Executor:       execution.Executor,
 				Contract:       execution.Contract,
 				ExecutorPubKey: nil,
@@ -1045,43 +1565,81 @@ func (exp *Service) ReadExecutionState(execution explorer.Execution) (string, er
 		Version:   uint32(execution.Version),
 		Nonce:     uint64(execution.Nonce),
 		GasLimit:  uint64(execution.GasLimit),
-		GasPrice:  big.NewInt(execution.GasPrice).Bytes(),
+		GasPrice:  gasPrice.Bytes(),
 		Signature: signature,
 	}
 
 	sc := &action.Execution{}
 	sc.ConvertFromActionPb(actPb)
-	res, err := exp.bc.ExecuteContractRead(sc)
+	receipt, err := exp.bc.ExecuteContractRead(sc)
 	if err != nil {
 		return "", err
 	}
-	return hex.EncodeToString(res), nil
+	return hex.EncodeToString(receipt.ReturnValue), nil
 }
 
 // GetBlockOrActionByHash get block or action by a hash
+//
+// Deprecated: the amounts and the gas prices may overflow int64, use GetBlockOrActionByHashV2 instead
 func (exp *Service) GetBlockOrActionByHash(hashStr string) (explorer.GetBlkOrActResponse, error) {
-	if blk, err := exp.GetBlockByID(hashStr); err == nil {
-		return explorer.GetBlkOrActResponse{Block: &blk}, nil
+	res, err := exp.GetBlockOrActionByHashV2(hashStr)
+	if err != nil {
+		return explorer.GetBlkOrActResponse{}, err
+	}
+	var resp explorer.GetBlkOrActResponse
+	switch {
+	case res.Block != nil:
+		blk, err := convertBlockV2ToBlock(*res.Block)
+		if err != nil {
+			return explorer.GetBlkOrActResponse{}, err
+		}
+		resp.Block = &blk
+	case res.Transfer != nil:
+		tsf, err := convertTsfV2ToTsf(*res.Transfer)
+		if err != nil {
+			return explorer.GetBlkOrActResponse{}, err
+		}
+		resp.Transfer = &tsf
+	case res.Vote != nil:
+		vote, err := convertVoteV2ToVote(*res.Vote)
+		if err != nil {
+			return explorer.GetBlkOrActResponse{}, err
+		}
+		resp.Vote = &vote
+	case res.Execution != nil:
+		exe, err := convertExecutionV2ToExecution(*res.Execution)
+		if err != nil {
+			return explorer.GetBlkOrActResponse{}, err
+		}
+		resp.Execution = &exe
+	}
+	return resp, nil
+}
+
+// GetBlockOrActionByHashV2 get block or action by a hash, of which the amounts and the gas prices are decimal strings
+func (exp *Service) GetBlockOrActionByHashV2(hashStr string) (explorer.GetBlkOrActResponseV2, error) {
+	if blk, err := exp.GetBlockByIDV2(hashStr); err == nil {
+		return explorer.GetBlkOrActResponseV2{Block: &blk}, nil
 	}
 
-	if tsf, err := exp.GetTransferByID(hashStr); err == nil {
-		return explorer.GetBlkOrActResponse{Transfer: &tsf}, nil
+	if tsf, err := exp.GetTransferByIDV2(hashStr); err == nil {
+		return explorer.GetBlkOrActResponseV2{Transfer: &tsf}, nil
 	}
 
-	if vote, err := exp.GetVoteByID(hashStr); err == nil {
-		return explorer.GetBlkOrActResponse{Vote: &vote}, nil
+	if vote, err := exp.GetVoteByIDV2(hashStr); err == nil {
+		return explorer.GetBlkOrActResponseV2{Vote: &vote}, nil
 	}
 
-	if exe, err := exp.GetExecutionByID(hashStr); err == nil {
-		return explorer.GetBlkOrActResponse{Execution: &exe}, nil
+	if exe, err := exp.GetExecutionByIDV2(hashStr); err == nil {
+		return explorer.GetBlkOrActResponseV2{Execution: &exe}, nil
 	}
 
-	return explorer.GetBlkOrActResponse{}, nil
+	return explorer.GetBlkOrActResponseV2{}, nil
 }
 
 // getTransfer takes in a blockchain and transferHash and returns an Explorer Transfer
-func getTransfer(bc blockchain.Blockchain, ap actpool.ActPool, transferHash hash.Hash32B) (explorer.Transfer, error) {
-	explorerTransfer := explorer.Transfer{}
+func getTransfer(bc blockchain.Blockchain, ap actpool.ActPool, transferHash hash.Hash32B) (explorer.TransferV2, error) {
+	explorerTransfer := explorer.TransferV2{}
 
 	transfer, err := bc.GetTransferByTransferHash(transferHash)
 	if err != nil {
@@ -1114,8 +1672,8 @@ func getTransfer(bc blockchain.Blockchain, ap actpool.ActPool, transferHash hash
 }
 
 // getVote takes in a blockchain and voteHash and returns an Explorer Vote
-func getVote(bc blockchain.Blockchain, ap actpool.ActPool, voteHash hash.Hash32B) (explorer.Vote, error) {
-	explorerVote := explorer.Vote{}
+func getVote(bc blockchain.Blockchain, ap actpool.ActPool, voteHash hash.Hash32B) (explorer.VoteV2, error) {
+	explorerVote := explorer.VoteV2{}
 
 	vote, err := bc.GetVoteByVoteHash(voteHash)
 	if err != nil {
@@ -1148,8 +1706,8 @@ func getVote(bc blockchain.Blockchain, ap actpool.ActPool, voteHash hash.Hash32B
 }
 
 // getExecution takes in a blockchain and executionHash and returns an Explorer execution
-func getExecution(bc blockchain.Blockchain, ap actpool.ActPool, executionHash hash.Hash32B) (explorer.Execution, error) {
-	explorerExecution := explorer.Execution{}
+func getExecution(bc blockchain.Blockchain, ap actpool.ActPool, executionHash hash.Hash32B) (explorer.ExecutionV2, error) {
+	explorerExecution := explorer.ExecutionV2{}
 
 	execution, err := bc.GetExecutionByExecutionHash(executionHash)
 	if err != nil {
@@ -1181,70 +1739,294 @@ func getExecution(bc blockchain.Blockchain, ap actpool.ActPool, executionHash ha
 	return explorerExecution, nil
 }
 
-func convertTsfToExplorerTsf(transfer *action.Transfer, isPending bool) (explorer.Transfer, error) {
+func convertTsfToExplorerTsf(transfer *action.Transfer, isPending bool) (explorer.TransferV2, error) {
 	if transfer == nil {
-		return explorer.Transfer{}, errors.Wrap(ErrTransfer, "transfer cannot be nil")
+		return explorer.TransferV2{}, errors.Wrap(ErrTransfer, "transfer cannot be nil")
 	}
 	hash := transfer.Hash()
-	explorerTransfer := explorer.Transfer{
+	explorerTransfer := explorer.TransferV2{
 		Nonce:     int64(transfer.Nonce()),
 		ID:        hex.EncodeToString(hash[:]),
 		Sender:    transfer.Sender(),
 		Recipient: transfer.Recipient(),
-		Fee:       0, // TODO: we need to get the actual fee.
+		Amount:    bigIntToString(transfer.Amount()),
+		Fee:       "0", // TODO: we need to get the actual fee.
 		Payload:   hex.EncodeToString(transfer.Payload()),
 		GasLimit:  int64(transfer.GasLimit()),
+		GasPrice:  bigIntToString(transfer.GasPrice()),
 		IsPending: isPending,
 	}
-	if transfer.Amount() != nil && len(transfer.Amount().Bytes()) > 0 {
-		explorerTransfer.Amount = transfer.Amount().Int64()
+	return explorerTransfer, nil
+}
+
+// convertTsfV2ToTsf converts a transfer into the deprecated one, whose amounts are int64
+func convertTsfV2ToTsf(transfer explorer.TransferV2) (explorer.Transfer, error) {
+	amount, err := stringToInt64(transfer.Amount)
+	if err != nil {
+		return explorer.Transfer{}, errors.Wrap(err, "invalid amount")
 	}
-	if transfer.GasPrice() != nil && len(transfer.GasPrice().Bytes()) > 0 {
-		explorerTransfer.GasPrice = transfer.GasPrice().Int64()
+	gasPrice, err := stringToInt64(transfer.GasPrice)
+	if err != nil {
+		return explorer.Transfer{}, errors.Wrap(err, "invalid gas price")
 	}
-	return explorerTransfer, nil
+	fee, err := stringToInt64(transfer.Fee)
+	if err != nil {
+		return explorer.Transfer{}, errors.Wrap(err, "invalid fee")
+	}
+	return explorer.Transfer{
+		Version:      transfer.Version,
+		ID:           transfer.ID,
+		Nonce:        transfer.Nonce,
+		Sender:       transfer.Sender,
+		Recipient:    transfer.Recipient,
+		Amount:       amount,
+		SenderPubKey: transfer.SenderPubKey,
+		Signature:    transfer.Signature,
+		Payload:      transfer.Payload,
+		GasLimit:     transfer.GasLimit,
+		GasPrice:     gasPrice,
+		IsCoinbase:   transfer.IsCoinbase,
+		Fee:          fee,
+		Timestamp:    transfer.Timestamp,
+		BlockID:      transfer.BlockID,
+		IsPending:    transfer.IsPending,
+	}, nil
+}
+
+func convertTsfsV2ToTsfs(transfers []explorer.TransferV2) ([]explorer.Transfer, error) {
+	res := make([]explorer.Transfer, 0, len(transfers))
+	for _, transfer := range transfers {
+		tsf, err := convertTsfV2ToTsf(transfer)
+		if err != nil {
+			return []explorer.Transfer{}, err
+		}
+		res = append(res, tsf)
+	}
+	return res, nil
+}
+
+// bigIntToString formats an amount or a gas price as a decimal string, of which nil is zero
+func bigIntToString(n *big.Int) string {
+	if n == nil {
+		return "0"
+	}
+	return n.String()
+}
+
+// stringToBigInt parses the decimal string of an amount or a gas price, which can't be negative
+func stringToBigInt(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, errors.Errorf("%s is not a decimal integer", s)
+	}
+	if n.Sign() < 0 {
+		return nil, errors.Errorf("%s is negative", s)
+	}
+	return n, nil
+}
+
+// stringToInt64 parses the decimal string of an amount for the deprecated methods, which fails if it overflows int64
+func stringToInt64(s string) (int64, error) {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return 0, errors.Errorf("%s is not a decimal integer", s)
+	}
+	if !n.IsInt64() {
+		return 0, errors.Wrap(ErrInt64Overflow, s)
+	}
+	return n.Int64(), nil
 }
 
-func convertVoteToExplorerVote(vote *action.Vote, isPending bool) (explorer.Vote, error) {
+func convertVoteToExplorerVote(vote *action.Vote, isPending bool) (explorer.VoteV2, error) {
 	if vote == nil {
-		return explorer.Vote{}, errors.Wrap(ErrVote, "vote cannot be nil")
+		return explorer.VoteV2{}, errors.Wrap(ErrVote, "vote cannot be nil")
 	}
 	hash := vote.Hash()
 	voterPubkey := vote.VoterPublicKey()
-	explorerVote := explorer.Vote{
+	explorerVote := explorer.VoteV2{
 		ID:          hex.EncodeToString(hash[:]),
 		Nonce:       int64(vote.Nonce()),
 		Voter:       vote.Voter(),
 		VoterPubKey: hex.EncodeToString(voterPubkey[:]),
 		Votee:       vote.Votee(),
 		GasLimit:    int64(vote.GasLimit()),
-		GasPrice:    vote.GasPrice().Int64(),
+		GasPrice:    bigIntToString(vote.GasPrice()),
 		IsPending:   isPending,
 	}
 	return explorerVote, nil
 }
 
-func convertExecutionToExplorerExecution(execution *action.Execution, isPending bool) (explorer.Execution, error) {
+// convertVoteV2ToVote converts a vote into the deprecated one, whose gas price is int64
+func convertVoteV2ToVote(vote explorer.VoteV2) (explorer.Vote, error) {
+	gasPrice, err := stringToInt64(vote.GasPrice)
+	if err != nil {
+		return explorer.Vote{}, errors.Wrap(err, "invalid gas price")
+	}
+	return explorer.Vote{
+		Version:     vote.Version,
+		ID:          vote.ID,
+		Nonce:       vote.Nonce,
+		Timestamp:   vote.Timestamp,
+		Voter:       vote.Voter,
+		Votee:       vote.Votee,
+		VoterPubKey: vote.VoterPubKey,
+		GasLimit:    vote.GasLimit,
+		GasPrice:    gasPrice,
+		Signature:   vote.Signature,
+		BlockID:     vote.BlockID,
+		IsPending:   vote.IsPending,
+	}, nil
+}
+
+func convertVotesV2ToVotes(votes []explorer.VoteV2) ([]explorer.Vote, error) {
+	res := make([]explorer.Vote, 0, len(votes))
+	for _, vote := range votes {
+		v, err := convertVoteV2ToVote(vote)
+		if err != nil {
+			return []explorer.Vote{}, err
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+func convertExecutionToExplorerExecution(execution *action.Execution, isPending bool) (explorer.ExecutionV2, error) {
 	if execution == nil {
-		return explorer.Execution{}, errors.Wrap(ErrExecution, "execution cannot be nil")
+		return explorer.ExecutionV2{}, errors.Wrap(ErrExecution, "execution cannot be nil")
 	}
 	hash := execution.Hash()
-	explorerExecution := explorer.Execution{
+	explorerExecution := explorer.ExecutionV2{
 		Nonce:     int64(execution.Nonce()),
 		ID:        hex.EncodeToString(hash[:]),
 		Executor:  execution.Executor(),
 		Contract:  execution.Contract(),
+		Amount:    bigIntToString(execution.Amount()),
 		GasLimit:  int64(execution.GasLimit()),
+		GasPrice:  bigIntToString(execution.GasPrice()),
 		Data:      hex.EncodeToString(execution.Data()),
 		IsPending: isPending,
 	}
-	if execution.Amount() != nil && len(execution.Amount().Bytes()) > 0 {
-		explorerExecution.Amount = execution.Amount().Int64()
+	return explorerExecution, nil
+}
+
+// convertExecutionV2ToExecution converts an execution into the deprecated one, whose amount and gas price are int64
+func convertExecutionV2ToExecution(execution explorer.ExecutionV2) (explorer.Execution, error) {
+	amount, err := stringToInt64(execution.Amount)
+	if err != nil {
+		return explorer.Execution{}, errors.Wrap(err, "invalid amount")
+	}
+	gasPrice, err := stringToInt64(execution.GasPrice)
+	if err != nil {
+		return explorer.Execution{}, errors.Wrap(err, "invalid gas price")
+	}
+	return explorer.Execution{
+		Version:        execution.Version,
+		ID:             execution.ID,
+		Nonce:          execution.Nonce,
+		Executor:       execution.Executor,
+		Contract:       execution.Contract,
+		Amount:         amount,
+		ExecutorPubKey: execution.ExecutorPubKey,
+		Signature:      execution.Signature,
+		GasLimit:       execution.GasLimit,
+		GasPrice:       gasPrice,
+		Timestamp:      execution.Timestamp,
+		Data:           execution.Data,
+		BlockID:        execution.BlockID,
+		IsPending:      execution.IsPending,
+	}, nil
+}
+
+func convertExecutionsV2ToExecutions(executions []explorer.ExecutionV2) ([]explorer.Execution, error) {
+	res := make([]explorer.Execution, 0, len(executions))
+	for _, execution := range executions {
+		e, err := convertExecutionV2ToExecution(execution)
+		if err != nil {
+			return []explorer.Execution{}, err
+		}
+		res = append(res, e)
 	}
-	if execution.GasPrice() != nil && len(execution.GasPrice().Bytes()) > 0 {
-		explorerExecution.GasPrice = execution.GasPrice().Int64()
+	return res, nil
+}
+
+// convertExecutionToExecutionV2 converts a deprecated execution request, whose amount and gas price are int64
+func convertExecutionToExecutionV2(execution explorer.Execution) explorer.ExecutionV2 {
+	return explorer.ExecutionV2{
+		Version:        execution.Version,
+		ID:             execution.ID,
+		Nonce:          execution.Nonce,
+		Executor:       execution.Executor,
+		Contract:       execution.Contract,
+		Amount:         strconv.FormatInt(execution.Amount, 10),
+		ExecutorPubKey: execution.ExecutorPubKey,
+		Signature:      execution.Signature,
+		GasLimit:       execution.GasLimit,
+		GasPrice:       strconv.FormatInt(execution.GasPrice, 10),
+		Timestamp:      execution.Timestamp,
+		Data:           execution.Data,
+		BlockID:        execution.BlockID,
+		IsPending:      execution.IsPending,
 	}
-	return explorerExecution, nil
+}
+
+// convertBlockV2ToBlock converts a block into the deprecated one, whose amount is int64
+func convertBlockV2ToBlock(block explorer.BlockV2) (explorer.Block, error) {
+	amount, err := stringToInt64(block.Amount)
+	if err != nil {
+		return explorer.Block{}, errors.Wrap(err, "invalid amount")
+	}
+	return explorer.Block{
+		ID:         block.ID,
+		Height:     block.Height,
+		Timestamp:  block.Timestamp,
+		Transfers:  block.Transfers,
+		Votes:      block.Votes,
+		Executions: block.Executions,
+		GenerateBy: block.GenerateBy,
+		Amount:     amount,
+		Forged:     block.Forged,
+		Size:       block.Size,
+	}, nil
+}
+
+func convertBlocksV2ToBlocks(blocks []explorer.BlockV2) ([]explorer.Block, error) {
+	res := make([]explorer.Block, 0, len(blocks))
+	for _, block := range blocks {
+		blk, err := convertBlockV2ToBlock(block)
+		if err != nil {
+			return []explorer.Block{}, err
+		}
+		res = append(res, blk)
+	}
+	return res, nil
+}
+
+// convertCandidateMetricsV2ToCandidateMetrics converts the candidate metrics into the deprecated ones, whose votes are
+// int64
+func convertCandidateMetricsV2ToCandidateMetrics(
+	metrics explorer.CandidateMetricsV2,
+) (explorer.CandidateMetrics, error) {
+	candidates := make([]explorer.Candidate, 0, len(metrics.Candidates))
+	for _, c := range metrics.Candidates {
+		totalVote, err := stringToInt64(c.TotalVote)
+		if err != nil {
+			return explorer.CandidateMetrics{}, errors.Wrapf(err, "invalid votes of candidate %s", c.Address)
+		}
+		candidates = append(candidates, explorer.Candidate{
+			Address:          c.Address,
+			PubKey:           c.PubKey,
+			TotalVote:        totalVote,
+			CreationHeight:   c.CreationHeight,
+			LastUpdateHeight: c.LastUpdateHeight,
+			IsDelegate:       c.IsDelegate,
+			IsProducer:       c.IsProducer,
+		})
+	}
+	return explorer.CandidateMetrics{
+		Candidates:   candidates,
+		LatestEpoch:  metrics.LatestEpoch,
+		LatestHeight: metrics.LatestHeight,
+	}, nil
 }
 
 func convertReceiptToExplorerReceipt(receipt *blockchain.Receipt) (explorer.Receipt, error) {
@@ -10,11 +10,15 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"math/big"
 	"net"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,10 +27,12 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/action"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
 	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
 	"github.com/iotexproject/iotex-core/network/node"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
+	pb "github.com/iotexproject/iotex-core/proto"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
@@ -170,6 +176,7 @@ func TestExplorerApi(t *testing.T) {
 		cfg: config.Explorer{
 			TpsWindow:               10,
 			MaxTransferPayloadBytes: 1024,
+			MaxPageSize:             10,
 		},
 	}
 
@@ -201,6 +208,57 @@ func TestExplorerApi(t *testing.T) {
 	require.Nil(err)
 	require.Equal(1, len(executions))
 
+	// Page through the transfers of charlie in both orders
+	for _, oldestFirst := range []bool{false, true} {
+		var paged []explorer.TransferV2
+		cursor := ""
+		for {
+			page, err := svc.GetTransfersByAddressPage(ta.Addrinfo["charlie"].RawAddress, cursor, 2, oldestFirst)
+			require.Nil(err)
+			require.True(len(page.Transfers) <= 2)
+			paged = append(paged, page.Transfers...)
+			if page.Next == "" {
+				break
+			}
+			cursor = page.Next
+		}
+		require.Equal(5, len(paged))
+		ids := make(map[string]bool)
+		for i, transfer := range paged {
+			require.False(ids[transfer.ID])
+			ids[transfer.ID] = true
+			if i > 0 && oldestFirst {
+				require.True(paged[i-1].Timestamp <= transfer.Timestamp)
+			} else if i > 0 {
+				require.True(paged[i-1].Timestamp >= transfer.Timestamp)
+			}
+		}
+	}
+
+	votePage, err := svc.GetVotesByAddressPage(ta.Addrinfo["charlie"].RawAddress, "", 10, true)
+	require.Nil(err)
+	require.Equal(3, len(votePage.Votes))
+	require.Equal("", votePage.Next)
+
+	executionPage, err := svc.GetExecutionsByAddressPage(ta.Addrinfo["charlie"].RawAddress, "", 1, false)
+	require.Nil(err)
+	require.Equal(1, len(executionPage.Executions))
+	require.NotEqual("", executionPage.Next)
+	executionPage, err = svc.GetExecutionsByAddressPage(ta.Addrinfo["charlie"].RawAddress, executionPage.Next, 1, false)
+	require.Nil(err)
+	require.Equal(1, len(executionPage.Executions))
+	require.Equal("", executionPage.Next)
+
+	// fail
+	_, err = svc.GetTransfersByAddressPage(ta.Addrinfo["charlie"].RawAddress, "invalid", 2, false)
+	require.Equal(ErrCursor, errors.Cause(err))
+	_, err = svc.GetTransfersByAddressPage(ta.Addrinfo["charlie"].RawAddress, "", 11, false)
+	require.Equal(ErrPageSize, errors.Cause(err))
+	_, err = svc.GetVotesByAddressPage(ta.Addrinfo["charlie"].RawAddress, "", math.MaxInt64, true)
+	require.Equal(ErrPageSize, errors.Cause(err))
+	_, err = svc.GetExecutionsByAddressPage(ta.Addrinfo["charlie"].RawAddress, "", 11, false)
+	require.Equal(ErrPageSize, errors.Cause(err))
+
 	transfers, err = svc.GetLastTransfersByRange(4, 1, 3, true)
 	require.Equal(3, len(transfers))
 	require.Nil(err)
@@ -339,6 +397,46 @@ func TestExplorerApi(t *testing.T) {
 	_, err = svc.GetAddressDetails("")
 	require.Error(err)
 
+	balanceV2, err := svc.GetAddressBalanceV2(ta.Addrinfo["charlie"].RawAddress)
+	require.Nil(err)
+	require.Equal("6", balanceV2)
+	addressDetailsV2, err := svc.GetAddressDetailsV2(ta.Addrinfo["charlie"].RawAddress)
+	require.Nil(err)
+	require.Equal("6", addressDetailsV2.TotalBalance)
+	require.Equal(int64(9), addressDetailsV2.PendingNonce)
+
+	transfersV2, err := svc.GetTransfersByAddressV2(ta.Addrinfo["charlie"].RawAddress, 0, 10)
+	require.Nil(err)
+	require.Equal(5, len(transfersV2))
+	transferV2, err := svc.GetTransferByIDV2(transfer.ID)
+	require.Nil(err)
+	require.Equal(transfer.ID, transferV2.ID)
+	require.Equal(strconv.FormatInt(transfer.Amount, 10), transferV2.Amount)
+	require.Equal(strconv.FormatInt(transfer.GasPrice, 10), transferV2.GasPrice)
+
+	voteV2, err := svc.GetVoteByIDV2(vote.ID)
+	require.Nil(err)
+	require.Equal(vote.ID, voteV2.ID)
+	require.Equal(strconv.FormatInt(vote.GasPrice, 10), voteV2.GasPrice)
+	executionV2, err := svc.GetExecutionByIDV2(execution.ID)
+	require.Nil(err)
+	require.Equal(execution.ID, executionV2.ID)
+	require.Equal(strconv.FormatInt(execution.Amount, 10), executionV2.Amount)
+	require.Equal(strconv.FormatInt(execution.GasPrice, 10), executionV2.GasPrice)
+	blkV2, err := svc.GetBlockByIDV2(blk.ID)
+	require.Nil(err)
+	require.Equal(blk.ID, blkV2.ID)
+	require.Equal(strconv.FormatInt(blk.Amount, 10), blkV2.Amount)
+	blksV2, err := svc.GetLastBlocksByRangeV2(3, 4)
+	require.Nil(err)
+	convertedBlks, err := convertBlocksV2ToBlocks(blksV2)
+	require.NoError(err)
+	require.Equal(convertedBlks, blks)
+	statsV2, err := svc.GetCoinStatisticV2()
+	require.Nil(err)
+	require.Equal(strconv.FormatUint(blockchain.Gen.TotalSupply, 10), statsV2.Supply)
+	require.Equal(stats.Aps, statsV2.Aps)
+
 	tip, err := svc.GetBlockchainHeight()
 	require.Nil(err)
 	require.Equal(4, int(tip))
@@ -418,6 +516,15 @@ func TestExplorerApi(t *testing.T) {
 	require.Nil(res.Transfer)
 	require.Nil(res.Vote)
 	require.Equal(&executions[0], res.Execution)
+
+	resV2, err := svc.GetBlockOrActionByHashV2(executions[0].ID)
+	require.NoError(err)
+	require.Nil(resV2.Block)
+	require.Nil(resV2.Transfer)
+	require.Nil(resV2.Vote)
+	execution, err := convertExecutionV2ToExecution(*resV2.Execution)
+	require.NoError(err)
+	require.Equal(executions[0], execution)
 }
 
 func TestService_StateByAddr(t *testing.T) {
@@ -446,6 +553,35 @@ func TestService_StateByAddr(t *testing.T) {
 	require.Equal("456", state.Votee)
 }
 
+func TestService_GetDelegateProductivity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_consensus.NewMockConsensus(ctrl)
+	c.EXPECT().Productivity(uint64(2)).Return(map[string]scheme.DelegateProductivity{
+		"io1qyqsyqcy6m6hkqkj3f4w4eflm2gzydmvc0mumm7kgax4l3": {Missed: 2, Endorsed: 1},
+		"io1qyqsyqcy6nm58gjd2wr035wz5eyd5uq47zyqpng3gxe7nh": {Produced: 3, Endorsed: 3},
+	}, nil)
+	c.EXPECT().Productivity(uint64(3)).Return(nil, consensus.ErrProductivityNotTracked)
+
+	svc := Service{c: c}
+
+	p, err := svc.GetDelegateProductivity(2)
+	require.Nil(t, err)
+	require.Equal(
+		t,
+		[]explorer.DelegateProductivity{
+			{Delegate: "io1qyqsyqcy6m6hkqkj3f4w4eflm2gzydmvc0mumm7kgax4l3", Missed: 2, Endorsed: 1},
+			{Delegate: "io1qyqsyqcy6nm58gjd2wr035wz5eyd5uq47zyqpng3gxe7nh", Produced: 3, Endorsed: 3},
+		},
+		p,
+	)
+	_, err = svc.GetDelegateProductivity(3)
+	require.Equal(t, consensus.ErrProductivityNotTracked, errors.Cause(err))
+	_, err = svc.GetDelegateProductivity(0)
+	require.Error(t, err)
+}
+
 func TestService_GetConsensusMetrics(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -462,6 +598,7 @@ func TestService_GetConsensusMetrics(t *testing.T) {
 	c := mock_consensus.NewMockConsensus(ctrl)
 	c.EXPECT().Metrics().Return(scheme.ConsensusMetrics{
 		LatestEpoch:         1,
+		LatestRound:         2,
 		LatestDelegates:     candidates[:4],
 		LatestBlockProducer: candidates[3],
 		Candidates:          candidates,
@@ -473,6 +610,7 @@ func TestService_GetConsensusMetrics(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, m)
 	require.Equal(t, int64(1), m.LatestEpoch)
+	require.Equal(t, int64(2), m.LatestRound)
 	require.Equal(
 		t,
 		[]string{
@@ -532,6 +670,52 @@ func TestService_SendTransfer(t *testing.T) {
 	require.Nil(err)
 }
 
+func TestService_SendTransferV2(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mDp := mock_dispatcher.NewMockDispatcher(ctrl)
+	p2p := mock_network.NewMockOverlay(ctrl)
+	svc := Service{dp: mDp, p2p: p2p}
+
+	// The amount which overflows int64 is kept
+	amount, ok := new(big.Int).SetString("100000000000000000000000", 10)
+	require.True(ok)
+	r := explorer.SendTransferRequestV2{
+		Version:      0x1,
+		Nonce:        1,
+		Sender:       senderRawAddr,
+		Recipient:    recipientRawAddr,
+		Amount:       amount.String(),
+		SenderPubKey: senderPubKey,
+		GasPrice:     "10",
+	}
+	var broadcasted proto.Message
+	p2p.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Do(func(_ uint32, msg proto.Message) {
+		broadcasted = msg
+	}).Return(nil).Times(1)
+	mDp.EXPECT().HandleBroadcast(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+	response, err := svc.SendTransferV2(r)
+	require.Nil(err)
+	require.NotEqual("", response.Hash)
+	tsf := &action.Transfer{}
+	tsf.ConvertFromActionPb(broadcasted.(*pb.ActionPb))
+	require.Equal(amount, tsf.Amount())
+	require.Equal(big.NewInt(10), tsf.GasPrice())
+
+	for _, invalid := range []string{"", "1.5", "-1"} {
+		r.Amount = invalid
+		_, err = svc.SendTransferV2(r)
+		require.Equal(ErrTransfer, errors.Cause(err))
+	}
+	r.Amount = "1"
+	r.GasPrice = "0x10"
+	_, err = svc.SendTransferV2(r)
+	require.Equal(ErrTransfer, errors.Cause(err))
+}
+
 func TestService_SendVote(t *testing.T) {
 	require := require.New(t)
 
@@ -564,6 +748,46 @@ func TestService_SendVote(t *testing.T) {
 	require.Nil(err)
 }
 
+func TestService_SendVoteV2(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mDp := mock_dispatcher.NewMockDispatcher(ctrl)
+	p2p := mock_network.NewMockOverlay(ctrl)
+	svc := Service{dp: mDp, p2p: p2p}
+
+	// The gas price which overflows int64 is kept
+	gasPrice, ok := new(big.Int).SetString("100000000000000000000000", 10)
+	require.True(ok)
+	r := explorer.SendVoteRequestV2{
+		Version:     0x1,
+		Nonce:       1,
+		Voter:       senderRawAddr,
+		Votee:       senderRawAddr,
+		VoterPubKey: senderPubKey,
+		GasPrice:    gasPrice.String(),
+	}
+	var broadcasted proto.Message
+	p2p.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Do(func(_ uint32, msg proto.Message) {
+		broadcasted = msg
+	}).Return(nil).Times(1)
+	mDp.EXPECT().HandleBroadcast(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+	response, err := svc.SendVoteV2(r)
+	require.Nil(err)
+	require.NotEqual("", response.Hash)
+	v := &action.Vote{}
+	v.ConvertFromActionPb(broadcasted.(*pb.ActionPb))
+	require.Equal(gasPrice, v.GasPrice())
+
+	for _, invalid := range []string{"", "1.5", "-1"} {
+		r.GasPrice = invalid
+		_, err = svc.SendVoteV2(r)
+		require.Equal(ErrVote, errors.Cause(err))
+	}
+}
+
 func TestService_SendSmartContract(t *testing.T) {
 	require := require.New(t)
 
@@ -581,12 +805,28 @@ func TestService_SendSmartContract(t *testing.T) {
 	explorerExecution.ExecutorPubKey = keypair.EncodePublicKey(execution.ExecutorPublicKey())
 	explorerExecution.Signature = hex.EncodeToString(execution.Signature())
 
-	mDp.EXPECT().HandleBroadcast(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
-	p2p.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Times(1)
+	mDp.EXPECT().HandleBroadcast(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+	p2p.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Times(2)
 
-	response, err := svc.SendSmartContract(explorerExecution)
+	request, err := convertExecutionV2ToExecution(explorerExecution)
+	require.NoError(err)
+	response, err := svc.SendSmartContract(request)
 	require.NotNil(response.Hash)
 	require.Nil(err)
+
+	responseV2, err := svc.SendSmartContractV2(explorerExecution)
+	require.Nil(err)
+	require.Equal(response.Hash, responseV2.Hash)
+
+	for _, invalid := range []string{"", "1.5", "-1"} {
+		explorerExecution.Amount = invalid
+		_, err = svc.SendSmartContractV2(explorerExecution)
+		require.Equal(ErrExecution, errors.Cause(err))
+	}
+	explorerExecution.Amount = "1"
+	explorerExecution.GasPrice = "0x10"
+	_, err = svc.SendSmartContractV2(explorerExecution)
+	require.Equal(ErrExecution, errors.Cause(err))
 }
 
 func TestServiceGetPeers(t *testing.T) {
@@ -605,12 +845,15 @@ func TestServiceGetPeers(t *testing.T) {
 		&node.Node{Addr: "127.0.0.1:10004"},
 	})
 	p2p.EXPECT().Self().Return(&node.Node{Addr: "127.0.0.1:10001"})
+	bannedUntil := time.Unix(1540000000, 0)
+	p2p.EXPECT().BannedPeers().Return(map[string]time.Time{"127.0.0.1:10005": bannedUntil})
 
 	response, err := svc.GetPeers()
 	require.Nil(err)
 	require.Equal("127.0.0.1:10001", response.Self.Address)
 	require.Len(response.Peers, 3)
 	require.Equal("127.0.0.1:10003", response.Peers[1].Address)
+	require.Equal([]explorer.BannedNode{{Address: "127.0.0.1:10005", BannedUntil: bannedUntil.Unix()}}, response.Banned)
 }
 
 func TestTransferPayloadBytesLimit(t *testing.T) {
@@ -629,7 +872,8 @@ func TestTransferPayloadBytesLimit(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(
 		t,
-		"transfer payload contains 9 bytes, and is longer than 8 bytes limit: invalid transfer",
+		"invalid payload, transfer payload contains 9 bytes, and is longer than 8 bytes limit: action error: "+
+			"invalid transfer",
 		err.Error(),
 	)
 	assert.Equal(t, ErrTransfer, errors.Cause(err))
@@ -674,6 +918,39 @@ func TestExplorerCandidateMetrics(t *testing.T) {
 	require.True(7 == len(metrics.Candidates))
 	require.True(0 == metrics.LatestHeight)
 	require.True(1 == metrics.LatestEpoch)
+
+	// The votes which overflow int64 are only carried by the v2 metrics
+	votes, ok := new(big.Int).SetString("100000000000000000000", 10)
+	require.True(ok)
+	c.EXPECT().Metrics().Return(scheme.ConsensusMetrics{
+		LatestEpoch:         1,
+		LatestDelegates:     candidates[:1],
+		LatestBlockProducer: candidates[0],
+		Candidates:          candidates[:1],
+	}, nil).Times(2)
+	bc.EXPECT().CandidatesByHeight(gomock.Any()).Return([]*state.Candidate{
+		{Address: candidates[0], Votes: votes},
+	}, nil).Times(2)
+	metricsV2, err := svc.GetCandidateMetricsV2()
+	require.NoError(err)
+	require.Equal(1, len(metricsV2.Candidates))
+	require.Equal(votes.String(), metricsV2.Candidates[0].TotalVote)
+	require.True(metricsV2.Candidates[0].IsDelegate)
+	require.True(metricsV2.Candidates[0].IsProducer)
+	_, err = svc.GetCandidateMetrics()
+	require.Equal(ErrInt64Overflow, errors.Cause(err))
+}
+
+func TestStringToInt64(t *testing.T) {
+	require := require.New(t)
+
+	n, err := stringToInt64("9223372036854775807")
+	require.NoError(err)
+	require.Equal(int64(math.MaxInt64), n)
+	_, err = stringToInt64("9223372036854775808")
+	require.Equal(ErrInt64Overflow, errors.Cause(err))
+	_, err = stringToInt64("1.5")
+	require.Error(err)
 }
 
 func TestExplorerGetReceiptByExecutionID(t *testing.T) {
@@ -9,8 +9,8 @@ import (
 )
 
 const BarristerVersion string = "0.1.6"
-const BarristerChecksum string = "97e0f2efe11dee3190b33a6c0cb7fda8"
-const BarristerDateGenerated int64 = 1538418246052000000
+const BarristerChecksum string = "2127fb7c2099d251dbc6f3a1908260b2"
+const BarristerDateGenerated int64 = 1792359400000000000
 
 type CoinStatistic struct {
 	Height     int64 `json:"height"`
@@ -137,11 +137,19 @@ type CandidateMetrics struct {
 	LatestHeight int64       `json:"latestHeight"`
 }
 
+type DelegateProductivity struct {
+	Delegate string `json:"delegate"`
+	Produced int64  `json:"produced"`
+	Missed   int64  `json:"missed"`
+	Endorsed int64  `json:"endorsed"`
+}
+
 type ConsensusMetrics struct {
 	LatestEpoch         int64    `json:"latestEpoch"`
 	LatestDelegates     []string `json:"latestDelegates"`
 	LatestBlockProducer string   `json:"latestBlockProducer"`
 	Candidates          []string `json:"candidates"`
+	LatestRound         int64    `json:"latestRound"`
 }
 
 type SendTransferRequest struct {
@@ -181,9 +189,15 @@ type Node struct {
 	Address string `json:"address"`
 }
 
+type BannedNode struct {
+	Address     string `json:"address"`
+	BannedUntil int64  `json:"bannedUntil"`
+}
+
 type GetPeersResponse struct {
-	Self  Node   `json:"Self"`
-	Peers []Node `json:"Peers"`
+	Self   Node         `json:"Self"`
+	Peers  []Node       `json:"Peers"`
+	Banned []BannedNode `json:"Banned"`
 }
 
 type SendSmartContractResponse struct {
@@ -197,6 +211,150 @@ type GetBlkOrActResponse struct {
 	Execution *Execution `json:"execution,omitempty"`
 }
 
+type TransferV2 struct {
+	Version      int64  `json:"version"`
+	ID           string `json:"ID"`
+	Nonce        int64  `json:"nonce"`
+	Sender       string `json:"sender"`
+	Recipient    string `json:"recipient"`
+	Amount       string `json:"amount"`
+	SenderPubKey string `json:"senderPubKey"`
+	Signature    string `json:"signature"`
+	Payload      string `json:"payload"`
+	GasLimit     int64  `json:"gasLimit"`
+	GasPrice     string `json:"gasPrice"`
+	IsCoinbase   bool   `json:"isCoinbase"`
+	Fee          string `json:"fee"`
+	Timestamp    int64  `json:"timestamp"`
+	BlockID      string `json:"blockID"`
+	IsPending    bool   `json:"isPending"`
+}
+
+type AddressDetailsV2 struct {
+	Address      string `json:"address"`
+	TotalBalance string `json:"totalBalance"`
+	Nonce        int64  `json:"nonce"`
+	PendingNonce int64  `json:"pendingNonce"`
+	IsCandidate  bool   `json:"isCandidate"`
+}
+
+type SendTransferRequestV2 struct {
+	Version      int64  `json:"version"`
+	Nonce        int64  `json:"nonce"`
+	Sender       string `json:"sender"`
+	Recipient    string `json:"recipient"`
+	Amount       string `json:"amount"`
+	SenderPubKey string `json:"senderPubKey"`
+	Signature    string `json:"signature"`
+	Payload      string `json:"payload"`
+	GasLimit     int64  `json:"gasLimit"`
+	GasPrice     string `json:"gasPrice"`
+	IsCoinbase   bool   `json:"isCoinbase"`
+}
+
+type CoinStatisticV2 struct {
+	Height     int64  `json:"height"`
+	Supply     string `json:"supply"`
+	Transfers  int64  `json:"transfers"`
+	Votes      int64  `json:"votes"`
+	Executions int64  `json:"executions"`
+	Aps        int64  `json:"aps"`
+}
+
+type BlockV2 struct {
+	ID         string         `json:"ID"`
+	Height     int64          `json:"height"`
+	Timestamp  int64          `json:"timestamp"`
+	Transfers  int64          `json:"transfers"`
+	Votes      int64          `json:"votes"`
+	Executions int64          `json:"executions"`
+	GenerateBy BlockGenerator `json:"generateBy"`
+	Amount     string         `json:"amount"`
+	Forged     int64          `json:"forged"`
+	Size       int64          `json:"size"`
+}
+
+type VoteV2 struct {
+	Version     int64  `json:"version"`
+	ID          string `json:"ID"`
+	Nonce       int64  `json:"nonce"`
+	Timestamp   int64  `json:"timestamp"`
+	Voter       string `json:"voter"`
+	Votee       string `json:"votee"`
+	VoterPubKey string `json:"voterPubKey"`
+	GasLimit    int64  `json:"gasLimit"`
+	GasPrice    string `json:"gasPrice"`
+	Signature   string `json:"signature"`
+	BlockID     string `json:"blockID"`
+	IsPending   bool   `json:"isPending"`
+}
+
+type ExecutionV2 struct {
+	Version        int64  `json:"version"`
+	ID             string `json:"ID"`
+	Nonce          int64  `json:"nonce"`
+	Executor       string `json:"executor"`
+	Contract       string `json:"contract"`
+	Amount         string `json:"amount"`
+	ExecutorPubKey string `json:"executorPubKey"`
+	Signature      string `json:"signature"`
+	GasLimit       int64  `json:"gasLimit"`
+	GasPrice       string `json:"gasPrice"`
+	Timestamp      int64  `json:"timestamp"`
+	Data           string `json:"data"`
+	BlockID        string `json:"blockID"`
+	IsPending      bool   `json:"isPending"`
+}
+
+type SendVoteRequestV2 struct {
+	Version     int64  `json:"version"`
+	Nonce       int64  `json:"nonce"`
+	Voter       string `json:"voter"`
+	Votee       string `json:"votee"`
+	VoterPubKey string `json:"voterPubKey"`
+	GasLimit    int64  `json:"gasLimit"`
+	GasPrice    string `json:"gasPrice"`
+	Signature   string `json:"signature"`
+}
+
+type CandidateV2 struct {
+	Address          string `json:"address"`
+	PubKey           string `json:"pubKey"`
+	TotalVote        string `json:"totalVote"`
+	CreationHeight   int64  `json:"creationHeight"`
+	LastUpdateHeight int64  `json:"lastUpdateHeight"`
+	IsDelegate       bool   `json:"isDelegate"`
+	IsProducer       bool   `json:"isProducer"`
+}
+
+type CandidateMetricsV2 struct {
+	Candidates   []CandidateV2 `json:"candidates"`
+	LatestEpoch  int64         `json:"latestEpoch"`
+	LatestHeight int64         `json:"latestHeight"`
+}
+
+type GetBlkOrActResponseV2 struct {
+	Block     *BlockV2     `json:"block,omitempty"`
+	Transfer  *TransferV2  `json:"transfer,omitempty"`
+	Vote      *VoteV2      `json:"vote,omitempty"`
+	Execution *ExecutionV2 `json:"execution,omitempty"`
+}
+
+type TransferPage struct {
+	Transfers []TransferV2 `json:"transfers"`
+	Next      string       `json:"next"`
+}
+
+type VotePage struct {
+	Votes []Vote `json:"votes"`
+	Next  string `json:"next"`
+}
+
+type ExecutionPage struct {
+	Executions []Execution `json:"executions"`
+	Next       string      `json:"next"`
+}
+
 type Explorer interface {
 	GetBlockchainHeight() (int64, error)
 	GetAddressBalance(address string) (int64, error)
@@ -222,6 +380,7 @@ type Explorer interface {
 	GetConsensusMetrics() (ConsensusMetrics, error)
 	GetCandidateMetrics() (CandidateMetrics, error)
 	GetCandidateMetricsByHeight(h int64) (CandidateMetrics, error)
+	GetDelegateProductivity(epoch int64) ([]DelegateProductivity, error)
 	SendTransfer(request SendTransferRequest) (SendTransferResponse, error)
 	SendVote(request SendVoteRequest) (SendVoteResponse, error)
 	SendSmartContract(request Execution) (SendSmartContractResponse, error)
@@ -229,6 +388,36 @@ type Explorer interface {
 	GetReceiptByExecutionID(id string) (Receipt, error)
 	ReadExecutionState(request Execution) (string, error)
 	GetBlockOrActionByHash(hashStr string) (GetBlkOrActResponse, error)
+	GetAddressBalanceV2(address string) (string, error)
+	GetAddressDetailsV2(address string) (AddressDetailsV2, error)
+	GetLastTransfersByRangeV2(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) ([]TransferV2, error)
+	GetTransferByIDV2(transferID string) (TransferV2, error)
+	GetTransfersByAddressV2(address string, offset int64, limit int64) ([]TransferV2, error)
+	GetUnconfirmedTransfersByAddressV2(address string, offset int64, limit int64) ([]TransferV2, error)
+	GetTransfersByBlockIDV2(blkID string, offset int64, limit int64) ([]TransferV2, error)
+	SendTransferV2(request SendTransferRequestV2) (SendTransferResponse, error)
+	GetLastVotesByRangeV2(startBlockHeight int64, offset int64, limit int64) ([]VoteV2, error)
+	GetVoteByIDV2(voteID string) (VoteV2, error)
+	GetVotesByAddressV2(address string, offset int64, limit int64) ([]VoteV2, error)
+	GetUnconfirmedVotesByAddressV2(address string, offset int64, limit int64) ([]VoteV2, error)
+	GetVotesByBlockIDV2(blkID string, offset int64, limit int64) ([]VoteV2, error)
+	GetLastExecutionsByRangeV2(startBlockHeight int64, offset int64, limit int64) ([]ExecutionV2, error)
+	GetExecutionByIDV2(executionID string) (ExecutionV2, error)
+	GetExecutionsByAddressV2(address string, offset int64, limit int64) ([]ExecutionV2, error)
+	GetUnconfirmedExecutionsByAddressV2(address string, offset int64, limit int64) ([]ExecutionV2, error)
+	GetExecutionsByBlockIDV2(blkID string, offset int64, limit int64) ([]ExecutionV2, error)
+	GetLastBlocksByRangeV2(offset int64, limit int64) ([]BlockV2, error)
+	GetBlockByIDV2(blkID string) (BlockV2, error)
+	GetCoinStatisticV2() (CoinStatisticV2, error)
+	SendVoteV2(request SendVoteRequestV2) (SendVoteResponse, error)
+	SendSmartContractV2(request ExecutionV2) (SendSmartContractResponse, error)
+	ReadExecutionStateV2(request ExecutionV2) (string, error)
+	GetBlockOrActionByHashV2(hashStr string) (GetBlkOrActResponseV2, error)
+	GetCandidateMetricsV2() (CandidateMetricsV2, error)
+	GetCandidateMetricsByHeightV2(h int64) (CandidateMetricsV2, error)
+	GetTransfersByAddressPage(address string, cursor string, limit int64, oldestFirst bool) (TransferPage, error)
+	GetVotesByAddressPage(address string, cursor string, limit int64, oldestFirst bool) (VotePage, error)
+	GetExecutionsByAddressPage(address string, cursor string, limit int64, oldestFirst bool) (ExecutionPage, error)
 }
 
 func NewExplorerProxy(c barrister.Client) Explorer {
@@ -672,6 +861,24 @@ func (_p ExplorerProxy) GetCandidateMetricsByHeight(h int64) (CandidateMetrics,
 	return CandidateMetrics{}, _err
 }
 
+func (_p ExplorerProxy) GetDelegateProductivity(epoch int64) ([]DelegateProductivity, error) {
+	_res, _err := _p.client.Call("Explorer.getDelegateProductivity", epoch)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getDelegateProductivity").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]DelegateProductivity{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]DelegateProductivity)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getDelegateProductivity returned invalid type: %v", _t)
+			return []DelegateProductivity{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []DelegateProductivity{}, _err
+}
+
 func (_p ExplorerProxy) SendTransfer(request SendTransferRequest) (SendTransferResponse, error) {
 	_res, _err := _p.client.Call("Explorer.sendTransfer", request)
 	if _err == nil {
@@ -798,139 +1005,1701 @@ func (_p ExplorerProxy) GetBlockOrActionByHash(hashStr string) (GetBlkOrActRespo
 	return GetBlkOrActResponse{}, _err
 }
 
-func NewJSONServer(idl *barrister.Idl, forceASCII bool, explorer Explorer) barrister.Server {
-	return NewServer(idl, &barrister.JsonSerializer{forceASCII}, explorer)
+func (_p ExplorerProxy) GetAddressBalanceV2(address string) (string, error) {
+	_res, _err := _p.client.Call("Explorer.getAddressBalanceV2", address)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getAddressBalanceV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(""), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(string)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getAddressBalanceV2 returned invalid type: %v", _t)
+			return "", &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return "", _err
 }
 
-func NewServer(idl *barrister.Idl, ser barrister.Serializer, explorer Explorer) barrister.Server {
-	_svr := barrister.NewServer(idl, ser)
-	_svr.AddHandler("Explorer", explorer)
-	return _svr
+func (_p ExplorerProxy) GetAddressDetailsV2(address string) (AddressDetailsV2, error) {
+	_res, _err := _p.client.Call("Explorer.getAddressDetailsV2", address)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getAddressDetailsV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(AddressDetailsV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(AddressDetailsV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getAddressDetailsV2 returned invalid type: %v", _t)
+			return AddressDetailsV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return AddressDetailsV2{}, _err
 }
 
-var IdlJsonRaw = `[
-    {
-        "type": "comment",
-        "name": "",
-        "comment": "",
-        "value": "Copyright (c) 2018 IoTeX\nThis is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no\nwarranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent\npermitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache\nLicense 2.0 that can be found in the LICENSE file.",
-        "extends": "",
-        "fields": null,
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "comment",
-        "name": "",
-        "comment": "",
-        "value": "To compile this file:\n1. Install the barrister translator (IDL -\u003e JSON)\nyou need to be root (or use sudo)\npip install barrister",
-        "extends": "",
-        "fields": null,
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "comment",
-        "name": "",
-        "comment": "",
-        "value": "2. Install barrister-go\ngo get github.com/coopernurse/barrister-go\ngo install github.com/coopernurse/barrister-go/idl2go",
-        "extends": "",
-        "fields": null,
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "comment",
-        "name": "",
-        "comment": "",
-        "value": "3. barrister explorer.idl | $GOPATH/bin/idl2go -i -p explorer",
-        "extends": "",
-        "fields": null,
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "struct",
-        "name": "CoinStatistic",
-        "comment": "",
-        "value": "",
-        "extends": "",
-        "fields": [
-            {
-                "name": "height",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            },
-            {
-                "name": "supply",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            },
-            {
-                "name": "transfers",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            },
-            {
-                "name": "votes",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            },
-            {
-                "name": "executions",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            },
-            {
-                "name": "aps",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            }
-        ],
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "struct",
-        "name": "BlockGenerator",
-        "comment": "",
-        "value": "",
-        "extends": "",
-        "fields": [
-            {
+func (_p ExplorerProxy) GetLastTransfersByRangeV2(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) ([]TransferV2, error) {
+	_res, _err := _p.client.Call("Explorer.getLastTransfersByRangeV2", startBlockHeight, offset, limit, showCoinBase)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getLastTransfersByRangeV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]TransferV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]TransferV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getLastTransfersByRangeV2 returned invalid type: %v", _t)
+			return []TransferV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []TransferV2{}, _err
+}
+
+func (_p ExplorerProxy) GetTransferByIDV2(transferID string) (TransferV2, error) {
+	_res, _err := _p.client.Call("Explorer.getTransferByIDV2", transferID)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getTransferByIDV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(TransferV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(TransferV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getTransferByIDV2 returned invalid type: %v", _t)
+			return TransferV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return TransferV2{}, _err
+}
+
+func (_p ExplorerProxy) GetTransfersByAddressV2(address string, offset int64, limit int64) ([]TransferV2, error) {
+	_res, _err := _p.client.Call("Explorer.getTransfersByAddressV2", address, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getTransfersByAddressV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]TransferV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]TransferV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getTransfersByAddressV2 returned invalid type: %v", _t)
+			return []TransferV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []TransferV2{}, _err
+}
+
+func (_p ExplorerProxy) GetUnconfirmedTransfersByAddressV2(address string, offset int64, limit int64) ([]TransferV2, error) {
+	_res, _err := _p.client.Call("Explorer.getUnconfirmedTransfersByAddressV2", address, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getUnconfirmedTransfersByAddressV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]TransferV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]TransferV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getUnconfirmedTransfersByAddressV2 returned invalid type: %v", _t)
+			return []TransferV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []TransferV2{}, _err
+}
+
+func (_p ExplorerProxy) GetTransfersByBlockIDV2(blkID string, offset int64, limit int64) ([]TransferV2, error) {
+	_res, _err := _p.client.Call("Explorer.getTransfersByBlockIDV2", blkID, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getTransfersByBlockIDV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]TransferV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]TransferV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getTransfersByBlockIDV2 returned invalid type: %v", _t)
+			return []TransferV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []TransferV2{}, _err
+}
+
+func (_p ExplorerProxy) SendTransferV2(request SendTransferRequestV2) (SendTransferResponse, error) {
+	_res, _err := _p.client.Call("Explorer.sendTransferV2", request)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.sendTransferV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(SendTransferResponse{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(SendTransferResponse)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.sendTransferV2 returned invalid type: %v", _t)
+			return SendTransferResponse{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return SendTransferResponse{}, _err
+}
+
+func (_p ExplorerProxy) GetLastVotesByRangeV2(startBlockHeight int64, offset int64, limit int64) ([]VoteV2, error) {
+	_res, _err := _p.client.Call("Explorer.getLastVotesByRangeV2", startBlockHeight, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getLastVotesByRangeV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]VoteV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]VoteV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getLastVotesByRangeV2 returned invalid type: %v", _t)
+			return []VoteV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []VoteV2{}, _err
+}
+
+func (_p ExplorerProxy) GetVoteByIDV2(voteID string) (VoteV2, error) {
+	_res, _err := _p.client.Call("Explorer.getVoteByIDV2", voteID)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getVoteByIDV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(VoteV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(VoteV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getVoteByIDV2 returned invalid type: %v", _t)
+			return VoteV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return VoteV2{}, _err
+}
+
+func (_p ExplorerProxy) GetVotesByAddressV2(address string, offset int64, limit int64) ([]VoteV2, error) {
+	_res, _err := _p.client.Call("Explorer.getVotesByAddressV2", address, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getVotesByAddressV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]VoteV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]VoteV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getVotesByAddressV2 returned invalid type: %v", _t)
+			return []VoteV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []VoteV2{}, _err
+}
+
+func (_p ExplorerProxy) GetUnconfirmedVotesByAddressV2(address string, offset int64, limit int64) ([]VoteV2, error) {
+	_res, _err := _p.client.Call("Explorer.getUnconfirmedVotesByAddressV2", address, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getUnconfirmedVotesByAddressV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]VoteV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]VoteV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getUnconfirmedVotesByAddressV2 returned invalid type: %v", _t)
+			return []VoteV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []VoteV2{}, _err
+}
+
+func (_p ExplorerProxy) GetVotesByBlockIDV2(blkID string, offset int64, limit int64) ([]VoteV2, error) {
+	_res, _err := _p.client.Call("Explorer.getVotesByBlockIDV2", blkID, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getVotesByBlockIDV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]VoteV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]VoteV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getVotesByBlockIDV2 returned invalid type: %v", _t)
+			return []VoteV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []VoteV2{}, _err
+}
+
+func (_p ExplorerProxy) GetLastExecutionsByRangeV2(startBlockHeight int64, offset int64, limit int64) ([]ExecutionV2, error) {
+	_res, _err := _p.client.Call("Explorer.getLastExecutionsByRangeV2", startBlockHeight, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getLastExecutionsByRangeV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]ExecutionV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]ExecutionV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getLastExecutionsByRangeV2 returned invalid type: %v", _t)
+			return []ExecutionV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []ExecutionV2{}, _err
+}
+
+func (_p ExplorerProxy) GetExecutionByIDV2(executionID string) (ExecutionV2, error) {
+	_res, _err := _p.client.Call("Explorer.getExecutionByIDV2", executionID)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getExecutionByIDV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(ExecutionV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(ExecutionV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getExecutionByIDV2 returned invalid type: %v", _t)
+			return ExecutionV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return ExecutionV2{}, _err
+}
+
+func (_p ExplorerProxy) GetExecutionsByAddressV2(address string, offset int64, limit int64) ([]ExecutionV2, error) {
+	_res, _err := _p.client.Call("Explorer.getExecutionsByAddressV2", address, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getExecutionsByAddressV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]ExecutionV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]ExecutionV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getExecutionsByAddressV2 returned invalid type: %v", _t)
+			return []ExecutionV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []ExecutionV2{}, _err
+}
+
+func (_p ExplorerProxy) GetUnconfirmedExecutionsByAddressV2(address string, offset int64, limit int64) ([]ExecutionV2, error) {
+	_res, _err := _p.client.Call("Explorer.getUnconfirmedExecutionsByAddressV2", address, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getUnconfirmedExecutionsByAddressV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]ExecutionV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]ExecutionV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getUnconfirmedExecutionsByAddressV2 returned invalid type: %v", _t)
+			return []ExecutionV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []ExecutionV2{}, _err
+}
+
+func (_p ExplorerProxy) GetExecutionsByBlockIDV2(blkID string, offset int64, limit int64) ([]ExecutionV2, error) {
+	_res, _err := _p.client.Call("Explorer.getExecutionsByBlockIDV2", blkID, offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getExecutionsByBlockIDV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]ExecutionV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]ExecutionV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getExecutionsByBlockIDV2 returned invalid type: %v", _t)
+			return []ExecutionV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []ExecutionV2{}, _err
+}
+
+func (_p ExplorerProxy) GetLastBlocksByRangeV2(offset int64, limit int64) ([]BlockV2, error) {
+	_res, _err := _p.client.Call("Explorer.getLastBlocksByRangeV2", offset, limit)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getLastBlocksByRangeV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]BlockV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]BlockV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getLastBlocksByRangeV2 returned invalid type: %v", _t)
+			return []BlockV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []BlockV2{}, _err
+}
+
+func (_p ExplorerProxy) GetBlockByIDV2(blkID string) (BlockV2, error) {
+	_res, _err := _p.client.Call("Explorer.getBlockByIDV2", blkID)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getBlockByIDV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(BlockV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(BlockV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getBlockByIDV2 returned invalid type: %v", _t)
+			return BlockV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return BlockV2{}, _err
+}
+
+func (_p ExplorerProxy) GetCoinStatisticV2() (CoinStatisticV2, error) {
+	_res, _err := _p.client.Call("Explorer.getCoinStatisticV2")
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getCoinStatisticV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(CoinStatisticV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(CoinStatisticV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getCoinStatisticV2 returned invalid type: %v", _t)
+			return CoinStatisticV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return CoinStatisticV2{}, _err
+}
+
+func (_p ExplorerProxy) SendVoteV2(request SendVoteRequestV2) (SendVoteResponse, error) {
+	_res, _err := _p.client.Call("Explorer.sendVoteV2", request)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.sendVoteV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(SendVoteResponse{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(SendVoteResponse)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.sendVoteV2 returned invalid type: %v", _t)
+			return SendVoteResponse{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return SendVoteResponse{}, _err
+}
+
+func (_p ExplorerProxy) SendSmartContractV2(request ExecutionV2) (SendSmartContractResponse, error) {
+	_res, _err := _p.client.Call("Explorer.sendSmartContractV2", request)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.sendSmartContractV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(SendSmartContractResponse{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(SendSmartContractResponse)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.sendSmartContractV2 returned invalid type: %v", _t)
+			return SendSmartContractResponse{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return SendSmartContractResponse{}, _err
+}
+
+func (_p ExplorerProxy) ReadExecutionStateV2(request ExecutionV2) (string, error) {
+	_res, _err := _p.client.Call("Explorer.readExecutionStateV2", request)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.readExecutionStateV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(""), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(string)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.readExecutionStateV2 returned invalid type: %v", _t)
+			return "", &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return "", _err
+}
+
+func (_p ExplorerProxy) GetBlockOrActionByHashV2(hashStr string) (GetBlkOrActResponseV2, error) {
+	_res, _err := _p.client.Call("Explorer.getBlockOrActionByHashV2", hashStr)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getBlockOrActionByHashV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(GetBlkOrActResponseV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(GetBlkOrActResponseV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getBlockOrActionByHashV2 returned invalid type: %v", _t)
+			return GetBlkOrActResponseV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return GetBlkOrActResponseV2{}, _err
+}
+
+func (_p ExplorerProxy) GetCandidateMetricsV2() (CandidateMetricsV2, error) {
+	_res, _err := _p.client.Call("Explorer.getCandidateMetricsV2")
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getCandidateMetricsV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(CandidateMetricsV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(CandidateMetricsV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getCandidateMetricsV2 returned invalid type: %v", _t)
+			return CandidateMetricsV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return CandidateMetricsV2{}, _err
+}
+
+func (_p ExplorerProxy) GetCandidateMetricsByHeightV2(h int64) (CandidateMetricsV2, error) {
+	_res, _err := _p.client.Call("Explorer.getCandidateMetricsByHeightV2", h)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getCandidateMetricsByHeightV2").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(CandidateMetricsV2{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(CandidateMetricsV2)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getCandidateMetricsByHeightV2 returned invalid type: %v", _t)
+			return CandidateMetricsV2{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return CandidateMetricsV2{}, _err
+}
+
+func (_p ExplorerProxy) GetTransfersByAddressPage(address string, cursor string, limit int64, oldestFirst bool) (TransferPage, error) {
+	_res, _err := _p.client.Call("Explorer.getTransfersByAddressPage", address, cursor, limit, oldestFirst)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getTransfersByAddressPage").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(TransferPage{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(TransferPage)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getTransfersByAddressPage returned invalid type: %v", _t)
+			return TransferPage{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return TransferPage{}, _err
+}
+
+func (_p ExplorerProxy) GetVotesByAddressPage(address string, cursor string, limit int64, oldestFirst bool) (VotePage, error) {
+	_res, _err := _p.client.Call("Explorer.getVotesByAddressPage", address, cursor, limit, oldestFirst)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getVotesByAddressPage").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(VotePage{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(VotePage)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getVotesByAddressPage returned invalid type: %v", _t)
+			return VotePage{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return VotePage{}, _err
+}
+
+func (_p ExplorerProxy) GetExecutionsByAddressPage(address string, cursor string, limit int64, oldestFirst bool) (ExecutionPage, error) {
+	_res, _err := _p.client.Call("Explorer.getExecutionsByAddressPage", address, cursor, limit, oldestFirst)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getExecutionsByAddressPage").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(ExecutionPage{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(ExecutionPage)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getExecutionsByAddressPage returned invalid type: %v", _t)
+			return ExecutionPage{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return ExecutionPage{}, _err
+}
+
+func NewJSONServer(idl *barrister.Idl, forceASCII bool, explorer Explorer) barrister.Server {
+	return NewServer(idl, &barrister.JsonSerializer{forceASCII}, explorer)
+}
+
+func NewServer(idl *barrister.Idl, ser barrister.Serializer, explorer Explorer) barrister.Server {
+	_svr := barrister.NewServer(idl, ser)
+	_svr.AddHandler("Explorer", explorer)
+	return _svr
+}
+
+var IdlJsonRaw = `[
+    {
+        "type": "comment",
+        "name": "",
+        "comment": "",
+        "value": "Copyright (c) 2018 IoTeX\nThis is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no\nwarranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent\npermitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache\nLicense 2.0 that can be found in the LICENSE file.",
+        "extends": "",
+        "fields": null,
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "comment",
+        "name": "",
+        "comment": "",
+        "value": "To compile this file:\n1. Install the barrister translator (IDL -\u003e JSON)\nyou need to be root (or use sudo)\npip install barrister",
+        "extends": "",
+        "fields": null,
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "comment",
+        "name": "",
+        "comment": "",
+        "value": "2. Install barrister-go\ngo get github.com/coopernurse/barrister-go\ngo install github.com/coopernurse/barrister-go/idl2go",
+        "extends": "",
+        "fields": null,
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "comment",
+        "name": "",
+        "comment": "",
+        "value": "3. barrister explorer.idl | $GOPATH/bin/idl2go -i -p explorer",
+        "extends": "",
+        "fields": null,
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "CoinStatistic",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "height",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "supply",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "transfers",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "votes",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "executions",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "aps",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "BlockGenerator",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
                 "name": "name",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
-            },
+            },
+            {
+                "name": "address",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "Block",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "ID",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "height",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "timestamp",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "transfers",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "votes",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "executions",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "generateBy",
+                "type": "BlockGenerator",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "amount",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "forged",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "size",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "Transfer",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "version",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "ID",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "nonce",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "sender",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "recipient",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "amount",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "senderPubKey",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "signature",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "payload",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasLimit",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasPrice",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "isCoinbase",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "fee",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "timestamp",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "blockID",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "isPending",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "Execution",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "version",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "ID",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "nonce",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "executor",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "contract",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "amount",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "executorPubKey",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "signature",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasLimit",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasPrice",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "timestamp",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "data",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "blockID",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "isPending",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "Log",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "address",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "topics",
+                "type": "string",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            },
+            {
+                "name": "data",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "blockNumber",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "txnHash",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "blockHash",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "index",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "Receipt",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "returnValue",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "status",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "hash",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasConsumed",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "contractAddress",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "logs",
+                "type": "Log",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "SendExecutionResponse",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "receipt",
+                "type": "Receipt",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "Vote",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "version",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "ID",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "nonce",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "timestamp",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "voter",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "votee",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "voterPubKey",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasLimit",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasPrice",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "signature",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "blockID",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "isPending",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "AddressDetails",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "address",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "totalBalance",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "nonce",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "pendingNonce",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "isCandidate",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "Candidate",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "address",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "pubKey",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "totalVote",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "creationHeight",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "lastUpdateHeight",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "isDelegate",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "isProducer",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "CandidateMetrics",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "candidates",
+                "type": "Candidate",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            },
+            {
+                "name": "latestEpoch",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "latestHeight",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "DelegateProductivity",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "delegate",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "produced",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "missed",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "endorsed",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "ConsensusMetrics",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "latestEpoch",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "latestDelegates",
+                "type": "string",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            },
+            {
+                "name": "latestBlockProducer",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "candidates",
+                "type": "string",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            },
+            {
+                "name": "latestRound",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "SendTransferRequest",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "version",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "nonce",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "sender",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "recipient",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "amount",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "senderPubKey",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "signature",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "payload",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasLimit",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasPrice",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "isCoinbase",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "SendTransferResponse",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "hash",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "SendVoteRequest",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "version",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "nonce",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "voter",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "votee",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "voterPubKey",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasLimit",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasPrice",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "signature",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "SendVoteResponse",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "hash",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "Node",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
             {
                 "name": "address",
                 "type": "string",
@@ -947,77 +2716,294 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "Block",
+        "name": "BannedNode",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "ID",
+                "name": "address",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "height",
+                "name": "bannedUntil",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "GetPeersResponse",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "Self",
+                "type": "Node",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
             },
             {
-                "name": "timestamp",
+                "name": "Peers",
+                "type": "Node",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            },
+            {
+                "name": "Banned",
+                "type": "BannedNode",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "SendSmartContractResponse",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "hash",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "GetBlkOrActResponse",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "block",
+                "type": "Block",
+                "optional": true,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "transfer",
+                "type": "Transfer",
+                "optional": true,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "vote",
+                "type": "Vote",
+                "optional": true,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "execution",
+                "type": "Execution",
+                "optional": true,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "TransferV2",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "version",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "transfers",
+                "name": "ID",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "nonce",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "votes",
+                "name": "sender",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "recipient",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "amount",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "senderPubKey",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "signature",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "payload",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasLimit",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "executions",
+                "name": "gasPrice",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "isCoinbase",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "fee",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "timestamp",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "generateBy",
-                "type": "BlockGenerator",
+                "name": "blockID",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "isPending",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "AddressDetailsV2",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "address",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "totalBalance",
+                "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "amount",
+                "name": "nonce",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "forged",
+                "name": "pendingNonce",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "size",
-                "type": "int",
+                "name": "isCandidate",
+                "type": "bool",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
@@ -1031,7 +3017,7 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "Transfer",
+        "name": "SendTransferRequestV2",
         "comment": "",
         "value": "",
         "extends": "",
@@ -1043,13 +3029,6 @@ var IdlJsonRaw = `[
                 "is_array": false,
                 "comment": ""
             },
-            {
-                "name": "ID",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            },
             {
                 "name": "nonce",
                 "type": "int",
@@ -1073,7 +3052,7 @@ var IdlJsonRaw = `[
             },
             {
                 "name": "amount",
-                "type": "int",
+                "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
@@ -1108,7 +3087,7 @@ var IdlJsonRaw = `[
             },
             {
                 "name": "gasPrice",
-                "type": "int",
+                "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
@@ -1119,31 +3098,59 @@ var IdlJsonRaw = `[
                 "optional": false,
                 "is_array": false,
                 "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "CoinStatisticV2",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "height",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
             },
             {
-                "name": "fee",
+                "name": "supply",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "transfers",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "timestamp",
+                "name": "votes",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "blockID",
-                "type": "string",
+                "name": "executions",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "isPending",
-                "type": "bool",
+                "name": "aps",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
@@ -1157,18 +3164,11 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "Execution",
+        "name": "BlockV2",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
-            {
-                "name": "version",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            },
             {
                 "name": "ID",
                 "type": "string",
@@ -1177,85 +3177,64 @@ var IdlJsonRaw = `[
                 "comment": ""
             },
             {
-                "name": "nonce",
+                "name": "height",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "executor",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            },
-            {
-                "name": "contract",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            },
-            {
-                "name": "amount",
+                "name": "timestamp",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "executorPubKey",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            },
-            {
-                "name": "signature",
-                "type": "string",
+                "name": "transfers",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "gasLimit",
+                "name": "votes",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "gasPrice",
+                "name": "executions",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "timestamp",
-                "type": "int",
+                "name": "generateBy",
+                "type": "BlockGenerator",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "data",
+                "name": "amount",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "blockID",
-                "type": "string",
+                "name": "forged",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "isPending",
-                "type": "bool",
+                "name": "size",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
@@ -1269,133 +3248,91 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "Log",
+        "name": "VoteV2",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "address",
-                "type": "string",
+                "name": "version",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "topics",
+                "name": "ID",
                 "type": "string",
                 "optional": false,
-                "is_array": true,
+                "is_array": false,
                 "comment": ""
             },
             {
-                "name": "data",
-                "type": "string",
+                "name": "nonce",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "blockNumber",
+                "name": "timestamp",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "txnHash",
+                "name": "voter",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "blockHash",
+                "name": "votee",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "index",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            }
-        ],
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "struct",
-        "name": "Receipt",
-        "comment": "",
-        "value": "",
-        "extends": "",
-        "fields": [
-            {
-                "name": "returnValue",
+                "name": "voterPubKey",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "status",
+                "name": "gasLimit",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "hash",
+                "name": "gasPrice",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "gasConsumed",
-                "type": "int",
+                "name": "signature",
+                "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "contractAddress",
+                "name": "blockID",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "logs",
-                "type": "Log",
-                "optional": false,
-                "is_array": true,
-                "comment": ""
-            }
-        ],
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "struct",
-        "name": "SendExecutionResponse",
-        "comment": "",
-        "value": "",
-        "extends": "",
-        "fields": [
-            {
-                "name": "receipt",
-                "type": "Receipt",
+                "name": "isPending",
+                "type": "bool",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
@@ -1409,7 +3346,7 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "Vote",
+        "name": "ExecutionV2",
         "comment": "",
         "value": "",
         "extends": "",
@@ -1436,28 +3373,35 @@ var IdlJsonRaw = `[
                 "comment": ""
             },
             {
-                "name": "timestamp",
-                "type": "int",
+                "name": "executor",
+                "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "voter",
+                "name": "contract",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "votee",
+                "name": "amount",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "voterPubKey",
+                "name": "executorPubKey",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "signature",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
@@ -1472,13 +3416,20 @@ var IdlJsonRaw = `[
             },
             {
                 "name": "gasPrice",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "timestamp",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "signature",
+                "name": "data",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
@@ -1507,42 +3458,63 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "AddressDetails",
+        "name": "SendVoteRequestV2",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "address",
-                "type": "string",
+                "name": "version",
+                "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "totalBalance",
+                "name": "nonce",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "nonce",
-                "type": "int",
+                "name": "voter",
+                "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "pendingNonce",
+                "name": "votee",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "voterPubKey",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "gasLimit",
                 "type": "int",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "isCandidate",
-                "type": "bool",
+                "name": "gasPrice",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "signature",
+                "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
@@ -1556,7 +3528,7 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "Candidate",
+        "name": "CandidateV2",
         "comment": "",
         "value": "",
         "extends": "",
@@ -1577,7 +3549,7 @@ var IdlJsonRaw = `[
             },
             {
                 "name": "totalVote",
-                "type": "int",
+                "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
@@ -1619,14 +3591,14 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "CandidateMetrics",
+        "name": "CandidateMetricsV2",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
                 "name": "candidates",
-                "type": "Candidate",
+                "type": "CandidateV2",
                 "optional": false,
                 "is_array": true,
                 "comment": ""
@@ -1654,37 +3626,93 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "ConsensusMetrics",
+        "name": "GetBlkOrActResponseV2",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "latestEpoch",
-                "type": "int",
-                "optional": false,
+                "name": "block",
+                "type": "BlockV2",
+                "optional": true,
                 "is_array": false,
                 "comment": ""
             },
             {
-                "name": "latestDelegates",
-                "type": "string",
+                "name": "transfer",
+                "type": "TransferV2",
+                "optional": true,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "vote",
+                "type": "VoteV2",
+                "optional": true,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "execution",
+                "type": "ExecutionV2",
+                "optional": true,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "TransferPage",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "transfers",
+                "type": "TransferV2",
                 "optional": false,
                 "is_array": true,
                 "comment": ""
             },
             {
-                "name": "latestBlockProducer",
+                "name": "next",
                 "type": "string",
                 "optional": false,
                 "is_array": false,
                 "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "VotePage",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "votes",
+                "type": "Vote",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
             },
             {
-                "name": "candidates",
+                "name": "next",
                 "type": "string",
                 "optional": false,
-                "is_array": true,
+                "is_array": false,
                 "comment": ""
             }
         ],
@@ -1696,342 +3724,825 @@ var IdlJsonRaw = `[
     },
     {
         "type": "struct",
-        "name": "SendTransferRequest",
+        "name": "ExecutionPage",
         "comment": "",
         "value": "",
         "extends": "",
         "fields": [
             {
-                "name": "version",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "executions",
+                "type": "Execution",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            },
+            {
+                "name": "next",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "interface",
+        "name": "Explorer",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": null,
+        "values": null,
+        "functions": [
+            {
+                "name": "getBlockchainHeight",
+                "comment": "get the blockchain tip height",
+                "params": [],
+                "returns": {
+                    "name": "",
+                    "type": "int",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getAddressBalance",
+                "comment": "get the balance of an address, deprecated by getAddressBalanceV2",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "int",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getAddressDetails",
+                "comment": "get the address detail of an iotex address, deprecated by getAddressDetailsV2",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "AddressDetails",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getLastTransfersByRange",
+                "comment": "get list of transfers by start block height, transfer offset and limit, deprecated by getLastTransfersByRangeV2",
+                "params": [
+                    {
+                        "name": "startBlockHeight",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "showCoinBase",
+                        "type": "bool",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Transfer",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getTransferByID",
+                "comment": "get transfers from transaction id, deprecated by getTransferByIDV2",
+                "params": [
+                    {
+                        "name": "transferID",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Transfer",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getTransfersByAddress",
+                "comment": "get list of transfers belonging to an address, deprecated by getTransfersByAddressV2",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Transfer",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getUnconfirmedTransfersByAddress",
+                "comment": "get list of unconfirmed transfers in actpool belonging to an address, deprecated by getUnconfirmedTransfersByAddressV2",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Transfer",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getTransfersByBlockID",
+                "comment": "get all transfers in a block, deprecated by getTransfersByBlockIDV2",
+                "params": [
+                    {
+                        "name": "blkID",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Transfer",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
             },
             {
-                "name": "nonce",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getLastVotesByRange",
+                "comment": "get list of votes by start block height, vote offset and limit, deprecated by getLastVotesByRangeV2",
+                "params": [
+                    {
+                        "name": "startBlockHeight",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Vote",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
             },
             {
-                "name": "sender",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getVoteByID",
+                "comment": "get vote from vote id, deprecated by getVoteByIDV2",
+                "params": [
+                    {
+                        "name": "voteID",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Vote",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
             },
             {
-                "name": "recipient",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getVotesByAddress",
+                "comment": "get list of votes belonging to an address, deprecated by getVotesByAddressV2",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Vote",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
             },
             {
-                "name": "amount",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getUnconfirmedVotesByAddress",
+                "comment": "get list of unconfirmed votes in actpool belonging to an address, deprecated by getUnconfirmedVotesByAddressV2",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Vote",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
             },
             {
-                "name": "senderPubKey",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getVotesByBlockID",
+                "comment": "get all votes in a block, deprecated by getVotesByBlockIDV2",
+                "params": [
+                    {
+                        "name": "blkID",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Vote",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
             },
             {
-                "name": "signature",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getLastExecutionsByRange",
+                "comment": "get list of executions by start block height, execution offset and limit, deprecated by getLastExecutionsByRangeV2",
+                "params": [
+                    {
+                        "name": "startBlockHeight",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Execution",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
             },
             {
-                "name": "payload",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getExecutionByID",
+                "comment": "get execution from execution id, deprecated by getExecutionByIDV2",
+                "params": [
+                    {
+                        "name": "executionID",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Execution",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
             },
             {
-                "name": "gasLimit",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getExecutionsByAddress",
+                "comment": "get list of executions belonging to an address, deprecated by getExecutionsByAddressV2",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Execution",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
             },
             {
-                "name": "gasPrice",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getUnconfirmedExecutionsByAddress",
+                "comment": "get list of unconfirmed executions in actpool belonging to an address, deprecated by getUnconfirmedExecutionsByAddressV2",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Execution",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
             },
             {
-                "name": "isCoinbase",
-                "type": "bool",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            }
-        ],
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "struct",
-        "name": "SendTransferResponse",
-        "comment": "",
-        "value": "",
-        "extends": "",
-        "fields": [
+                "name": "getExecutionsByBlockID",
+                "comment": "get all executions in a block, deprecated by getExecutionsByBlockIDV2",
+                "params": [
+                    {
+                        "name": "blkID",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Execution",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
             {
-                "name": "hash",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            }
-        ],
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "struct",
-        "name": "SendVoteRequest",
-        "comment": "",
-        "value": "",
-        "extends": "",
-        "fields": [
+                "name": "getLastBlocksByRange",
+                "comment": "get list of blocks by block id offset and limit, deprecated by getLastBlocksByRangeV2",
+                "params": [
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Block",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
             {
-                "name": "version",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getBlockByID",
+                "comment": "get block by block id, deprecated by getBlockByIDV2",
+                "params": [
+                    {
+                        "name": "blkID",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Block",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
             },
             {
-                "name": "nonce",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getCoinStatistic",
+                "comment": "get statistic of iotx, deprecated by getCoinStatisticV2",
+                "params": [],
+                "returns": {
+                    "name": "",
+                    "type": "CoinStatistic",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
             },
             {
-                "name": "voter",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getConsensusMetrics",
+                "comment": "get consensus metrics",
+                "params": [],
+                "returns": {
+                    "name": "",
+                    "type": "ConsensusMetrics",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
             },
             {
-                "name": "votee",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getCandidateMetrics",
+                "comment": "get candidates metrics, deprecated by getCandidateMetricsV2",
+                "params": [],
+                "returns": {
+                    "name": "",
+                    "type": "CandidateMetrics",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
             },
             {
-                "name": "voterPubKey",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getCandidateMetricsByHeight",
+                "comment": "get candidates metrics at given height, deprecated by getCandidateMetricsByHeightV2",
+                "params": [
+                    {
+                        "name": "h",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "CandidateMetrics",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
             },
             {
-                "name": "gasLimit",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "getDelegateProductivity",
+                "comment": "get delegates productivity in given epoch",
+                "params": [
+                    {
+                        "name": "epoch",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "DelegateProductivity",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
             },
             {
-                "name": "gasPrice",
-                "type": "int",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "sendTransfer",
+                "comment": "send transfer, deprecated by sendTransferV2",
+                "params": [
+                    {
+                        "name": "request",
+                        "type": "SendTransferRequest",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "SendTransferResponse",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
             },
             {
-                "name": "signature",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            }
-        ],
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "struct",
-        "name": "SendVoteResponse",
-        "comment": "",
-        "value": "",
-        "extends": "",
-        "fields": [
-            {
-                "name": "hash",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            }
-        ],
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "struct",
-        "name": "Node",
-        "comment": "",
-        "value": "",
-        "extends": "",
-        "fields": [
-            {
-                "name": "address",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            }
-        ],
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "struct",
-        "name": "GetPeersResponse",
-        "comment": "",
-        "value": "",
-        "extends": "",
-        "fields": [
-            {
-                "name": "Self",
-                "type": "Node",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
+                "name": "sendVote",
+                "comment": "send vote, deprecated by sendVoteV2",
+                "params": [
+                    {
+                        "name": "request",
+                        "type": "SendVoteRequest",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "SendVoteResponse",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
             },
             {
-                "name": "Peers",
-                "type": "Node",
-                "optional": false,
-                "is_array": true,
-                "comment": ""
-            }
-        ],
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "struct",
-        "name": "SendSmartContractResponse",
-        "comment": "",
-        "value": "",
-        "extends": "",
-        "fields": [
-            {
-                "name": "hash",
-                "type": "string",
-                "optional": false,
-                "is_array": false,
-                "comment": ""
-            }
-        ],
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "struct",
-        "name": "GetBlkOrActResponse",
-        "comment": "",
-        "value": "",
-        "extends": "",
-        "fields": [
+                "name": "sendSmartContract",
+                "comment": "sendSmartContract, deprecated by sendSmartContractV2",
+                "params": [
+                    {
+                        "name": "request",
+                        "type": "Execution",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "SendSmartContractResponse",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
             {
-                "name": "block",
-                "type": "Block",
-                "optional": true,
-                "is_array": false,
-                "comment": ""
+                "name": "getPeers",
+                "comment": "get list of peers",
+                "params": [],
+                "returns": {
+                    "name": "",
+                    "type": "GetPeersResponse",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
             },
             {
-                "name": "transfer",
-                "type": "Transfer",
-                "optional": true,
-                "is_array": false,
-                "comment": ""
+                "name": "getReceiptByExecutionID",
+                "comment": "get receipt by execution id",
+                "params": [
+                    {
+                        "name": "id",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Receipt",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
             },
             {
-                "name": "vote",
-                "type": "Vote",
-                "optional": true,
-                "is_array": false,
-                "comment": ""
+                "name": "readExecutionState",
+                "comment": "read execution state, deprecated by readExecutionStateV2",
+                "params": [
+                    {
+                        "name": "request",
+                        "type": "Execution",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "string",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
             },
             {
-                "name": "execution",
-                "type": "Execution",
-                "optional": true,
-                "is_array": false,
-                "comment": ""
-            }
-        ],
-        "values": null,
-        "functions": null,
-        "barrister_version": "",
-        "date_generated": 0,
-        "checksum": ""
-    },
-    {
-        "type": "interface",
-        "name": "Explorer",
-        "comment": "",
-        "value": "",
-        "extends": "",
-        "fields": null,
-        "values": null,
-        "functions": [
-            {
-                "name": "getBlockchainHeight",
-                "comment": "get the blockchain tip height",
-                "params": [],
+                "name": "getBlockOrActionByHash",
+                "comment": "get block or action by a hash, deprecated by getBlockOrActionByHashV2",
+                "params": [
+                    {
+                        "name": "hashStr",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
                 "returns": {
                     "name": "",
-                    "type": "int",
+                    "type": "GetBlkOrActResponse",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getAddressBalance",
+                "name": "getAddressBalanceV2",
                 "comment": "get the balance of an address",
                 "params": [
                     {
@@ -2044,14 +4555,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "int",
+                    "type": "string",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getAddressDetails",
+                "name": "getAddressDetailsV2",
                 "comment": "get the address detail of an iotex address",
                 "params": [
                     {
@@ -2064,14 +4575,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "AddressDetails",
+                    "type": "AddressDetailsV2",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getLastTransfersByRange",
+                "name": "getLastTransfersByRangeV2",
                 "comment": "get list of transfers by start block height, transfer offset and limit",
                 "params": [
                     {
@@ -2105,14 +4616,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Transfer",
+                    "type": "TransferV2",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getTransferByID",
+                "name": "getTransferByIDV2",
                 "comment": "get transfers from transaction id",
                 "params": [
                     {
@@ -2125,14 +4636,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Transfer",
+                    "type": "TransferV2",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getTransfersByAddress",
+                "name": "getTransfersByAddressV2",
                 "comment": "get list of transfers belonging to an address",
                 "params": [
                     {
@@ -2159,14 +4670,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Transfer",
+                    "type": "TransferV2",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getUnconfirmedTransfersByAddress",
+                "name": "getUnconfirmedTransfersByAddressV2",
                 "comment": "get list of unconfirmed transfers in actpool belonging to an address",
                 "params": [
                     {
@@ -2193,14 +4704,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Transfer",
+                    "type": "TransferV2",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getTransfersByBlockID",
+                "name": "getTransfersByBlockIDV2",
                 "comment": "get all transfers in a block",
                 "params": [
                     {
@@ -2227,14 +4738,34 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Transfer",
+                    "type": "TransferV2",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getLastVotesByRange",
+                "name": "sendTransferV2",
+                "comment": "send transfer",
+                "params": [
+                    {
+                        "name": "request",
+                        "type": "SendTransferRequestV2",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "SendTransferResponse",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getLastVotesByRangeV2",
                 "comment": "get list of votes by start block height, vote offset and limit",
                 "params": [
                     {
@@ -2261,14 +4792,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Vote",
+                    "type": "VoteV2",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getVoteByID",
+                "name": "getVoteByIDV2",
                 "comment": "get vote from vote id",
                 "params": [
                     {
@@ -2281,14 +4812,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Vote",
+                    "type": "VoteV2",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getVotesByAddress",
+                "name": "getVotesByAddressV2",
                 "comment": "get list of votes belonging to an address",
                 "params": [
                     {
@@ -2315,14 +4846,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Vote",
+                    "type": "VoteV2",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getUnconfirmedVotesByAddress",
+                "name": "getUnconfirmedVotesByAddressV2",
                 "comment": "get list of unconfirmed votes in actpool belonging to an address",
                 "params": [
                     {
@@ -2349,14 +4880,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Vote",
+                    "type": "VoteV2",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getVotesByBlockID",
+                "name": "getVotesByBlockIDV2",
                 "comment": "get all votes in a block",
                 "params": [
                     {
@@ -2383,14 +4914,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Vote",
+                    "type": "VoteV2",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getLastExecutionsByRange",
+                "name": "getLastExecutionsByRangeV2",
                 "comment": "get list of executions by start block height, execution offset and limit",
                 "params": [
                     {
@@ -2417,14 +4948,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Execution",
+                    "type": "ExecutionV2",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getExecutionByID",
+                "name": "getExecutionByIDV2",
                 "comment": "get execution from execution id",
                 "params": [
                     {
@@ -2437,14 +4968,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Execution",
+                    "type": "ExecutionV2",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getExecutionsByAddress",
+                "name": "getExecutionsByAddressV2",
                 "comment": "get list of executions belonging to an address",
                 "params": [
                     {
@@ -2471,14 +5002,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Execution",
+                    "type": "ExecutionV2",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getUnconfirmedExecutionsByAddress",
+                "name": "getUnconfirmedExecutionsByAddressV2",
                 "comment": "get list of unconfirmed executions in actpool belonging to an address",
                 "params": [
                     {
@@ -2505,14 +5036,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Execution",
+                    "type": "ExecutionV2",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getExecutionsByBlockID",
+                "name": "getExecutionsByBlockIDV2",
                 "comment": "get all executions in a block",
                 "params": [
                     {
@@ -2539,14 +5070,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Execution",
+                    "type": "ExecutionV2",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getLastBlocksByRange",
+                "name": "getLastBlocksByRangeV2",
                 "comment": "get list of blocks by block id offset and limit",
                 "params": [
                     {
@@ -2566,14 +5097,14 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Block",
+                    "type": "BlockV2",
                     "optional": false,
                     "is_array": true,
                     "comment": ""
                 }
             },
             {
-                "name": "getBlockByID",
+                "name": "getBlockByIDV2",
                 "comment": "get block by block id",
                 "params": [
                     {
@@ -2586,55 +5117,31 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Block",
+                    "type": "BlockV2",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getCoinStatistic",
+                "name": "getCoinStatisticV2",
                 "comment": "get statistic of iotx",
                 "params": [],
                 "returns": {
                     "name": "",
-                    "type": "CoinStatistic",
-                    "optional": false,
-                    "is_array": false,
-                    "comment": ""
-                }
-            },
-            {
-                "name": "getConsensusMetrics",
-                "comment": "get consensus metrics",
-                "params": [],
-                "returns": {
-                    "name": "",
-                    "type": "ConsensusMetrics",
-                    "optional": false,
-                    "is_array": false,
-                    "comment": ""
-                }
-            },
-            {
-                "name": "getCandidateMetrics",
-                "comment": "get candidates metrics",
-                "params": [],
-                "returns": {
-                    "name": "",
-                    "type": "CandidateMetrics",
+                    "type": "CoinStatisticV2",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getCandidateMetricsByHeight",
-                "comment": "get candidates metrics at given height",
+                "name": "sendVoteV2",
+                "comment": "send vote",
                 "params": [
                     {
-                        "name": "h",
-                        "type": "int",
+                        "name": "request",
+                        "type": "SendVoteRequestV2",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2642,19 +5149,19 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "CandidateMetrics",
+                    "type": "SendVoteResponse",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "sendTransfer",
-                "comment": "send transfer",
+                "name": "sendSmartContractV2",
+                "comment": "sendSmartContract",
                 "params": [
                     {
                         "name": "request",
-                        "type": "SendTransferRequest",
+                        "type": "ExecutionV2",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2662,19 +5169,19 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "SendTransferResponse",
+                    "type": "SendSmartContractResponse",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "sendVote",
-                "comment": "send vote",
+                "name": "readExecutionStateV2",
+                "comment": "read execution state",
                 "params": [
                     {
                         "name": "request",
-                        "type": "SendVoteRequest",
+                        "type": "ExecutionV2",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2682,19 +5189,19 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "SendVoteResponse",
+                    "type": "string",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "sendSmartContract",
-                "comment": "sendSmartContract",
+                "name": "getBlockOrActionByHashV2",
+                "comment": "get block or action by a hash",
                 "params": [
                     {
-                        "name": "request",
-                        "type": "Execution",
+                        "name": "hashStr",
+                        "type": "string",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2702,51 +5209,113 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "SendSmartContractResponse",
+                    "type": "GetBlkOrActResponseV2",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getPeers",
-                "comment": "get list of peers",
+                "name": "getCandidateMetricsV2",
+                "comment": "get candidates metrics",
                 "params": [],
                 "returns": {
                     "name": "",
-                    "type": "GetPeersResponse",
+                    "type": "CandidateMetricsV2",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getReceiptByExecutionID",
-                "comment": "get receipt by execution id",
+                "name": "getCandidateMetricsByHeightV2",
+                "comment": "get candidates metrics at given height",
                 "params": [
                     {
-                        "name": "id",
+                        "name": "h",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "CandidateMetricsV2",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getTransfersByAddressPage",
+                "comment": "get a page of transfers belonging to an address",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "cursor",
                         "type": "string",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "oldestFirst",
+                        "type": "bool",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
                     }
                 ],
                 "returns": {
                     "name": "",
-                    "type": "Receipt",
+                    "type": "TransferPage",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "readExecutionState",
-                "comment": "read execution state",
+                "name": "getVotesByAddressPage",
+                "comment": "get a page of votes belonging to an address",
                 "params": [
                     {
-                        "name": "request",
-                        "type": "Execution",
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "cursor",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "oldestFirst",
+                        "type": "bool",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
@@ -2754,27 +5323,48 @@ var IdlJsonRaw = `[
                 ],
                 "returns": {
                     "name": "",
-                    "type": "string",
+                    "type": "VotePage",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
                 }
             },
             {
-                "name": "getBlockOrActionByHash",
-                "comment": "get block or action by a hash",
+                "name": "getExecutionsByAddressPage",
+                "comment": "get a page of executions belonging to an address",
                 "params": [
                     {
-                        "name": "hashStr",
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "cursor",
                         "type": "string",
                         "optional": false,
                         "is_array": false,
                         "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "oldestFirst",
+                        "type": "bool",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
                     }
                 ],
                 "returns": {
                     "name": "",
-                    "type": "GetBlkOrActResponse",
+                    "type": "ExecutionPage",
                     "optional": false,
                     "is_array": false,
                     "comment": ""
@@ -2795,7 +5385,7 @@ var IdlJsonRaw = `[
         "values": null,
         "functions": null,
         "barrister_version": "0.1.6",
-        "date_generated": 1538418246052,
-        "checksum": "97e0f2efe11dee3190b33a6c0cb7fda8"
+        "date_generated": 1792359400000,
+        "checksum": "2127fb7c2099d251dbc6f3a1908260b2"
     }
 ]`
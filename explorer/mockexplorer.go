@@ -66,6 +66,60 @@ func (exp *MockExplorer) GetTransfersByBlockID(blockID string, offset int64, lim
 	return exp.GetLastTransfersByRange(0, offset, limit, true)
 }
 
+// GetAddressBalanceV2 returns the balance of an address
+func (exp *MockExplorer) GetAddressBalanceV2(address string) (string, error) {
+	return randString(), nil
+}
+
+// GetAddressDetailsV2 returns the properties of an address
+func (exp *MockExplorer) GetAddressDetailsV2(address string) (explorer.AddressDetailsV2, error) {
+	return explorer.AddressDetailsV2{
+		Address:      address,
+		TotalBalance: randString(),
+	}, nil
+}
+
+// GetLastTransfersByRangeV2 return transfers in [-(offset+limit-1), -offset] from block
+// with height startBlockHeight
+func (exp *MockExplorer) GetLastTransfersByRangeV2(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) ([]explorer.TransferV2, error) {
+	var txs []explorer.TransferV2
+	for i := int64(0); i < limit; i++ {
+		txs = append(txs, randTransactionV2())
+	}
+	return txs, nil
+}
+
+// GetTransferByIDV2 returns transfer by transfer id
+func (exp *MockExplorer) GetTransferByIDV2(transferID string) (explorer.TransferV2, error) {
+	return randTransactionV2(), nil
+}
+
+// GetTransfersByAddressV2 returns all transfers associate with an address
+func (exp *MockExplorer) GetTransfersByAddressV2(address string, offset int64, limit int64) ([]explorer.TransferV2, error) {
+	return exp.GetLastTransfersByRangeV2(0, offset, limit, true)
+}
+
+// GetTransfersByAddressPage returns a page of the transfers associated with an address
+func (exp *MockExplorer) GetTransfersByAddressPage(
+	address string,
+	cursor string,
+	limit int64,
+	oldestFirst bool,
+) (explorer.TransferPage, error) {
+	transfers, err := exp.GetLastTransfersByRangeV2(0, 0, limit, true)
+	return explorer.TransferPage{Transfers: transfers, Next: randString()}, err
+}
+
+// GetUnconfirmedTransfersByAddressV2 returns all unconfirmed transfers in actpool associated with an address
+func (exp *MockExplorer) GetUnconfirmedTransfersByAddressV2(address string, offset int64, limit int64) ([]explorer.TransferV2, error) {
+	return exp.GetLastTransfersByRangeV2(0, offset, limit, true)
+}
+
+// GetTransfersByBlockIDV2 returns transfers in a block
+func (exp *MockExplorer) GetTransfersByBlockIDV2(blockID string, offset int64, limit int64) ([]explorer.TransferV2, error) {
+	return exp.GetLastTransfersByRangeV2(0, offset, limit, true)
+}
+
 // GetLastVotesByRange return votes in [-(offset+limit-1), -offset] from block
 // with height startBlockHeight
 func (exp *MockExplorer) GetLastVotesByRange(startBlockHeight int64, offset int64, limit int64) ([]explorer.Vote, error) {
@@ -86,6 +140,17 @@ func (exp *MockExplorer) GetVotesByAddress(address string, offset int64, limit i
 	return exp.GetLastVotesByRange(0, offset, limit)
 }
 
+// GetVotesByAddressPage returns a page of the votes associated with an address
+func (exp *MockExplorer) GetVotesByAddressPage(
+	address string,
+	cursor string,
+	limit int64,
+	oldestFirst bool,
+) (explorer.VotePage, error) {
+	votes, err := exp.GetLastVotesByRange(0, 0, limit)
+	return explorer.VotePage{Votes: votes, Next: randString()}, err
+}
+
 // GetUnconfirmedVotesByAddress returns all unconfirmed votes in actpool associated with an address
 func (exp *MockExplorer) GetUnconfirmedVotesByAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
 	return exp.GetLastVotesByRange(0, offset, limit)
@@ -121,6 +186,17 @@ func (exp *MockExplorer) GetExecutionsByAddress(address string, offset int64, li
 	return exp.GetLastExecutionsByRange(0, offset, limit)
 }
 
+// GetExecutionsByAddressPage returns a page of the executions associated with an address
+func (exp *MockExplorer) GetExecutionsByAddressPage(
+	address string,
+	cursor string,
+	limit int64,
+	oldestFirst bool,
+) (explorer.ExecutionPage, error) {
+	executions, err := exp.GetLastExecutionsByRange(0, 0, limit)
+	return explorer.ExecutionPage{Executions: executions, Next: randString()}, err
+}
+
 // GetUnconfirmedExecutionsByAddress returns all unconfirmed executions in actpool associated with an address
 func (exp *MockExplorer) GetUnconfirmedExecutionsByAddress(address string, offset int64, limit int64) ([]explorer.Execution, error) {
 	return exp.GetLastExecutionsByRange(0, offset, limit)
@@ -165,6 +241,7 @@ func (exp *MockExplorer) GetConsensusMetrics() (explorer.ConsensusMetrics, error
 		LatestEpoch:         randInt64(),
 		LatestDelegates:     delegates,
 		LatestBlockProducer: delegates[0],
+		LatestRound:         randInt64(),
 	}, nil
 }
 
@@ -198,11 +275,27 @@ func (exp *MockExplorer) GetCandidateMetricsByHeight(h int64) (explorer.Candidat
 	}, nil
 }
 
+// GetDelegateProductivity returns the fake delegates productivity
+func (exp *MockExplorer) GetDelegateProductivity(epoch int64) ([]explorer.DelegateProductivity, error) {
+	productivity := explorer.DelegateProductivity{
+		Delegate: randString(),
+		Produced: randInt64(),
+		Missed:   randInt64(),
+		Endorsed: randInt64(),
+	}
+	return []explorer.DelegateProductivity{productivity}, nil
+}
+
 // SendTransfer sends a fake transfer
 func (exp *MockExplorer) SendTransfer(request explorer.SendTransferRequest) (explorer.SendTransferResponse, error) {
 	return explorer.SendTransferResponse{}, nil
 }
 
+// SendTransferV2 sends a fake transfer
+func (exp *MockExplorer) SendTransferV2(request explorer.SendTransferRequestV2) (explorer.SendTransferResponse, error) {
+	return explorer.SendTransferResponse{}, nil
+}
+
 // SendVote sends a fake vote
 func (exp *MockExplorer) SendVote(request explorer.SendVoteRequest) (explorer.SendVoteResponse, error) {
 	return explorer.SendVoteResponse{}, nil
@@ -228,6 +321,128 @@ func (exp *MockExplorer) GetBlockOrActionByHash(hash string) (explorer.GetBlkOrA
 	return explorer.GetBlkOrActResponse{}, nil
 }
 
+// GetLastVotesByRangeV2 return votes in [-(offset+limit-1), -offset] from block
+// with height startBlockHeight
+func (exp *MockExplorer) GetLastVotesByRangeV2(startBlockHeight int64, offset int64, limit int64) ([]explorer.VoteV2, error) {
+	var votes []explorer.VoteV2
+	for i := int64(0); i < limit; i++ {
+		votes = append(votes, randVoteV2())
+	}
+	return votes, nil
+}
+
+// GetVoteByIDV2 returns vote by vote id
+func (exp *MockExplorer) GetVoteByIDV2(voteID string) (explorer.VoteV2, error) {
+	return randVoteV2(), nil
+}
+
+// GetVotesByAddressV2 returns all votes associate with an address
+func (exp *MockExplorer) GetVotesByAddressV2(address string, offset int64, limit int64) ([]explorer.VoteV2, error) {
+	return exp.GetLastVotesByRangeV2(0, offset, limit)
+}
+
+// GetUnconfirmedVotesByAddressV2 returns all unconfirmed votes in actpool associated with an address
+func (exp *MockExplorer) GetUnconfirmedVotesByAddressV2(address string, offset int64, limit int64) ([]explorer.VoteV2, error) {
+	return exp.GetLastVotesByRangeV2(0, offset, limit)
+}
+
+// GetVotesByBlockIDV2 returns votes in a block
+func (exp *MockExplorer) GetVotesByBlockIDV2(blkID string, offset int64, limit int64) ([]explorer.VoteV2, error) {
+	return exp.GetLastVotesByRangeV2(0, offset, limit)
+}
+
+// GetLastExecutionsByRangeV2 return executions in [-(offset+limit-1), -offset] from block
+// with height startBlockHeight
+func (exp *MockExplorer) GetLastExecutionsByRangeV2(startBlockHeight int64, offset int64, limit int64) ([]explorer.ExecutionV2, error) {
+	var executions []explorer.ExecutionV2
+	for i := int64(0); i < limit; i++ {
+		executions = append(executions, randExecutionV2())
+	}
+	return executions, nil
+}
+
+// GetExecutionByIDV2 returns execution by execution id
+func (exp *MockExplorer) GetExecutionByIDV2(executionID string) (explorer.ExecutionV2, error) {
+	return randExecutionV2(), nil
+}
+
+// GetExecutionsByAddressV2 returns all executions associate with an address
+func (exp *MockExplorer) GetExecutionsByAddressV2(address string, offset int64, limit int64) ([]explorer.ExecutionV2, error) {
+	return exp.GetLastExecutionsByRangeV2(0, offset, limit)
+}
+
+// GetUnconfirmedExecutionsByAddressV2 returns all unconfirmed executions in actpool associated with an address
+func (exp *MockExplorer) GetUnconfirmedExecutionsByAddressV2(address string, offset int64, limit int64) ([]explorer.ExecutionV2, error) {
+	return exp.GetLastExecutionsByRangeV2(0, offset, limit)
+}
+
+// GetExecutionsByBlockIDV2 returns executions in a block
+func (exp *MockExplorer) GetExecutionsByBlockIDV2(blkID string, offset int64, limit int64) ([]explorer.ExecutionV2, error) {
+	return exp.GetLastExecutionsByRangeV2(0, offset, limit)
+}
+
+// GetLastBlocksByRangeV2 get block with height [offset-limit+1, offset]
+func (exp *MockExplorer) GetLastBlocksByRangeV2(offset int64, limit int64) ([]explorer.BlockV2, error) {
+	var blks []explorer.BlockV2
+	for i := int64(0); i < limit; i++ {
+		blks = append(blks, randBlockV2())
+	}
+	return blks, nil
+}
+
+// GetBlockByIDV2 returns block by block id
+func (exp *MockExplorer) GetBlockByIDV2(blkID string) (explorer.BlockV2, error) {
+	return randBlockV2(), nil
+}
+
+// GetCoinStatisticV2 returns stats in blockchain
+func (exp *MockExplorer) GetCoinStatisticV2() (explorer.CoinStatisticV2, error) {
+	return explorer.CoinStatisticV2{
+		Height: randInt64(),
+		Supply: randString(),
+	}, nil
+}
+
+// SendVoteV2 sends a fake vote
+func (exp *MockExplorer) SendVoteV2(request explorer.SendVoteRequestV2) (explorer.SendVoteResponse, error) {
+	return explorer.SendVoteResponse{}, nil
+}
+
+// SendSmartContractV2 sends a smart contract
+func (exp *MockExplorer) SendSmartContractV2(request explorer.ExecutionV2) (explorer.SendSmartContractResponse, error) {
+	return explorer.SendSmartContractResponse{}, nil
+}
+
+// ReadExecutionStateV2 sends a smart contract
+func (exp *MockExplorer) ReadExecutionStateV2(request explorer.ExecutionV2) (string, error) {
+	return "100", nil
+}
+
+// GetBlockOrActionByHashV2 get block or action by a hash
+func (exp *MockExplorer) GetBlockOrActionByHashV2(hash string) (explorer.GetBlkOrActResponseV2, error) {
+	return explorer.GetBlkOrActResponseV2{}, nil
+}
+
+// GetCandidateMetricsV2 returns the fake delegates metrics
+func (exp *MockExplorer) GetCandidateMetricsV2() (explorer.CandidateMetricsV2, error) {
+	candidate := explorer.CandidateV2{
+		Address:          randString(),
+		TotalVote:        strconv.FormatInt(randInt64(), 10),
+		CreationHeight:   randInt64(),
+		LastUpdateHeight: randInt64(),
+		IsDelegate:       false,
+		IsProducer:       false,
+	}
+	return explorer.CandidateMetricsV2{
+		Candidates: []explorer.CandidateV2{candidate},
+	}, nil
+}
+
+// GetCandidateMetricsByHeightV2 returns the fake delegates metrics
+func (exp *MockExplorer) GetCandidateMetricsByHeightV2(h int64) (explorer.CandidateMetricsV2, error) {
+	return exp.GetCandidateMetricsV2()
+}
+
 func randInt64() int64 {
 	rand.Seed(time.Now().UnixNano())
 	amount := int64(0)
@@ -253,6 +468,18 @@ func randTransaction() explorer.Transfer {
 	}
 }
 
+func randTransactionV2() explorer.TransferV2 {
+	return explorer.TransferV2{
+		ID:        randString(),
+		Sender:    randString(),
+		Recipient: randString(),
+		Amount:    randString(),
+		Fee:       "12",
+		Timestamp: randInt64(),
+		BlockID:   randString(),
+	}
+}
+
 func randVote() explorer.Vote {
 	return explorer.Vote{
 		ID:        randString(),
@@ -264,6 +491,18 @@ func randVote() explorer.Vote {
 	}
 }
 
+func randVoteV2() explorer.VoteV2 {
+	return explorer.VoteV2{
+		ID:        randString(),
+		Timestamp: randInt64(),
+		BlockID:   randString(),
+		Nonce:     randInt64(),
+		Voter:     randString(),
+		Votee:     randString(),
+		GasPrice:  randString(),
+	}
+}
+
 func randExecution() explorer.Execution {
 	return explorer.Execution{
 		ID:        randString(),
@@ -278,6 +517,20 @@ func randExecution() explorer.Execution {
 	}
 }
 
+func randExecutionV2() explorer.ExecutionV2 {
+	return explorer.ExecutionV2{
+		ID:        randString(),
+		Timestamp: randInt64(),
+		BlockID:   randString(),
+		Nonce:     randInt64(),
+		Executor:  randString(),
+		Contract:  randString(),
+		Amount:    randString(),
+		GasLimit:  randInt64(),
+		GasPrice:  randString(),
+	}
+}
+
 func randBlock() explorer.Block {
 	return explorer.Block{
 		ID:        randString(),
@@ -292,3 +545,18 @@ func randBlock() explorer.Block {
 		Forged: randInt64(),
 	}
 }
+
+func randBlockV2() explorer.BlockV2 {
+	return explorer.BlockV2{
+		ID:        randString(),
+		Height:    randInt64(),
+		Timestamp: randInt64(),
+		Transfers: randInt64(),
+		GenerateBy: explorer.BlockGenerator{
+			Name:    randString(),
+			Address: randString(),
+		},
+		Amount: randString(),
+		Forged: randInt64(),
+	}
+}
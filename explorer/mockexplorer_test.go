@@ -38,6 +38,27 @@ func TestMockExplorerApi(t *testing.T) {
 	_, err = svc.GetTransfersByBlockID("", 0, 10)
 	require.Nil(err)
 
+	_, err = svc.GetAddressBalanceV2("")
+	require.Nil(err)
+
+	_, err = svc.GetAddressDetailsV2("")
+	require.Nil(err)
+
+	_, err = svc.GetLastTransfersByRangeV2(0, 0, 10, true)
+	require.Nil(err)
+
+	_, err = svc.GetTransferByIDV2("")
+	require.Nil(err)
+
+	_, err = svc.GetTransfersByAddressV2("", 0, 10)
+	require.Nil(err)
+
+	_, err = svc.GetTransfersByAddressPage("", "", 10, false)
+	require.Nil(err)
+
+	_, err = svc.GetTransfersByBlockIDV2("", 0, 10)
+	require.Nil(err)
+
 	_, err = svc.GetLastVotesByRange(0, 0, 10)
 	require.Nil(err)
 
@@ -47,6 +68,9 @@ func TestMockExplorerApi(t *testing.T) {
 	_, err = svc.GetVotesByAddress("", 0, 10)
 	require.Nil(err)
 
+	_, err = svc.GetVotesByAddressPage("", "", 10, false)
+	require.Nil(err)
+
 	_, err = svc.GetVotesByBlockID("", 0, 10)
 	require.Nil(err)
 
@@ -59,6 +83,9 @@ func TestMockExplorerApi(t *testing.T) {
 	_, err = svc.GetExecutionsByAddress("", 0, 10)
 	require.Nil(err)
 
+	_, err = svc.GetExecutionsByAddressPage("", "", 10, false)
+	require.Nil(err)
+
 	_, err = svc.GetExecutionsByBlockID("", 0, 10)
 	require.Nil(err)
 
@@ -71,6 +98,39 @@ func TestMockExplorerApi(t *testing.T) {
 	_, err = svc.GetCoinStatistic()
 	require.Nil(err)
 
+	_, err = svc.GetLastVotesByRangeV2(0, 0, 10)
+	require.Nil(err)
+
+	_, err = svc.GetVoteByIDV2("")
+	require.Nil(err)
+
+	_, err = svc.GetVotesByAddressV2("", 0, 10)
+	require.Nil(err)
+
+	_, err = svc.GetVotesByBlockIDV2("", 0, 10)
+	require.Nil(err)
+
+	_, err = svc.GetLastExecutionsByRangeV2(0, 0, 10)
+	require.Nil(err)
+
+	_, err = svc.GetExecutionByIDV2("")
+	require.Nil(err)
+
+	_, err = svc.GetExecutionsByAddressV2("", 0, 10)
+	require.Nil(err)
+
+	_, err = svc.GetExecutionsByBlockIDV2("", 0, 10)
+	require.Nil(err)
+
+	_, err = svc.GetLastBlocksByRangeV2(0, 10)
+	require.Nil(err)
+
+	_, err = svc.GetBlockByIDV2("")
+	require.Nil(err)
+
+	_, err = svc.GetCoinStatisticV2()
+	require.Nil(err)
+
 	_, err = svc.GetConsensusMetrics()
 	require.Nil(err)
 
@@ -86,9 +146,21 @@ func TestMockExplorerApi(t *testing.T) {
 	randTransaction := randTransaction()
 	require.NotNil(randTransaction)
 
+	randTransactionV2 := randTransactionV2()
+	require.NotNil(randTransactionV2)
+
 	randVote := randVote()
 	require.NotNil(randVote)
 
+	randVoteV2 := randVoteV2()
+	require.NotNil(randVoteV2)
+
+	randExecutionV2 := randExecutionV2()
+	require.NotNil(randExecutionV2)
+
 	randBlock := randBlock()
 	require.NotNil(randBlock)
+
+	randBlockV2 := randBlockV2()
+	require.NotNil(randBlockV2)
 }
@@ -0,0 +1,357 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+var (
+	// ErrCursor indicates the error of cursor
+	ErrCursor = errors.New("invalid cursor")
+	// ErrPageSize indicates that the limit of a page is larger than the max page size
+	ErrPageSize = errors.New("invalid page size")
+)
+
+// addressIndex is the index of the actions of an address as either the sender or the recipient, which is in the order
+// of the actions on the chain
+type addressIndex struct {
+	count func(address string) (uint64, error)
+	read  func(address string, start uint64, count uint64) ([]hash.Hash32B, error)
+}
+
+// locateFunc finds the block of an action and the index of the action in the block
+type locateFunc func(h hash.Hash32B) (*blockchain.Block, int, error)
+
+// actionRef is an action located in its block
+type actionRef struct {
+	blk    *blockchain.Block
+	height uint64
+	index  int
+}
+
+// before tells if the action is before the other one on the chain
+func (r actionRef) before(other actionRef) bool {
+	if r.height != other.height {
+		return r.height < other.height
+	}
+	return r.index < other.index
+}
+
+// pageCursor is where a page of the actions of an address stops. It has the position of the last action of the page,
+// that is the height of its block and its index in the block, and the positions in the indexes of the sender and the
+// recipient where the next page starts.
+type pageCursor struct {
+	height uint64
+	index  uint64
+	from   uint64
+	to     uint64
+}
+
+// cursorLen is the length of the serialized cursor
+const cursorLen = 32
+
+func (c *pageCursor) String() string {
+	b := make([]byte, 0, cursorLen)
+	b = append(b, byteutil.Uint64ToBytes(c.height)...)
+	b = append(b, byteutil.Uint64ToBytes(c.index)...)
+	b = append(b, byteutil.Uint64ToBytes(c.from)...)
+	b = append(b, byteutil.Uint64ToBytes(c.to)...)
+	return hex.EncodeToString(b)
+}
+
+func parseCursor(s string) (*pageCursor, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != cursorLen {
+		return nil, errors.Wrapf(ErrCursor, "cursor %s is malformed", s)
+	}
+	return &pageCursor{
+		height: byteutil.BytesToUint64(b[:8]),
+		index:  byteutil.BytesToUint64(b[8:16]),
+		from:   byteutil.BytesToUint64(b[16:24]),
+		to:     byteutil.BytesToUint64(b[24:]),
+	}, nil
+}
+
+// transferIndexes returns the indexes of the transfers from and to an address
+func transferIndexes(bc blockchain.Blockchain) (addressIndex, addressIndex) {
+	return addressIndex{count: bc.GetTransferCountFromAddress, read: bc.GetTransfersFromAddressByRange},
+		addressIndex{count: bc.GetTransferCountToAddress, read: bc.GetTransfersToAddressByRange}
+}
+
+// voteIndexes returns the indexes of the votes from and to an address
+func voteIndexes(bc blockchain.Blockchain) (addressIndex, addressIndex) {
+	return addressIndex{count: bc.GetVoteCountFromAddress, read: bc.GetVotesFromAddressByRange},
+		addressIndex{count: bc.GetVoteCountToAddress, read: bc.GetVotesToAddressByRange}
+}
+
+// executionIndexes returns the indexes of the executions from and to an address
+func executionIndexes(bc blockchain.Blockchain) (addressIndex, addressIndex) {
+	return addressIndex{count: bc.GetExecutionCountFromAddress, read: bc.GetExecutionsFromAddressByRange},
+		addressIndex{count: bc.GetExecutionCountToAddress, read: bc.GetExecutionsToAddressByRange}
+}
+
+// locateTransfer returns the function finding the block of a transfer
+func locateTransfer(bc blockchain.Blockchain) locateFunc {
+	return func(h hash.Hash32B) (*blockchain.Block, int, error) {
+		blkHash, err := bc.GetBlockHashByTransferHash(h)
+		if err != nil {
+			return nil, 0, err
+		}
+		blk, err := bc.GetBlockByHash(blkHash)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i, transfer := range blk.Transfers {
+			if transfer.Hash() == h {
+				return blk, i, nil
+			}
+		}
+		return nil, 0, errors.Wrapf(ErrTransfer, "transfer %x is not in block %x", h, blkHash)
+	}
+}
+
+// locateVote returns the function finding the block of a vote
+func locateVote(bc blockchain.Blockchain) locateFunc {
+	return func(h hash.Hash32B) (*blockchain.Block, int, error) {
+		blkHash, err := bc.GetBlockHashByVoteHash(h)
+		if err != nil {
+			return nil, 0, err
+		}
+		blk, err := bc.GetBlockByHash(blkHash)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i, vote := range blk.Votes {
+			if vote.Hash() == h {
+				return blk, i, nil
+			}
+		}
+		return nil, 0, errors.Wrapf(ErrVote, "vote %x is not in block %x", h, blkHash)
+	}
+}
+
+// locateExecution returns the function finding the block of an execution
+func locateExecution(bc blockchain.Blockchain) locateFunc {
+	return func(h hash.Hash32B) (*blockchain.Block, int, error) {
+		blkHash, err := bc.GetBlockHashByExecutionHash(h)
+		if err != nil {
+			return nil, 0, err
+		}
+		blk, err := bc.GetBlockByHash(blkHash)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i, execution := range blk.Executions {
+			if execution.Hash() == h {
+				return blk, i, nil
+			}
+		}
+		return nil, 0, errors.Wrapf(ErrExecution, "execution %x is not in block %x", h, blkHash)
+	}
+}
+
+// getHashesByAddress returns the actions in [offset, offset+limit) of the actions from an address followed by the
+// actions to it, reading only that range of the indexes
+func getHashesByAddress(address string, offset int64, limit int64, sender, recipient addressIndex) ([]hash.Hash32B, error) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		return []hash.Hash32B{}, nil
+	}
+	fromCount, err := sender.count(address)
+	if err != nil {
+		return nil, err
+	}
+	res, err := sender.read(address, uint64(offset), uint64(limit))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(res)) >= limit {
+		return res, nil
+	}
+	toStart := uint64(0)
+	if uint64(offset) > fromCount {
+		toStart = uint64(offset) - fromCount
+	}
+	hashes, err := recipient.read(address, toStart, uint64(limit)-uint64(len(res)))
+	if err != nil {
+		return nil, err
+	}
+	return append(res, hashes...), nil
+}
+
+// getPageByAddress returns a page of at most limit actions of an address, merging the actions from and to it in the
+// order on the chain, and the cursor of the next page, which is empty after the last page. Only the entries of the
+// indexes which may be in the page are read, and the limit can't be larger than the max page size.
+func getPageByAddress(
+	address string,
+	cursor string,
+	limit int64,
+	maxPageSize int64,
+	oldestFirst bool,
+	sender, recipient addressIndex,
+	locate locateFunc,
+) ([]actionRef, string, error) {
+	if limit > maxPageSize {
+		return nil, "", errors.Wrapf(ErrPageSize, "limit %d is larger than the max page size %d", limit, maxPageSize)
+	}
+	if limit <= 0 {
+		return []actionRef{}, cursor, nil
+	}
+	fromCount, err := sender.count(address)
+	if err != nil {
+		return nil, "", err
+	}
+	toCount, err := recipient.count(address)
+	if err != nil {
+		return nil, "", err
+	}
+	var c *pageCursor
+	from, to := fromCount, toCount
+	if oldestFirst {
+		from, to = 0, 0
+	}
+	if cursor != "" {
+		if c, err = parseCursor(cursor); err != nil {
+			return nil, "", err
+		}
+		// The indexes shrink if the tip block is deleted
+		from, to = minUint64(c.from, fromCount), minUint64(c.to, toCount)
+	}
+
+	fromRefs, err := readIndex(address, sender, from, fromCount, limit, oldestFirst, locate)
+	if err != nil {
+		return nil, "", err
+	}
+	toRefs, err := readIndex(address, recipient, to, toCount, limit, oldestFirst, locate)
+	if err != nil {
+		return nil, "", err
+	}
+	precedes := func(a, b actionRef) bool {
+		if oldestFirst {
+			return a.before(b)
+		}
+		return b.before(a)
+	}
+	var last *actionRef
+	if c != nil {
+		last = &actionRef{height: c.height, index: int(c.index)}
+	}
+	// The merge stops once a list which doesn't reach the end of its index runs out, since the unread actions of the
+	// index may precede the remaining ones of the other list
+	fromMore := hasMore(from, fromCount, len(fromRefs), oldestFirst)
+	toMore := hasMore(to, toCount, len(toRefs), oldestFirst)
+	res := make([]actionRef, 0, minUint64(uint64(limit), uint64(len(fromRefs)+len(toRefs))))
+	i, j := 0, 0
+	for int64(len(res)) < limit && (i < len(fromRefs) || j < len(toRefs)) {
+		if (i >= len(fromRefs) && fromMore) || (j >= len(toRefs) && toMore) {
+			break
+		}
+		var next actionRef
+		switch {
+		case j >= len(toRefs) || (i < len(fromRefs) && precedes(fromRefs[i], toRefs[j])):
+			next = fromRefs[i]
+			i++
+		case i >= len(fromRefs) || precedes(toRefs[j], fromRefs[i]):
+			next = toRefs[j]
+			j++
+		default:
+			// The action is both from and to the address
+			next = fromRefs[i]
+			i++
+			j++
+		}
+		// Skip the actions up to the last one of the previous page
+		if last != nil && !precedes(*last, next) {
+			continue
+		}
+		res = append(res, next)
+		last = &res[len(res)-1]
+	}
+
+	if oldestFirst {
+		from, to = from+uint64(i), to+uint64(j)
+		if from >= fromCount && to >= toCount {
+			return res, "", nil
+		}
+	} else {
+		from, to = from-uint64(i), to-uint64(j)
+		if from == 0 && to == 0 {
+			return res, "", nil
+		}
+	}
+	next := &pageCursor{from: from, to: to}
+	if last != nil {
+		next.height, next.index = last.height, uint64(last.index)
+	}
+	return res, next.String(), nil
+}
+
+// readIndex reads and locates at most limit actions of the index of the given size next to the position, which are the
+// ones after it if oldestFirst is true, or the ones before it in the reverse order otherwise
+func readIndex(
+	address string,
+	index addressIndex,
+	pos uint64,
+	size uint64,
+	limit int64,
+	oldestFirst bool,
+	locate locateFunc,
+) ([]actionRef, error) {
+	start, count := pos, uint64(0)
+	switch {
+	case !oldestFirst:
+		count = minUint64(uint64(limit), pos)
+		start = pos - count
+	case pos < size:
+		count = minUint64(uint64(limit), size-pos)
+	}
+	if count == 0 {
+		return []actionRef{}, nil
+	}
+	hashes, err := index.read(address, start, count)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]actionRef, 0, len(hashes))
+	for _, h := range hashes {
+		blk, i, err := locate(h)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, actionRef{blk: blk, height: blk.Height(), index: i})
+	}
+	if !oldestFirst {
+		for l, r := 0, len(refs)-1; l < r; l, r = l+1, r-1 {
+			refs[l], refs[r] = refs[r], refs[l]
+		}
+	}
+	return refs, nil
+}
+
+// hasMore tells if the index has more actions next to the ones read from the position
+func hasMore(pos uint64, count uint64, read int, oldestFirst bool) bool {
+	if oldestFirst {
+		return pos+uint64(read) < count
+	}
+	return pos > uint64(read)
+}
+
+func minUint64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus"
 	"github.com/iotexproject/iotex-core/dispatcher"
+	"github.com/iotexproject/iotex-core/explorer/ethrpc"
 	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network"
@@ -32,6 +33,8 @@ type Server struct {
 	jrpcSvr barrister.Server
 	httpSvr http.Server
 	port    int
+	// eth serves the Ethereum compatible JSON-RPC API if its port is set
+	eth *ethrpc.Server
 }
 
 // NewServer instantiates an explorer server
@@ -43,7 +46,7 @@ func NewServer(
 	actPool actpool.ActPool,
 	p2p network.Overlay,
 ) *Server {
-	return &Server{
+	s := &Server{
 		cfg: cfg,
 		exp: &Service{
 			bc:  chain,
@@ -54,6 +57,10 @@ func NewServer(
 			cfg: cfg,
 		},
 	}
+	if cfg.EthPort != 0 {
+		s.eth = ethrpc.NewServer(cfg, chain, dispatcher, p2p)
+	}
+	return s
 }
 
 // NewTestSever instantiates an explorer server with mock handler
@@ -65,7 +72,7 @@ func NewTestSever(cfg config.Explorer) *Server {
 }
 
 // Start starts the explorer server
-func (s *Server) Start(_ context.Context) error {
+func (s *Server) Start(ctx context.Context) error {
 	portStr := strconv.Itoa(s.cfg.Port)
 	started := make(chan bool)
 	go func(started chan bool) {
@@ -92,11 +99,19 @@ func (s *Server) Start(_ context.Context) error {
 		}
 	}(started)
 	<-started
+	if s.eth != nil {
+		return s.eth.Start(ctx)
+	}
 	return nil
 }
 
 // Stop stops the explorer server
 func (s *Server) Stop(ctx context.Context) error {
+	if s.eth != nil {
+		if err := s.eth.Stop(ctx); err != nil {
+			return err
+		}
+	}
 	if err := s.httpSvr.Shutdown(ctx); err != nil {
 		return errors.Wrap(err, "error when shutting down explorer http server")
 	}
@@ -108,6 +123,14 @@ func (s *Server) Port() int {
 	return s.port
 }
 
+// EthPort returns the actually binding port of the Ethereum JSON-RPC API, or 0 if it is disabled
+func (s *Server) EthPort() int {
+	if s.eth == nil {
+		return 0
+	}
+	return s.eth.Port()
+}
+
 // logFilter example of Filter implementation
 type logFilter struct{}
 
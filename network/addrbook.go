@@ -0,0 +1,406 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"math/bits"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+)
+
+const (
+	// NodeIDLength is the length of a node ID in bytes
+	NodeIDLength = 32
+	// defaultBucketSize is the max number of addresses in a bucket, unless it is configured
+	defaultBucketSize = 16
+	// maxAddrFailures is the number of consecutive failures after which an address is dropped
+	maxAddrFailures = 3
+)
+
+// NodeID identifies a node in the routing table, which is derived from the node key, so that a node can't choose its
+// position in the routing table of the others without the key to authenticate with
+type NodeID [NodeIDLength]byte
+
+// NewNodeID returns the ID of the node with the key
+func NewNodeID(pubKey keypair.PublicKey) NodeID {
+	var id NodeID
+	copy(id[:], hash.Hash256b(pubKey[:]))
+	return id
+}
+
+// String returns the hex string of the node ID
+func (id NodeID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// distance returns the XOR distance between two node IDs
+func distance(a, b NodeID) NodeID {
+	var d NodeID
+	for i := range d {
+		d[i] = a[i] ^ b[i]
+	}
+	return d
+}
+
+// bucketIndex returns the index of the bucket which the node at the distance falls into, which is the position of the
+// highest set bit of the distance, or -1 if the distance is 0
+func bucketIndex(d NodeID) int {
+	for i, b := range d {
+		if b != 0 {
+			return (NodeIDLength-i)*8 - 1 - bits.LeadingZeros8(b)
+		}
+	}
+	return -1
+}
+
+// NodeAddr is the address of a node along with its key, which is zero if the key is unknown
+type NodeAddr struct {
+	Addr   string
+	PubKey keypair.PublicKey
+}
+
+// knownAddr is an address in the address book
+type knownAddr struct {
+	Addr     string    `yaml:"addr"`
+	PubKey   string    `yaml:"pubKey,omitempty"`
+	LastSeen time.Time `yaml:"lastSeen"`
+	Failures int       `yaml:"failures"`
+	pubKey   keypair.PublicKey
+	id       NodeID
+}
+
+func newKnownAddr(addr string, pubKey keypair.PublicKey) *knownAddr {
+	ka := &knownAddr{Addr: addr}
+	ka.setKey(pubKey)
+	return ka
+}
+
+// setKey sets the key of the node at the address, which its ID is derived from
+func (ka *knownAddr) setKey(pubKey keypair.PublicKey) {
+	ka.pubKey = pubKey
+	ka.id = NewNodeID(pubKey)
+	ka.PubKey = ""
+	if ka.keyed() {
+		ka.PubKey = keypair.EncodePublicKey(pubKey)
+	}
+}
+
+// keyed returns true if the key of the node at the address is known
+func (ka *knownAddr) keyed() bool {
+	return ka.pubKey != keypair.ZeroPublicKey
+}
+
+// addrList is the persisted addresses in the address book
+type addrList struct {
+	Addrs []*knownAddr `yaml:"addrs"`
+}
+
+// AddrBook keeps the known addresses in a Kademlia-style routing table. The addresses are put into the buckets by the
+// XOR distance between the IDs derived from their keys and the node's own ID, and each bucket keeps the addresses from
+// the least to the most recently seen. The addresses whose keys are not known yet, such as the bootstrap nodes, are
+// kept in a separate bucket until the nodes at them authenticate. A nil AddrBook knows no address.
+type AddrBook struct {
+	mu         sync.RWMutex
+	clock      clock.Clock
+	self       NodeID
+	selfAddr   string
+	bucketSize int
+	buckets    [NodeIDLength * 8][]*knownAddr
+	unkeyed    []*knownAddr
+	addrs      map[string]*knownAddr
+	path       string
+}
+
+// NewAddrBook creates an instance of AddrBook for the node at the address with the key, and loads the addresses
+// persisted in the file if any
+func NewAddrBook(selfAddr string, selfKey keypair.PublicKey, bucketSize int, path string) (*AddrBook, error) {
+	if bucketSize <= 0 {
+		bucketSize = defaultBucketSize
+	}
+	b := &AddrBook{
+		clock:      clock.New(),
+		self:       NewNodeID(selfKey),
+		selfAddr:   selfAddr,
+		bucketSize: bucketSize,
+		addrs:      make(map[string]*knownAddr),
+		path:       path,
+	}
+	if path == "" {
+		return b, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read address book %s", path)
+	}
+	var list addrList
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal address book %s", path)
+	}
+	for _, ka := range list.Addrs {
+		if _, ok := b.addrs[ka.Addr]; ok {
+			continue
+		}
+		pubKey := keypair.ZeroPublicKey
+		if ka.PubKey != "" {
+			if pubKey, err = keypair.DecodePublicKey(ka.PubKey); err != nil {
+				return nil, errors.Wrapf(err, "failed to decode the key of %s in address book %s", ka.Addr, path)
+			}
+		}
+		ka.setKey(pubKey)
+		b.insert(ka)
+	}
+	return b, nil
+}
+
+// Self returns the node's own ID
+func (b *AddrBook) Self() NodeID {
+	if b == nil {
+		return NodeID{}
+	}
+	return b.self
+}
+
+// Add adds the address of the node with the key into the address book, unless it is already known, in which case only
+// the unknown key is filled. If the bucket is full, the least recently seen address is evicted if it has failed,
+// otherwise the new address is dropped, which favors the long-lived nodes.
+func (b *AddrBook) Add(addr string, pubKey keypair.PublicKey) {
+	if b == nil || addr == "" || addr == b.selfAddr {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ka, ok := b.addrs[addr]
+	if !ok {
+		b.insert(newKnownAddr(addr, pubKey))
+		return
+	}
+	if !ka.keyed() && pubKey != keypair.ZeroPublicKey {
+		b.rekey(ka, pubKey)
+	}
+}
+
+// MarkGood marks the address as seen just now with the key which the node at it has authenticated with, and moves it
+// to the tail of its bucket. The authenticated key replaces the one which the address is known with.
+func (b *AddrBook) MarkGood(addr string, pubKey keypair.PublicKey) {
+	if b == nil || addr == "" || addr == b.selfAddr {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ka, ok := b.addrs[addr]
+	switch {
+	case !ok:
+		ka = newKnownAddr(addr, pubKey)
+		if !b.insert(ka) {
+			return
+		}
+	case pubKey != keypair.ZeroPublicKey && pubKey != ka.pubKey:
+		if !b.rekey(ka, pubKey) {
+			return
+		}
+	}
+	ka.LastSeen = b.clock.Now()
+	ka.Failures = 0
+	bucket := b.bucket(ka)
+	*bucket = append(removeAddr(*bucket, addr), ka)
+}
+
+// MarkFailed records a failure to reach the address, and drops it after too many consecutive failures
+func (b *AddrBook) MarkFailed(addr string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ka, ok := b.addrs[addr]
+	if !ok {
+		return
+	}
+	ka.Failures++
+	if ka.Failures >= maxAddrFailures {
+		b.remove(ka)
+	}
+}
+
+// Closest returns at most n known addresses closest to the target by XOR distance. The addresses whose keys are not
+// known are not returned, since their distances are unknown.
+func (b *AddrBook) Closest(target NodeID, n int) []NodeAddr {
+	if b == nil {
+		return nil
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var kas []*knownAddr
+	for _, bucket := range b.buckets {
+		kas = append(kas, bucket...)
+	}
+	sort.Slice(kas, func(i, j int) bool {
+		di, dj := distance(kas[i].id, target), distance(kas[j].id, target)
+		return bytes.Compare(di[:], dj[:]) < 0
+	})
+	if len(kas) > n {
+		kas = kas[:n]
+	}
+	nodes := make([]NodeAddr, 0, len(kas))
+	for _, ka := range kas {
+		nodes = append(nodes, NodeAddr{Addr: ka.Addr, PubKey: ka.pubKey})
+	}
+	return nodes
+}
+
+// Unkeyed returns the known addresses whose keys are not known yet
+func (b *AddrBook) Unkeyed() []string {
+	if b == nil {
+		return nil
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	addrs := make([]string, 0, len(b.unkeyed))
+	for _, ka := range b.unkeyed {
+		addrs = append(addrs, ka.Addr)
+	}
+	return addrs
+}
+
+// Pick returns at most n random known addresses which are not excluded, picking the ones which haven't failed first
+func (b *AddrBook) Pick(n int, exclude func(string) bool) []string {
+	if b == nil {
+		return nil
+	}
+	b.mu.RLock()
+	var good, failed []string
+	for _, ka := range b.addrs {
+		if exclude != nil && exclude(ka.Addr) {
+			continue
+		}
+		if ka.Failures == 0 {
+			good = append(good, ka.Addr)
+		} else {
+			failed = append(failed, ka.Addr)
+		}
+	}
+	b.mu.RUnlock()
+
+	stringsAreShuffled(good)
+	stringsAreShuffled(failed)
+	addrs := append(good, failed...)
+	if len(addrs) > n {
+		addrs = addrs[:n]
+	}
+	return addrs
+}
+
+// Size returns the number of known addresses
+func (b *AddrBook) Size() int {
+	if b == nil {
+		return 0
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.addrs)
+}
+
+// Save persists the known addresses into the file
+func (b *AddrBook) Save() error {
+	if b == nil || b.path == "" {
+		return nil
+	}
+	b.mu.RLock()
+	list := addrList{}
+	for _, bucket := range b.buckets {
+		list.Addrs = append(list.Addrs, bucket...)
+	}
+	list.Addrs = append(list.Addrs, b.unkeyed...)
+	data, err := yaml.Marshal(&list)
+	b.mu.RUnlock()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal address book")
+	}
+	if err := ioutil.WriteFile(b.path, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write address book %s", b.path)
+	}
+	logger.Debug().Int("size", len(list.Addrs)).Msg("Saved the address book")
+	return nil
+}
+
+// bucket returns the bucket which the address falls into, which is nil if it has the same ID as the node itself
+func (b *AddrBook) bucket(ka *knownAddr) *[]*knownAddr {
+	if !ka.keyed() {
+		return &b.unkeyed
+	}
+	idx := bucketIndex(distance(b.self, ka.id))
+	if idx < 0 {
+		return nil
+	}
+	return &b.buckets[idx]
+}
+
+// insert puts the address into its bucket, and returns false if the address is dropped
+func (b *AddrBook) insert(ka *knownAddr) bool {
+	bucket := b.bucket(ka)
+	if bucket == nil {
+		return false
+	}
+	if len(*bucket) >= b.bucketSize {
+		evicted := (*bucket)[0]
+		if evicted.Failures == 0 {
+			return false
+		}
+		*bucket = (*bucket)[1:]
+		delete(b.addrs, evicted.Addr)
+	}
+	*bucket = append(*bucket, ka)
+	b.addrs[ka.Addr] = ka
+	return true
+}
+
+// remove drops the address from the address book
+func (b *AddrBook) remove(ka *knownAddr) {
+	if bucket := b.bucket(ka); bucket != nil {
+		*bucket = removeAddr(*bucket, ka.Addr)
+	}
+	delete(b.addrs, ka.Addr)
+}
+
+// rekey moves the address into the bucket of the new key, and returns false if it is dropped
+func (b *AddrBook) rekey(ka *knownAddr, pubKey keypair.PublicKey) bool {
+	b.remove(ka)
+	ka.setKey(pubKey)
+	return b.insert(ka)
+}
+
+func removeAddr(bucket []*knownAddr, addr string) []*knownAddr {
+	kept := make([]*knownAddr, 0, len(bucket))
+	for _, ka := range bucket {
+		if ka.Addr != addr {
+			kept = append(kept, ka)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+)
+
+// testNodeKey returns a fake key of the node at the address, which only serves to derive the node ID
+func testNodeKey(addr string) keypair.PublicKey {
+	var pubKey keypair.PublicKey
+	copy(pubKey[:], hash.Hash256b([]byte(addr)))
+	return pubKey
+}
+
+// testNodeID returns the ID of the node at the address with the fake key
+func testNodeID(addr string) NodeID {
+	return NewNodeID(testNodeKey(addr))
+}
+
+func TestBucketIndex(t *testing.T) {
+	require := require.New(t)
+
+	var d NodeID
+	require.Equal(-1, bucketIndex(d))
+	d[NodeIDLength-1] = 1
+	require.Equal(0, bucketIndex(d))
+	d[NodeIDLength-2] = 0x10
+	require.Equal(12, bucketIndex(d))
+	d[0] = 0x80
+	require.Equal(NodeIDLength*8-1, bucketIndex(d))
+}
+
+func TestAddrBook(t *testing.T) {
+	require := require.New(t)
+
+	self := "127.0.0.1:10000"
+	book, err := NewAddrBook(self, testNodeKey(self), 1, "")
+	require.NoError(err)
+
+	// Find two addresses falling into the same bucket, and one into another
+	var addr1, addr2, addr3 string
+	for i := 1; addr3 == "" || addr2 == ""; i++ {
+		addr := fmt.Sprintf("127.0.0.1:%d", 10000+i)
+		idx := bucketIndex(distance(book.Self(), testNodeID(addr)))
+		switch {
+		case addr1 == "":
+			addr1 = addr
+		case addr2 == "" && idx == bucketIndex(distance(book.Self(), testNodeID(addr1))):
+			addr2 = addr
+		case addr3 == "" && idx != bucketIndex(distance(book.Self(), testNodeID(addr1))):
+			addr3 = addr
+		}
+	}
+
+	// The node itself is not added, and the new address is dropped if the bucket is full of the good ones
+	book.Add(self, testNodeKey(self))
+	book.Add(addr1, testNodeKey(addr1))
+	book.Add(addr2, testNodeKey(addr2))
+	book.Add(addr3, testNodeKey(addr3))
+	require.Equal(2, book.Size())
+	require.ElementsMatch([]string{addr1, addr3}, book.Pick(10, nil))
+	require.Equal([]string{addr3}, book.Pick(10, func(addr string) bool { return addr == addr1 }))
+
+	// The failed address is evicted for the new one, and is dropped after too many failures
+	book.MarkFailed(addr1)
+	require.Equal(addr1, book.Pick(10, nil)[1])
+	book.Add(addr2, testNodeKey(addr2))
+	require.ElementsMatch([]string{addr2, addr3}, book.Pick(10, nil))
+	book.MarkGood(addr1, testNodeKey(addr1))
+	require.ElementsMatch([]string{addr2, addr3}, book.Pick(10, nil))
+	for i := 0; i < maxAddrFailures; i++ {
+		book.MarkFailed(addr3)
+	}
+	require.Equal([]string{addr2}, book.Pick(10, nil))
+
+	// A nil address book knows no address
+	var nilBook *AddrBook
+	nilBook.Add(addr1, testNodeKey(addr1))
+	nilBook.MarkGood(addr1, testNodeKey(addr1))
+	nilBook.MarkFailed(addr1)
+	require.Empty(nilBook.Pick(10, nil))
+	require.Empty(nilBook.Closest(testNodeID(addr1), 10))
+	require.Empty(nilBook.Unkeyed())
+	require.Equal(0, nilBook.Size())
+	require.NoError(nilBook.Save())
+}
+
+func TestAddrBookKeys(t *testing.T) {
+	require := require.New(t)
+
+	book, err := NewAddrBook("127.0.0.1:10000", testNodeKey("127.0.0.1:10000"), 16, "")
+	require.NoError(err)
+
+	// The address whose key is unknown is only picked, until the key is learned
+	addr := "127.0.0.1:10001"
+	book.Add(addr, keypair.ZeroPublicKey)
+	require.Equal(1, book.Size())
+	require.Equal([]string{addr}, book.Pick(10, nil))
+	require.Equal([]string{addr}, book.Unkeyed())
+	require.Empty(book.Closest(testNodeID(addr), 10))
+	book.Add(addr, testNodeKey(addr))
+	require.Empty(book.Unkeyed())
+	require.Equal([]NodeAddr{{Addr: addr, PubKey: testNodeKey(addr)}}, book.Closest(testNodeID(addr), 10))
+
+	// The advertised key doesn't replace the known one, while the authenticated key does
+	book.Add(addr, testNodeKey("another"))
+	require.Equal([]NodeAddr{{Addr: addr, PubKey: testNodeKey(addr)}}, book.Closest(testNodeID(addr), 10))
+	book.MarkGood(addr, testNodeKey("another"))
+	require.Equal(1, book.Size())
+	require.Equal([]NodeAddr{{Addr: addr, PubKey: testNodeKey("another")}}, book.Closest(testNodeID(addr), 10))
+}
+
+func TestAddrBookClosest(t *testing.T) {
+	require := require.New(t)
+
+	book, err := NewAddrBook("127.0.0.1:10000", testNodeKey("127.0.0.1:10000"), 100, "")
+	require.NoError(err)
+	var nodes []NodeAddr
+	for i := 1; i <= 50; i++ {
+		addr := fmt.Sprintf("127.0.0.1:%d", 10000+i)
+		book.Add(addr, testNodeKey(addr))
+		nodes = append(nodes, NodeAddr{Addr: addr, PubKey: testNodeKey(addr)})
+	}
+	require.Equal(50, book.Size())
+
+	target := testNodeID("127.0.0.1:20000")
+	sortByDistance(nodes, target)
+	require.Equal(nodes[:10], book.Closest(target, 10))
+	require.Equal(nodes, book.Closest(target, 100))
+	closest := book.Closest(testNodeID("127.0.0.1:10001"), 1)
+	require.Equal(1, len(closest))
+	require.Equal("127.0.0.1:10001", closest[0].Addr)
+}
+
+func TestAddrBookPersistence(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "addrbook")
+	require.NoError(err)
+	defer func() {
+		require.NoError(os.RemoveAll(dir))
+	}()
+	path := filepath.Join(dir, "addrbook.yaml")
+
+	selfKey := testNodeKey("127.0.0.1:10000")
+	book, err := NewAddrBook("127.0.0.1:10000", selfKey, 16, path)
+	require.NoError(err)
+	require.Equal(0, book.Size())
+	book.Add("127.0.0.1:10001", keypair.ZeroPublicKey)
+	book.MarkGood("127.0.0.1:10002", testNodeKey("127.0.0.1:10002"))
+	book.MarkFailed("127.0.0.1:10001")
+	require.NoError(book.Save())
+
+	// The known addresses, their keys and failures are loaded after restart
+	book2, err := NewAddrBook("127.0.0.1:10000", selfKey, 16, path)
+	require.NoError(err)
+	require.Equal(2, book2.Size())
+	require.Equal([]string{"127.0.0.1:10002", "127.0.0.1:10001"}, book2.Pick(10, nil))
+	require.Equal([]string{"127.0.0.1:10001"}, book2.Unkeyed())
+	closest := book2.Closest(testNodeID("127.0.0.1:10002"), 1)
+	require.Equal(1, len(closest))
+	require.Equal(testNodeKey("127.0.0.1:10002"), closest[0].PubKey)
+
+	require.NoError(ioutil.WriteFile(path, []byte("addrs: {"), 0600))
+	_, err = NewAddrBook("127.0.0.1:10000", selfKey, 16, path)
+	require.Error(err)
+}
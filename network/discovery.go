@@ -0,0 +1,227 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"sort"
+	"time"
+
+	"github.com/iotexproject/iotex-core/logger"
+	pb "github.com/iotexproject/iotex-core/network/proto"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+)
+
+const (
+	// lookupAlpha is the number of nodes queried concurrently in each step of a lookup
+	lookupAlpha = 3
+	// findNodeTimeout is how long a node is waited for to answer a lookup query
+	findNodeTimeout = 3 * time.Second
+)
+
+// findNodeResult is the answer of a node to a lookup query
+type findNodeResult struct {
+	addr string
+	// pubKey is the key which the node has authenticated with
+	pubKey keypair.PublicKey
+	nodes  []NodeAddr
+	err    error
+}
+
+// Discovery finds the nodes in the P2P network by Kademlia-style lookups, and fills the address book with them. It
+// only learns about the nodes, while the peer maintainer chooses which of them to connect to.
+type Discovery struct {
+	Overlay *IotxOverlay
+	round   int
+	// findNode asks the node at the address for the known nodes closest to the target, and returns them along with
+	// the key which the node has authenticated with
+	findNode func(addr string, target NodeID, count int) (keypair.PublicKey, []NodeAddr, error)
+}
+
+// NewDiscovery creates an instance of Discovery
+func NewDiscovery(o *IotxOverlay) *Discovery {
+	d := &Discovery{Overlay: o}
+	d.findNode = d.findNodeRPC
+	return d
+}
+
+// Discover looks up the node itself in the first round to learn about its neighborhood, and a random ID in each of
+// the following rounds to refresh the far buckets. The bootstrap nodes are always known, so that the node could rejoin
+// the network when all the other addresses go stale.
+func (d *Discovery) Discover() {
+	defer func() {
+		d.round++
+	}()
+
+	for _, bn := range d.Overlay.Config.BootstrapNodes {
+		d.Overlay.AddrBook.Add(bn, keypair.ZeroPublicKey)
+	}
+	target := d.Overlay.AddrBook.Self()
+	if d.round > 0 {
+		if _, err := rand.Read(target[:]); err != nil {
+			logger.Error().Err(err).Msg("Failed to generate a random node ID")
+			return
+		}
+	}
+	found := d.Lookup(target)
+	logger.Debug().
+		Str("target", target.String()).
+		Int("found", len(found)).
+		Int("known", d.Overlay.AddrBook.Size()).
+		Msg("Looked up the network")
+	if err := d.Overlay.AddrBook.Save(); err != nil {
+		logger.Error().Err(err).Msg("Failed to persist the address book")
+	}
+}
+
+// Lookup iteratively queries the closest known nodes to the target for the nodes even closer, until the closest ones
+// have all been queried, and returns their addresses. The nodes whose keys are not known yet, such as the bootstrap
+// nodes, are queried as well, and are placed by the keys which they authenticate with. The nodes found along the way
+// are added into the address book.
+func (d *Discovery) Lookup(target NodeID) []string {
+	book := d.Overlay.AddrBook
+	if book == nil {
+		return nil
+	}
+	k := book.bucketSize
+	closest := book.Closest(target, k)
+	for _, addr := range book.Unkeyed() {
+		closest = append(closest, NodeAddr{Addr: addr})
+	}
+	seen := make(map[string]bool)
+	for _, node := range closest {
+		seen[node.Addr] = true
+	}
+	queried := make(map[string]bool)
+	for {
+		var batch []string
+		for _, node := range closest {
+			if !queried[node.Addr] {
+				batch = append(batch, node.Addr)
+			}
+			if len(batch) == lookupAlpha {
+				break
+			}
+		}
+		if len(batch) == 0 {
+			addrs := make([]string, 0, len(closest))
+			for _, node := range closest {
+				addrs = append(addrs, node.Addr)
+			}
+			return addrs
+		}
+		results := make(chan findNodeResult, len(batch))
+		for _, addr := range batch {
+			queried[addr] = true
+			go func(addr string) {
+				pubKey, nodes, err := d.findNode(addr, target, k)
+				results <- findNodeResult{addr: addr, pubKey: pubKey, nodes: nodes, err: err}
+			}(addr)
+		}
+		var failed []string
+		for range batch {
+			res := <-results
+			if res.err != nil {
+				logger.Debug().Err(res.err).Str("dst", res.addr).Msg("Node failed to answer the lookup")
+				book.MarkFailed(res.addr)
+				failed = append(failed, res.addr)
+				continue
+			}
+			book.MarkGood(res.addr, res.pubKey)
+			for i := range closest {
+				if closest[i].Addr == res.addr && res.pubKey != keypair.ZeroPublicKey {
+					closest[i].PubKey = res.pubKey
+				}
+			}
+			for _, node := range res.nodes {
+				if node.Addr == d.Overlay.RPC.String() || seen[node.Addr] || d.Overlay.IsPeerBanned(node.Addr) {
+					continue
+				}
+				seen[node.Addr] = true
+				book.Add(node.Addr, node.PubKey)
+				closest = append(closest, node)
+			}
+		}
+		for _, addr := range failed {
+			closest = removeNode(closest, addr)
+		}
+		sortByDistance(closest, target)
+		if len(closest) > k {
+			closest = closest[:k]
+		}
+	}
+}
+
+// findNodeRPC queries the node by GetPeers, over the existing connection if it is a peer, or a temporary one otherwise
+func (d *Discovery) findNodeRPC(addr string, target NodeID, count int) (keypair.PublicKey, []NodeAddr, error) {
+	req := &pb.GetPeersReq{Count: uint32(count), Target: target[:]}
+	if value, ok := d.Overlay.PM.Peers.Load(addr); ok {
+		p := value.(*Peer)
+		res, err := p.GetPeers(req)
+		if err != nil {
+			return keypair.ZeroPublicKey, nil, err
+		}
+		return p.PubKey, nodeAddrs(res), nil
+	}
+	p := NewTCPPeer(addr)
+	if err := p.Connect(d.Overlay.Config, d.Overlay.Identity, d.Overlay.RPC.String()); err != nil {
+		return keypair.ZeroPublicKey, nil, err
+	}
+	defer func() {
+		if err := p.Close(); err != nil {
+			logger.Error().Err(err).Str("dst", addr).Msg("Failed to close the lookup connection")
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), findNodeTimeout)
+	defer cancel()
+	p.Ctx = ctx
+	res, err := p.GetPeers(req)
+	if err != nil {
+		return keypair.ZeroPublicKey, nil, err
+	}
+	return p.PubKey, nodeAddrs(res), nil
+}
+
+// nodeAddrs returns the nodes in the GetPeers response. The key of a node is zero if it is missing or malformed.
+func nodeAddrs(res *pb.GetPeersRes) []NodeAddr {
+	nodes := make([]NodeAddr, 0, len(res.Addr))
+	for i, addr := range res.Addr {
+		node := NodeAddr{Addr: addr}
+		if i < len(res.PubKey) {
+			if pubKey, err := keypair.BytesToPublicKey(res.PubKey[i]); err == nil {
+				node.PubKey = pubKey
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// sortByDistance sorts the nodes from the closest to the farthest to the target, followed by the ones whose keys are
+// not known
+func sortByDistance(nodes []NodeAddr, target NodeID) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		ki, kj := nodes[i].PubKey != keypair.ZeroPublicKey, nodes[j].PubKey != keypair.ZeroPublicKey
+		if ki != kj {
+			return ki
+		}
+		di, dj := distance(NewNodeID(nodes[i].PubKey), target), distance(NewNodeID(nodes[j].PubKey), target)
+		return bytes.Compare(di[:], dj[:]) < 0
+	})
+}
+
+func removeNode(nodes []NodeAddr, addr string) []NodeAddr {
+	kept := make([]NodeAddr, 0, len(nodes))
+	for _, node := range nodes {
+		if node.Addr != addr {
+			kept = append(kept, node)
+		}
+	}
+	return kept
+}
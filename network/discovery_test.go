@@ -0,0 +1,125 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+)
+
+// newDiscoveryNetwork creates the nodes bootstrapping from the first one, whose lookups are answered by each other's
+// address books directly. The queried node learns about the querying one, as it would from its pings.
+func newDiscoveryNetwork(t *testing.T, n int, bucketSize int, dead map[string]bool) []*Discovery {
+	nodes := make(map[string]*IotxOverlay)
+	var ds []*Discovery
+	for i := 0; i < n; i++ {
+		addr := fmt.Sprintf("127.0.0.1:%d", 30000+i)
+		cfg := LoadTestConfig(addr, true)
+		cfg.BootstrapNodes = []string{"127.0.0.1:30000"}
+		o := &IotxOverlay{Config: cfg}
+		o.RPC = NewRPCServer(o)
+		o.PM = NewPeerManager(o, cfg.NumPeersLowerBound, cfg.NumPeersUpperBound)
+		book, err := NewAddrBook(addr, testNodeKey(addr), bucketSize, "")
+		require.NoError(t, err)
+		o.AddrBook = book
+		nodes[addr] = o
+
+		d := NewDiscovery(o)
+		d.findNode = func(addr string, target NodeID, count int) (keypair.PublicKey, []NodeAddr, error) {
+			if dead[addr] {
+				return keypair.ZeroPublicKey, nil, errors.New("node is down")
+			}
+			nodes[addr].AddrBook.Add(o.RPC.String(), testNodeKey(o.RPC.String()))
+			return testNodeKey(addr), nodes[addr].AddrBook.Closest(target, count), nil
+		}
+		ds = append(ds, d)
+	}
+	return ds
+}
+
+func TestDiscovery(t *testing.T) {
+	require := require.New(t)
+
+	ds := newDiscoveryNetwork(t, 50, 4, nil)
+	for round := 0; round < 3; round++ {
+		for _, d := range ds {
+			d.Discover()
+		}
+	}
+
+	// Every node is found by a lookup from any other node
+	for _, i := range []int{1, 17, 49} {
+		for _, j := range []int{0, 23, 42} {
+			target := ds[j].Overlay.RPC.String()
+			found := ds[i].Lookup(testNodeID(target))
+			require.NotEmpty(found)
+			require.Equal(target, found[0])
+		}
+	}
+	for _, d := range ds {
+		require.True(d.Overlay.AddrBook.Size() >= 4)
+	}
+}
+
+func TestDiscoveryDeadNode(t *testing.T) {
+	require := require.New(t)
+
+	dead := map[string]bool{"127.0.0.1:30001": true}
+	ds := newDiscoveryNetwork(t, 5, 16, dead)
+	for _, d := range ds[2:] {
+		d.Discover()
+	}
+	ds[4].Overlay.AddrBook.Add("127.0.0.1:30001", testNodeKey("127.0.0.1:30001"))
+
+	// The dead node is not returned by a lookup, and is dropped from the address book after too many failures
+	found := ds[4].Lookup(testNodeID("127.0.0.1:30001"))
+	require.NotContains(found, "127.0.0.1:30001")
+	require.Contains(found, "127.0.0.1:30000")
+	for i := 0; i < maxAddrFailures; i++ {
+		ds[4].Lookup(testNodeID("127.0.0.1:30001"))
+	}
+	require.NotContains(ds[4].Overlay.AddrBook.Pick(10, nil), "127.0.0.1:30001")
+}
+
+func TestPeerMaintainerPicksFromAddrBook(t *testing.T) {
+	require := require.New(t)
+
+	cfg := LoadTestConfig("127.0.0.1:30000", true)
+	cfg.NumPeersLowerBound = 2
+	o := &IotxOverlay{Config: cfg}
+	o.RPC = NewRPCServer(o)
+	o.PM = NewPeerManager(o, cfg.NumPeersLowerBound, cfg.NumPeersUpperBound)
+	r, err := NewReputation(cfg)
+	require.NoError(err)
+	o.Reputation = r
+	book, err := NewAddrBook(o.RPC.String(), testNodeKey(o.RPC.String()), 16, "")
+	require.NoError(err)
+	o.AddrBook = book
+	for _, addr := range []string{"127.0.0.1:30001", "127.0.0.1:30002", "127.0.0.1:30003"} {
+		book.Add(addr, testNodeKey(addr))
+	}
+	r.bans["127.0.0.1:30003"] = r.clock.Now().Add(time.Hour)
+
+	// The peers are picked from the address book up to the lower bound, skipping the banned ones
+	pm := NewPeerMaintainer(o)
+	defer o.PM.Peers.Range(func(_, value interface{}) bool {
+		require.NoError(value.(*Peer).Close())
+		return true
+	})
+	pm.Update()
+	require.Equal(uint(2), LenSyncMap(o.PM.Peers))
+	_, ok := o.PM.Peers.Load("127.0.0.1:30003")
+	require.False(ok)
+	pm.Update()
+	require.Equal(uint(2), LenSyncMap(o.PM.Peers))
+}
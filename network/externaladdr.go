@@ -0,0 +1,219 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/facebookgo/clock"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/network/nat"
+)
+
+const (
+	// maxObservations is the max number of peers whose observed hosts are remembered
+	maxObservations = 64
+	// portMappingDesc is the description of the port mapping on the NAT gateway
+	portMappingDesc = "iotex"
+)
+
+// ExternalAddr is the address which the node advertises to the other nodes. The host is the first known one of the
+// configured external host, the public IP of the NAT gateway, the host observed by enough peers and the bind host. The
+// port is the first known one of the configured external port, the port mapped on the NAT gateway and the bound port.
+type ExternalAddr struct {
+	mu           sync.RWMutex
+	cfgHost      string
+	cfgPort      int
+	bindHost     string
+	boundPort    int
+	natHost      string
+	natPort      int
+	observedHost string
+	threshold    int
+	observations map[string]string
+}
+
+// NewExternalAddr creates an instance of ExternalAddr
+func NewExternalAddr(cfg *config.Network) *ExternalAddr {
+	return &ExternalAddr{
+		cfgHost:      cfg.ExternalHost,
+		cfgPort:      cfg.ExternalPort,
+		bindHost:     cfg.Host,
+		boundPort:    cfg.Port,
+		threshold:    cfg.ObservedAddrThreshold,
+		observations: make(map[string]string),
+	}
+}
+
+// String returns the external address in the form of host:port
+func (a *ExternalAddr) String() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	host := a.bindHost
+	for _, h := range []string{a.cfgHost, a.natHost, a.observedHost} {
+		if h != "" {
+			host = h
+			break
+		}
+	}
+	port := a.boundPort
+	for _, p := range []int{a.cfgPort, a.natPort} {
+		if p > 0 {
+			port = p
+			break
+		}
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// Observe records the host of the node observed by the peer. Once the number of the peers observing the same host
+// reaches the threshold, the host is taken as the external host.
+func (a *ExternalAddr) Observe(peer string, host string) {
+	if a.threshold <= 0 {
+		return
+	}
+	if ip := net.ParseIP(host); ip == nil || ip.IsUnspecified() {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.observations[peer]; !ok && len(a.observations) >= maxObservations {
+		// Forget a random peer to make room for the new one
+		for p := range a.observations {
+			delete(a.observations, p)
+			break
+		}
+	}
+	a.observations[peer] = host
+	if host == a.observedHost {
+		return
+	}
+	count := 0
+	for _, h := range a.observations {
+		if h == host {
+			count++
+		}
+	}
+	if count >= a.threshold {
+		logger.Info().Str("host", host).Int("peers", count).Msg("Learned the external host from the peers")
+		a.observedHost = host
+	}
+}
+
+func (a *ExternalAddr) setBoundPort(port int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.boundPort = port
+}
+
+func (a *ExternalAddr) setNAT(host string, port int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.natHost = host
+	a.natPort = port
+}
+
+// portMapper maps the port of the node on the NAT gateway, and renews the mapping every half of its lifetime until it
+// is stopped
+type portMapper struct {
+	newNAT   func() (nat.NAT, error)
+	addr     *ExternalAddr
+	clock    clock.Clock
+	intPort  int
+	extPort  int
+	lifetime time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func newPortMapper(
+	newNAT func() (nat.NAT, error),
+	addr *ExternalAddr,
+	intPort int,
+	extPort int,
+	lifetime time.Duration,
+) *portMapper {
+	return &portMapper{
+		newNAT:   newNAT,
+		addr:     addr,
+		clock:    clock.New(),
+		intPort:  intPort,
+		extPort:  extPort,
+		lifetime: lifetime,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (m *portMapper) start() {
+	go m.run()
+}
+
+// close stops renewing the mapping, and removes it from the gateway
+func (m *portMapper) close() {
+	close(m.stop)
+	<-m.done
+}
+
+func (m *portMapper) run() {
+	defer close(m.done)
+
+	n, err := m.newNAT()
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to find the NAT gateway")
+		return
+	}
+	mapped := m.mapPort(n, 0)
+	ticker := m.clock.Ticker(m.lifetime / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stop:
+			if mapped == 0 {
+				return
+			}
+			if err := n.DeletePortMapping("tcp", mapped, m.intPort); err != nil {
+				logger.Error().Err(err).Int("port", mapped).Msg("Failed to remove the port mapping")
+			}
+			m.addr.setNAT("", 0)
+			return
+		case <-ticker.C:
+			mapped = m.mapPort(n, mapped)
+		}
+	}
+}
+
+// mapPort maps the port on the gateway, asking for the port mapped before if any to keep the external address stable,
+// and returns the mapped port, or the port mapped before if it fails
+func (m *portMapper) mapPort(n nat.NAT, mapped int) int {
+	ip, err := n.ExternalIP()
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to get the external IP from the NAT gateway")
+		return mapped
+	}
+	extPort := m.extPort
+	if mapped != 0 {
+		extPort = mapped
+	}
+	port, err := n.AddPortMapping("tcp", extPort, m.intPort, portMappingDesc, m.lifetime)
+	if err != nil {
+		logger.Error().Err(err).Int("port", extPort).Msg("Failed to map the port on the NAT gateway")
+		return mapped
+	}
+	if port != mapped {
+		logger.Info().Str("host", ip.String()).Int("port", port).Msg("Mapped the port on the NAT gateway")
+	}
+	m.addr.setNAT(ip.String(), port)
+	return port
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/network/nat"
+)
+
+// fakeNAT maps the requested external port plus 1, unless the requested port is 0, and records the current mappings
+type fakeNAT struct {
+	mu       sync.Mutex
+	mappings map[int]int
+	adds     int
+}
+
+func (n *fakeNAT) ExternalIP() (net.IP, error) {
+	return net.IPv4(1, 2, 3, 4), nil
+}
+
+func (n *fakeNAT) AddPortMapping(_ string, extPort int, intPort int, _ string, _ time.Duration) (int, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.mappings[extPort]; !ok {
+		extPort++
+	}
+	n.mappings[extPort] = intPort
+	n.adds++
+	return extPort, nil
+}
+
+func (n *fakeNAT) DeletePortMapping(_ string, extPort int, _ int) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.mappings, extPort)
+	return nil
+}
+
+func (n *fakeNAT) state() (map[int]int, int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	mappings := make(map[int]int)
+	for ext, in := range n.mappings {
+		mappings[ext] = in
+	}
+	return mappings, n.adds
+}
+
+func TestExternalAddr(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default.Network
+	cfg.Host = "0.0.0.0"
+	cfg.Port = 0
+	cfg.ObservedAddrThreshold = 2
+	addr := NewExternalAddr(&cfg)
+	addr.setBoundPort(4689)
+	require.Equal("0.0.0.0:4689", addr.String())
+
+	// The observed host is taken once enough peers agree on it
+	addr.Observe("10.0.0.1:4689", "5.6.7.8")
+	addr.Observe("10.0.0.2:4689", "")
+	addr.Observe("10.0.0.3:4689", "0.0.0.0")
+	require.Equal("0.0.0.0:4689", addr.String())
+	addr.Observe("10.0.0.1:4689", "5.6.7.8")
+	require.Equal("0.0.0.0:4689", addr.String())
+	addr.Observe("10.0.0.2:4689", "5.6.7.8")
+	require.Equal("5.6.7.8:4689", addr.String())
+
+	// The NAT gateway overrides the observed host
+	addr.setNAT("1.2.3.4", 4690)
+	require.Equal("1.2.3.4:4690", addr.String())
+
+	// The configured address overrides everything
+	cfg.ExternalHost = "iotex.io"
+	cfg.ExternalPort = 4700
+	addr = NewExternalAddr(&cfg)
+	addr.setNAT("1.2.3.4", 4690)
+	require.Equal("iotex.io:4700", addr.String())
+
+	// The observed hosts are ignored if the threshold is 0
+	cfg = config.Default.Network
+	cfg.ObservedAddrThreshold = 0
+	addr = NewExternalAddr(&cfg)
+	addr.Observe("10.0.0.1:4689", "5.6.7.8")
+	require.Equal("127.0.0.1:4689", addr.String())
+}
+
+func TestPortMapper(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default.Network
+	addr := NewExternalAddr(&cfg)
+	n := &fakeNAT{mappings: make(map[int]int)}
+	m := newPortMapper(func() (nat.NAT, error) { return n, nil }, addr, 4689, 4689, time.Minute)
+	clk := clock.NewMock()
+	m.clock = clk
+	m.start()
+
+	require.NoError(waitUntil(func() bool { return addr.String() == "1.2.3.4:4690" }))
+	mappings, _ := n.state()
+	require.Equal(map[int]int{4690: 4689}, mappings)
+
+	// The mapping is renewed every half of the lifetime with the same external port
+	require.NoError(waitUntil(func() bool {
+		clk.Add(30 * time.Second)
+		_, adds := n.state()
+		return adds >= 3
+	}))
+	mappings, _ = n.state()
+	require.Equal(map[int]int{4690: 4689}, mappings)
+	require.Equal("1.2.3.4:4690", addr.String())
+
+	// The mapping is removed once the mapper is closed
+	m.close()
+	mappings, _ = n.state()
+	require.Empty(mappings)
+	require.Equal("127.0.0.1:4689", addr.String())
+}
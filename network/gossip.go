@@ -7,26 +7,38 @@
 package network
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"math/rand"
 	"sync"
-	"time"
 
-	"encoding/hex"
+	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network/proto"
+	"github.com/iotexproject/iotex-core/network/trace"
+	"github.com/iotexproject/iotex-core/pkg/cache"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
-	"github.com/iotexproject/iotex-core/pkg/routine"
 	"github.com/iotexproject/iotex-core/proto"
 )
 
-// Gossip relays messages in the IotxOverlay (at least once semantics)
+// ErrInvalidChecksum means the checksum of the broadcast message doesn't match the hash of its body
+var ErrInvalidChecksum = errors.New("invalid message checksum")
+
+// Gossip relays messages in the IotxOverlay (at least once semantics). A message is relayed to the random peers up to
+// the fanout of its type. The message of a lazy push type is only announced by its hash, and the peers which haven't
+// seen it pull the full message, so that a large message is sent to each node once.
 type Gossip struct {
-	Overlay     *IotxOverlay
-	Dispatcher  dispatcher.Dispatcher
-	MsgLogs     *sync.Map
-	CleanerTask *routine.RecurringTask
+	Overlay    *IotxOverlay
+	Dispatcher dispatcher.Dispatcher
+	// Seen is the hashes of the most recent messages, along with the full messages of the lazy push types to answer
+	// the pulls
+	Seen     *cache.LRU
+	lazyPush map[uint32]bool
+	pulling  *sync.Map
 
 	lifecycle lifecycle.Lifecycle
 }
@@ -34,12 +46,14 @@ type Gossip struct {
 // NewGossip generates a Gossip instance
 func NewGossip(o *IotxOverlay) *Gossip {
 	g := &Gossip{
-		Overlay: o,
-		MsgLogs: &sync.Map{},
+		Overlay:  o,
+		Seen:     cache.NewLRU(o.Config.SeenCacheSize),
+		lazyPush: make(map[uint32]bool),
+		pulling:  &sync.Map{},
+	}
+	for _, msgType := range o.Config.GossipLazyPushMsgTypes {
+		g.lazyPush[msgType] = true
 	}
-	cleaner := NewMsgLogsCleaner(g)
-	g.CleanerTask = routine.NewRecurringTask(cleaner.Clean, o.Config.MsgLogsCleaningInterval)
-	g.lifecycle.Add(g.CleanerTask)
 	return g
 }
 
@@ -54,14 +68,31 @@ func (g *Gossip) AttachDispatcher(dispatcher dispatcher.Dispatcher) {
 	g.Dispatcher = dispatcher
 }
 
-// OnReceivingMsg listens to and handles the incoming broadcast message
+// OnReceivingMsg listens to and handles the incoming broadcast message. The message whose checksum doesn't match the
+// hash of its body is rejected before it is remembered, so that a forged body can't take the place of the genuine
+// message in the seen cache, and the peer sending it is penalized.
 func (g *Gossip) OnReceivingMsg(msg *network.BroadcastReq) error {
-	checksumStr := hex.EncodeToString(msg.MsgChecksum)
-	if _, loaded := g.MsgLogs.LoadOrStore(checksumStr, time.Now()); loaded {
+	if !bytes.Equal(hash.Hash256b(msg.MsgBody), msg.MsgChecksum) {
+		g.Overlay.PenalizePeer(msg.Addr, OffenseInvalidData)
+		return errors.Wrapf(ErrInvalidChecksum, "message from %s", msg.Addr)
+	}
+	seen := g.markSeen(msg)
+	g.Overlay.trace(trace.Event{
+		Direction: trace.Receive,
+		Kind:      trace.Broadcast,
+		ChainID:   msg.ChainId,
+		MsgType:   msg.MsgType,
+		Checksum:  hex.EncodeToString(msg.MsgChecksum),
+		Peer:      msg.Addr,
+		TTL:       msg.Ttl,
+		Duplicate: seen,
+	})
+	if seen {
 		return nil
 	}
 	// Call dispatch to notify that a new message comes in
 	if err := g.processMsg(msg.ChainId, msg.MsgType, msg.MsgBody); err != nil {
+		g.Overlay.PenalizePeer(msg.Addr, OffenseMalformedMsg)
 		return err
 	}
 	// If other nodes use a crazy TTL, truncate it to the local configured value
@@ -76,12 +107,70 @@ func (g *Gossip) OnReceivingMsg(msg *network.BroadcastReq) error {
 			Msg("message used up all delivery hops")
 		return nil
 	}
-	if err := g.relayMsg(msg.ChainId, msg.MsgType, msg.MsgBody, msg.MsgChecksum, msg.Ttl-1); err != nil {
+	if err := g.relayMsg(msg.ChainId, msg.MsgType, msg.MsgBody, msg.MsgChecksum, msg.Ttl-1, msg.Addr); err != nil {
 		return nil
 	}
 	return nil
 }
 
+// OnReceivingAnnouncement pulls the announced message from the peer, unless it has been seen or is being pulled
+func (g *Gossip) OnReceivingAnnouncement(req *network.AnnounceReq) {
+	key := string(req.MsgChecksum)
+	if g.Seen.Contains(key) {
+		return
+	}
+	if _, loaded := g.pulling.LoadOrStore(key, true); loaded {
+		return
+	}
+	go func() {
+		defer g.pulling.Delete(key)
+		peer := g.Overlay.PM.GetOrAddPeer(req.Addr)
+		if peer == nil {
+			logger.Debug().Str("dst", req.Addr).Msg("Failed to connect to the peer to pull the message")
+			return
+		}
+		res, err := peer.Pull(&network.PullReq{MsgChecksum: req.MsgChecksum, Addr: g.Overlay.RPC.String()})
+		if err != nil {
+			logger.Debug().
+				Err(err).
+				Str("dst", req.Addr).
+				Str("msg-checksum", hex.EncodeToString(req.MsgChecksum)).
+				Msg("Failed to pull the announced message")
+			return
+		}
+		// The pulled message is checked against the announced hash as a broadcast one
+		if err := g.OnReceivingMsg(&network.BroadcastReq{
+			ChainId:     req.ChainId,
+			MsgType:     req.MsgType,
+			MsgBody:     res.MsgBody,
+			MsgChecksum: req.MsgChecksum,
+			Ttl:         req.Ttl,
+			Addr:        req.Addr,
+		}); err != nil {
+			logger.Error().Err(err).Str("src", req.Addr).Msg("Failed to handle the pulled message")
+		}
+	}()
+}
+
+// OnReceivingPull returns the body of the message which the node has announced
+func (g *Gossip) OnReceivingPull(req *network.PullReq) ([]byte, bool) {
+	value, ok := g.Seen.Get(string(req.MsgChecksum))
+	if !ok || value == nil {
+		return nil, false
+	}
+	return value.(*network.BroadcastReq).MsgBody, true
+}
+
+// markSeen remembers the message, and returns true if it has been seen. The full message of a lazy push type is kept
+// to answer the pulls.
+func (g *Gossip) markSeen(msg *network.BroadcastReq) bool {
+	var value interface{}
+	if g.lazyPush[msg.MsgType] {
+		value = msg
+	}
+	return g.Seen.ContainsOrAdd(string(msg.MsgChecksum), value)
+}
+
 func (g *Gossip) processMsg(chainID uint32, msgType uint32, msgBody []byte) error {
 	protoMsg, err := iproto.TypifyProtoMsg(msgType, msgBody)
 	if err != nil {
@@ -93,60 +182,90 @@ func (g *Gossip) processMsg(chainID uint32, msgType uint32, msgBody []byte) erro
 	return nil
 }
 
-func (g *Gossip) relayMsg(chainID uint32, msgType uint32, msgBody []byte, msgChecksum []byte, ttl int32) error {
-	// Send the message to all neighbors
-	g.Overlay.PM.Peers.Range(func(_, value interface{}) bool {
-		go func() {
-			peer, ok := value.(*Peer)
-			if !ok {
-				logger.Error().Msg("value is not an instance of Peer")
-				return
-			}
-			_, err := peer.BroadcastMsg(
-				&network.BroadcastReq{
+func (g *Gossip) relayMsg(
+	chainID uint32,
+	msgType uint32,
+	msgBody []byte,
+	msgChecksum []byte,
+	ttl int32,
+	from string,
+) error {
+	lazy := g.lazyPush[msgType]
+	kind := trace.Broadcast
+	if lazy {
+		kind = trace.Announce
+	}
+	for _, peer := range g.pickPeers(msgType, from) {
+		g.Overlay.trace(trace.Event{
+			Direction: trace.Send,
+			Kind:      kind,
+			ChainID:   chainID,
+			MsgType:   msgType,
+			Checksum:  hex.EncodeToString(msgChecksum),
+			Peer:      peer.String(),
+			TTL:       ttl,
+		})
+		go func(peer *Peer) {
+			var err error
+			if lazy {
+				_, err = peer.Announce(&network.AnnounceReq{
 					ChainId:     chainID,
 					MsgType:     msgType,
-					MsgBody:     msgBody,
 					MsgChecksum: msgChecksum,
 					Ttl:         ttl,
-				},
-			)
+					Addr:        g.Overlay.RPC.String(),
+				})
+			} else {
+				_, err = peer.BroadcastMsg(
+					&network.BroadcastReq{
+						ChainId:     chainID,
+						MsgType:     msgType,
+						MsgBody:     msgBody,
+						MsgChecksum: msgChecksum,
+						Ttl:         ttl,
+						Addr:        g.Overlay.RPC.String(),
+					},
+				)
+			}
 			if err != nil {
 				logger.Error().
 					Err(err).
 					Str("dst", peer.String()).
-					Str("msg-type", string(msgType)).
+					Uint32("msg-type", msgType).
 					Str("msg-checksum", hex.EncodeToString(msgChecksum)).
 					Int32("ttl", ttl).
+					Bool("lazy", lazy).
 					Msg("failed to broadcast a message")
 			}
-		}()
-		return true
-	})
+		}(peer)
+	}
 	return nil
 }
 
-// MsgLogsCleaner periodically refreshes the recent received message log
-type MsgLogsCleaner struct {
-	G *Gossip
-}
-
-// NewMsgLogsCleaner generates a MsgLogsCleaner instance
-func NewMsgLogsCleaner(g *Gossip) *MsgLogsCleaner {
-	c := &MsgLogsCleaner{G: g}
-	return c
-}
-
-// Clean cleans logs
-func (c *MsgLogsCleaner) Clean() {
-	var keys []string
-	c.G.MsgLogs.Range(func(key, value interface{}) bool {
-		if time.Since(value.(time.Time)) > c.G.Overlay.Config.MsgLogRetention {
-			keys = append(keys, key.(string))
+// pickPeers returns the random peers up to the fanout of the message type, except the one which the message comes from
+func (g *Gossip) pickPeers(msgType uint32, from string) []*Peer {
+	var peers []*Peer
+	g.Overlay.PM.Peers.Range(func(_, value interface{}) bool {
+		peer, ok := value.(*Peer)
+		if !ok {
+			logger.Error().Msg("value is not an instance of Peer")
+			return true
+		}
+		if peer.String() != from {
+			peers = append(peers, peer)
 		}
 		return true
 	})
-	for _, key := range keys {
-		c.G.MsgLogs.Delete(key)
+	fanout := g.Overlay.Config.GossipFanout
+	if f, ok := g.Overlay.Config.GossipFanoutPerMsgType[msgType]; ok {
+		fanout = f
+	}
+	if fanout == 0 || int(fanout) >= len(peers) {
+		return peers
+	}
+	for i := range peers {
+		j := rand.Intn(i + 1)
+		peers[i], peers[j] = peers[j], peers[i]
 	}
+	return peers[:fanout]
 }
@@ -0,0 +1,152 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/iotexproject/iotex-core/network/proto"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+func TestGossipPickPeers(t *testing.T) {
+	require := require.New(t)
+
+	cfg := LoadTestConfig("", true)
+	cfg.GossipFanout = 2
+	cfg.GossipFanoutPerMsgType = map[uint32]uint{iproto.MsgBlockProtoMsgType: 0}
+	o := &IotxOverlay{Config: cfg}
+	o.PM = NewPeerManager(o, 0, 0)
+	o.Gossip = NewGossip(o)
+	for i := 0; i < 5; i++ {
+		addr := fmt.Sprintf("127.0.0.1:%d", 10001+i)
+		o.PM.Peers.Store(addr, NewTCPPeer(addr))
+	}
+
+	// The message is relayed to the random peers up to the fanout of its type, except the one which it comes from
+	for i := 0; i < 10; i++ {
+		peers := o.Gossip.pickPeers(iproto.MsgActionType, "127.0.0.1:10001")
+		require.Len(peers, 2)
+		for _, peer := range peers {
+			require.NotEqual("127.0.0.1:10001", peer.String())
+		}
+	}
+	require.Len(o.Gossip.pickPeers(iproto.MsgBlockProtoMsgType, "127.0.0.1:10001"), 4)
+	require.Len(o.Gossip.pickPeers(iproto.MsgBlockProtoMsgType, ""), 5)
+}
+
+func newGossipTestOverlay(t *testing.T) (*IotxOverlay, *MockDispatcher3) {
+	cfg := LoadTestConfig("", true)
+	cfg.SeenCacheSize = 2
+	cfg.PeerBanThreshold = -100
+	cfg.GossipLazyPushMsgTypes = []uint32{iproto.TestPayloadType}
+	o := &IotxOverlay{Config: cfg}
+	r, err := NewReputation(cfg)
+	require.NoError(t, err)
+	o.Reputation = r
+	o.PM = NewPeerManager(o, 0, 5)
+	o.Gossip = NewGossip(o)
+	o.RPC = NewRPCServer(o)
+	dp := &MockDispatcher3{C: make(chan bool, 10)}
+	o.Gossip.AttachDispatcher(dp)
+	require.NoError(t, o.RPC.Start(context.Background()))
+	return o, dp
+}
+
+func TestGossipLazyPush(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	o1, dp1 := newGossipTestOverlay(t)
+	o2, dp2 := newGossipTestOverlay(t)
+	defer func() {
+		o1.PM.Peers.Range(func(_, value interface{}) bool {
+			require.NoError(value.(*Peer).Close())
+			return true
+		})
+		o2.PM.Peers.Range(func(_, value interface{}) bool {
+			require.NoError(value.(*Peer).Close())
+			return true
+		})
+		require.NoError(o1.RPC.Stop(ctx))
+		require.NoError(o2.RPC.Stop(ctx))
+	}()
+	o1.PM.AddPeer(o2.RPC.String())
+
+	// The message is announced, and pulled by the peer
+	msg := &iproto.TestPayload{MsgBody: []byte("payload")}
+	require.NoError(o1.Broadcast(0, msg))
+	select {
+	case <-dp2.C:
+	case <-time.After(5 * time.Second):
+		require.Fail("the announced message is not pulled")
+	}
+	body, err := proto.Marshal(msg)
+	require.NoError(err)
+	checksum := hash.Hash256b(body)
+	_, ok := o2.Gossip.OnReceivingPull(&pb.PullReq{MsgChecksum: checksum})
+	require.True(ok)
+
+	// The seen message is not pulled again, nor relayed back
+	o2.Gossip.OnReceivingAnnouncement(&pb.AnnounceReq{
+		MsgType:     iproto.TestPayloadType,
+		MsgChecksum: checksum,
+		Ttl:         1,
+		Addr:        o1.RPC.String(),
+	})
+	select {
+	case <-dp1.C:
+		require.Fail("the message is relayed back")
+	case <-dp2.C:
+		require.Fail("the seen message is pulled again")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The message which is unknown to the peer is not found
+	peer := o2.PM.GetOrAddPeer(o1.RPC.String())
+	require.NotNil(peer)
+	_, err = peer.Pull(&pb.PullReq{MsgChecksum: []byte("unknown")})
+	require.Equal(codes.NotFound, status.Code(err))
+
+	// The peer which serves the message mismatching the announced hash is penalized
+	fake := hash.Hash256b([]byte("fake"))
+	o1.Gossip.Seen.Add(string(fake), &pb.BroadcastReq{MsgBody: body})
+	o2.Gossip.OnReceivingAnnouncement(&pb.AnnounceReq{
+		MsgType:     iproto.TestPayloadType,
+		MsgChecksum: fake,
+		Ttl:         1,
+		Addr:        o1.RPC.String(),
+	})
+	require.NoError(waitUntil(func() bool { return o2.Reputation.Score(peerID(o1.Identity.PubKey)) < 0 }))
+	require.False(o2.Gossip.Seen.Contains(string(fake)))
+
+	// The seen cache is bounded
+	for i := 0; i < 3; i++ {
+		require.NoError(o1.Broadcast(0, &iproto.TestPayload{MsgBody: []byte{byte(i)}}))
+	}
+	require.Equal(2, o1.Gossip.Seen.Len())
+	require.False(o1.Gossip.Seen.Contains(string(checksum)))
+}
+
+func waitUntil(cond func() bool) error {
+	for i := 0; i < 500; i++ {
+		if cond() {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return fmt.Errorf("timed out")
+}
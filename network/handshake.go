@@ -0,0 +1,333 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/logger"
+	pb "github.com/iotexproject/iotex-core/network/proto"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+const (
+	// nonceLength is the length of the handshake challenge in bytes
+	nonceLength = 32
+	// handshakeTimeout is how long the peer is waited for to complete the handshake
+	handshakeTimeout = 5 * time.Second
+	// pinTTL is how long the key of a peer stays pinned to its address since the last handshake with it
+	pinTTL = 24 * time.Hour
+)
+
+var (
+	// ErrIncompatiblePeer means the peer is on another chain, or speaks another protocol version
+	ErrIncompatiblePeer = errors.New("incompatible peer")
+	// ErrUnauthenticated means the peer fails to prove the possession of its key
+	ErrUnauthenticated = errors.New("peer is not authenticated")
+)
+
+// Identity is the keypair which the node proves itself with in the handshake, along with the chain it serves. The
+// handshake goes as:
+//  1. The client sends its hello with a nonce
+//  2. The server checks the compatibility, and sends back its hello with another nonce and its signature over the
+//     nonce of the client
+//  3. The client verifies the signature, and sends back its signature over the nonce of the server
+//
+// After that, the server binds the connection to the address and key of the client, and only accepts the requests
+// from the address over it. The client pins the key which the server has authenticated with to the address it has
+// dialed, so that the address can't be claimed by another key afterwards, and keeps the reputation of the peer by the
+// key. The server doesn't pin the address claimed by the client, since anyone could claim it before the node at it
+// connects, but rejects the claim if another key is pinned to it. The pin expires after pinTTL, and is cleared once
+// the address can't be reached.
+type Identity struct {
+	ChainID uint32
+	PubKey  keypair.PublicKey
+	priKey  keypair.PrivateKey
+	// peers are the keys pinned to the addresses of the peers
+	peers sync.Map
+}
+
+// pinnedKey is the key pinned to the address of a peer until it expires
+type pinnedKey struct {
+	pubKey    keypair.PublicKey
+	expiresAt time.Time
+}
+
+// nodeKey is the node keypair persisted in the file
+type nodeKey struct {
+	PubKey  string `yaml:"pubKey"`
+	PrivKey string `yaml:"privKey"`
+}
+
+// NewIdentity creates an instance of Identity from the configured node keypair, or the one persisted in the node key
+// file if it is not configured. It serves the default chain, unless the chain ID is set to the one which the node runs.
+func NewIdentity(cfg *config.Network) (*Identity, error) {
+	id := &Identity{ChainID: config.Default.Chain.ID}
+	var err error
+	if cfg.NodePubKey == "" && cfg.NodePrivKey == "" {
+		id.PubKey, id.priKey, err = loadNodeKey(cfg.NodeKeyPath)
+	} else {
+		id.PubKey, id.priKey, err = cfg.NodeKeyPair()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// loadNodeKey loads the node keypair persisted in the file, or generates a new one and persists it if the file doesn't
+// exist yet, so that the node keeps its identity across restarts. The new keypair is kept in memory only if the path
+// is empty.
+func loadNodeKey(path string) (keypair.PublicKey, keypair.PrivateKey, error) {
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err == nil {
+			var key nodeKey
+			if err := yaml.Unmarshal(data, &key); err != nil {
+				return keypair.ZeroPublicKey,
+					keypair.ZeroPrivateKey,
+					errors.Wrapf(err, "failed to unmarshal node key %s", path)
+			}
+			keyCfg := config.Network{NodePubKey: key.PubKey, NodePrivKey: key.PrivKey}
+			return keyCfg.NodeKeyPair()
+		}
+		if !os.IsNotExist(err) {
+			return keypair.ZeroPublicKey, keypair.ZeroPrivateKey, errors.Wrapf(err, "failed to read node key %s", path)
+		}
+	}
+	pk, sk, err := crypto.EC283.NewKeyPair()
+	if err != nil {
+		return keypair.ZeroPublicKey, keypair.ZeroPrivateKey, errors.Wrap(err, "failed to generate the node keypair")
+	}
+	if path == "" {
+		return pk, sk, nil
+	}
+	data, err := yaml.Marshal(&nodeKey{PubKey: keypair.EncodePublicKey(pk), PrivKey: keypair.EncodePrivateKey(sk)})
+	if err != nil {
+		return keypair.ZeroPublicKey, keypair.ZeroPrivateKey, errors.Wrap(err, "failed to marshal node key")
+	}
+	// Write into a temporary file first, so that a partially written key is never loaded
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return keypair.ZeroPublicKey, keypair.ZeroPrivateKey, errors.Wrapf(err, "failed to write node key %s", tmp)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return keypair.ZeroPublicKey, keypair.ZeroPrivateKey, errors.Wrapf(err, "failed to persist node key %s", path)
+	}
+	logger.Info().Str("path", path).Str("pubKey", keypair.EncodePublicKey(pk)).Msg("Generated a new node key")
+	return pk, sk, nil
+}
+
+// hello introduces the node at the address with a new nonce
+func (id *Identity) hello(addr string) (*pb.Hello, error) {
+	nonce := make([]byte, nonceLength)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate the handshake nonce")
+	}
+	return &pb.Hello{
+		PubKey:          id.PubKey[:],
+		Addr:            addr,
+		ChainId:         id.ChainID,
+		ProtocolVersion: version.ProtocolVersion,
+		Nonce:           nonce,
+	}, nil
+}
+
+// sign signs the nonce of the other end, along with the hello of the node, so that the signature can't be replayed
+// for another address or key
+func (id *Identity) sign(hello *pb.Hello, nonce []byte) []byte {
+	return crypto.EC283.Sign(id.priKey, handshakeDigest(hello, nonce))
+}
+
+// checkCompatibility checks that the other end is on the same chain and speaks the same protocol version
+func (id *Identity) checkCompatibility(hello *pb.Hello) error {
+	if hello == nil {
+		return errors.Wrap(ErrIncompatiblePeer, "missing hello")
+	}
+	if hello.ChainId != id.ChainID {
+		return errors.Wrapf(ErrIncompatiblePeer, "chain ID %d doesn't match %d", hello.ChainId, id.ChainID)
+	}
+	if hello.ProtocolVersion != version.ProtocolVersion {
+		return errors.Wrapf(
+			ErrIncompatiblePeer,
+			"protocol version %d doesn't match %d",
+			hello.ProtocolVersion,
+			version.ProtocolVersion,
+		)
+	}
+	if len(hello.Nonce) != nonceLength {
+		return errors.Wrapf(ErrIncompatiblePeer, "invalid nonce length %d", len(hello.Nonce))
+	}
+	return nil
+}
+
+// pin pins the key which the peer has authenticated with to the address which the node has dialed, and returns an
+// error if another key is already pinned to it
+func (id *Identity) pin(addr string, pubKey keypair.PublicKey) error {
+	if err := id.checkPinned(addr, pubKey); err != nil {
+		return err
+	}
+	id.peers.Store(addr, &pinnedKey{pubKey: pubKey, expiresAt: time.Now().Add(pinTTL)})
+	return nil
+}
+
+// unpin clears the key pinned to the address, if any
+func (id *Identity) unpin(addr string) {
+	id.peers.Delete(addr)
+}
+
+// checkPinned returns an error if another key than the given one is pinned to the address
+func (id *Identity) checkPinned(addr string, pubKey keypair.PublicKey) error {
+	if pinned, ok := id.peerKey(addr); ok && pinned != pubKey {
+		return errors.Wrapf(ErrUnauthenticated, "%s is pinned to another key", addr)
+	}
+	return nil
+}
+
+// peerKey returns the key pinned to the address of the peer, if any. The expired pin is cleared.
+func (id *Identity) peerKey(addr string) (keypair.PublicKey, bool) {
+	if id == nil {
+		return keypair.ZeroPublicKey, false
+	}
+	value, ok := id.peers.Load(addr)
+	if !ok {
+		return keypair.ZeroPublicKey, false
+	}
+	pinned := value.(*pinnedKey)
+	if time.Now().After(pinned.expiresAt) {
+		id.peers.Delete(addr)
+		return keypair.ZeroPublicKey, false
+	}
+	return pinned.pubKey, true
+}
+
+// peerID returns the identity of the peer with the key, which the reputation of the peer is kept by
+func peerID(pubKey keypair.PublicKey) string {
+	return keypair.EncodePublicKey(pubKey)
+}
+
+// verifyHandshake checks that the signature is signed by the key in the hello over the nonce, and that no other key
+// is pinned to the address in the hello
+func (id *Identity) verifyHandshake(hello *pb.Hello, nonce []byte, signature []byte) (keypair.PublicKey, error) {
+	pubKey, err := keypair.BytesToPublicKey(hello.PubKey)
+	if err != nil {
+		return keypair.ZeroPublicKey, errors.Wrap(ErrUnauthenticated, err.Error())
+	}
+	if len(signature) == 0 || !crypto.EC283.Verify(pubKey, handshakeDigest(hello, nonce), signature) {
+		return keypair.ZeroPublicKey, errors.Wrapf(ErrUnauthenticated, "invalid signature of %s", hello.Addr)
+	}
+	if err := id.checkPinned(hello.Addr, pubKey); err != nil {
+		return keypair.ZeroPublicKey, err
+	}
+	return pubKey, nil
+}
+
+func handshakeDigest(hello *pb.Hello, nonce []byte) []byte {
+	msg := []byte(fmt.Sprintf("%s/%d/%d/", hello.Addr, hello.ChainId, hello.ProtocolVersion))
+	msg = append(msg, hello.PubKey...)
+	msg = append(msg, nonce...)
+	return hash.Hash256b(msg)
+}
+
+// session is the state of the handshake over a connection to the RPC server
+type session struct {
+	hello         *pb.Hello
+	nonce         []byte
+	authenticated bool
+	pubKey        keypair.PublicKey
+}
+
+type connKey struct{}
+
+// sessionHandler drops the session of a connection once it ends
+type sessionHandler struct {
+	s *RPCServer
+}
+
+func (h *sessionHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context { return ctx }
+
+func (h *sessionHandler) HandleRPC(context.Context, stats.RPCStats) {}
+
+func (h *sessionHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return context.WithValue(ctx, connKey{}, info.RemoteAddr.String())
+}
+
+func (h *sessionHandler) HandleConn(ctx context.Context, s stats.ConnStats) {
+	if _, ok := s.(*stats.ConnEnd); !ok {
+		return
+	}
+	if remote, ok := ctx.Value(connKey{}).(string); ok {
+		h.s.sessions.Delete(remote)
+	}
+}
+
+// authenticate checks that the request comes over a connection authenticated as the address, and returns the identity
+// of the peer, unless the node has no identity to run the handshake with, in which case the identity is the address.
+// An empty address only requires the connection to be authenticated.
+func (s *RPCServer) authenticate(ctx context.Context, addr string) (string, error) {
+	if s.Overlay.Identity == nil {
+		return addr, nil
+	}
+	sess, ok := s.authenticatedSession(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, ErrUnauthenticated.Error())
+	}
+	if addr != "" && sess.hello.Addr != addr {
+		return "", status.Errorf(
+			codes.Unauthenticated,
+			"connection is authenticated as %s rather than %s",
+			sess.hello.Addr,
+			addr,
+		)
+	}
+	// The address may have been pinned to another key since the handshake, once the node has dialed it back
+	if err := s.Overlay.Identity.checkPinned(sess.hello.Addr, sess.pubKey); err != nil {
+		return "", status.Error(codes.Unauthenticated, err.Error())
+	}
+	return peerID(sess.pubKey), nil
+}
+
+// authenticatedSession returns the session of the connection which the request comes over, if it is authenticated
+func (s *RPCServer) authenticatedSession(ctx context.Context) (*session, bool) {
+	remote, err := s.getClientAddr(ctx)
+	if err != nil {
+		return nil, false
+	}
+	value, ok := s.sessions.Load(remote)
+	if !ok || !value.(*session).authenticated {
+		return nil, false
+	}
+	return value.(*session), true
+}
+
+// penalizeRateLimited penalizes the peer authenticated over the connection if the request is dropped by the rate
+// limiter. The requests over a connection which isn't authenticated are only dropped, since anyone could claim the
+// address in them.
+func (s *RPCServer) penalizeRateLimited(ctx context.Context, err error) {
+	if errors.Cause(err) != ErrRateLimited {
+		return
+	}
+	if sess, ok := s.authenticatedSession(ctx); ok {
+		s.Overlay.penalize(peerID(sess.pubKey), sess.hello.Addr, s.clientHost(ctx), OffenseRateLimited)
+	}
+}
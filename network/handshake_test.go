@@ -0,0 +1,232 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/crypto"
+	pb "github.com/iotexproject/iotex-core/network/proto"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+func TestHandshake(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := LoadTestConfig("", true)
+	o := &IotxOverlay{Config: cfg}
+	o.PM = NewPeerManager(o, 0, 0)
+	id, err := NewIdentity(cfg)
+	require.NoError(err)
+	o.Identity = id
+	s := NewRPCServer(o)
+	o.RPC = s
+	require.NoError(s.Start(ctx))
+	defer func() {
+		require.NoError(s.Stop(ctx))
+	}()
+
+	// The peer proves its identity to the node, and is only accepted for its own address
+	clientID, err := NewIdentity(cfg)
+	require.NoError(err)
+	p := NewPeer(s.Network(), s.String())
+	require.NoError(p.Connect(cfg, clientID, "127.0.0.1:10001"))
+	require.Equal(id.PubKey, p.PubKey)
+	pubKey, ok := clientID.peerKey(s.String())
+	require.True(ok)
+	require.Equal(id.PubKey, pubKey)
+	// The address claimed by the peer is not pinned, since the node hasn't dialed it
+	_, ok = id.peerKey("127.0.0.1:10001")
+	require.False(ok)
+	_, err = p.Ping(&pb.Ping{Nonce: 1, Addr: "127.0.0.1:10001"})
+	require.NoError(err)
+	_, err = p.Ping(&pb.Ping{Nonce: 1, Addr: "127.0.0.1:10002"})
+	require.Equal(codes.Unauthenticated, status.Code(err))
+	_, err = p.GetPeers(&pb.GetPeersReq{Count: 1})
+	require.NoError(err)
+	require.NoError(p.Close())
+
+	// The peer which skips the handshake is rejected
+	p = NewPeer(s.Network(), s.String())
+	require.NoError(p.Connect(cfg, nil, ""))
+	_, err = p.Ping(&pb.Ping{Nonce: 1, Addr: "127.0.0.1:10001"})
+	require.Equal(codes.Unauthenticated, status.Code(err))
+
+	// The peer which claims the key of another node fails to sign the nonce
+	hello, err := clientID.hello("127.0.0.1:10001")
+	require.NoError(err)
+	res, err := p.Client.Handshake(ctx, &pb.HandshakeReq{Hello: hello})
+	require.NoError(err)
+	forgerID, err := NewIdentity(cfg)
+	require.NoError(err)
+	_, err = p.Client.Authenticate(ctx, &pb.AuthReq{Signature: forgerID.sign(hello, res.Hello.Nonce)})
+	require.Equal(codes.Unauthenticated, status.Code(err))
+	_, err = p.Ping(&pb.Ping{Nonce: 1, Addr: "127.0.0.1:10001"})
+	require.Equal(codes.Unauthenticated, status.Code(err))
+
+	// Another node can't take over the address once the key of the peer is pinned to it
+	require.NoError(id.pin("127.0.0.1:10001", clientID.PubKey))
+	forgerHello, err := forgerID.hello("127.0.0.1:10001")
+	require.NoError(err)
+	res, err = p.Client.Handshake(ctx, &pb.HandshakeReq{Hello: forgerHello})
+	require.NoError(err)
+	_, err = p.Client.Authenticate(ctx, &pb.AuthReq{Signature: forgerID.sign(forgerHello, res.Hello.Nonce)})
+	require.Equal(codes.Unauthenticated, status.Code(err))
+
+	// The node which introduces itself as another address than the dialed one is rejected
+	dialed := s.String()
+	s.external.setNAT("127.0.0.2", 0)
+	p2 := NewPeer(s.Network(), dialed)
+	require.Equal(ErrUnauthenticated, errors.Cause(p2.Connect(cfg, forgerID, "127.0.0.1:10003")))
+	s.external.setNAT("", 0)
+
+	// The peer on another chain, or speaking another protocol version, is rejected
+	hello.ChainId++
+	_, err = p.Client.Handshake(ctx, &pb.HandshakeReq{Hello: hello})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+	hello.ChainId--
+	hello.ProtocolVersion = version.ProtocolVersion + 1
+	_, err = p.Client.Handshake(ctx, &pb.HandshakeReq{Hello: hello})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+	require.NoError(p.Close())
+	clientID.ChainID++
+	p = NewPeer(s.Network(), s.String())
+	require.Error(p.Connect(cfg, clientID, "127.0.0.1:10001"))
+}
+
+func TestHandshakeSquatting(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := LoadTestConfig("", true)
+	startServer := func() *RPCServer {
+		o := &IotxOverlay{Config: cfg}
+		o.PM = NewPeerManager(o, 0, 0)
+		id, err := NewIdentity(cfg)
+		require.NoError(err)
+		o.Identity = id
+		s := NewRPCServer(o)
+		o.RPC = s
+		require.NoError(s.Start(ctx))
+		return s
+	}
+	node := startServer()
+	defer func() {
+		require.NoError(node.Stop(ctx))
+	}()
+	id := node.Overlay.Identity
+	peerServer := startServer()
+	peerIdentity := peerServer.Overlay.Identity
+	peerAddr := peerServer.String()
+
+	// The squatter connects first claiming the address of the peer, which doesn't pin its key to the address
+	squatterID, err := NewIdentity(cfg)
+	require.NoError(err)
+	squatter := NewPeer(node.Network(), node.String())
+	require.NoError(squatter.Connect(cfg, squatterID, peerAddr))
+	_, ok := id.peerKey(peerAddr)
+	require.False(ok)
+
+	// Once the node dials the address, the key of the peer is pinned, and the squatter is rejected
+	p := NewPeer(peerServer.Network(), peerAddr)
+	require.NoError(p.Connect(cfg, id, node.String()))
+	require.NoError(p.Close())
+	pubKey, ok := id.peerKey(peerAddr)
+	require.True(ok)
+	require.Equal(peerIdentity.PubKey, pubKey)
+	_, err = squatter.Ping(&pb.Ping{Nonce: 1, Addr: peerAddr})
+	require.Equal(codes.Unauthenticated, status.Code(err))
+	require.NoError(squatter.Close())
+	squatter = NewPeer(node.Network(), node.String())
+	require.Error(squatter.Connect(cfg, squatterID, peerAddr))
+
+	// The peer itself is accepted for its address
+	p = NewPeer(node.Network(), node.String())
+	require.NoError(p.Connect(cfg, peerIdentity, peerAddr))
+	_, err = p.GetPeers(&pb.GetPeersReq{Count: 1})
+	require.NoError(err)
+	require.NoError(p.Close())
+
+	// The pin expires
+	id.peers.Store(peerAddr, &pinnedKey{pubKey: peerIdentity.PubKey, expiresAt: time.Now().Add(-time.Second)})
+	_, ok = id.peerKey(peerAddr)
+	require.False(ok)
+
+	// The pin is cleared once the address can't be reached
+	require.NoError(id.pin(peerAddr, peerIdentity.PubKey))
+	require.NoError(peerServer.Stop(ctx))
+	p = NewPeer(peerServer.Network(), peerAddr)
+	require.Error(p.Connect(cfg, id, node.String()))
+	_, ok = id.peerKey(peerAddr)
+	require.False(ok)
+}
+
+func TestNewIdentity(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "nodekey")
+	require.NoError(err)
+	defer func() {
+		require.NoError(os.RemoveAll(dir))
+	}()
+	cfg := config.Default.Network
+	cfg.NodeKeyPath = filepath.Join(dir, "nodekey.yaml")
+	id, err := NewIdentity(&cfg)
+	require.NoError(err)
+	require.NotEqual(keypair.ZeroPublicKey, id.PubKey)
+	require.Equal(config.Default.Chain.ID, id.ChainID)
+
+	// The generated keypair is persisted and loaded after restart, unless the path is empty
+	id2, err := NewIdentity(&cfg)
+	require.NoError(err)
+	require.Equal(id.PubKey, id2.PubKey)
+	cfg.NodeKeyPath = ""
+	id2, err = NewIdentity(&cfg)
+	require.NoError(err)
+	require.NotEqual(id.PubKey, id2.PubKey)
+	cfg.NodeKeyPath = filepath.Join(dir, "nodekey.yaml")
+	require.NoError(ioutil.WriteFile(cfg.NodeKeyPath, []byte("pubKey: {"), 0600))
+	_, err = NewIdentity(&cfg)
+	require.Error(err)
+	cfg.NodeKeyPath = ""
+
+	// The configured keypair is used, and the unmatched one is rejected
+	pk, sk, err := crypto.EC283.NewKeyPair()
+	require.NoError(err)
+	cfg.NodePubKey = keypair.EncodePublicKey(pk)
+	cfg.NodePrivKey = keypair.EncodePrivateKey(sk)
+	id, err = NewIdentity(&cfg)
+	require.NoError(err)
+	require.Equal(pk, id.PubKey)
+	hello, err := id.hello("127.0.0.1:10001")
+	require.NoError(err)
+	nonce := []byte("nonce")
+	pubKey, err := id.verifyHandshake(hello, nonce, id.sign(hello, nonce))
+	require.NoError(err)
+	require.Equal(pk, pubKey)
+	_, err = id.verifyHandshake(hello, []byte("another nonce"), id.sign(hello, nonce))
+	require.Equal(ErrUnauthenticated, errors.Cause(err))
+
+	other, _, err := crypto.EC283.NewKeyPair()
+	require.NoError(err)
+	cfg.NodePubKey = keypair.EncodePublicKey(other)
+	_, err = NewIdentity(&cfg)
+	require.Error(err)
+}
@@ -34,6 +34,7 @@ func (hc *HealthChecker) Check() {
 		return true
 	})
 	for _, addr := range addrs {
+		hc.Overlay.AddrBook.MarkFailed(addr)
 		go hc.Overlay.PM.RemovePeer(addr)
 	}
 }
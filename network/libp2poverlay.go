@@ -0,0 +1,432 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+//go:build libp2p
+// +build libp2p
+
+package network
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/host"
+	p2pnet "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	noise "github.com/libp2p/go-libp2p-noise"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	secio "github.com/libp2p/go-libp2p-secio"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/dispatcher"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/network/node"
+	pb "github.com/iotexproject/iotex-core/network/proto"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+const (
+	// libp2pNetwork is the network type of the addresses of the libp2p peers, which are their peer IDs
+	libp2pNetwork = "libp2p"
+	// tellProtocol is the libp2p protocol of the streams which carry the tell messages
+	tellProtocol = protocol.ID("/iotex/tell/1.0.0")
+	// tellTimeout is the timeout of sending a tell message
+	tellTimeout = 10 * time.Second
+)
+
+var _ Overlay = (*Libp2pOverlay)(nil)
+
+// ErrOverlayNotStarted means the message is sent before the overlay is started
+var ErrOverlayNotStarted = errors.New("overlay is not started")
+
+// Libp2pOverlay is the implementation of Overlay on libp2p. The broadcast messages are published to the gossipsub
+// topic of the chain, and the tell messages are sent in the direct streams to the peers, so that the node could
+// interoperate with the standard libp2p tooling. The peers are addressed by their peer IDs.
+type Libp2pOverlay struct {
+	Host       host.Host
+	PubSub     *pubsub.PubSub
+	Config     *config.Network
+	Dispatcher dispatcher.Dispatcher
+
+	chainID uint32
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mu      sync.Mutex
+	topics  map[uint32]*pubsub.Topic
+	sub     *pubsub.Subscription
+}
+
+// NewLibp2pOverlay creates an instance of Libp2pOverlay, which subscribes to the broadcast messages of the chain once
+// it is started
+func NewLibp2pOverlay(cfg *config.Network, chainID uint32) *Libp2pOverlay {
+	return &Libp2pOverlay{
+		Config:  cfg,
+		chainID: chainID,
+		topics:  make(map[uint32]*pubsub.Topic),
+	}
+}
+
+// AttachDispatcher attaches to a Dispatcher instance
+func (o *Libp2pOverlay) AttachDispatcher(dispatcher dispatcher.Dispatcher) {
+	o.Dispatcher = dispatcher
+}
+
+// Start creates the libp2p host listening on the configured address, joins the topic of the chain and connects to the
+// bootstrap nodes
+func (o *Libp2pOverlay) Start(_ context.Context) error {
+	o.ctx, o.cancel = context.WithCancel(context.Background())
+
+	opts, err := libp2pOptions(o.Config)
+	if err != nil {
+		return err
+	}
+	h, err := libp2p.New(o.ctx, opts...)
+	if err != nil {
+		return errors.Wrap(err, "failed to create libp2p host")
+	}
+	o.Host = h
+	ps, err := pubsub.NewGossipSub(o.ctx, h, pubsub.WithMaxMessageSize(o.Config.MaxMsgSize))
+	if err != nil {
+		return errors.Wrap(err, "failed to create gossipsub")
+	}
+	o.PubSub = ps
+	h.SetStreamHandler(tellProtocol, o.handleTell)
+
+	topic, err := o.topic(o.chainID)
+	if err != nil {
+		return err
+	}
+	if o.sub, err = topic.Subscribe(); err != nil {
+		return errors.Wrapf(err, "failed to subscribe to the topic of chain %d", o.chainID)
+	}
+	go o.readBroadcasts(o.sub)
+
+	for _, addr := range o.Config.BootstrapNodes {
+		info, err := addrInfo(addr)
+		if err != nil {
+			logger.Error().Err(err).Str("addr", addr).Msg("Invalid libp2p bootstrap node")
+			continue
+		}
+		if err := h.Connect(o.ctx, *info); err != nil {
+			logger.Error().Err(err).Str("addr", addr).Msg("Failed to connect to the bootstrap node")
+		}
+	}
+	logger.Info().Str("self", o.Self().String()).Msg("start libp2p overlay")
+	return nil
+}
+
+// Stop leaves the topics and closes the libp2p host
+func (o *Libp2pOverlay) Stop(_ context.Context) error {
+	logger.Info().Msg("stop libp2p overlay")
+	if o.cancel == nil {
+		return nil
+	}
+	o.cancel()
+	if o.sub != nil {
+		o.sub.Cancel()
+	}
+	o.mu.Lock()
+	for _, topic := range o.topics {
+		if err := topic.Close(); err != nil {
+			logger.Error().Err(err).Msg("Failed to close the topic")
+		}
+	}
+	o.topics = make(map[uint32]*pubsub.Topic)
+	o.mu.Unlock()
+	if o.Host != nil {
+		return o.Host.Close()
+	}
+	return nil
+}
+
+// Broadcast publishes the message to the topic of the chain
+func (o *Libp2pOverlay) Broadcast(chainID uint32, msg proto.Message) error {
+	if o.PubSub == nil {
+		return ErrOverlayNotStarted
+	}
+	msgType, err := iproto.GetTypeFromProtoMsg(msg)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert msg to proto when broadcast")
+	}
+	msgBody, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal msg when broadcast")
+	}
+	data, err := proto.Marshal(&pb.BroadcastReq{ChainId: chainID, MsgType: msgType, MsgBody: msgBody})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal broadcast request")
+	}
+	topic, err := o.topic(chainID)
+	if err != nil {
+		return err
+	}
+	if err := topic.Publish(o.ctx, data); err != nil {
+		return errors.Wrap(err, "failed to publish msg when broadcast")
+	}
+	return nil
+}
+
+// Tell sends the message to the peer in a new stream. The peer is either its peer ID or a multiaddr ending with it.
+func (o *Libp2pOverlay) Tell(chainID uint32, addr net.Addr, msg proto.Message) error {
+	if o.Host == nil {
+		return ErrOverlayNotStarted
+	}
+	info, err := addrInfo(addr.String())
+	if err != nil {
+		return errors.Wrap(ErrPeerNotFound, err.Error())
+	}
+	msgType, err := iproto.GetTypeFromProtoMsg(msg)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert msg to proto when tell msg")
+	}
+	msgBody, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal msg when tell msg")
+	}
+	req := &pb.TellReq{ChainId: chainID, Addr: o.Host.ID().Pretty(), MsgType: msgType, MsgBody: msgBody}
+	go func() {
+		if err := o.tell(info, req); err != nil {
+			logger.Error().
+				Err(err).
+				Str("dst", info.ID.Pretty()).
+				Uint32("msg-type", msgType).
+				Msg("failed to tell a message")
+		}
+	}()
+	return nil
+}
+
+// Self returns the multiaddr which the other nodes could reach the node at
+func (o *Libp2pOverlay) Self() net.Addr {
+	if o.Host == nil {
+		return node.NewNode(libp2pNetwork, "")
+	}
+	addrs := o.Host.Addrs()
+	if len(addrs) == 0 {
+		return node.NewNode(libp2pNetwork, o.Host.ID().Pretty())
+	}
+	return node.NewNode(libp2pNetwork, fmt.Sprintf("%s/p2p/%s", addrs[0], o.Host.ID().Pretty()))
+}
+
+// GetPeers returns the peer IDs of the connected peers
+func (o *Libp2pOverlay) GetPeers() []net.Addr {
+	if o.Host == nil {
+		return nil
+	}
+	var nodes []net.Addr
+	for _, id := range o.Host.Network().Peers() {
+		nodes = append(nodes, node.NewNode(libp2pNetwork, id.Pretty()))
+	}
+	return nodes
+}
+
+// BannedPeers returns no peer, because libp2p manages the connections, and the overlay doesn't ban the peers
+func (o *Libp2pOverlay) BannedPeers() map[string]time.Time {
+	return map[string]time.Time{}
+}
+
+// topic returns the joined topic of the chain
+func (o *Libp2pOverlay) topic(chainID uint32) (*pubsub.Topic, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if topic, ok := o.topics[chainID]; ok {
+		return topic, nil
+	}
+	topic, err := o.PubSub.Join(broadcastTopic(chainID))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to join the topic of chain %d", chainID)
+	}
+	o.topics[chainID] = topic
+	return topic, nil
+}
+
+func (o *Libp2pOverlay) readBroadcasts(sub *pubsub.Subscription) {
+	for {
+		msg, err := sub.Next(o.ctx)
+		if err != nil {
+			// The subscription is cancelled
+			return
+		}
+		if msg.ReceivedFrom == o.Host.ID() {
+			continue
+		}
+		var req pb.BroadcastReq
+		if err := proto.Unmarshal(msg.Data, &req); err != nil {
+			logger.Error().Err(err).Str("src", msg.ReceivedFrom.Pretty()).Msg("Failed to unmarshal broadcast msg")
+			continue
+		}
+		protoMsg, err := iproto.TypifyProtoMsg(req.MsgType, req.MsgBody)
+		if err != nil {
+			logger.Error().Err(err).Str("src", msg.ReceivedFrom.Pretty()).Msg("Failed to typify broadcast msg")
+			continue
+		}
+		if o.Dispatcher != nil {
+			o.Dispatcher.HandleBroadcast(req.ChainId, protoMsg, nil)
+		}
+	}
+}
+
+func (o *Libp2pOverlay) tell(info *peer.AddrInfo, req *pb.TellReq) error {
+	ctx, cancel := context.WithTimeout(o.ctx, tellTimeout)
+	defer cancel()
+
+	if len(info.Addrs) > 0 {
+		if err := o.Host.Connect(ctx, *info); err != nil {
+			return err
+		}
+	}
+	s, err := o.Host.NewStream(ctx, info.ID, tellProtocol)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	return writeDelimited(s, req)
+}
+
+// handleTell reads the tell message from the stream, whose sender is the peer at the other end of the connection,
+// authenticated by the secure transport
+func (o *Libp2pOverlay) handleTell(s p2pnet.Stream) {
+	defer s.Close()
+
+	sender := s.Conn().RemotePeer().Pretty()
+	var req pb.TellReq
+	if err := readDelimited(bufio.NewReader(s), o.Config.MaxMsgSize, &req); err != nil {
+		logger.Error().Err(err).Str("src", sender).Msg("Failed to read tell msg")
+		return
+	}
+	protoMsg, err := iproto.TypifyProtoMsg(req.MsgType, req.MsgBody)
+	if err != nil {
+		logger.Error().Err(err).Str("src", sender).Msg("Failed to typify tell msg")
+		return
+	}
+	if o.Dispatcher != nil {
+		o.Dispatcher.HandleTell(req.ChainId, node.NewNode(libp2pNetwork, sender), protoMsg, nil)
+	}
+}
+
+// broadcastTopic returns the gossipsub topic of the broadcast messages of the chain
+func broadcastTopic(chainID uint32) string {
+	return "/iotex/broadcast/" + strconv.FormatUint(uint64(chainID), 10)
+}
+
+// libp2pOptions builds the options of the libp2p host from the config
+func libp2pOptions(cfg *config.Network) ([]libp2p.Option, error) {
+	var priv crypto.PrivKey
+	if cfg.Libp2pPrivKey != "" {
+		b, err := hex.DecodeString(cfg.Libp2pPrivKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode libp2p private key")
+		}
+		if priv, err = crypto.UnmarshalPrivateKey(b); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal libp2p private key")
+		}
+	} else {
+		var err error
+		if priv, _, err = crypto.GenerateKeyPair(crypto.Ed25519, -1); err != nil {
+			return nil, errors.Wrap(err, "failed to generate libp2p private key")
+		}
+	}
+	listen, err := multiaddr(cfg.Host, cfg.Port)
+	if err != nil {
+		return nil, err
+	}
+	opts := []libp2p.Option{libp2p.Identity(priv), libp2p.ListenAddrs(listen)}
+	switch cfg.Libp2pSecurity {
+	case config.SecioSecurity:
+		opts = append(opts, libp2p.Security(secio.ID, secio.New))
+	default:
+		opts = append(opts, libp2p.Security(noise.ID, noise.New))
+	}
+	if cfg.ExternalHost != "" {
+		port := cfg.ExternalPort
+		if port == 0 {
+			port = cfg.Port
+		}
+		external, err := multiaddr(cfg.ExternalHost, port)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, libp2p.AddrsFactory(func([]ma.Multiaddr) []ma.Multiaddr {
+			return []ma.Multiaddr{external}
+		}))
+	}
+	return opts, nil
+}
+
+// multiaddr converts the host and the port into a TCP multiaddr
+func multiaddr(host string, port int) (ma.Multiaddr, error) {
+	ipProto := "dns4"
+	if ip := net.ParseIP(host); ip != nil {
+		ipProto = "ip4"
+		if ip.To4() == nil {
+			ipProto = "ip6"
+		}
+	}
+	return ma.NewMultiaddr(fmt.Sprintf("/%s/%s/tcp/%d", ipProto, host, port))
+}
+
+// addrInfo parses the address of a peer, which is either its peer ID or a multiaddr ending with it
+func addrInfo(addr string) (*peer.AddrInfo, error) {
+	if !strings.HasPrefix(addr, "/") {
+		id, err := peer.IDB58Decode(addr)
+		if err != nil {
+			return nil, err
+		}
+		return &peer.AddrInfo{ID: id}, nil
+	}
+	maddr, err := ma.NewMultiaddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	return peer.AddrInfoFromP2pAddr(maddr)
+}
+
+// writeDelimited writes the message prefixed by its size in varint
+func writeDelimited(w io.Writer, msg proto.Message) error {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	size := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(size, uint64(len(b)))
+	if _, err := w.Write(size[:n]); err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// readDelimited reads a message prefixed by its size in varint, which should be no more than the max size
+func readDelimited(r *bufio.Reader, maxSize int, msg proto.Message) error {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return err
+	}
+	if size > uint64(maxSize) {
+		return errors.Errorf("msg size %d exceeds the limit %d", size, maxSize)
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return err
+	}
+	return proto.Unmarshal(b, msg)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+//go:build libp2p
+// +build libp2p
+
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	pb "github.com/iotexproject/iotex-core/network/proto"
+	"github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// libp2pDispatcher counts the received messages, and remembers the sender of the last tell message
+type libp2pDispatcher struct {
+	MockDispatcher
+	broadcasts uint32
+	tells      uint32
+	sender     atomic.Value
+}
+
+func (d *libp2pDispatcher) HandleBroadcast(uint32, proto.Message, chan bool) {
+	atomic.AddUint32(&d.broadcasts, 1)
+}
+
+func (d *libp2pDispatcher) HandleTell(_ uint32, sender net.Addr, _ proto.Message, _ chan bool) {
+	d.sender.Store(sender.String())
+	atomic.AddUint32(&d.tells, 1)
+}
+
+func TestLibp2pOverlay(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg1 := LoadTestConfig("", true)
+	cfg1.Transport = config.Libp2pTransport
+	cfg1.Libp2pSecurity = config.NoiseSecurity
+	cfg1.BootstrapNodes = nil
+	dp1 := &libp2pDispatcher{}
+	o1 := NewLibp2pOverlay(cfg1, config.Default.Chain.ID)
+	o1.AttachDispatcher(dp1)
+	require.NoError(o1.Start(ctx))
+	defer func() {
+		require.NoError(o1.Stop(ctx))
+	}()
+
+	cfg2 := LoadTestConfig("", true)
+	cfg2.Transport = config.Libp2pTransport
+	cfg2.Libp2pSecurity = config.NoiseSecurity
+	cfg2.BootstrapNodes = []string{o1.Self().String()}
+	dp2 := &libp2pDispatcher{}
+	o2 := NewLibp2pOverlay(cfg2, config.Default.Chain.ID)
+	o2.AttachDispatcher(dp2)
+	require.NoError(o2.Start(ctx))
+	defer func() {
+		require.NoError(o2.Stop(ctx))
+	}()
+
+	require.Equal(1, len(o1.GetPeers()))
+	require.Equal(o2.Host.ID().Pretty(), o1.GetPeers()[0].String())
+
+	// The broadcast reaches the other node once the gossipsub meshes are built, but not the node itself
+	require.NoError(testutil.WaitUntil(100*time.Millisecond, 10*time.Second, func() (bool, error) {
+		if err := o1.Broadcast(config.Default.Chain.ID, &iproto.ActionPb{}); err != nil {
+			return false, err
+		}
+		return atomic.LoadUint32(&dp2.broadcasts) > 0, nil
+	}))
+	require.Equal(uint32(0), atomic.LoadUint32(&dp1.broadcasts))
+
+	// The tell carries the peer ID of the sender, which could be told back
+	require.NoError(o2.Tell(config.Default.Chain.ID, o1.Self(), &iproto.ActionPb{}))
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return atomic.LoadUint32(&dp1.tells) == 1, nil
+	}))
+	sender := dp1.sender.Load().(string)
+	require.Equal(o2.Host.ID().Pretty(), sender)
+	require.NoError(o1.Tell(config.Default.Chain.ID, o1.GetPeers()[0], &iproto.ActionPb{}))
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return atomic.LoadUint32(&dp2.tells) == 1, nil
+	}))
+}
+
+func TestDelimited(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	req := &pb.TellReq{ChainId: 1, Addr: "peer", MsgType: 2, MsgBody: []byte("body")}
+	require.NoError(writeDelimited(&buf, req))
+	require.NoError(writeDelimited(&buf, req))
+	r := bufio.NewReader(&buf)
+	var res pb.TellReq
+	require.NoError(readDelimited(r, 1024, &res))
+	require.True(proto.Equal(req, &res))
+	require.Error(readDelimited(r, 4, &res))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package nat maps the port of the node on the NAT gateway, so that the nodes out of the local network could reach it
+package nat
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// UPnP is the mechanism mapping the port by the UPnP internet gateway device protocol
+	UPnP = "upnp"
+	// NATPMP is the mechanism mapping the port by the NAT port mapping protocol
+	NATPMP = "natpmp"
+)
+
+// NAT is the gateway which maps an external port to the port of the node
+type NAT interface {
+	// ExternalIP returns the public IP of the gateway
+	ExternalIP() (net.IP, error)
+	// AddPortMapping maps the external port to the internal port of the node for the lifetime, and returns the
+	// external port which is actually mapped. The gateway may map another port if the requested one is taken.
+	AddPortMapping(protocol string, extPort int, intPort int, desc string, lifetime time.Duration) (int, error)
+	// DeletePortMapping removes the mapping of the external port
+	DeletePortMapping(protocol string, extPort int, intPort int) error
+}
+
+// New creates the client of the NAT gateway by the mechanism. The gateway is discovered if its address is empty.
+func New(mechanism string, gateway string) (NAT, error) {
+	switch mechanism {
+	case UPnP:
+		return DiscoverUPnP(gateway)
+	case NATPMP:
+		if gateway == "" {
+			gw, err := defaultGateway()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to find the default gateway")
+			}
+			gateway = gw.String()
+		}
+		return NewNATPMPClient(gateway), nil
+	default:
+		return nil, errors.Errorf("unknown NAT traversal mechanism %s", mechanism)
+	}
+}
+
+// defaultGateway reads the gateway of the default route from the routing table
+func defaultGateway() (net.IP, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+		gw, err := hex.DecodeString(fields[2])
+		if err != nil || len(gw) != net.IPv4len {
+			continue
+		}
+		// The addresses in the routing table are in little endian
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, binary.LittleEndian.Uint32(gw))
+		return ip, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("no default route")
+}
+
+// localIP returns the IP of the node in the local network of the gateway
+func localIP(gateway string) (net.IP, error) {
+	conn, err := net.Dial("udp4", gateway)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package nat
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// natpmpPort is the port which the gateway listens on for the NAT-PMP requests
+	natpmpPort = "5351"
+	// natpmpTries is the number of times that a request is sent before giving up, with the timeout doubled each time
+	natpmpTries = 4
+	// natpmpTimeout is the timeout of the first try
+	natpmpTimeout = 250 * time.Millisecond
+)
+
+// NATPMPClient is the client of the NAT port mapping protocol defined in RFC 6886
+type NATPMPClient struct {
+	gateway string
+}
+
+// NewNATPMPClient creates the NAT-PMP client of the gateway, whose port is 5351 unless given along with the host
+func NewNATPMPClient(gateway string) *NATPMPClient {
+	if _, _, err := net.SplitHostPort(gateway); err != nil {
+		gateway = net.JoinHostPort(strings.Trim(gateway, "[]"), natpmpPort)
+	}
+	return &NATPMPClient{gateway: gateway}
+}
+
+// ExternalIP returns the public IP of the gateway
+func (n *NATPMPClient) ExternalIP() (net.IP, error) {
+	res, err := n.call([]byte{0, 0}, 12)
+	if err != nil {
+		return nil, err
+	}
+	return net.IPv4(res[8], res[9], res[10], res[11]), nil
+}
+
+// AddPortMapping maps the external port to the internal port of the node for the lifetime
+func (n *NATPMPClient) AddPortMapping(
+	protocol string,
+	extPort int,
+	intPort int,
+	_ string,
+	lifetime time.Duration,
+) (int, error) {
+	return n.mapPort(protocol, extPort, intPort, uint32(lifetime/time.Second))
+}
+
+// DeletePortMapping removes the mapping of the port, which is requesting the mapping with the lifetime of 0
+func (n *NATPMPClient) DeletePortMapping(protocol string, _ int, intPort int) error {
+	_, err := n.mapPort(protocol, 0, intPort, 0)
+	return err
+}
+
+func (n *NATPMPClient) mapPort(protocol string, extPort int, intPort int, lifetime uint32) (int, error) {
+	req := make([]byte, 12)
+	switch strings.ToLower(protocol) {
+	case "udp":
+		req[1] = 1
+	case "tcp":
+		req[1] = 2
+	default:
+		return 0, errors.Errorf("unknown protocol %s", protocol)
+	}
+	binary.BigEndian.PutUint16(req[4:], uint16(intPort))
+	binary.BigEndian.PutUint16(req[6:], uint16(extPort))
+	binary.BigEndian.PutUint32(req[8:], lifetime)
+	res, err := n.call(req, 16)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(res[10:])), nil
+}
+
+// call sends the request to the gateway, and waits for the response of the expected size, resending the request on
+// each timeout
+func (n *NATPMPClient) call(req []byte, size int) ([]byte, error) {
+	conn, err := net.Dial("udp", n.gateway)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial NAT-PMP gateway %s", n.gateway)
+	}
+	defer conn.Close()
+
+	res := make([]byte, 16)
+	timeout := natpmpTimeout
+	for i := 0; i < natpmpTries; i++ {
+		if _, err := conn.Write(req); err != nil {
+			return nil, errors.Wrapf(err, "failed to send NAT-PMP request to %s", n.gateway)
+		}
+		if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, err
+		}
+		timeout *= 2
+		read, err := conn.Read(res)
+		if err, ok := err.(net.Error); ok && err.Timeout() {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to receive NAT-PMP response from %s", n.gateway)
+		}
+		// The response echoes the opcode of the request plus 128
+		if read < size || res[0] != 0 || res[1] != req[1]|0x80 {
+			return nil, errors.Errorf("invalid NAT-PMP response from %s", n.gateway)
+		}
+		if code := binary.BigEndian.Uint16(res[2:]); code != 0 {
+			return nil, errors.Errorf("NAT-PMP gateway %s returns result code %d", n.gateway, code)
+		}
+		return res[:size], nil
+	}
+	return nil, errors.Errorf("NAT-PMP gateway %s doesn't respond", n.gateway)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package nat
+
+import (
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNATPMPGateway answers the NAT-PMP requests with the external IP 1.2.3.4, and maps the requested external port
+// plus 1
+func fakeNATPMPGateway(t *testing.T) (*net.UDPConn, *sync.Map) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	lifetimes := &sync.Map{}
+	go func() {
+		buf := make([]byte, 16)
+		for {
+			n, addr, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			res := make([]byte, 16)
+			res[1] = buf[1] | 0x80
+			switch {
+			case n == 2 && buf[1] == 0:
+				copy(res[8:], []byte{1, 2, 3, 4})
+				res = res[:12]
+			case n == 12 && buf[1] == 2:
+				intPort := binary.BigEndian.Uint16(buf[4:])
+				lifetimes.Store(intPort, binary.BigEndian.Uint32(buf[8:]))
+				copy(res[8:], buf[4:6])
+				binary.BigEndian.PutUint16(res[10:], binary.BigEndian.Uint16(buf[6:])+1)
+				copy(res[12:], buf[8:12])
+			default:
+				// Unsupported opcode
+				binary.BigEndian.PutUint16(res[2:], 5)
+			}
+			if _, err := conn.WriteToUDP(res, addr); err != nil {
+				return
+			}
+		}
+	}()
+	return conn, lifetimes
+}
+
+func TestNATPMP(t *testing.T) {
+	require := require.New(t)
+
+	gateway, lifetimes := fakeNATPMPGateway(t)
+	defer func() {
+		require.NoError(gateway.Close())
+	}()
+	n := NewNATPMPClient(gateway.LocalAddr().String())
+
+	ip, err := n.ExternalIP()
+	require.NoError(err)
+	require.Equal("1.2.3.4", ip.String())
+
+	port, err := n.AddPortMapping("tcp", 4689, 4690, "iotex", time.Hour)
+	require.NoError(err)
+	require.Equal(4690, port)
+	lifetime, _ := lifetimes.Load(uint16(4690))
+	require.Equal(uint32(3600), lifetime)
+
+	// The mapping is removed by mapping the port with the lifetime of 0
+	require.NoError(n.DeletePortMapping("tcp", 4690, 4690))
+	lifetime, _ = lifetimes.Load(uint16(4690))
+	require.Equal(uint32(0), lifetime)
+
+	_, err = n.AddPortMapping("udp", 4689, 4690, "iotex", time.Hour)
+	require.Error(err)
+	_, err = n.AddPortMapping("sctp", 4689, 4690, "iotex", time.Hour)
+	require.Error(err)
+}
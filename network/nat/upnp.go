@@ -0,0 +1,262 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package nat
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ssdpAddr is the multicast address which the UPnP devices are searched at
+	ssdpAddr = "239.255.255.250:1900"
+	// ssdpTimeout is how long to wait for the gateway to answer the search
+	ssdpTimeout = 3 * time.Second
+	// soapTimeout is the timeout of a call to the gateway
+	soapTimeout = 5 * time.Second
+)
+
+// wanServices are the types of the services of the gateway which map the ports
+var wanServices = []string{
+	"urn:schemas-upnp-org:service:WANIPConnection:1",
+	"urn:schemas-upnp-org:service:WANPPPConnection:1",
+}
+
+// UPnPClient is the client of the WAN connection service of a UPnP internet gateway device
+type UPnPClient struct {
+	client      *http.Client
+	serviceType string
+	controlURL  string
+	localIP     net.IP
+}
+
+// upnpDevice is the device description of the gateway, which nests the services in the embedded devices
+type upnpDevice struct {
+	Services []struct {
+		ServiceType string `xml:"serviceType"`
+		ControlURL  string `xml:"controlURL"`
+	} `xml:"serviceList>service"`
+	Devices []upnpDevice `xml:"deviceList>device"`
+}
+
+type upnpRoot struct {
+	URLBase string     `xml:"URLBase"`
+	Device  upnpDevice `xml:"device"`
+}
+
+// DiscoverUPnP searches the local network for the gateway. If the location of the gateway description is given, the
+// search is skipped.
+func DiscoverUPnP(location string) (*UPnPClient, error) {
+	if location == "" {
+		var err error
+		if location, err = searchGateway(); err != nil {
+			return nil, err
+		}
+	}
+	return NewUPnPClient(location)
+}
+
+// NewUPnPClient creates the client of the gateway from its description at the location
+func NewUPnPClient(location string) (*UPnPClient, error) {
+	client := &http.Client{Timeout: soapTimeout}
+	res, err := client.Get(location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get UPnP device description from %s", location)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to get UPnP device description from %s: %s", location, res.Status)
+	}
+	var root upnpRoot
+	if err := xml.NewDecoder(res.Body).Decode(&root); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode UPnP device description from %s", location)
+	}
+	base, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	if root.URLBase != "" {
+		if base, err = url.Parse(root.URLBase); err != nil {
+			return nil, err
+		}
+	}
+	serviceType, controlURL := findWANService(&root.Device)
+	if controlURL == "" {
+		return nil, errors.Errorf("UPnP device at %s has no WAN connection service", location)
+	}
+	ctrl, err := base.Parse(controlURL)
+	if err != nil {
+		return nil, err
+	}
+	gateway := ctrl.Host
+	if ctrl.Port() == "" {
+		gateway = net.JoinHostPort(ctrl.Hostname(), "80")
+	}
+	ip, err := localIP(gateway)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find the local IP")
+	}
+	return &UPnPClient{client: client, serviceType: serviceType, controlURL: ctrl.String(), localIP: ip}, nil
+}
+
+// ExternalIP returns the public IP of the gateway
+func (u *UPnPClient) ExternalIP() (net.IP, error) {
+	var res struct {
+		IP string `xml:"Body>GetExternalIPAddressResponse>NewExternalIPAddress"`
+	}
+	if err := u.call("GetExternalIPAddress", nil, &res); err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(res.IP)
+	if ip == nil {
+		return nil, errors.Errorf("invalid external IP %s", res.IP)
+	}
+	return ip, nil
+}
+
+// AddPortMapping maps the external port to the internal port of the node for the lifetime. The gateway doesn't pick
+// another port for the mapping, so the external port is always the one requested.
+func (u *UPnPClient) AddPortMapping(
+	protocol string,
+	extPort int,
+	intPort int,
+	desc string,
+	lifetime time.Duration,
+) (int, error) {
+	args := [][2]string{
+		{"NewRemoteHost", ""},
+		{"NewExternalPort", strconv.Itoa(extPort)},
+		{"NewProtocol", strings.ToUpper(protocol)},
+		{"NewInternalPort", strconv.Itoa(intPort)},
+		{"NewInternalClient", u.localIP.String()},
+		{"NewEnabled", "1"},
+		{"NewPortMappingDescription", desc},
+		{"NewLeaseDuration", strconv.Itoa(int(lifetime / time.Second))},
+	}
+	if err := u.call("AddPortMapping", args, nil); err != nil {
+		return 0, err
+	}
+	return extPort, nil
+}
+
+// DeletePortMapping removes the mapping of the external port
+func (u *UPnPClient) DeletePortMapping(protocol string, extPort int, _ int) error {
+	args := [][2]string{
+		{"NewRemoteHost", ""},
+		{"NewExternalPort", strconv.Itoa(extPort)},
+		{"NewProtocol", strings.ToUpper(protocol)},
+	}
+	return u.call("DeletePortMapping", args, nil)
+}
+
+// call invokes the action of the WAN connection service by SOAP, and decodes the response into res if it's not nil
+func (u *UPnPClient) call(action string, args [][2]string, res interface{}) error {
+	var body bytes.Buffer
+	body.WriteString(`<?xml version="1.0"?>`)
+	body.WriteString(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" `)
+	body.WriteString(`s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>`)
+	fmt.Fprintf(&body, `<u:%s xmlns:u="%s">`, action, u.serviceType)
+	for _, arg := range args {
+		fmt.Fprintf(&body, "<%s>", arg[0])
+		if err := xml.EscapeText(&body, []byte(arg[1])); err != nil {
+			return err
+		}
+		fmt.Fprintf(&body, "</%s>", arg[0])
+	}
+	fmt.Fprintf(&body, `</u:%s></s:Body></s:Envelope>`, action)
+
+	req, err := http.NewRequest(http.MethodPost, u.controlURL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	req.Header.Set("SOAPAction", fmt.Sprintf(`"%s#%s"`, u.serviceType, action))
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call UPnP action %s", action)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("UPnP action %s fails with status %s", action, resp.Status)
+	}
+	if res == nil {
+		return nil
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(res); err != nil {
+		return errors.Wrapf(err, "failed to decode the response of UPnP action %s", action)
+	}
+	return nil
+}
+
+// findWANService looks for the WAN connection service in the device and its embedded devices
+func findWANService(d *upnpDevice) (string, string) {
+	for _, s := range d.Services {
+		for _, t := range wanServices {
+			if s.ServiceType == t {
+				return s.ServiceType, s.ControlURL
+			}
+		}
+	}
+	for i := range d.Devices {
+		if serviceType, controlURL := findWANService(&d.Devices[i]); controlURL != "" {
+			return serviceType, controlURL
+		}
+	}
+	return "", ""
+}
+
+// searchGateway multicasts the SSDP search for the gateway, and returns the location of its description
+func searchGateway() (string, error) {
+	addr, err := net.ResolveUDPAddr("udp4", ssdpAddr)
+	if err != nil {
+		return "", err
+	}
+	conn, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	for _, st := range wanServices {
+		search := "M-SEARCH * HTTP/1.1\r\n" +
+			"HOST: " + ssdpAddr + "\r\n" +
+			"ST: " + st + "\r\n" +
+			"MAN: \"ssdp:discover\"\r\n" +
+			"MX: 2\r\n\r\n"
+		if _, err := conn.WriteTo([]byte(search), addr); err != nil {
+			return "", errors.Wrap(err, "failed to send SSDP search")
+		}
+	}
+	if err := conn.SetReadDeadline(time.Now().Add(ssdpTimeout)); err != nil {
+		return "", err
+	}
+	buf := make([]byte, 2048)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return "", errors.Wrap(err, "no UPnP gateway is found")
+		}
+		res, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buf[:n])), nil)
+		if err != nil {
+			continue
+		}
+		res.Body.Close()
+		if location := res.Header.Get("Location"); location != "" {
+			return location, nil
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package nat
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDeviceDesc = `<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+  <device>
+    <deviceType>urn:schemas-upnp-org:device:InternetGatewayDevice:1</deviceType>
+    <deviceList>
+      <device>
+        <deviceType>urn:schemas-upnp-org:device:WANDevice:1</deviceType>
+        <deviceList>
+          <device>
+            <deviceType>urn:schemas-upnp-org:device:WANConnectionDevice:1</deviceType>
+            <serviceList>
+              <service>
+                <serviceType>urn:schemas-upnp-org:service:WANIPConnection:1</serviceType>
+                <controlURL>/ctl/IPConn</controlURL>
+              </service>
+            </serviceList>
+          </device>
+        </deviceList>
+      </device>
+    </deviceList>
+  </device>
+</root>`
+
+func TestUPnP(t *testing.T) {
+	require := require.New(t)
+
+	actions := make(chan string, 10)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/desc.xml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testDeviceDesc)
+	})
+	mux.HandleFunc("/ctl/IPConn", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(err)
+		action := r.Header.Get("SOAPAction")
+		actions <- action + " " + string(body)
+		if strings.HasSuffix(action, `#GetExternalIPAddress"`) {
+			fmt.Fprint(w, `<?xml version="1.0"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body>
+<u:GetExternalIPAddressResponse xmlns:u="urn:schemas-upnp-org:service:WANIPConnection:1">
+<NewExternalIPAddress>1.2.3.4</NewExternalIPAddress>
+</u:GetExternalIPAddressResponse></s:Body></s:Envelope>`)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	u, err := DiscoverUPnP(server.URL + "/desc.xml")
+	require.NoError(err)
+	require.Equal(server.URL+"/ctl/IPConn", u.controlURL)
+
+	ip, err := u.ExternalIP()
+	require.NoError(err)
+	require.Equal("1.2.3.4", ip.String())
+	require.Contains(<-actions, `"urn:schemas-upnp-org:service:WANIPConnection:1#GetExternalIPAddress"`)
+
+	port, err := u.AddPortMapping("tcp", 4689, 4690, "iotex", time.Hour)
+	require.NoError(err)
+	require.Equal(4689, port)
+	action := <-actions
+	require.Contains(action, "#AddPortMapping")
+	require.Contains(action, "<NewExternalPort>4689</NewExternalPort>")
+	require.Contains(action, "<NewInternalPort>4690</NewInternalPort>")
+	require.Contains(action, "<NewProtocol>TCP</NewProtocol>")
+	require.Contains(action, "<NewLeaseDuration>3600</NewLeaseDuration>")
+
+	require.NoError(u.DeletePortMapping("tcp", 4689, 4690))
+	require.Contains(<-actions, "#DeletePortMapping")
+
+	_, err = DiscoverUPnP(server.URL + "/missing.xml")
+	require.Error(err)
+}
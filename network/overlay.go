@@ -20,14 +20,20 @@ import (
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network/node"
 	"github.com/iotexproject/iotex-core/network/proto"
+	"github.com/iotexproject/iotex-core/network/trace"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/routine"
 	"github.com/iotexproject/iotex-core/proto"
 )
 
-// ErrPeerNotFound means the peer is not found
-var ErrPeerNotFound = errors.New("Peer not found")
+var (
+	// ErrPeerNotFound means the peer is not found
+	ErrPeerNotFound = errors.New("Peer not found")
+	// ErrPeerBanned means the peer is banned for misbehaving
+	ErrPeerBanned = errors.New("Peer is banned")
+)
 
 // Overlay represents the peer-to-peer network
 type Overlay interface {
@@ -36,16 +42,23 @@ type Overlay interface {
 	Tell(uint32, net.Addr, proto.Message) error
 	Self() net.Addr
 	GetPeers() []net.Addr
+	// BannedPeers returns the banned peers and the time that the bans end
+	BannedPeers() map[string]time.Time
 }
 
 // IotxOverlay is the implementation
 type IotxOverlay struct {
 	PM         *PeerManager
+	AddrBook   *AddrBook
+	Reputation *Reputation
+	Identity   *Identity
 	RPC        *RPCServer
 	Gossip     *Gossip
 	Tasks      []*routine.RecurringTask
 	Config     *config.Network
 	Dispatcher dispatcher.Dispatcher
+	// Tracer records the messages sent and received, which is nil if the tracing is disabled
+	Tracer *trace.Recorder
 
 	lifecycle lifecycle.Lifecycle
 }
@@ -53,14 +66,44 @@ type IotxOverlay struct {
 // NewOverlay creates an instance of IotxOverlay
 func NewOverlay(config *config.Network) *IotxOverlay {
 	o := &IotxOverlay{Config: config}
+	identity, err := NewIdentity(config)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Fail to load node identity")
+	}
+	o.Identity = identity
+	if config.PeerReputationEnabled {
+		reputation, err := NewReputation(config)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Fail to load peer reputation")
+		}
+		o.Reputation = reputation
+	}
 	o.RPC = NewRPCServer(o)
+	if config.PeerDiscovery {
+		book, err := NewAddrBook(o.RPC.String(), identity.PubKey, config.AddrBookBucketSize, config.AddrBookPath)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Fail to load address book")
+		}
+		for _, bn := range config.BootstrapNodes {
+			book.Add(bn, keypair.ZeroPublicKey)
+		}
+		o.AddrBook = book
+	}
 	o.PM = NewPeerManager(o, config.NumPeersLowerBound, config.NumPeersUpperBound)
 	o.Gossip = NewGossip(o)
 	o.lifecycle.AddModels(o.RPC, o.PM, o.Gossip)
+	if config.TracePath != "" {
+		o.Tracer = trace.NewRecorder(config.TracePath, o.RPC.String)
+		o.lifecycle.Add(o.Tracer)
+	}
 
 	o.addPingTask()
 	o.addHealthCheckTask()
+	if config.RateLimitEnabled && config.RateLimitPath != "" {
+		o.addRateLimitReloadTask()
+	}
 	if config.PeerDiscovery {
+		o.addDiscoveryTask()
 		o.addPeerMaintainer()
 	} else {
 		o.addConfigBasedPeerMaintainer()
@@ -78,6 +121,75 @@ func (o *IotxOverlay) Stop(ctx context.Context) error { return o.lifecycle.OnSto
 func (o *IotxOverlay) AttachDispatcher(dispatcher dispatcher.Dispatcher) {
 	o.Dispatcher = dispatcher
 	o.Gossip.AttachDispatcher(dispatcher)
+	dispatcher.SetPeerReporter(func(sender string, err error) {
+		logger.Debug().Err(err).Str("peer", sender).Msg("Peer sent invalid data")
+		o.PenalizePeer(sender, OffenseInvalidData)
+	})
+}
+
+// PenalizePeer lowers the score of the peer at the address for the offense, and disconnects the peer if it gets banned.
+// The peer which hasn't authenticated at the address is not penalized, since anyone could claim the address.
+func (o *IotxOverlay) PenalizePeer(addr string, offense Offense) {
+	if id, ok := o.peerID(addr); ok {
+		o.penalize(id, addr, addrHost(addr), offense)
+	}
+}
+
+// IsPeerBanned returns true if the peer which has authenticated at the address, or the host of the address, is banned
+func (o *IotxOverlay) IsPeerBanned(addr string) bool {
+	id, _ := o.peerID(addr)
+	return o.Reputation.IsBanned(id, addrHost(addr))
+}
+
+// BannedPeers returns the banned peers and hosts and the time that the bans end. A peer is shown by the address which
+// it has authenticated at, or by its key if the address is not known.
+func (o *IotxOverlay) BannedPeers() map[string]time.Time {
+	bans := o.Reputation.Bans()
+	if o.Identity == nil {
+		return bans
+	}
+	o.Identity.peers.Range(func(key, value interface{}) bool {
+		id := peerID(value.(*pinnedKey).pubKey)
+		if until, ok := bans[id]; ok {
+			delete(bans, id)
+			bans[key.(string)] = until
+		}
+		return true
+	})
+	return bans
+}
+
+// penalize lowers the score of the peer with the identity for the offense, and disconnects the peer at the address if
+// it gets banned along with the host it connects from
+func (o *IotxOverlay) penalize(id string, addr string, host string, offense Offense) {
+	if o.Reputation.Penalize(id, host, offense) {
+		o.PM.RemovePeer(addr)
+	}
+}
+
+// addrHost returns the host of the address, or an empty string if the address is malformed
+func addrHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return ""
+	}
+	return host
+}
+
+// peerID returns the identity of the peer which has authenticated at the address, which is the address itself if the
+// node doesn't run the handshake
+func (o *IotxOverlay) peerID(addr string) (string, bool) {
+	if addr == "" {
+		return "", false
+	}
+	if o.Identity == nil {
+		return addr, true
+	}
+	pubKey, ok := o.Identity.peerKey(addr)
+	if !ok {
+		return "", false
+	}
+	return peerID(pubKey), true
 }
 
 func (o *IotxOverlay) addPingTask() {
@@ -94,6 +206,24 @@ func (o *IotxOverlay) addHealthCheckTask() {
 	o.Tasks = append(o.Tasks, hcTask)
 }
 
+func (o *IotxOverlay) addRateLimitReloadTask() {
+	reload := func() {
+		if _, err := o.RPC.RateLimiter().Reload(); err != nil {
+			logger.Error().Err(err).Msg("Failed to reload the rate limits")
+		}
+	}
+	rlTask := routine.NewRecurringTask(reload, o.Config.RateLimitReloadInterval)
+	o.lifecycle.Add(rlTask)
+	o.Tasks = append(o.Tasks, rlTask)
+}
+
+func (o *IotxOverlay) addDiscoveryTask() {
+	d := NewDiscovery(o)
+	dTask := routine.NewRecurringTask(d.Discover, o.Config.DiscoveryInterval)
+	o.lifecycle.Add(dTask)
+	o.Tasks = append(o.Tasks, dTask)
+}
+
 func (o *IotxOverlay) addPeerMaintainer() {
 	pm := NewPeerMaintainer(o)
 	pmTask := routine.NewRecurringTask(pm.Update, o.Config.PeerMaintainerInterval)
@@ -124,10 +254,15 @@ func (o *IotxOverlay) Broadcast(chainID uint32, msg proto.Message) error {
 	}
 	// Source also needs to remember the message sent so that it wouldn't process it again
 	msgChecksum := hash.Hash256b(msgBody)
-	checksumStr := hex.EncodeToString(msgChecksum)
-	o.Gossip.MsgLogs.Store(checksumStr, time.Now())
+	o.Gossip.markSeen(&network.BroadcastReq{
+		ChainId:     chainID,
+		MsgType:     msgType,
+		MsgBody:     msgBody,
+		MsgChecksum: msgChecksum,
+		Ttl:         o.Config.TTL,
+	})
 	// Kick off the message
-	if err = o.Gossip.relayMsg(chainID, msgType, msgBody, msgChecksum, o.Config.TTL); err != nil {
+	if err = o.Gossip.relayMsg(chainID, msgType, msgBody, msgChecksum, o.Config.TTL, ""); err != nil {
 		return errors.Wrap(err, "failed to relay msg when broadcast")
 	}
 	return nil
@@ -158,6 +293,16 @@ func (o *IotxOverlay) Tell(chainID uint32, node net.Addr, msg proto.Message) err
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal msg when broadcast")
 	}
+	if o.Tracer != nil {
+		o.trace(trace.Event{
+			Direction: trace.Send,
+			Kind:      trace.Tell,
+			ChainID:   chainID,
+			MsgType:   msgType,
+			Checksum:  hex.EncodeToString(hash.Hash256b(msgBody)),
+			Peer:      peer.String(),
+		})
+	}
 	go func(p *Peer) {
 		_, err := p.Tell(&network.TellReq{ChainId: chainID, Addr: o.RPC.String(), MsgType: msgType, MsgBody: msgBody})
 		if err != nil {
@@ -174,3 +319,13 @@ func (o *IotxOverlay) Tell(chainID uint32, node net.Addr, msg proto.Message) err
 func (o *IotxOverlay) Self() net.Addr {
 	return o.RPC
 }
+
+// trace records the message event if the tracing is enabled
+func (o *IotxOverlay) trace(e trace.Event) {
+	if o.Tracer == nil {
+		return
+	}
+	if err := o.Tracer.Record(e); err != nil {
+		logger.Debug().Err(err).Msg("Failed to record the message trace")
+	}
+}
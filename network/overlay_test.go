@@ -47,19 +47,20 @@ func LoadTestConfig(addr string, allowMultiConnsPerHost bool) *config.Network {
 		Network: config.Network{
 			Host: host,
 			Port: port,
-			MsgLogsCleaningInterval: 2 * time.Second,
-			MsgLogRetention:         10 * time.Second,
 			HealthCheckInterval:     time.Second,
 			SilentInterval:          5 * time.Second,
+			SeenCacheSize:           10000,
 			PeerMaintainerInterval:  time.Second,
 			NumPeersLowerBound:      5,
 			NumPeersUpperBound:      5,
 			AllowMultiConnsPerHost:  allowMultiConnsPerHost,
 			RateLimitEnabled:        false,
+			RateLimitMaxBuckets:     10000,
 			PingInterval:            time.Second,
 			BootstrapNodes:          []string{"127.0.0.1:10001", "127.0.0.1:10002"},
 			MaxMsgSize:              1024 * 1024 * 10,
 			PeerDiscovery:           true,
+			DiscoveryInterval:       time.Second,
 			TTL:                     3,
 		},
 	}
@@ -78,7 +79,9 @@ func LoadTestConfigWithTLSEnabled(addr string, allowMultiConnsPerHost bool) *con
 type MockDispatcher struct {
 }
 
-func (d *MockDispatcher) AddSubscriber(uint32, dispatcher.Subscriber) {}
+func (d *MockDispatcher) RegisterHandler(uint32, uint32, dispatcher.Lane, dispatcher.Handler) error {
+	return nil
+}
 
 func (d *MockDispatcher) Start(_ context.Context) error {
 	return nil
@@ -94,13 +97,13 @@ func (d *MockDispatcher) HandleBroadcast(uint32, proto.Message, chan bool) {
 func (d *MockDispatcher) HandleTell(uint32, net.Addr, proto.Message, chan bool) {
 }
 
+func (d *MockDispatcher) SetPeerReporter(dispatcher.PeerReporter) {}
+
 type MockDispatcher1 struct {
 	MockDispatcher
 	Count uint32
 }
 
-func (d1 *MockDispatcher1) AddSubscriber(uint32, dispatcher.Subscriber) {}
-
 func (d1 *MockDispatcher1) HandleBroadcast(uint32, proto.Message, chan bool) {
 	d1.Count++
 }
@@ -161,8 +164,6 @@ type MockDispatcher2 struct {
 	Count uint32
 }
 
-func (d2 *MockDispatcher2) AddSubscriber(uint32, dispatcher.Subscriber) {}
-
 func (d2 *MockDispatcher2) HandleTell(chainID uint32, sender net.Addr, message proto.Message, done chan bool) {
 	// Handle Tx Msg
 	msgType, err := iproto.GetTypeFromProtoMsg(message)
@@ -232,13 +233,18 @@ func TestOneConnPerHost(t *testing.T) {
 	p1.AttachDispatcher(dp1)
 	err := p1.Start(ctx)
 	require.Nil(t, err)
+	// The other nodes bootstrap from the first one, which only keeps the connection to one of them
 	dp2 := &MockDispatcher2{T: t}
-	p2 := NewOverlay(LoadTestConfig(addr2, false))
+	cfg2 := LoadTestConfig(addr2, false)
+	cfg2.BootstrapNodes = []string{addr1}
+	p2 := NewOverlay(cfg2)
 	p2.AttachDispatcher(dp2)
 	err = p2.Start(ctx)
 	assert.NoError(t, err)
 	dp3 := &MockDispatcher2{T: t}
-	p3 := NewOverlay(LoadTestConfig(addr3, false))
+	cfg3 := LoadTestConfig(addr3, false)
+	cfg3.BootstrapNodes = []string{addr1}
+	p3 := NewOverlay(cfg3)
 	p3.AttachDispatcher(dp3)
 	err = p3.Start(ctx)
 	assert.NoError(t, err)
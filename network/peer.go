@@ -7,16 +7,21 @@
 package network
 
 import (
+	"sync"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network/node"
 	pb "github.com/iotexproject/iotex-core/network/proto"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/proto"
 )
 
@@ -49,6 +54,12 @@ type Peer struct {
 	Conn        *grpc.ClientConn
 	Ctx         context.Context
 	LastResTime time.Time
+	// PubKey is the key which the peer proves to possess in the handshake
+	PubKey keypair.PublicKey
+
+	mu       sync.Mutex
+	identity *Identity
+	selfAddr string
 }
 
 // NewTCPPeer creates an instance of Peer with tcp transportation
@@ -64,8 +75,9 @@ func NewPeer(n string, addr string) *Peer {
 	return p
 }
 
-// Connect connects the peer
-func (p *Peer) Connect(config *config.Network) error {
+// Connect connects the peer, and runs the handshake as the node at the address with the identity. The handshake is
+// skipped if the identity is nil.
+func (p *Peer) Connect(config *config.Network, id *Identity, selfAddr string) error {
 	// Set up a connection to the peer.
 	var conn *grpc.ClientConn
 	var err error
@@ -96,9 +108,71 @@ func (p *Peer) Connect(config *config.Network) error {
 	p.Conn = conn
 	p.Client = pb.NewPeerClient(conn)
 	p.Ctx = context.Background()
+	if id == nil {
+		return nil
+	}
+	p.identity = id
+	p.selfAddr = selfAddr
+	if err := p.handshake(); err != nil {
+		if cerr := conn.Close(); cerr != nil {
+			logger.Error().Err(cerr).Str("dst", p.String()).Msg("Failed to close the connection")
+		}
+		return err
+	}
 	return nil
 }
 
+// handshake proves the identity of the node to the peer, and verifies the identity of the peer
+func (p *Peer) handshake() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	defer cancel()
+	hello, err := p.identity.hello(p.selfAddr)
+	if err != nil {
+		return err
+	}
+	res, err := p.Client.Handshake(ctx, &pb.HandshakeReq{Hello: hello})
+	if err != nil {
+		// The node at the address may be gone, so that the address could be taken by another node
+		p.identity.unpin(p.String())
+		return errors.Wrapf(err, "failed to handshake with %s", p.String())
+	}
+	if err := p.identity.checkCompatibility(res.Hello); err != nil {
+		return err
+	}
+	if res.Hello.Addr != p.String() {
+		return errors.Wrapf(ErrUnauthenticated, "peer at %s introduces itself as %s", p.String(), res.Hello.Addr)
+	}
+	pubKey, err := p.identity.verifyHandshake(res.Hello, hello.Nonce, res.Signature)
+	if err != nil {
+		return err
+	}
+	if _, err := p.Client.Authenticate(ctx, &pb.AuthReq{Signature: p.identity.sign(hello, res.Hello.Nonce)}); err != nil {
+		return errors.Wrapf(err, "failed to authenticate to %s", p.String())
+	}
+	// The node has dialed the address itself, so that the key is known to be served at it
+	if err := p.identity.pin(p.String(), pubKey); err != nil {
+		return err
+	}
+	p.PubKey = pubKey
+	return nil
+}
+
+// reauthenticate runs the handshake again if the connection is no longer authenticated, which happens when it is
+// re-established, and returns true if the request should be retried
+func (p *Peer) reauthenticate(err error) bool {
+	if p.identity == nil || status.Code(err) != codes.Unauthenticated {
+		return false
+	}
+	if err := p.handshake(); err != nil {
+		logger.Error().Err(err).Str("dst", p.String()).Msg("Failed to handshake again")
+		return false
+	}
+	return true
+}
+
 // Close terminates the connection
 func (p *Peer) Close() error {
 	return p.Conn.Close()
@@ -108,6 +182,9 @@ func (p *Peer) Close() error {
 func (p *Peer) Ping(ping *pb.Ping) (*pb.Pong, error) {
 	succeed := "false"
 	pong, err := p.Client.Ping(p.Ctx, ping)
+	if p.reauthenticate(err) {
+		pong, err = p.Client.Ping(p.Ctx, ping)
+	}
 	if err == nil {
 		succeed = "true"
 		p.updateLastResTime()
@@ -120,6 +197,9 @@ func (p *Peer) Ping(ping *pb.Ping) (*pb.Pong, error) {
 func (p *Peer) GetPeers(req *pb.GetPeersReq) (*pb.GetPeersRes, error) {
 	succeed := "false"
 	res, err := p.Client.GetPeers(p.Ctx, req)
+	if p.reauthenticate(err) {
+		res, err = p.Client.GetPeers(p.Ctx, req)
+	}
 	if err == nil {
 		succeed = "true"
 		p.updateLastResTime()
@@ -133,6 +213,9 @@ func (p *Peer) BroadcastMsg(req *pb.BroadcastReq) (*pb.BroadcastRes, error) {
 	succeed := "false"
 	req.Header = iproto.MagicBroadcastMsgHeader
 	res, err := p.Client.Broadcast(p.Ctx, req)
+	if p.reauthenticate(err) {
+		res, err = p.Client.Broadcast(p.Ctx, req)
+	}
 	if err == nil {
 		succeed = "true"
 		p.updateLastResTime()
@@ -146,6 +229,9 @@ func (p *Peer) Tell(req *pb.TellReq) (*pb.TellRes, error) {
 	succeed := "false"
 	req.Header = iproto.MagicBroadcastMsgHeader
 	res, err := p.Client.Tell(p.Ctx, req)
+	if p.reauthenticate(err) {
+		res, err = p.Client.Tell(p.Ctx, req)
+	}
 	if err == nil {
 		succeed = "true"
 		p.updateLastResTime()
@@ -154,6 +240,37 @@ func (p *Peer) Tell(req *pb.TellReq) (*pb.TellRes, error) {
 	return res, err
 }
 
+// Announce implements the client side RPC
+func (p *Peer) Announce(req *pb.AnnounceReq) (*pb.AnnounceRes, error) {
+	succeed := "false"
+	req.Header = iproto.MagicBroadcastMsgHeader
+	res, err := p.Client.Announce(p.Ctx, req)
+	if p.reauthenticate(err) {
+		res, err = p.Client.Announce(p.Ctx, req)
+	}
+	if err == nil {
+		succeed = "true"
+		p.updateLastResTime()
+	}
+	cRequestMtc.WithLabelValues("Announce", succeed).Inc()
+	return res, err
+}
+
+// Pull implements the client side RPC
+func (p *Peer) Pull(req *pb.PullReq) (*pb.PullRes, error) {
+	succeed := "false"
+	res, err := p.Client.Pull(p.Ctx, req)
+	if p.reauthenticate(err) {
+		res, err = p.Client.Pull(p.Ctx, req)
+	}
+	if err == nil {
+		succeed = "true"
+		p.updateLastResTime()
+	}
+	cRequestMtc.WithLabelValues("Pull", succeed).Inc()
+	return res, err
+}
+
 // Update the last time when successfully getting an response from the peer
 func (p *Peer) updateLastResTime() {
 	p.LastResTime = time.Now()
@@ -7,11 +7,9 @@
 package network
 
 import (
-	"math/rand"
 	"net"
 
 	"github.com/iotexproject/iotex-core/network/node"
-	pb "github.com/iotexproject/iotex-core/network/proto"
 )
 
 // PeerMaintainer helps maintain enough connections to other peers in the P2P networks
@@ -25,8 +23,8 @@ func NewPeerMaintainer(o *IotxOverlay) *PeerMaintainer {
 	return &PeerMaintainer{Overlay: o}
 }
 
-// Update maintains peer connection. Current strategy is to get the (upper_bound - count) peer addresses from one of the
-// current peer if the count is lower than the lower bound
+// Update maintains peer connection. Current strategy is to connect to the (lower_bound - count) addresses picked from
+// the address book if the count is lower than the lower bound, which is filled by the discovery separately
 func (pm *PeerMaintainer) Update() {
 	defer func() {
 		pm.round++
@@ -39,43 +37,14 @@ func (pm *PeerMaintainer) Update() {
 
 	count := LenSyncMap(pm.Overlay.PM.Peers)
 	cConnMtc.WithLabelValues().Set(float64(count))
-	if count == 0 {
-		// TODO: Now we simply read the bootstrap nodes from the config. This needs to be changed in the future
-		bns1 := pm.Overlay.Config.BootstrapNodes
-		bns2 := make([]string, len(bns1))
-		copy(bns2, bns1)
-		stringsAreShuffled(bns2)
-		for i, bn := range bns2 {
-			if uint(i) >= pm.Overlay.PM.NumPeersLowerBound {
-				break
-			}
-			pm.Overlay.PM.AddPeer(bn)
-		}
-	} else if count < pm.Overlay.PM.NumPeersLowerBound {
-		targetIdx := rand.Intn(int(count))
-		idx := 0
-		// It's possible that no target is reached because the peer is deleted concurrently, so that targetIdx is
-		// greater than the number of peers
-		var target *Peer
-		pm.Overlay.PM.Peers.Range(func(_, value interface{}) bool {
-			if idx == targetIdx {
-				target = value.(*Peer)
-				return false
-			}
-			idx++
-			return true
+	if count < pm.Overlay.PM.NumPeersLowerBound {
+		addrs := pm.Overlay.AddrBook.Pick(int(pm.Overlay.PM.NumPeersLowerBound-count), func(addr string) bool {
+			_, ok := pm.Overlay.PM.Peers.Load(addr)
+			return ok || pm.Overlay.IsPeerBanned(addr)
 		})
-		go func() {
-			if target != nil {
-				res, error := target.GetPeers(
-					&pb.GetPeersReq{Count: uint32(pm.Overlay.PM.NumPeersUpperBound - count)})
-				if res != nil && error == nil {
-					for _, addr := range res.Addr {
-						pm.Overlay.PM.AddPeer(addr)
-					}
-				}
-			}
-		}()
+		for _, addr := range addrs {
+			pm.Overlay.PM.AddPeer(addr)
+		}
 	} else if count > pm.Overlay.PM.NumPeersUpperBound {
 		for count > pm.Overlay.PM.NumPeersUpperBound {
 			pm.Overlay.PM.RemoveLRUPeer()
@@ -13,9 +13,10 @@ import (
 	"github.com/iotexproject/iotex-core/logger"
 )
 
-// PeerManager represents the outgoing neighbor list
-// TODO: We should decouple peer address and peer. Node can know more nodes than it connects to
+// PeerManager represents the outgoing neighbor list. The node knows more nodes than it connects to, which are kept in
+// the address book.
 type PeerManager struct {
+	mu sync.Mutex
 	// TODO: Need to revisit sync.Map: https://github.com/golang/go/issues/24112
 	Peers              *sync.Map
 	Overlay            *IotxOverlay
@@ -35,24 +36,61 @@ func NewPeerManager(o *IotxOverlay, lb uint, ub uint) *PeerManager {
 
 // AddPeer adds a new peer
 func (pm *PeerManager) AddPeer(addr string) {
+	if !pm.canAdd(addr) {
+		return
+	}
+	p := NewTCPPeer(addr)
+	err := p.Connect(pm.Overlay.Config, pm.Overlay.Identity, pm.Overlay.RPC.String())
+	if err != nil {
+		logger.Error().
+			Str("dst", addr).
+			Msg("failed to establish an outgoing connection")
+		pm.Overlay.AddrBook.MarkFailed(addr)
+		return
+	}
+	// Check again, as another peer could be added during the handshake
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if !pm.canAdd(addr) {
+		if err := p.Close(); err != nil {
+			logger.Error().
+				Str("dst", addr).
+				Msg("failed to terminate an outgoing connection")
+		}
+		return
+	}
+	pm.Peers.Store(addr, p)
+	logger.Debug().
+		Str("dst", addr).
+		Msg("establish an outgoing connection")
+}
+
+// canAdd checks whether the node at the address could be added as a new peer
+func (pm *PeerManager) canAdd(addr string) bool {
 	if LenSyncMap(pm.Peers) >= pm.NumPeersUpperBound {
 		logger.Debug().
 			Uint("peers", pm.NumPeersUpperBound).
 			Msg("Node already reached the max number of peers")
-		return
+		return false
+	}
+	if pm.Overlay.IsPeerBanned(addr) {
+		logger.Debug().
+			Str("dst", addr).
+			Msg("Node at address is banned")
+		return false
 	}
 	if pm.Overlay.RPC.String() == addr {
 		logger.Debug().
 			Str("dst", addr).
 			Msg("Node at address is the current node")
-		return
+		return false
 	}
 	_, ok := pm.Peers.Load(addr)
 	if ok {
 		logger.Debug().
 			Str("dst", addr).
 			Msg("Node at address is already the peer")
-		return
+		return false
 	}
 	if !pm.Overlay.Config.AllowMultiConnsPerHost {
 		nHost, _, err := net.SplitHostPort(addr)
@@ -60,7 +98,7 @@ func (pm *PeerManager) AddPeer(addr string) {
 			logger.Error().
 				Str("dst", addr).
 				Msg("Node address is invalid")
-			return
+			return false
 		}
 		found := false
 		pm.Peers.Range(func(key, value interface{}) bool {
@@ -82,20 +120,10 @@ func (pm *PeerManager) AddPeer(addr string) {
 			logger.Debug().
 				Str("dst-host", nHost).
 				Msg("Another node on the same Host is already the peer")
-			return
+			return false
 		}
 	}
-	p := NewTCPPeer(addr)
-	err := p.Connect(pm.Overlay.Config)
-	if err != nil {
-		logger.Error().
-			Str("dst", addr).
-			Msg("failed to establish an outgoing connection")
-	}
-	pm.Peers.Store(addr, p)
-	logger.Debug().
-		Str("dst", addr).
-		Msg("establish an outgoing connection")
+	return true
 }
 
 // RemovePeer removes an existing peer
@@ -36,6 +36,7 @@ func (h *Pinger) Ping() {
 			pong, err := p.Ping(&pb.Ping{Nonce: n, Addr: h.Overlay.RPC.String()})
 			if err != nil {
 				logger.Error().Err(err).Str("dst", p.String()).Msg("error when getting pong")
+				h.Overlay.AddrBook.MarkFailed(p.String())
 				return
 			}
 			if pong == nil {
@@ -48,7 +49,10 @@ func (h *Pinger) Ping() {
 					Uint64("out-nonce", n).
 					Uint64("in-nonce", pong.AckNonce).
 					Msg("pong carries an unmatched nonce")
+				return
 			}
+			h.Overlay.AddrBook.MarkGood(p.String(), p.PubKey)
+			h.Overlay.RPC.ExternalAddr().Observe(p.String(), pong.ObservedHost)
 		}()
 		return true
 	})
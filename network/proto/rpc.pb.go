@@ -23,6 +23,232 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// Hello introduces a node to the other end of the connection in the handshake
+type Hello struct {
+	PubKey          []byte `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Addr            string `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	ChainId         uint32 `protobuf:"varint,3,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ProtocolVersion uint32 `protobuf:"varint,4,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	// The challenge which the other end needs to sign to prove the possession of its key
+	Nonce                []byte   `protobuf:"bytes,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Hello) Reset()         { *m = Hello{} }
+func (m *Hello) String() string { return proto.CompactTextString(m) }
+func (*Hello) ProtoMessage()    {}
+func (*Hello) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{0}
+}
+func (m *Hello) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Hello.Unmarshal(m, b)
+}
+func (m *Hello) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Hello.Marshal(b, m, deterministic)
+}
+func (dst *Hello) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Hello.Merge(dst, src)
+}
+func (m *Hello) XXX_Size() int {
+	return xxx_messageInfo_Hello.Size(m)
+}
+func (m *Hello) XXX_DiscardUnknown() {
+	xxx_messageInfo_Hello.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Hello proto.InternalMessageInfo
+
+func (m *Hello) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *Hello) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *Hello) GetChainId() uint32 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+func (m *Hello) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *Hello) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+type HandshakeReq struct {
+	Hello                *Hello   `protobuf:"bytes,1,opt,name=hello,proto3" json:"hello,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HandshakeReq) Reset()         { *m = HandshakeReq{} }
+func (m *HandshakeReq) String() string { return proto.CompactTextString(m) }
+func (*HandshakeReq) ProtoMessage()    {}
+func (*HandshakeReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{1}
+}
+func (m *HandshakeReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandshakeReq.Unmarshal(m, b)
+}
+func (m *HandshakeReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandshakeReq.Marshal(b, m, deterministic)
+}
+func (dst *HandshakeReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandshakeReq.Merge(dst, src)
+}
+func (m *HandshakeReq) XXX_Size() int {
+	return xxx_messageInfo_HandshakeReq.Size(m)
+}
+func (m *HandshakeReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandshakeReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandshakeReq proto.InternalMessageInfo
+
+func (m *HandshakeReq) GetHello() *Hello {
+	if m != nil {
+		return m.Hello
+	}
+	return nil
+}
+
+type HandshakeRes struct {
+	Hello *Hello `protobuf:"bytes,1,opt,name=hello,proto3" json:"hello,omitempty"`
+	// The signature of the server over the nonce of the client
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HandshakeRes) Reset()         { *m = HandshakeRes{} }
+func (m *HandshakeRes) String() string { return proto.CompactTextString(m) }
+func (*HandshakeRes) ProtoMessage()    {}
+func (*HandshakeRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{2}
+}
+func (m *HandshakeRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandshakeRes.Unmarshal(m, b)
+}
+func (m *HandshakeRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandshakeRes.Marshal(b, m, deterministic)
+}
+func (dst *HandshakeRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandshakeRes.Merge(dst, src)
+}
+func (m *HandshakeRes) XXX_Size() int {
+	return xxx_messageInfo_HandshakeRes.Size(m)
+}
+func (m *HandshakeRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandshakeRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandshakeRes proto.InternalMessageInfo
+
+func (m *HandshakeRes) GetHello() *Hello {
+	if m != nil {
+		return m.Hello
+	}
+	return nil
+}
+
+func (m *HandshakeRes) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type AuthReq struct {
+	// The signature of the client over the nonce of the server
+	Signature            []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthReq) Reset()         { *m = AuthReq{} }
+func (m *AuthReq) String() string { return proto.CompactTextString(m) }
+func (*AuthReq) ProtoMessage()    {}
+func (*AuthReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{3}
+}
+func (m *AuthReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthReq.Unmarshal(m, b)
+}
+func (m *AuthReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthReq.Marshal(b, m, deterministic)
+}
+func (dst *AuthReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthReq.Merge(dst, src)
+}
+func (m *AuthReq) XXX_Size() int {
+	return xxx_messageInfo_AuthReq.Size(m)
+}
+func (m *AuthReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthReq proto.InternalMessageInfo
+
+func (m *AuthReq) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type AuthRes struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthRes) Reset()         { *m = AuthRes{} }
+func (m *AuthRes) String() string { return proto.CompactTextString(m) }
+func (*AuthRes) ProtoMessage()    {}
+func (*AuthRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{4}
+}
+func (m *AuthRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthRes.Unmarshal(m, b)
+}
+func (m *AuthRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthRes.Marshal(b, m, deterministic)
+}
+func (dst *AuthRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthRes.Merge(dst, src)
+}
+func (m *AuthRes) XXX_Size() int {
+	return xxx_messageInfo_AuthRes.Size(m)
+}
+func (m *AuthRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthRes proto.InternalMessageInfo
+
 type Ping struct {
 	Nonce uint64 `protobuf:"varint,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	// Every one who participates into the network needs to tell others its address
@@ -37,7 +263,7 @@ func (m *Ping) Reset()         { *m = Ping{} }
 func (m *Ping) String() string { return proto.CompactTextString(m) }
 func (*Ping) ProtoMessage()    {}
 func (*Ping) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_c5f1a61bd6a5b846, []int{0}
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{5}
 }
 func (m *Ping) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Ping.Unmarshal(m, b)
@@ -72,7 +298,9 @@ func (m *Ping) GetAddr() string {
 }
 
 type Pong struct {
-	AckNonce             uint64   `protobuf:"varint,1,opt,name=ack_nonce,json=ackNonce,proto3" json:"ack_nonce,omitempty"`
+	AckNonce uint64 `protobuf:"varint,1,opt,name=ack_nonce,json=ackNonce,proto3" json:"ack_nonce,omitempty"`
+	// The host of the ping sender seen by the receiver, which lets the sender learn its public address behind a NAT
+	ObservedHost         string   `protobuf:"bytes,2,opt,name=observed_host,json=observedHost,proto3" json:"observed_host,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -82,7 +310,7 @@ func (m *Pong) Reset()         { *m = Pong{} }
 func (m *Pong) String() string { return proto.CompactTextString(m) }
 func (*Pong) ProtoMessage()    {}
 func (*Pong) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_c5f1a61bd6a5b846, []int{1}
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{6}
 }
 func (m *Pong) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Pong.Unmarshal(m, b)
@@ -109,8 +337,17 @@ func (m *Pong) GetAckNonce() uint64 {
 	return 0
 }
 
+func (m *Pong) GetObservedHost() string {
+	if m != nil {
+		return m.ObservedHost
+	}
+	return ""
+}
+
 type GetPeersReq struct {
-	Count                uint32   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Count uint32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// The node ID to look up. If it is set, the known addresses closest to it are returned rather than the peers.
+	Target               []byte   `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -120,7 +357,7 @@ func (m *GetPeersReq) Reset()         { *m = GetPeersReq{} }
 func (m *GetPeersReq) String() string { return proto.CompactTextString(m) }
 func (*GetPeersReq) ProtoMessage()    {}
 func (*GetPeersReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_c5f1a61bd6a5b846, []int{2}
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{7}
 }
 func (m *GetPeersReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetPeersReq.Unmarshal(m, b)
@@ -147,8 +384,17 @@ func (m *GetPeersReq) GetCount() uint32 {
 	return 0
 }
 
+func (m *GetPeersReq) GetTarget() []byte {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
 type GetPeersRes struct {
-	Addr                 []string `protobuf:"bytes,1,rep,name=addr,proto3" json:"addr,omitempty"`
+	Addr []string `protobuf:"bytes,1,rep,name=addr,proto3" json:"addr,omitempty"`
+	// The keys of the nodes at the addresses in the same order, which their node IDs are derived from
+	PubKey               [][]byte `protobuf:"bytes,2,rep,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -158,7 +404,7 @@ func (m *GetPeersRes) Reset()         { *m = GetPeersRes{} }
 func (m *GetPeersRes) String() string { return proto.CompactTextString(m) }
 func (*GetPeersRes) ProtoMessage()    {}
 func (*GetPeersRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_c5f1a61bd6a5b846, []int{3}
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{8}
 }
 func (m *GetPeersRes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetPeersRes.Unmarshal(m, b)
@@ -185,13 +431,22 @@ func (m *GetPeersRes) GetAddr() []string {
 	return nil
 }
 
+func (m *GetPeersRes) GetPubKey() [][]byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
 type BroadcastReq struct {
-	Header               uint32   `protobuf:"varint,1,opt,name=header,proto3" json:"header,omitempty"`
-	ChainId              uint32   `protobuf:"varint,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	MsgType              uint32   `protobuf:"varint,3,opt,name=msg_type,json=msgType,proto3" json:"msg_type,omitempty"`
-	MsgBody              []byte   `protobuf:"bytes,4,opt,name=msg_body,json=msgBody,proto3" json:"msg_body,omitempty"`
-	MsgChecksum          []byte   `protobuf:"bytes,5,opt,name=msg_checksum,json=msgChecksum,proto3" json:"msg_checksum,omitempty"`
-	Ttl                  int32    `protobuf:"varint,6,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Header      uint32 `protobuf:"varint,1,opt,name=header,proto3" json:"header,omitempty"`
+	ChainId     uint32 `protobuf:"varint,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	MsgType     uint32 `protobuf:"varint,3,opt,name=msg_type,json=msgType,proto3" json:"msg_type,omitempty"`
+	MsgBody     []byte `protobuf:"bytes,4,opt,name=msg_body,json=msgBody,proto3" json:"msg_body,omitempty"`
+	MsgChecksum []byte `protobuf:"bytes,5,opt,name=msg_checksum,json=msgChecksum,proto3" json:"msg_checksum,omitempty"`
+	Ttl         int32  `protobuf:"varint,6,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// The address of the peer which relays the message, so that it could be held accountable for the message
+	Addr                 string   `protobuf:"bytes,7,opt,name=addr,proto3" json:"addr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -201,7 +456,7 @@ func (m *BroadcastReq) Reset()         { *m = BroadcastReq{} }
 func (m *BroadcastReq) String() string { return proto.CompactTextString(m) }
 func (*BroadcastReq) ProtoMessage()    {}
 func (*BroadcastReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_c5f1a61bd6a5b846, []int{4}
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{9}
 }
 func (m *BroadcastReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BroadcastReq.Unmarshal(m, b)
@@ -263,6 +518,13 @@ func (m *BroadcastReq) GetTtl() int32 {
 	return 0
 }
 
+func (m *BroadcastReq) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
 type BroadcastRes struct {
 	Header               uint32   `protobuf:"varint,1,opt,name=header,proto3" json:"header,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -274,7 +536,7 @@ func (m *BroadcastRes) Reset()         { *m = BroadcastRes{} }
 func (m *BroadcastRes) String() string { return proto.CompactTextString(m) }
 func (*BroadcastRes) ProtoMessage()    {}
 func (*BroadcastRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_c5f1a61bd6a5b846, []int{5}
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{10}
 }
 func (m *BroadcastRes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BroadcastRes.Unmarshal(m, b)
@@ -316,7 +578,7 @@ func (m *TellReq) Reset()         { *m = TellReq{} }
 func (m *TellReq) String() string { return proto.CompactTextString(m) }
 func (*TellReq) ProtoMessage()    {}
 func (*TellReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_c5f1a61bd6a5b846, []int{6}
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{11}
 }
 func (m *TellReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TellReq.Unmarshal(m, b)
@@ -382,7 +644,7 @@ func (m *TellRes) Reset()         { *m = TellRes{} }
 func (m *TellRes) String() string { return proto.CompactTextString(m) }
 func (*TellRes) ProtoMessage()    {}
 func (*TellRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_c5f1a61bd6a5b846, []int{7}
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{12}
 }
 func (m *TellRes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TellRes.Unmarshal(m, b)
@@ -409,7 +671,213 @@ func (m *TellRes) GetHeader() uint32 {
 	return 0
 }
 
+// AnnounceReq tells the peer that the node has the broadcast message, which the peer pulls if it hasn't seen it
+type AnnounceReq struct {
+	Header               uint32   `protobuf:"varint,1,opt,name=header,proto3" json:"header,omitempty"`
+	ChainId              uint32   `protobuf:"varint,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	MsgType              uint32   `protobuf:"varint,3,opt,name=msg_type,json=msgType,proto3" json:"msg_type,omitempty"`
+	MsgChecksum          []byte   `protobuf:"bytes,4,opt,name=msg_checksum,json=msgChecksum,proto3" json:"msg_checksum,omitempty"`
+	Ttl                  int32    `protobuf:"varint,5,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Addr                 string   `protobuf:"bytes,6,opt,name=addr,proto3" json:"addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AnnounceReq) Reset()         { *m = AnnounceReq{} }
+func (m *AnnounceReq) String() string { return proto.CompactTextString(m) }
+func (*AnnounceReq) ProtoMessage()    {}
+func (*AnnounceReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{13}
+}
+func (m *AnnounceReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnnounceReq.Unmarshal(m, b)
+}
+func (m *AnnounceReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnnounceReq.Marshal(b, m, deterministic)
+}
+func (dst *AnnounceReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnnounceReq.Merge(dst, src)
+}
+func (m *AnnounceReq) XXX_Size() int {
+	return xxx_messageInfo_AnnounceReq.Size(m)
+}
+func (m *AnnounceReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnnounceReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnnounceReq proto.InternalMessageInfo
+
+func (m *AnnounceReq) GetHeader() uint32 {
+	if m != nil {
+		return m.Header
+	}
+	return 0
+}
+
+func (m *AnnounceReq) GetChainId() uint32 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+func (m *AnnounceReq) GetMsgType() uint32 {
+	if m != nil {
+		return m.MsgType
+	}
+	return 0
+}
+
+func (m *AnnounceReq) GetMsgChecksum() []byte {
+	if m != nil {
+		return m.MsgChecksum
+	}
+	return nil
+}
+
+func (m *AnnounceReq) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *AnnounceReq) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+type AnnounceRes struct {
+	Header               uint32   `protobuf:"varint,1,opt,name=header,proto3" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AnnounceRes) Reset()         { *m = AnnounceRes{} }
+func (m *AnnounceRes) String() string { return proto.CompactTextString(m) }
+func (*AnnounceRes) ProtoMessage()    {}
+func (*AnnounceRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{14}
+}
+func (m *AnnounceRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnnounceRes.Unmarshal(m, b)
+}
+func (m *AnnounceRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnnounceRes.Marshal(b, m, deterministic)
+}
+func (dst *AnnounceRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnnounceRes.Merge(dst, src)
+}
+func (m *AnnounceRes) XXX_Size() int {
+	return xxx_messageInfo_AnnounceRes.Size(m)
+}
+func (m *AnnounceRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnnounceRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnnounceRes proto.InternalMessageInfo
+
+func (m *AnnounceRes) GetHeader() uint32 {
+	if m != nil {
+		return m.Header
+	}
+	return 0
+}
+
+type PullReq struct {
+	MsgChecksum          []byte   `protobuf:"bytes,1,opt,name=msg_checksum,json=msgChecksum,proto3" json:"msg_checksum,omitempty"`
+	Addr                 string   `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PullReq) Reset()         { *m = PullReq{} }
+func (m *PullReq) String() string { return proto.CompactTextString(m) }
+func (*PullReq) ProtoMessage()    {}
+func (*PullReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{15}
+}
+func (m *PullReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PullReq.Unmarshal(m, b)
+}
+func (m *PullReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PullReq.Marshal(b, m, deterministic)
+}
+func (dst *PullReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PullReq.Merge(dst, src)
+}
+func (m *PullReq) XXX_Size() int {
+	return xxx_messageInfo_PullReq.Size(m)
+}
+func (m *PullReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_PullReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PullReq proto.InternalMessageInfo
+
+func (m *PullReq) GetMsgChecksum() []byte {
+	if m != nil {
+		return m.MsgChecksum
+	}
+	return nil
+}
+
+func (m *PullReq) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+type PullRes struct {
+	MsgBody              []byte   `protobuf:"bytes,1,opt,name=msg_body,json=msgBody,proto3" json:"msg_body,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PullRes) Reset()         { *m = PullRes{} }
+func (m *PullRes) String() string { return proto.CompactTextString(m) }
+func (*PullRes) ProtoMessage()    {}
+func (*PullRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_fd9183753a9ccebf, []int{16}
+}
+func (m *PullRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PullRes.Unmarshal(m, b)
+}
+func (m *PullRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PullRes.Marshal(b, m, deterministic)
+}
+func (dst *PullRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PullRes.Merge(dst, src)
+}
+func (m *PullRes) XXX_Size() int {
+	return xxx_messageInfo_PullRes.Size(m)
+}
+func (m *PullRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_PullRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PullRes proto.InternalMessageInfo
+
+func (m *PullRes) GetMsgBody() []byte {
+	if m != nil {
+		return m.MsgBody
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterType((*Hello)(nil), "network.Hello")
+	proto.RegisterType((*HandshakeReq)(nil), "network.HandshakeReq")
+	proto.RegisterType((*HandshakeRes)(nil), "network.HandshakeRes")
+	proto.RegisterType((*AuthReq)(nil), "network.AuthReq")
+	proto.RegisterType((*AuthRes)(nil), "network.AuthRes")
 	proto.RegisterType((*Ping)(nil), "network.Ping")
 	proto.RegisterType((*Pong)(nil), "network.Pong")
 	proto.RegisterType((*GetPeersReq)(nil), "network.GetPeersReq")
@@ -418,6 +886,10 @@ func init() {
 	proto.RegisterType((*BroadcastRes)(nil), "network.BroadcastRes")
 	proto.RegisterType((*TellReq)(nil), "network.TellReq")
 	proto.RegisterType((*TellRes)(nil), "network.TellRes")
+	proto.RegisterType((*AnnounceReq)(nil), "network.AnnounceReq")
+	proto.RegisterType((*AnnounceRes)(nil), "network.AnnounceRes")
+	proto.RegisterType((*PullReq)(nil), "network.PullReq")
+	proto.RegisterType((*PullRes)(nil), "network.PullRes")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -432,10 +904,14 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type PeerClient interface {
+	Handshake(ctx context.Context, in *HandshakeReq, opts ...grpc.CallOption) (*HandshakeRes, error)
+	Authenticate(ctx context.Context, in *AuthReq, opts ...grpc.CallOption) (*AuthRes, error)
 	Ping(ctx context.Context, in *Ping, opts ...grpc.CallOption) (*Pong, error)
 	GetPeers(ctx context.Context, in *GetPeersReq, opts ...grpc.CallOption) (*GetPeersRes, error)
 	Broadcast(ctx context.Context, in *BroadcastReq, opts ...grpc.CallOption) (*BroadcastRes, error)
 	Tell(ctx context.Context, in *TellReq, opts ...grpc.CallOption) (*TellRes, error)
+	Announce(ctx context.Context, in *AnnounceReq, opts ...grpc.CallOption) (*AnnounceRes, error)
+	Pull(ctx context.Context, in *PullReq, opts ...grpc.CallOption) (*PullRes, error)
 }
 
 type peerClient struct {
@@ -446,6 +922,24 @@ func NewPeerClient(cc *grpc.ClientConn) PeerClient {
 	return &peerClient{cc}
 }
 
+func (c *peerClient) Handshake(ctx context.Context, in *HandshakeReq, opts ...grpc.CallOption) (*HandshakeRes, error) {
+	out := new(HandshakeRes)
+	err := c.cc.Invoke(ctx, "/network.Peer/handshake", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerClient) Authenticate(ctx context.Context, in *AuthReq, opts ...grpc.CallOption) (*AuthRes, error) {
+	out := new(AuthRes)
+	err := c.cc.Invoke(ctx, "/network.Peer/authenticate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *peerClient) Ping(ctx context.Context, in *Ping, opts ...grpc.CallOption) (*Pong, error) {
 	out := new(Pong)
 	err := c.cc.Invoke(ctx, "/network.Peer/ping", in, out, opts...)
@@ -482,18 +976,76 @@ func (c *peerClient) Tell(ctx context.Context, in *TellReq, opts ...grpc.CallOpt
 	return out, nil
 }
 
+func (c *peerClient) Announce(ctx context.Context, in *AnnounceReq, opts ...grpc.CallOption) (*AnnounceRes, error) {
+	out := new(AnnounceRes)
+	err := c.cc.Invoke(ctx, "/network.Peer/announce", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerClient) Pull(ctx context.Context, in *PullReq, opts ...grpc.CallOption) (*PullRes, error) {
+	out := new(PullRes)
+	err := c.cc.Invoke(ctx, "/network.Peer/pull", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PeerServer is the server API for Peer service.
 type PeerServer interface {
+	Handshake(context.Context, *HandshakeReq) (*HandshakeRes, error)
+	Authenticate(context.Context, *AuthReq) (*AuthRes, error)
 	Ping(context.Context, *Ping) (*Pong, error)
 	GetPeers(context.Context, *GetPeersReq) (*GetPeersRes, error)
 	Broadcast(context.Context, *BroadcastReq) (*BroadcastRes, error)
 	Tell(context.Context, *TellReq) (*TellRes, error)
+	Announce(context.Context, *AnnounceReq) (*AnnounceRes, error)
+	Pull(context.Context, *PullReq) (*PullRes, error)
 }
 
 func RegisterPeerServer(s *grpc.Server, srv PeerServer) {
 	s.RegisterService(&_Peer_serviceDesc, srv)
 }
 
+func _Peer_Handshake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandshakeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServer).Handshake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Peer/Handshake",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServer).Handshake(ctx, req.(*HandshakeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Peer_Authenticate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServer).Authenticate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Peer/Authenticate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServer).Authenticate(ctx, req.(*AuthReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Peer_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Ping)
 	if err := dec(in); err != nil {
@@ -566,10 +1118,54 @@ func _Peer_Tell_Handler(srv interface{}, ctx context.Context, dec func(interface
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Peer_Announce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnnounceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServer).Announce(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Peer/Announce",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServer).Announce(ctx, req.(*AnnounceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Peer_Pull_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PullReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServer).Pull(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/network.Peer/Pull",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServer).Pull(ctx, req.(*PullReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Peer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "network.Peer",
 	HandlerType: (*PeerServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "handshake",
+			Handler:    _Peer_Handshake_Handler,
+		},
+		{
+			MethodName: "authenticate",
+			Handler:    _Peer_Authenticate_Handler,
+		},
 		{
 			MethodName: "ping",
 			Handler:    _Peer_Ping_Handler,
@@ -586,38 +1182,63 @@ var _Peer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "tell",
 			Handler:    _Peer_Tell_Handler,
 		},
+		{
+			MethodName: "announce",
+			Handler:    _Peer_Announce_Handler,
+		},
+		{
+			MethodName: "pull",
+			Handler:    _Peer_Pull_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "network/proto/rpc.proto",
 }
 
-func init() { proto.RegisterFile("network/proto/rpc.proto", fileDescriptor_rpc_c5f1a61bd6a5b846) }
-
-var fileDescriptor_rpc_c5f1a61bd6a5b846 = []byte{
-	// 399 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0xcd, 0x8e, 0xd3, 0x30,
-	0x10, 0xae, 0x89, 0xd3, 0x9f, 0xd9, 0x56, 0x5a, 0x59, 0x0b, 0x84, 0x70, 0x49, 0xbd, 0xd2, 0x2a,
-	0x07, 0xd4, 0x45, 0x70, 0x41, 0xe2, 0x56, 0x0e, 0x88, 0x0b, 0xaa, 0xa2, 0xde, 0xab, 0xd4, 0xb6,
-	0xd2, 0x2a, 0x89, 0x1d, 0x62, 0x57, 0x28, 0x2f, 0xc0, 0xd3, 0xf0, 0x36, 0xbc, 0x10, 0xb2, 0x9b,
-	0x46, 0x29, 0x6a, 0xb8, 0xcd, 0x37, 0xdf, 0x78, 0x3c, 0xdf, 0xf8, 0x33, 0xbc, 0x96, 0xc2, 0xfc,
-	0x54, 0x75, 0xfe, 0x5c, 0xd5, 0xca, 0xa8, 0xe7, 0xba, 0x62, 0x2b, 0x17, 0x91, 0x49, 0x4b, 0xd0,
-	0xf7, 0x80, 0x37, 0x47, 0x99, 0x91, 0x07, 0xf0, 0xa5, 0x92, 0x4c, 0x04, 0x28, 0x42, 0x31, 0x4e,
-	0xce, 0x80, 0x10, 0xc0, 0x29, 0xe7, 0x75, 0xf0, 0x22, 0x42, 0xf1, 0x2c, 0x71, 0x31, 0x7d, 0x04,
-	0xbc, 0x51, 0x32, 0x23, 0x6f, 0x61, 0x96, 0xb2, 0x7c, 0xd7, 0x3f, 0x35, 0x4d, 0x59, 0xfe, 0xdd,
-	0x62, 0xfa, 0x08, 0x77, 0x5f, 0x85, 0xd9, 0x08, 0x51, 0xeb, 0x44, 0xfc, 0xb0, 0xdd, 0x99, 0x3a,
-	0x49, 0xe3, 0xea, 0x16, 0xc9, 0x19, 0xd0, 0x65, 0xbf, 0x48, 0x77, 0x97, 0xa1, 0xc8, 0xeb, 0x2e,
-	0xfb, 0x8d, 0x60, 0xbe, 0xae, 0x55, 0xca, 0x59, 0xaa, 0x8d, 0xed, 0xf4, 0x0a, 0xc6, 0x07, 0x91,
-	0x72, 0x51, 0xb7, 0xad, 0x5a, 0x44, 0xde, 0xc0, 0x94, 0x1d, 0xd2, 0xa3, 0xdc, 0x1d, 0xb9, 0x9b,
-	0x76, 0x91, 0x4c, 0x1c, 0xfe, 0xc6, 0x2d, 0x55, 0xea, 0x6c, 0x67, 0x9a, 0x4a, 0x04, 0xde, 0x99,
-	0x2a, 0x75, 0xb6, 0x6d, 0x2a, 0x71, 0xa1, 0xf6, 0x8a, 0x37, 0x01, 0x8e, 0x50, 0x3c, 0x77, 0xd4,
-	0x5a, 0xf1, 0x86, 0x2c, 0x61, 0x6e, 0x29, 0x76, 0x10, 0x2c, 0xd7, 0xa7, 0x32, 0xf0, 0x1d, 0x7d,
-	0x57, 0xea, 0xec, 0x4b, 0x9b, 0x22, 0xf7, 0xe0, 0x19, 0x53, 0x04, 0xe3, 0x08, 0xc5, 0x7e, 0x62,
-	0x43, 0xfa, 0x74, 0x35, 0xad, 0x1e, 0x9a, 0x96, 0xfe, 0x42, 0x30, 0xd9, 0x8a, 0xa2, 0xf8, 0x9f,
-	0xa2, 0x1b, 0xbb, 0xbf, 0x52, 0xe9, 0x0d, 0xab, 0xc4, 0xc3, 0x2a, 0xfd, 0x2b, 0x95, 0x74, 0x79,
-	0x99, 0x63, 0x70, 0xd6, 0x0f, 0x7f, 0x10, 0x60, 0xfb, 0x46, 0xe4, 0x09, 0x70, 0x65, 0xad, 0xb2,
-	0x58, 0xb5, 0xe6, 0x59, 0x59, 0xe7, 0x84, 0x3d, 0xa8, 0x64, 0x46, 0x47, 0xe4, 0x13, 0x4c, 0xb3,
-	0xf6, 0x59, 0xc9, 0x43, 0x47, 0xf6, 0xec, 0x10, 0xde, 0xca, 0x6a, 0x3a, 0x22, 0x9f, 0x61, 0xb6,
-	0xbf, 0xac, 0x8f, 0xbc, 0xec, 0x8a, 0xfa, 0x06, 0x08, 0x6f, 0xa6, 0xed, 0xe1, 0x77, 0x80, 0x8d,
-	0x28, 0x0a, 0x72, 0xdf, 0x15, 0xb4, 0x1b, 0x0e, 0xff, 0xcd, 0x68, 0x3a, 0xda, 0x8f, 0xdd, 0x3f,
-	0xf8, 0xf8, 0x37, 0x00, 0x00, 0xff, 0xff, 0xbf, 0xf4, 0x7b, 0x4a, 0x22, 0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("network/proto/rpc.proto", fileDescriptor_rpc_fd9183753a9ccebf) }
+
+var fileDescriptor_rpc_fd9183753a9ccebf = []byte{
+	// 672 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xad, 0x55, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0x8d, 0x1d, 0x37, 0x13, 0x07, 0xaa, 0x55, 0xa1, 0xc1, 0x70, 0x68, 0x4d, 0x29, 0x20,
+	0xa1, 0x14, 0x15, 0x0e, 0x88, 0x5e, 0x68, 0x39, 0x10, 0x84, 0x84, 0x22, 0xab, 0xe2, 0x1a, 0x6d,
+	0xec, 0x55, 0x1c, 0x25, 0xf5, 0x06, 0xef, 0xba, 0x28, 0x2f, 0xc0, 0x99, 0x87, 0xe0, 0xcc, 0x33,
+	0xf0, 0x68, 0x8c, 0x37, 0x6b, 0x77, 0x9d, 0x26, 0x08, 0x24, 0x6e, 0x3b, 0xdf, 0xcc, 0x78, 0xe6,
+	0x9b, 0x3f, 0xc3, 0x5e, 0xca, 0xe4, 0x57, 0x9e, 0x4d, 0x8f, 0xe7, 0x19, 0x97, 0xfc, 0x38, 0x9b,
+	0x47, 0x3d, 0xf5, 0x22, 0xae, 0x56, 0x04, 0xdf, 0x2d, 0x70, 0xfa, 0x6c, 0x36, 0xe3, 0x64, 0x0f,
+	0xdc, 0x79, 0x3e, 0x1a, 0x4e, 0xd9, 0xa2, 0x6b, 0xed, 0x5b, 0x4f, 0xbd, 0xb0, 0x89, 0xe2, 0x47,
+	0xb6, 0x20, 0x04, 0x6c, 0x1a, 0xc7, 0x59, 0x77, 0x0b, 0xd1, 0x56, 0xa8, 0xde, 0xe4, 0x3e, 0x6c,
+	0x47, 0x09, 0x9d, 0xa4, 0xc3, 0x49, 0xdc, 0x6d, 0x20, 0xde, 0x09, 0x5d, 0x25, 0x7f, 0x88, 0xc9,
+	0x33, 0xd8, 0x51, 0x31, 0x22, 0x3e, 0x1b, 0x5e, 0xb1, 0x4c, 0x4c, 0x78, 0xda, 0xb5, 0x95, 0xc9,
+	0x9d, 0x12, 0xff, 0xbc, 0x84, 0xc9, 0x2e, 0x38, 0x29, 0x4f, 0x23, 0xd6, 0x75, 0x54, 0xc0, 0xa5,
+	0x10, 0xbc, 0x02, 0xaf, 0x4f, 0xd3, 0x58, 0x24, 0x74, 0xca, 0x42, 0xf6, 0x85, 0x1c, 0x82, 0x93,
+	0x14, 0x19, 0xaa, 0xb4, 0xda, 0x27, 0xb7, 0x7b, 0x3a, 0xf7, 0x9e, 0xca, 0x3b, 0x5c, 0x2a, 0x83,
+	0xb0, 0xe6, 0x25, 0xfe, 0xce, 0x8b, 0x3c, 0x84, 0x96, 0x98, 0x8c, 0x53, 0x2a, 0xf3, 0x8c, 0x29,
+	0x82, 0x5e, 0x78, 0x0d, 0x04, 0x4f, 0xc0, 0x3d, 0xcb, 0x65, 0x52, 0x24, 0x51, 0x33, 0xb4, 0x56,
+	0x0d, 0x5b, 0xa5, 0xa1, 0x08, 0x5e, 0x80, 0x3d, 0x98, 0xa4, 0xe3, 0x6b, 0x6e, 0x85, 0xb1, 0xad,
+	0xb9, 0xad, 0xab, 0x65, 0xd0, 0x47, 0x0f, 0x8e, 0x1e, 0x0f, 0xa0, 0x45, 0xa3, 0xe9, 0xd0, 0xf4,
+	0xda, 0x46, 0xe0, 0x93, 0x72, 0x7c, 0x04, 0x1d, 0x3e, 0x12, 0x2c, 0xbb, 0x62, 0xf1, 0x30, 0xe1,
+	0x42, 0xea, 0x2f, 0x78, 0x25, 0xd8, 0x47, 0x2c, 0x38, 0x85, 0xf6, 0x7b, 0x26, 0x07, 0x0c, 0xcb,
+	0x5b, 0xe4, 0x8c, 0x29, 0x44, 0x3c, 0x4f, 0xa5, 0xfa, 0x58, 0x27, 0x5c, 0x0a, 0xe4, 0x1e, 0x34,
+	0x25, 0xcd, 0xc6, 0x4c, 0x6a, 0xbe, 0x5a, 0x0a, 0xde, 0x98, 0xce, 0xa2, 0xca, 0xd4, 0xda, 0x6f,
+	0x54, 0x5d, 0x37, 0x46, 0x64, 0x0b, 0xe1, 0x6a, 0x44, 0x82, 0x5f, 0x16, 0x78, 0xe7, 0x19, 0xa7,
+	0x71, 0x44, 0x85, 0x2c, 0x42, 0x63, 0x90, 0x84, 0xd1, 0x98, 0x65, 0x3a, 0xb6, 0x96, 0x6a, 0x73,
+	0xb3, 0x55, 0x9f, 0x1b, 0x54, 0x5d, 0x8a, 0xf1, 0x50, 0x2e, 0xe6, 0xac, 0x1c, 0x29, 0x94, 0x2f,
+	0x50, 0x2c, 0x55, 0x23, 0x1e, 0x2f, 0xd4, 0x28, 0x79, 0x4a, 0x75, 0x8e, 0x22, 0x39, 0x00, 0xaf,
+	0x50, 0x45, 0x09, 0x8b, 0xa6, 0x22, 0xbf, 0xd4, 0x93, 0xd4, 0x46, 0xec, 0x9d, 0x86, 0xc8, 0x0e,
+	0x34, 0xa4, 0x9c, 0x75, 0x9b, 0xa8, 0x71, 0xc2, 0xe2, 0x59, 0x71, 0x73, 0x8d, 0x2e, 0x1c, 0xd5,
+	0x18, 0x88, 0x4d, 0x0c, 0x82, 0x6f, 0x16, 0xb8, 0x17, 0x38, 0x3b, 0x7f, 0x62, 0xf9, 0x8f, 0x1b,
+	0x63, 0x32, 0xb7, 0x37, 0x33, 0x77, 0x6a, 0xcc, 0x83, 0x83, 0x32, 0x8f, 0xcd, 0xb9, 0xfe, 0xb0,
+	0xa0, 0x7d, 0x96, 0xa6, 0xd8, 0xf6, 0x88, 0xfd, 0xff, 0xae, 0xac, 0x96, 0xde, 0xde, 0x58, 0x7a,
+	0xe7, 0x66, 0xe9, 0x9b, 0x46, 0xe9, 0x1f, 0x9b, 0x59, 0x6e, 0x66, 0xf3, 0x16, 0xdc, 0x41, 0xbe,
+	0x2c, 0xfc, 0x6a, 0x68, 0xeb, 0x66, 0xe8, 0x75, 0x9b, 0x76, 0x58, 0x7e, 0x41, 0xd4, 0x0a, 0x6b,
+	0xd5, 0x0a, 0x7b, 0xf2, 0xb3, 0x81, 0x0b, 0x89, 0x6b, 0x40, 0x4e, 0xa1, 0x95, 0x94, 0x27, 0x85,
+	0xdc, 0xbd, 0x3e, 0x20, 0xc6, 0x71, 0xf2, 0xd7, 0xc2, 0x22, 0xb8, 0x45, 0xf0, 0x8a, 0x51, 0x3c,
+	0x09, 0x2c, 0x95, 0x93, 0x88, 0x4a, 0x46, 0x76, 0x2a, 0x43, 0x7d, 0x52, 0xfc, 0x55, 0xa4, 0xf0,
+	0x3a, 0x02, 0x7b, 0x5e, 0x5c, 0x8f, 0x4e, 0xa5, 0x2b, 0x8e, 0x89, 0x6f, 0x88, 0x78, 0x29, 0xd0,
+	0xee, 0x35, 0x6c, 0x8f, 0xf5, 0xb2, 0x92, 0xdd, 0x4a, 0x69, 0x2c, 0xbf, 0xbf, 0x0e, 0x2d, 0x22,
+	0x20, 0xa9, 0x51, 0x39, 0xe7, 0x06, 0x29, 0x73, 0x7b, 0xfd, 0xb5, 0x70, 0xe1, 0xfc, 0x1c, 0x6c,
+	0x89, 0x33, 0x67, 0x90, 0xd1, 0xab, 0xe0, 0xaf, 0x22, 0x62, 0x99, 0x24, 0xd5, 0x7d, 0x35, 0x92,
+	0x34, 0x06, 0xd2, 0x5f, 0x87, 0xea, 0x38, 0xf3, 0xbc, 0x16, 0x47, 0x77, 0xde, 0x5f, 0x45, 0xd0,
+	0x7a, 0xd4, 0x54, 0xff, 0x95, 0x97, 0xbf, 0x01, 0x76, 0x19, 0xba, 0x80, 0xee, 0x06, 0x00, 0x00,
 }
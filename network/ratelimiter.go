@@ -0,0 +1,258 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/ratelimit"
+)
+
+// pruneInterval is how often the buckets which have been refilled to full are forgotten
+const pruneInterval = time.Minute
+
+// ErrRateLimited means the peer has sent the requests over the rate limits
+var ErrRateLimited = errors.New("sended requests too frequently")
+
+var rateLimitedMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_network_rate_limited",
+		Help: "Counter of the requests dropped for being over the rate limits.",
+	},
+	[]string{"limit"},
+)
+
+func init() {
+	prometheus.MustRegister(rateLimitedMtc)
+}
+
+// peerBuckets are the token buckets of a peer or a host
+type peerBuckets struct {
+	requests   *ratelimit.TokenBucket
+	bytes      *ratelimit.TokenBucket
+	perMsgType map[uint32]*ratelimit.TokenBucket
+	usedAt     time.Time
+}
+
+// full returns true if all the buckets have been refilled to full
+func (b *peerBuckets) full() bool {
+	if !b.requests.Full() || !b.bytes.Full() {
+		return false
+	}
+	for _, bucket := range b.perMsgType {
+		if !bucket.Full() {
+			return false
+		}
+	}
+	return true
+}
+
+// RateLimiter keeps the token buckets of each peer or host for the requests, the bytes and each message type. The
+// limits could be changed at any time, and apply to the existing buckets at once. The buckets are kept until they are
+// refilled to full, so that the peer can't reset its limits by reconnecting. At most maxBuckets are kept, and the least
+// recently used ones are forgotten beyond that.
+type RateLimiter struct {
+	mu         sync.Mutex
+	clock      clock.Clock
+	limits     config.RateLimits
+	peers      map[string]*peerBuckets
+	maxBuckets int
+	lastPrune  time.Time
+	maxMsgSize int
+	path       string
+	modTime    time.Time
+}
+
+// NewRateLimiter creates an instance of RateLimiter, and loads the limits from the rate limit file if it exists
+func NewRateLimiter(cfg *config.Network) (*RateLimiter, error) {
+	l := &RateLimiter{
+		clock:      clock.New(),
+		limits:     cfg.RateLimit,
+		peers:      make(map[string]*peerBuckets),
+		maxBuckets: cfg.RateLimitMaxBuckets,
+		maxMsgSize: cfg.MaxMsgSize,
+		path:       cfg.RateLimitPath,
+	}
+	if l.maxBuckets <= 0 {
+		l.maxBuckets = 1
+	}
+	if _, err := l.Reload(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Allow takes the tokens for a request of the message type and the size from the buckets of each of the peers, e.g.,
+// the key which the sender has authenticated with and the host it connects from. It returns ErrRateLimited if the
+// request is over any of the limits of any of the peers, in which case no token is taken from any bucket. The message
+// type is iproto.UnknownProtoMsgType for the requests not carrying a message.
+func (l *RateLimiter) Allow(peers []string, msgType uint32, size int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.prune(false)
+	all := make([]*peerBuckets, 0, len(peers))
+	for _, peer := range peers {
+		buckets := l.buckets(peer)
+		if !buckets.requests.Available(1) {
+			rateLimitedMtc.WithLabelValues("requests").Inc()
+			return errors.Wrapf(ErrRateLimited, "%s is over the request rate limit", peer)
+		}
+		if !buckets.bytes.Available(uint64(size)) {
+			rateLimitedMtc.WithLabelValues("bytes").Inc()
+			return errors.Wrapf(ErrRateLimited, "%s is over the byte rate limit", peer)
+		}
+		if b, ok := buckets.perMsgType[msgType]; ok && !b.Available(1) {
+			rateLimitedMtc.WithLabelValues("msgType").Inc()
+			return errors.Wrapf(ErrRateLimited, "%s is over the rate limit of message type %d", peer, msgType)
+		}
+		all = append(all, buckets)
+	}
+	// The buckets are only used under the lock, so the tokens checked above are still there
+	for _, buckets := range all {
+		buckets.requests.Allow(1)
+		buckets.bytes.Allow(uint64(size))
+		if b, ok := buckets.perMsgType[msgType]; ok {
+			b.Allow(1)
+		}
+	}
+	return nil
+}
+
+// Limits returns the limits in use
+func (l *RateLimiter) Limits() config.RateLimits {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.limits
+}
+
+// SetLimits replaces the limits, which apply to the buckets of the known peers at once
+func (l *RateLimiter) SetLimits(limits config.RateLimits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limits = limits
+	for _, buckets := range l.peers {
+		buckets.requests.SetLimit(limits.Requests.Rate, limits.Requests.Burst)
+		buckets.bytes.SetLimit(limits.Bytes.Rate, limits.Bytes.Burst)
+		for msgType, b := range buckets.perMsgType {
+			if limit, ok := limits.PerMsgType[msgType]; ok {
+				b.SetLimit(limit.Rate, limit.Burst)
+			} else {
+				delete(buckets.perMsgType, msgType)
+			}
+		}
+		for msgType, limit := range limits.PerMsgType {
+			if _, ok := buckets.perMsgType[msgType]; !ok {
+				buckets.perMsgType[msgType] = ratelimit.NewTokenBucket(limit.Rate, limit.Burst, l.clock)
+			}
+		}
+	}
+}
+
+// Reload reads the limits from the rate limit file if it has changed since the last reload, and returns true if the
+// limits are replaced
+func (l *RateLimiter) Reload() (bool, error) {
+	if l.path == "" {
+		return false, nil
+	}
+	info, err := os.Stat(l.path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to stat rate limit file %s", l.path)
+	}
+	if !info.ModTime().After(l.modTime) {
+		return false, nil
+	}
+	data, err := ioutil.ReadFile(l.path)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read rate limit file %s", l.path)
+	}
+	// The file isn't read again until it changes, even if it's invalid
+	l.modTime = info.ModTime()
+	var limits config.RateLimits
+	if err := yaml.Unmarshal(data, &limits); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal rate limit file %s", l.path)
+	}
+	if err := limits.Validate(l.maxMsgSize); err != nil {
+		return false, errors.Wrapf(err, "invalid rate limit file %s", l.path)
+	}
+	l.SetLimits(limits)
+	logger.Info().Str("path", l.path).Msg("Reloaded the rate limits")
+	return true, nil
+}
+
+// prune forgets the buckets which have been refilled to full once every prune interval, or at once if forced, since
+// they are the same as the new ones
+func (l *RateLimiter) prune(force bool) {
+	now := l.clock.Now()
+	if !force && now.Sub(l.lastPrune) < pruneInterval {
+		return
+	}
+	l.lastPrune = now
+	for peer, buckets := range l.peers {
+		if buckets.full() {
+			delete(l.peers, peer)
+		}
+	}
+}
+
+// buckets returns the buckets of the peer, which are created full on the first request. Once there are too many
+// buckets, the full ones are forgotten, and then the least recently used one if none is full.
+func (l *RateLimiter) buckets(peer string) *peerBuckets {
+	now := l.clock.Now()
+	buckets, ok := l.peers[peer]
+	if ok {
+		buckets.usedAt = now
+		return buckets
+	}
+	if len(l.peers) >= l.maxBuckets {
+		l.prune(true)
+	}
+	if len(l.peers) >= l.maxBuckets {
+		l.evictLRU()
+	}
+	buckets = &peerBuckets{
+		requests:   ratelimit.NewTokenBucket(l.limits.Requests.Rate, l.limits.Requests.Burst, l.clock),
+		bytes:      ratelimit.NewTokenBucket(l.limits.Bytes.Rate, l.limits.Bytes.Burst, l.clock),
+		perMsgType: make(map[uint32]*ratelimit.TokenBucket),
+		usedAt:     now,
+	}
+	for msgType, limit := range l.limits.PerMsgType {
+		buckets.perMsgType[msgType] = ratelimit.NewTokenBucket(limit.Rate, limit.Burst, l.clock)
+	}
+	l.peers[peer] = buckets
+	return buckets
+}
+
+// evictLRU forgets the buckets which are least recently used
+func (l *RateLimiter) evictLRU() {
+	var (
+		lru    string
+		usedAt time.Time
+	)
+	for peer, buckets := range l.peers {
+		if lru == "" || buckets.usedAt.Before(usedAt) {
+			lru = peer
+			usedAt = buckets.usedAt
+		}
+	}
+	delete(l.peers, lru)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+func TestRateLimiter(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default.Network
+	cfg.RateLimit = config.RateLimits{
+		Requests: config.RateLimit{Rate: 4},
+		Bytes:    config.RateLimit{Rate: 100, Burst: 200},
+		PerMsgType: map[uint32]config.RateLimit{
+			iproto.MsgActionType: {Rate: 1, Burst: 2},
+		},
+	}
+	l, err := NewRateLimiter(&cfg)
+	require.NoError(err)
+	clk := clock.NewMock()
+	l.clock = clk
+
+	// The message type has its own limit
+	peer := []string{"127.0.0.1"}
+	require.NoError(l.Allow(peer, iproto.MsgActionType, 10))
+	require.NoError(l.Allow(peer, iproto.MsgActionType, 10))
+	require.Equal(ErrRateLimited, errors.Cause(l.Allow(peer, iproto.MsgActionType, 10)))
+	require.NoError(l.Allow(peer, iproto.MsgEndorseProtoMsgType, 10))
+
+	// All the requests of the peer count against the request limit, but not those of the other peers. The dropped
+	// request takes no token.
+	require.NoError(l.Allow(peer, iproto.MsgEndorseProtoMsgType, 10))
+	require.Equal(ErrRateLimited, errors.Cause(l.Allow(peer, iproto.MsgEndorseProtoMsgType, 10)))
+	require.NoError(l.Allow([]string{"127.0.0.2"}, iproto.MsgEndorseProtoMsgType, 10))
+
+	// The bytes have their own limit
+	clk.Add(time.Second)
+	require.NoError(l.Allow(peer, iproto.UnknownProtoMsgType, 150))
+	require.Equal(ErrRateLimited, errors.Cause(l.Allow(peer, iproto.UnknownProtoMsgType, 150)))
+
+	// The buckets are kept until they are refilled to full
+	l.lastPrune = clk.Now().Add(-pruneInterval)
+	require.NoError(l.Allow([]string{"127.0.0.3"}, iproto.UnknownProtoMsgType, 10))
+	_, ok := l.peers["127.0.0.2"]
+	require.False(ok)
+	_, ok = l.peers[peer[0]]
+	require.True(ok)
+
+	// The new limits apply to the known peers at once
+	l.SetLimits(config.RateLimits{})
+	for i := 0; i < 10; i++ {
+		require.NoError(l.Allow(peer, iproto.MsgActionType, 1000))
+	}
+}
+
+func TestRateLimiterHostAndKey(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default.Network
+	cfg.RateLimit = config.RateLimits{Requests: config.RateLimit{Rate: 2}, Bytes: config.RateLimit{Rate: 1000}}
+	cfg.RateLimitMaxBuckets = 3
+	l, err := NewRateLimiter(&cfg)
+	require.NoError(err)
+	clk := clock.NewMock()
+	l.clock = clk
+
+	// A new key from the same host doesn't reset the limits of the host, and the dropped request takes no token from
+	// the buckets of the new key
+	require.NoError(l.Allow([]string{"10.0.0.1", "key1"}, iproto.MsgActionType, 10))
+	require.NoError(l.Allow([]string{"10.0.0.1", "key1"}, iproto.MsgActionType, 10))
+	require.Equal(ErrRateLimited, errors.Cause(l.Allow([]string{"key2", "10.0.0.1"}, iproto.MsgActionType, 10)))
+	require.True(l.peers["key2"].full())
+
+	// The key doesn't reset its limits by connecting from another host
+	require.Equal(ErrRateLimited, errors.Cause(l.Allow([]string{"10.0.0.2", "key1"}, iproto.MsgActionType, 10)))
+
+	// The buckets are bounded, and the full ones are forgotten first, and then the least recently used ones
+	require.Equal(3, len(l.peers))
+	clk.Add(time.Millisecond)
+	require.Equal(ErrRateLimited, errors.Cause(l.Allow([]string{"10.0.0.1"}, iproto.MsgActionType, 10)))
+	require.NoError(l.Allow([]string{"10.0.0.3"}, iproto.MsgActionType, 10))
+	require.Equal(3, len(l.peers))
+	_, ok := l.peers["10.0.0.2"]
+	require.False(ok)
+	clk.Add(time.Millisecond)
+	require.NoError(l.Allow([]string{"10.0.0.4"}, iproto.MsgActionType, 10))
+	require.Equal(3, len(l.peers))
+	_, ok = l.peers["key1"]
+	require.False(ok)
+	_, ok = l.peers["10.0.0.1"]
+	require.True(ok)
+}
+
+func TestRateLimiterReload(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "ratelimit")
+	require.NoError(err)
+	defer func() {
+		require.NoError(os.RemoveAll(dir))
+	}()
+	cfg := config.Default.Network
+	cfg.RateLimitPath = filepath.Join(dir, "ratelimit.yaml")
+	l, err := NewRateLimiter(&cfg)
+	require.NoError(err)
+	require.Equal(cfg.RateLimit, l.Limits())
+
+	// The limits are replaced once the file changes
+	require.NoError(ioutil.WriteFile(cfg.RateLimitPath, []byte("requests:\n  rate: 1\n"), 0600))
+	reloaded, err := l.Reload()
+	require.NoError(err)
+	require.True(reloaded)
+	require.Equal(config.RateLimits{Requests: config.RateLimit{Rate: 1}}, l.Limits())
+	reloaded, err = l.Reload()
+	require.NoError(err)
+	require.False(reloaded)
+
+	// The invalid limits are refused
+	require.NoError(ioutil.WriteFile(cfg.RateLimitPath, []byte("bytes:\n  rate: 1\n"), 0600))
+	require.NoError(os.Chtimes(cfg.RateLimitPath, time.Now(), time.Now().Add(time.Minute)))
+	_, err = l.Reload()
+	require.Error(err)
+	require.Equal(config.RateLimits{Requests: config.RateLimit{Rate: 1}}, l.Limits())
+}
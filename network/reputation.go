@@ -0,0 +1,267 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/cache"
+)
+
+// Offense is a misbehavior of a peer, which lowers its score
+type Offense int
+
+const (
+	// OffenseMalformedMsg is a message which fails to be decoded
+	OffenseMalformedMsg Offense = iota
+	// OffenseInvalidData is the data sent back to a request which fails to be validated
+	OffenseInvalidData
+	// OffenseRateLimited is a request over the rate limit
+	OffenseRateLimited
+)
+
+// penalties are the points which each offense costs
+var penalties = map[Offense]int64{
+	OffenseMalformedMsg: 25,
+	OffenseInvalidData:  50,
+	OffenseRateLimited:  10,
+}
+
+func (o Offense) String() string {
+	switch o {
+	case OffenseMalformedMsg:
+		return "malformed message"
+	case OffenseInvalidData:
+		return "invalid data"
+	case OffenseRateLimited:
+		return "rate limited"
+	default:
+		return "unknown"
+	}
+}
+
+// peerScore is the score of a peer as of the last update
+type peerScore struct {
+	score     int64
+	updatedAt time.Time
+}
+
+// banList is the persisted bans, which maps the peer identity or host to the unix time that the ban ends
+type banList struct {
+	Bans     map[string]int64 `yaml:"bans"`
+	HostBans map[string]int64 `yaml:"hostBans"`
+}
+
+// Reputation keeps the scores of the peers by their identities, which are the keys they have authenticated with, and
+// bans the ones whose scores drop below the threshold. Since a new key costs nothing, the host which the banned peer
+// connects from is banned as well. Only the scores of the most recently penalized peers are kept. A nil Reputation
+// bans no one.
+type Reputation struct {
+	mu               sync.Mutex
+	clock            clock.Clock
+	threshold        int64
+	recoveryInterval time.Duration
+	banDuration      time.Duration
+	path             string
+	scores           *cache.LRU
+	bans             map[string]time.Time
+	hostBans         map[string]time.Time
+}
+
+// NewReputation creates an instance of Reputation, and loads the bans persisted in the ban list file if any
+func NewReputation(cfg *config.Network) (*Reputation, error) {
+	r := &Reputation{
+		clock:            clock.New(),
+		threshold:        cfg.PeerBanThreshold,
+		recoveryInterval: cfg.PeerScoreRecoveryInterval,
+		banDuration:      cfg.PeerBanDuration,
+		path:             cfg.PeerBanListPath,
+		scores:           cache.NewLRU(cfg.PeerScoreCacheSize),
+		bans:             make(map[string]time.Time),
+		hostBans:         make(map[string]time.Time),
+	}
+	if r.path == "" {
+		return r, nil
+	}
+	data, err := ioutil.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read ban list %s", r.path)
+	}
+	var list banList
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal ban list %s", r.path)
+	}
+	for addr, until := range list.Bans {
+		r.bans[addr] = time.Unix(until, 0)
+	}
+	for host, until := range list.HostBans {
+		r.hostBans[host] = time.Unix(until, 0)
+	}
+	return r, nil
+}
+
+// Score returns the current score of the peer
+func (r *Reputation) Score(addr string) int64 {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	value, ok := r.scores.Get(addr)
+	if !ok {
+		return 0
+	}
+	return r.recover(value.(*peerScore)).score
+}
+
+// Penalize lowers the score of the peer for the offense, and bans the peer and the host it connects from if its score
+// drops below the threshold. The host is not banned if it's empty. It returns true if the peer gets banned.
+func (r *Reputation) Penalize(addr string, host string, offense Offense) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	score := r.score(addr)
+	score.score -= penalties[offense]
+	logger.Debug().
+		Str("peer", addr).
+		Str("host", host).
+		Str("offense", offense.String()).
+		Int64("score", score.score).
+		Msg("Penalize the peer")
+	if score.score >= r.threshold {
+		return false
+	}
+	r.scores.Remove(addr)
+	until := r.clock.Now().Add(r.banDuration)
+	r.bans[addr] = until
+	if host != "" {
+		r.hostBans[host] = until
+	}
+	if err := r.save(); err != nil {
+		logger.Error().Err(err).Msg("Failed to persist the ban list")
+	}
+	logger.Warn().Str("peer", addr).Str("host", host).Str("offense", offense.String()).Msg("Ban the peer")
+	return true
+}
+
+// IsBanned returns true if the peer or the host it connects from is being banned. An empty host is not checked.
+func (r *Reputation) IsBanned(addr string, host string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	banned := r.isBanned(r.bans, addr)
+	if host != "" && r.isBanned(r.hostBans, host) {
+		banned = true
+	}
+	return banned
+}
+
+// isBanned returns true if the key is being banned in the bans, and removes the ban if it has ended
+func (r *Reputation) isBanned(bans map[string]time.Time, key string) bool {
+	until, ok := bans[key]
+	if !ok {
+		return false
+	}
+	if r.clock.Now().Before(until) {
+		return true
+	}
+	delete(bans, key)
+	if err := r.save(); err != nil {
+		logger.Error().Err(err).Msg("Failed to persist the ban list")
+	}
+	return false
+}
+
+// Bans returns the peers and the hosts being banned and the time that the bans end
+func (r *Reputation) Bans() map[string]time.Time {
+	bans := make(map[string]time.Time)
+	if r == nil {
+		return bans
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock.Now()
+	for addr, until := range r.bans {
+		if now.Before(until) {
+			bans[addr] = until
+		}
+	}
+	for host, until := range r.hostBans {
+		if now.Before(until) {
+			bans[host] = until
+		}
+	}
+	return bans
+}
+
+// score returns the score of the peer, and starts a new one at 0 if the peer has no score kept, which evicts the score
+// of the least recently penalized peer once there are too many
+func (r *Reputation) score(addr string) *peerScore {
+	value, ok := r.scores.Get(addr)
+	if !ok {
+		score := &peerScore{updatedAt: r.clock.Now()}
+		r.scores.Add(addr, score)
+		return score
+	}
+	return r.recover(value.(*peerScore))
+}
+
+// recover regains the score a point every recovery interval since the last update up to 0
+func (r *Reputation) recover(score *peerScore) *peerScore {
+	now := r.clock.Now()
+	if r.recoveryInterval > 0 && score.score < 0 {
+		recovered := int64(now.Sub(score.updatedAt) / r.recoveryInterval)
+		score.score += recovered
+		if score.score > 0 {
+			score.score = 0
+		}
+		score.updatedAt = score.updatedAt.Add(time.Duration(recovered) * r.recoveryInterval)
+	}
+	if score.score == 0 {
+		score.updatedAt = now
+	}
+	return score
+}
+
+// save persists the bans into the ban list file
+func (r *Reputation) save() error {
+	if r.path == "" {
+		return nil
+	}
+	list := banList{Bans: make(map[string]int64), HostBans: make(map[string]int64)}
+	for addr, until := range r.bans {
+		list.Bans[addr] = until.Unix()
+	}
+	for host, until := range r.hostBans {
+		list.HostBans[host] = until.Unix()
+	}
+	data, err := yaml.Marshal(&list)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal ban list")
+	}
+	return ioutil.WriteFile(r.path, data, 0600)
+}
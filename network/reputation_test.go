@@ -0,0 +1,141 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package network
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/crypto"
+)
+
+func TestReputation(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "reputation")
+	require.NoError(err)
+	defer func() {
+		require.NoError(os.RemoveAll(dir))
+	}()
+	cfg := config.Default.Network
+	cfg.PeerBanListPath = filepath.Join(dir, "bans.yaml")
+	r, err := NewReputation(&cfg)
+	require.NoError(err)
+	clk := clock.NewMock()
+	r.clock = clk
+
+	// The score drops on each offense, and recovers a point per interval up to 0
+	id := "peer1"
+	host := "10.0.0.1"
+	require.False(r.Penalize(id, host, OffenseInvalidData))
+	require.False(r.Penalize(id, host, OffenseMalformedMsg))
+	require.Equal(int64(-75), r.Score(id))
+	clk.Add(10*cfg.PeerScoreRecoveryInterval + cfg.PeerScoreRecoveryInterval/2)
+	require.Equal(int64(-65), r.Score(id))
+	clk.Add(cfg.PeerScoreRecoveryInterval / 2)
+	require.Equal(int64(-64), r.Score(id))
+	clk.Add(100 * cfg.PeerScoreRecoveryInterval)
+	require.Equal(int64(0), r.Score(id))
+
+	// The peer is banned once its score drops below the threshold, and so is the host it connects from, which a new
+	// key doesn't get around
+	for i := 0; i < 10; i++ {
+		require.False(r.Penalize(id, host, OffenseRateLimited))
+	}
+	require.False(r.IsBanned(id, host))
+	require.True(r.Penalize(id, host, OffenseRateLimited))
+	require.True(r.IsBanned(id, ""))
+	require.True(r.IsBanned("peer2", host))
+	require.False(r.IsBanned("peer2", "10.0.0.2"))
+	until := clk.Now().Add(cfg.PeerBanDuration)
+	require.Equal(map[string]time.Time{id: until, host: until}, r.Bans())
+
+	// The ban persists across restarts
+	r2, err := NewReputation(&cfg)
+	require.NoError(err)
+	r2.clock = clk
+	require.True(r2.IsBanned(id, ""))
+	require.True(r2.IsBanned("peer2", host))
+	require.False(r2.IsBanned("peer2", "10.0.0.2"))
+
+	// The ban ends after the ban duration, and the peer starts over
+	clk.Add(cfg.PeerBanDuration)
+	require.False(r.IsBanned(id, host))
+	require.Empty(r.Bans())
+	require.Equal(int64(0), r.Score(id))
+	r3, err := NewReputation(&cfg)
+	require.NoError(err)
+	require.Empty(r3.bans)
+	require.Empty(r3.hostBans)
+
+	// A nil reputation bans no one
+	var nilReputation *Reputation
+	require.False(nilReputation.Penalize(id, host, OffenseInvalidData))
+	require.False(nilReputation.IsBanned(id, host))
+	require.Empty(nilReputation.Bans())
+}
+
+func TestReputationScoreCacheSize(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default.Network
+	cfg.PeerScoreCacheSize = 2
+	r, err := NewReputation(&cfg)
+	require.NoError(err)
+
+	// Only the scores of the most recently penalized peers are kept, and looking up a score doesn't keep one
+	require.False(r.Penalize("peer1", "", OffenseMalformedMsg))
+	require.False(r.Penalize("peer2", "", OffenseMalformedMsg))
+	require.Equal(int64(0), r.Score("peer4"))
+	require.Equal(2, r.scores.Len())
+	require.False(r.Penalize("peer3", "", OffenseMalformedMsg))
+	require.Equal(2, r.scores.Len())
+	require.Equal(int64(0), r.Score("peer1"))
+	require.Equal(-penalties[OffenseMalformedMsg], r.Score("peer2"))
+	require.Equal(-penalties[OffenseMalformedMsg], r.Score("peer3"))
+}
+
+func TestOverlayPenalizePeer(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default.Network
+	cfg.PeerBanThreshold = -1
+	o := NewOverlay(&cfg)
+	addr := "127.0.0.1:10001"
+	peer := NewTCPPeer(addr)
+	require.NoError(peer.Connect(&cfg, nil, ""))
+	o.PM.Peers.Store(addr, peer)
+
+	// The peer which hasn't authenticated at the address is not penalized
+	o.PenalizePeer(addr, OffenseMalformedMsg)
+	_, ok := o.PM.Peers.Load(addr)
+	require.True(ok)
+	require.Empty(o.BannedPeers())
+
+	// The banned peer is disconnected, and is not added back
+	pk, _, err := crypto.EC283.NewKeyPair()
+	require.NoError(err)
+	require.NoError(o.Identity.pin(addr, pk))
+	o.PenalizePeer(addr, OffenseMalformedMsg)
+	_, ok = o.PM.Peers.Load(addr)
+	require.False(ok)
+	require.True(o.Reputation.IsBanned(peerID(pk), ""))
+	require.Contains(o.BannedPeers(), addr)
+	o.PM.AddPeer(addr)
+	_, ok = o.PM.Peers.Load(addr)
+	require.False(ok)
+
+	// Another address of the banned host is refused as well
+	require.True(o.IsPeerBanned("127.0.0.1:10002"))
+}
@@ -7,22 +7,30 @@
 package network
 
 import (
+	"encoding/hex"
 	"fmt"
 	"net"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 
 	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/network/nat"
 	"github.com/iotexproject/iotex-core/network/node"
 	pb "github.com/iotexproject/iotex-core/network/proto"
-	"github.com/iotexproject/iotex-core/pkg/counter"
+	"github.com/iotexproject/iotex-core/network/trace"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/proto"
 )
@@ -50,54 +58,146 @@ type RPCServer struct {
 	Server  *grpc.Server
 	Overlay *IotxOverlay
 
-	listenPort  string
-	counters    *sync.Map
-	rateLimit   uint64
+	listenAddr  string
+	external    *ExternalAddr
+	mapper      *portMapper
+	limiter     *RateLimiter
+	sessions    *sync.Map
 	lastReqTime time.Time
 }
 
 // NewRPCServer creates an instance of RPCServer
 func NewRPCServer(o *IotxOverlay) *RPCServer {
-	listenPort := ":" + strconv.Itoa(o.Config.Port)
+	listenAddr := net.JoinHostPort(o.Config.Host, strconv.Itoa(o.Config.Port))
+	limiter, err := NewRateLimiter(o.Config)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Fail to load rate limits")
+	}
 	return &RPCServer{
 		Overlay: o,
 		Node: node.Node{
-			Addr: o.Config.Host + listenPort,
+			Addr: listenAddr,
 		},
 
-		listenPort: listenPort,
-		rateLimit:  o.Config.RateLimitPerSec * uint64(o.Config.RateLimitWindowSize) / uint64(time.Second),
-		counters:   &sync.Map{},
+		listenAddr: listenAddr,
+		external:   NewExternalAddr(o.Config),
+		limiter:    limiter,
+		sessions:   &sync.Map{},
+	}
+}
+
+// Handshake implements the server side RPC logic, which checks the hello of the client, and proves the identity of
+// the node by signing the nonce of the client
+func (s *RPCServer) Handshake(ctx context.Context, req *pb.HandshakeReq) (*pb.HandshakeRes, error) {
+	err := s.checkRateLimit(ctx, iproto.UnknownProtoMsgType, proto.Size(req))
+	s.updateLastResTime()
+	if err != nil {
+		return nil, err
+	}
+	sRequestMtc.WithLabelValues("Handshake", "false").Inc()
+
+	id := s.Overlay.Identity
+	if id == nil {
+		return nil, status.Error(codes.Unimplemented, "node has no identity")
+	}
+	if err := id.checkCompatibility(req.Hello); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	pubKey, err := keypair.BytesToPublicKey(req.Hello.PubKey)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if s.Overlay.Reputation.IsBanned(peerID(pubKey), s.clientHost(ctx)) {
+		return nil, ErrPeerBanned
+	}
+	remote, err := s.getClientAddr(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hello, err := id.hello(s.String())
+	if err != nil {
+		return nil, err
+	}
+	s.sessions.Store(remote, &session{hello: req.Hello, nonce: hello.Nonce})
+	return &pb.HandshakeRes{Hello: hello, Signature: id.sign(hello, req.Hello.Nonce)}, nil
+}
+
+// Authenticate implements the server side RPC logic, which binds the connection to the address of the client once it
+// proves the possession of its key by signing the nonce of the node
+func (s *RPCServer) Authenticate(ctx context.Context, req *pb.AuthReq) (*pb.AuthRes, error) {
+	remote, err := s.getClientAddr(ctx)
+	if err != nil {
+		return nil, err
 	}
+	value, ok := s.sessions.Load(remote)
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "handshake is not started")
+	}
+	sess := value.(*session)
+	pubKey, err := s.Overlay.Identity.verifyHandshake(sess.hello, sess.nonce, req.Signature)
+	if err != nil {
+		s.sessions.Delete(remote)
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	s.sessions.Store(remote, &session{hello: sess.hello, nonce: sess.nonce, authenticated: true, pubKey: pubKey})
+	logger.Debug().Str("src", sess.hello.Addr).Str("conn", remote).Msg("Peer is authenticated")
+	return &pb.AuthRes{}, nil
 }
 
 // Ping implements the server side RPC logic
 func (s *RPCServer) Ping(ctx context.Context, ping *pb.Ping) (*pb.Pong, error) {
-	drop, err := s.shouldDropRequest(ctx)
+	err := s.checkRateLimit(ctx, iproto.UnknownProtoMsgType, proto.Size(ping))
 	s.updateLastResTime()
 	if err != nil {
+		s.penalizeRateLimited(ctx, err)
 		return nil, err
 	}
-	if drop {
-		return nil, fmt.Errorf("sended requests too frequently")
+	id, err := s.authenticate(ctx, ping.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if s.Overlay.Reputation.IsBanned(id, s.clientHost(ctx)) {
+		return nil, ErrPeerBanned
 	}
 	sRequestMtc.WithLabelValues("Ping", "false").Inc()
+	// Dial the address back, which pins the key served at it
 	s.Overlay.PM.AddPeer(ping.Addr)
-	return &pb.Pong{AckNonce: ping.Nonce}, nil
+	pubKey, _ := s.Overlay.Identity.peerKey(ping.Addr)
+	s.Overlay.AddrBook.Add(ping.Addr, pubKey)
+	pong := &pb.Pong{AckNonce: ping.Nonce}
+	// Tell the peer the host it connects from, so that it could learn its public address behind a NAT
+	if remote, err := s.getClientAddr(ctx); err == nil {
+		pong.ObservedHost, _, _ = net.SplitHostPort(remote)
+	}
+	return pong, nil
 }
 
 // GetPeers implements the server side RPC logic
 func (s *RPCServer) GetPeers(ctx context.Context, req *pb.GetPeersReq) (*pb.GetPeersRes, error) {
-	drop, err := s.shouldDropRequest(ctx)
+	err := s.checkRateLimit(ctx, iproto.UnknownProtoMsgType, proto.Size(req))
 	s.updateLastResTime()
 	if err != nil {
 		return nil, err
 	}
-	if drop {
-		return nil, fmt.Errorf("sended requests too frequently")
+	if _, err := s.authenticate(ctx, ""); err != nil {
+		return nil, err
 	}
 	sRequestMtc.WithLabelValues("GetPeers", "false").Inc()
 
+	if len(req.Target) > 0 {
+		if len(req.Target) != NodeIDLength {
+			return nil, fmt.Errorf("invalid target length %d", len(req.Target))
+		}
+		var target NodeID
+		copy(target[:], req.Target)
+		res := &pb.GetPeersRes{}
+		for _, node := range s.Overlay.AddrBook.Closest(target, int(req.Count)) {
+			pubKey := node.PubKey
+			res.Addr = append(res.Addr, node.Addr)
+			res.PubKey = append(res.PubKey, pubKey[:])
+		}
+		return res, nil
+	}
 	var addrs []string
 	s.Overlay.PM.Peers.Range(func(key, value interface{}) bool {
 		addrs = append(addrs, value.(*Peer).String())
@@ -115,13 +215,18 @@ func (s *RPCServer) GetPeers(ctx context.Context, req *pb.GetPeersReq) (*pb.GetP
 
 // Broadcast implements the server side RPC logic
 func (s *RPCServer) Broadcast(ctx context.Context, req *pb.BroadcastReq) (*pb.BroadcastRes, error) {
-	drop, err := s.shouldDropRequest(ctx)
+	err := s.checkRateLimit(ctx, req.MsgType, proto.Size(req))
 	s.updateLastResTime()
 	if err != nil {
+		s.penalizeRateLimited(ctx, err)
 		return nil, err
 	}
-	if drop {
-		return nil, fmt.Errorf("sended requests too frequently")
+	id, err := s.authenticate(ctx, req.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if s.Overlay.Reputation.IsBanned(id, s.clientHost(ctx)) {
+		return nil, ErrPeerBanned
 	}
 	sRequestMtc.WithLabelValues("Broadcast", "false").Inc()
 
@@ -134,18 +239,34 @@ func (s *RPCServer) Broadcast(ctx context.Context, req *pb.BroadcastReq) (*pb.Br
 
 // Tell implements the server side RPC logic
 func (s *RPCServer) Tell(ctx context.Context, req *pb.TellReq) (*pb.TellRes, error) {
-	drop, err := s.shouldDropRequest(ctx)
+	err := s.checkRateLimit(ctx, req.MsgType, proto.Size(req))
 	s.updateLastResTime()
 	if err != nil {
+		s.penalizeRateLimited(ctx, err)
 		return nil, err
 	}
-	if drop {
-		return nil, fmt.Errorf("sended requests too frequently")
+	id, err := s.authenticate(ctx, req.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if s.Overlay.Reputation.IsBanned(id, s.clientHost(ctx)) {
+		return nil, ErrPeerBanned
 	}
 	sRequestMtc.WithLabelValues("Tell", "false").Inc()
+	if s.Overlay.Tracer != nil {
+		s.Overlay.trace(trace.Event{
+			Direction: trace.Receive,
+			Kind:      trace.Tell,
+			ChainID:   req.ChainId,
+			MsgType:   req.MsgType,
+			Checksum:  hex.EncodeToString(hash.Hash256b(req.MsgBody)),
+			Peer:      req.Addr,
+		})
+	}
 
 	protoMsg, err := iproto.TypifyProtoMsg(req.MsgType, req.MsgBody)
 	if err != nil {
+		s.Overlay.penalize(id, req.Addr, s.clientHost(ctx), OffenseMalformedMsg)
 		return nil, err
 	}
 	if s.Overlay.Dispatcher != nil {
@@ -154,18 +275,76 @@ func (s *RPCServer) Tell(ctx context.Context, req *pb.TellReq) (*pb.TellRes, err
 	return &pb.TellRes{Header: iproto.MagicBroadcastMsgHeader}, nil
 }
 
+// Announce implements the server side RPC logic
+func (s *RPCServer) Announce(ctx context.Context, req *pb.AnnounceReq) (*pb.AnnounceRes, error) {
+	err := s.checkRateLimit(ctx, req.MsgType, proto.Size(req))
+	s.updateLastResTime()
+	if err != nil {
+		s.penalizeRateLimited(ctx, err)
+		return nil, err
+	}
+	id, err := s.authenticate(ctx, req.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if s.Overlay.Reputation.IsBanned(id, s.clientHost(ctx)) {
+		return nil, ErrPeerBanned
+	}
+	sRequestMtc.WithLabelValues("Announce", "false").Inc()
+
+	s.Overlay.Gossip.OnReceivingAnnouncement(req)
+	return &pb.AnnounceRes{Header: iproto.MagicBroadcastMsgHeader}, nil
+}
+
+// Pull implements the server side RPC logic
+func (s *RPCServer) Pull(ctx context.Context, req *pb.PullReq) (*pb.PullRes, error) {
+	err := s.checkRateLimit(ctx, iproto.UnknownProtoMsgType, proto.Size(req))
+	s.updateLastResTime()
+	if err != nil {
+		s.penalizeRateLimited(ctx, err)
+		return nil, err
+	}
+	id, err := s.authenticate(ctx, req.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if s.Overlay.Reputation.IsBanned(id, s.clientHost(ctx)) {
+		return nil, ErrPeerBanned
+	}
+	sRequestMtc.WithLabelValues("Pull", "false").Inc()
+
+	body, ok := s.Overlay.Gossip.OnReceivingPull(req)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "message is not found")
+	}
+	return &pb.PullRes{MsgBody: body}, nil
+}
+
 // Start starts the rpc server
 func (s *RPCServer) Start(_ context.Context) error {
-	lis, err := net.Listen(s.Network(), s.listenPort)
+	lis, err := net.Listen(s.Network(), s.listenAddr)
 	if err != nil {
 		logger.Error().Err(err).Msg("Node failed to listen")
 		return err
 	}
 
-	_, port, err := net.SplitHostPort(lis.Addr().String())
-	if err == nil {
-		s.listenPort = ":" + port
-		s.Addr = s.Overlay.Config.Host + s.listenPort
+	port := lis.Addr().(*net.TCPAddr).Port
+	s.listenAddr = net.JoinHostPort(s.Overlay.Config.Host, strconv.Itoa(port))
+	s.Addr = s.listenAddr
+	s.external.setBoundPort(port)
+	if cfg := s.Overlay.Config; cfg.NATTraversal != "" {
+		extPort := cfg.ExternalPort
+		if extPort == 0 {
+			extPort = port
+		}
+		s.mapper = newPortMapper(
+			func() (nat.NAT, error) { return nat.New(cfg.NATTraversal, cfg.NATGateway) },
+			s.external,
+			port,
+			extPort,
+			cfg.NATMappingLifetime,
+		)
+		s.mapper.start()
 	}
 	// Create the gRPC server with the credentials
 	if s.Overlay.Config.TLSEnabled {
@@ -177,12 +356,14 @@ func (s *RPCServer) Start(_ context.Context) error {
 			grpc.Creds(creds),
 			grpc.KeepaliveEnforcementPolicy(s.Overlay.Config.KLPolicy),
 			grpc.KeepaliveParams(s.Overlay.Config.KLServerParams),
-			grpc.MaxRecvMsgSize(s.Overlay.Config.MaxMsgSize))
+			grpc.MaxRecvMsgSize(s.Overlay.Config.MaxMsgSize),
+			grpc.StatsHandler(&sessionHandler{s: s}))
 	} else {
 		s.Server = grpc.NewServer(
 			grpc.KeepaliveEnforcementPolicy(s.Overlay.Config.KLPolicy),
 			grpc.KeepaliveParams(s.Overlay.Config.KLServerParams),
-			grpc.MaxRecvMsgSize(1024*1024*10))
+			grpc.MaxRecvMsgSize(1024*1024*10),
+			grpc.StatsHandler(&sessionHandler{s: s}))
 	}
 
 	pb.RegisterPeerServer(s.Server, s)
@@ -190,7 +371,7 @@ func (s *RPCServer) Start(_ context.Context) error {
 	reflection.Register(s.Server)
 	started := make(chan bool)
 	go func(started chan bool) {
-		logger.Info().Msgf("start RPC server on %s", s.String())
+		logger.Info().Msgf("start RPC server on %s, advertised as %s", s.listenAddr, s.String())
 		started <- true
 		if err := s.Server.Serve(lis); err != nil {
 			logger.Fatal().Err(err).Msg("Node failed to serve")
@@ -206,30 +387,52 @@ func (s *RPCServer) Stop(_ context.Context) error {
 	if s.Server != nil {
 		s.Server.Stop()
 	}
+	if s.mapper != nil {
+		s.mapper.close()
+	}
 	return nil
 }
 
+// String returns the external address of the node, which is advertised to the other nodes
+func (s *RPCServer) String() string {
+	return s.external.String()
+}
+
+// ExternalAddr returns the tracker of the external address of the node
+func (s *RPCServer) ExternalAddr() *ExternalAddr {
+	return s.external
+}
+
 // LastReqTime returns the timestamp of the last accepted request
 func (s *RPCServer) LastReqTime() time.Time {
 	return s.lastReqTime
 }
 
-func (s *RPCServer) shouldDropRequest(ctx context.Context) (bool, error) {
+// RateLimiter returns the rate limiter of the requests, whose limits could be changed at runtime
+func (s *RPCServer) RateLimiter() *RateLimiter {
+	return s.limiter
+}
+
+// checkRateLimit checks the request of the message type and the size against the rate limits of the host which it
+// comes from, and of the peer authenticated over the connection if any, so that the peer can't reset its limits by
+// reconnecting, nor by generating new keys. The error is caused by ErrRateLimited if the request should be dropped.
+func (s *RPCServer) checkRateLimit(ctx context.Context, msgType uint32, size int) error {
 	if !s.Overlay.Config.RateLimitEnabled {
-		return false, nil
+		return nil
 	}
-	addr, err := s.getClientAddr(ctx)
+	remote, err := s.getClientAddr(ctx)
 	if err != nil {
-		return false, err
+		return err
 	}
-	c, _ := s.counters.LoadOrStore(
-		addr,
-		counter.NewSlidingWindowCounterWithSecondSlot(s.Overlay.Config.RateLimitWindowSize))
-	c.(*counter.SlidingWindowCounter).Increment()
-	if c.(*counter.SlidingWindowCounter).Count() > s.rateLimit {
-		return true, nil
+	host, _, err := net.SplitHostPort(remote)
+	if err != nil {
+		return err
 	}
-	return false, nil
+	peers := []string{host}
+	if sess, ok := s.authenticatedSession(ctx); ok {
+		peers = append(peers, peerID(sess.pubKey))
+	}
+	return s.limiter.Allow(peers, msgType, size)
 }
 
 func (s *RPCServer) getClientAddr(ctx context.Context) (string, error) {
@@ -243,6 +446,19 @@ func (s *RPCServer) getClientAddr(ctx context.Context) (string, error) {
 	return p.Addr.String(), nil
 }
 
+// clientHost returns the host of the client which the request comes from, or an empty string if it's unknown
+func (s *RPCServer) clientHost(ctx context.Context) string {
+	remote, err := s.getClientAddr(ctx)
+	if err != nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(remote)
+	if err != nil {
+		return ""
+	}
+	return host
+}
+
 // Update the last time when successfully getting an req from the peer
 func (s *RPCServer) updateLastResTime() {
 	s.lastReqTime = time.Now()
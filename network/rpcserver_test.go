@@ -18,6 +18,8 @@ import (
 	"golang.org/x/net/context"
 
 	pb "github.com/iotexproject/iotex-core/network/proto"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/proto"
 	"github.com/iotexproject/iotex-core/test/mock/mock_dispatcher"
 )
@@ -32,7 +34,7 @@ func TestRpcPingPong(t *testing.T) {
 	err := s.Start(ctx)
 	assert.NoError(t, err)
 	p := NewPeer(s.Network(), s.String())
-	err = p.Connect(config)
+	err = p.Connect(config, nil, "")
 	assert.NoError(t, err)
 
 	defer func() {
@@ -46,6 +48,7 @@ func TestRpcPingPong(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, pong)
 	assert.Equal(t, uint64(4689), pong.AckNonce)
+	assert.Equal(t, "127.0.0.1", pong.ObservedHost)
 	value, ok := o.PM.Peers.Load("127.0.0.1:10001")
 	assert.True(t, ok)
 	assert.NotNil(t, value)
@@ -64,7 +67,7 @@ func TestGetPeers(t *testing.T) {
 	err := s.Start(ctx)
 	assert.NoError(t, err)
 	p := NewPeer(s.Network(), s.String())
-	err = p.Connect(config)
+	err = p.Connect(config, nil, "")
 	assert.NoError(t, err)
 
 	defer func() {
@@ -96,6 +99,22 @@ func TestGetPeers(t *testing.T) {
 	assert.True(t, res.Addr[1] == "127.0.0.1:10001" || res.Addr[1] == "127.0.0.1:10002")
 	assert.False(t, res.Addr[0] == res.Addr[1])
 
+	// The known addresses closest to the target are returned if it is set
+	book, err := NewAddrBook(s.String(), testNodeKey(s.String()), 16, "")
+	assert.NoError(t, err)
+	book.Add("127.0.0.1:10003", testNodeKey("127.0.0.1:10003"))
+	book.Add("127.0.0.1:10004", testNodeKey("127.0.0.1:10004"))
+	book.Add("127.0.0.1:10005", keypair.ZeroPublicKey)
+	o.AddrBook = book
+	target := testNodeID("127.0.0.1:10004")
+	res, err = p.GetPeers(&pb.GetPeersReq{Count: 1, Target: target[:]})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.1:10004"}, res.Addr)
+	pubKey := testNodeKey("127.0.0.1:10004")
+	assert.Equal(t, [][]byte{pubKey[:]}, res.PubKey)
+	assert.Equal(t, []NodeAddr{{Addr: "127.0.0.1:10004", PubKey: pubKey}}, nodeAddrs(res))
+	_, err = p.GetPeers(&pb.GetPeersReq{Count: 1, Target: []byte{1}})
+	assert.Error(t, err)
 }
 
 func TestBroadcast(t *testing.T) {
@@ -109,7 +128,7 @@ func TestBroadcast(t *testing.T) {
 	err := s.Start(ctx)
 	assert.NoError(t, err)
 	p := NewPeer(s.Network(), s.String())
-	err = p.Connect(config)
+	err = p.Connect(config, nil, "")
 	assert.NoError(t, err)
 
 	defer func() {
@@ -122,11 +141,26 @@ func TestBroadcast(t *testing.T) {
 	assert.NoError(t, err)
 
 	b, _ := proto.Marshal(&iproto.ActionPb{})
-	res, err := p.BroadcastMsg(
-		&pb.BroadcastReq{Header: iproto.MagicBroadcastMsgHeader, MsgType: iproto.MsgActionType, MsgBody: b})
+	res, err := p.BroadcastMsg(&pb.BroadcastReq{
+		Header:      iproto.MagicBroadcastMsgHeader,
+		MsgType:     iproto.MsgActionType,
+		MsgBody:     b,
+		MsgChecksum: hash.Hash256b(b),
+	})
 	assert.Nil(t, err)
 	assert.NotNil(t, res)
 	assert.Equal(t, iproto.MagicBroadcastMsgHeader, res.Header)
+
+	// The message whose checksum doesn't match its body is rejected, and is not remembered
+	forged := hash.Hash256b([]byte("forged"))
+	_, err = p.BroadcastMsg(&pb.BroadcastReq{
+		Header:      iproto.MagicBroadcastMsgHeader,
+		MsgType:     iproto.MsgActionType,
+		MsgBody:     b,
+		MsgChecksum: forged,
+	})
+	assert.Error(t, err)
+	assert.False(t, o.Gossip.Seen.Contains(string(forged)))
 }
 
 func TestRPCTell(t *testing.T) {
@@ -142,7 +176,7 @@ func TestRPCTell(t *testing.T) {
 	err := s.Start(ctx)
 	assert.NoError(t, err)
 	p := NewPeer(s.Network(), s.String())
-	err = p.Connect(config)
+	err = p.Connect(config, nil, "")
 	assert.NoError(t, err)
 
 	defer func() {
@@ -171,15 +205,15 @@ func TestRateLimit(t *testing.T) {
 
 	config := LoadTestConfig("", true)
 	config.RateLimitEnabled = true
-	config.RateLimitPerSec = 5
-	config.RateLimitWindowSize = time.Second
+	config.RateLimit.Requests.Rate = 1
+	config.RateLimit.Requests.Burst = 5
 	o := &IotxOverlay{Dispatcher: dp, Config: config}
 	s := NewRPCServer(o)
 	o.RPC = s
 	err := s.Start(ctx)
 	assert.NoError(t, err)
 	p := NewPeer(s.Network(), s.String())
-	err = p.Connect(config)
+	err = p.Connect(config, nil, "")
 	assert.NoError(t, err)
 
 	defer func() {
@@ -208,6 +242,18 @@ func TestRateLimit(t *testing.T) {
 			assert.True(t, strings.Contains(err.Error(), "sended requests too frequently"))
 		}
 	}
+
+	// The limits are kept across the reconnections
+	p2 := NewPeer(s.Network(), s.String())
+	assert.NoError(t, p2.Connect(config, nil, ""))
+	b, _ := proto.Marshal(&iproto.ActionPb{})
+	_, err = p2.Tell(&pb.TellReq{Header: iproto.MagicBroadcastMsgHeader,
+		Addr:    s.String(),
+		MsgType: iproto.MsgActionType,
+		MsgBody: b})
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "sended requests too frequently"))
+	assert.NoError(t, p2.Close())
 }
 
 func TestSecureRpcPingPong(t *testing.T) {
@@ -224,7 +270,7 @@ func TestSecureRpcPingPong(t *testing.T) {
 	err := s.Start(ctx)
 	assert.NoError(t, err)
 	p := NewPeer(s.Network(), s.String())
-	err = p.Connect(config)
+	err = p.Connect(config, nil, "")
 	assert.NoError(t, err)
 
 	defer func() {
@@ -260,7 +306,7 @@ func TestKeepaliveParams(t *testing.T) {
 	err := s.Start(ctx)
 	require.Nil(t, err)
 	p := NewPeer(s.Network(), s.String())
-	err = p.Connect(config)
+	err = p.Connect(config, nil, "")
 	assert.NoError(t, err)
 
 	defer func() {
@@ -0,0 +1,208 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package trace
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Hop is a node which a broadcast message reaches
+type Hop struct {
+	Node string `json:"node"`
+	// Parent is the peer which the node first receives the message from
+	Parent string `json:"parent"`
+	// Depth is the number of the hops from the origin, which is 0 for the origin
+	Depth int `json:"depth"`
+	// Latency is how long the message takes to reach the node since the origin sends it
+	Latency time.Duration `json:"latencyNs"`
+}
+
+// Tree is how a broadcast message propagates in the cluster
+type Tree struct {
+	Checksum string `json:"checksum"`
+	ChainID  uint32 `json:"chainId"`
+	MsgType  uint32 `json:"msgType"`
+	// Origin is the node which sends the message without receiving it, or empty if its trace is missing
+	Origin string    `json:"origin"`
+	Start  time.Time `json:"start"`
+	// Hops are the nodes which receive the message, ordered by the latency
+	Hops []Hop `json:"hops"`
+	// Duplicates is the number of the times that the nodes receive the message again
+	Duplicates int           `json:"duplicates"`
+	MaxLatency time.Duration `json:"maxLatencyNs"`
+}
+
+// Graph is the undirected graph of the nodes and the peers that they exchange the messages with
+type Graph struct {
+	Nodes []string    `json:"nodes"`
+	Edges [][2]string `json:"edges"`
+}
+
+// Trees reconstructs the propagation trees of the broadcast messages of the type, or all the types if it is 0, ordered
+// by the time that they start
+func Trees(events []Event, msgType uint32) []*Tree {
+	byChecksum := make(map[string][]Event)
+	for _, e := range events {
+		if e.Kind == Tell || (msgType != 0 && e.MsgType != msgType) {
+			continue
+		}
+		byChecksum[e.Checksum] = append(byChecksum[e.Checksum], e)
+	}
+	trees := make([]*Tree, 0, len(byChecksum))
+	for checksum, es := range byChecksum {
+		trees = append(trees, buildTree(checksum, es))
+	}
+	sort.Slice(trees, func(i, j int) bool {
+		if trees[i].Start.Equal(trees[j].Start) {
+			return trees[i].Checksum < trees[j].Checksum
+		}
+		return trees[i].Start.Before(trees[j].Start)
+	})
+	return trees
+}
+
+func buildTree(checksum string, events []Event) *Tree {
+	sort.Slice(events, func(i, j int) bool { return events[i].Timestamp.Before(events[j].Timestamp) })
+	t := &Tree{Checksum: checksum, ChainID: events[0].ChainID, MsgType: events[0].MsgType}
+
+	// The first receipt of each node tells where the message comes from
+	first := make(map[string]Event)
+	for _, e := range events {
+		if e.Direction != Receive {
+			continue
+		}
+		if _, ok := first[e.Node]; ok || e.Duplicate {
+			t.Duplicates++
+			continue
+		}
+		first[e.Node] = e
+	}
+	for _, e := range events {
+		if _, ok := first[e.Node]; e.Direction == Send && !ok {
+			t.Origin = e.Node
+			t.Start = e.Timestamp
+			break
+		}
+	}
+	if t.Origin == "" {
+		t.Start = events[0].Timestamp
+	} else {
+		t.Hops = append(t.Hops, Hop{Node: t.Origin})
+	}
+
+	depths := make(map[string]int)
+	var depth func(node string, seen map[string]bool) int
+	depth = func(node string, seen map[string]bool) int {
+		if d, ok := depths[node]; ok {
+			return d
+		}
+		e, ok := first[node]
+		if !ok || seen[node] {
+			// The node is the origin, or taken as the origin if its trace is missing
+			return 0
+		}
+		seen[node] = true
+		d := depth(e.Peer, seen) + 1
+		depths[node] = d
+		return d
+	}
+	for node, e := range first {
+		latency := e.Timestamp.Sub(t.Start)
+		t.Hops = append(t.Hops, Hop{
+			Node:    node,
+			Parent:  e.Peer,
+			Depth:   depth(node, make(map[string]bool)),
+			Latency: latency,
+		})
+		if latency > t.MaxLatency {
+			t.MaxLatency = latency
+		}
+	}
+	sort.Slice(t.Hops, func(i, j int) bool {
+		if t.Hops[i].Latency == t.Hops[j].Latency {
+			return t.Hops[i].Node < t.Hops[j].Node
+		}
+		return t.Hops[i].Latency < t.Hops[j].Latency
+	})
+	return t
+}
+
+// PeerGraph returns the graph of the nodes and the peers that they have sent messages to or received messages from
+func PeerGraph(events []Event) *Graph {
+	nodes := make(map[string]bool)
+	edges := make(map[[2]string]bool)
+	for _, e := range events {
+		if e.Node == "" || e.Peer == "" {
+			continue
+		}
+		nodes[e.Node] = true
+		nodes[e.Peer] = true
+		edge := [2]string{e.Node, e.Peer}
+		if edge[0] > edge[1] {
+			edge[0], edge[1] = edge[1], edge[0]
+		}
+		edges[edge] = true
+	}
+	g := &Graph{Nodes: make([]string, 0, len(nodes)), Edges: make([][2]string, 0, len(edges))}
+	for node := range nodes {
+		g.Nodes = append(g.Nodes, node)
+	}
+	for edge := range edges {
+		g.Edges = append(g.Edges, edge)
+	}
+	sort.Strings(g.Nodes)
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i][0] == g.Edges[j][0] {
+			return g.Edges[i][1] < g.Edges[j][1]
+		}
+		return g.Edges[i][0] < g.Edges[j][0]
+	})
+	return g
+}
+
+// WriteDOT writes the graph in the DOT language
+func (g *Graph) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "graph peers {"); err != nil {
+		return err
+	}
+	for _, node := range g.Nodes {
+		if _, err := fmt.Fprintf(w, "  %q;\n", node); err != nil {
+			return err
+		}
+	}
+	for _, edge := range g.Edges {
+		if _, err := fmt.Fprintf(w, "  %q -- %q;\n", edge[0], edge[1]); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+// WriteDOT writes the tree in the DOT language, labeling each node with its latency
+func (t *Tree) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "digraph %q {\n", "msg_"+t.Checksum); err != nil {
+		return err
+	}
+	for _, h := range t.Hops {
+		label := fmt.Sprintf("%s\\n%s", h.Node, h.Latency)
+		if _, err := fmt.Fprintf(w, "  %q [label=\"%s\"];\n", h.Node, label); err != nil {
+			return err
+		}
+		if h.Parent == "" {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "  %q -> %q;\n", h.Parent, h.Node); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
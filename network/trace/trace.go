@@ -0,0 +1,149 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package trace records the messages that a node sends and receives, and reconstructs how the messages propagate in a
+// cluster from the traces of its nodes
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Send means the node sends the message to the peer
+	Send = "send"
+	// Receive means the node receives the message from the peer
+	Receive = "receive"
+
+	// Broadcast is the message carried in full by the gossip
+	Broadcast = "broadcast"
+	// Announce is the message announced by its checksum by the gossip
+	Announce = "announce"
+	// Tell is the message sent to a single peer
+	Tell = "tell"
+)
+
+// Event is a message sent or received by a node
+type Event struct {
+	Node      string `json:"node"`
+	Direction string `json:"direction"`
+	Kind      string `json:"kind"`
+	ChainID   uint32 `json:"chainId"`
+	MsgType   uint32 `json:"msgType"`
+	// Checksum is the hex encoded hash of the message body
+	Checksum string `json:"checksum"`
+	Peer     string `json:"peer"`
+	// TTL is the number of the remaining hops of a broadcast message
+	TTL int32 `json:"ttl"`
+	// Duplicate is set if the received message has been seen before
+	Duplicate bool      `json:"duplicate,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Recorder appends the events of a node to the trace file as JSON lines. The events are dropped if it's not started.
+type Recorder struct {
+	node  func() string
+	path  string
+	clock clock.Clock
+	mu    sync.Mutex
+	file  *os.File
+}
+
+// NewRecorder creates an instance of Recorder writing to the file, which tags the events with the address of the node
+func NewRecorder(path string, node func() string) *Recorder {
+	return &Recorder{node: node, path: path, clock: clock.New()}
+}
+
+// Start opens the trace file
+func (r *Recorder) Start(_ context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open trace file %s", r.path)
+	}
+	r.file = file
+	return nil
+}
+
+// Stop closes the trace file
+func (r *Recorder) Stop(_ context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// Record writes the event, setting its node and timestamp if they are empty
+func (r *Recorder) Record(e Event) error {
+	if e.Node == "" {
+		e.Node = r.node()
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = r.clock.Now()
+	}
+	b, err := json.Marshal(&e)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	_, err = r.file.Write(append(b, '\n'))
+	return err
+}
+
+// Read reads the events from the JSON lines
+func Read(reader io.Reader) ([]Event, error) {
+	var events []Event
+	dec := json.NewDecoder(reader)
+	for {
+		var e Event
+		err := dec.Decode(&e)
+		if err == io.EOF {
+			return events, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode trace event")
+		}
+		events = append(events, e)
+	}
+}
+
+// ReadFiles reads the events from the trace files of several nodes
+func ReadFiles(paths ...string) ([]Event, error) {
+	var events []Event
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		es, err := Read(file)
+		file.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read trace file %s", path)
+		}
+		events = append(events, es...)
+	}
+	return events, nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package trace
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "trace")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "trace.jsonl")
+
+	r := NewRecorder(path, func() string { return "127.0.0.1:4689" })
+	clk := clock.NewMock()
+	r.clock = clk
+	// The events are dropped before the recorder is started
+	require.NoError(r.Record(Event{Direction: Send, Kind: Broadcast, Checksum: "aa"}))
+	require.NoError(r.Start(context.Background()))
+	clk.Add(time.Second)
+	require.NoError(r.Record(Event{Direction: Send, Kind: Broadcast, Checksum: "bb", Peer: "127.0.0.1:4690", TTL: 3}))
+	require.NoError(r.Record(Event{Direction: Receive, Kind: Tell, Checksum: "cc", Peer: "127.0.0.1:4690"}))
+	require.NoError(r.Stop(context.Background()))
+
+	events, err := ReadFiles(path)
+	require.NoError(err)
+	require.Equal(2, len(events))
+	require.Equal("127.0.0.1:4689", events[0].Node)
+	require.Equal("bb", events[0].Checksum)
+	require.Equal(int32(3), events[0].TTL)
+	require.True(clk.Now().Equal(events[0].Timestamp))
+	require.Equal(Tell, events[1].Kind)
+
+	_, err = ReadFiles(filepath.Join(dir, "missing.jsonl"))
+	require.Error(err)
+}
+
+func TestTrees(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(1000, 0)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+	events := []Event{
+		// a broadcasts to b and c, b relays to c and d, and c receives it twice
+		{Node: "a", Direction: Send, Kind: Broadcast, MsgType: 2, Checksum: "m1", Peer: "b", Timestamp: at(0)},
+		{Node: "a", Direction: Send, Kind: Broadcast, MsgType: 2, Checksum: "m1", Peer: "c", Timestamp: at(0)},
+		{Node: "b", Direction: Receive, Kind: Broadcast, MsgType: 2, Checksum: "m1", Peer: "a", Timestamp: at(10)},
+		{Node: "c", Direction: Receive, Kind: Broadcast, MsgType: 2, Checksum: "m1", Peer: "a", Timestamp: at(30)},
+		{Node: "b", Direction: Send, Kind: Broadcast, MsgType: 2, Checksum: "m1", Peer: "c", Timestamp: at(11)},
+		{Node: "b", Direction: Send, Kind: Broadcast, MsgType: 2, Checksum: "m1", Peer: "d", Timestamp: at(11)},
+		{Node: "c", Direction: Receive, Kind: Broadcast, MsgType: 2, Checksum: "m1", Peer: "b", Timestamp: at(40),
+			Duplicate: true},
+		{Node: "d", Direction: Receive, Kind: Broadcast, MsgType: 2, Checksum: "m1", Peer: "b", Timestamp: at(20)},
+		// Another message of another type, whose origin has no trace
+		{Node: "b", Direction: Receive, Kind: Announce, MsgType: 1, Checksum: "m2", Peer: "e", Timestamp: at(5)},
+		// The tells are not broadcast
+		{Node: "a", Direction: Send, Kind: Tell, MsgType: 2, Checksum: "m3", Peer: "b", Timestamp: at(1)},
+	}
+
+	trees := Trees(events, 0)
+	require.Equal(2, len(trees))
+	require.Equal("m1", trees[0].Checksum)
+	require.Equal("m2", trees[1].Checksum)
+
+	trees = Trees(events, 2)
+	require.Equal(1, len(trees))
+	tree := trees[0]
+	require.Equal("a", tree.Origin)
+	require.True(start.Equal(tree.Start))
+	require.Equal(1, tree.Duplicates)
+	require.Equal(30*time.Millisecond, tree.MaxLatency)
+	require.Equal([]Hop{
+		{Node: "a"},
+		{Node: "b", Parent: "a", Depth: 1, Latency: 10 * time.Millisecond},
+		{Node: "d", Parent: "b", Depth: 2, Latency: 20 * time.Millisecond},
+		{Node: "c", Parent: "a", Depth: 1, Latency: 30 * time.Millisecond},
+	}, tree.Hops)
+
+	var buf bytes.Buffer
+	require.NoError(tree.WriteDOT(&buf))
+	require.Contains(buf.String(), `"b" -> "d";`)
+	require.Contains(buf.String(), `"d" [label="d\n20ms"];`)
+
+	tree = Trees(events, 1)[0]
+	require.Equal("", tree.Origin)
+	require.Equal([]Hop{{Node: "b", Parent: "e", Depth: 1}}, tree.Hops)
+}
+
+func TestPeerGraph(t *testing.T) {
+	require := require.New(t)
+
+	events := []Event{
+		{Node: "a", Direction: Send, Peer: "b"},
+		{Node: "b", Direction: Receive, Peer: "a"},
+		{Node: "b", Direction: Send, Peer: "c"},
+		{Node: "c", Direction: Send},
+	}
+	g := PeerGraph(events)
+	require.Equal([]string{"a", "b", "c"}, g.Nodes)
+	require.Equal([][2]string{{"a", "b"}, {"b", "c"}}, g.Edges)
+
+	var buf bytes.Buffer
+	require.NoError(g.WriteDOT(&buf))
+	require.Equal("graph peers {\n  \"a\";\n  \"b\";\n  \"c\";\n  \"a\" -- \"b\";\n  \"b\" -- \"c\";\n}\n", buf.String())
+}
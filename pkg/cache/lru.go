@@ -0,0 +1,113 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRU is a thread-safe cache of a bounded size, which evicts the least recently used entry when it is full
+type LRU struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[interface{}]*list.Element
+}
+
+type entry struct {
+	key   interface{}
+	value interface{}
+}
+
+// NewLRU creates an instance of LRU holding at most size entries
+func NewLRU(size int) *LRU {
+	if size <= 0 {
+		size = 1
+	}
+	return &LRU{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[interface{}]*list.Element),
+	}
+}
+
+// Add adds or updates the entry of the key, and marks it as the most recently used
+func (c *LRU) Add(key, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*entry).value = value
+		c.ll.MoveToFront(elem)
+		return
+	}
+	c.add(key, value)
+}
+
+// ContainsOrAdd returns true if the key is in the cache, without updating its recency. Otherwise, it adds the entry,
+// and returns false.
+func (c *LRU) ContainsOrAdd(key, value interface{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.items[key]; ok {
+		return true
+	}
+	c.add(key, value)
+	return false
+}
+
+// Get returns the value of the key, and marks it as the most recently used
+func (c *LRU) Get(key interface{}) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(elem)
+	return elem.Value.(*entry).value, true
+}
+
+// Contains returns true if the key is in the cache, without updating its recency
+func (c *LRU) Contains(key interface{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.items[key]
+	return ok
+}
+
+// Remove removes the entry of the key
+func (c *LRU) Remove(key interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.ll.Remove(elem)
+		delete(c.items, key)
+	}
+}
+
+// Len returns the number of entries in the cache
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
+
+func (c *LRU) add(key, value interface{}) {
+	c.items[key] = c.ll.PushFront(&entry{key: key, value: value})
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*entry).key)
+	}
+}
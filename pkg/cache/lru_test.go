@@ -0,0 +1,49 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU(t *testing.T) {
+	require := require.New(t)
+
+	c := NewLRU(2)
+	require.False(c.ContainsOrAdd("a", 1))
+	require.True(c.ContainsOrAdd("a", 2))
+	value, ok := c.Get("a")
+	require.True(ok)
+	require.Equal(1, value)
+
+	// The least recently used entry is evicted when the cache is full
+	c.Add("b", 2)
+	_, ok = c.Get("a")
+	require.True(ok)
+	c.Add("c", 3)
+	require.Equal(2, c.Len())
+	require.False(c.Contains("b"))
+	require.True(c.Contains("a"))
+	require.True(c.Contains("c"))
+
+	// Contains doesn't update the recency, while Add updates the value
+	require.True(c.Contains("a"))
+	c.Add("c", 4)
+	c.Add("d", 5)
+	require.False(c.Contains("a"))
+	value, ok = c.Get("c")
+	require.True(ok)
+	require.Equal(4, value)
+
+	c.Remove("c")
+	c.Remove("e")
+	require.Equal(1, c.Len())
+	_, ok = c.Get("c")
+	require.False(ok)
+}
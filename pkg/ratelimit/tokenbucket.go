@@ -0,0 +1,106 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/facebookgo/clock"
+)
+
+// TokenBucket limits the rate of the events. The bucket is refilled with rate tokens every second up to its burst,
+// and an event is allowed if there are enough tokens left for it. A bucket whose rate is 0 allows every event.
+type TokenBucket struct {
+	mu     sync.Mutex
+	clock  clock.Clock
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates an instance of TokenBucket, which is full at the beginning. The burst is the rate if it is 0.
+func NewTokenBucket(rate uint64, burst uint64, c clock.Clock) *TokenBucket {
+	if c == nil {
+		c = clock.New()
+	}
+	b := &TokenBucket{clock: c, last: c.Now()}
+	b.setLimit(rate, burst)
+	b.tokens = b.burst
+	return b
+}
+
+// Allow takes n tokens from the bucket, and returns false without taking any if there are not enough tokens
+func (b *TokenBucket) Allow(n uint64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate == 0 {
+		return true
+	}
+	b.refill()
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+// Available returns true if there are enough tokens for n, without taking any
+func (b *TokenBucket) Available(n uint64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate == 0 {
+		return true
+	}
+	b.refill()
+	return b.tokens >= float64(n)
+}
+
+// Full returns true if the bucket has been refilled up to its burst, which is the same as a new bucket
+func (b *TokenBucket) Full() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate == 0 {
+		return true
+	}
+	b.refill()
+	return b.tokens >= b.burst
+}
+
+// SetLimit changes the rate and the burst of the bucket. The tokens left are kept up to the new burst.
+func (b *TokenBucket) SetLimit(rate uint64, burst uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	b.setLimit(rate, burst)
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+func (b *TokenBucket) setLimit(rate uint64, burst uint64) {
+	if burst == 0 {
+		burst = rate
+	}
+	b.rate = float64(rate)
+	b.burst = float64(burst)
+}
+
+// refill adds the tokens accumulated since the last refill
+func (b *TokenBucket) refill() {
+	now := b.clock.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+}
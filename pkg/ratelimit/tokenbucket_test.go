@@ -0,0 +1,50 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket(t *testing.T) {
+	require := require.New(t)
+
+	clk := clock.NewMock()
+	b := NewTokenBucket(2, 4, clk)
+
+	// The bucket is full at the beginning
+	require.True(b.Full())
+	for i := 0; i < 4; i++ {
+		require.True(b.Available(1))
+		require.True(b.Allow(1))
+	}
+	require.False(b.Available(1))
+	require.False(b.Allow(1))
+	require.False(b.Full())
+
+	// The bucket is refilled at the rate up to the burst
+	clk.Add(500 * time.Millisecond)
+	require.True(b.Allow(1))
+	require.False(b.Allow(1))
+	clk.Add(time.Hour)
+	require.True(b.Full())
+	require.False(b.Allow(5))
+	require.True(b.Allow(4))
+	require.False(b.Allow(1))
+
+	// The new limit applies at once
+	b.SetLimit(10, 0)
+	clk.Add(time.Second)
+	require.True(b.Allow(10))
+	require.False(b.Allow(1))
+	b.SetLimit(0, 0)
+	require.True(b.Allow(100))
+}
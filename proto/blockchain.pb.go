@@ -23,22 +23,27 @@ type EndorsePb_EndorsementTopic int32
 const (
 	EndorsePb_PROPOSAL EndorsePb_EndorsementTopic = 0
 	EndorsePb_COMMIT   EndorsePb_EndorsementTopic = 1
+	// the signed timeout of a round, which asks to move to the given round. The block hash is the one that the
+	// endorser has locked on, if any.
+	EndorsePb_ROUND_CHANGE EndorsePb_EndorsementTopic = 2
 )
 
 var EndorsePb_EndorsementTopic_name = map[int32]string{
 	0: "PROPOSAL",
 	1: "COMMIT",
+	2: "ROUND_CHANGE",
 }
 var EndorsePb_EndorsementTopic_value = map[string]int32{
-	"PROPOSAL": 0,
-	"COMMIT":   1,
+	"PROPOSAL":     0,
+	"COMMIT":       1,
+	"ROUND_CHANGE": 2,
 }
 
 func (x EndorsePb_EndorsementTopic) String() string {
 	return proto.EnumName(EndorsePb_EndorsementTopic_name, int32(x))
 }
 func (EndorsePb_EndorsementTopic) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{14, 0}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{17, 0}
 }
 
 type TransferPb struct {
@@ -58,7 +63,7 @@ func (m *TransferPb) Reset()         { *m = TransferPb{} }
 func (m *TransferPb) String() string { return proto.CompactTextString(m) }
 func (*TransferPb) ProtoMessage()    {}
 func (*TransferPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{0}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{0}
 }
 func (m *TransferPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TransferPb.Unmarshal(m, b)
@@ -134,7 +139,7 @@ func (m *VotePb) Reset()         { *m = VotePb{} }
 func (m *VotePb) String() string { return proto.CompactTextString(m) }
 func (*VotePb) ProtoMessage()    {}
 func (*VotePb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{1}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{1}
 }
 func (m *VotePb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VotePb.Unmarshal(m, b)
@@ -197,7 +202,7 @@ func (m *ExecutionPb) Reset()         { *m = ExecutionPb{} }
 func (m *ExecutionPb) String() string { return proto.CompactTextString(m) }
 func (*ExecutionPb) ProtoMessage()    {}
 func (*ExecutionPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{2}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{2}
 }
 func (m *ExecutionPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExecutionPb.Unmarshal(m, b)
@@ -265,7 +270,7 @@ func (m *SecretProposalPb) Reset()         { *m = SecretProposalPb{} }
 func (m *SecretProposalPb) String() string { return proto.CompactTextString(m) }
 func (*SecretProposalPb) ProtoMessage()    {}
 func (*SecretProposalPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{3}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{3}
 }
 func (m *SecretProposalPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SecretProposalPb.Unmarshal(m, b)
@@ -318,7 +323,7 @@ func (m *SecretWitnessPb) Reset()         { *m = SecretWitnessPb{} }
 func (m *SecretWitnessPb) String() string { return proto.CompactTextString(m) }
 func (*SecretWitnessPb) ProtoMessage()    {}
 func (*SecretWitnessPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{4}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{4}
 }
 func (m *SecretWitnessPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SecretWitnessPb.Unmarshal(m, b)
@@ -369,7 +374,7 @@ func (m *LogPb) Reset()         { *m = LogPb{} }
 func (m *LogPb) String() string { return proto.CompactTextString(m) }
 func (*LogPb) ProtoMessage()    {}
 func (*LogPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{5}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{5}
 }
 func (m *LogPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogPb.Unmarshal(m, b)
@@ -454,7 +459,7 @@ func (m *ReceiptPb) Reset()         { *m = ReceiptPb{} }
 func (m *ReceiptPb) String() string { return proto.CompactTextString(m) }
 func (*ReceiptPb) ProtoMessage()    {}
 func (*ReceiptPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{6}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{6}
 }
 func (m *ReceiptPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptPb.Unmarshal(m, b)
@@ -538,7 +543,7 @@ func (m *ActionPb) Reset()         { *m = ActionPb{} }
 func (m *ActionPb) String() string { return proto.CompactTextString(m) }
 func (*ActionPb) ProtoMessage()    {}
 func (*ActionPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{7}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{7}
 }
 func (m *ActionPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ActionPb.Unmarshal(m, b)
@@ -825,7 +830,7 @@ func (m *BlockHeaderPb) Reset()         { *m = BlockHeaderPb{} }
 func (m *BlockHeaderPb) String() string { return proto.CompactTextString(m) }
 func (*BlockHeaderPb) ProtoMessage()    {}
 func (*BlockHeaderPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{8}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{8}
 }
 func (m *BlockHeaderPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockHeaderPb.Unmarshal(m, b)
@@ -946,18 +951,19 @@ func (m *BlockHeaderPb) GetDkgSignature() []byte {
 // block consists of header followed by transactions
 // hash of current block can be computed from header hence not stored
 type BlockPb struct {
-	Header               *BlockHeaderPb `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Actions              []*ActionPb    `protobuf:"bytes,2,rep,name=actions,proto3" json:"actions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Header               *BlockHeaderPb       `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Actions              []*ActionPb          `protobuf:"bytes,2,rep,name=actions,proto3" json:"actions,omitempty"`
+	Certificate          *CommitCertificatePb `protobuf:"bytes,3,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *BlockPb) Reset()         { *m = BlockPb{} }
 func (m *BlockPb) String() string { return proto.CompactTextString(m) }
 func (*BlockPb) ProtoMessage()    {}
 func (*BlockPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{9}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{9}
 }
 func (m *BlockPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockPb.Unmarshal(m, b)
@@ -991,6 +997,101 @@ func (m *BlockPb) GetActions() []*ActionPb {
 	return nil
 }
 
+func (m *BlockPb) GetCertificate() *CommitCertificatePb {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+// commit certificate aggregates the delegates' BLS signature shares on the block hash, which proves the block's finality
+// it is not part of the block hash
+type CommitCertificatePb struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,2,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Endorsers            []string `protobuf:"bytes,3,rep,name=endorsers,proto3" json:"endorsers,omitempty"`
+	DkgIDs               [][]byte `protobuf:"bytes,4,rep,name=dkgIDs,proto3" json:"dkgIDs,omitempty"`
+	DkgPubkeys           [][]byte `protobuf:"bytes,5,rep,name=dkgPubkeys,proto3" json:"dkgPubkeys,omitempty"`
+	AggregateSignature   []byte   `protobuf:"bytes,6,opt,name=aggregateSignature,proto3" json:"aggregateSignature,omitempty"`
+	DkgSigs              [][]byte `protobuf:"bytes,7,rep,name=dkgSigs,proto3" json:"dkgSigs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitCertificatePb) Reset()         { *m = CommitCertificatePb{} }
+func (m *CommitCertificatePb) String() string { return proto.CompactTextString(m) }
+func (*CommitCertificatePb) ProtoMessage()    {}
+func (*CommitCertificatePb) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{10}
+}
+func (m *CommitCertificatePb) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitCertificatePb.Unmarshal(m, b)
+}
+func (m *CommitCertificatePb) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitCertificatePb.Marshal(b, m, deterministic)
+}
+func (dst *CommitCertificatePb) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitCertificatePb.Merge(dst, src)
+}
+func (m *CommitCertificatePb) XXX_Size() int {
+	return xxx_messageInfo_CommitCertificatePb.Size(m)
+}
+func (m *CommitCertificatePb) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitCertificatePb.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitCertificatePb proto.InternalMessageInfo
+
+func (m *CommitCertificatePb) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CommitCertificatePb) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *CommitCertificatePb) GetEndorsers() []string {
+	if m != nil {
+		return m.Endorsers
+	}
+	return nil
+}
+
+func (m *CommitCertificatePb) GetDkgIDs() [][]byte {
+	if m != nil {
+		return m.DkgIDs
+	}
+	return nil
+}
+
+func (m *CommitCertificatePb) GetDkgPubkeys() [][]byte {
+	if m != nil {
+		return m.DkgPubkeys
+	}
+	return nil
+}
+
+func (m *CommitCertificatePb) GetAggregateSignature() []byte {
+	if m != nil {
+		return m.AggregateSignature
+	}
+	return nil
+}
+
+func (m *CommitCertificatePb) GetDkgSigs() [][]byte {
+	if m != nil {
+		return m.DkgSigs
+	}
+	return nil
+}
+
 // index of block raw data file
 type BlockIndex struct {
 	Start                uint64   `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
@@ -1005,7 +1106,7 @@ func (m *BlockIndex) Reset()         { *m = BlockIndex{} }
 func (m *BlockIndex) String() string { return proto.CompactTextString(m) }
 func (*BlockIndex) ProtoMessage()    {}
 func (*BlockIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{10}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{11}
 }
 func (m *BlockIndex) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockIndex.Unmarshal(m, b)
@@ -1047,8 +1148,12 @@ func (m *BlockIndex) GetOffset() []uint32 {
 }
 
 type BlockSync struct {
-	Start                uint64   `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
-	End                  uint64   `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	Start uint64 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   uint64 `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	// asks for the headers only, which are verified before the bodies are downloaded
+	HeadersOnly bool `protobuf:"varint,4,opt,name=headersOnly,proto3" json:"headersOnly,omitempty"`
+	// asks for the state snapshot at the start height, along with the block at it
+	Snapshot             bool     `protobuf:"varint,5,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1058,7 +1163,7 @@ func (m *BlockSync) Reset()         { *m = BlockSync{} }
 func (m *BlockSync) String() string { return proto.CompactTextString(m) }
 func (*BlockSync) ProtoMessage()    {}
 func (*BlockSync) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{11}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{12}
 }
 func (m *BlockSync) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockSync.Unmarshal(m, b)
@@ -1092,20 +1197,40 @@ func (m *BlockSync) GetEnd() uint64 {
 	return 0
 }
 
+func (m *BlockSync) GetHeadersOnly() bool {
+	if m != nil {
+		return m.HeadersOnly
+	}
+	return false
+}
+
+func (m *BlockSync) GetSnapshot() bool {
+	if m != nil {
+		return m.Snapshot
+	}
+	return false
+}
+
 // block container
 // used to send old/existing blocks in block sync
 type BlockContainer struct {
-	Block                *BlockPb `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Block *BlockPb `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	// a batch of consecutive blocks sent in one message
+	Blocks []*BlockPb `protobuf:"bytes,2,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	// a batch of consecutive block headers sent in one message
+	Headers []*BlockHeaderPb `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty"`
+	// the state snapshot at the height of the block
+	Snapshot             *StateSnapshotPb `protobuf:"bytes,4,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *BlockContainer) Reset()         { *m = BlockContainer{} }
 func (m *BlockContainer) String() string { return proto.CompactTextString(m) }
 func (*BlockContainer) ProtoMessage()    {}
 func (*BlockContainer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{12}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{13}
 }
 func (m *BlockContainer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockContainer.Unmarshal(m, b)
@@ -1132,10 +1257,161 @@ func (m *BlockContainer) GetBlock() *BlockPb {
 	return nil
 }
 
+func (m *BlockContainer) GetBlocks() []*BlockPb {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+func (m *BlockContainer) GetHeaders() []*BlockHeaderPb {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *BlockContainer) GetSnapshot() *StateSnapshotPb {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+// state snapshot at a block height, which is imported by the fast sync instead of replaying the blocks before it
+type StateSnapshotPb struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// the serialized states in the account trie keyed by the address hashes
+	Accounts []*StateEntryPb `protobuf:"bytes,2,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	// the contract codes keyed by the code hashes
+	Codes []*StateEntryPb `protobuf:"bytes,3,rep,name=codes,proto3" json:"codes,omitempty"`
+	// the entries in the contract storage tries, which are keyed by the contract address hashes and the storage keys
+	Storages []*StateEntryPb `protobuf:"bytes,4,rep,name=storages,proto3" json:"storages,omitempty"`
+	// the serialized candidate list at the height
+	Candidates           []byte   `protobuf:"bytes,5,opt,name=candidates,proto3" json:"candidates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateSnapshotPb) Reset()         { *m = StateSnapshotPb{} }
+func (m *StateSnapshotPb) String() string { return proto.CompactTextString(m) }
+func (*StateSnapshotPb) ProtoMessage()    {}
+func (*StateSnapshotPb) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{14}
+}
+func (m *StateSnapshotPb) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateSnapshotPb.Unmarshal(m, b)
+}
+func (m *StateSnapshotPb) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateSnapshotPb.Marshal(b, m, deterministic)
+}
+func (dst *StateSnapshotPb) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateSnapshotPb.Merge(dst, src)
+}
+func (m *StateSnapshotPb) XXX_Size() int {
+	return xxx_messageInfo_StateSnapshotPb.Size(m)
+}
+func (m *StateSnapshotPb) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateSnapshotPb.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateSnapshotPb proto.InternalMessageInfo
+
+func (m *StateSnapshotPb) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *StateSnapshotPb) GetAccounts() []*StateEntryPb {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+func (m *StateSnapshotPb) GetCodes() []*StateEntryPb {
+	if m != nil {
+		return m.Codes
+	}
+	return nil
+}
+
+func (m *StateSnapshotPb) GetStorages() []*StateEntryPb {
+	if m != nil {
+		return m.Storages
+	}
+	return nil
+}
+
+func (m *StateSnapshotPb) GetCandidates() []byte {
+	if m != nil {
+		return m.Candidates
+	}
+	return nil
+}
+
+type StateEntryPb struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Address              []byte   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateEntryPb) Reset()         { *m = StateEntryPb{} }
+func (m *StateEntryPb) String() string { return proto.CompactTextString(m) }
+func (*StateEntryPb) ProtoMessage()    {}
+func (*StateEntryPb) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{15}
+}
+func (m *StateEntryPb) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateEntryPb.Unmarshal(m, b)
+}
+func (m *StateEntryPb) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateEntryPb.Marshal(b, m, deterministic)
+}
+func (dst *StateEntryPb) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateEntryPb.Merge(dst, src)
+}
+func (m *StateEntryPb) XXX_Size() int {
+	return xxx_messageInfo_StateEntryPb.Size(m)
+}
+func (m *StateEntryPb) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateEntryPb.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateEntryPb proto.InternalMessageInfo
+
+func (m *StateEntryPb) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *StateEntryPb) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *StateEntryPb) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
 // corresponding to pre-prepare pharse in view change protocol
 type ProposePb struct {
 	Proposer             string   `protobuf:"bytes,1,opt,name=proposer,proto3" json:"proposer,omitempty"`
 	Block                *BlockPb `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	Round                uint32   `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1145,7 +1421,7 @@ func (m *ProposePb) Reset()         { *m = ProposePb{} }
 func (m *ProposePb) String() string { return proto.CompactTextString(m) }
 func (*ProposePb) ProtoMessage()    {}
 func (*ProposePb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{13}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{16}
 }
 func (m *ProposePb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProposePb.Unmarshal(m, b)
@@ -1179,6 +1455,13 @@ func (m *ProposePb) GetBlock() *BlockPb {
 	return nil
 }
 
+func (m *ProposePb) GetRound() uint32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
 // corresponding to prepare and pre-prepare phase in view change protocol
 type EndorsePb struct {
 	Height               uint64                     `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
@@ -1188,6 +1471,12 @@ type EndorsePb struct {
 	EndorserPubKey       []byte                     `protobuf:"bytes,5,opt,name=endorserPubKey,proto3" json:"endorserPubKey,omitempty"`
 	Decision             bool                       `protobuf:"varint,6,opt,name=decision,proto3" json:"decision,omitempty"`
 	Signature            []byte                     `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	DkgID                []byte                     `protobuf:"bytes,8,opt,name=dkgID,proto3" json:"dkgID,omitempty"`
+	DkgPubkey            []byte                     `protobuf:"bytes,9,opt,name=dkgPubkey,proto3" json:"dkgPubkey,omitempty"`
+	DkgSignature         []byte                     `protobuf:"bytes,10,opt,name=dkgSignature,proto3" json:"dkgSignature,omitempty"`
+	Round                uint32                     `protobuf:"varint,11,opt,name=round,proto3" json:"round,omitempty"`
+	LockedRound          uint32                     `protobuf:"varint,12,opt,name=lockedRound,proto3" json:"lockedRound,omitempty"`
+	LockProof            []*EndorsePb               `protobuf:"bytes,13,rep,name=lockProof,proto3" json:"lockProof,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
 	XXX_unrecognized     []byte                     `json:"-"`
 	XXX_sizecache        int32                      `json:"-"`
@@ -1197,7 +1486,7 @@ func (m *EndorsePb) Reset()         { *m = EndorsePb{} }
 func (m *EndorsePb) String() string { return proto.CompactTextString(m) }
 func (*EndorsePb) ProtoMessage()    {}
 func (*EndorsePb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{14}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{17}
 }
 func (m *EndorsePb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EndorsePb.Unmarshal(m, b)
@@ -1266,6 +1555,48 @@ func (m *EndorsePb) GetSignature() []byte {
 	return nil
 }
 
+func (m *EndorsePb) GetDkgID() []byte {
+	if m != nil {
+		return m.DkgID
+	}
+	return nil
+}
+
+func (m *EndorsePb) GetDkgPubkey() []byte {
+	if m != nil {
+		return m.DkgPubkey
+	}
+	return nil
+}
+
+func (m *EndorsePb) GetDkgSignature() []byte {
+	if m != nil {
+		return m.DkgSignature
+	}
+	return nil
+}
+
+func (m *EndorsePb) GetRound() uint32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *EndorsePb) GetLockedRound() uint32 {
+	if m != nil {
+		return m.LockedRound
+	}
+	return 0
+}
+
+func (m *EndorsePb) GetLockProof() []*EndorsePb {
+	if m != nil {
+		return m.LockProof
+	}
+	return nil
+}
+
 // Candidates and list of candidates
 type Candidate struct {
 	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
@@ -1282,7 +1613,7 @@ func (m *Candidate) Reset()         { *m = Candidate{} }
 func (m *Candidate) String() string { return proto.CompactTextString(m) }
 func (*Candidate) ProtoMessage()    {}
 func (*Candidate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{15}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{18}
 }
 func (m *Candidate) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Candidate.Unmarshal(m, b)
@@ -1348,7 +1679,7 @@ func (m *CandidateList) Reset()         { *m = CandidateList{} }
 func (m *CandidateList) String() string { return proto.CompactTextString(m) }
 func (*CandidateList) ProtoMessage()    {}
 func (*CandidateList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{16}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{19}
 }
 func (m *CandidateList) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CandidateList.Unmarshal(m, b)
@@ -1389,7 +1720,7 @@ func (m *TestPayload) Reset()         { *m = TestPayload{} }
 func (m *TestPayload) String() string { return proto.CompactTextString(m) }
 func (*TestPayload) ProtoMessage()    {}
 func (*TestPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_aa550579a5d1fe4d, []int{17}
+	return fileDescriptor_blockchain_c2cb069fd570ac44, []int{20}
 }
 func (m *TestPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TestPayload.Unmarshal(m, b)
@@ -1427,9 +1758,12 @@ func init() {
 	proto.RegisterType((*ActionPb)(nil), "iproto.ActionPb")
 	proto.RegisterType((*BlockHeaderPb)(nil), "iproto.BlockHeaderPb")
 	proto.RegisterType((*BlockPb)(nil), "iproto.BlockPb")
+	proto.RegisterType((*CommitCertificatePb)(nil), "iproto.CommitCertificatePb")
 	proto.RegisterType((*BlockIndex)(nil), "iproto.BlockIndex")
 	proto.RegisterType((*BlockSync)(nil), "iproto.BlockSync")
 	proto.RegisterType((*BlockContainer)(nil), "iproto.BlockContainer")
+	proto.RegisterType((*StateSnapshotPb)(nil), "iproto.StateSnapshotPb")
+	proto.RegisterType((*StateEntryPb)(nil), "iproto.StateEntryPb")
 	proto.RegisterType((*ProposePb)(nil), "iproto.ProposePb")
 	proto.RegisterType((*EndorsePb)(nil), "iproto.EndorsePb")
 	proto.RegisterType((*Candidate)(nil), "iproto.Candidate")
@@ -1438,84 +1772,104 @@ func init() {
 	proto.RegisterEnum("iproto.EndorsePb_EndorsementTopic", EndorsePb_EndorsementTopic_name, EndorsePb_EndorsementTopic_value)
 }
 
-func init() { proto.RegisterFile("blockchain.proto", fileDescriptor_blockchain_aa550579a5d1fe4d) }
-
-var fileDescriptor_blockchain_aa550579a5d1fe4d = []byte{
-	// 1214 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x56, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0xcf, 0xfa, 0xbf, 0x9f, 0x63, 0xd7, 0x0c, 0xa5, 0x2c, 0x15, 0x42, 0x66, 0x55, 0x2a, 0xab,
-	0x82, 0x0a, 0xd2, 0x03, 0xdc, 0x50, 0x93, 0x56, 0x72, 0x44, 0xda, 0xae, 0x26, 0xa1, 0x1c, 0x61,
-	0x76, 0x77, 0xe2, 0xac, 0x62, 0xef, 0x58, 0x33, 0xe3, 0x10, 0x7f, 0x09, 0xee, 0x1c, 0x39, 0xf5,
-	0xc2, 0x07, 0xe0, 0xc2, 0x9d, 0x8f, 0x85, 0xe6, 0xcd, 0xcc, 0xae, 0x77, 0xa1, 0x39, 0x79, 0x7f,
-	0xbf, 0xf7, 0xe6, 0xcd, 0xbc, 0xff, 0x86, 0x69, 0xb2, 0x12, 0xe9, 0x75, 0x7a, 0xc5, 0xf2, 0xe2,
-	0xe9, 0x46, 0x0a, 0x2d, 0x48, 0x2f, 0xc7, 0xdf, 0xe8, 0xaf, 0x00, 0xe0, 0x42, 0xb2, 0x42, 0x5d,
-	0x72, 0x19, 0x27, 0xe4, 0x01, 0xf4, 0xd8, 0x5a, 0x6c, 0x0b, 0x1d, 0x06, 0xb3, 0x60, 0x7e, 0x48,
-	0x1d, 0x32, 0xbc, 0xe2, 0x45, 0xc6, 0x65, 0xd8, 0x9a, 0x05, 0xf3, 0x21, 0x75, 0x88, 0x7c, 0x0a,
-	0x43, 0xc9, 0xd3, 0x7c, 0x93, 0xf3, 0x42, 0x87, 0x6d, 0x14, 0x55, 0x04, 0x09, 0xa1, 0xbf, 0x61,
-	0xbb, 0x95, 0x60, 0x59, 0xd8, 0x41, 0x73, 0x1e, 0x92, 0x08, 0x0e, 0xad, 0x85, 0x78, 0x9b, 0xfc,
-	0xc0, 0x77, 0x61, 0x17, 0xc5, 0x35, 0x8e, 0x7c, 0x06, 0x90, 0xab, 0x13, 0x91, 0x17, 0x09, 0x53,
-	0x3c, 0xec, 0xcd, 0x82, 0xf9, 0x80, 0xee, 0x31, 0xd1, 0x6f, 0x01, 0xf4, 0xde, 0x0a, 0xcd, 0xe3,
-	0xc4, 0x3c, 0x43, 0xe7, 0x6b, 0xae, 0x34, 0x5b, 0x6f, 0xf0, 0xe5, 0x1d, 0x5a, 0x11, 0xc6, 0x90,
-	0xe2, 0xab, 0xcb, 0x78, 0x9b, 0x5c, 0xf3, 0x1d, 0x3a, 0x70, 0x48, 0xf7, 0x18, 0xf3, 0x98, 0x1b,
-	0xa1, 0xb9, 0x7c, 0x9e, 0x65, 0x92, 0x2b, 0xe5, 0xfc, 0xa8, 0x71, 0x5e, 0x87, 0x7b, 0x9d, 0x4e,
-	0xa5, 0xe3, 0xb9, 0xe8, 0xf7, 0x00, 0x46, 0x2f, 0x6f, 0x79, 0xba, 0xd5, 0xb9, 0x28, 0xee, 0x08,
-	0xe6, 0x43, 0x18, 0x70, 0x54, 0x13, 0x3e, 0x9c, 0x25, 0x36, 0xb2, 0x54, 0x14, 0x5a, 0xb2, 0xd4,
-	0xc7, 0xb3, 0xc4, 0xe4, 0x31, 0x4c, 0xbc, 0x9e, 0x0b, 0x9b, 0x8d, 0x6a, 0x83, 0x25, 0x04, 0x3a,
-	0x19, 0xd3, 0xcc, 0x05, 0x15, 0xbf, 0xa3, 0x5f, 0x60, 0x7a, 0xce, 0x53, 0xc9, 0x75, 0x2c, 0xc5,
-	0x46, 0x28, 0xb6, 0xb2, 0xef, 0x73, 0x49, 0x0d, 0xde, 0x9f, 0xd4, 0x56, 0x33, 0xa9, 0x78, 0xca,
-	0x58, 0x0a, 0xdb, 0xb3, 0xf6, 0x7c, 0x4c, 0x1d, 0x8a, 0x4e, 0xe0, 0x9e, 0xbd, 0xe1, 0xa7, 0x5c,
-	0x17, 0x5c, 0xa9, 0x3b, 0x2e, 0x08, 0xa1, 0xff, 0xab, 0x55, 0x0a, 0x5b, 0xb3, 0xb6, 0xa9, 0x0b,
-	0x07, 0xa3, 0xbf, 0x03, 0xe8, 0x9e, 0x89, 0x65, 0x9c, 0x18, 0x1d, 0xe6, 0x62, 0x6d, 0x0f, 0x7b,
-	0x68, 0xac, 0x6a, 0xb1, 0xc9, 0x53, 0x7f, 0xd8, 0xa1, 0xd2, 0xed, 0x76, 0xe5, 0x36, 0x99, 0xc1,
-	0x08, 0x4b, 0xff, 0xf5, 0x76, 0x9d, 0x70, 0x89, 0xf1, 0xea, 0xd0, 0x7d, 0xca, 0xdc, 0xa3, 0x6f,
-	0x8b, 0x05, 0x53, 0x57, 0x2e, 0x5e, 0x1e, 0x9a, 0x30, 0xa0, 0x22, 0xca, 0x7a, 0x28, 0xab, 0x08,
-	0x72, 0x1f, 0xba, 0x79, 0x91, 0xf1, 0xdb, 0xb0, 0x3f, 0x0b, 0xe6, 0x63, 0x6a, 0x41, 0xf4, 0x4f,
-	0x00, 0x43, 0xca, 0x53, 0x9e, 0x6f, 0x74, 0x9c, 0x98, 0xdb, 0x25, 0xd7, 0x5b, 0x59, 0xbc, 0x65,
-	0xab, 0x2d, 0x77, 0x55, 0xb0, 0x4f, 0x61, 0x84, 0x34, 0xd3, 0x5b, 0x85, 0x71, 0xee, 0x50, 0x87,
-	0x8c, 0x2f, 0x57, 0xe6, 0x5a, 0xe7, 0x8b, 0xf9, 0x36, 0xd6, 0x96, 0x4c, 0x9d, 0x88, 0x42, 0x6d,
-	0xd7, 0x3c, 0xf3, 0xbe, 0xec, 0x51, 0x64, 0x0e, 0xf7, 0x7c, 0xb1, 0xf8, 0x3a, 0xed, 0x62, 0xec,
-	0x9a, 0x34, 0xf9, 0x1c, 0x3a, 0x2b, 0xb1, 0x54, 0x61, 0x6f, 0xd6, 0x9e, 0x8f, 0x8e, 0xc6, 0x4f,
-	0xed, 0x34, 0x78, 0x8a, 0xa1, 0xa7, 0x28, 0x8a, 0xfe, 0x6c, 0xc3, 0xe0, 0x79, 0xea, 0x4a, 0x39,
-	0x84, 0xfe, 0x0d, 0x97, 0x2a, 0x17, 0x05, 0x7a, 0x31, 0xa6, 0x1e, 0x9a, 0x38, 0x14, 0xa2, 0x48,
-	0xb9, 0x73, 0xc0, 0x02, 0x53, 0xc6, 0x4b, 0xa6, 0xce, 0xf2, 0x75, 0x6e, 0xcb, 0xb8, 0x43, 0x4b,
-	0xec, 0x64, 0xb1, 0xcc, 0x53, 0xee, 0x0a, 0xb8, 0xc4, 0x26, 0xe6, 0x2a, 0x5f, 0x16, 0x4c, 0x6f,
-	0x25, 0x77, 0xf9, 0xa8, 0x08, 0xf2, 0x35, 0x0c, 0xb4, 0x9b, 0x55, 0x21, 0xcc, 0x82, 0xf9, 0xe8,
-	0x88, 0xf8, 0x97, 0x57, 0x33, 0x6c, 0x71, 0x40, 0x4b, 0x2d, 0xf2, 0x08, 0x3a, 0xa6, 0x45, 0xc3,
-	0x11, 0x6a, 0x4f, 0xbc, 0xb6, 0x1d, 0x1b, 0x8b, 0x03, 0x8a, 0x52, 0xf2, 0x0c, 0x86, 0xdc, 0xf7,
-	0x6d, 0x78, 0x88, 0xaa, 0x1f, 0x7a, 0xd5, 0xbd, 0x86, 0x5e, 0x1c, 0xd0, 0x4a, 0x8f, 0x1c, 0xc3,
-	0x44, 0xd5, 0x3a, 0x2a, 0x1c, 0xe3, 0xc9, 0xd0, 0x9f, 0x6c, 0xf6, 0xdb, 0xe2, 0x80, 0x36, 0x4e,
-	0x90, 0xef, 0x61, 0xac, 0xf6, 0x7b, 0x26, 0x9c, 0xa0, 0x89, 0x8f, 0xeb, 0x26, 0xca, 0x86, 0x5a,
-	0x1c, 0xd0, 0xba, 0xfe, 0xf1, 0x00, 0x7a, 0x0c, 0x73, 0x14, 0xfd, 0xd1, 0x86, 0xf1, 0x31, 0x56,
-	0x27, 0x67, 0x19, 0xce, 0xf2, 0xf7, 0xe7, 0x2c, 0x84, 0x3e, 0xee, 0x82, 0xd3, 0x17, 0x98, 0xb5,
-	0x31, 0xf5, 0xd0, 0xd4, 0xe3, 0x15, 0xcf, 0x97, 0x57, 0x3e, 0x6b, 0x0e, 0xd5, 0x07, 0x6c, 0xa7,
-	0x39, 0x60, 0x1f, 0xc1, 0x78, 0x23, 0xf9, 0xcd, 0x71, 0xd9, 0x2d, 0x36, 0x73, 0x75, 0x12, 0xfb,
-	0xf6, 0x96, 0x0a, 0xa1, 0x5d, 0x33, 0x39, 0x84, 0x39, 0xd7, 0x4c, 0x73, 0x14, 0xf5, 0x5d, 0xce,
-	0x3d, 0x61, 0x7b, 0x08, 0x1b, 0x0a, 0xe5, 0x03, 0xdf, 0x43, 0x25, 0x65, 0xea, 0x49, 0x72, 0xc5,
-	0xe5, 0x0d, 0xcf, 0xc2, 0xa1, 0xad, 0x27, 0x8f, 0xeb, 0xf5, 0x04, 0xcd, 0x7a, 0x7a, 0x00, 0xbd,
-	0x8d, 0x5d, 0x0a, 0x23, 0xfb, 0x22, 0x8b, 0x4c, 0x4d, 0x67, 0xd7, 0xcb, 0xd3, 0x17, 0x58, 0x0b,
-	0x87, 0xd4, 0x02, 0x63, 0x2b, 0xbb, 0x5e, 0xba, 0x2d, 0x32, 0xb6, 0xb6, 0x4a, 0xc2, 0x2c, 0x88,
-	0xec, 0x7a, 0x79, 0x5e, 0x5e, 0x36, 0xb1, 0x1b, 0x6d, 0x9f, 0x8b, 0x32, 0xe8, 0x63, 0x38, 0xe2,
-	0x84, 0x7c, 0x65, 0x02, 0xcd, 0xfc, 0x68, 0x1c, 0x1d, 0x7d, 0xe4, 0x53, 0x5e, 0xcb, 0x21, 0x75,
-	0x4a, 0xe4, 0x09, 0xf4, 0x6d, 0x9e, 0xed, 0xd0, 0x1b, 0x1d, 0x4d, 0xbd, 0xbe, 0x6f, 0x51, 0xea,
-	0x15, 0xa2, 0x33, 0x00, 0x34, 0x72, 0x6a, 0x26, 0x92, 0xf1, 0x45, 0x69, 0x26, 0xb5, 0x5b, 0x8b,
-	0x16, 0x90, 0x29, 0xb4, 0x79, 0x91, 0xb9, 0x9e, 0x35, 0x9f, 0x26, 0x16, 0xe2, 0xf2, 0x52, 0x55,
-	0x63, 0xdd, 0xa2, 0xe8, 0x19, 0x0c, 0xd1, 0xda, 0xf9, 0xae, 0x48, 0x2b, 0x63, 0xad, 0xff, 0x31,
-	0xd6, 0x2e, 0x8d, 0x45, 0xdf, 0xc2, 0x04, 0x0f, 0x9d, 0x88, 0x42, 0xb3, 0xbc, 0xe0, 0x92, 0x7c,
-	0x01, 0x5d, 0x9c, 0x9d, 0xce, 0xdd, 0x7b, 0x35, 0x77, 0xe3, 0x84, 0x5a, 0x69, 0xf4, 0x1a, 0x86,
-	0xb6, 0x39, 0xcc, 0x56, 0x7f, 0x08, 0x83, 0x8d, 0x05, 0x7e, 0x81, 0x94, 0xb8, 0xb2, 0xd7, 0xba,
-	0xd3, 0xde, 0xbb, 0x16, 0x0c, 0x5f, 0x16, 0x99, 0x90, 0x68, 0xb0, 0xaa, 0xee, 0xa0, 0x59, 0xdd,
-	0xd5, 0xa4, 0x6f, 0x35, 0x27, 0xfd, 0x77, 0xd0, 0xc5, 0x0d, 0x83, 0x0e, 0x4e, 0x8e, 0xa2, 0x72,
-	0x32, 0x78, 0xbb, 0xfe, 0x6b, 0xcd, 0x0b, 0x7d, 0x61, 0x34, 0xa9, 0x3d, 0x80, 0x8b, 0xde, 0x8a,
-	0xa4, 0xfb, 0xc3, 0x50, 0x62, 0x5c, 0xe6, 0xee, 0xbb, 0xf6, 0x1f, 0xa8, 0xc1, 0x1a, 0x1b, 0x19,
-	0x4f, 0x73, 0x6c, 0x63, 0xfb, 0x1f, 0xa8, 0xc4, 0xf5, 0xea, 0xee, 0x37, 0xaa, 0x3b, 0xfa, 0x12,
-	0xa6, 0xcd, 0x87, 0x91, 0x43, 0x18, 0xc4, 0xf4, 0x4d, 0xfc, 0xe6, 0xfc, 0xf9, 0xd9, 0xf4, 0x80,
-	0x00, 0xf4, 0x4e, 0xde, 0xbc, 0x7a, 0x75, 0x7a, 0x31, 0x0d, 0xa2, 0x77, 0x01, 0x0c, 0x4f, 0x58,
-	0x91, 0xe5, 0x19, 0xd3, 0xfc, 0x8e, 0xed, 0x7b, 0x1f, 0xba, 0x66, 0x66, 0x2a, 0x17, 0x27, 0x0b,
-	0x5c, 0x27, 0x19, 0x2f, 0xda, 0x65, 0x27, 0x99, 0xd7, 0x3f, 0x86, 0x49, 0x2a, 0x39, 0x33, 0x85,
-	0xb9, 0xb0, 0x91, 0xb7, 0xc3, 0xa3, 0xc1, 0x92, 0x27, 0x30, 0x5d, 0x31, 0xa5, 0x7f, 0xdc, 0x98,
-	0xdb, 0x9d, 0x66, 0x17, 0x35, 0xff, 0xc3, 0x47, 0xc7, 0x30, 0x2e, 0x1f, 0x7a, 0x96, 0x2b, 0x4d,
-	0xbe, 0x01, 0x48, 0x3d, 0x61, 0xde, 0x6b, 0xfa, 0xe3, 0x03, 0x9f, 0xa5, 0x52, 0x95, 0xee, 0x29,
-	0x45, 0x73, 0x18, 0x5d, 0x70, 0xa5, 0x63, 0xf7, 0x77, 0xf4, 0x13, 0x18, 0xac, 0xd5, 0xf2, 0xe7,
-	0x44, 0x64, 0x3b, 0xb7, 0xa5, 0xfb, 0x6b, 0xb5, 0x3c, 0x16, 0xd9, 0x2e, 0xe9, 0xa1, 0x99, 0x67,
-	0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0xa4, 0xe0, 0xa1, 0x30, 0x43, 0x0b, 0x00, 0x00,
+func init() { proto.RegisterFile("blockchain.proto", fileDescriptor_blockchain_c2cb069fd570ac44) }
+
+var fileDescriptor_blockchain_c2cb069fd570ac44 = []byte{
+	// 1526 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9d, 0x57, 0x4b, 0x6f, 0x23, 0x45,
+	0x10, 0xde, 0xf1, 0xdb, 0x65, 0x3b, 0x31, 0xbd, 0x0b, 0x98, 0x87, 0xd0, 0x32, 0x5a, 0x20, 0x42,
+	0x22, 0x40, 0xf6, 0xc2, 0x05, 0x50, 0xe2, 0x8d, 0xc8, 0x8a, 0xec, 0xc6, 0x9a, 0x64, 0x97, 0xe3,
+	0x32, 0x1e, 0x77, 0x9c, 0xd1, 0xda, 0x33, 0x66, 0x7a, 0x1c, 0x92, 0x3f, 0xc1, 0x9d, 0x23, 0xe2,
+	0xc0, 0x85, 0x1f, 0xc0, 0x85, 0x2b, 0xe2, 0x2f, 0xf0, 0x1b, 0xf8, 0x07, 0x9c, 0xa8, 0xaa, 0xee,
+	0x9e, 0x57, 0x1e, 0x48, 0x9c, 0x3c, 0xf5, 0xe8, 0xea, 0xea, 0xaa, 0xaf, 0x1e, 0x86, 0xe1, 0x74,
+	0x11, 0x07, 0x2f, 0x83, 0x33, 0x3f, 0x8c, 0xb6, 0x57, 0x49, 0x9c, 0xc6, 0xa2, 0x15, 0xf2, 0xaf,
+	0xfb, 0x9b, 0x03, 0x70, 0x92, 0xf8, 0x91, 0x3a, 0x95, 0xc9, 0x64, 0x2a, 0x5e, 0x83, 0x96, 0xbf,
+	0x8c, 0xd7, 0x51, 0x3a, 0x72, 0xee, 0x3b, 0x5b, 0x7d, 0xcf, 0x50, 0xc4, 0x57, 0x32, 0x9a, 0xc9,
+	0x64, 0x54, 0x43, 0x7e, 0xd7, 0x33, 0x94, 0x78, 0x1b, 0xba, 0x89, 0x0c, 0xc2, 0x55, 0x28, 0xf1,
+	0x48, 0x9d, 0x45, 0x39, 0x43, 0x8c, 0xa0, 0xbd, 0xf2, 0x2f, 0x17, 0xb1, 0x3f, 0x1b, 0x35, 0xd8,
+	0x9c, 0x25, 0x85, 0x0b, 0x7d, 0x6d, 0x61, 0xb2, 0x9e, 0x7e, 0x2d, 0x2f, 0x47, 0x4d, 0x16, 0x97,
+	0x78, 0xe2, 0x1d, 0x80, 0x50, 0x8d, 0xe3, 0x30, 0x9a, 0xfa, 0x4a, 0x8e, 0x5a, 0xa8, 0xd1, 0xf1,
+	0x0a, 0x1c, 0xf7, 0x07, 0x07, 0x5a, 0xcf, 0xe3, 0x54, 0xa2, 0xdb, 0xe8, 0x46, 0x1a, 0x2e, 0xa5,
+	0x4a, 0xfd, 0xe5, 0x8a, 0x3d, 0x6f, 0x78, 0x39, 0x83, 0x0c, 0x29, 0xb9, 0x38, 0x45, 0xb3, 0x2f,
+	0xf1, 0xaa, 0x1a, 0x5f, 0x55, 0xe0, 0x90, 0x33, 0xe7, 0x68, 0x27, 0xd9, 0x9d, 0xcd, 0x12, 0xa9,
+	0x94, 0x79, 0x47, 0x89, 0x67, 0x75, 0xa4, 0xd5, 0x69, 0xe4, 0x3a, 0x96, 0xe7, 0xfe, 0xe8, 0x40,
+	0x6f, 0xff, 0x42, 0x06, 0xeb, 0x34, 0x8c, 0xa3, 0x5b, 0x82, 0xf9, 0x26, 0x74, 0x24, 0xab, 0xc5,
+	0x36, 0x9c, 0x19, 0x4d, 0xb2, 0x20, 0x8e, 0xd2, 0xc4, 0x0f, 0x6c, 0x3c, 0x33, 0x5a, 0xbc, 0x0f,
+	0x1b, 0x56, 0xcf, 0x84, 0x4d, 0x47, 0xb5, 0xc2, 0x15, 0x02, 0x1a, 0x33, 0x3f, 0xf5, 0x4d, 0x50,
+	0xf9, 0xdb, 0xfd, 0x16, 0x86, 0xc7, 0x32, 0x48, 0x64, 0x3a, 0x49, 0xe2, 0x55, 0xac, 0xfc, 0x85,
+	0xf6, 0xcf, 0x24, 0xd5, 0xb9, 0x39, 0xa9, 0xb5, 0x6a, 0x52, 0xf9, 0x14, 0x59, 0x42, 0xff, 0xea,
+	0x5b, 0x03, 0xcf, 0x50, 0xee, 0x18, 0x36, 0xf5, 0x0d, 0xdf, 0x84, 0x69, 0x84, 0xe1, 0xb8, 0xe5,
+	0x02, 0xc4, 0xc5, 0xf7, 0x5a, 0x09, 0xcd, 0xd7, 0x09, 0x17, 0x86, 0x74, 0x7f, 0x77, 0xa0, 0x79,
+	0x18, 0xcf, 0xf1, 0x2c, 0xea, 0xf8, 0x26, 0xd6, 0xfa, 0xb0, 0x25, 0xc9, 0x6a, 0x1a, 0xaf, 0xc2,
+	0xc0, 0x1e, 0x36, 0x54, 0xf6, 0xec, 0x7a, 0xfe, 0x6c, 0x71, 0x1f, 0x7a, 0x0c, 0xfd, 0xa7, 0xeb,
+	0xe5, 0x14, 0xdd, 0x68, 0x30, 0x34, 0x8a, 0x2c, 0xba, 0x27, 0xbd, 0x88, 0x0e, 0x7c, 0x75, 0x66,
+	0xe2, 0x65, 0x49, 0x0a, 0x03, 0x2b, 0xb2, 0xac, 0xc5, 0xb2, 0x9c, 0x21, 0xee, 0x41, 0x33, 0xc4,
+	0xc7, 0x5c, 0x8c, 0xda, 0x28, 0x19, 0x78, 0x9a, 0x70, 0xff, 0x74, 0xa0, 0xeb, 0xc9, 0x40, 0x86,
+	0xab, 0x14, 0xdf, 0x80, 0xb7, 0x63, 0x3c, 0xd6, 0x49, 0xf4, 0xdc, 0x5f, 0xac, 0xa5, 0x41, 0x41,
+	0x91, 0xc5, 0x11, 0x4a, 0xfd, 0x74, 0xad, 0x38, 0xce, 0x0d, 0xcf, 0x50, 0xf4, 0x96, 0x33, 0xba,
+	0xd6, 0xbc, 0x85, 0xbe, 0xc9, 0xda, 0xdc, 0x47, 0xf8, 0x47, 0x6a, 0xbd, 0x94, 0x33, 0xfb, 0x96,
+	0x02, 0x4b, 0x6c, 0xc1, 0xa6, 0x05, 0x8b, 0xc5, 0x69, 0x93, 0x63, 0x57, 0x65, 0x8b, 0x77, 0xa1,
+	0xb1, 0x88, 0xe7, 0x0a, 0x9f, 0x55, 0xdf, 0xea, 0xed, 0x0c, 0xb6, 0x75, 0x37, 0xd8, 0xe6, 0xd0,
+	0x7b, 0x2c, 0x72, 0x7f, 0xad, 0x43, 0x67, 0x37, 0x30, 0x50, 0xc6, 0x28, 0x9d, 0xcb, 0x44, 0x21,
+	0xc1, 0xaf, 0x18, 0x78, 0x96, 0xa4, 0x38, 0x44, 0x71, 0x14, 0x48, 0xf3, 0x00, 0x4d, 0x10, 0x8c,
+	0xd1, 0xb1, 0xc3, 0x70, 0x19, 0x6a, 0x18, 0x37, 0xbc, 0x8c, 0x36, 0xb2, 0x49, 0x12, 0xe2, 0x21,
+	0x0d, 0xe0, 0x8c, 0xa6, 0x98, 0xab, 0x70, 0x1e, 0x61, 0x0c, 0x12, 0x69, 0xf2, 0x91, 0x33, 0xc4,
+	0x27, 0xd0, 0x49, 0x4d, 0xaf, 0x1a, 0x01, 0x0a, 0x7b, 0x3b, 0xc2, 0x7a, 0x9e, 0xf7, 0xb0, 0x83,
+	0x3b, 0x5e, 0xa6, 0x25, 0x1e, 0x40, 0x83, 0x4a, 0x74, 0xd4, 0x63, 0xed, 0x0d, 0xab, 0xad, 0xdb,
+	0x06, 0x6a, 0xb2, 0x54, 0x3c, 0x84, 0xae, 0xb4, 0x75, 0x3b, 0xea, 0xb3, 0xea, 0x5d, 0xab, 0x5a,
+	0x28, 0x68, 0xd4, 0xcf, 0xf5, 0xc4, 0x1e, 0x6c, 0xa8, 0x52, 0x45, 0x8d, 0x06, 0x7c, 0x72, 0x64,
+	0x4f, 0x56, 0xeb, 0x0d, 0x8f, 0x57, 0x4e, 0x88, 0x2f, 0x61, 0xa0, 0x8a, 0x35, 0x33, 0xda, 0x60,
+	0x13, 0xaf, 0x97, 0x4d, 0x64, 0x05, 0x85, 0x16, 0xca, 0xfa, 0x7b, 0x1d, 0x6c, 0x31, 0x9c, 0x23,
+	0xf7, 0xa7, 0x3a, 0x0c, 0xf6, 0x18, 0x9d, 0xd2, 0x9f, 0x71, 0x2f, 0xbf, 0x39, 0x67, 0x28, 0xe1,
+	0x59, 0xf0, 0xf8, 0x11, 0x67, 0x0d, 0x25, 0x86, 0x24, 0x3c, 0x9e, 0xc9, 0x70, 0x7e, 0x66, 0xb3,
+	0x66, 0xa8, 0x72, 0x83, 0x6d, 0x54, 0x1b, 0xec, 0x03, 0x18, 0xac, 0x12, 0x79, 0xbe, 0x97, 0x55,
+	0x8b, 0xce, 0x5c, 0x99, 0xc9, 0x75, 0x7b, 0xe1, 0xc5, 0x71, 0x6a, 0x8a, 0xc9, 0x50, 0x9c, 0x73,
+	0x44, 0xbd, 0x64, 0x51, 0xdb, 0xe4, 0xdc, 0x32, 0x74, 0x0d, 0x71, 0x41, 0xb1, 0xbc, 0x63, 0x6b,
+	0x28, 0x63, 0x11, 0x9e, 0x10, 0xd3, 0x32, 0x39, 0xc7, 0xa2, 0xe8, 0x6a, 0x3c, 0x59, 0xba, 0x8c,
+	0x27, 0xa8, 0xe2, 0x09, 0x3d, 0x5a, 0xe9, 0xa1, 0xd0, 0xd3, 0x1e, 0x69, 0x8a, 0x30, 0x3d, 0x7b,
+	0x39, 0xc7, 0xe8, 0xf4, 0x99, 0xad, 0x09, 0xb2, 0x85, 0x1f, 0x66, 0x8a, 0x0c, 0xb4, 0xad, 0x8c,
+	0x41, 0x03, 0x02, 0x89, 0xe3, 0xec, 0xb2, 0x0d, 0x3d, 0xd1, 0x8a, 0x3c, 0xf7, 0x67, 0x07, 0xda,
+	0x1c, 0x0f, 0xcc, 0xce, 0x47, 0x14, 0x69, 0xdf, 0xf6, 0xc6, 0xde, 0xce, 0xab, 0x36, 0xe7, 0xa5,
+	0x24, 0x7a, 0x46, 0x49, 0x7c, 0x88, 0xed, 0x90, 0x13, 0xad, 0xbb, 0x5e, 0x6f, 0x67, 0x68, 0xf5,
+	0x6d, 0x8d, 0x7a, 0x56, 0x41, 0x7c, 0x0e, 0xbd, 0x40, 0x26, 0x69, 0x78, 0x1a, 0x06, 0x18, 0x45,
+	0xce, 0x64, 0x6f, 0xe7, 0x2d, 0xab, 0x3f, 0x8e, 0x97, 0x58, 0x85, 0xe3, 0x5c, 0x01, 0x8f, 0x16,
+	0xf5, 0xdd, 0xbf, 0x1d, 0xb8, 0x7b, 0x8d, 0x52, 0x01, 0x1b, 0x4e, 0x15, 0x1b, 0x79, 0x9f, 0xac,
+	0x55, 0xfb, 0x24, 0x4a, 0xb1, 0xe9, 0xc7, 0x09, 0x26, 0x44, 0xf1, 0xc4, 0xc0, 0x61, 0x92, 0x31,
+	0xc8, 0x26, 0x07, 0x97, 0x06, 0x2a, 0xf7, 0x72, 0x4d, 0xd1, 0xc8, 0xce, 0x42, 0x4b, 0x4d, 0x8c,
+	0x64, 0x05, 0x8e, 0xd8, 0x06, 0xe1, 0xcf, 0xe7, 0x89, 0x9c, 0xa3, 0x6b, 0x79, 0xcc, 0x35, 0xae,
+	0xae, 0x91, 0x10, 0xe2, 0x75, 0x26, 0x14, 0x22, 0x8c, 0x27, 0x8e, 0x21, 0xdd, 0x43, 0x00, 0x8e,
+	0xf8, 0x63, 0xea, 0xdf, 0x94, 0x79, 0x84, 0x5e, 0x62, 0x9f, 0xa8, 0x09, 0x31, 0x84, 0x3a, 0xba,
+	0x6c, 0x3a, 0x1c, 0x7d, 0x92, 0xdf, 0xf1, 0xe9, 0xa9, 0xca, 0x87, 0xa0, 0xa6, 0xdc, 0xef, 0xa0,
+	0xcb, 0xd6, 0x8e, 0x2f, 0xa3, 0x20, 0x37, 0x56, 0xbb, 0xc6, 0x58, 0x3d, 0x37, 0x86, 0x10, 0xd7,
+	0x59, 0x56, 0x47, 0xd1, 0x42, 0x0f, 0xf5, 0x8e, 0x57, 0x64, 0x11, 0xc4, 0x55, 0xe4, 0xaf, 0xd4,
+	0x19, 0x56, 0x40, 0x93, 0xc5, 0x19, 0xed, 0xfe, 0xe1, 0xc0, 0x06, 0xdf, 0x89, 0x63, 0x20, 0xc5,
+	0x2a, 0x46, 0xb0, 0xbc, 0x07, 0x4d, 0x4e, 0x80, 0x81, 0xd6, 0x66, 0x09, 0x5a, 0x98, 0x6e, 0x2d,
+	0x15, 0x1f, 0x40, 0x8b, 0x3f, 0x2c, 0xa4, 0xae, 0xe8, 0x19, 0xb1, 0xf8, 0x18, 0xda, 0xc6, 0x1b,
+	0x7e, 0xee, 0x8d, 0x60, 0xb5, 0x5a, 0xd8, 0x50, 0x73, 0x7f, 0x1b, 0x95, 0x96, 0x46, 0x95, 0x7d,
+	0x6c, 0x84, 0x78, 0x26, 0x7f, 0xc8, 0x5f, 0x0e, 0x6e, 0x10, 0x65, 0xe9, 0x8d, 0x98, 0xc3, 0x49,
+	0xe0, 0x07, 0x01, 0x6d, 0x53, 0xd6, 0xf9, 0x7b, 0xa5, 0x0b, 0xf6, 0x71, 0xdc, 0x5d, 0x92, 0x75,
+	0xab, 0x85, 0x05, 0xd4, 0x0c, 0xe2, 0x99, 0xb4, 0x2f, 0xb8, 0x5e, 0x5d, 0xab, 0x90, 0x75, 0x85,
+	0xeb, 0x94, 0x3f, 0x97, 0x1a, 0x97, 0x37, 0x5a, 0xb7, 0x5a, 0x84, 0xd7, 0xc0, 0x8f, 0x66, 0x21,
+	0x2e, 0x1d, 0x52, 0x99, 0xf6, 0x57, 0xe0, 0xb8, 0x13, 0xe8, 0x17, 0x4f, 0x12, 0x08, 0xa8, 0x8b,
+	0xe8, 0x8d, 0xa0, 0x6e, 0x7a, 0xce, 0x39, 0x6f, 0x09, 0xba, 0x82, 0x34, 0x51, 0xdc, 0x82, 0xf4,
+	0x2a, 0x60, 0x49, 0x77, 0x06, 0x5d, 0x3d, 0x46, 0xa8, 0x34, 0x11, 0x1f, 0x2b, 0x4d, 0xd8, 0x55,
+	0x2b, 0xa3, 0x73, 0x30, 0xd4, 0x6e, 0x05, 0x03, 0xde, 0x9f, 0x60, 0xa4, 0x34, 0x30, 0x71, 0x9f,
+	0x61, 0xc2, 0xfd, 0xa7, 0x0e, 0xdd, 0x7d, 0x5d, 0xad, 0xff, 0xbb, 0x03, 0x7c, 0x06, 0x4d, 0xde,
+	0xd0, 0xd8, 0xf2, 0xc6, 0x8e, 0x9b, 0x4d, 0x56, 0x6b, 0xd7, 0x7e, 0x2d, 0x71, 0xbd, 0x3c, 0x21,
+	0x4d, 0x4f, 0x1f, 0xe0, 0x45, 0xd9, 0xb4, 0x0a, 0xb3, 0x70, 0x67, 0x34, 0x2f, 0xc3, 0xe6, 0xbb,
+	0xf4, 0x1f, 0xa2, 0xc2, 0x25, 0x1b, 0x33, 0xdc, 0x5d, 0x79, 0x0c, 0xea, 0xff, 0x10, 0x19, 0x5d,
+	0x9e, 0x0e, 0xed, 0xea, 0x74, 0xc8, 0xa6, 0x40, 0xe7, 0xc6, 0x29, 0xd0, 0xfd, 0xaf, 0x29, 0x00,
+	0x57, 0xa7, 0x40, 0x1e, 0xe9, 0x5e, 0x21, 0xd2, 0xd4, 0x04, 0x28, 0x62, 0x72, 0xe6, 0xb1, 0xac,
+	0xcf, 0xb2, 0x22, 0x0b, 0xab, 0xb0, 0xcb, 0x29, 0x4b, 0xe2, 0xf8, 0x14, 0xe7, 0x0f, 0xc1, 0xf2,
+	0x95, 0x2b, 0xb1, 0xf4, 0x72, 0x1d, 0xf7, 0x0b, 0x18, 0x56, 0x23, 0x2b, 0xfa, 0xd0, 0x99, 0x78,
+	0x47, 0x93, 0xa3, 0xe3, 0xdd, 0xc3, 0xe1, 0x1d, 0x01, 0xd0, 0x1a, 0x1f, 0x3d, 0x79, 0xf2, 0xf8,
+	0x64, 0xe8, 0x20, 0x24, 0xfb, 0xde, 0xd1, 0xb3, 0xa7, 0x8f, 0x5e, 0x8c, 0x0f, 0x76, 0x9f, 0x7e,
+	0xb5, 0x3f, 0xac, 0xb9, 0xbf, 0xe0, 0x32, 0x3b, 0xb6, 0x18, 0xbe, 0x65, 0x21, 0x27, 0xe8, 0xc6,
+	0x84, 0x7b, 0x0b, 0x5d, 0x22, 0xcc, 0x70, 0xa5, 0xc4, 0xd4, 0xb3, 0xe1, 0x4a, 0x09, 0xc1, 0xc4,
+	0xe1, 0x06, 0xe3, 0xd3, 0xa8, 0x3a, 0xd0, 0x60, 0xd2, 0xfb, 0x44, 0x85, 0x8b, 0x05, 0x3b, 0x5c,
+	0xf8, 0x2a, 0x7d, 0xb6, 0xa2, 0xdb, 0x8d, 0x66, 0x93, 0x35, 0xaf, 0xf0, 0xdd, 0x3d, 0x18, 0x64,
+	0x8e, 0x1e, 0x86, 0x2a, 0x15, 0x9f, 0x96, 0xea, 0xd1, 0x29, 0x07, 0x2b, 0x53, 0x2d, 0x95, 0xe8,
+	0x16, 0xf4, 0x4e, 0x70, 0x9f, 0x99, 0x98, 0x7f, 0xa8, 0x6f, 0x40, 0x67, 0xa9, 0xe6, 0x2f, 0xa6,
+	0xf1, 0xcc, 0x96, 0x69, 0x1b, 0xe9, 0x3d, 0x24, 0xa7, 0x2d, 0x36, 0xf3, 0xf0, 0x5f, 0x4a, 0x0a,
+	0x18, 0x28, 0x56, 0x0f, 0x00, 0x00,
 }
@@ -8,6 +8,8 @@ package iproto
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
 
 	"github.com/golang/protobuf/proto"
 )
@@ -38,50 +40,52 @@ const (
 	TestPayloadType uint32 = 10001
 )
 
+var (
+	msgTypes     = make(map[reflect.Type]uint32)
+	msgFactories = make(map[uint32]func() proto.Message)
+)
+
+func init() {
+	RegisterMsgType(MsgBlockProtoMsgType, func() proto.Message { return &BlockPb{} })
+	RegisterMsgType(MsgBlockSyncReqType, func() proto.Message { return &BlockSync{} })
+	RegisterMsgType(MsgBlockSyncDataType, func() proto.Message { return &BlockContainer{} })
+	RegisterMsgType(MsgActionType, func() proto.Message { return &ActionPb{} })
+	RegisterMsgType(MsgProposeProtoMsgType, func() proto.Message { return &ProposePb{} })
+	RegisterMsgType(MsgEndorseProtoMsgType, func() proto.Message { return &EndorsePb{} })
+	RegisterMsgType(TestPayloadType, func() proto.Message { return &TestPayload{} })
+}
+
+// RegisterMsgType registers the message type of the proto message created by newMsg, so that the message could be
+// sent over the network and dispatched to its handler. It is meant to be called in init, and panics if the type or the
+// proto message has already been registered.
+func RegisterMsgType(tp uint32, newMsg func() proto.Message) {
+	if _, ok := msgFactories[tp]; ok {
+		panic(fmt.Sprintf("proto message type %d has already been registered", tp))
+	}
+	t := reflect.TypeOf(newMsg())
+	if registered, ok := msgTypes[t]; ok {
+		panic(fmt.Sprintf("proto message %s has already been registered as type %d", t, registered))
+	}
+	msgTypes[t] = tp
+	msgFactories[tp] = newMsg
+}
+
 // GetTypeFromProtoMsg retrieves the proto message type
 func GetTypeFromProtoMsg(msg proto.Message) (uint32, error) {
-	switch msg.(type) {
-	case *BlockPb:
-		return MsgBlockProtoMsgType, nil
-	case *BlockSync:
-		return MsgBlockSyncReqType, nil
-	case *BlockContainer:
-		return MsgBlockSyncDataType, nil
-	case *ActionPb:
-		return MsgActionType, nil
-	case *TestPayload:
-		return TestPayloadType, nil
-	case *ProposePb:
-		return MsgProposeProtoMsgType, nil
-	case *EndorsePb:
-		return MsgEndorseProtoMsgType, nil
-	default:
+	tp, ok := msgTypes[reflect.TypeOf(msg)]
+	if !ok {
 		return UnknownProtoMsgType, errors.New("UnknownProtoMsgType proto message type")
 	}
+	return tp, nil
 }
 
 // TypifyProtoMsg unmarshal a proto message based on the given MessageType
 func TypifyProtoMsg(tp uint32, msg []byte) (proto.Message, error) {
-	var m proto.Message
-	switch tp {
-	case MsgBlockProtoMsgType:
-		m = &BlockPb{}
-	case MsgProposeProtoMsgType:
-		m = &ProposePb{}
-	case MsgEndorseProtoMsgType:
-		m = &EndorsePb{}
-	case MsgBlockSyncReqType:
-		m = &BlockSync{}
-	case MsgBlockSyncDataType:
-		m = &BlockContainer{}
-	case MsgActionType:
-		m = &ActionPb{}
-	case TestPayloadType:
-		m = &TestPayload{}
-	default:
+	newMsg, ok := msgFactories[tp]
+	if !ok {
 		return nil, errors.New("UnknownProtoMsgType proto message type")
 	}
-
+	m := newMsg()
 	err := proto.Unmarshal(msg, m)
 	if err != nil {
 		return nil, err
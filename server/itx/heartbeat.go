@@ -8,6 +8,7 @@ package itx
 
 import (
 	"encoding/json"
+	"sort"
 	"strconv"
 	"time"
 
@@ -49,25 +50,29 @@ func NewHeartbeatHandler(s *Server) *HeartbeatHandler {
 // Log executes the logging logic
 func (h *HeartbeatHandler) Log() {
 	// Network metrics
-	p2p, ok := h.s.P2P().(*network.IotxOverlay)
-	if !ok {
-		logger.Error().Msg("value is not the instance of IotxOverlay")
-		return
-	}
-	numPeers := network.LenSyncMap(p2p.PM.Peers)
+	numPeers := uint(len(h.s.P2P().GetPeers()))
 	lastOutTime := time.Unix(0, 0)
-	p2p.PM.Peers.Range(func(_, value interface{}) bool {
-		p, ok := value.(*network.Peer)
-		if !ok {
-			logger.Error().Msg("value is not the instance of Peer")
+	lastInTime := time.Unix(0, 0)
+	// The request times are only tracked by the gRPC overlay
+	if p2p, ok := h.s.P2P().(*network.IotxOverlay); ok {
+		p2p.PM.Peers.Range(func(_, value interface{}) bool {
+			p, ok := value.(*network.Peer)
+			if !ok {
+				logger.Error().Msg("value is not the instance of Peer")
+				return true
+			}
+			if p.LastResTime.After(lastOutTime) {
+				lastOutTime = p.LastResTime
+			}
 			return true
-		}
-		if p.LastResTime.After(lastOutTime) {
-			lastOutTime = p.LastResTime
-		}
-		return true
-	})
-	lastInTime := p2p.RPC.LastReqTime()
+		})
+		lastInTime = p2p.RPC.LastReqTime()
+	}
+	var bannedPeers []string
+	for addr := range h.s.P2P().BannedPeers() {
+		bannedPeers = append(bannedPeers, addr)
+	}
+	sort.Strings(bannedPeers)
 
 	// Dispatcher metrics
 	dp, ok := h.s.Dispatcher().(*dispatcher.IotxDispatcher)
@@ -75,22 +80,33 @@ func (h *HeartbeatHandler) Log() {
 		logger.Error().Msg("dispatcher is not the instance of IotxDispatcher")
 		return
 	}
-	numDPEvts := len(*dp.EventChan())
+	numDPEvts := 0
+	for _, num := range dp.PendingEvents() {
+		numDPEvts += num
+	}
 	dpEvtsAudit, err := json.Marshal(dp.EventAudit())
 	if err != nil {
 		logger.Error().Msg("error when serializing the dispatcher event audit map")
 		return
 	}
+	dpDroppedEvtsAudit, err := json.Marshal(dp.DroppedEventAudit())
+	if err != nil {
+		logger.Error().Msg("error when serializing the dispatcher dropped event audit map")
+		return
+	}
 
 	logger.Info().
 		Uint("numPeers", numPeers).
+		Strs("bannedPeers", bannedPeers).
 		Time("lastOut", lastOutTime).
 		Time("lastIn", lastInTime).
 		Int("pendingDispatcherEvents", numDPEvts).
 		Str("pendingDispatcherEventsAudit", string(dpEvtsAudit)).
+		Str("droppedDispatcherEventsAudit", string(dpDroppedEvtsAudit)).
 		Msg("node status")
 
 	heartbeatMtc.WithLabelValues("numPeers", "node").Set(float64(numPeers))
+	heartbeatMtc.WithLabelValues("numBannedPeers", "node").Set(float64(len(bannedPeers)))
 	heartbeatMtc.WithLabelValues("pendingDispatcherEvents", "node").Set(float64(numDPEvts))
 	// chain service
 	for _, c := range h.s.chainservices {
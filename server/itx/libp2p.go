@@ -0,0 +1,23 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+//go:build libp2p
+// +build libp2p
+
+package itx
+
+import (
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/dispatcher"
+	"github.com/iotexproject/iotex-core/network"
+)
+
+// newLibp2pOverlay creates the overlay on libp2p, which dispatches the received messages to the dispatcher
+func newLibp2pOverlay(cfg *config.Config, dispatcher dispatcher.Dispatcher) (network.Overlay, error) {
+	overlay := network.NewLibp2pOverlay(&cfg.Network, cfg.Chain.ID)
+	overlay.AttachDispatcher(dispatcher)
+	return overlay, nil
+}
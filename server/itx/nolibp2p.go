@@ -0,0 +1,24 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+//go:build !libp2p
+// +build !libp2p
+
+package itx
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/dispatcher"
+	"github.com/iotexproject/iotex-core/network"
+)
+
+// newLibp2pOverlay returns an error, because the node is built without the libp2p tag, which the libp2p transport
+// requires since its dependencies are not vendored
+func newLibp2pOverlay(*config.Config, dispatcher.Dispatcher) (network.Overlay, error) {
+	return nil, errors.Errorf("%s transport is not built in, rebuild with -tags libp2p", config.Libp2pTransport)
+}
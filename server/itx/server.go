@@ -36,15 +36,25 @@ func NewInMemTestServer(cfg *config.Config) (*Server, error) {
 }
 
 func newServer(cfg *config.Config, testing bool) (*Server, error) {
-	// create P2P network and BlockSync
-	p2p := network.NewOverlay(&cfg.Network)
-
 	// create dispatcher instance
 	dispatcher, err := dispatcher.NewDispatcher(cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, "fail to create dispatcher")
 	}
-	p2p.AttachDispatcher(dispatcher)
+
+	// create P2P network on the configured transport
+	var p2p network.Overlay
+	switch cfg.Network.Transport {
+	case config.Libp2pTransport:
+		if p2p, err = newLibp2pOverlay(cfg, dispatcher); err != nil {
+			return nil, err
+		}
+	default:
+		overlay := network.NewOverlay(&cfg.Network)
+		overlay.Identity.ChainID = cfg.Chain.ID
+		overlay.AttachDispatcher(dispatcher)
+		p2p = overlay
+	}
 
 	chains := make(map[uint32]*chainservice.ChainService)
 
@@ -60,7 +70,6 @@ func newServer(cfg *config.Config, testing bool) (*Server, error) {
 	}
 
 	chains[cs.ChainID()] = cs
-	dispatcher.AddSubscriber(cs.ChainID(), cs)
 	return &Server{
 		p2p:           p2p,
 		dispatcher:    dispatcher,
@@ -107,7 +116,6 @@ func (s *Server) NewChainService(cfg *config.Config) error {
 		return err
 	}
 	s.chainservices[cs.ChainID()] = cs
-	s.dispatcher.AddSubscriber(cs.ChainID(), cs)
 	return nil
 }
 
@@ -118,7 +126,6 @@ func (s *Server) NewTestingChainService(cfg *config.Config) error {
 		return err
 	}
 	s.chainservices[cs.ChainID()] = cs
-	s.dispatcher.AddSubscriber(cs.ChainID(), cs)
 	return nil
 }
 
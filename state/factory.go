@@ -22,6 +22,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	iproto "github.com/iotexproject/iotex-core/proto"
 	"github.com/iotexproject/iotex-core/trie"
 )
 
@@ -40,6 +41,9 @@ var (
 
 	// ErrFailedToUnmarshalState is the error that the state un-marshaling is failed
 	ErrFailedToUnmarshalState = errors.New("failed to unmarshal state")
+
+	// ErrInvalidSnapshot is the error that the state snapshot doesn't match the root hash
+	ErrInvalidSnapshot = errors.New("invalid state snapshot")
 )
 
 const (
@@ -73,6 +77,9 @@ type (
 		// Candidate pool
 		Candidates() (uint64, []*Candidate)
 		CandidatesByHeight(uint64) ([]*Candidate, error)
+		// Snapshot
+		Snapshot(hash.Hash32B, uint64) (*iproto.StateSnapshotPb, error)
+		LoadSnapshot(hash.Hash32B, *iproto.StateSnapshotPb) error
 	}
 
 	// factory implements StateFactory interface, tracks changes to account/contract and batch-commits to DB
@@ -0,0 +1,187 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package state
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	iproto "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/trie"
+)
+
+// Snapshot returns the snapshot of the state whose account trie has the given root at the given height. The states
+// are copied as they are serialized in the tries, so that the tries rebuilt from the snapshot have the same roots.
+func (sf *factory) Snapshot(root hash.Hash32B, height uint64) (*iproto.StateSnapshotPb, error) {
+	snapshot := &iproto.StateSnapshotPb{Height: height}
+	codes := make(map[hash.Hash32B]bool)
+	if err := trie.Iterate(sf.dao, trie.AccountKVNameSpace, root, func(addr, ss []byte) error {
+		snapshot.Accounts = append(snapshot.Accounts, &iproto.StateEntryPb{Key: addr, Value: ss})
+		state, err := bytesToState(ss)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get state of %x", addr)
+		}
+		if codeHash := byteutil.BytesTo32B(state.CodeHash); len(state.CodeHash) > 0 && !codes[codeHash] {
+			code, err := sf.dao.Get(trie.CodeKVNameSpace, state.CodeHash)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get the code of contract %x", addr)
+			}
+			snapshot.Codes = append(snapshot.Codes, &iproto.StateEntryPb{Key: state.CodeHash, Value: code})
+			codes[codeHash] = true
+		}
+		if state.Root == hash.ZeroHash32B {
+			return nil
+		}
+		return trie.Iterate(sf.dao, trie.ContractKVNameSpace, state.Root, func(key, value []byte) error {
+			snapshot.Storages = append(snapshot.Storages, &iproto.StateEntryPb{Address: addr, Key: key, Value: value})
+			return nil
+		})
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to iterate the state of root %x", root)
+	}
+	candidates, err := sf.dao.Get(trie.CandidateKVNameSpace, byteutil.Uint64ToBytes(height))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get candidates on height %d", height)
+	}
+	snapshot.Candidates = candidates
+	return snapshot, nil
+}
+
+// LoadSnapshot replaces the state with the snapshot, whose account trie should have the given root. The snapshot is
+// verified in memory first, so that the state is left untouched if it doesn't match the root.
+func (sf *factory) LoadSnapshot(root hash.Hash32B, snapshot *iproto.StateSnapshotPb) error {
+	if _, _, err := loadSnapshot(db.NewCachedKVStore(db.NewMemKVStore()), root, snapshot); err != nil {
+		return err
+	}
+	accountTrie, candidates, err := loadSnapshot(sf.dao, root, snapshot)
+	if err != nil {
+		return err
+	}
+	if err := accountTrie.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit the state snapshot")
+	}
+	if sf.cachedCandidates, err = CandidatesToMap(candidates); err != nil {
+		return errors.Wrap(err, "failed to convert candidate list to map of cached candidates")
+	}
+	sf.accountTrie = accountTrie
+	sf.currentChainHeight = snapshot.Height
+	sf.rootHash = root
+	sf.clearCache()
+	sf.run = false
+	return nil
+}
+
+// loadSnapshot rebuilds the tries of the snapshot in the KV store, and checks that the account trie has the given root
+func loadSnapshot(
+	dao db.CachedKVStore,
+	root hash.Hash32B,
+	snapshot *iproto.StateSnapshotPb,
+) (trie.Trie, CandidateList, error) {
+	codes := make(map[hash.Hash32B]bool)
+	for _, code := range snapshot.Codes {
+		codeHash := byteutil.BytesTo32B(code.Key)
+		if byteutil.BytesTo32B(hash.Hash256b(code.Value)) != codeHash {
+			return nil, nil, errors.Wrapf(ErrInvalidSnapshot, "code hash %x doesn't match", code.Key)
+		}
+		if err := dao.Put(trie.CodeKVNameSpace, code.Key, code.Value); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to store code %x", code.Key)
+		}
+		codes[codeHash] = true
+	}
+	storages := make(map[hash.PKHash][]*iproto.StateEntryPb)
+	for _, storage := range snapshot.Storages {
+		addr := byteutil.BytesTo20B(storage.Address)
+		storages[addr] = append(storages[addr], storage)
+	}
+
+	accountTrie, err := trie.NewTrieSharedDB(dao, trie.AccountKVNameSpace, trie.EmptyRoot)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create account trie")
+	}
+	if err := accountTrie.Start(context.Background()); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to start account trie")
+	}
+	states := make(map[hash.PKHash]*State)
+	for _, account := range snapshot.Accounts {
+		addr := byteutil.BytesTo20B(account.Key)
+		state, err := bytesToState(account.Value)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to get state of %x", account.Key)
+		}
+		if len(state.CodeHash) > 0 && !codes[byteutil.BytesTo32B(state.CodeHash)] {
+			return nil, nil, errors.Wrapf(ErrInvalidSnapshot, "code of contract %x is missing", account.Key)
+		}
+		if state.Root != hash.ZeroHash32B {
+			if err := loadStorage(dao, state.Root, storages[addr]); err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to load the storage of contract %x", account.Key)
+			}
+		}
+		if err := accountTrie.Upsert(account.Key, account.Value); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to put state of %x", account.Key)
+		}
+		states[addr] = state
+	}
+	if accountTrie.RootHash() != root {
+		return nil, nil, errors.Wrapf(
+			ErrInvalidSnapshot,
+			"account trie root %x doesn't match %x",
+			accountTrie.RootHash(),
+			root,
+		)
+	}
+
+	// The candidates are not part of the trie, but each of them should be a candidate account
+	candidates, err := Deserialize(snapshot.Candidates)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to deserialize candidates")
+	}
+	for _, candidate := range candidates {
+		pkHash, err := iotxaddress.GetPubkeyHash(candidate.Address)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid candidate address %s", candidate.Address)
+		}
+		if state, ok := states[byteutil.BytesTo20B(pkHash)]; !ok || !state.IsCandidate {
+			return nil, nil, errors.Wrapf(ErrInvalidSnapshot, "%s is not a candidate", candidate.Address)
+		}
+	}
+	height := byteutil.Uint64ToBytes(snapshot.Height)
+	if err := dao.Put(trie.CandidateKVNameSpace, height, snapshot.Candidates); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to store candidates on height %d", snapshot.Height)
+	}
+	if err := dao.Put(trie.AccountKVNameSpace, []byte(AccountTrieRootKey), root[:]); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to store accountTrie's root hash")
+	}
+	if err := dao.Put(trie.AccountKVNameSpace, []byte(CurrentHeightKey), height); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to store accountTrie's current height")
+	}
+	return accountTrie, candidates, nil
+}
+
+// loadStorage rebuilds the storage trie of a contract, and checks that it has the given root
+func loadStorage(dao db.CachedKVStore, root hash.Hash32B, entries []*iproto.StateEntryPb) error {
+	tr, err := trie.NewTrieSharedDB(dao, trie.ContractKVNameSpace, trie.EmptyRoot)
+	if err != nil {
+		return errors.Wrap(err, "failed to create storage trie")
+	}
+	if err := tr.Start(context.Background()); err != nil {
+		return errors.Wrap(err, "failed to start storage trie")
+	}
+	for _, entry := range entries {
+		if err := tr.Upsert(entry.Key, entry.Value); err != nil {
+			return errors.Wrapf(err, "failed to put storage %x", entry.Key)
+		}
+	}
+	if tr.RootHash() != root {
+		return errors.Wrapf(ErrInvalidSnapshot, "storage trie root %x doesn't match %x", tr.RootHash(), root)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package state
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	iproto "github.com/iotexproject/iotex-core/proto"
+)
+
+func TestSnapshot(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	sf, err := NewFactory(&cfg, InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+
+	// a is a candidate, and b is a contract with storage
+	a, _ := iotxaddress.NewAddress(iotxaddress.IsTestnet, iotxaddress.ChainID)
+	b, _ := iotxaddress.NewAddress(iotxaddress.IsTestnet, iotxaddress.ChainID)
+	_, err = sf.LoadOrCreateState(a.RawAddress, 100)
+	require.NoError(err)
+	_, err = sf.LoadOrCreateState(b.RawAddress, 200)
+	require.NoError(err)
+	vote, err := action.NewVote(1, a.RawAddress, a.RawAddress, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	_, err = sf.RunActions(0, nil, []*action.Vote{vote}, nil)
+	require.NoError(err)
+	require.NoError(sf.Commit())
+	contractHash, err := iotxaddress.GetPubkeyHash(b.RawAddress)
+	require.NoError(err)
+	contract := byteutil.BytesTo20B(contractHash)
+	require.NoError(sf.SetCode(contract, []byte("test snapshot")))
+	k := byteutil.BytesTo32B(hash.Hash160b([]byte("cat")))
+	v := byteutil.BytesTo32B(hash.Hash256b([]byte("cat")))
+	require.NoError(sf.SetContractState(contract, k, v))
+	root, err := sf.RunActions(1, nil, nil, nil)
+	require.NoError(err)
+	require.NoError(sf.Commit())
+
+	snapshot, err := sf.Snapshot(root, 1)
+	require.NoError(err)
+	require.Equal(uint64(1), snapshot.Height)
+	require.Len(snapshot.Accounts, 2)
+	require.Len(snapshot.Codes, 1)
+	require.Len(snapshot.Storages, 1)
+
+	// The snapshot is imported into a new factory
+	sf2, err := NewFactory(&cfg, InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf2.Start(ctx))
+	defer func() {
+		require.NoError(sf2.Stop(ctx))
+	}()
+	_, err = sf2.LoadOrCreateState(b.RawAddress, 1)
+	require.NoError(err)
+	_, err = sf2.RunActions(0, nil, nil, nil)
+	require.NoError(err)
+	require.NoError(sf2.Commit())
+	require.NoError(sf2.LoadSnapshot(root, snapshot))
+	require.Equal(root, sf2.RootHash())
+	height, err := sf2.Height()
+	require.NoError(err)
+	require.Equal(uint64(1), height)
+	balance, err := sf2.Balance(b.RawAddress)
+	require.NoError(err)
+	require.Equal(big.NewInt(200), balance)
+	code, err := sf2.GetCode(contract)
+	require.NoError(err)
+	require.Equal([]byte("test snapshot"), code)
+	w, err := sf2.GetContractState(contract, k)
+	require.NoError(err)
+	require.Equal(v, w)
+	candidates, err := sf2.CandidatesByHeight(1)
+	require.NoError(err)
+	require.Len(candidates, 1)
+	require.Equal(a.RawAddress, candidates[0].Address)
+	_, candidates = sf2.Candidates()
+	require.Len(candidates, 1)
+
+	// The tampered snapshot doesn't match the root, and leaves the state untouched
+	tampered := proto.Clone(snapshot).(*iproto.StateSnapshotPb)
+	tampered.Storages[0].Value = []byte("dog")
+	sf3, err := NewFactory(&cfg, InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf3.Start(ctx))
+	defer func() {
+		require.NoError(sf3.Stop(ctx))
+	}()
+	err = sf3.LoadSnapshot(root, tampered)
+	require.Equal(ErrInvalidSnapshot, errors.Cause(err))
+	_, err = sf3.Height()
+	require.Error(err)
+	tampered = proto.Clone(snapshot).(*iproto.StateSnapshotPb)
+	tampered.Accounts = tampered.Accounts[:1]
+	require.Equal(ErrInvalidSnapshot, errors.Cause(sf3.LoadSnapshot(root, tampered)))
+}
@@ -11,6 +11,7 @@ import (
 	action "github.com/iotexproject/iotex-core/blockchain/action"
 	iotxaddress "github.com/iotexproject/iotex-core/iotxaddress"
 	hash "github.com/iotexproject/iotex-core/pkg/hash"
+	proto "github.com/iotexproject/iotex-core/proto"
 	state "github.com/iotexproject/iotex-core/state"
 	big "math/big"
 	reflect "reflect"
@@ -245,6 +246,58 @@ func (mr *MockBlockchainMockRecorder) GetTransfersToAddress(address interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfersToAddress", reflect.TypeOf((*MockBlockchain)(nil).GetTransfersToAddress), address)
 }
 
+// GetTransferCountFromAddress mocks base method
+func (m *MockBlockchain) GetTransferCountFromAddress(address string) (uint64, error) {
+	ret := m.ctrl.Call(m, "GetTransferCountFromAddress", address)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferCountFromAddress indicates an expected call of GetTransferCountFromAddress
+func (mr *MockBlockchainMockRecorder) GetTransferCountFromAddress(address interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferCountFromAddress", reflect.TypeOf((*MockBlockchain)(nil).GetTransferCountFromAddress), address)
+}
+
+// GetTransfersFromAddressByRange mocks base method
+func (m *MockBlockchain) GetTransfersFromAddressByRange(address string, start, count uint64) ([]hash.Hash32B, error) {
+	ret := m.ctrl.Call(m, "GetTransfersFromAddressByRange", address, start, count)
+	ret0, _ := ret[0].([]hash.Hash32B)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfersFromAddressByRange indicates an expected call of GetTransfersFromAddressByRange
+func (mr *MockBlockchainMockRecorder) GetTransfersFromAddressByRange(address, start, count interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfersFromAddressByRange", reflect.TypeOf((*MockBlockchain)(nil).GetTransfersFromAddressByRange), address, start, count)
+}
+
+// GetTransferCountToAddress mocks base method
+func (m *MockBlockchain) GetTransferCountToAddress(address string) (uint64, error) {
+	ret := m.ctrl.Call(m, "GetTransferCountToAddress", address)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferCountToAddress indicates an expected call of GetTransferCountToAddress
+func (mr *MockBlockchainMockRecorder) GetTransferCountToAddress(address interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferCountToAddress", reflect.TypeOf((*MockBlockchain)(nil).GetTransferCountToAddress), address)
+}
+
+// GetTransfersToAddressByRange mocks base method
+func (m *MockBlockchain) GetTransfersToAddressByRange(address string, start, count uint64) ([]hash.Hash32B, error) {
+	ret := m.ctrl.Call(m, "GetTransfersToAddressByRange", address, start, count)
+	ret0, _ := ret[0].([]hash.Hash32B)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfersToAddressByRange indicates an expected call of GetTransfersToAddressByRange
+func (mr *MockBlockchainMockRecorder) GetTransfersToAddressByRange(address, start, count interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfersToAddressByRange", reflect.TypeOf((*MockBlockchain)(nil).GetTransfersToAddressByRange), address, start, count)
+}
+
 // GetTransferByTransferHash mocks base method
 func (m *MockBlockchain) GetTransferByTransferHash(h hash.Hash32B) (*action.Transfer, error) {
 	ret := m.ctrl.Call(m, "GetTransferByTransferHash", h)
@@ -297,6 +350,58 @@ func (mr *MockBlockchainMockRecorder) GetVotesToAddress(address interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotesToAddress", reflect.TypeOf((*MockBlockchain)(nil).GetVotesToAddress), address)
 }
 
+// GetVoteCountFromAddress mocks base method
+func (m *MockBlockchain) GetVoteCountFromAddress(address string) (uint64, error) {
+	ret := m.ctrl.Call(m, "GetVoteCountFromAddress", address)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoteCountFromAddress indicates an expected call of GetVoteCountFromAddress
+func (mr *MockBlockchainMockRecorder) GetVoteCountFromAddress(address interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoteCountFromAddress", reflect.TypeOf((*MockBlockchain)(nil).GetVoteCountFromAddress), address)
+}
+
+// GetVotesFromAddressByRange mocks base method
+func (m *MockBlockchain) GetVotesFromAddressByRange(address string, start, count uint64) ([]hash.Hash32B, error) {
+	ret := m.ctrl.Call(m, "GetVotesFromAddressByRange", address, start, count)
+	ret0, _ := ret[0].([]hash.Hash32B)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVotesFromAddressByRange indicates an expected call of GetVotesFromAddressByRange
+func (mr *MockBlockchainMockRecorder) GetVotesFromAddressByRange(address, start, count interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotesFromAddressByRange", reflect.TypeOf((*MockBlockchain)(nil).GetVotesFromAddressByRange), address, start, count)
+}
+
+// GetVoteCountToAddress mocks base method
+func (m *MockBlockchain) GetVoteCountToAddress(address string) (uint64, error) {
+	ret := m.ctrl.Call(m, "GetVoteCountToAddress", address)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoteCountToAddress indicates an expected call of GetVoteCountToAddress
+func (mr *MockBlockchainMockRecorder) GetVoteCountToAddress(address interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoteCountToAddress", reflect.TypeOf((*MockBlockchain)(nil).GetVoteCountToAddress), address)
+}
+
+// GetVotesToAddressByRange mocks base method
+func (m *MockBlockchain) GetVotesToAddressByRange(address string, start, count uint64) ([]hash.Hash32B, error) {
+	ret := m.ctrl.Call(m, "GetVotesToAddressByRange", address, start, count)
+	ret0, _ := ret[0].([]hash.Hash32B)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVotesToAddressByRange indicates an expected call of GetVotesToAddressByRange
+func (mr *MockBlockchainMockRecorder) GetVotesToAddressByRange(address, start, count interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotesToAddressByRange", reflect.TypeOf((*MockBlockchain)(nil).GetVotesToAddressByRange), address, start, count)
+}
+
 // GetVoteByVoteHash mocks base method
 func (m *MockBlockchain) GetVoteByVoteHash(h hash.Hash32B) (*action.Vote, error) {
 	ret := m.ctrl.Call(m, "GetVoteByVoteHash", h)
@@ -349,6 +454,58 @@ func (mr *MockBlockchainMockRecorder) GetExecutionsToAddress(address interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionsToAddress", reflect.TypeOf((*MockBlockchain)(nil).GetExecutionsToAddress), address)
 }
 
+// GetExecutionCountFromAddress mocks base method
+func (m *MockBlockchain) GetExecutionCountFromAddress(address string) (uint64, error) {
+	ret := m.ctrl.Call(m, "GetExecutionCountFromAddress", address)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExecutionCountFromAddress indicates an expected call of GetExecutionCountFromAddress
+func (mr *MockBlockchainMockRecorder) GetExecutionCountFromAddress(address interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionCountFromAddress", reflect.TypeOf((*MockBlockchain)(nil).GetExecutionCountFromAddress), address)
+}
+
+// GetExecutionsFromAddressByRange mocks base method
+func (m *MockBlockchain) GetExecutionsFromAddressByRange(address string, start, count uint64) ([]hash.Hash32B, error) {
+	ret := m.ctrl.Call(m, "GetExecutionsFromAddressByRange", address, start, count)
+	ret0, _ := ret[0].([]hash.Hash32B)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExecutionsFromAddressByRange indicates an expected call of GetExecutionsFromAddressByRange
+func (mr *MockBlockchainMockRecorder) GetExecutionsFromAddressByRange(address, start, count interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionsFromAddressByRange", reflect.TypeOf((*MockBlockchain)(nil).GetExecutionsFromAddressByRange), address, start, count)
+}
+
+// GetExecutionCountToAddress mocks base method
+func (m *MockBlockchain) GetExecutionCountToAddress(address string) (uint64, error) {
+	ret := m.ctrl.Call(m, "GetExecutionCountToAddress", address)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExecutionCountToAddress indicates an expected call of GetExecutionCountToAddress
+func (mr *MockBlockchainMockRecorder) GetExecutionCountToAddress(address interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionCountToAddress", reflect.TypeOf((*MockBlockchain)(nil).GetExecutionCountToAddress), address)
+}
+
+// GetExecutionsToAddressByRange mocks base method
+func (m *MockBlockchain) GetExecutionsToAddressByRange(address string, start, count uint64) ([]hash.Hash32B, error) {
+	ret := m.ctrl.Call(m, "GetExecutionsToAddressByRange", address, start, count)
+	ret0, _ := ret[0].([]hash.Hash32B)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExecutionsToAddressByRange indicates an expected call of GetExecutionsToAddressByRange
+func (mr *MockBlockchainMockRecorder) GetExecutionsToAddressByRange(address, start, count interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionsToAddressByRange", reflect.TypeOf((*MockBlockchain)(nil).GetExecutionsToAddressByRange), address, start, count)
+}
+
 // GetExecutionByExecutionHash mocks base method
 func (m *MockBlockchain) GetExecutionByExecutionHash(h hash.Hash32B) (*action.Execution, error) {
 	ret := m.ctrl.Call(m, "GetExecutionByExecutionHash", h)
@@ -524,6 +681,32 @@ func (mr *MockBlockchainMockRecorder) ValidateBlock(blk, containCoinbase interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBlock", reflect.TypeOf((*MockBlockchain)(nil).ValidateBlock), blk, containCoinbase)
 }
 
+// StateSnapshot mocks base method
+func (m *MockBlockchain) StateSnapshot(height uint64) (*blockchain.Block, *proto.StateSnapshotPb, error) {
+	ret := m.ctrl.Call(m, "StateSnapshot", height)
+	ret0, _ := ret[0].(*blockchain.Block)
+	ret1, _ := ret[1].(*proto.StateSnapshotPb)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StateSnapshot indicates an expected call of StateSnapshot
+func (mr *MockBlockchainMockRecorder) StateSnapshot(height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSnapshot", reflect.TypeOf((*MockBlockchain)(nil).StateSnapshot), height)
+}
+
+// ImportStateSnapshot mocks base method
+func (m *MockBlockchain) ImportStateSnapshot(blk *blockchain.Block, snapshot *proto.StateSnapshotPb) error {
+	ret := m.ctrl.Call(m, "ImportStateSnapshot", blk, snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportStateSnapshot indicates an expected call of ImportStateSnapshot
+func (mr *MockBlockchainMockRecorder) ImportStateSnapshot(blk, snapshot interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportStateSnapshot", reflect.TypeOf((*MockBlockchain)(nil).ImportStateSnapshot), blk, snapshot)
+}
+
 // Validator mocks base method
 func (m *MockBlockchain) Validator() blockchain.Validator {
 	ret := m.ctrl.Call(m, "Validator")
@@ -547,9 +730,9 @@ func (mr *MockBlockchainMockRecorder) SetValidator(val interface{}) *gomock.Call
 }
 
 // ExecuteContractRead mocks base method
-func (m *MockBlockchain) ExecuteContractRead(arg0 *action.Execution) ([]byte, error) {
+func (m *MockBlockchain) ExecuteContractRead(arg0 *action.Execution) (*blockchain.Receipt, error) {
 	ret := m.ctrl.Call(m, "ExecuteContractRead", arg0)
-	ret0, _ := ret[0].([]byte)
+	ret0, _ := ret[0].(*blockchain.Receipt)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -97,13 +97,37 @@ func (mr *MockBlockSyncMockRecorder) ProcessBlock(blk interface{}) *gomock.Call
 }
 
 // ProcessBlockSync mocks base method
-func (m *MockBlockSync) ProcessBlockSync(blk *blockchain.Block) error {
-	ret := m.ctrl.Call(m, "ProcessBlockSync", blk)
+func (m *MockBlockSync) ProcessBlockSync(sender string, blk *blockchain.Block) error {
+	ret := m.ctrl.Call(m, "ProcessBlockSync", sender, blk)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ProcessBlockSync indicates an expected call of ProcessBlockSync
-func (mr *MockBlockSyncMockRecorder) ProcessBlockSync(blk interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlockSync", reflect.TypeOf((*MockBlockSync)(nil).ProcessBlockSync), blk)
+func (mr *MockBlockSyncMockRecorder) ProcessBlockSync(sender, blk interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlockSync", reflect.TypeOf((*MockBlockSync)(nil).ProcessBlockSync), sender, blk)
+}
+
+// ProcessBlockHeaders mocks base method
+func (m *MockBlockSync) ProcessBlockHeaders(sender string, headers []*proto.BlockHeaderPb) error {
+	ret := m.ctrl.Call(m, "ProcessBlockHeaders", sender, headers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessBlockHeaders indicates an expected call of ProcessBlockHeaders
+func (mr *MockBlockSyncMockRecorder) ProcessBlockHeaders(sender, headers interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlockHeaders", reflect.TypeOf((*MockBlockSync)(nil).ProcessBlockHeaders), sender, headers)
+}
+
+// ProcessStateSnapshot mocks base method
+func (m *MockBlockSync) ProcessStateSnapshot(sender string, data *proto.BlockContainer) error {
+	ret := m.ctrl.Call(m, "ProcessStateSnapshot", sender, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessStateSnapshot indicates an expected call of ProcessStateSnapshot
+func (mr *MockBlockSyncMockRecorder) ProcessStateSnapshot(sender, data interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessStateSnapshot", reflect.TypeOf((*MockBlockSync)(nil).ProcessStateSnapshot), sender, data)
 }
@@ -7,6 +7,7 @@ package mock_consensus
 import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
+	blockchain "github.com/iotexproject/iotex-core/blockchain"
 	scheme "github.com/iotexproject/iotex-core/consensus/scheme"
 	proto "github.com/iotexproject/iotex-core/proto"
 	reflect "reflect"
@@ -95,3 +96,26 @@ func (m *MockConsensus) Metrics() (scheme.ConsensusMetrics, error) {
 func (mr *MockConsensusMockRecorder) Metrics() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metrics", reflect.TypeOf((*MockConsensus)(nil).Metrics))
 }
+
+// Productivity mocks base method
+func (m *MockConsensus) Productivity(epochNum uint64) (map[string]scheme.DelegateProductivity, error) {
+	ret := m.ctrl.Call(m, "Productivity", epochNum)
+	ret0, _ := ret[0].(map[string]scheme.DelegateProductivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Productivity indicates an expected call of Productivity
+func (mr *MockConsensusMockRecorder) Productivity(epochNum interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Productivity", reflect.TypeOf((*MockConsensus)(nil).Productivity), epochNum)
+}
+
+// HandleCommittedBlock mocks base method
+func (m *MockConsensus) HandleCommittedBlock(blk *blockchain.Block) {
+	m.ctrl.Call(m, "HandleCommittedBlock", blk)
+}
+
+// HandleCommittedBlock indicates an expected call of HandleCommittedBlock
+func (mr *MockConsensusMockRecorder) HandleCommittedBlock(blk interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCommittedBlock", reflect.TypeOf((*MockConsensus)(nil).HandleCommittedBlock), blk)
+}
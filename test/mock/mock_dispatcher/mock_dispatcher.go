@@ -9,106 +9,10 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	proto "github.com/golang/protobuf/proto"
 	dispatcher "github.com/iotexproject/iotex-core/dispatcher"
-	proto0 "github.com/iotexproject/iotex-core/proto"
 	net "net"
 	reflect "reflect"
 )
 
-// MockSubscriber is a mock of Subscriber interface
-type MockSubscriber struct {
-	ctrl     *gomock.Controller
-	recorder *MockSubscriberMockRecorder
-}
-
-// MockSubscriberMockRecorder is the mock recorder for MockSubscriber
-type MockSubscriberMockRecorder struct {
-	mock *MockSubscriber
-}
-
-// NewMockSubscriber creates a new mock instance
-func NewMockSubscriber(ctrl *gomock.Controller) *MockSubscriber {
-	mock := &MockSubscriber{ctrl: ctrl}
-	mock.recorder = &MockSubscriberMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use
-func (m *MockSubscriber) EXPECT() *MockSubscriberMockRecorder {
-	return m.recorder
-}
-
-// HandleAction mocks base method
-func (m *MockSubscriber) HandleAction(arg0 *proto0.ActionPb) error {
-	ret := m.ctrl.Call(m, "HandleAction", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// HandleAction indicates an expected call of HandleAction
-func (mr *MockSubscriberMockRecorder) HandleAction(arg0 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleAction", reflect.TypeOf((*MockSubscriber)(nil).HandleAction), arg0)
-}
-
-// HandleBlock mocks base method
-func (m *MockSubscriber) HandleBlock(arg0 *proto0.BlockPb) error {
-	ret := m.ctrl.Call(m, "HandleBlock", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// HandleBlock indicates an expected call of HandleBlock
-func (mr *MockSubscriberMockRecorder) HandleBlock(arg0 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlock", reflect.TypeOf((*MockSubscriber)(nil).HandleBlock), arg0)
-}
-
-// HandleBlockSync mocks base method
-func (m *MockSubscriber) HandleBlockSync(arg0 *proto0.BlockPb) error {
-	ret := m.ctrl.Call(m, "HandleBlockSync", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// HandleBlockSync indicates an expected call of HandleBlockSync
-func (mr *MockSubscriberMockRecorder) HandleBlockSync(arg0 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockSync", reflect.TypeOf((*MockSubscriber)(nil).HandleBlockSync), arg0)
-}
-
-// HandleSyncRequest mocks base method
-func (m *MockSubscriber) HandleSyncRequest(arg0 string, arg1 *proto0.BlockSync) error {
-	ret := m.ctrl.Call(m, "HandleSyncRequest", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// HandleSyncRequest indicates an expected call of HandleSyncRequest
-func (mr *MockSubscriberMockRecorder) HandleSyncRequest(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSyncRequest", reflect.TypeOf((*MockSubscriber)(nil).HandleSyncRequest), arg0, arg1)
-}
-
-// HandleBlockPropose mocks base method
-func (m *MockSubscriber) HandleBlockPropose(arg0 *proto0.ProposePb) error {
-	ret := m.ctrl.Call(m, "HandleBlockPropose", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// HandleBlockPropose indicates an expected call of HandleBlockPropose
-func (mr *MockSubscriberMockRecorder) HandleBlockPropose(arg0 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockPropose", reflect.TypeOf((*MockSubscriber)(nil).HandleBlockPropose), arg0)
-}
-
-// HandleEndorse mocks base method
-func (m *MockSubscriber) HandleEndorse(arg0 *proto0.EndorsePb) error {
-	ret := m.ctrl.Call(m, "HandleEndorse", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// HandleEndorse indicates an expected call of HandleEndorse
-func (mr *MockSubscriberMockRecorder) HandleEndorse(arg0 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEndorse", reflect.TypeOf((*MockSubscriber)(nil).HandleEndorse), arg0)
-}
-
 // MockDispatcher is a mock of Dispatcher interface
 type MockDispatcher struct {
 	ctrl     *gomock.Controller
@@ -156,14 +60,16 @@ func (mr *MockDispatcherMockRecorder) Stop(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockDispatcher)(nil).Stop), arg0)
 }
 
-// AddSubscriber mocks base method
-func (m *MockDispatcher) AddSubscriber(arg0 uint32, arg1 dispatcher.Subscriber) {
-	m.ctrl.Call(m, "AddSubscriber", arg0, arg1)
+// RegisterHandler mocks base method
+func (m *MockDispatcher) RegisterHandler(arg0, arg1 uint32, arg2 dispatcher.Lane, arg3 dispatcher.Handler) error {
+	ret := m.ctrl.Call(m, "RegisterHandler", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// AddSubscriber indicates an expected call of AddSubscriber
-func (mr *MockDispatcherMockRecorder) AddSubscriber(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscriber", reflect.TypeOf((*MockDispatcher)(nil).AddSubscriber), arg0, arg1)
+// RegisterHandler indicates an expected call of RegisterHandler
+func (mr *MockDispatcherMockRecorder) RegisterHandler(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterHandler", reflect.TypeOf((*MockDispatcher)(nil).RegisterHandler), arg0, arg1, arg2, arg3)
 }
 
 // HandleBroadcast mocks base method
@@ -185,3 +91,13 @@ func (m *MockDispatcher) HandleTell(arg0 uint32, arg1 net.Addr, arg2 proto.Messa
 func (mr *MockDispatcherMockRecorder) HandleTell(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleTell", reflect.TypeOf((*MockDispatcher)(nil).HandleTell), arg0, arg1, arg2, arg3)
 }
+
+// SetPeerReporter mocks base method
+func (m *MockDispatcher) SetPeerReporter(arg0 dispatcher.PeerReporter) {
+	m.ctrl.Call(m, "SetPeerReporter", arg0)
+}
+
+// SetPeerReporter indicates an expected call of SetPeerReporter
+func (mr *MockDispatcherMockRecorder) SetPeerReporter(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPeerReporter", reflect.TypeOf((*MockDispatcher)(nil).SetPeerReporter), arg0)
+}
@@ -10,6 +10,7 @@ import (
 	proto "github.com/golang/protobuf/proto"
 	net "net"
 	reflect "reflect"
+	time "time"
 )
 
 // MockOverlay is a mock of Overlay interface
@@ -106,3 +107,15 @@ func (m *MockOverlay) GetPeers() []net.Addr {
 func (mr *MockOverlayMockRecorder) GetPeers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeers", reflect.TypeOf((*MockOverlay)(nil).GetPeers))
 }
+
+// BannedPeers mocks base method
+func (m *MockOverlay) BannedPeers() map[string]time.Time {
+	ret := m.ctrl.Call(m, "BannedPeers")
+	ret0, _ := ret[0].(map[string]time.Time)
+	return ret0
+}
+
+// BannedPeers indicates an expected call of BannedPeers
+func (mr *MockOverlayMockRecorder) BannedPeers() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BannedPeers", reflect.TypeOf((*MockOverlay)(nil).BannedPeers))
+}
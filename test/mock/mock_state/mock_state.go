@@ -9,6 +9,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	action "github.com/iotexproject/iotex-core/blockchain/action"
 	hash "github.com/iotexproject/iotex-core/pkg/hash"
+	proto "github.com/iotexproject/iotex-core/proto"
 	state "github.com/iotexproject/iotex-core/state"
 	big "math/big"
 	reflect "reflect"
@@ -276,3 +277,28 @@ func (m *MockFactory) CandidatesByHeight(arg0 uint64) ([]*state.Candidate, error
 func (mr *MockFactoryMockRecorder) CandidatesByHeight(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CandidatesByHeight", reflect.TypeOf((*MockFactory)(nil).CandidatesByHeight), arg0)
 }
+
+// Snapshot mocks base method
+func (m *MockFactory) Snapshot(arg0 hash.Hash32B, arg1 uint64) (*proto.StateSnapshotPb, error) {
+	ret := m.ctrl.Call(m, "Snapshot", arg0, arg1)
+	ret0, _ := ret[0].(*proto.StateSnapshotPb)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot
+func (mr *MockFactoryMockRecorder) Snapshot(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockFactory)(nil).Snapshot), arg0, arg1)
+}
+
+// LoadSnapshot mocks base method
+func (m *MockFactory) LoadSnapshot(arg0 hash.Hash32B, arg1 *proto.StateSnapshotPb) error {
+	ret := m.ctrl.Call(m, "LoadSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadSnapshot indicates an expected call of LoadSnapshot
+func (mr *MockFactoryMockRecorder) LoadSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSnapshot", reflect.TypeOf((*MockFactory)(nil).LoadSnapshot), arg0, arg1)
+}
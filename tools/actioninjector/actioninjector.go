@@ -373,20 +373,20 @@ func injectTransfer(
 	logger.Info().Msg("Created signed transfer")
 
 	tsf := transfer.ToJSON()
-	request := exp.SendTransferRequest{
+	request := exp.SendTransferRequestV2{
 		Version:      tsf.Version,
 		Nonce:        tsf.Nonce,
 		Sender:       tsf.Sender,
 		Recipient:    tsf.Recipient,
-		Amount:       tsf.Amount,
+		Amount:       transfer.Amount().String(),
 		SenderPubKey: tsf.SenderPubKey,
 		GasLimit:     tsf.GasLimit,
-		GasPrice:     tsf.GasPrice,
+		GasPrice:     transfer.GasPrice().String(),
 		Signature:    tsf.Signature,
 		Payload:      tsf.Payload,
 	}
 	for i := 0; i < retryNum; i++ {
-		if _, err = c.SendTransfer(request); err == nil {
+		if _, err = c.SendTransferV2(request); err == nil {
 			break
 		}
 		time.Sleep(time.Duration(retryInterval) * time.Second)
@@ -435,18 +435,18 @@ func injectVote(
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to inject vote")
 	}
-	request := exp.SendVoteRequest{
+	request := exp.SendVoteRequestV2{
 		Version:     jsonVote.Version,
 		Nonce:       jsonVote.Nonce,
 		Voter:       jsonVote.Voter,
 		Votee:       jsonVote.Votee,
 		VoterPubKey: jsonVote.VoterPubKey,
 		GasLimit:    jsonVote.GasLimit,
-		GasPrice:    jsonVote.GasPrice,
+		GasPrice:    vote.GasPrice().String(),
 		Signature:   jsonVote.Signature,
 	}
 	for i := 0; i < retryNum; i++ {
-		if _, err = c.SendVote(request); err == nil {
+		if _, err = c.SendVoteV2(request); err == nil {
 			break
 		}
 		time.Sleep(time.Duration(retryInterval) * time.Second)
@@ -493,8 +493,20 @@ func injectExecution(
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to inject execution")
 	}
+	request := exp.ExecutionV2{
+		Version:        jsonExecution.Version,
+		Nonce:          jsonExecution.Nonce,
+		Executor:       jsonExecution.Executor,
+		Contract:       jsonExecution.Contract,
+		Amount:         execution.Amount().String(),
+		ExecutorPubKey: jsonExecution.ExecutorPubKey,
+		GasLimit:       jsonExecution.GasLimit,
+		GasPrice:       execution.GasPrice().String(),
+		Data:           jsonExecution.Data,
+		Signature:      jsonExecution.Signature,
+	}
 	for i := 0; i < retryNum; i++ {
-		if _, err = c.SendSmartContract(*jsonExecution); err == nil {
+		if _, err = c.SendSmartContractV2(request); err == nil {
 			break
 		}
 		time.Sleep(time.Duration(retryInterval) * time.Second)
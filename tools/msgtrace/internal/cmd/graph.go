@@ -0,0 +1,54 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/network/trace"
+)
+
+// graphCmd represents the graph command
+var graphCmd = &cobra.Command{
+	Use:   "graph [trace files]",
+	Short: "Emits the peer graph of the nodes.",
+	Long:  `Emits the graph of the nodes and the peers that they exchange the messages with, from the trace files.`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return graph(os.Stdout, args)
+	},
+}
+
+var _graphFormat string
+
+func graph(w io.Writer, paths []string) error {
+	events, err := trace.ReadFiles(paths...)
+	if err != nil {
+		return err
+	}
+	g := trace.PeerGraph(events)
+	switch _graphFormat {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(g)
+	case formatDOT:
+		return g.WriteDOT(w)
+	default:
+		return errors.Errorf("unknown format %s", _graphFormat)
+	}
+}
+
+func init() {
+	graphCmd.Flags().StringVarP(&_graphFormat, "format", "f", formatDOT, "output format, json or dot")
+	rootCmd.AddCommand(graphCmd)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/logger"
+)
+
+const (
+	formatJSON = "json"
+	formatDOT  = "dot"
+)
+
+// rootCmd represents the base command when called without any subcommands
+var rootCmd = &cobra.Command{
+	Use:   "msgtrace [command] [flags]",
+	Short: "Command-line interface for IoTeX message trace analyzer",
+	Long:  "msgtrace is a command-line interface to analyze the message traces recorded by the nodes of a cluster.",
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		logger.Fatal().Err(err).Msg("failed to add cmd")
+	}
+}
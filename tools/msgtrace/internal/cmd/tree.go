@@ -0,0 +1,71 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/network/trace"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+// treeCmd represents the tree command
+var treeCmd = &cobra.Command{
+	Use:   "tree [trace files]",
+	Short: "Reconstructs the propagation trees of the broadcast messages.",
+	Long: `Reconstructs the propagation trees of the broadcast messages from the trace files of the nodes, along with
+the hops and the latency of each node. The messages are blocks by default.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return tree(os.Stdout, args)
+	},
+}
+
+var (
+	_treeMsgType uint32
+	_treeFormat  string
+)
+
+func tree(w io.Writer, paths []string) error {
+	events, err := trace.ReadFiles(paths...)
+	if err != nil {
+		return err
+	}
+	trees := trace.Trees(events, _treeMsgType)
+	switch _treeFormat {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(trees)
+	case formatDOT:
+		for _, t := range trees {
+			if err := t.WriteDOT(w); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return errors.Errorf("unknown format %s", _treeFormat)
+	}
+}
+
+func init() {
+	treeCmd.Flags().Uint32VarP(
+		&_treeMsgType,
+		"type",
+		"t",
+		iproto.MsgBlockProtoMsgType,
+		"type of the messages, or 0 for all the types",
+	)
+	treeCmd.Flags().StringVarP(&_treeFormat, "format", "f", formatJSON, "output format, json or dot")
+	rootCmd.AddCommand(treeCmd)
+}
@@ -0,0 +1,15 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// This is a debugging tool to analyze the message traces collected from the nodes of a local cluster
+// To use, set network.tracePath of each node, run "make build" and " ./bin/msgtrace"
+package main
+
+import "github.com/iotexproject/iotex-core/tools/msgtrace/internal/cmd"
+
+func main() {
+	cmd.Execute()
+}
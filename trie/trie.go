@@ -89,6 +89,19 @@ func NewTrieSharedDB(kvStore db.CachedKVStore, name string, root hash.Hash32B) (
 	return newTrieSharedDB(kvStore, name, root), nil
 }
 
+// Iterate calls fn on every <key, value> entry in the trie of the given root, which is stored in the bucket of the KV
+// store. The entries are visited in the order of their keys.
+func Iterate(kvStore db.KVStore, name string, root hash.Hash32B, fn func(key, value []byte) error) error {
+	if kvStore == nil {
+		return errors.New("Failed to create KV store for Trie")
+	}
+	if root == EmptyRoot {
+		return nil
+	}
+	t := newTrie(kvStore, name, root)
+	return t.iterate(root[:], nil, fn)
+}
+
 func (t *trie) Start(ctx context.Context) error {
 	t.lifecycle.OnStart(ctx)
 	return t.loadRoot()
@@ -523,6 +536,34 @@ func (t *trie) getValue(ptr patricia, index byte) ([]byte, error) {
 	return v, e
 }
 
+// iterate visits the entries under the patricia node of the given key, whose path from root is prefix
+func (t *trie) iterate(key []byte, prefix []byte, fn func(key, value []byte) error) error {
+	ptr, err := t.getPatricia(key)
+	if err != nil {
+		return err
+	}
+	switch node := ptr.(type) {
+	case *branch:
+		for i, child := range node.Path {
+			if len(child) == 0 {
+				continue
+			}
+			path := append(append([]byte{}, prefix...), byte(i))
+			if err := t.iterate(child, path, fn); err != nil {
+				return err
+			}
+		}
+	case *leaf:
+		path := append(append([]byte{}, prefix...), node.Path...)
+		if node.Ext == 1 {
+			// ext node stores the hash to next patricia node
+			return t.iterate(node.Value, path, fn)
+		}
+		return fn(path, node.Value)
+	}
+	return nil
+}
+
 // clear the stack
 func (t *trie) clear() {
 	for t.toRoot.Len() > 0 {
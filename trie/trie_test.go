@@ -454,6 +454,60 @@ func Test2kEntries(t *testing.T) {
 	require.Nil(tr.Stop(context.Background()))
 }
 
+func TestIterate(t *testing.T) {
+	require := require.New(t)
+
+	kv := db.NewMemKVStore()
+	tr, err := NewTrie(kv, "test", EmptyRoot)
+	require.Nil(err)
+	require.Nil(tr.Start(context.Background()))
+	count := 0
+	require.Nil(Iterate(kv, "test", tr.RootHash(), func(key, value []byte) error {
+		count++
+		return nil
+	}))
+	require.Equal(0, count)
+
+	// the keys sharing the prefixes are split into branches and extensions
+	expected := map[string][]byte{}
+	for i, k := range [][]byte{ham, car, cat, rat, egg, dog, fox, cow, ant} {
+		require.Nil(tr.Upsert(k, testV[i%8]))
+		expected[string(k)] = testV[i%8]
+	}
+	var k [32]byte
+	for i := 0; i < 1<<8; i++ {
+		k = blake2b.Sum256(k[:])
+		v := append([]byte{}, k[8:]...)
+		require.Nil(tr.Upsert(k[:8], v))
+		expected[string(k[:8])] = v
+	}
+	require.Nil(tr.Delete(cat))
+	delete(expected, string(cat))
+	require.Nil(tr.Commit())
+
+	entries := map[string][]byte{}
+	require.Nil(Iterate(kv, "test", tr.RootHash(), func(key, value []byte) error {
+		entries[string(key)] = value
+		return nil
+	}))
+	require.Equal(expected, entries)
+
+	// the trie rebuilt from the entries has the same root
+	tr2, err := NewTrie(db.NewMemKVStore(), "test", EmptyRoot)
+	require.Nil(err)
+	require.Nil(tr2.Start(context.Background()))
+	for key, value := range entries {
+		require.Nil(tr2.Upsert([]byte(key), value))
+	}
+	require.Equal(tr.RootHash(), tr2.RootHash())
+
+	// the error of fn stops the iteration
+	require.Error(Iterate(kv, "test", tr.RootHash(), func(key, value []byte) error {
+		return errors.New("stop")
+	}))
+	require.Nil(tr.Stop(context.Background()))
+}
+
 func TestPressure(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping TestPressure in short mode.")